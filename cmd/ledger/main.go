@@ -0,0 +1,225 @@
+// Command ledger prints the general ledger reports of the database in
+// app.env: the chart of accounts, the trial balance, the balance sheet or
+// the profit and loss statement.
+//
+//	go run ./cmd/ledger [-currency USD] [-json] accounts
+//	go run ./cmd/ledger [-currency USD] [-json] [-date 2024-10-31] trial-balance
+//	go run ./cmd/ledger [-currency USD] [-json] [-date 2024-10-31] balance-sheet
+//	go run ./cmd/ledger [-currency USD] [-json] -from 2024-10-01 [-to 2024-10-31] profit-and-loss
+package main
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/NhutHuyDev/sgbank/internal/currency"
+	"github.com/NhutHuyDev/sgbank/internal/infra/db"
+	"github.com/NhutHuyDev/sgbank/internal/ledger"
+	"github.com/NhutHuyDev/sgbank/pkg/utils"
+	_ "github.com/lib/pq"
+)
+
+func main() {
+	if err := run(os.Args[1:], os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, "ledger:", err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string, out io.Writer) error {
+	flags := flag.NewFlagSet("ledger", flag.ContinueOnError)
+	today := time.Now().UTC().Format(ledger.DateLayout)
+	date := flags.String("date", today, "date of the trial balance or balance sheet (UTC)")
+	from := flags.String("from", "", "first date of the profit and loss statement (UTC)")
+	to := flags.String("to", today, "last date of the profit and loss statement (UTC)")
+	currencyCode := flags.String("currency", "", "only report this currency")
+	asJSON := flags.Bool("json", false, "print JSON instead of tables")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "usage: ledger [flags] accounts|trial-balance|balance-sheet|profit-and-loss")
+		flags.PrintDefaults()
+	}
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		flags.Usage()
+		return fmt.Errorf("expected one report")
+	}
+
+	report := flags.Arg(0)
+	switch report {
+	case "accounts", "trial-balance", "balance-sheet", "profit-and-loss":
+	default:
+		flags.Usage()
+		return fmt.Errorf("unknown report %q", report)
+	}
+
+	config, err := utils.LoadConfig(".", "app")
+	if err != nil {
+		return fmt.Errorf("cannot load config: %w", err)
+	}
+
+	conn, err := sql.Open(config.DBDriver, config.DBSource)
+	if err != nil {
+		return fmt.Errorf("cannot connect to db: %w", err)
+	}
+	defer conn.Close()
+
+	ctx := context.Background()
+	store := db.NewStore(conn)
+	service := ledger.NewService(store)
+
+	currencies := currency.NewService(store, currency.NewRegistry())
+	if err := currencies.Refresh(ctx); err != nil {
+		return fmt.Errorf("cannot load currencies: %w", err)
+	}
+	printer := printer{out: out, registry: currencies.Registry(), json: *asJSON}
+
+	switch report {
+	case "accounts":
+		accounts, err := service.Chart(ctx)
+		if err != nil {
+			return err
+		}
+		return printer.accounts(accounts)
+	case "trial-balance", "balance-sheet":
+		at, err := time.Parse(ledger.DateLayout, *date)
+		if err != nil {
+			return fmt.Errorf("invalid -date: %w", err)
+		}
+
+		if report == "trial-balance" {
+			reports, err := service.TrialBalances(ctx, at, *currencyCode)
+			if err != nil {
+				return err
+			}
+			return printer.trialBalances(reports)
+		}
+
+		reports, err := service.BalanceSheets(ctx, at, *currencyCode)
+		if err != nil {
+			return err
+		}
+		return printer.balanceSheets(reports)
+	case "profit-and-loss":
+		start, err := time.Parse(ledger.DateLayout, *from)
+		if err != nil {
+			return fmt.Errorf("invalid -from: %w", err)
+		}
+		end, err := time.Parse(ledger.DateLayout, *to)
+		if err != nil {
+			return fmt.Errorf("invalid -to: %w", err)
+		}
+
+		reports, err := service.ProfitAndLoss(ctx, start, end, *currencyCode)
+		if err != nil {
+			return err
+		}
+		return printer.profitAndLoss(reports)
+	}
+
+	return nil
+}
+
+// printer writes reports as tables with amounts in major units, or as the
+// JSON the admin API returns.
+type printer struct {
+	out      io.Writer
+	registry *currency.Registry
+	json     bool
+}
+
+func (p printer) encode(value any) error {
+	encoder := json.NewEncoder(p.out)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(value)
+}
+
+func (p printer) table(write func(w io.Writer)) error {
+	w := tabwriter.NewWriter(p.out, 0, 0, 2, ' ', 0)
+	write(w)
+	return w.Flush()
+}
+
+func (p printer) accounts(accounts []db.GlAccount) error {
+	if p.json {
+		return p.encode(accounts)
+	}
+
+	return p.table(func(w io.Writer) {
+		for _, account := range accounts {
+			fmt.Fprintf(w, "%s\t%s\t%s\t\n", account.Code, account.Name, account.Type)
+		}
+	})
+}
+
+func (p printer) trialBalances(reports []ledger.TrialBalance) error {
+	if p.json {
+		return p.encode(reports)
+	}
+
+	return p.table(func(w io.Writer) {
+		for _, report := range reports {
+			fmt.Fprintf(w, "%s\t\tDebit\tCredit\t\n", report.Currency)
+			for _, line := range report.Lines {
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\t\n", line.Code, line.Name,
+					p.amount(line.Debit, report.Currency), p.amount(line.Credit, report.Currency))
+			}
+			fmt.Fprintf(w, "\tTotal\t%s\t%s\t\n\t\t\t\t\n",
+				p.amount(report.TotalDebit, report.Currency), p.amount(report.TotalCredit, report.Currency))
+		}
+	})
+}
+
+func (p printer) balanceSheets(reports []ledger.BalanceSheet) error {
+	if p.json {
+		return p.encode(reports)
+	}
+
+	return p.table(func(w io.Writer) {
+		for _, report := range reports {
+			fmt.Fprintf(w, "%s\t\t\t\n", report.Currency)
+			p.section(w, "Assets", report.Assets, report.Currency)
+			p.section(w, "Liabilities", report.Liabilities, report.Currency)
+			p.section(w, "Equity", report.Equity, report.Currency)
+		}
+	})
+}
+
+func (p printer) profitAndLoss(reports []ledger.ProfitAndLoss) error {
+	if p.json {
+		return p.encode(reports)
+	}
+
+	return p.table(func(w io.Writer) {
+		for _, report := range reports {
+			fmt.Fprintf(w, "%s\t\t\t\n", report.Currency)
+			p.section(w, "Revenue", report.Revenue, report.Currency)
+			p.section(w, "Expenses", report.Expenses, report.Currency)
+			fmt.Fprintf(w, "\tNet income\t%s\t\n\t\t\t\n", p.amount(report.NetIncome, report.Currency))
+		}
+	})
+}
+
+func (p printer) section(w io.Writer, title string, section ledger.Section, currencyCode string) {
+	fmt.Fprintf(w, "\t%s\t\t\n", title)
+	for _, item := range section.Items {
+		fmt.Fprintf(w, "%s\t%s\t%s\t\n", item.Code, item.Name, p.amount(item.Amount, currencyCode))
+	}
+	fmt.Fprintf(w, "\tTotal %s\t%s\t\n", title, p.amount(section.Total, currencyCode))
+}
+
+func (p printer) amount(minor int64, currencyCode string) string {
+	if formatted := p.registry.Format(minor, currencyCode); formatted != "" {
+		return formatted
+	}
+	return fmt.Sprint(minor)
+}
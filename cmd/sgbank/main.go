@@ -3,16 +3,22 @@ package main
 import (
 	"context"
 	"database/sql"
-	"net"
-	"net/http"
 	"os"
-
-	"github.com/NhutHuyDev/sgbank/internal/gapi"
+	"os/signal"
+	"syscall"
+
+	"github.com/NhutHuyDev/sgbank/internal/app"
+	"github.com/NhutHuyDev/sgbank/internal/currency"
+	"github.com/NhutHuyDev/sgbank/internal/dispute"
+	"github.com/NhutHuyDev/sgbank/internal/eod"
+	"github.com/NhutHuyDev/sgbank/internal/escrow"
 	"github.com/NhutHuyDev/sgbank/internal/infra/db"
-	"github.com/NhutHuyDev/sgbank/internal/rest"
-	"github.com/NhutHuyDev/sgbank/pb"
+	"github.com/NhutHuyDev/sgbank/internal/metrics"
+	"github.com/NhutHuyDev/sgbank/internal/overdraft"
+	"github.com/NhutHuyDev/sgbank/internal/screening"
+	"github.com/NhutHuyDev/sgbank/internal/tracing"
+	"github.com/NhutHuyDev/sgbank/internal/transferbatch"
 	"github.com/NhutHuyDev/sgbank/pkg/utils"
-	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	_ "github.com/lib/pq"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
@@ -48,89 +54,79 @@ func main() {
 
 	config, err := utils.LoadConfig(".", "app")
 	if err != nil {
-		log.Fatal().Msgf("cannot load config:", err)
+		log.Fatal().Err(err).Msg("cannot load config")
 	}
 
 	conn, err := sql.Open(config.DBDriver, config.DBSource)
 	if err != nil {
-		log.Fatal().Msgf("cannot connect to db: ", err)
+		log.Fatal().Err(err).Msg("cannot connect to db")
 	}
 
 	RunDbMigration(config.MigrationUrl, config.DBSource)
 
-	store := db.NewStore(conn)
-
-	// go RunGateWayServer(config, store)
-	go RunHttpServer(config, store)
+	if err := metrics.RegisterDBStats(conn); err != nil {
+		log.Fatal().Err(err).Msg("cannot register db metrics")
+	}
 
-	RunGrpcServer(config, store)
-}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
 
-func RunDbMigration(migrationURL string, dbSource string) {
-	migration, err := migrate.New(migrationURL, dbSource)
+	shutdownTracing, err := tracing.Init(ctx, config)
 	if err != nil {
-		log.Fatal().Msgf("cannot create new migrate instance: ", err)
-	}
-
-	if err = migration.Up(); err != nil && err != migrate.ErrNoChange {
-		log.Fatal().Msgf("faild to run migrate up: ", err)
+		log.Fatal().Err(err).Msg("cannot init tracing")
 	}
 
-	log.Info().Msg("db migrated successfully")
-}
+	store := tracing.NewStore(metrics.NewStore(db.NewStore(conn)))
 
-func RunHttpServer(config utils.Config, store db.Store) {
-	server, err := rest.NewServer(config, store)
+	runtime, err := app.New(config, store)
 	if err != nil {
-		log.Fatal().Msgf(err.Error())
+		log.Fatal().Err(err).Msg("cannot create services")
 	}
+	set := runtime.Services
 
-	err = server.Start(config.HTTPServerAddress)
-	if err != nil {
-		log.Fatal().Msgf("cannot start HTTP server: ", err)
+	if err := set.Currencies.Refresh(ctx); err != nil {
+		log.Fatal().Err(err).Msg("cannot load currencies")
 	}
-}
 
-func RunGrpcServer(config utils.Config, store db.Store) {
-	server, err := gapi.NewServer(config, store)
-	if err != nil {
-		log.Fatal().Msg(err.Error())
+	if _, err := set.Screening.Reload(); err != nil {
+		log.Fatal().Err(err).Msg("cannot load sanctions lists")
 	}
 
-	err = server.Start(config.GRPCServerAddress)
-	if err != nil {
-		log.Fatal().Msgf("cannot start gRPC server: ", err)
-	}
-}
+	runtime.AddWorker("currencies", currency.NewWorker(set.Currencies, config.CurrencyRefreshInterval))
+	runtime.AddWorker("screening-lists", screening.NewWorker(set.Screening, config.ScreeningReloadInterval))
+	runtime.AddWorker("transfer-batches", transferbatch.NewWorker(set.TransferBatches, config.TransferBatchPollInterval))
+	runtime.AddWorker("end-of-day", eod.NewWorker(set.EndOfDay, config.EODRunInterval))
+	runtime.AddWorker("overdraft-notifications", overdraft.NewWorker(set.Overdrafts, config.OverdraftNotifyInterval))
+	runtime.AddWorker("dispute-sla", dispute.NewWorker(set.Disputes, config.DisputeCheckInterval))
+	runtime.AddWorker("escrow-release", escrow.NewWorker(set.Escrows, config.EscrowReleaseInterval))
 
-func RunGateWayServer(config utils.Config, store db.Store) {
-	server, err := gapi.NewServer(config, store)
+	err = runtime.Run(ctx)
 	if err != nil {
-		log.Fatal().Msg(err.Error())
+		log.Error().Err(err).Msg("server runtime failed")
 	}
 
-	grpcMux := runtime.NewServeMux()
+	if flushErr := shutdownTracing(context.Background()); flushErr != nil {
+		log.Error().Err(flushErr).Msg("cannot flush traces")
+	}
 
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
+	if closeErr := conn.Close(); closeErr != nil {
+		log.Error().Err(closeErr).Msg("cannot close db pool")
+	}
 
-	pb.RegisterSgbankHandlerServer(ctx, grpcMux, server)
 	if err != nil {
-		log.Fatal().Msgf("cannot register handler server", err)
+		os.Exit(1)
 	}
+}
 
-	mux := http.NewServeMux()
-	mux.Handle("/", grpcMux)
-
-	listener, err := net.Listen("tcp", config.HTTPServerAddress)
+func RunDbMigration(migrationURL string, dbSource string) {
+	migration, err := migrate.New(migrationURL, dbSource)
 	if err != nil {
-		log.Fatal().Msgf("cannot create listener", err)
+		log.Fatal().Err(err).Msg("cannot create new migrate instance")
 	}
 
-	log.Printf("start gRPC server at %s", listener.Addr().String())
-	handler := gapi.HttpLogger(mux)
-	err = http.Serve(listener, handler)
-	if err != nil {
-		log.Fatal().Msgf("cannot start HTTP gateway server", err)
+	if err = migration.Up(); err != nil && err != migrate.ErrNoChange {
+		log.Fatal().Err(err).Msg("faild to run migrate up")
 	}
+
+	log.Info().Msg("db migrated successfully")
 }
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS "recipient_lookups";
+
+ALTER TABLE "users" DROP COLUMN IF EXISTS "is_email_verified";
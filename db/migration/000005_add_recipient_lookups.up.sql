@@ -0,0 +1,19 @@
+ALTER TABLE "users" ADD COLUMN "is_email_verified" boolean NOT NULL DEFAULT false;
+
+CREATE TABLE "recipient_lookups" (
+  "id" bigserial PRIMARY KEY,
+  "username" varchar NOT NULL,
+  "identifier" varchar NOT NULL,
+  "purpose" varchar NOT NULL,
+  "found" boolean NOT NULL,
+  "client_ip" varchar NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX ON "recipient_lookups" ("username", "created_at");
+
+COMMENT ON COLUMN "recipient_lookups"."username" IS 'the user who looked the recipient up';
+
+COMMENT ON COLUMN "recipient_lookups"."purpose" IS 'lookup or transfer';
+
+ALTER TABLE "recipient_lookups" ADD FOREIGN KEY ("username") REFERENCES "users" ("username");
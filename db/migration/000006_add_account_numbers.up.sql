@@ -0,0 +1,15 @@
+ALTER TABLE "accounts" ADD COLUMN "account_number" varchar;
+
+-- Existing accounts get numbers under the default bank and branch code 0001.
+-- The check digits are 98 minus the MOD 97 of the BBAN followed by the
+-- country code expanded to digits (S=28, G=16) and "00".
+UPDATE "accounts" SET "account_number" = 'SG' || lpad((98 - ((bban || '281600')::numeric % 97))::text, 2, '0') || bban
+FROM (
+  SELECT "id" AS "account_id", '00010001' || lpad((floor(random() * 1e12))::bigint::text, 12, '0') AS bban
+  FROM "accounts"
+) AS "numbers"
+WHERE "accounts"."id" = "numbers"."account_id";
+
+ALTER TABLE "accounts" ALTER COLUMN "account_number" SET NOT NULL;
+
+CREATE UNIQUE INDEX ON "accounts" ("account_number");
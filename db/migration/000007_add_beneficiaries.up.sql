@@ -0,0 +1,15 @@
+CREATE TABLE "beneficiaries" (
+  "id" bigserial PRIMARY KEY,
+  "owner" varchar NOT NULL,
+  "account_number" varchar NOT NULL,
+  "nickname" varchar NOT NULL,
+  "currency" varchar NOT NULL,
+  "is_verified" boolean NOT NULL DEFAULT false,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE UNIQUE INDEX ON "beneficiaries" ("owner", "account_number");
+
+COMMENT ON COLUMN "beneficiaries"."is_verified" IS 'the account number resolved to an account in currency';
+
+ALTER TABLE "beneficiaries" ADD FOREIGN KEY ("owner") REFERENCES "users" ("username");
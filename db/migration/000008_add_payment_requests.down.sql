@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS "payment_requests";
+
+DROP TABLE IF EXISTS "notifications";
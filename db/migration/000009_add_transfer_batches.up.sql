@@ -0,0 +1,56 @@
+CREATE TABLE "transfer_batches" (
+  "id" bigserial PRIMARY KEY,
+  "owner" varchar NOT NULL,
+  "from_account_id" bigint NOT NULL,
+  "currency" varchar NOT NULL,
+  "mode" varchar NOT NULL,
+  "status" varchar NOT NULL DEFAULT 'pending',
+  "total_rows" int NOT NULL,
+  "total_amount" bigint NOT NULL,
+  "processed_rows" int NOT NULL DEFAULT 0,
+  "succeeded_rows" int NOT NULL DEFAULT 0,
+  "failed_rows" int NOT NULL DEFAULT 0,
+  "error" varchar NOT NULL DEFAULT '',
+  "completed_at" timestamptz,
+  "updated_at" timestamptz NOT NULL DEFAULT (now()),
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "transfer_batch_items" (
+  "id" bigserial PRIMARY KEY,
+  "batch_id" bigint NOT NULL,
+  "row_number" int NOT NULL,
+  "to_account_id" bigint NOT NULL,
+  "amount" bigint NOT NULL,
+  "reference" varchar NOT NULL DEFAULT '',
+  "status" varchar NOT NULL DEFAULT 'pending',
+  "error" varchar NOT NULL DEFAULT '',
+  "transfer_id" bigint,
+  "updated_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX ON "transfer_batches" ("owner", "id");
+
+CREATE INDEX ON "transfer_batches" ("status", "id") WHERE "status" IN ('pending', 'processing');
+
+CREATE UNIQUE INDEX ON "transfer_batch_items" ("batch_id", "row_number");
+
+COMMENT ON COLUMN "transfer_batches"."mode" IS 'all_or_nothing or best_effort';
+
+COMMENT ON COLUMN "transfer_batches"."status" IS 'pending, processing, completed or failed';
+
+COMMENT ON COLUMN "transfer_batch_items"."row_number" IS 'position of the row in the uploaded file, from 1';
+
+COMMENT ON COLUMN "transfer_batch_items"."status" IS 'pending, succeeded or failed';
+
+ALTER TABLE "transfer_batch_items" ADD CONSTRAINT "transfer_batch_items_amount_check" CHECK ("amount" > 0);
+
+ALTER TABLE "transfer_batches" ADD FOREIGN KEY ("owner") REFERENCES "users" ("username");
+
+ALTER TABLE "transfer_batches" ADD FOREIGN KEY ("from_account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "transfer_batch_items" ADD FOREIGN KEY ("batch_id") REFERENCES "transfer_batches" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "transfer_batch_items" ADD FOREIGN KEY ("to_account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "transfer_batch_items" ADD FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
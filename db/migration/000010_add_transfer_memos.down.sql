@@ -0,0 +1,15 @@
+DROP TABLE IF EXISTS "categorization_rules";
+
+ALTER TABLE "entries" DROP COLUMN IF EXISTS "category";
+
+ALTER TABLE "entries" DROP COLUMN IF EXISTS "merchant_category";
+
+ALTER TABLE "entries" DROP COLUMN IF EXISTS "external_reference";
+
+ALTER TABLE "entries" DROP COLUMN IF EXISTS "description";
+
+ALTER TABLE "transfers" DROP COLUMN IF EXISTS "merchant_category";
+
+ALTER TABLE "transfers" DROP COLUMN IF EXISTS "external_reference";
+
+ALTER TABLE "transfers" DROP COLUMN IF EXISTS "description";
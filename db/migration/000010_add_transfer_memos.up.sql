@@ -0,0 +1,38 @@
+ALTER TABLE "transfers" ADD COLUMN "description" varchar NOT NULL DEFAULT '';
+
+ALTER TABLE "transfers" ADD COLUMN "external_reference" varchar NOT NULL DEFAULT '';
+
+ALTER TABLE "transfers" ADD COLUMN "merchant_category" varchar NOT NULL DEFAULT '';
+
+ALTER TABLE "entries" ADD COLUMN "description" varchar NOT NULL DEFAULT '';
+
+ALTER TABLE "entries" ADD COLUMN "external_reference" varchar NOT NULL DEFAULT '';
+
+ALTER TABLE "entries" ADD COLUMN "merchant_category" varchar NOT NULL DEFAULT '';
+
+ALTER TABLE "entries" ADD COLUMN "category" varchar NOT NULL DEFAULT '';
+
+CREATE TABLE "categorization_rules" (
+  "id" bigserial PRIMARY KEY,
+  "owner" varchar NOT NULL,
+  "category" varchar NOT NULL,
+  "description_contains" varchar NOT NULL DEFAULT '',
+  "merchant_category" varchar NOT NULL DEFAULT '',
+  "counterparty_account_id" bigint,
+  "priority" int NOT NULL DEFAULT 0,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX ON "transfers" USING GIN (to_tsvector('simple', "description" || ' ' || "external_reference"));
+
+CREATE INDEX ON "entries" USING GIN (to_tsvector('simple', "description" || ' ' || "external_reference"));
+
+CREATE INDEX ON "categorization_rules" ("owner", "id");
+
+COMMENT ON COLUMN "transfers"."merchant_category" IS 'ISO 18245 merchant category code';
+
+COMMENT ON COLUMN "entries"."category" IS 'set from the account owner''s categorization rules';
+
+ALTER TABLE "categorization_rules" ADD FOREIGN KEY ("owner") REFERENCES "users" ("username");
+
+ALTER TABLE "categorization_rules" ADD FOREIGN KEY ("counterparty_account_id") REFERENCES "accounts" ("id");
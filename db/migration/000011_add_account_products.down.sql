@@ -0,0 +1,14 @@
+DROP TABLE IF EXISTS "interest_accruals";
+
+DROP TABLE IF EXISTS "interest_capitalizations";
+
+ALTER TABLE "accounts" DROP CONSTRAINT IF EXISTS "owner_currency_product_key";
+
+ALTER TABLE "accounts" ADD CONSTRAINT "owner_currency_key" UNIQUE ("owner", "currency");
+
+ALTER TABLE "accounts" DROP COLUMN IF EXISTS "product";
+
+DELETE FROM "users" WHERE "username" = 'sgbank-system'
+  AND NOT EXISTS (SELECT 1 FROM "accounts" WHERE "owner" = 'sgbank-system');
+
+DROP TABLE IF EXISTS "account_products";
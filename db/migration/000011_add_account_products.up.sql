@@ -0,0 +1,77 @@
+CREATE TABLE "account_products" (
+  "code" varchar PRIMARY KEY,
+  "name" varchar NOT NULL,
+  "annual_rate_ppm" bigint NOT NULL DEFAULT 0 CHECK ("annual_rate_ppm" >= 0),
+  "is_internal" boolean NOT NULL DEFAULT false,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+INSERT INTO "account_products" ("code", "name", "annual_rate_ppm", "is_internal") VALUES
+  ('checking', 'Checking', 0, false),
+  ('savings', 'Savings', 20000, false),
+  ('interest_expense', 'Interest expense', 0, true);
+
+ALTER TABLE "accounts" ADD COLUMN "product" varchar NOT NULL DEFAULT 'checking';
+
+ALTER TABLE "accounts" DROP CONSTRAINT "owner_currency_key";
+
+ALTER TABLE "accounts" ADD CONSTRAINT "owner_currency_product_key" UNIQUE ("owner", "currency", "product");
+
+-- The bank's own accounts, such as the interest expense account of each
+-- currency, belong to this user. Its name is not alphanumeric, so nobody can
+-- register it, and its empty password hash never matches.
+INSERT INTO "users" ("username", "hashed_password", "full_name", "email") VALUES
+  ('sgbank-system', '', 'SG Bank', 'system@sgbank.invalid');
+
+CREATE TABLE "interest_accruals" (
+  "id" bigserial PRIMARY KEY,
+  "account_id" bigint NOT NULL,
+  "business_date" date NOT NULL,
+  "closing_balance" bigint NOT NULL,
+  "annual_rate_ppm" bigint NOT NULL,
+  "day_count" int NOT NULL,
+  "amount_micros" bigint NOT NULL,
+  "capitalization_id" bigint,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "interest_capitalizations" (
+  "id" bigserial PRIMARY KEY,
+  "account_id" bigint NOT NULL,
+  "period" date NOT NULL,
+  "accrued_micros" bigint NOT NULL,
+  "amount" bigint NOT NULL,
+  "carry_micros" bigint NOT NULL,
+  "transfer_id" bigint,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE UNIQUE INDEX ON "interest_accruals" ("account_id", "business_date");
+
+CREATE INDEX ON "interest_accruals" ("business_date");
+
+CREATE INDEX ON "interest_accruals" ("account_id") WHERE "capitalization_id" IS NULL;
+
+CREATE UNIQUE INDEX ON "interest_capitalizations" ("account_id", "period");
+
+COMMENT ON COLUMN "account_products"."annual_rate_ppm" IS 'nominal annual rate in parts per million, e.g. 20000 for 2%';
+
+COMMENT ON COLUMN "interest_accruals"."closing_balance" IS 'balance at the end of the business date (UTC)';
+
+COMMENT ON COLUMN "interest_accruals"."amount_micros" IS 'interest in millionths of a minor unit';
+
+COMMENT ON COLUMN "interest_capitalizations"."period" IS 'first day of the month the interest was accrued in';
+
+COMMENT ON COLUMN "interest_capitalizations"."accrued_micros" IS 'accruals of the period plus the carry of the previous capitalization';
+
+COMMENT ON COLUMN "interest_capitalizations"."carry_micros" IS 'fraction of a minor unit carried to the next capitalization';
+
+ALTER TABLE "accounts" ADD FOREIGN KEY ("product") REFERENCES "account_products" ("code");
+
+ALTER TABLE "interest_accruals" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "interest_accruals" ADD FOREIGN KEY ("capitalization_id") REFERENCES "interest_capitalizations" ("id");
+
+ALTER TABLE "interest_capitalizations" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "interest_capitalizations" ADD FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
@@ -0,0 +1,12 @@
+ALTER TABLE "transfers" DROP COLUMN IF EXISTS "fee";
+
+DELETE FROM "account_products" WHERE "code" = 'fee_revenue'
+  AND NOT EXISTS (SELECT 1 FROM "accounts" WHERE "product" = 'fee_revenue');
+
+DROP TABLE IF EXISTS "fee_schedule_bands";
+
+DROP TABLE IF EXISTS "fee_schedules";
+
+ALTER TABLE "users" DROP COLUMN IF EXISTS "tier";
+
+DROP TABLE IF EXISTS "user_tiers";
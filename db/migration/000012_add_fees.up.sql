@@ -0,0 +1,48 @@
+CREATE TABLE "user_tiers" (
+  "code" varchar PRIMARY KEY,
+  "name" varchar NOT NULL,
+  "fees_waived" boolean NOT NULL DEFAULT false
+);
+
+INSERT INTO "user_tiers" ("code", "name", "fees_waived") VALUES
+  ('standard', 'Standard', false),
+  ('premium', 'Premium', true);
+
+ALTER TABLE "users" ADD COLUMN "tier" varchar NOT NULL DEFAULT 'standard';
+
+CREATE TABLE "fee_schedules" (
+  "id" bigserial PRIMARY KEY,
+  "currency" varchar UNIQUE NOT NULL,
+  "min_fee" bigint NOT NULL DEFAULT 0 CHECK ("min_fee" >= 0),
+  "max_fee" bigint CHECK ("max_fee" >= "min_fee"),
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "fee_schedule_bands" (
+  "id" bigserial PRIMARY KEY,
+  "schedule_id" bigint NOT NULL,
+  "up_to" bigint,
+  "flat_fee" bigint NOT NULL DEFAULT 0 CHECK ("flat_fee" >= 0),
+  "rate_ppm" bigint NOT NULL DEFAULT 0 CHECK ("rate_ppm" >= 0)
+);
+
+CREATE UNIQUE INDEX ON "fee_schedule_bands" ("schedule_id", "up_to");
+
+CREATE UNIQUE INDEX ON "fee_schedule_bands" ("schedule_id") WHERE "up_to" IS NULL;
+
+INSERT INTO "account_products" ("code", "name", "annual_rate_ppm", "is_internal") VALUES
+  ('fee_revenue', 'Fee revenue', 0, true);
+
+ALTER TABLE "transfers" ADD COLUMN "fee" bigint NOT NULL DEFAULT 0;
+
+COMMENT ON COLUMN "fee_schedules"."max_fee" IS 'no cap when null';
+
+COMMENT ON COLUMN "fee_schedule_bands"."up_to" IS 'largest transfer amount the band applies to, unbounded when null';
+
+COMMENT ON COLUMN "fee_schedule_bands"."rate_ppm" IS 'percentage of the amount in parts per million, e.g. 5000 for 0.5%';
+
+COMMENT ON COLUMN "transfers"."fee" IS 'paid by the sender to the fee revenue account on top of amount';
+
+ALTER TABLE "users" ADD FOREIGN KEY ("tier") REFERENCES "user_tiers" ("code");
+
+ALTER TABLE "fee_schedule_bands" ADD FOREIGN KEY ("schedule_id") REFERENCES "fee_schedules" ("id") ON DELETE CASCADE;
@@ -0,0 +1,14 @@
+DROP TABLE IF EXISTS "overdraft_events";
+
+DROP TABLE IF EXISTS "overdraft_approvals";
+
+ALTER TABLE "accounts" DROP COLUMN IF EXISTS "overdrawn_since";
+
+ALTER TABLE "accounts" DROP COLUMN IF EXISTS "overdraft_limit";
+
+DELETE FROM "account_products" WHERE "code" = 'interest_income'
+  AND NOT EXISTS (SELECT 1 FROM "accounts" WHERE "product" = 'interest_income');
+
+ALTER TABLE "account_products" DROP COLUMN IF EXISTS "overdraft_rate_ppm";
+
+ALTER TABLE "users" DROP COLUMN IF EXISTS "role";
@@ -0,0 +1,50 @@
+ALTER TABLE "users" ADD COLUMN "role" varchar NOT NULL DEFAULT 'depositor' CHECK ("role" IN ('depositor', 'admin'));
+
+ALTER TABLE "account_products" ADD COLUMN "overdraft_rate_ppm" bigint NOT NULL DEFAULT 0 CHECK ("overdraft_rate_ppm" >= 0);
+
+UPDATE "account_products" SET "overdraft_rate_ppm" = 180000 WHERE "code" = 'checking';
+
+INSERT INTO "account_products" ("code", "name", "annual_rate_ppm", "is_internal") VALUES
+  ('interest_income', 'Interest income', 0, true);
+
+ALTER TABLE "accounts" ADD COLUMN "overdraft_limit" bigint NOT NULL DEFAULT 0 CHECK ("overdraft_limit" >= 0);
+
+ALTER TABLE "accounts" ADD COLUMN "overdrawn_since" timestamptz;
+
+CREATE TABLE "overdraft_approvals" (
+  "id" bigserial PRIMARY KEY,
+  "account_id" bigint NOT NULL,
+  "overdraft_limit" bigint NOT NULL,
+  "approved_by" varchar NOT NULL,
+  "reason" varchar NOT NULL DEFAULT '',
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "overdraft_events" (
+  "id" bigserial PRIMARY KEY,
+  "account_id" bigint NOT NULL,
+  "kind" varchar NOT NULL CHECK ("kind" IN ('entered', 'left')),
+  "balance" bigint NOT NULL,
+  "notified_at" timestamptz,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX ON "accounts" ("id") WHERE "balance" < 0;
+
+CREATE INDEX ON "overdraft_approvals" ("account_id", "created_at");
+
+CREATE INDEX ON "overdraft_events" ("id") WHERE "notified_at" IS NULL;
+
+COMMENT ON COLUMN "account_products"."overdraft_rate_ppm" IS 'annual rate charged on overdrawn balances in parts per million';
+
+COMMENT ON COLUMN "accounts"."overdraft_limit" IS 'how far below zero the balance may go, approved by an admin';
+
+COMMENT ON COLUMN "accounts"."overdrawn_since" IS 'when the balance last went below zero within an overdraft limit, null when not overdrawn';
+
+COMMENT ON COLUMN "overdraft_events"."balance" IS 'balance right after the transfer that crossed zero';
+
+ALTER TABLE "overdraft_approvals" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "overdraft_approvals" ADD FOREIGN KEY ("approved_by") REFERENCES "users" ("username");
+
+ALTER TABLE "overdraft_events" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
@@ -0,0 +1,23 @@
+CREATE TABLE "currencies" (
+  "code" varchar(3) PRIMARY KEY CHECK ("code" ~ '^[A-Z]{3}$'),
+  "name" varchar NOT NULL,
+  "exponent" integer NOT NULL CHECK ("exponent" BETWEEN 0 AND 4),
+  "enabled" boolean NOT NULL DEFAULT false,
+  "updated_at" timestamptz NOT NULL DEFAULT (now()),
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+INSERT INTO "currencies" ("code", "name", "exponent", "enabled") VALUES
+  ('USD', 'US Dollar', 2, true),
+  ('EUR', 'Euro', 2, true),
+  ('CAD', 'Canadian Dollar', 2, true),
+  ('GBP', 'Pound Sterling', 2, false),
+  ('CHF', 'Swiss Franc', 2, false),
+  ('JPY', 'Yen', 0, false),
+  ('KRW', 'Won', 0, false),
+  ('KWD', 'Kuwaiti Dinar', 3, false),
+  ('BHD', 'Bahraini Dinar', 3, false);
+
+ALTER TABLE "accounts" ADD FOREIGN KEY ("currency") REFERENCES "currencies" ("code");
+
+ALTER TABLE "fee_schedules" ADD FOREIGN KEY ("currency") REFERENCES "currencies" ("code");
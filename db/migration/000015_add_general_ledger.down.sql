@@ -0,0 +1,8 @@
+ALTER TABLE "account_products" DROP COLUMN IF EXISTS "overdraft_gl_account";
+
+ALTER TABLE "account_products" DROP COLUMN IF EXISTS "gl_account";
+
+DELETE FROM "account_products" WHERE "code" IN ('cash', 'fx_position')
+  AND NOT EXISTS (SELECT 1 FROM "accounts" WHERE "product" = "account_products"."code");
+
+DROP TABLE IF EXISTS "gl_accounts";
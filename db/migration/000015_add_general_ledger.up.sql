@@ -0,0 +1,42 @@
+CREATE TABLE "gl_accounts" (
+  "code" varchar PRIMARY KEY,
+  "name" varchar NOT NULL,
+  "type" varchar NOT NULL CHECK ("type" IN ('asset', 'liability', 'equity', 'revenue', 'expense')),
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+INSERT INTO "gl_accounts" ("code", "name", "type") VALUES
+  ('1000', 'Cash', 'asset'),
+  ('1100', 'Customer overdrafts', 'asset'),
+  ('1500', 'FX position', 'asset'),
+  ('1900', 'Suspense', 'asset'),
+  ('2000', 'Customer deposits', 'liability'),
+  ('3000', 'Retained earnings', 'equity'),
+  ('4000', 'Fee revenue', 'revenue'),
+  ('4100', 'Interest income', 'revenue'),
+  ('5000', 'Interest expense', 'expense');
+
+INSERT INTO "account_products" ("code", "name", "annual_rate_ppm", "is_internal") VALUES
+  ('cash', 'Cash', 0, true),
+  ('fx_position', 'FX position', 0, true);
+
+ALTER TABLE "account_products" ADD COLUMN "gl_account" varchar REFERENCES "gl_accounts" ("code");
+
+ALTER TABLE "account_products" ADD COLUMN "overdraft_gl_account" varchar REFERENCES "gl_accounts" ("code");
+
+UPDATE "account_products" SET "gl_account" = CASE "code"
+  WHEN 'cash' THEN '1000'
+  WHEN 'fx_position' THEN '1500'
+  WHEN 'fee_revenue' THEN '4000'
+  WHEN 'interest_income' THEN '4100'
+  WHEN 'interest_expense' THEN '5000'
+  ELSE '2000'
+END;
+
+UPDATE "account_products" SET "overdraft_gl_account" = '1100' WHERE NOT "is_internal";
+
+ALTER TABLE "account_products" ALTER COLUMN "gl_account" SET NOT NULL;
+
+COMMENT ON COLUMN "account_products"."gl_account" IS 'general ledger account the entries of accounts of the product are posted to';
+
+COMMENT ON COLUMN "account_products"."overdraft_gl_account" IS 'general ledger account overdrawn balances are reported in, gl_account when null';
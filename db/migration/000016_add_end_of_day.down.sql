@@ -0,0 +1,9 @@
+DROP TABLE IF EXISTS "daily_balances";
+
+DROP TABLE IF EXISTS "eod_steps";
+
+ALTER TABLE "entries" DROP COLUMN IF EXISTS "business_date";
+
+ALTER TABLE "transfers" DROP COLUMN IF EXISTS "business_date";
+
+DROP TABLE IF EXISTS "business_days";
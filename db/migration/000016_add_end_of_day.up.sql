@@ -0,0 +1,65 @@
+CREATE TABLE "business_days" (
+  "business_date" date PRIMARY KEY,
+  "status" varchar NOT NULL DEFAULT 'open' CHECK ("status" IN ('open', 'closing', 'closed')),
+  "opened_at" timestamptz NOT NULL DEFAULT (now()),
+  "closing_at" timestamptz,
+  "closed_at" timestamptz
+);
+
+CREATE TABLE "eod_steps" (
+  "business_date" date NOT NULL,
+  "name" varchar NOT NULL,
+  "position" int NOT NULL,
+  "status" varchar NOT NULL DEFAULT 'pending' CHECK ("status" IN ('pending', 'running', 'succeeded', 'failed')),
+  "attempts" int NOT NULL DEFAULT 0,
+  "error" varchar NOT NULL DEFAULT '',
+  "started_at" timestamptz,
+  "finished_at" timestamptz,
+  PRIMARY KEY ("business_date", "name")
+);
+
+CREATE TABLE "daily_balances" (
+  "account_id" bigint NOT NULL,
+  "business_date" date NOT NULL,
+  "balance" bigint NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  PRIMARY KEY ("account_id", "business_date")
+);
+
+INSERT INTO "business_days" ("business_date") VALUES ((now() AT TIME ZONE 'UTC')::date);
+
+ALTER TABLE "transfers" ADD COLUMN "business_date" date;
+
+ALTER TABLE "entries" ADD COLUMN "business_date" date;
+
+UPDATE "transfers" SET "business_date" = ("created_at" AT TIME ZONE 'UTC')::date;
+
+UPDATE "entries" SET "business_date" = ("created_at" AT TIME ZONE 'UTC')::date;
+
+ALTER TABLE "transfers" ALTER COLUMN "business_date" SET NOT NULL;
+
+ALTER TABLE "entries" ALTER COLUMN "business_date" SET NOT NULL;
+
+CREATE UNIQUE INDEX ON "business_days" ("status") WHERE "status" = 'open';
+
+CREATE INDEX ON "eod_steps" ("business_date", "position");
+
+CREATE INDEX ON "daily_balances" ("business_date");
+
+CREATE INDEX ON "entries" ("account_id", "business_date");
+
+COMMENT ON COLUMN "business_days"."status" IS 'postings go to the one open day; a closing day runs its end-of-day steps';
+
+COMMENT ON COLUMN "business_days"."closing_at" IS 'when the end of day started; accounts opened later are not in its daily balances';
+
+COMMENT ON COLUMN "eod_steps"."position" IS 'steps run in ascending position, each once it succeeded';
+
+COMMENT ON COLUMN "daily_balances"."balance" IS 'balance at the end of the business date';
+
+COMMENT ON COLUMN "entries"."business_date" IS 'business day the entry was posted to';
+
+ALTER TABLE "eod_steps" ADD FOREIGN KEY ("business_date") REFERENCES "business_days" ("business_date");
+
+ALTER TABLE "daily_balances" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "daily_balances" ADD FOREIGN KEY ("business_date") REFERENCES "business_days" ("business_date");
@@ -0,0 +1,39 @@
+CREATE TABLE "fraud_decisions" (
+  "id" bigserial PRIMARY KEY,
+  "username" varchar NOT NULL,
+  "from_account_id" bigint NOT NULL,
+  "to_account_id" bigint NOT NULL,
+  "amount" bigint NOT NULL,
+  "currency" varchar NOT NULL,
+  "client_ip" varchar NOT NULL DEFAULT '',
+  "score" integer NOT NULL,
+  "outcome" varchar NOT NULL CHECK ("outcome" IN ('allow', 'hold', 'block')),
+  "hits" jsonb NOT NULL DEFAULT '[]',
+  "transfer" jsonb NOT NULL,
+  "status" varchar NOT NULL CHECK ("status" IN ('allowed', 'blocked', 'pending', 'approved', 'rejected')),
+  "transfer_id" bigint,
+  "reviewed_by" varchar,
+  "review_note" varchar NOT NULL DEFAULT '',
+  "reviewed_at" timestamptz,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX ON "fraud_decisions" ("id") WHERE "status" = 'pending';
+
+CREATE INDEX ON "fraud_decisions" ("username", "created_at");
+
+COMMENT ON COLUMN "fraud_decisions"."hits" IS 'rules that matched, each with its score and details';
+
+COMMENT ON COLUMN "fraud_decisions"."transfer" IS 'transfer parameters, posted when a held transfer is approved';
+
+COMMENT ON COLUMN "fraud_decisions"."status" IS 'allowed or blocked, or pending review until approved or rejected';
+
+ALTER TABLE "fraud_decisions" ADD FOREIGN KEY ("username") REFERENCES "users" ("username");
+
+ALTER TABLE "fraud_decisions" ADD FOREIGN KEY ("from_account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "fraud_decisions" ADD FOREIGN KEY ("to_account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "fraud_decisions" ADD FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
+
+ALTER TABLE "fraud_decisions" ADD FOREIGN KEY ("reviewed_by") REFERENCES "users" ("username");
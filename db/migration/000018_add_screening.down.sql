@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS "screening_whitelist";
+DROP TABLE IF EXISTS "screening_matches";
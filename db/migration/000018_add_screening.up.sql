@@ -0,0 +1,38 @@
+CREATE TABLE "screening_matches" (
+  "id" bigserial PRIMARY KEY,
+  "subject" varchar NOT NULL CHECK ("subject" IN ('signup', 'counterparty')),
+  "name" varchar NOT NULL,
+  "username" varchar NOT NULL,
+  "account_id" bigint,
+  "list" varchar NOT NULL,
+  "entry_id" varchar NOT NULL,
+  "entry_name" varchar NOT NULL,
+  "score" double precision NOT NULL,
+  "action" varchar NOT NULL CHECK ("action" IN ('flag', 'block')),
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "screening_whitelist" (
+  "id" bigserial PRIMARY KEY,
+  "name" varchar NOT NULL,
+  "entry_id" varchar NOT NULL,
+  "reason" varchar NOT NULL DEFAULT '',
+  "created_by" varchar NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX ON "screening_matches" ("username");
+
+CREATE UNIQUE INDEX ON "screening_whitelist" ("name", "entry_id");
+
+COMMENT ON COLUMN "screening_matches"."name" IS 'name screened: the full name of a user signing up, or of the owner of the account a transfer is sent to';
+
+COMMENT ON COLUMN "screening_matches"."username" IS 'user signing up or sending the transfer; a blocked signup has no user';
+
+COMMENT ON COLUMN "screening_matches"."account_id" IS 'account a transfer is sent to';
+
+COMMENT ON COLUMN "screening_whitelist"."name" IS 'normalized name, words sorted';
+
+ALTER TABLE "screening_matches" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "screening_whitelist" ADD FOREIGN KEY ("created_by") REFERENCES "users" ("username");
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS "kyc_documents";
+DROP TABLE IF EXISTS "kyc_submissions";
+ALTER TABLE "users" DROP COLUMN IF EXISTS "kyc_level";
+DROP TABLE IF EXISTS "kyc_levels";
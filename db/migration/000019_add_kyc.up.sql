@@ -0,0 +1,64 @@
+CREATE TABLE "kyc_levels" (
+  "level" integer PRIMARY KEY,
+  "name" varchar NOT NULL,
+  "max_accounts" integer,
+  "max_transfer_amount" bigint,
+  "daily_transfer_limit" bigint
+);
+
+CREATE TABLE "kyc_submissions" (
+  "id" bigserial PRIMARY KEY,
+  "username" varchar NOT NULL,
+  "level" integer NOT NULL,
+  "status" varchar NOT NULL DEFAULT 'pending' CHECK ("status" IN ('pending', 'approved', 'rejected')),
+  "reason" varchar NOT NULL DEFAULT '',
+  "reviewed_by" varchar,
+  "reviewed_at" timestamptz,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "kyc_documents" (
+  "id" bigserial PRIMARY KEY,
+  "submission_id" bigint NOT NULL,
+  "kind" varchar NOT NULL CHECK ("kind" IN ('id_document', 'proof_of_address')),
+  "file_name" varchar NOT NULL,
+  "content_type" varchar NOT NULL,
+  "size" bigint NOT NULL,
+  "sha256" varchar NOT NULL,
+  "storage_key" varchar NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+INSERT INTO "kyc_levels" ("level", "name", "max_accounts", "max_transfer_amount", "daily_transfer_limit") VALUES
+  (0, 'Unverified', 1, 10000, 20000),
+  (1, 'Identity verified', 3, 100000, 500000),
+  (2, 'Fully verified', NULL, NULL, NULL);
+
+ALTER TABLE "users" ADD COLUMN "kyc_level" integer NOT NULL DEFAULT 0;
+
+-- users who signed up before verification existed keep the abilities they had
+UPDATE "users" SET "kyc_level" = 2;
+
+CREATE UNIQUE INDEX ON "kyc_submissions" ("username") WHERE "status" = 'pending';
+
+CREATE INDEX ON "kyc_documents" ("submission_id");
+
+COMMENT ON COLUMN "kyc_levels"."max_accounts" IS 'no limit when null';
+
+COMMENT ON COLUMN "kyc_levels"."max_transfer_amount" IS 'in minor units of the currency of the transfer; no limit when null';
+
+COMMENT ON COLUMN "kyc_levels"."daily_transfer_limit" IS 'total sent since midnight UTC, in minor units of any currency; no limit when null';
+
+COMMENT ON COLUMN "kyc_submissions"."level" IS 'level requested';
+
+COMMENT ON COLUMN "kyc_documents"."storage_key" IS 'key of the file in the document store';
+
+ALTER TABLE "users" ADD FOREIGN KEY ("kyc_level") REFERENCES "kyc_levels" ("level");
+
+ALTER TABLE "kyc_submissions" ADD FOREIGN KEY ("username") REFERENCES "users" ("username");
+
+ALTER TABLE "kyc_submissions" ADD FOREIGN KEY ("level") REFERENCES "kyc_levels" ("level");
+
+ALTER TABLE "kyc_submissions" ADD FOREIGN KEY ("reviewed_by") REFERENCES "users" ("username");
+
+ALTER TABLE "kyc_documents" ADD FOREIGN KEY ("submission_id") REFERENCES "kyc_submissions" ("id");
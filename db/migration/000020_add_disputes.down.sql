@@ -0,0 +1,9 @@
+DROP TABLE IF EXISTS "dispute_events";
+DROP TABLE IF EXISTS "dispute_evidence";
+DROP TABLE IF EXISTS "disputes";
+
+DELETE FROM "account_products" WHERE "code" = 'dispute_credits'
+  AND NOT EXISTS (SELECT 1 FROM "accounts" WHERE "product" = "account_products"."code");
+
+DELETE FROM "gl_accounts" WHERE "code" = '1200'
+  AND NOT EXISTS (SELECT 1 FROM "account_products" WHERE "gl_account" = '1200');
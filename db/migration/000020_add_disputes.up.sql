@@ -0,0 +1,96 @@
+INSERT INTO "gl_accounts" ("code", "name", "type") VALUES
+  ('1200', 'Provisional dispute credits', 'asset');
+
+INSERT INTO "account_products" ("code", "name", "annual_rate_ppm", "is_internal", "gl_account") VALUES
+  ('dispute_credits', 'Provisional dispute credits', 0, true, '1200');
+
+CREATE TABLE "disputes" (
+  "id" bigserial PRIMARY KEY,
+  "transfer_id" bigint NOT NULL,
+  "username" varchar NOT NULL,
+  "reason" varchar NOT NULL CHECK ("reason" IN ('unauthorized', 'not_received', 'not_as_described', 'duplicate', 'incorrect_amount', 'other')),
+  "description" varchar NOT NULL DEFAULT '',
+  "amount" bigint NOT NULL CHECK ("amount" > 0),
+  "currency" varchar NOT NULL,
+  "status" varchar NOT NULL DEFAULT 'open' CHECK ("status" IN ('open', 'under_review', 'won', 'lost', 'withdrawn')),
+  "provisional_credit_transfer_id" bigint,
+  "resolution_transfer_id" bigint,
+  "assigned_to" varchar,
+  "resolution" varchar NOT NULL DEFAULT '',
+  "resolved_by" varchar,
+  "due_at" timestamptz NOT NULL,
+  "sla_warned_at" timestamptz,
+  "sla_breached_at" timestamptz,
+  "resolved_at" timestamptz,
+  "updated_at" timestamptz NOT NULL DEFAULT (now()),
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "dispute_evidence" (
+  "id" bigserial PRIMARY KEY,
+  "dispute_id" bigint NOT NULL,
+  "submitted_by" varchar NOT NULL,
+  "note" varchar NOT NULL DEFAULT '',
+  "file_name" varchar NOT NULL DEFAULT '',
+  "content_type" varchar NOT NULL DEFAULT '',
+  "size" bigint NOT NULL DEFAULT 0,
+  "sha256" varchar NOT NULL DEFAULT '',
+  "storage_key" varchar NOT NULL DEFAULT '',
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "dispute_events" (
+  "id" bigserial PRIMARY KEY,
+  "dispute_id" bigint NOT NULL,
+  "actor" varchar NOT NULL,
+  "from_status" varchar NOT NULL DEFAULT '',
+  "to_status" varchar NOT NULL,
+  "note" varchar NOT NULL DEFAULT '',
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE UNIQUE INDEX ON "disputes" ("transfer_id") WHERE "status" <> 'withdrawn';
+
+CREATE INDEX ON "disputes" ("username");
+
+CREATE INDEX ON "disputes" ("due_at") WHERE "status" IN ('open', 'under_review');
+
+CREATE INDEX ON "dispute_evidence" ("dispute_id");
+
+CREATE INDEX ON "dispute_events" ("dispute_id");
+
+COMMENT ON COLUMN "disputes"."username" IS 'owner of the account the transfer was sent from, who opened the dispute';
+
+COMMENT ON COLUMN "disputes"."amount" IS 'amount disputed, at most the amount of the transfer';
+
+COMMENT ON COLUMN "disputes"."provisional_credit_transfer_id" IS 'transfer crediting the amount to the customer while the dispute is decided';
+
+COMMENT ON COLUMN "disputes"."resolution_transfer_id" IS 'reversal of the transfer when won, or recovery of the provisional credit when lost or withdrawn';
+
+COMMENT ON COLUMN "disputes"."due_at" IS 'when the dispute must be decided by';
+
+COMMENT ON COLUMN "dispute_evidence"."storage_key" IS 'key of the file in the document store; empty for a note without a file';
+
+COMMENT ON COLUMN "dispute_events"."from_status" IS 'empty when the dispute was opened';
+
+ALTER TABLE "disputes" ADD FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
+
+ALTER TABLE "disputes" ADD FOREIGN KEY ("username") REFERENCES "users" ("username");
+
+ALTER TABLE "disputes" ADD FOREIGN KEY ("currency") REFERENCES "currencies" ("code");
+
+ALTER TABLE "disputes" ADD FOREIGN KEY ("provisional_credit_transfer_id") REFERENCES "transfers" ("id");
+
+ALTER TABLE "disputes" ADD FOREIGN KEY ("resolution_transfer_id") REFERENCES "transfers" ("id");
+
+ALTER TABLE "disputes" ADD FOREIGN KEY ("assigned_to") REFERENCES "users" ("username");
+
+ALTER TABLE "disputes" ADD FOREIGN KEY ("resolved_by") REFERENCES "users" ("username");
+
+ALTER TABLE "dispute_evidence" ADD FOREIGN KEY ("dispute_id") REFERENCES "disputes" ("id");
+
+ALTER TABLE "dispute_evidence" ADD FOREIGN KEY ("submitted_by") REFERENCES "users" ("username");
+
+ALTER TABLE "dispute_events" ADD FOREIGN KEY ("dispute_id") REFERENCES "disputes" ("id");
+
+ALTER TABLE "dispute_events" ADD FOREIGN KEY ("actor") REFERENCES "users" ("username");
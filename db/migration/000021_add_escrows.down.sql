@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS "escrows";
+
+DELETE FROM "account_products" WHERE "code" = 'escrow'
+  AND NOT EXISTS (SELECT 1 FROM "accounts" WHERE "product" = "account_products"."code");
+
+DELETE FROM "gl_accounts" WHERE "code" = '2100'
+  AND NOT EXISTS (SELECT 1 FROM "account_products" WHERE "gl_account" = '2100');
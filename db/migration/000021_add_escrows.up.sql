@@ -0,0 +1,64 @@
+INSERT INTO "gl_accounts" ("code", "name", "type") VALUES
+  ('2100', 'Escrow deposits', 'liability');
+
+INSERT INTO "account_products" ("code", "name", "annual_rate_ppm", "is_internal", "gl_account") VALUES
+  ('escrow', 'Escrow', 0, true, '2100');
+
+CREATE TABLE "escrows" (
+  "id" bigserial PRIMARY KEY,
+  "payer" varchar NOT NULL,
+  "payee" varchar NOT NULL,
+  "from_account_id" bigint NOT NULL,
+  "to_account_id" bigint NOT NULL,
+  "escrow_account_id" bigint NOT NULL,
+  "amount" bigint NOT NULL CHECK ("amount" > 0),
+  "currency" varchar NOT NULL,
+  "description" varchar NOT NULL DEFAULT '',
+  "status" varchar NOT NULL DEFAULT 'held' CHECK ("status" IN ('held', 'disputed', 'released', 'refunded')),
+  "release_at" timestamptz NOT NULL,
+  "funding_transfer_id" bigint NOT NULL,
+  "settlement_transfer_id" bigint,
+  "dispute_reason" varchar NOT NULL DEFAULT '',
+  "disputed_at" timestamptz,
+  "note" varchar NOT NULL DEFAULT '',
+  "settled_by" varchar,
+  "settled_at" timestamptz,
+  "updated_at" timestamptz NOT NULL DEFAULT (now()),
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX ON "escrows" ("payer");
+
+CREATE INDEX ON "escrows" ("payee");
+
+CREATE INDEX ON "escrows" ("release_at") WHERE "status" = 'held';
+
+COMMENT ON COLUMN "escrows"."payee" IS 'owner of the account the money is released to';
+
+COMMENT ON COLUMN "escrows"."escrow_account_id" IS 'bank escrow account holding the money';
+
+COMMENT ON COLUMN "escrows"."release_at" IS 'when a held escrow is released to the payee without confirmation';
+
+COMMENT ON COLUMN "escrows"."funding_transfer_id" IS 'transfer from the payer into the escrow account';
+
+COMMENT ON COLUMN "escrows"."settlement_transfer_id" IS 'transfer out of the escrow account to the payee when released or to the payer when refunded';
+
+COMMENT ON COLUMN "escrows"."settled_by" IS 'user who released or refunded the escrow; null when released on timeout';
+
+ALTER TABLE "escrows" ADD FOREIGN KEY ("payer") REFERENCES "users" ("username");
+
+ALTER TABLE "escrows" ADD FOREIGN KEY ("payee") REFERENCES "users" ("username");
+
+ALTER TABLE "escrows" ADD FOREIGN KEY ("from_account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "escrows" ADD FOREIGN KEY ("to_account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "escrows" ADD FOREIGN KEY ("escrow_account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "escrows" ADD FOREIGN KEY ("currency") REFERENCES "currencies" ("code");
+
+ALTER TABLE "escrows" ADD FOREIGN KEY ("funding_transfer_id") REFERENCES "transfers" ("id");
+
+ALTER TABLE "escrows" ADD FOREIGN KEY ("settlement_transfer_id") REFERENCES "transfers" ("id");
+
+ALTER TABLE "escrows" ADD FOREIGN KEY ("settled_by") REFERENCES "users" ("username");
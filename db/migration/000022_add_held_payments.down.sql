@@ -0,0 +1,14 @@
+COMMENT ON COLUMN "transfer_batch_items"."status" IS 'pending, succeeded or failed';
+
+UPDATE "transfer_batch_items" SET "status" = 'failed', "error" = 'held for fraud review' WHERE "status" = 'held';
+
+ALTER TABLE "transfer_batches" DROP COLUMN IF EXISTS "held_rows";
+
+ALTER TABLE "transfer_batches" DROP COLUMN IF EXISTS "client_ip";
+
+UPDATE "fraud_decisions" SET "status" = 'rejected', "review_note" = 'cannot be posted without its kind', "reviewed_at" = now()
+  WHERE "status" = 'pending' AND "kind" <> 'transfer';
+
+ALTER TABLE "fraud_decisions" DROP COLUMN IF EXISTS "payload";
+
+ALTER TABLE "fraud_decisions" DROP COLUMN IF EXISTS "kind";
@@ -0,0 +1,13 @@
+ALTER TABLE "fraud_decisions" ADD COLUMN "kind" varchar NOT NULL DEFAULT 'transfer' CHECK ("kind" IN ('transfer', 'payment_request', 'transfer_batch_item'));
+
+ALTER TABLE "fraud_decisions" ADD COLUMN "payload" jsonb NOT NULL DEFAULT '{}';
+
+COMMENT ON COLUMN "fraud_decisions"."kind" IS 'what the transfer pays: a plain transfer, a payment request or a row of a transfer batch';
+
+COMMENT ON COLUMN "fraud_decisions"."payload" IS 'what posting a held transfer of its kind settles besides the transfer, e.g. the payment request it pays';
+
+ALTER TABLE "transfer_batches" ADD COLUMN "client_ip" varchar NOT NULL DEFAULT '';
+
+ALTER TABLE "transfer_batches" ADD COLUMN "held_rows" int NOT NULL DEFAULT 0;
+
+COMMENT ON COLUMN "transfer_batch_items"."status" IS 'pending, held for fraud review, succeeded or failed';
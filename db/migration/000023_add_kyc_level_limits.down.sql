@@ -0,0 +1,12 @@
+ALTER TABLE "kyc_levels" ADD COLUMN "max_transfer_amount" bigint;
+
+ALTER TABLE "kyc_levels" ADD COLUMN "daily_transfer_limit" bigint;
+
+UPDATE "kyc_levels" k SET "max_transfer_amount" = l."max_transfer_amount", "daily_transfer_limit" = l."daily_transfer_limit"
+  FROM "kyc_level_limits" l WHERE l."level" = k."level" AND l."currency" = 'USD';
+
+COMMENT ON COLUMN "kyc_levels"."max_transfer_amount" IS 'in minor units of the currency of the transfer; no limit when null';
+
+COMMENT ON COLUMN "kyc_levels"."daily_transfer_limit" IS 'total sent since midnight UTC, in minor units of any currency; no limit when null';
+
+DROP TABLE IF EXISTS "kyc_level_limits";
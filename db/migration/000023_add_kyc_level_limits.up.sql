@@ -0,0 +1,42 @@
+CREATE TABLE "kyc_level_limits" (
+  "level" integer NOT NULL,
+  "currency" varchar NOT NULL,
+  "max_transfer_amount" bigint,
+  "daily_transfer_limit" bigint,
+  PRIMARY KEY ("level", "currency")
+);
+
+-- about 100 and 200 USD a transfer and a day unverified, 1000 and 5000 USD
+-- identity verified; a level has no limits in a currency without a row, so
+-- fully verified users have none
+INSERT INTO "kyc_level_limits" ("level", "currency", "max_transfer_amount", "daily_transfer_limit") VALUES
+  (0, 'USD', 10000, 20000),
+  (0, 'EUR', 10000, 20000),
+  (0, 'CAD', 10000, 20000),
+  (0, 'GBP', 10000, 20000),
+  (0, 'CHF', 10000, 20000),
+  (0, 'JPY', 15000, 30000),
+  (0, 'KRW', 130000, 260000),
+  (0, 'KWD', 30000, 60000),
+  (0, 'BHD', 38000, 76000),
+  (1, 'USD', 100000, 500000),
+  (1, 'EUR', 100000, 500000),
+  (1, 'CAD', 100000, 500000),
+  (1, 'GBP', 100000, 500000),
+  (1, 'CHF', 100000, 500000),
+  (1, 'JPY', 150000, 750000),
+  (1, 'KRW', 1300000, 6500000),
+  (1, 'KWD', 300000, 1500000),
+  (1, 'BHD', 380000, 1900000);
+
+ALTER TABLE "kyc_levels" DROP COLUMN "max_transfer_amount";
+
+ALTER TABLE "kyc_levels" DROP COLUMN "daily_transfer_limit";
+
+COMMENT ON COLUMN "kyc_level_limits"."max_transfer_amount" IS 'in minor units of the currency; no limit when null';
+
+COMMENT ON COLUMN "kyc_level_limits"."daily_transfer_limit" IS 'total sent in the currency since midnight UTC, in its minor units; no limit when null';
+
+ALTER TABLE "kyc_level_limits" ADD FOREIGN KEY ("level") REFERENCES "kyc_levels" ("level");
+
+ALTER TABLE "kyc_level_limits" ADD FOREIGN KEY ("currency") REFERENCES "currencies" ("code");
@@ -0,0 +1,10 @@
+UPDATE "fraud_decisions" SET "status" = 'rejected', "review_note" = 'cannot be posted without its kind', "reviewed_at" = now()
+  WHERE "status" = 'pending' AND "kind" = 'escrow';
+
+UPDATE "fraud_decisions" SET "kind" = 'transfer' WHERE "kind" = 'escrow';
+
+COMMENT ON COLUMN "fraud_decisions"."kind" IS 'what the transfer pays: a plain transfer, a payment request or a row of a transfer batch';
+
+ALTER TABLE "fraud_decisions" DROP CONSTRAINT IF EXISTS "fraud_decisions_kind_check";
+
+ALTER TABLE "fraud_decisions" ADD CONSTRAINT "fraud_decisions_kind_check" CHECK ("kind" IN ('transfer', 'payment_request', 'transfer_batch_item'));
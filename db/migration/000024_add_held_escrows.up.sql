@@ -0,0 +1,5 @@
+ALTER TABLE "fraud_decisions" DROP CONSTRAINT IF EXISTS "fraud_decisions_kind_check";
+
+ALTER TABLE "fraud_decisions" ADD CONSTRAINT "fraud_decisions_kind_check" CHECK ("kind" IN ('transfer', 'payment_request', 'transfer_batch_item', 'escrow'));
+
+COMMENT ON COLUMN "fraud_decisions"."kind" IS 'what the transfer pays: a plain transfer, a payment request, a row of a transfer batch or an escrow';
@@ -0,0 +1,13 @@
+ALTER TABLE "transfers" ADD COLUMN "kind" varchar NOT NULL DEFAULT 'payment' CHECK ("kind" IN ('payment', 'internal', 'forced'));
+
+COMMENT ON COLUMN "transfers"."kind" IS 'payment by a customer, internal posting from one of the bank''s accounts, or forced posting the bank took from a customer account, such as a chargeback or overdraft interest';
+
+UPDATE "transfers" t SET "kind" = 'internal'
+  FROM "accounts" a
+  WHERE a."id" = t."from_account_id" AND a."owner" = 'sgbank-system';
+
+UPDATE "transfers" SET "kind" = 'forced'
+  WHERE "kind" = 'payment' AND (
+    "id" IN (SELECT "resolution_transfer_id" FROM "disputes" WHERE "resolution_transfer_id" IS NOT NULL)
+    OR "id" IN (SELECT "transfer_id" FROM "interest_capitalizations" WHERE "transfer_id" IS NOT NULL)
+  );
@@ -0,0 +1,7 @@
+-- name: ListAccountProducts :many
+SELECT * FROM account_products
+WHERE NOT is_internal
+ORDER BY code;
+
+-- name: GetAccountProduct :one
+SELECT * FROM account_products WHERE code = $1 LIMIT 1;
@@ -1,20 +1,42 @@
 -- name: CreateAccount :one
-INSERT INTO accounts (owner, balance, currency)
-VALUES ($1, $2, $3)
+INSERT INTO accounts (owner, balance, currency, account_number, product)
+VALUES ($1, $2, $3, $4, $5)
 RETURNING *;
 
 -- name: GetAccount :one
 SELECT * FROM accounts WHERE id = $1 LIMIT 1;
 
+-- name: GetAccountByNumber :one
+SELECT * FROM accounts WHERE account_number = $1 LIMIT 1;
+
 -- name: GetAccountForUpdate :one
 SELECT * FROM accounts WHERE id = $1 LIMIT 1 FOR NO KEY UPDATE;
 
+-- name: GetAccountByOwnerCurrency :one
+-- Returns owner's checking account in currency, where payments to the
+-- owner are credited.
+SELECT * FROM accounts
+WHERE owner = $1 AND currency = $2 AND product = 'checking'
+LIMIT 1;
+
+-- name: GetAccountByOwnerProduct :one
+SELECT * FROM accounts
+WHERE owner = $1 AND currency = $2 AND product = $3
+LIMIT 1;
+
 -- name: ListAccounts :many
 SELECT * FROM accounts
 WHERE owner = $1
 ORDER BY id 
 LIMIT $2 OFFSET $3;
 
+-- name: ListAccountsAfter :many
+SELECT * FROM accounts
+WHERE owner = sqlc.arg(owner)
+    AND id > sqlc.arg(after_id)
+ORDER BY id
+LIMIT sqlc.arg(page_limit);
+
 -- name: UpdatedAccount :one
 UPDATE accounts
 SET balance = $2
@@ -0,0 +1,43 @@
+-- name: GetAccountBalanceBefore :one
+-- Returns the balance of an account before a time: the daily balance of the
+-- last business day that started closing by then plus the entries posted to
+-- later days before it, or, without such a daily balance, the current
+-- balance minus the entries created since.
+WITH snapshot AS (
+    SELECT b.business_date, b.balance
+    FROM daily_balances b
+    JOIN business_days d ON d.business_date = b.business_date
+    WHERE b.account_id = sqlc.arg(account_id) AND d.closing_at <= sqlc.arg(before)
+    ORDER BY b.business_date DESC
+    LIMIT 1
+)
+SELECT (CASE
+    WHEN EXISTS (SELECT 1 FROM snapshot) THEN
+        (SELECT s.balance FROM snapshot s) + COALESCE((
+            SELECT SUM(e.amount) FROM entries e
+            WHERE e.account_id = a.id
+                AND e.business_date > (SELECT s.business_date FROM snapshot s)
+                AND e.created_at < sqlc.arg(before)
+        ), 0)
+    ELSE
+        a.balance - COALESCE((
+            SELECT SUM(e.amount) FROM entries e
+            WHERE e.account_id = a.id AND e.created_at >= sqlc.arg(before)
+        ), 0)
+    END)::bigint AS balance
+FROM accounts a
+WHERE a.id = sqlc.arg(account_id);
+
+-- name: SumEntriesByPeriod :many
+-- Sums the credits and debits of an account's entries created from from to
+-- before to, by UTC day, week (from Monday) or month.
+SELECT
+    (date_trunc(sqlc.arg(period)::text, e.created_at AT TIME ZONE 'UTC') AT TIME ZONE 'UTC')::timestamptz AS period_start,
+    COALESCE(SUM(e.amount) FILTER (WHERE e.amount > 0), 0)::bigint AS credits,
+    COALESCE(-SUM(e.amount) FILTER (WHERE e.amount < 0), 0)::bigint AS debits
+FROM entries e
+WHERE e.account_id = sqlc.arg(account_id)
+    AND e.created_at >= sqlc.arg(created_from)
+    AND e.created_at < sqlc.arg(created_to)
+GROUP BY 1
+ORDER BY 1;
@@ -0,0 +1,36 @@
+-- name: CreateBeneficiary :one
+INSERT INTO beneficiaries (
+    owner,
+    account_number,
+    nickname,
+    currency,
+    is_verified
+) VALUES (
+    $1, $2, $3, $4, $5
+) RETURNING *;
+
+-- name: GetBeneficiary :one
+SELECT * FROM beneficiaries WHERE id = $1 LIMIT 1;
+
+-- name: ListBeneficiariesAfter :many
+SELECT * FROM beneficiaries
+WHERE owner = sqlc.arg(owner)
+    AND id > sqlc.arg(after_id)
+ORDER BY id
+LIMIT sqlc.arg(page_limit);
+
+-- name: UpdateBeneficiaryNickname :one
+UPDATE beneficiaries
+SET nickname = $2
+WHERE id = $1
+RETURNING *;
+
+-- name: MarkBeneficiaryVerified :one
+UPDATE beneficiaries
+SET is_verified = true
+WHERE id = $1
+RETURNING *;
+
+-- name: DeleteBeneficiary :exec
+DELETE FROM beneficiaries
+WHERE id = $1;
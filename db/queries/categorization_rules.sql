@@ -0,0 +1,34 @@
+-- name: CreateCategorizationRule :one
+INSERT INTO categorization_rules (
+    owner,
+    category,
+    description_contains,
+    merchant_category,
+    counterparty_account_id,
+    priority
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+) RETURNING *;
+
+-- name: GetCategorizationRule :one
+SELECT * FROM categorization_rules WHERE id = $1 LIMIT 1;
+
+-- name: ListCategorizationRules :many
+SELECT * FROM categorization_rules
+WHERE owner = $1
+ORDER BY priority DESC, id;
+
+-- name: DeleteCategorizationRule :exec
+DELETE FROM categorization_rules WHERE id = $1;
+
+-- name: MatchCategorizationRule :one
+-- Returns the category of owner's highest-priority rule matching an entry;
+-- the oldest rule wins a tie. Empty matchers match anything.
+SELECT category FROM categorization_rules
+WHERE owner = sqlc.arg(owner)
+    AND (merchant_category = '' OR merchant_category = sqlc.arg(merchant_category)::varchar)
+    AND (description_contains = ''
+        OR strpos(lower(sqlc.arg(description)::varchar), lower(description_contains)) > 0)
+    AND (counterparty_account_id IS NULL OR counterparty_account_id = sqlc.arg(counterparty_account_id)::bigint)
+ORDER BY priority DESC, id
+LIMIT 1;
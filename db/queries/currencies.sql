@@ -0,0 +1,15 @@
+-- name: ListCurrencies :many
+SELECT * FROM currencies
+ORDER BY code;
+
+-- name: GetCurrency :one
+SELECT * FROM currencies WHERE code = $1 LIMIT 1;
+
+-- name: UpsertCurrency :one
+INSERT INTO currencies (code, name, exponent, enabled)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (code) DO UPDATE
+SET name = EXCLUDED.name,
+    enabled = EXCLUDED.enabled,
+    updated_at = now()
+RETURNING *;
@@ -0,0 +1,128 @@
+-- name: CreateDispute :one
+INSERT INTO disputes (
+    transfer_id,
+    username,
+    reason,
+    description,
+    amount,
+    currency,
+    due_at
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7
+) RETURNING *;
+
+-- name: GetDispute :one
+SELECT * FROM disputes
+WHERE id = $1 LIMIT 1;
+
+-- name: GetDisputeForUpdate :one
+SELECT * FROM disputes
+WHERE id = $1 LIMIT 1
+FOR NO KEY UPDATE;
+
+-- name: ListUserDisputes :many
+SELECT * FROM disputes
+WHERE username = $1
+ORDER BY id DESC;
+
+-- name: ListDisputes :many
+-- The support queue: disputes oldest first, optionally with a status or
+-- assignee.
+SELECT * FROM disputes
+WHERE id > sqlc.arg(after_id)
+    AND (sqlc.narg(status)::varchar IS NULL OR status = sqlc.narg(status))
+    AND (sqlc.narg(assigned_to)::varchar IS NULL OR assigned_to = sqlc.narg(assigned_to))
+ORDER BY id
+LIMIT sqlc.arg(page_limit);
+
+-- name: UpdateDispute :one
+-- Sets the status of a dispute and the fields given.
+UPDATE disputes
+SET status = sqlc.arg(status),
+    assigned_to = COALESCE(sqlc.narg(assigned_to), assigned_to),
+    resolution = COALESCE(sqlc.narg(resolution), resolution),
+    resolved_by = COALESCE(sqlc.narg(resolved_by), resolved_by),
+    resolved_at = COALESCE(sqlc.narg(resolved_at), resolved_at),
+    provisional_credit_transfer_id = COALESCE(sqlc.narg(provisional_credit_transfer_id), provisional_credit_transfer_id),
+    resolution_transfer_id = COALESCE(sqlc.narg(resolution_transfer_id), resolution_transfer_id),
+    updated_at = now()
+WHERE id = sqlc.arg(id)
+RETURNING *;
+
+-- name: CreateDisputeEvent :one
+INSERT INTO dispute_events (
+    dispute_id,
+    actor,
+    from_status,
+    to_status,
+    note
+) VALUES (
+    $1, $2, $3, $4, $5
+) RETURNING *;
+
+-- name: ListDisputeEvents :many
+SELECT * FROM dispute_events
+WHERE dispute_id = $1
+ORDER BY id;
+
+-- name: CreateDisputeEvidence :one
+INSERT INTO dispute_evidence (
+    dispute_id,
+    submitted_by,
+    note,
+    file_name,
+    content_type,
+    size,
+    sha256,
+    storage_key
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8
+) RETURNING *;
+
+-- name: ListDisputeEvidence :many
+SELECT * FROM dispute_evidence
+WHERE dispute_id = $1
+ORDER BY id;
+
+-- name: GetDisputeEvidence :one
+SELECT * FROM dispute_evidence
+WHERE id = sqlc.arg(id) AND dispute_id = sqlc.arg(dispute_id)
+LIMIT 1;
+
+-- name: ClaimDisputeSLAWarnings :many
+-- Marks the undecided disputes due before warn_before, and not overdue yet,
+-- that were not warned about. SKIP LOCKED lets several instances claim at once.
+UPDATE disputes
+SET sla_warned_at = now()
+WHERE id IN (
+    SELECT d.id FROM disputes d
+    WHERE d.status IN ('open', 'under_review')
+        AND d.sla_warned_at IS NULL
+        AND d.due_at > now()
+        AND d.due_at <= sqlc.arg(warn_before)
+    ORDER BY d.due_at
+    LIMIT sqlc.arg(page_limit)
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING *;
+
+-- name: ClaimDisputeSLABreaches :many
+-- Marks the undecided disputes past their due date that were not reported
+-- yet.
+UPDATE disputes
+SET sla_breached_at = now()
+WHERE id IN (
+    SELECT d.id FROM disputes d
+    WHERE d.status IN ('open', 'under_review')
+        AND d.sla_breached_at IS NULL
+        AND d.due_at <= now()
+    ORDER BY d.due_at
+    LIMIT sqlc.arg(page_limit)
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING *;
+
+-- name: ListUsernamesByRole :many
+SELECT username FROM users
+WHERE role = $1
+ORDER BY username;
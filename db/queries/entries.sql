@@ -1,6 +1,8 @@
 -- name: CreateEntry :one
-INSERT INTO entries (account_id, amount) 
-VALUES ($1, $2)
+-- Posts to business_date, or to the open business day when it is null.
+INSERT INTO entries (account_id, amount, transfer_id, description, external_reference, merchant_category, category, business_date)
+VALUES ($1, $2, $3, $4, $5, $6, $7,
+    COALESCE(sqlc.narg(business_date)::date, (SELECT d.business_date FROM business_days d WHERE d.status = 'open')))
 RETURNING *;
 
 -- name: GetEntry :one
@@ -11,3 +13,51 @@ SELECT * FROM entries
 WHERE account_id = $1
 ORDER BY id
 LIMIT $2 OFFSET $3;
+
+-- name: ListEntriesDesc :many
+SELECT sqlc.embed(e),
+    COALESCE(CASE WHEN t.from_account_id = e.account_id THEN t.to_account_id ELSE t.from_account_id END, 0)::bigint AS counterparty_account_id
+FROM entries e
+LEFT JOIN transfers t ON t.id = e.transfer_id
+WHERE e.account_id = sqlc.arg(account_id)
+    AND (sqlc.narg(created_from)::timestamptz IS NULL OR e.created_at >= sqlc.narg(created_from)::timestamptz)
+    AND (sqlc.narg(created_to)::timestamptz IS NULL OR e.created_at < sqlc.narg(created_to)::timestamptz)
+    AND (sqlc.narg(min_amount)::bigint IS NULL OR abs(e.amount) >= sqlc.narg(min_amount)::bigint)
+    AND (sqlc.narg(max_amount)::bigint IS NULL OR abs(e.amount) <= sqlc.narg(max_amount)::bigint)
+    AND (sqlc.narg(direction)::text IS NULL
+        OR (sqlc.narg(direction)::text = 'credit' AND e.amount > 0)
+        OR (sqlc.narg(direction)::text = 'debit' AND e.amount < 0))
+    AND (sqlc.narg(counterparty_account_id)::bigint IS NULL
+        OR (t.from_account_id = e.account_id AND t.to_account_id = sqlc.narg(counterparty_account_id)::bigint)
+        OR (t.to_account_id = e.account_id AND t.from_account_id = sqlc.narg(counterparty_account_id)::bigint))
+    AND (sqlc.narg(category)::varchar IS NULL OR e.category = sqlc.narg(category)::varchar)
+    AND (sqlc.narg(query)::text IS NULL
+        OR to_tsvector('simple', e.description || ' ' || e.external_reference) @@ websearch_to_tsquery('simple', sqlc.narg(query)::text))
+    AND (sqlc.narg(after_created_at)::timestamptz IS NULL
+        OR (e.created_at, e.id) < (sqlc.narg(after_created_at)::timestamptz, sqlc.narg(after_id)::bigint))
+ORDER BY e.created_at DESC, e.id DESC
+LIMIT sqlc.arg(page_limit);
+
+-- name: ListEntriesAsc :many
+SELECT sqlc.embed(e),
+    COALESCE(CASE WHEN t.from_account_id = e.account_id THEN t.to_account_id ELSE t.from_account_id END, 0)::bigint AS counterparty_account_id
+FROM entries e
+LEFT JOIN transfers t ON t.id = e.transfer_id
+WHERE e.account_id = sqlc.arg(account_id)
+    AND (sqlc.narg(created_from)::timestamptz IS NULL OR e.created_at >= sqlc.narg(created_from)::timestamptz)
+    AND (sqlc.narg(created_to)::timestamptz IS NULL OR e.created_at < sqlc.narg(created_to)::timestamptz)
+    AND (sqlc.narg(min_amount)::bigint IS NULL OR abs(e.amount) >= sqlc.narg(min_amount)::bigint)
+    AND (sqlc.narg(max_amount)::bigint IS NULL OR abs(e.amount) <= sqlc.narg(max_amount)::bigint)
+    AND (sqlc.narg(direction)::text IS NULL
+        OR (sqlc.narg(direction)::text = 'credit' AND e.amount > 0)
+        OR (sqlc.narg(direction)::text = 'debit' AND e.amount < 0))
+    AND (sqlc.narg(counterparty_account_id)::bigint IS NULL
+        OR (t.from_account_id = e.account_id AND t.to_account_id = sqlc.narg(counterparty_account_id)::bigint)
+        OR (t.to_account_id = e.account_id AND t.from_account_id = sqlc.narg(counterparty_account_id)::bigint))
+    AND (sqlc.narg(category)::varchar IS NULL OR e.category = sqlc.narg(category)::varchar)
+    AND (sqlc.narg(query)::text IS NULL
+        OR to_tsvector('simple', e.description || ' ' || e.external_reference) @@ websearch_to_tsquery('simple', sqlc.narg(query)::text))
+    AND (sqlc.narg(after_created_at)::timestamptz IS NULL
+        OR (e.created_at, e.id) > (sqlc.narg(after_created_at)::timestamptz, sqlc.narg(after_id)::bigint))
+ORDER BY e.created_at ASC, e.id ASC
+LIMIT sqlc.arg(page_limit);
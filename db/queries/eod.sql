@@ -0,0 +1,105 @@
+-- name: GetOpenBusinessDay :one
+SELECT * FROM business_days
+WHERE status = 'open'
+LIMIT 1;
+
+-- name: GetOpenBusinessDayForShare :one
+-- Closing the day waits for the transactions holding this lock, so no
+-- posting lands in a day after it started closing.
+SELECT * FROM business_days
+WHERE status = 'open'
+LIMIT 1
+FOR SHARE;
+
+-- name: GetBusinessDay :one
+SELECT * FROM business_days
+WHERE business_date = $1
+LIMIT 1;
+
+-- name: GetClosingBusinessDay :one
+SELECT * FROM business_days
+WHERE status = 'closing'
+ORDER BY business_date
+LIMIT 1;
+
+-- name: CreateBusinessDay :one
+INSERT INTO business_days (business_date) VALUES ($1)
+RETURNING *;
+
+-- name: StartClosingBusinessDay :one
+UPDATE business_days
+SET status = 'closing',
+    closing_at = now()
+WHERE business_date = $1 AND status = 'open'
+RETURNING *;
+
+-- name: FinishClosingBusinessDay :one
+-- Affects no row while a step of the day has not succeeded.
+UPDATE business_days
+SET status = 'closed',
+    closed_at = now()
+WHERE business_days.business_date = $1 AND business_days.status = 'closing'
+    AND NOT EXISTS (
+        SELECT 1 FROM eod_steps s
+        WHERE s.business_date = $1 AND s.status <> 'succeeded')
+RETURNING *;
+
+-- name: CreateEODStep :one
+INSERT INTO eod_steps (
+    business_date,
+    name,
+    position
+) VALUES (
+    $1, $2, $3
+) RETURNING *;
+
+-- name: ListEODSteps :many
+SELECT * FROM eod_steps
+WHERE business_date = $1
+ORDER BY position;
+
+-- name: ClaimEODStep :one
+-- Claims a pending or failed step, or a running step whose worker started
+-- it before stale_before, for the calling worker.
+UPDATE eod_steps
+SET status = 'running',
+    attempts = attempts + 1,
+    error = '',
+    started_at = now(),
+    finished_at = NULL
+WHERE business_date = sqlc.arg(business_date) AND name = sqlc.arg(name)
+    AND (status IN ('pending', 'failed')
+        OR (status = 'running' AND started_at < sqlc.arg(stale_before)::timestamptz))
+RETURNING *;
+
+-- name: FinishEODStep :one
+UPDATE eod_steps
+SET status = sqlc.arg(status),
+    error = sqlc.arg(error),
+    finished_at = now()
+WHERE business_date = sqlc.arg(business_date) AND name = sqlc.arg(name)
+    AND status = 'running'
+RETURNING *;
+
+-- name: SnapshotDailyBalances :execrows
+-- Records the balance at the end of a closing business date of every
+-- account opened before the day started closing: its current balance minus
+-- every entry posted to a later day. Accounts already recorded are skipped,
+-- so it may run again.
+INSERT INTO daily_balances (account_id, business_date, balance)
+SELECT
+    a.id,
+    d.business_date,
+    a.balance - COALESCE((
+        SELECT SUM(e.amount) FROM entries e
+        WHERE e.account_id = a.id AND e.business_date > d.business_date
+    ), 0)
+FROM accounts a
+JOIN business_days d ON d.business_date = sqlc.arg(business_date)
+WHERE a.created_at < d.closing_at
+ON CONFLICT (account_id, business_date) DO NOTHING;
+
+-- name: GetDailyBalance :one
+SELECT * FROM daily_balances
+WHERE account_id = $1 AND business_date = $2
+LIMIT 1;
@@ -0,0 +1,59 @@
+-- name: CreateEscrow :one
+INSERT INTO escrows (
+    payer,
+    payee,
+    from_account_id,
+    to_account_id,
+    escrow_account_id,
+    amount,
+    currency,
+    description,
+    release_at,
+    funding_transfer_id
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10
+) RETURNING *;
+
+-- name: GetEscrow :one
+SELECT * FROM escrows
+WHERE id = $1 LIMIT 1;
+
+-- name: GetEscrowForUpdate :one
+SELECT * FROM escrows
+WHERE id = $1 LIMIT 1
+FOR NO KEY UPDATE;
+
+-- name: ListUserEscrows :many
+-- Escrows the user pays or is paid by, newest first.
+SELECT * FROM escrows
+WHERE payer = sqlc.arg(username) OR payee = sqlc.arg(username)
+ORDER BY id DESC;
+
+-- name: ListEscrows :many
+-- Escrows oldest first, optionally with a status.
+SELECT * FROM escrows
+WHERE id > sqlc.arg(after_id)
+    AND (sqlc.narg(status)::varchar IS NULL OR status = sqlc.narg(status))
+ORDER BY id
+LIMIT sqlc.arg(page_limit);
+
+-- name: ListDueEscrowIDs :many
+-- Held escrows past their release time, oldest first.
+SELECT id FROM escrows
+WHERE status = 'held' AND release_at <= now()
+ORDER BY release_at
+LIMIT sqlc.arg(page_limit);
+
+-- name: UpdateEscrow :one
+-- Sets the status of an escrow and the fields given.
+UPDATE escrows
+SET status = sqlc.arg(status),
+    settlement_transfer_id = COALESCE(sqlc.narg(settlement_transfer_id), settlement_transfer_id),
+    dispute_reason = COALESCE(sqlc.narg(dispute_reason), dispute_reason),
+    disputed_at = COALESCE(sqlc.narg(disputed_at), disputed_at),
+    note = COALESCE(sqlc.narg(note), note),
+    settled_by = COALESCE(sqlc.narg(settled_by), settled_by),
+    settled_at = COALESCE(sqlc.narg(settled_at), settled_at),
+    updated_at = now()
+WHERE id = sqlc.arg(id)
+RETURNING *;
@@ -0,0 +1,23 @@
+-- name: CreateFeeSchedule :one
+INSERT INTO fee_schedules (currency, min_fee, max_fee)
+VALUES ($1, $2, $3)
+RETURNING *;
+
+-- name: GetFeeSchedule :one
+SELECT * FROM fee_schedules WHERE currency = $1 LIMIT 1;
+
+-- name: CreateFeeScheduleBand :one
+INSERT INTO fee_schedule_bands (schedule_id, up_to, flat_fee, rate_ppm)
+VALUES ($1, $2, $3, $4)
+RETURNING *;
+
+-- name: ListFeeScheduleBands :many
+SELECT * FROM fee_schedule_bands
+WHERE schedule_id = $1
+ORDER BY up_to ASC NULLS LAST;
+
+-- name: GetUserTier :one
+SELECT t.* FROM user_tiers t
+JOIN users u ON u.tier = t.code
+WHERE u.username = $1
+LIMIT 1;
@@ -0,0 +1,80 @@
+-- name: CountRecentTransfers :one
+-- Counts the transfers from accounts of an owner created since a time, and
+-- those of them whose amount is a multiple of round_amount.
+SELECT
+    count(*) AS transfers,
+    count(*) FILTER (WHERE t.amount % NULLIF(sqlc.arg(round_amount)::bigint, 0) = 0) AS round_transfers
+FROM transfers t
+JOIN accounts a ON a.id = t.from_account_id
+WHERE a.owner = sqlc.arg(owner) AND t.created_at >= sqlc.arg(created_since);
+
+-- name: HasTransferredTo :one
+-- Reports whether an owner has sent money to an account before, directly
+-- or through an escrow for it.
+SELECT (
+    EXISTS (
+        SELECT 1 FROM transfers t
+        JOIN accounts a ON a.id = t.from_account_id
+        WHERE a.owner = sqlc.arg(owner) AND t.to_account_id = sqlc.arg(to_account_id)
+    )
+    OR EXISTS (
+        SELECT 1 FROM escrows e
+        WHERE e.payer = sqlc.arg(owner) AND e.to_account_id = sqlc.arg(to_account_id)
+    )
+)::bool;
+
+-- name: CountSessionsFromClientIP :one
+-- Counts the sessions of a user created before a time, and those of them
+-- signed in from client_ip.
+SELECT
+    count(*) AS sessions,
+    count(*) FILTER (WHERE client_ip = sqlc.arg(client_ip)) AS from_client_ip
+FROM sessions
+WHERE username = sqlc.arg(username) AND created_at < sqlc.arg(created_before);
+
+-- name: CreateFraudDecision :one
+INSERT INTO fraud_decisions (
+    username,
+    from_account_id,
+    to_account_id,
+    amount,
+    currency,
+    client_ip,
+    score,
+    outcome,
+    hits,
+    transfer,
+    status,
+    kind,
+    payload
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13
+) RETURNING *;
+
+-- name: GetFraudDecision :one
+SELECT * FROM fraud_decisions WHERE id = $1 LIMIT 1;
+
+-- name: GetFraudDecisionForUpdate :one
+SELECT * FROM fraud_decisions WHERE id = $1 LIMIT 1 FOR NO KEY UPDATE;
+
+-- name: SetFraudDecisionTransfer :exec
+UPDATE fraud_decisions
+SET transfer_id = sqlc.arg(transfer_id)
+WHERE id = sqlc.arg(id);
+
+-- name: ReviewFraudDecision :one
+-- Moves a pending decision to approved or rejected.
+UPDATE fraud_decisions
+SET status = sqlc.arg(status),
+    transfer_id = sqlc.narg(transfer_id),
+    reviewed_by = sqlc.arg(reviewed_by)::varchar,
+    review_note = sqlc.arg(review_note),
+    reviewed_at = now()
+WHERE id = sqlc.arg(id) AND status = 'pending'
+RETURNING *;
+
+-- name: ListPendingFraudDecisions :many
+SELECT * FROM fraud_decisions
+WHERE status = 'pending' AND id > sqlc.arg(after_id)
+ORDER BY id
+LIMIT sqlc.arg(page_limit);
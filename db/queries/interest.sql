@@ -0,0 +1,73 @@
+-- name: ListInterestBearingAccounts :many
+-- Returns a page of the accounts in the daily balances of business_date
+-- whose product pays interest, or charges overdraft interest on an account
+-- that may be overdrawn, with their balance at the end of that day.
+SELECT
+    a.id,
+    a.currency,
+    p.annual_rate_ppm,
+    p.overdraft_rate_ppm,
+    b.balance AS closing_balance
+FROM daily_balances b
+JOIN accounts a ON a.id = b.account_id
+JOIN account_products p ON p.code = a.product
+WHERE b.business_date = sqlc.arg(business_date)
+    AND (p.annual_rate_ppm > 0
+        OR (p.overdraft_rate_ppm > 0 AND (a.overdraft_limit > 0 OR a.overdrawn_since IS NOT NULL)))
+    AND a.id > sqlc.arg(after_id)
+ORDER BY a.id
+LIMIT sqlc.arg(page_limit);
+
+-- name: CreateInterestAccrual :execrows
+-- Affects no row when the account already accrued for the business date.
+INSERT INTO interest_accruals (
+    account_id,
+    business_date,
+    closing_balance,
+    annual_rate_ppm,
+    day_count,
+    amount_micros
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+) ON CONFLICT (account_id, business_date) DO NOTHING;
+
+-- name: ListAccountsWithUncapitalizedInterest :many
+SELECT DISTINCT account_id FROM interest_accruals
+WHERE capitalization_id IS NULL AND business_date < sqlc.arg(before)
+ORDER BY account_id;
+
+-- name: SumUncapitalizedInterest :one
+SELECT COALESCE(SUM(amount_micros), 0)::bigint FROM interest_accruals
+WHERE account_id = sqlc.arg(account_id)
+    AND capitalization_id IS NULL
+    AND business_date < sqlc.arg(before);
+
+-- name: MarkInterestCapitalized :exec
+UPDATE interest_accruals
+SET capitalization_id = sqlc.arg(capitalization_id)
+WHERE account_id = sqlc.arg(account_id)
+    AND capitalization_id IS NULL
+    AND business_date < sqlc.arg(before);
+
+-- name: GetInterestCapitalization :one
+SELECT * FROM interest_capitalizations
+WHERE account_id = $1 AND period = $2
+LIMIT 1;
+
+-- name: GetLastInterestCapitalization :one
+SELECT * FROM interest_capitalizations
+WHERE account_id = $1
+ORDER BY period DESC
+LIMIT 1;
+
+-- name: CreateInterestCapitalization :one
+INSERT INTO interest_capitalizations (
+    account_id,
+    period,
+    accrued_micros,
+    amount,
+    carry_micros,
+    transfer_id
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+) RETURNING *;
@@ -0,0 +1,115 @@
+-- name: ListKycLevels :many
+SELECT * FROM kyc_levels
+ORDER BY level;
+
+-- name: GetKycLevel :one
+SELECT * FROM kyc_levels
+WHERE level = $1 LIMIT 1;
+
+-- name: GetUserKycLimits :one
+SELECT l.*
+FROM users u
+JOIN kyc_levels l ON l.level = u.kyc_level
+WHERE u.username = $1 LIMIT 1;
+
+-- name: ListKycLevelLimits :many
+SELECT * FROM kyc_level_limits
+ORDER BY level, currency;
+
+-- name: GetUserKycTransferLimits :one
+SELECT k.level, k.name, l.max_transfer_amount, l.daily_transfer_limit
+FROM users u
+JOIN kyc_levels k ON k.level = u.kyc_level
+LEFT JOIN kyc_level_limits l ON l.level = k.level AND l.currency = sqlc.arg(currency)
+WHERE u.username = sqlc.arg(username) LIMIT 1;
+
+-- name: CountAccountsByOwner :one
+SELECT count(*) FROM accounts
+WHERE owner = $1;
+
+-- name: SumTransfersFromOwnerSince :one
+SELECT COALESCE(sum(t.amount), 0)::bigint AS total
+FROM transfers t
+JOIN accounts fa ON fa.id = t.from_account_id
+WHERE fa.owner = sqlc.arg(owner)
+    AND fa.currency = sqlc.arg(currency)
+    AND t.kind = 'payment'
+    AND t.created_at >= sqlc.arg(since);
+
+-- name: SumTransfersFromOwnerSinceByCurrency :many
+SELECT fa.currency, COALESCE(sum(t.amount), 0)::bigint AS total
+FROM transfers t
+JOIN accounts fa ON fa.id = t.from_account_id
+WHERE fa.owner = sqlc.arg(owner)
+    AND t.kind = 'payment'
+    AND t.created_at >= sqlc.arg(since)
+GROUP BY fa.currency
+ORDER BY fa.currency;
+
+-- name: CreateKycSubmission :one
+INSERT INTO kyc_submissions (
+    username,
+    level
+) VALUES (
+    $1, $2
+) RETURNING *;
+
+-- name: CreateKycDocument :one
+INSERT INTO kyc_documents (
+    submission_id,
+    kind,
+    file_name,
+    content_type,
+    size,
+    sha256,
+    storage_key
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7
+) RETURNING *;
+
+-- name: GetKycSubmission :one
+SELECT * FROM kyc_submissions
+WHERE id = $1 LIMIT 1;
+
+-- name: GetKycSubmissionForUpdate :one
+SELECT * FROM kyc_submissions
+WHERE id = $1 LIMIT 1
+FOR NO KEY UPDATE;
+
+-- name: ListUserKycSubmissions :many
+SELECT * FROM kyc_submissions
+WHERE username = $1
+ORDER BY id DESC;
+
+-- name: ListPendingKycSubmissions :many
+SELECT * FROM kyc_submissions
+WHERE status = 'pending' AND id > sqlc.arg(after_id)
+ORDER BY id
+LIMIT sqlc.arg(page_limit);
+
+-- name: ListKycDocuments :many
+SELECT * FROM kyc_documents
+WHERE submission_id = $1
+ORDER BY id;
+
+-- name: GetKycDocument :one
+SELECT * FROM kyc_documents
+WHERE id = sqlc.arg(id) AND submission_id = sqlc.arg(submission_id)
+LIMIT 1;
+
+-- name: ReviewKycSubmission :one
+-- Moves a pending submission to approved or rejected.
+UPDATE kyc_submissions
+SET status = sqlc.arg(status),
+    reason = sqlc.arg(reason),
+    reviewed_by = sqlc.arg(reviewed_by)::varchar,
+    reviewed_at = now()
+WHERE id = sqlc.arg(id) AND status = 'pending'
+RETURNING *;
+
+-- name: RaiseUserKycLevel :one
+-- Raises the level of a user; a level is never lowered by an approval.
+UPDATE users
+SET kyc_level = GREATEST(kyc_level, sqlc.arg(level))
+WHERE username = sqlc.arg(username)
+RETURNING *;
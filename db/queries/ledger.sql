@@ -0,0 +1,35 @@
+-- name: ListGLAccounts :many
+SELECT * FROM gl_accounts
+ORDER BY code;
+
+-- name: SumGLBalances :many
+-- Sums the entries created before the given time per currency and general
+-- ledger account, positive for credits. Accounts with a negative balance are
+-- reported in the overdraft account of their product. Entries that are not
+-- part of a transfer have no offsetting entry, so they are offset in the
+-- suspense account to keep the ledger balanced.
+WITH balances AS (
+  SELECT e.account_id, SUM(e.amount)::bigint AS balance
+  FROM entries e
+  WHERE e.created_at < sqlc.arg(before)
+  GROUP BY e.account_id
+), unmatched AS (
+  SELECT a.currency, SUM(e.amount)::bigint AS balance
+  FROM entries e
+  JOIN accounts a ON a.id = e.account_id
+  WHERE e.created_at < sqlc.arg(before) AND e.transfer_id IS NULL
+  GROUP BY a.currency
+)
+SELECT a.currency::varchar AS currency,
+  COALESCE(CASE WHEN b.balance < 0 THEN p.overdraft_gl_account END, p.gl_account)::varchar AS gl_account,
+  SUM(b.balance)::bigint AS balance
+FROM balances b
+JOIN accounts a ON a.id = b.account_id
+JOIN account_products p ON p.code = a.product
+WHERE sqlc.narg(currency)::varchar IS NULL OR a.currency = sqlc.narg(currency)
+GROUP BY 1, 2
+UNION ALL
+SELECT u.currency::varchar, sqlc.arg(suspense_account)::varchar, (-u.balance)::bigint
+FROM unmatched u
+WHERE u.balance <> 0 AND (sqlc.narg(currency)::varchar IS NULL OR u.currency = sqlc.narg(currency))
+ORDER BY 1, 2;
@@ -0,0 +1,15 @@
+-- name: CreateNotification :one
+INSERT INTO notifications (
+    username,
+    kind,
+    message
+) VALUES (
+    $1, $2, $3
+) RETURNING *;
+
+-- name: ListNotificationsBefore :many
+SELECT * FROM notifications
+WHERE username = sqlc.arg(username)
+    AND (sqlc.arg(before_id)::bigint = 0 OR id < sqlc.arg(before_id)::bigint)
+ORDER BY id DESC
+LIMIT sqlc.arg(page_limit);
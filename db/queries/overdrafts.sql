@@ -0,0 +1,69 @@
+-- name: SetAccountOverdraftLimit :one
+UPDATE accounts
+SET overdraft_limit = sqlc.arg(overdraft_limit)
+WHERE id = sqlc.arg(id)
+RETURNING *;
+
+-- name: SetAccountOverdrawnSince :exec
+UPDATE accounts
+SET overdrawn_since = sqlc.narg(overdrawn_since)
+WHERE id = sqlc.arg(id);
+
+-- name: CreateOverdraftApproval :one
+INSERT INTO overdraft_approvals (account_id, overdraft_limit, approved_by, reason)
+VALUES ($1, $2, $3, $4)
+RETURNING *;
+
+-- name: ListOverdraftApprovals :many
+SELECT * FROM overdraft_approvals
+WHERE account_id = $1
+ORDER BY created_at DESC, id DESC;
+
+-- name: CreateOverdraftEvent :one
+INSERT INTO overdraft_events (account_id, kind, balance)
+VALUES ($1, $2, $3)
+RETURNING *;
+
+-- name: ClaimOverdraftEvents :many
+-- Marks a page of undelivered events notified and returns them. Events
+-- claimed by a concurrent worker are skipped, so each is delivered once.
+WITH claimed AS (
+    UPDATE overdraft_events
+    SET notified_at = now()
+    WHERE id IN (
+        SELECT id FROM overdraft_events
+        WHERE notified_at IS NULL
+        ORDER BY id
+        LIMIT sqlc.arg(page_limit)
+        FOR UPDATE SKIP LOCKED
+    )
+    RETURNING id, account_id, kind, balance, created_at
+)
+SELECT c.id, c.account_id, c.kind, c.balance, c.created_at, a.owner, a.currency, a.account_number, a.overdraft_limit
+FROM claimed c
+JOIN accounts a ON a.id = c.account_id
+ORDER BY c.id;
+
+-- name: ListOverdrawnAccounts :many
+-- Like SummarizeOverdrafts, only lists customer accounts.
+SELECT * FROM accounts
+WHERE balance < 0 AND overdrawn_since IS NOT NULL
+    AND (sqlc.narg(currency)::varchar IS NULL OR currency = sqlc.narg(currency)::varchar)
+    AND id > sqlc.arg(after_id)
+ORDER BY id
+LIMIT sqlc.arg(page_limit);
+
+-- name: SummarizeOverdrafts :many
+-- Totals of the customer accounts in overdraft per currency. The bank's own
+-- accounts, which have no overdraft limit and are never marked overdrawn,
+-- are left out.
+SELECT
+    currency,
+    count(*)::bigint AS accounts,
+    (-SUM(balance))::bigint AS overdrawn,
+    SUM(overdraft_limit)::bigint AS overdraft_limits,
+    count(*) FILTER (WHERE -balance > overdraft_limit)::bigint AS over_limit
+FROM accounts
+WHERE balance < 0 AND overdrawn_since IS NOT NULL
+GROUP BY currency
+ORDER BY currency;
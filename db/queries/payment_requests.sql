@@ -0,0 +1,36 @@
+-- name: CreatePaymentRequest :one
+INSERT INTO payment_requests (
+    requester,
+    payer,
+    to_account_id,
+    amount,
+    currency,
+    memo,
+    expires_at
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7
+) RETURNING *;
+
+-- name: GetPaymentRequest :one
+SELECT * FROM payment_requests WHERE id = $1 LIMIT 1;
+
+-- name: GetPaymentRequestForUpdate :one
+SELECT * FROM payment_requests WHERE id = $1 LIMIT 1 FOR NO KEY UPDATE;
+
+-- name: UpdatePaymentRequestStatus :one
+UPDATE payment_requests
+SET status = sqlc.arg(to_status),
+    payer = COALESCE(sqlc.narg(payer), payer),
+    transfer_id = COALESCE(sqlc.narg(transfer_id), transfer_id),
+    updated_at = now()
+WHERE id = sqlc.arg(id) AND status = sqlc.arg(from_status)
+RETURNING *;
+
+-- name: ListPaymentRequestsBefore :many
+SELECT * FROM payment_requests
+WHERE ((sqlc.arg(direction)::text = 'outgoing' AND requester = sqlc.arg(username))
+        OR (sqlc.arg(direction)::text = 'incoming' AND payer = sqlc.arg(username)))
+    AND (sqlc.narg(status)::text IS NULL OR status = sqlc.narg(status)::text)
+    AND (sqlc.arg(before_id)::bigint = 0 OR id < sqlc.arg(before_id)::bigint)
+ORDER BY id DESC
+LIMIT sqlc.arg(page_limit);
@@ -0,0 +1,15 @@
+-- name: CreateRecipientLookup :one
+INSERT INTO recipient_lookups (
+    username,
+    identifier,
+    purpose,
+    found,
+    client_ip
+) VALUES (
+    $1, $2, $3, $4, $5
+) RETURNING *;
+
+-- name: CountRecipientLookupsSince :one
+SELECT count(*) FROM recipient_lookups
+WHERE username = sqlc.arg(username)
+    AND created_at >= sqlc.arg(since);
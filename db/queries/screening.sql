@@ -0,0 +1,44 @@
+-- name: CreateScreeningMatch :one
+INSERT INTO screening_matches (
+    subject,
+    name,
+    username,
+    account_id,
+    list,
+    entry_id,
+    entry_name,
+    score,
+    action
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9
+) RETURNING *;
+
+-- name: ListScreeningMatches :many
+SELECT * FROM screening_matches
+WHERE id > sqlc.arg(after_id)
+  AND (sqlc.narg(action)::varchar IS NULL OR action = sqlc.narg(action))
+ORDER BY id
+LIMIT sqlc.arg(page_limit);
+
+-- name: CreateScreeningWhitelistEntry :one
+INSERT INTO screening_whitelist (
+    name,
+    entry_id,
+    reason,
+    created_by
+) VALUES (
+    $1, $2, $3, $4
+) RETURNING *;
+
+-- name: ListScreeningWhitelist :many
+SELECT * FROM screening_whitelist
+ORDER BY id;
+
+-- name: ListWhitelistedEntryIDs :many
+SELECT entry_id FROM screening_whitelist
+WHERE name = $1;
+
+-- name: DeleteScreeningWhitelistEntry :one
+DELETE FROM screening_whitelist
+WHERE id = $1
+RETURNING *;
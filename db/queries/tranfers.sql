@@ -1,6 +1,8 @@
 -- name: CreateTransfer :one
-INSERT INTO transfers (from_account_id, to_account_id, amount) 
-VALUES ($1, $2, $3)
+-- Posts to business_date, or to the open business day when it is null.
+INSERT INTO transfers (from_account_id, to_account_id, amount, fee, description, external_reference, merchant_category, kind, business_date)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8,
+    COALESCE(sqlc.narg(business_date)::date, (SELECT d.business_date FROM business_days d WHERE d.status = 'open')))
 RETURNING *;
 
 -- name: GetTransfer :one
@@ -12,3 +14,68 @@ WHERE from_account_id = $1 OR to_account_id = $2
 ORDER BY id
 LIMIT $3 OFFSET $4;
 
+-- name: ListTransfersDesc :many
+SELECT sqlc.embed(t), fa.currency
+FROM transfers t
+JOIN accounts fa ON fa.id = t.from_account_id
+JOIN accounts ta ON ta.id = t.to_account_id
+WHERE (fa.owner = sqlc.arg(owner) OR ta.owner = sqlc.arg(owner))
+    AND (sqlc.narg(account_id)::bigint IS NULL
+        OR t.from_account_id = sqlc.narg(account_id)::bigint
+        OR t.to_account_id = sqlc.narg(account_id)::bigint)
+    AND (sqlc.narg(created_from)::timestamptz IS NULL OR t.created_at >= sqlc.narg(created_from)::timestamptz)
+    AND (sqlc.narg(created_to)::timestamptz IS NULL OR t.created_at < sqlc.narg(created_to)::timestamptz)
+    AND (sqlc.narg(min_amount)::bigint IS NULL OR t.amount >= sqlc.narg(min_amount)::bigint)
+    AND (sqlc.narg(max_amount)::bigint IS NULL OR t.amount <= sqlc.narg(max_amount)::bigint)
+    AND (sqlc.narg(direction)::text IS NULL
+        OR (sqlc.narg(direction)::text = 'outgoing' AND fa.owner = sqlc.arg(owner)
+            AND (sqlc.narg(account_id)::bigint IS NULL OR t.from_account_id = sqlc.narg(account_id)::bigint))
+        OR (sqlc.narg(direction)::text = 'incoming' AND ta.owner = sqlc.arg(owner)
+            AND (sqlc.narg(account_id)::bigint IS NULL OR t.to_account_id = sqlc.narg(account_id)::bigint)))
+    AND (sqlc.narg(counterparty_account_id)::bigint IS NULL
+        OR (fa.owner = sqlc.arg(owner) AND t.to_account_id = sqlc.narg(counterparty_account_id)::bigint)
+        OR (ta.owner = sqlc.arg(owner) AND t.from_account_id = sqlc.narg(counterparty_account_id)::bigint))
+    AND (sqlc.narg(currency)::varchar IS NULL OR fa.currency = sqlc.narg(currency)::varchar)
+    AND (sqlc.narg(query)::text IS NULL
+        OR to_tsvector('simple', t.description || ' ' || t.external_reference) @@ websearch_to_tsquery('simple', sqlc.narg(query)::text))
+    AND (sqlc.narg(after_created_at)::timestamptz IS NULL
+        OR (t.created_at, t.id) < (sqlc.narg(after_created_at)::timestamptz, sqlc.narg(after_id)::bigint))
+ORDER BY t.created_at DESC, t.id DESC
+LIMIT sqlc.arg(page_limit);
+
+-- name: ListTransfersAsc :many
+SELECT sqlc.embed(t), fa.currency
+FROM transfers t
+JOIN accounts fa ON fa.id = t.from_account_id
+JOIN accounts ta ON ta.id = t.to_account_id
+WHERE (fa.owner = sqlc.arg(owner) OR ta.owner = sqlc.arg(owner))
+    AND (sqlc.narg(account_id)::bigint IS NULL
+        OR t.from_account_id = sqlc.narg(account_id)::bigint
+        OR t.to_account_id = sqlc.narg(account_id)::bigint)
+    AND (sqlc.narg(created_from)::timestamptz IS NULL OR t.created_at >= sqlc.narg(created_from)::timestamptz)
+    AND (sqlc.narg(created_to)::timestamptz IS NULL OR t.created_at < sqlc.narg(created_to)::timestamptz)
+    AND (sqlc.narg(min_amount)::bigint IS NULL OR t.amount >= sqlc.narg(min_amount)::bigint)
+    AND (sqlc.narg(max_amount)::bigint IS NULL OR t.amount <= sqlc.narg(max_amount)::bigint)
+    AND (sqlc.narg(direction)::text IS NULL
+        OR (sqlc.narg(direction)::text = 'outgoing' AND fa.owner = sqlc.arg(owner)
+            AND (sqlc.narg(account_id)::bigint IS NULL OR t.from_account_id = sqlc.narg(account_id)::bigint))
+        OR (sqlc.narg(direction)::text = 'incoming' AND ta.owner = sqlc.arg(owner)
+            AND (sqlc.narg(account_id)::bigint IS NULL OR t.to_account_id = sqlc.narg(account_id)::bigint)))
+    AND (sqlc.narg(counterparty_account_id)::bigint IS NULL
+        OR (fa.owner = sqlc.arg(owner) AND t.to_account_id = sqlc.narg(counterparty_account_id)::bigint)
+        OR (ta.owner = sqlc.arg(owner) AND t.from_account_id = sqlc.narg(counterparty_account_id)::bigint))
+    AND (sqlc.narg(currency)::varchar IS NULL OR fa.currency = sqlc.narg(currency)::varchar)
+    AND (sqlc.narg(query)::text IS NULL
+        OR to_tsvector('simple', t.description || ' ' || t.external_reference) @@ websearch_to_tsquery('simple', sqlc.narg(query)::text))
+    AND (sqlc.narg(after_created_at)::timestamptz IS NULL
+        OR (t.created_at, t.id) > (sqlc.narg(after_created_at)::timestamptz, sqlc.narg(after_id)::bigint))
+ORDER BY t.created_at ASC, t.id ASC
+LIMIT sqlc.arg(page_limit);
+
+-- name: SumTransfersToAccountSince :one
+SELECT COALESCE(sum(t.amount), 0)::bigint AS total
+FROM transfers t
+JOIN accounts fa ON fa.id = t.from_account_id
+WHERE fa.owner = sqlc.arg(owner)
+    AND t.to_account_id = sqlc.arg(to_account_id)
+    AND t.created_at >= sqlc.arg(since);
@@ -0,0 +1,103 @@
+-- name: CreateTransferBatch :one
+INSERT INTO transfer_batches (
+    owner,
+    from_account_id,
+    currency,
+    mode,
+    total_rows,
+    total_amount,
+    client_ip
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7
+) RETURNING *;
+
+-- name: CreateTransferBatchItem :one
+INSERT INTO transfer_batch_items (
+    batch_id,
+    row_number,
+    to_account_id,
+    amount,
+    reference
+) VALUES (
+    $1, $2, $3, $4, $5
+) RETURNING *;
+
+-- name: GetTransferBatch :one
+SELECT * FROM transfer_batches WHERE id = $1 LIMIT 1;
+
+-- name: ListTransferBatchesBefore :many
+SELECT * FROM transfer_batches
+WHERE owner = sqlc.arg(owner)
+    AND (sqlc.arg(before_id)::bigint = 0 OR id < sqlc.arg(before_id)::bigint)
+ORDER BY id DESC
+LIMIT sqlc.arg(page_limit);
+
+-- name: ClaimTransferBatch :one
+-- Claims the oldest pending batch, or a processing batch whose worker has not
+-- reported progress since stale_before, for the calling worker.
+UPDATE transfer_batches
+SET status = 'processing',
+    updated_at = now()
+WHERE id = (
+    SELECT b.id FROM transfer_batches b
+    WHERE b.status = 'pending'
+        OR (b.status = 'processing' AND b.updated_at < sqlc.arg(stale_before))
+    ORDER BY b.id
+    LIMIT 1
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING *;
+
+-- name: UpdateTransferBatchProgress :one
+UPDATE transfer_batches
+SET processed_rows = (
+        SELECT count(*) FROM transfer_batch_items i
+        WHERE i.batch_id = sqlc.arg(id) AND i.status <> 'pending'),
+    succeeded_rows = (
+        SELECT count(*) FROM transfer_batch_items i
+        WHERE i.batch_id = sqlc.arg(id) AND i.status = 'succeeded'),
+    failed_rows = (
+        SELECT count(*) FROM transfer_batch_items i
+        WHERE i.batch_id = sqlc.arg(id) AND i.status = 'failed'),
+    held_rows = (
+        SELECT count(*) FROM transfer_batch_items i
+        WHERE i.batch_id = sqlc.arg(id) AND i.status = 'held'),
+    updated_at = now()
+WHERE id = sqlc.arg(id)
+RETURNING *;
+
+-- name: FinishTransferBatch :one
+UPDATE transfer_batches
+SET status = sqlc.arg(status),
+    error = sqlc.arg(error),
+    completed_at = now(),
+    updated_at = now()
+WHERE id = sqlc.arg(id) AND status = 'processing'
+RETURNING *;
+
+-- name: ListTransferBatchItems :many
+SELECT * FROM transfer_batch_items
+WHERE batch_id = $1
+ORDER BY row_number;
+
+-- name: ListPendingTransferBatchItems :many
+SELECT * FROM transfer_batch_items
+WHERE batch_id = $1 AND status = 'pending'
+ORDER BY row_number;
+
+-- name: UpdateTransferBatchItem :one
+-- Settles a pending row, or a row held for fraud review.
+UPDATE transfer_batch_items
+SET status = sqlc.arg(status),
+    error = sqlc.arg(error),
+    transfer_id = sqlc.narg(transfer_id),
+    updated_at = now()
+WHERE id = sqlc.arg(id) AND status IN ('pending', 'held')
+RETURNING *;
+
+-- name: FailPendingTransferBatchItems :exec
+UPDATE transfer_batch_items
+SET status = 'failed',
+    error = sqlc.arg(error),
+    updated_at = now()
+WHERE batch_id = sqlc.arg(batch_id) AND status = 'pending';
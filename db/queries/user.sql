@@ -12,13 +12,27 @@ INSERT INTO users (
 SELECT * FROM users 
 WHERE username = $1 LIMIT 1;
 
+-- name: GetUserForUpdate :one
+SELECT * FROM users
+WHERE username = $1 LIMIT 1
+FOR NO KEY UPDATE;
+
 -- name: UpdateUser :one
 UPDATE users
 SET 
     hashed_password = COALESCE(sqlc.narg(hashed_password), hashed_password),
     password_changed_at = COALESCE(sqlc.narg(password_changed_at), password_changed_at),
     full_name = COALESCE(sqlc.narg(full_name), full_name),
-    email = COALESCE(sqlc.narg(email), email)
+    email = COALESCE(sqlc.narg(email), email),
+    is_email_verified = CASE
+        WHEN sqlc.narg(email)::varchar IS NOT NULL AND sqlc.narg(email)::varchar <> email THEN false
+        ELSE is_email_verified
+    END
 WHERE
     username = sqlc.arg(username)
-RETURNING *;
\ No newline at end of file
+RETURNING *;
+
+-- name: GetUserByVerifiedEmail :one
+SELECT * FROM users
+WHERE lower(email) = lower(sqlc.arg(email)) AND is_email_verified
+LIMIT 1;
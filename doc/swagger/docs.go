@@ -26,24 +26,18 @@ const docTemplate = `{
     "paths": {
         "/accounts/{id}": {
             "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Get account detail by account ID. Only the account owner can access this resource.",
+                "description": "Get account detail by internal account ID or external account number. Only the account owner can access this resource.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "accounts"
                 ],
-                "summary": "Get account by ID",
+                "summary": "Get account by ID or account number",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "format": "int64",
-                        "description": "Account ID (must be \u003e= 1)",
+                        "type": "string",
+                        "description": "Account ID (must be \u003e= 1) or account number",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -59,69 +53,5274 @@ const docTemplate = `{
                     "400": {
                         "description": "Invalid account ID",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/domain.Problem"
                         }
                     },
                     "401": {
-                        "description": "Unauthorized or account does not belong to user",
+                        "description": "Unauthenticated",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Account does not belong to user",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/domain.Problem"
                         }
                     },
                     "404": {
                         "description": "Account not found",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/domain.Problem"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/domain.Problem"
                         }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
             }
-        }
-    },
-    "definitions": {
-        "db.Account": {
-            "type": "object",
-            "properties": {
-                "balance": {
-                    "type": "integer"
+        },
+        "/v1/account-products": {
+            "get": {
+                "description": "List the products an account can be opened with and their interest rates. Interest accrues daily on the balance at the end of each day (UTC) and is credited monthly.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "accounts"
+                ],
+                "summary": "List account products",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ListAccountProductsRes"
+                        }
+                    }
                 },
-                "created_at": {
-                    "type": "string"
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/v1/accounts/{id}/balance": {
+            "get": {
+                "description": "Get the balance of an account after every entry created before a time, now by default.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "accounts"
+                ],
+                "summary": "Get the balance of an account at a time",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Account ID or account number",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "RFC 3339 time",
+                        "name": "at",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.AccountBalanceRes"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameters",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Not the owner of the account",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Problem"
+                        }
+                    }
                 },
-                "currency": {
-                    "type": "string"
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/v1/accounts/{id}/balance-history": {
+            "get": {
+                "description": "Get the opening and closing balance, credits and debits of an account by UTC day, week (from Monday) or month, from the bucket \"from\" is in up to \"to\". Defaults to the last 30 days, 12 weeks or 12 months; at most 400 buckets.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "accounts"
+                ],
+                "summary": "Get the balance history of an account",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Account ID or account number",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "day, week or month",
+                        "name": "interval",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "RFC 3339 time",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "RFC 3339 time",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.BalanceHistoryRes"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameters",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Not the owner of the account",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Problem"
+                        }
+                    }
                 },
-                "id": {
-                    "type": "integer"
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/v1/admin/accounts/{id}/overdraft": {
+            "get": {
+                "description": "Get an account with the history of its overdraft approvals, newest first. Admins only.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Get the overdraft of an account",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Account ID or account number",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.GetOverdraftRes"
+                        }
+                    },
+                    "403": {
+                        "description": "Not an admin",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Account not found",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Problem"
+                        }
+                    }
                 },
-                "owner": {
-                    "type": "string"
-                }
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "put": {
+                "description": "Set how far below zero the balance of a customer account may go; 0 withdraws the overdraft. The approval is recorded with the admin and reason. Admins only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Approve an overdraft limit",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Account ID or account number",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Overdraft limit in minor units",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/rest.approveOverdraftDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/db.ApproveOverdraftTxResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameters",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Not an admin",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Account not found",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Problem"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
             }
         },
-        "rest.GetAccountRes": {
-            "type": "object",
-            "properties": {
-                "account": {
-                    "$ref": "#/definitions/db.Account"
+        "/v1/admin/currencies/{code}": {
+            "put": {
+                "description": "Add an ISO 4217 currency or rename, enable or disable an existing one. The exponent of an existing currency cannot change. Other instances pick the change up at their next refresh. Admins only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Add, enable or disable a currency",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ISO 4217 code",
+                        "name": "code",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Currency",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/rest.upsertCurrencyDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/db.Currency"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameters",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Not an admin",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Problem"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/v1/admin/disputes": {
+            "get": {
+                "description": "List disputes, oldest first, optionally by status and assignee. Admins only.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "List disputes to handle",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "open, under_review, won, lost or withdrawn",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Username of the assignee",
+                        "name": "assigned_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Return disputes after this ID",
+                        "name": "after_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Disputes per page, 1 to 100 (default 100)",
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ListDisputesRes"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameters",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Not an admin",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Problem"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/v1/admin/disputes/{id}": {
+            "get": {
+                "description": "Get any dispute with its evidence and history. Admins only.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Get a dispute to handle",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Dispute ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.DisputeCaseRes"
+                        }
+                    },
+                    "403": {
+                        "description": "Not an admin",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Problem"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/v1/admin/disputes/{id}/evidence/{evidence_id}": {
+            "get": {
+                "description": "Download an evidence file of a dispute. Admins only.",
+                "produces": [
+                    "application/pdf",
+                    "image/jpeg",
+                    "image/png"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Download a dispute evidence file",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Dispute ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Evidence ID",
+                        "name": "evidence_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "403": {
+                        "description": "Not an admin",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Problem"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/v1/admin/disputes/{id}/resolve": {
+            "post": {
+                "description": "Decide an open or under review dispute. A won dispute reverses the amount from the payee to the customer, or recovers the provisional credit from the payee; a lost one takes a provisional credit back from the customer. The customer is notified with the resolution. Admins only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Decide a dispute",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Dispute ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Outcome and resolution",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/rest.resolveDisputeDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.TransitionDisputeRes"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameters",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Not an admin",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Already decided",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Problem"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/v1/admin/disputes/{id}/review": {
+            "post": {
+                "description": "Take an open dispute under review and assign it to the authenticated admin. The customer is notified. Admins only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Take a dispute under review",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Dispute ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Note",
+                        "name": "body",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/rest.disputeNoteDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.TransitionDisputeRes"
+                        }
+                    },
+                    "403": {
+                        "description": "Not an admin",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Not open",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Problem"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/v1/admin/eod": {
+            "get": {
+                "description": "Get the open business day postings go to and the day that is closing, if any, with its end-of-day steps. Admins only.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Get the business date",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.EODStatusRes"
+                        }
+                    },
+                    "403": {
+                        "description": "Not an admin",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Problem"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/v1/admin/eod/close": {
+            "post": {
+                "description": "Close the open business day now, open the next one and run the end-of-day steps of the closed day. A failed step leaves the day closing with the error on the step; resume it once the cause is fixed. Admins only.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Close the business day",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.BusinessDayRes"
+                        }
+                    },
+                    "403": {
+                        "description": "Not an admin",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "An earlier day is still closing",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Problem"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/v1/admin/eod/days/{date}": {
+            "get": {
+                "description": "Get a business day with the status of its end-of-day steps. Admins only.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Get a business day",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Date as YYYY-MM-DD",
+                        "name": "date",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.BusinessDayRes"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameters",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Not an admin",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Problem"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/v1/admin/eod/resume": {
+            "post": {
+                "description": "Run the end-of-day steps of the closing business day again from the first one that has not succeeded. Admins only.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Resume the end of day",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.BusinessDayRes"
+                        }
+                    },
+                    "403": {
+                        "description": "Not an admin",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "No day is closing",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Problem"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/v1/admin/escrows": {
+            "get": {
+                "description": "List escrows, oldest first, optionally by status. Admins only.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "List escrows to handle",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "held, disputed, released or refunded",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Return escrows after this ID",
+                        "name": "after_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Escrows per page, 1 to 100 (default 100)",
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ListEscrowsRes"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameters",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Not an admin",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Problem"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/v1/admin/escrows/{id}/resolve": {
+            "post": {
+                "description": "Release a disputed escrow to its payee or refund it to its payer. The party paid is notified. Admins only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Decide a disputed escrow",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Escrow ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Outcome",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/rest.resolveEscrowDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.SettleEscrowRes"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameters",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Not an admin",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Not disputed",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Problem"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/v1/admin/fraud/decisions/{id}": {
+            "get": {
+                "description": "Get the risk assessment of a transfer and its review. Admins only.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Get a fraud decision",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Fraud decision ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.FraudDecisionRes"
+                        }
+                    },
+                    "403": {
+                        "description": "Not an admin",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Problem"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/v1/admin/fraud/decisions/{id}/approve": {
+            "post": {
+                "description": "Post a transfer the fraud rules held for review, paying the payment request, settling the batch row or funding the escrow it was held from. A transfer that fails, e.g. for insufficient funds or a payment request that is no longer pending, stays held. Admins only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Approve a held transfer",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Fraud decision ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Review note",
+                        "name": "body",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/rest.reviewFraudDecisionDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ApproveFraudDecisionRes"
+                        }
+                    },
+                    "403": {
+                        "description": "Not an admin",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Not held, or the transfer failed",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Problem"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/v1/admin/fraud/decisions/{id}/reject": {
+            "post": {
+                "description": "Refuse a transfer the fraud rules held for review; it is never posted, and the batch row it was held from fails. A held payment request stays pending. Admins only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Reject a held transfer",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Fraud decision ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Review note",
+                        "name": "body",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/rest.reviewFraudDecisionDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.FraudDecisionRes"
+                        }
+                    },
+                    "403": {
+                        "description": "Not an admin",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Not held",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Problem"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/v1/admin/fraud/reviews": {
+            "get": {
+                "description": "List the transfers the fraud rules held, oldest first, with the rules that matched. Admins only.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "List transfers held for review",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Return decisions after this ID",
+                        "name": "after_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Decisions per page, 1 to 100 (default 100)",
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ListFraudReviewsRes"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameters",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Not an admin",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Problem"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/v1/admin/kyc/submissions": {
+            "get": {
+                "description": "List the pending verification submissions, oldest first. Admins only.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "List verification submissions to review",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Return submissions after this ID",
+                        "name": "after_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Submissions per page, 1 to 100 (default 100)",
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ListKYCSubmissionsRes"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameters",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Not an admin",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Problem"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/v1/admin/kyc/submissions/{id}": {
+            "get": {
+                "description": "Get a verification submission with its documents. Admins only.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Get a verification submission to review",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Submission ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.KYCSubmissionRes"
+                        }
+                    },
+                    "403": {
+                        "description": "Not an admin",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Problem"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/v1/admin/kyc/submissions/{id}/approve": {
+            "post": {
+                "description": "Raise the user to the level of a pending submission. Admins only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Approve a verification submission",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Submission ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Reason",
+                        "name": "body",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/rest.reviewKYCSubmissionDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ReviewKYCSubmissionRes"
+                        }
+                    },
+                    "403": {
+                        "description": "Not an admin",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Not pending",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Problem"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/v1/admin/kyc/submissions/{id}/documents/{document_id}": {
+            "get": {
+                "description": "Download a document of a verification submission. Admins only.",
+                "produces": [
+                    "application/pdf",
+                    "image/jpeg",
+                    "image/png"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Download a verification document",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Submission ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Document ID",
+                        "name": "document_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "403": {
+                        "description": "Not an admin",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Problem"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/v1/admin/kyc/submissions/{id}/reject": {
+            "post": {
+                "description": "Refuse a pending submission with a reason, which the user is notified of. Admins only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Reject a verification submission",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Submission ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Reason",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/rest.reviewKYCSubmissionDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ReviewKYCSubmissionRes"
+                        }
+                    },
+                    "400": {
+                        "description": "No reason",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Not an admin",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Not pending",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Problem"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/v1/admin/ledger/accounts": {
+            "get": {
+                "description": "List the general ledger accounts the entries are posted to. Admins only.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Get the chart of accounts",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ListGLAccountsRes"
+                        }
+                    },
+                    "403": {
+                        "description": "Not an admin",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Problem"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/v1/admin/ledger/balance-sheet": {
+            "get": {
+                "description": "Get assets, liabilities and equity at the end of a date (UTC), per currency. Revenue less expenses to date is reported as retained earnings. Admins only.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Get the balance sheet",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Date as YYYY-MM-DD, today by default",
+                        "name": "date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only this currency",
+                        "name": "currency",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.BalanceSheetRes"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameters",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Not an admin",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Problem"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/v1/admin/ledger/profit-and-loss": {
+            "get": {
+                "description": "Get the revenue and expenses posted from the start of from to the end of to (UTC), per currency. Admins only.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Get the profit and loss statement",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "First date as YYYY-MM-DD",
+                        "name": "from",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last date as YYYY-MM-DD, today by default",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only this currency",
+                        "name": "currency",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ProfitAndLossRes"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameters",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Not an admin",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Problem"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/v1/admin/ledger/trial-balance": {
+            "get": {
+                "description": "Get the debit or credit balance of every general ledger account at the end of a date (UTC), per currency. Admins only.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Get the trial balance",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Date as YYYY-MM-DD, today by default",
+                        "name": "date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only this currency",
+                        "name": "currency",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.TrialBalanceRes"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameters",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Not an admin",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Problem"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/v1/admin/overdrafts": {
+            "get": {
+                "description": "List the customer accounts with a negative balance by ID, with the number of accounts, amount overdrawn, limits and accounts over their limit per currency. Admins only.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Report the accounts in overdraft",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Only accounts in this currency",
+                        "name": "currency",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Return accounts after this ID",
+                        "name": "after_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Accounts per page, 1 to 100 (default 100)",
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ListOverdraftsRes"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameters",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Not an admin",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Problem"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/v1/admin/screening": {
+            "get": {
+                "description": "Get the sanctions list files loaded, their entries and when they were loaded. Admins only.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Get the sanctions lists loaded",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/screening.Status"
+                        }
+                    },
+                    "403": {
+                        "description": "Not an admin",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Problem"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/v1/admin/screening/matches": {
+            "get": {
+                "description": "List the signups and transfer counterparties that matched a sanctions list entry, oldest first. Admins only.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "List sanctions list matches",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "flag or block",
+                        "name": "action",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Return matches after this ID",
+                        "name": "after_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Matches per page, 1 to 100 (default 100)",
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ListScreeningMatchesRes"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameters",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Not an admin",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Problem"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/v1/admin/screening/reload": {
+            "post": {
+                "description": "Read the sanctions list files again without waiting for the worker to notice they changed. When a file cannot be read the lists loaded before stay in use. Admins only.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Reload the sanctions lists",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/screening.Status"
+                        }
+                    },
+                    "403": {
+                        "description": "Not an admin",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "A list file cannot be read",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Problem"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/v1/admin/screening/search": {
+            "get": {
+                "description": "Get the entries a name would be flagged or blocked for, whitelisted or not, best first. Nothing is stored. Admins only.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Search the sanctions lists",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Name",
+                        "name": "name",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.SearchScreeningListsRes"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameters",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Not an admin",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Problem"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/v1/admin/screening/whitelist": {
+            "get": {
+                "description": "List the names that are no longer matched against a sanctions list entry. Admins only.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "List whitelisted sanctions matches",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ListScreeningWhitelistRes"
+                        }
+                    },
+                    "403": {
+                        "description": "Not an admin",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Problem"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "post": {
+                "description": "Stop matching a name against a sanctions list entry, once the match was found to be a false positive. The name is stored normalized, so it also covers other accents, punctuation and word order. Admins only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Whitelist a sanctions match",
+                "parameters": [
+                    {
+                        "description": "Name and entry",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/rest.whitelistScreeningMatchDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/db.ScreeningWhitelist"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameters",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Not an admin",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Already whitelisted",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Problem"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/v1/admin/screening/whitelist/{id}": {
+            "delete": {
+                "description": "Match the name against the sanctions list entry again. Admins only.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Remove a whitelisted sanctions match",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Whitelist entry ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/db.ScreeningWhitelist"
+                        }
+                    },
+                    "403": {
+                        "description": "Not an admin",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Problem"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/v1/beneficiaries": {
+            "post": {
+                "description": "Save a payee by account number. Transfers to a new beneficiary are limited during its cooling-off period.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "beneficiaries"
+                ],
+                "summary": "Save a beneficiary",
+                "parameters": [
+                    {
+                        "description": "Beneficiary",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/rest.createBeneficiaryDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.BeneficiaryRes"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameters or account number",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Beneficiary already saved",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Problem"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/v1/categorization-rules": {
+            "get": {
+                "description": "List the authenticated user's rules in the order they are tried.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "categorization-rules"
+                ],
+                "summary": "List categorization rules",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ListCategorizationRulesRes"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "post": {
+                "description": "Entries of transfers posted afterwards take the category of the highest-priority rule matching their description (case-insensitive substring), merchant category and counterparty account.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "categorization-rules"
+                ],
+                "summary": "Create a categorization rule",
+                "parameters": [
+                    {
+                        "description": "Rule",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/rest.createCategorizationRuleDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.CategorizationRuleRes"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameters",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Counterparty account not found",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Problem"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/v1/categorization-rules/{id}": {
+            "delete": {
+                "description": "Entries already categorized by the rule keep their category.",
+                "tags": [
+                    "categorization-rules"
+                ],
+                "summary": "Delete a categorization rule",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Rule ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "403": {
+                        "description": "Rule belongs to another user",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Rule not found",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Problem"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/v1/currencies": {
+            "get": {
+                "description": "List the currencies accounts and transfers may use, with the number of decimal places of their minor unit.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "currencies"
+                ],
+                "summary": "List currencies",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ListCurrenciesRes"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthenticated",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Problem"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/v1/disputes": {
+            "get": {
+                "description": "List the disputes of the authenticated user, newest first.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "disputes"
+                ],
+                "summary": "List disputes",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ListDisputesRes"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "post": {
+                "description": "Dispute a transfer sent from an account of the authenticated user, within the dispute window. Send JSON, or a multipart form with up to 5 evidence files (PDF, JPEG or PNG). The amount defaults to the whole transfer. A provisional credit of the amount is given at once when asked for and the amount is within the limit; it is taken back if the dispute is lost or withdrawn. A transfer has at most one dispute that was not withdrawn.",
+                "consumes": [
+                    "application/json",
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "disputes"
+                ],
+                "summary": "Dispute a transfer",
+                "parameters": [
+                    {
+                        "description": "Dispute",
+                        "name": "body",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/rest.openDisputeDTO"
+                        }
+                    },
+                    {
+                        "type": "file",
+                        "description": "Evidence file",
+                        "name": "evidence",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/rest.OpenDisputeRes"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameters",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Not a transfer of the user",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Transfer not found",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "The transfer is already disputed",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "The transfer is too old to be disputed",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Problem"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/v1/disputes/{id}": {
+            "get": {
+                "description": "Get a dispute of the authenticated user with its evidence and history.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "disputes"
+                ],
+                "summary": "Get a dispute",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Dispute ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.DisputeCaseRes"
+                        }
+                    },
+                    "403": {
+                        "description": "Not a dispute of the user",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Problem"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/v1/disputes/{id}/evidence": {
+            "post": {
+                "description": "Add a note, a file (PDF, JPEG or PNG) or both to an open or under review dispute of the authenticated user. Send JSON for a note alone, or a multipart form.",
+                "consumes": [
+                    "application/json",
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "disputes"
+                ],
+                "summary": "Add evidence to a dispute",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Dispute ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Note",
+                        "name": "body",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/rest.disputeNoteDTO"
+                        }
+                    },
+                    {
+                        "type": "file",
+                        "description": "Evidence file",
+                        "name": "file",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/rest.DisputeEvidenceRes"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameters",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Not a dispute of the user",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "The dispute is decided",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Problem"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/v1/disputes/{id}/withdraw": {
+            "post": {
+                "description": "Drop an open or under review dispute of the authenticated user. A provisional credit is taken back.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "disputes"
+                ],
+                "summary": "Withdraw a dispute",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Dispute ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Note",
+                        "name": "body",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/rest.disputeNoteDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.TransitionDisputeRes"
+                        }
+                    },
+                    "403": {
+                        "description": "Not a dispute of the user",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "The dispute is decided",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Problem"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/v1/escrows": {
+            "get": {
+                "description": "List the escrows the authenticated user pays or is paid by, newest first.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "escrows"
+                ],
+                "summary": "List escrows",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ListEscrowsRes"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "post": {
+                "description": "Move an amount from an account of the authenticated user into the bank's escrow account for the owner of another customer's account. The payment goes through the same sanctions screening, KYC limits, fee and fraud rules as a transfer; a payment held for fraud review creates the escrow once an admin approves it. The payee is paid when the payer confirms, or at release_at unless the payer disputes first; the payer is refunded when the payee cancels. release_at defaults to ESCROW_DEFAULT_RELEASE_AFTER from now and is at most ESCROW_MAX_RELEASE_AFTER away.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "escrows"
+                ],
+                "summary": "Pay into escrow",
+                "parameters": [
+                    {
+                        "description": "Escrow",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/rest.createEscrowDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/rest.CreateEscrowRes"
+                        }
+                    },
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/rest.HeldEscrowRes"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameters",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Not an account of the user, or the payee is sanctioned",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Account not found",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Insufficient funds, currency mismatch, over the KYC limits or blocked by the fraud rules",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Problem"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/v1/escrows/{id}": {
+            "get": {
+                "description": "Get an escrow the authenticated user pays or is paid by.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "escrows"
+                ],
+                "summary": "Get an escrow",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Escrow ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.EscrowRes"
+                        }
+                    },
+                    "403": {
+                        "description": "Not an escrow of the user",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Problem"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/v1/escrows/{id}/cancel": {
+            "post": {
+                "description": "Refund a held or disputed escrow the authenticated user is paid by to its payer. The payer is notified.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "escrows"
+                ],
+                "summary": "Cancel an escrow",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Escrow ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Note",
+                        "name": "body",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/rest.escrowNoteDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.SettleEscrowRes"
+                        }
+                    },
+                    "403": {
+                        "description": "Not the payee",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Already released or refunded",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Problem"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/v1/escrows/{id}/confirm": {
+            "post": {
+                "description": "Release a held or disputed escrow the authenticated user pays to its payee. The payee is notified.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "escrows"
+                ],
+                "summary": "Confirm an escrow",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Escrow ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.SettleEscrowRes"
+                        }
+                    },
+                    "403": {
+                        "description": "Not the payer",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Already released or refunded",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Problem"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/v1/escrows/{id}/dispute": {
+            "post": {
+                "description": "Stop a held escrow the authenticated user pays from being released at release_at, until an admin releases or refunds it. The payee and admins are notified.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "escrows"
+                ],
+                "summary": "Dispute an escrow",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Escrow ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Reason",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/rest.disputeEscrowDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.SettleEscrowRes"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameters",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Not the payer",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Not held",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Problem"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/v1/kyc": {
+            "get": {
+                "description": "Get the verification level of the authenticated user and its limits, the accounts they have and what they sent since midnight UTC, their submissions, newest first, and every level.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "kyc"
+                ],
+                "summary": "Get the verification level of the user",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.KYCStatusRes"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/v1/kyc/submissions": {
+            "post": {
+                "description": "Send a multipart form with the level and a file for each document it requires: id_document for level 1, id_document and proof_of_address for level 2. Files are PDF, JPEG or PNG. A user has at most one submission pending.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "kyc"
+                ],
+                "summary": "Submit documents for a higher verification level",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Level requested",
+                        "name": "level",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Identity document",
+                        "name": "id_document",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "file",
+                        "description": "Proof of address",
+                        "name": "proof_of_address",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/rest.KYCSubmissionRes"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameters",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "A submission is already pending",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Problem"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/v1/kyc/submissions/{id}": {
+            "get": {
+                "description": "Get a submission of the authenticated user with its documents.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "kyc"
+                ],
+                "summary": "Get a verification submission",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Submission ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.KYCSubmissionRes"
+                        }
+                    },
+                    "403": {
+                        "description": "Not a submission of the user",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Problem"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/v1/payment-requests": {
+            "post": {
+                "description": "Ask a user for money, or leave payer empty to get a shareable signed link anyone can pay.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "payment-requests"
+                ],
+                "summary": "Request money",
+                "parameters": [
+                    {
+                        "description": "Payment request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/rest.createPaymentRequestDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.PaymentRequestRes"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameters",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Account does not belong to user",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Problem"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/v1/recipients/lookup": {
+            "get": {
+                "description": "Check that a username or verified email can receive transfers in a currency. Only the masked name of the recipient is returned, and lookups are audited and rate limited.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transfers"
+                ],
+                "summary": "Look up a transfer recipient",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Username or verified email",
+                        "name": "recipient",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Currency of the recipient account",
+                        "name": "currency",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.LookupRecipientRes"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameters",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthenticated",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Recipient not found",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Problem"
+                        }
+                    },
+                    "429": {
+                        "description": "Lookup limit reached",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Problem"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/v1/transfer-batches": {
+            "post": {
+                "description": "Send a JSON body, or a CSV file with Content-Type text/csv and from_account_id, currency and mode as query parameters. Every row is validated before the batch is queued; invalid rows are reported as rows[n] with n counted from 1.",
+                "consumes": [
+                    "application/json",
+                    "text/csv"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transfers"
+                ],
+                "summary": "Queue a batch of transfers",
+                "parameters": [
+                    {
+                        "description": "Transfer batch",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/rest.createTransferBatchDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/rest.TransferBatchRes"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid rows",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Account does not belong to user",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Insufficient funds for an all-or-nothing batch",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Problem"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/v1/transfers/quote": {
+            "get": {
+                "description": "Return the fee the authenticated user pays on top of a transfer of amount in currency, and its breakdown. The band of the currency's fee schedule containing the amount charges its flat fee plus its percentage of the whole amount, within the schedule's minimum and maximum. Fees are waived for premium users.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transfers"
+                ],
+                "summary": "Quote a transfer fee",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Amount to transfer: a whole number of minor units, or a decimal in major units such as 12.50",
+                        "name": "amount",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Currency of the transfer",
+                        "name": "currency",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.QuoteRes"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameters",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthenticated",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Problem"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        }
+    },
+    "definitions": {
+        "db.Account": {
+            "type": "object",
+            "properties": {
+                "account_number": {
+                    "type": "string"
+                },
+                "balance": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "overdraft_limit": {
+                    "description": "how far below zero the balance may go, approved by an admin",
+                    "type": "integer"
+                },
+                "overdrawn_since": {
+                    "description": "when the balance last went below zero within an overdraft limit, null when not overdrawn",
+                    "type": "string"
+                },
+                "owner": {
+                    "type": "string"
+                },
+                "product": {
+                    "type": "string"
+                }
+            }
+        },
+        "db.ApproveOverdraftTxResult": {
+            "type": "object",
+            "properties": {
+                "account": {
+                    "$ref": "#/definitions/db.Account"
+                },
+                "approval": {
+                    "$ref": "#/definitions/db.OverdraftApproval"
+                }
+            }
+        },
+        "db.Currency": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "enabled": {
+                    "type": "boolean"
+                },
+                "exponent": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "db.Entry": {
+            "type": "object",
+            "properties": {
+                "account_id": {
+                    "type": "integer"
+                },
+                "amount": {
+                    "description": "can be negative or positive",
+                    "type": "integer"
+                },
+                "business_date": {
+                    "description": "business day the entry was posted to",
+                    "type": "string"
+                },
+                "category": {
+                    "description": "set from the account owner's categorization rules",
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "external_reference": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "merchant_category": {
+                    "type": "string"
+                },
+                "transfer_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "db.GlAccount": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "db.OverdraftApproval": {
+            "type": "object",
+            "properties": {
+                "account_id": {
+                    "type": "integer"
+                },
+                "approved_by": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "overdraft_limit": {
+                    "type": "integer"
+                },
+                "reason": {
+                    "type": "string"
+                }
+            }
+        },
+        "db.ScreeningWhitelist": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "entry_id": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "description": "normalized name, words sorted",
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                }
+            }
+        },
+        "db.SummarizeOverdraftsRow": {
+            "type": "object",
+            "properties": {
+                "accounts": {
+                    "type": "integer"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "over_limit": {
+                    "type": "integer"
+                },
+                "overdraft_limits": {
+                    "type": "integer"
+                },
+                "overdrawn": {
+                    "type": "integer"
+                }
+            }
+        },
+        "db.Transfer": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "description": "must be positive",
+                    "type": "integer"
+                },
+                "business_date": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "external_reference": {
+                    "type": "string"
+                },
+                "fee": {
+                    "description": "paid by the sender to the fee revenue account on top of amount",
+                    "type": "integer"
+                },
+                "from_account_id": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "kind": {
+                    "description": "payment by a customer, internal posting from one of the bank's accounts, or forced posting the bank took from a customer account, such as a chargeback or overdraft interest",
+                    "type": "string"
+                },
+                "merchant_category": {
+                    "description": "ISO 18245 merchant category code",
+                    "type": "string"
+                },
+                "to_account_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "db.TransferTxResult": {
+            "type": "object",
+            "properties": {
+                "fee_entry": {
+                    "description": "FeeEntry debits the fee from the from account. It is nil when no fee\nwas charged.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/db.Entry"
+                        }
+                    ]
+                },
+                "from_account": {
+                    "$ref": "#/definitions/db.Account"
+                },
+                "from_entry": {
+                    "$ref": "#/definitions/db.Entry"
+                },
+                "to_account": {
+                    "$ref": "#/definitions/db.Account"
+                },
+                "to_entry": {
+                    "$ref": "#/definitions/db.Entry"
+                },
+                "transfer": {
+                    "$ref": "#/definitions/db.Transfer"
+                }
+            }
+        },
+        "domain.FieldViolation": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "field": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.Problem": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "detail": {
+                    "type": "string"
+                },
+                "instance": {
+                    "type": "string"
+                },
+                "invalid_params": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.FieldViolation"
+                    }
+                },
+                "request_id": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "integer"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "ledger.BalanceSheet": {
+            "type": "object",
+            "properties": {
+                "assets": {
+                    "$ref": "#/definitions/ledger.Section"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "equity": {
+                    "$ref": "#/definitions/ledger.Section"
+                },
+                "liabilities": {
+                    "$ref": "#/definitions/ledger.Section"
+                }
+            }
+        },
+        "ledger.Item": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer"
+                },
+                "code": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "ledger.Line": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "credit": {
+                    "type": "integer"
+                },
+                "debit": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "ledger.ProfitAndLoss": {
+            "type": "object",
+            "properties": {
+                "currency": {
+                    "type": "string"
+                },
+                "expenses": {
+                    "$ref": "#/definitions/ledger.Section"
+                },
+                "net_income": {
+                    "type": "integer"
+                },
+                "revenue": {
+                    "$ref": "#/definitions/ledger.Section"
+                }
+            }
+        },
+        "ledger.Section": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/ledger.Item"
+                    }
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "ledger.TrialBalance": {
+            "type": "object",
+            "properties": {
+                "currency": {
+                    "type": "string"
+                },
+                "lines": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/ledger.Line"
+                    }
+                },
+                "total_credit": {
+                    "type": "integer"
+                },
+                "total_debit": {
+                    "type": "integer"
+                }
+            }
+        },
+        "rest.AccountBalanceRes": {
+            "type": "object",
+            "properties": {
+                "account_id": {
+                    "type": "integer"
+                },
+                "at": {
+                    "type": "string"
+                },
+                "balance": {
+                    "type": "integer"
+                },
+                "balance_decimal": {
+                    "type": "string"
+                },
+                "currency": {
+                    "type": "string"
+                }
+            }
+        },
+        "rest.AccountProductRes": {
+            "type": "object",
+            "properties": {
+                "annual_rate_ppm": {
+                    "description": "AnnualRate is the nominal annual interest rate in parts per million.",
+                    "type": "integer"
+                },
+                "code": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "overdraft_rate_ppm": {
+                    "description": "OverdraftRate is the annual rate charged on overdrawn balances in\nparts per million.",
+                    "type": "integer"
+                }
+            }
+        },
+        "rest.ApproveFraudDecisionRes": {
+            "type": "object",
+            "properties": {
+                "escrow": {
+                    "description": "Escrow is the escrow the transfer funded, if any.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/rest.EscrowRes"
+                        }
+                    ]
+                },
+                "fee_entry": {
+                    "description": "FeeEntry debits the fee from the from account. It is nil when no fee\nwas charged.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/db.Entry"
+                        }
+                    ]
+                },
+                "fraud_decision": {
+                    "$ref": "#/definitions/rest.FraudDecisionRes"
+                },
+                "from_account": {
+                    "$ref": "#/definitions/db.Account"
+                },
+                "from_entry": {
+                    "$ref": "#/definitions/db.Entry"
+                },
+                "payment_request": {
+                    "description": "PaymentRequest is the request the transfer paid, if any.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/rest.PaymentRequestRes"
+                        }
+                    ]
+                },
+                "to_account": {
+                    "$ref": "#/definitions/db.Account"
+                },
+                "to_entry": {
+                    "$ref": "#/definitions/db.Entry"
+                },
+                "transfer": {
+                    "$ref": "#/definitions/db.Transfer"
+                },
+                "transfer_batch_item": {
+                    "description": "TransferBatchItem is the batch row the transfer posted, if any.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/rest.TransferBatchItemRes"
+                        }
+                    ]
+                }
+            }
+        },
+        "rest.BalanceHistoryRes": {
+            "type": "object",
+            "properties": {
+                "account_id": {
+                    "type": "integer"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "interval": {
+                    "type": "string"
+                },
+                "points": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/rest.BalancePointRes"
+                    }
+                }
+            }
+        },
+        "rest.BalancePointRes": {
+            "type": "object",
+            "properties": {
+                "closing": {
+                    "type": "integer"
+                },
+                "closing_decimal": {
+                    "type": "string"
+                },
+                "credits": {
+                    "type": "integer"
+                },
+                "debits": {
+                    "type": "integer"
+                },
+                "end": {
+                    "type": "string"
+                },
+                "opening": {
+                    "type": "integer"
+                },
+                "start": {
+                    "type": "string"
+                }
+            }
+        },
+        "rest.BalanceSheetRes": {
+            "type": "object",
+            "properties": {
+                "balance_sheets": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/ledger.BalanceSheet"
+                    }
+                },
+                "date": {
+                    "type": "string"
+                }
+            }
+        },
+        "rest.BeneficiaryRes": {
+            "type": "object",
+            "properties": {
+                "account_number": {
+                    "type": "string"
+                },
+                "cooling_off_until": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "is_verified": {
+                    "type": "boolean"
+                },
+                "nickname": {
+                    "type": "string"
+                }
+            }
+        },
+        "rest.BusinessDayRes": {
+            "type": "object",
+            "properties": {
+                "business_date": {
+                    "type": "string"
+                },
+                "closed_at": {
+                    "type": "string"
+                },
+                "closing_at": {
+                    "type": "string"
+                },
+                "opened_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "steps": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/rest.EODStepRes"
+                    }
+                }
+            }
+        },
+        "rest.CategorizationRuleRes": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "type": "string"
+                },
+                "counterparty_account_id": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "description_contains": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "merchant_category": {
+                    "type": "string"
+                },
+                "priority": {
+                    "type": "integer"
+                }
+            }
+        },
+        "rest.CreateEscrowRes": {
+            "type": "object",
+            "properties": {
+                "escrow": {
+                    "$ref": "#/definitions/rest.EscrowRes"
+                },
+                "fee_details": {
+                    "$ref": "#/definitions/rest.QuoteRes"
+                },
+                "fraud_decision_id": {
+                    "type": "integer"
+                },
+                "funding": {
+                    "description": "Funding moves the amount from the payer into the escrow account.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/db.TransferTxResult"
+                        }
+                    ]
+                }
+            }
+        },
+        "rest.CurrencyRes": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "exponent": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "rest.DisputeCaseRes": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer"
+                },
+                "assigned_to": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "due_at": {
+                    "type": "string"
+                },
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/rest.DisputeEventRes"
+                    }
+                },
+                "evidence": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/rest.DisputeEvidenceRes"
+                    }
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "overdue": {
+                    "type": "boolean"
+                },
+                "provisional_credit_transfer_id": {
+                    "type": "integer"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "resolution": {
+                    "type": "string"
+                },
+                "resolution_transfer_id": {
+                    "type": "integer"
+                },
+                "resolved_at": {
+                    "type": "string"
+                },
+                "resolved_by": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "transfer_id": {
+                    "type": "integer"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "rest.DisputeEventRes": {
+            "type": "object",
+            "properties": {
+                "actor": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "from_status": {
+                    "type": "string"
+                },
+                "note": {
+                    "type": "string"
+                },
+                "to_status": {
+                    "type": "string"
+                }
+            }
+        },
+        "rest.DisputeEvidenceRes": {
+            "type": "object",
+            "properties": {
+                "content_type": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "file_name": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "note": {
+                    "type": "string"
+                },
+                "sha256": {
+                    "type": "string"
+                },
+                "size": {
+                    "type": "integer"
+                },
+                "submitted_by": {
+                    "type": "string"
+                }
+            }
+        },
+        "rest.DisputeRes": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer"
+                },
+                "assigned_to": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "due_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "overdue": {
+                    "type": "boolean"
+                },
+                "provisional_credit_transfer_id": {
+                    "type": "integer"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "resolution": {
+                    "type": "string"
+                },
+                "resolution_transfer_id": {
+                    "type": "integer"
+                },
+                "resolved_at": {
+                    "type": "string"
+                },
+                "resolved_by": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "transfer_id": {
+                    "type": "integer"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "rest.EODStatusRes": {
+            "type": "object",
+            "properties": {
+                "business_date": {
+                    "description": "BusinessDate is the open business day postings go to.",
+                    "type": "string"
+                },
+                "closing": {
+                    "description": "Closing is the day whose end-of-day steps have not all succeeded.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/rest.BusinessDayRes"
+                        }
+                    ]
+                }
+            }
+        },
+        "rest.EODStepRes": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "type": "integer"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "finished_at": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "position": {
+                    "type": "integer"
+                },
+                "started_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "rest.EscrowRes": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "dispute_reason": {
+                    "type": "string"
+                },
+                "disputed_at": {
+                    "type": "string"
+                },
+                "from_account_id": {
+                    "type": "integer"
+                },
+                "funding_transfer_id": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "note": {
+                    "type": "string"
+                },
+                "overdue": {
+                    "type": "boolean"
+                },
+                "payee": {
+                    "type": "string"
+                },
+                "payer": {
+                    "type": "string"
+                },
+                "release_at": {
+                    "type": "string"
+                },
+                "settled_at": {
+                    "type": "string"
+                },
+                "settled_by": {
+                    "type": "string"
+                },
+                "settlement_transfer_id": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "to_account_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "rest.FraudDecisionRes": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer"
+                },
+                "amount_decimal": {
+                    "type": "string"
+                },
+                "client_ip": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "from_account_id": {
+                    "type": "integer"
+                },
+                "hits": {
+                    "type": "array",
+                    "items": {
+                        "type": "object"
+                    }
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "kind": {
+                    "type": "string"
+                },
+                "outcome": {
+                    "type": "string"
+                },
+                "review_note": {
+                    "type": "string"
+                },
+                "reviewed_at": {
+                    "type": "string"
+                },
+                "reviewed_by": {
+                    "type": "string"
+                },
+                "score": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "to_account_id": {
+                    "type": "integer"
+                },
+                "transfer_id": {
+                    "type": "integer"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "rest.GetAccountRes": {
+            "type": "object",
+            "properties": {
+                "account": {
+                    "$ref": "#/definitions/db.Account"
+                }
+            }
+        },
+        "rest.GetOverdraftRes": {
+            "type": "object",
+            "properties": {
+                "account": {
+                    "$ref": "#/definitions/db.Account"
+                },
+                "approvals": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/db.OverdraftApproval"
+                    }
+                }
+            }
+        },
+        "rest.HeldEscrowRes": {
+            "type": "object",
+            "properties": {
+                "fee_details": {
+                    "$ref": "#/definitions/rest.QuoteRes"
+                },
+                "fraud_decision": {
+                    "$ref": "#/definitions/rest.FraudDecisionRes"
+                }
+            }
+        },
+        "rest.KYCDocumentRes": {
+            "type": "object",
+            "properties": {
+                "content_type": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "file_name": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "kind": {
+                    "type": "string"
+                },
+                "sha256": {
+                    "type": "string"
+                },
+                "size": {
+                    "type": "integer"
+                }
+            }
+        },
+        "rest.KYCLevelLimitRes": {
+            "type": "object",
+            "properties": {
+                "currency": {
+                    "type": "string"
+                },
+                "daily_transfer_limit": {
+                    "type": "integer"
+                },
+                "max_transfer_amount": {
+                    "type": "integer"
+                }
+            }
+        },
+        "rest.KYCLevelRes": {
+            "type": "object",
+            "properties": {
+                "level": {
+                    "type": "integer"
+                },
+                "limits": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/rest.KYCLevelLimitRes"
+                    }
+                },
+                "max_accounts": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "rest.KYCStatusRes": {
+            "type": "object",
+            "properties": {
+                "accounts": {
+                    "type": "integer"
+                },
+                "level": {
+                    "$ref": "#/definitions/rest.KYCLevelRes"
+                },
+                "levels": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/rest.KYCLevelRes"
+                    }
+                },
+                "sent_today": {
+                    "description": "SentToday is what the user sent since midnight UTC in each currency.",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer",
+                        "format": "int64"
+                    }
+                },
+                "submissions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/rest.KYCSubmissionRes"
+                    }
+                }
+            }
+        },
+        "rest.KYCSubmissionRes": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "documents": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/rest.KYCDocumentRes"
+                    }
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "level": {
+                    "type": "integer"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "reviewed_at": {
+                    "type": "string"
+                },
+                "reviewed_by": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "rest.ListAccountProductsRes": {
+            "type": "object",
+            "properties": {
+                "products": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/rest.AccountProductRes"
+                    }
+                }
+            }
+        },
+        "rest.ListCategorizationRulesRes": {
+            "type": "object",
+            "properties": {
+                "rules": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/rest.CategorizationRuleRes"
+                    }
+                }
+            }
+        },
+        "rest.ListCurrenciesRes": {
+            "type": "object",
+            "properties": {
+                "currencies": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/rest.CurrencyRes"
+                    }
+                }
+            }
+        },
+        "rest.ListDisputesRes": {
+            "type": "object",
+            "properties": {
+                "disputes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/rest.DisputeRes"
+                    }
+                },
+                "next_after_id": {
+                    "description": "NextAfterID is the after_id of the next page, 0 on the last page.",
+                    "type": "integer"
+                }
+            }
+        },
+        "rest.ListEscrowsRes": {
+            "type": "object",
+            "properties": {
+                "escrows": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/rest.EscrowRes"
+                    }
+                },
+                "next_after_id": {
+                    "description": "NextAfterID is the after_id of the next page, 0 on the last page.",
+                    "type": "integer"
+                }
+            }
+        },
+        "rest.ListFraudReviewsRes": {
+            "type": "object",
+            "properties": {
+                "decisions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/rest.FraudDecisionRes"
+                    }
+                },
+                "next_after_id": {
+                    "description": "NextAfterID is the after_id of the next page, 0 on the last page.",
+                    "type": "integer"
+                }
+            }
+        },
+        "rest.ListGLAccountsRes": {
+            "type": "object",
+            "properties": {
+                "accounts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/db.GlAccount"
+                    }
+                }
+            }
+        },
+        "rest.ListKYCSubmissionsRes": {
+            "type": "object",
+            "properties": {
+                "next_after_id": {
+                    "description": "NextAfterID is the after_id of the next page, 0 on the last page.",
+                    "type": "integer"
+                },
+                "submissions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/rest.KYCSubmissionRes"
+                    }
+                }
+            }
+        },
+        "rest.ListOverdraftsRes": {
+            "type": "object",
+            "properties": {
+                "accounts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/db.Account"
+                    }
+                },
+                "next_after_id": {
+                    "description": "NextAfterID is the after_id of the next page, 0 on the last page.",
+                    "type": "integer"
+                },
+                "totals": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/db.SummarizeOverdraftsRow"
+                    }
+                }
+            }
+        },
+        "rest.ListScreeningMatchesRes": {
+            "type": "object",
+            "properties": {
+                "matches": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/rest.ScreeningMatchRes"
+                    }
+                },
+                "next_after_id": {
+                    "description": "NextAfterID is the after_id of the next page, 0 on the last page.",
+                    "type": "integer"
+                }
+            }
+        },
+        "rest.ListScreeningWhitelistRes": {
+            "type": "object",
+            "properties": {
+                "entries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/db.ScreeningWhitelist"
+                    }
+                }
+            }
+        },
+        "rest.LookupRecipientRes": {
+            "type": "object",
+            "properties": {
+                "currency": {
+                    "type": "string"
+                },
+                "masked_name": {
+                    "type": "string"
+                }
+            }
+        },
+        "rest.OpenDisputeRes": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer"
+                },
+                "assigned_to": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "due_at": {
+                    "type": "string"
+                },
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/rest.DisputeEventRes"
+                    }
+                },
+                "evidence": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/rest.DisputeEvidenceRes"
+                    }
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "overdue": {
+                    "type": "boolean"
+                },
+                "provisional_credit": {
+                    "description": "ProvisionalCredit is omitted when no provisional credit was given.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/db.TransferTxResult"
+                        }
+                    ]
+                },
+                "provisional_credit_transfer_id": {
+                    "type": "integer"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "resolution": {
+                    "type": "string"
+                },
+                "resolution_transfer_id": {
+                    "type": "integer"
+                },
+                "resolved_at": {
+                    "type": "string"
+                },
+                "resolved_by": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "transfer_id": {
+                    "type": "integer"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "rest.PaymentRequestRes": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer"
+                },
+                "amount_decimal": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "link": {
+                    "type": "string"
+                },
+                "memo": {
+                    "type": "string"
+                },
+                "payer": {
+                    "type": "string"
+                },
+                "requester": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "to_account_id": {
+                    "type": "integer"
+                },
+                "transfer_id": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "rest.ProfitAndLossRes": {
+            "type": "object",
+            "properties": {
+                "from": {
+                    "type": "string"
+                },
+                "statements": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/ledger.ProfitAndLoss"
+                    }
+                },
+                "to": {
+                    "type": "string"
+                }
+            }
+        },
+        "rest.QuoteRes": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer"
+                },
+                "amount_decimal": {
+                    "type": "string"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "fee": {
+                    "description": "Fee is FlatFee plus PercentageFee, raised to the schedule's minimum\nand capped at its maximum.",
+                    "type": "integer"
+                },
+                "fee_decimal": {
+                    "type": "string"
+                },
+                "flat_fee": {
+                    "type": "integer"
+                },
+                "percentage_fee": {
+                    "type": "integer"
+                },
+                "rate_ppm": {
+                    "description": "RatePpm is the percentage rate of the band the amount fell in, in\nparts per million.",
+                    "type": "integer"
+                },
+                "tier": {
+                    "description": "Tier is the payer's tier. Fees are waived for some tiers, in which\ncase Waived is set and Fee is zero.",
+                    "type": "string"
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "total_decimal": {
+                    "type": "string"
+                },
+                "waived": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "rest.ReviewKYCSubmissionRes": {
+            "type": "object",
+            "properties": {
+                "submission": {
+                    "$ref": "#/definitions/rest.KYCSubmissionRes"
+                },
+                "user": {
+                    "$ref": "#/definitions/rest.UserRes"
+                }
+            }
+        },
+        "rest.ScreeningMatchRes": {
+            "type": "object",
+            "properties": {
+                "account_id": {
+                    "type": "integer"
+                },
+                "action": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "entry_id": {
+                    "type": "string"
+                },
+                "entry_name": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "list": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "score": {
+                    "type": "number"
+                },
+                "subject": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "rest.SearchScreeningListsRes": {
+            "type": "object",
+            "properties": {
+                "matches": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/screening.Match"
+                    }
+                }
+            }
+        },
+        "rest.SettleEscrowRes": {
+            "type": "object",
+            "properties": {
+                "escrow": {
+                    "$ref": "#/definitions/rest.EscrowRes"
+                },
+                "transfer": {
+                    "description": "Transfer pays the amount out of escrow, omitted for a dispute.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/db.TransferTxResult"
+                        }
+                    ]
+                }
+            }
+        },
+        "rest.TransferBatchItemRes": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer"
+                },
+                "batch_id": {
+                    "type": "integer"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "reference": {
+                    "type": "string"
+                },
+                "row_number": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "to_account_id": {
+                    "type": "integer"
+                },
+                "transfer_id": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "rest.TransferBatchRes": {
+            "type": "object",
+            "properties": {
+                "completed_at": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "failed_rows": {
+                    "type": "integer"
+                },
+                "from_account_id": {
+                    "type": "integer"
+                },
+                "held_rows": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "mode": {
+                    "type": "string"
+                },
+                "processed_rows": {
+                    "type": "integer"
+                },
+                "results": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "succeeded_rows": {
+                    "type": "integer"
+                },
+                "total_amount": {
+                    "type": "integer"
+                },
+                "total_rows": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "rest.TransitionDisputeRes": {
+            "type": "object",
+            "properties": {
+                "dispute": {
+                    "$ref": "#/definitions/rest.DisputeRes"
+                },
+                "transfer": {
+                    "description": "Transfer is the reversal of a won dispute or the recovery of a\nprovisional credit, omitted when no money moved.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/db.TransferTxResult"
+                        }
+                    ]
+                }
+            }
+        },
+        "rest.TrialBalanceRes": {
+            "type": "object",
+            "properties": {
+                "date": {
+                    "type": "string"
+                },
+                "trial_balances": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/ledger.TrialBalance"
+                    }
+                }
+            }
+        },
+        "rest.UserRes": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "full_name": {
+                    "type": "string"
+                },
+                "kyc_level": {
+                    "type": "integer"
+                },
+                "password_changed_at": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "rest.approveOverdraftDTO": {
+            "type": "object",
+            "required": [
+                "overdraft_limit"
+            ],
+            "properties": {
+                "overdraft_limit": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "reason": {
+                    "type": "string"
+                }
+            }
+        },
+        "rest.createBeneficiaryDTO": {
+            "type": "object",
+            "required": [
+                "account_number",
+                "currency",
+                "nickname"
+            ],
+            "properties": {
+                "account_number": {
+                    "type": "string",
+                    "maxLength": 64
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "nickname": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 1
+                }
+            }
+        },
+        "rest.createCategorizationRuleDTO": {
+            "type": "object",
+            "required": [
+                "category"
+            ],
+            "properties": {
+                "category": {
+                    "type": "string"
+                },
+                "counterparty_account_id": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "description_contains": {
+                    "type": "string"
+                },
+                "merchant_category": {
+                    "type": "string"
+                },
+                "priority": {
+                    "type": "integer"
+                }
+            }
+        },
+        "rest.createEscrowDTO": {
+            "type": "object",
+            "required": [
+                "currency",
+                "from_account_id",
+                "to_account_id"
+            ],
+            "properties": {
+                "amount": {
+                    "description": "minor units, or a decimal string in major units",
+                    "type": "string",
+                    "example": "12.50"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "from_account_id": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "release_at": {
+                    "description": "ReleaseAt defaults to ESCROW_DEFAULT_RELEASE_AFTER from now.",
+                    "type": "string"
+                },
+                "to_account_id": {
+                    "type": "integer",
+                    "minimum": 1
+                }
+            }
+        },
+        "rest.createPaymentRequestDTO": {
+            "type": "object",
+            "required": [
+                "currency",
+                "to_account_id"
+            ],
+            "properties": {
+                "amount": {
+                    "description": "minor units, or a decimal string in major units",
+                    "type": "string",
+                    "example": "12.50"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "memo": {
+                    "type": "string"
+                },
+                "payer": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 3
+                },
+                "to_account_id": {
+                    "type": "integer",
+                    "minimum": 1
+                }
+            }
+        },
+        "rest.createTransferBatchDTO": {
+            "type": "object",
+            "required": [
+                "currency",
+                "from_account_id",
+                "mode",
+                "rows"
+            ],
+            "properties": {
+                "currency": {
+                    "type": "string"
+                },
+                "from_account_id": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "mode": {
+                    "type": "string",
+                    "enum": [
+                        "all_or_nothing",
+                        "best_effort"
+                    ]
+                },
+                "rows": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/transferbatch.Row"
+                    }
+                }
+            }
+        },
+        "rest.disputeEscrowDTO": {
+            "type": "object",
+            "required": [
+                "reason"
+            ],
+            "properties": {
+                "reason": {
+                    "type": "string"
+                }
+            }
+        },
+        "rest.disputeNoteDTO": {
+            "type": "object",
+            "properties": {
+                "note": {
+                    "type": "string"
+                }
+            }
+        },
+        "rest.escrowNoteDTO": {
+            "type": "object",
+            "properties": {
+                "note": {
+                    "type": "string"
+                }
+            }
+        },
+        "rest.openDisputeDTO": {
+            "type": "object",
+            "required": [
+                "reason",
+                "transfer_id"
+            ],
+            "properties": {
+                "amount": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "description": {
+                    "type": "string"
+                },
+                "provisional_credit": {
+                    "type": "boolean"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "transfer_id": {
+                    "type": "integer",
+                    "minimum": 1
+                }
+            }
+        },
+        "rest.resolveDisputeDTO": {
+            "type": "object",
+            "required": [
+                "outcome",
+                "resolution"
+            ],
+            "properties": {
+                "outcome": {
+                    "type": "string",
+                    "enum": [
+                        "won",
+                        "lost"
+                    ]
+                },
+                "resolution": {
+                    "type": "string"
+                }
+            }
+        },
+        "rest.resolveEscrowDTO": {
+            "type": "object",
+            "required": [
+                "outcome"
+            ],
+            "properties": {
+                "note": {
+                    "type": "string"
+                },
+                "outcome": {
+                    "type": "string",
+                    "enum": [
+                        "released",
+                        "refunded"
+                    ]
+                }
+            }
+        },
+        "rest.reviewFraudDecisionDTO": {
+            "type": "object",
+            "properties": {
+                "note": {
+                    "type": "string"
+                }
+            }
+        },
+        "rest.reviewKYCSubmissionDTO": {
+            "type": "object",
+            "properties": {
+                "reason": {
+                    "type": "string"
+                }
+            }
+        },
+        "rest.upsertCurrencyDTO": {
+            "type": "object",
+            "required": [
+                "exponent",
+                "name"
+            ],
+            "properties": {
+                "enabled": {
+                    "type": "boolean"
+                },
+                "exponent": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "rest.whitelistScreeningMatchDTO": {
+            "type": "object",
+            "required": [
+                "entry_id",
+                "name"
+            ],
+            "properties": {
+                "entry_id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 200
+                },
+                "reason": {
+                    "type": "string"
+                }
+            }
+        },
+        "screening.Entry": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "list": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "programs": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "screening.File": {
+            "type": "object",
+            "properties": {
+                "entries": {
+                    "type": "integer"
+                },
+                "modified_at": {
+                    "type": "string"
+                },
+                "path": {
+                    "type": "string"
+                }
+            }
+        },
+        "screening.Match": {
+            "type": "object",
+            "properties": {
+                "entry": {
+                    "$ref": "#/definitions/screening.Entry"
+                },
+                "score": {
+                    "type": "number"
+                }
+            }
+        },
+        "screening.Status": {
+            "type": "object",
+            "properties": {
+                "entries": {
+                    "type": "integer"
+                },
+                "files": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/screening.File"
+                    }
+                },
+                "loaded_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "transferbatch.Row": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer"
+                },
+                "reference": {
+                    "type": "string"
+                },
+                "to_account_id": {
+                    "type": "integer"
+                },
+                "to_account_number": {
+                    "type": "string"
                 }
             }
         }
@@ -0,0 +1,126 @@
+package accountno
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strings"
+
+	"github.com/NhutHuyDev/sgbank/pkg/utils"
+)
+
+// An account number follows the IBAN layout:
+//
+//	SG kk BBBB SSSS NNNNNNNNNNNN
+//
+// with the country code, two mod-97 check digits, the bank code, the branch
+// code and a random serial. The serial is drawn at random rather than derived
+// from the account ID so numbers cannot be enumerated.
+const (
+	CountryCode = "SG"
+
+	DefaultBankCode   = "0001"
+	DefaultBranchCode = "0001"
+
+	codeLength   = 4
+	serialLength = 12
+	Length       = len(CountryCode) + 2 + 2*codeLength + serialLength
+)
+
+var (
+	isValidCode   = regexp.MustCompile(`^[A-Z0-9]{4}$`).MatchString
+	isValidNumber = regexp.MustCompile(`^[A-Z]{2}[0-9]{2}[A-Z0-9]{8}[0-9]{12}$`).MatchString
+
+	ErrInvalidFormat      = errors.New("must be a " + CountryCode + " account number of 24 characters")
+	ErrInvalidCheckDigits = errors.New("check digits do not match, the number may contain a typo")
+)
+
+// Generator issues account numbers for one bank branch.
+type Generator struct {
+	bankCode   string
+	branchCode string
+}
+
+func NewGenerator(bankCode string, branchCode string) (*Generator, error) {
+	if !isValidCode(bankCode) {
+		return nil, fmt.Errorf("invalid bank code %q: must be 4 uppercase letters or digits", bankCode)
+	}
+
+	if !isValidCode(branchCode) {
+		return nil, fmt.Errorf("invalid branch code %q: must be 4 uppercase letters or digits", branchCode)
+	}
+
+	return &Generator{bankCode: bankCode, branchCode: branchCode}, nil
+}
+
+// FromConfig builds the generator from ACCOUNT_BANK_CODE and
+// ACCOUNT_BRANCH_CODE, falling back to the defaults when they are not set.
+func FromConfig(config utils.Config) (*Generator, error) {
+	bankCode, branchCode := config.AccountBankCode, config.AccountBranchCode
+	if bankCode == "" {
+		bankCode = DefaultBankCode
+	}
+
+	if branchCode == "" {
+		branchCode = DefaultBranchCode
+	}
+
+	return NewGenerator(bankCode, branchCode)
+}
+
+func (generator *Generator) Generate() (string, error) {
+	max := new(big.Int).Exp(big.NewInt(10), big.NewInt(serialLength), nil)
+	serial, err := rand.Int(rand.Reader, max)
+	if err != nil {
+		return "", fmt.Errorf("cannot generate account number: %w", err)
+	}
+
+	bban := fmt.Sprintf("%s%s%0*d", generator.bankCode, generator.branchCode, serialLength, serial)
+	return CountryCode + checkDigits(CountryCode, bban) + bban, nil
+}
+
+// Normalize strips the spaces clients use to group the number and upper-cases it.
+func Normalize(number string) string {
+	return strings.ToUpper(strings.Join(strings.Fields(number), ""))
+}
+
+// Validate checks the layout and the check digits of a normalized number.
+func Validate(number string) error {
+	if len(number) != Length || !isValidNumber(number) || number[:2] != CountryCode {
+		return ErrInvalidFormat
+	}
+
+	if mod97(number[4:]+number[:4]) != 1 {
+		return ErrInvalidCheckDigits
+	}
+
+	return nil
+}
+
+// IsAccountNumber reports whether ref looks like an account number rather
+// than an internal account ID.
+func IsAccountNumber(ref string) bool {
+	return len(ref) >= 2 && strings.HasPrefix(strings.ToUpper(ref), CountryCode)
+}
+
+func checkDigits(country string, bban string) string {
+	return fmt.Sprintf("%02d", 98-mod97(bban+country+"00"))
+}
+
+// mod97 computes the ISO 7064 MOD 97-10 remainder of s with letters
+// expanded to two digits (A=10 ... Z=35), one character at a time so the
+// number never overflows.
+func mod97(s string) int {
+	remainder := 0
+	for _, c := range s {
+		if c >= 'A' && c <= 'Z' {
+			remainder = (remainder*100 + int(c-'A') + 10) % 97
+		} else {
+			remainder = (remainder*10 + int(c-'0')) % 97
+		}
+	}
+
+	return remainder
+}
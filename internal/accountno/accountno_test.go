@@ -0,0 +1,49 @@
+package accountno
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerate(t *testing.T) {
+	generator, err := NewGenerator("SGBK", "0042")
+	require.NoError(t, err)
+
+	for i := 0; i < 100; i++ {
+		number, err := generator.Generate()
+		require.NoError(t, err)
+		require.Len(t, number, Length)
+		require.Equal(t, "SGBK0042", number[4:12])
+		require.NoError(t, Validate(number))
+	}
+}
+
+func TestNewGeneratorInvalidCode(t *testing.T) {
+	_, err := NewGenerator("sgbk", "0001")
+	require.Error(t, err)
+
+	_, err = NewGenerator("0001", "12345")
+	require.Error(t, err)
+}
+
+func TestValidate(t *testing.T) {
+	// check digits computed independently of mod97 for SG + 0001 0001 000000000042
+	number := "SG0500010001000000000042"
+	require.NoError(t, Validate(number))
+	require.NoError(t, Validate(Normalize("sg05 0001 0001 0000 0000 0042")))
+
+	// single-digit typo and transposed digits are caught by the check digits
+	require.ErrorIs(t, Validate("SG0500010001000000000043"), ErrInvalidCheckDigits)
+	require.ErrorIs(t, Validate("SG0500010001000000000024"), ErrInvalidCheckDigits)
+
+	require.ErrorIs(t, Validate("SG05000100010000000000"), ErrInvalidFormat)
+	require.ErrorIs(t, Validate("GB4100010001000000000042"), ErrInvalidFormat)
+	require.ErrorIs(t, Validate("SG0500010001ABCDEFGHIJKL"), ErrInvalidFormat)
+}
+
+func TestIsAccountNumber(t *testing.T) {
+	require.True(t, IsAccountNumber("SG0500010001000000000042"))
+	require.True(t, IsAccountNumber("sg05"))
+	require.False(t, IsAccountNumber("42"))
+}
@@ -0,0 +1,255 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/NhutHuyDev/sgbank/internal/gapi"
+	"github.com/NhutHuyDev/sgbank/internal/infra/db"
+	"github.com/NhutHuyDev/sgbank/internal/metrics"
+	"github.com/NhutHuyDev/sgbank/internal/rest"
+	"github.com/NhutHuyDev/sgbank/internal/services"
+	"github.com/NhutHuyDev/sgbank/pkg/utils"
+	"github.com/rs/zerolog/log"
+	"golang.org/x/sync/errgroup"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+const defaultShutdownTimeout = 15 * time.Second
+
+// Worker is a background job run alongside the servers. Run must return
+// once ctx is cancelled.
+type Worker interface {
+	Run(ctx context.Context) error
+}
+
+// WorkerFunc adapts a plain function to the Worker interface.
+type WorkerFunc func(ctx context.Context) error
+
+func (fn WorkerFunc) Run(ctx context.Context) error {
+	return fn(ctx)
+}
+
+// App is the single server runtime: it serves gRPC on GRPCServerAddress, the
+// Gin routes or the grpc-gateway mapping on HTTPServerAddress, and runs the
+// registered background workers until it is asked to shut down.
+type App struct {
+	Config    utils.Config
+	Store     db.Store
+	Services  *services.Set
+	Readiness *Readiness
+
+	workers map[string]Worker
+
+	mu       sync.Mutex
+	httpAddr net.Addr
+	grpcAddr net.Addr
+}
+
+// New builds the services the servers and the workers share.
+func New(config utils.Config, store db.Store) (*App, error) {
+	set, err := services.FromConfig(store, config)
+	if err != nil {
+		return nil, err
+	}
+
+	return &App{
+		Config:    config,
+		Store:     store,
+		Services:  set,
+		Readiness: &Readiness{},
+		workers:   make(map[string]Worker),
+	}, nil
+}
+
+// AddWorker registers a background worker. Workers are started by Run and
+// drained during shutdown after the servers stop accepting requests.
+func (app *App) AddWorker(name string, worker Worker) {
+	app.workers[name] = worker
+}
+
+// HTTPAddr returns the address the HTTP server listens on once Run has started it.
+func (app *App) HTTPAddr() net.Addr {
+	app.mu.Lock()
+	defer app.mu.Unlock()
+	return app.httpAddr
+}
+
+// GRPCAddr returns the address the gRPC server listens on once Run has started it.
+func (app *App) GRPCAddr() net.Addr {
+	app.mu.Lock()
+	defer app.mu.Unlock()
+	return app.grpcAddr
+}
+
+// Run serves until ctx is cancelled or one of the servers fails, then drains
+// in-flight requests and workers within Config.ShutdownTimeout.
+func (app *App) Run(ctx context.Context) error {
+	grpcAPI, err := gapi.NewServer(app.Config, app.Store, app.Services)
+	if err != nil {
+		return err
+	}
+
+	httpHandler, err := app.newHTTPHandler(ctx, grpcAPI)
+	if err != nil {
+		return err
+	}
+
+	healthServer := health.NewServer()
+	grpcServer := grpcAPI.NewGrpcServer()
+	healthpb.RegisterHealthServer(grpcServer, healthServer)
+
+	httpServer := &http.Server{
+		Handler:           httpHandler,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	grpcListener, err := net.Listen("tcp", app.Config.GRPCServerAddress)
+	if err != nil {
+		return fmt.Errorf("cannot create gRPC listener: %w", err)
+	}
+
+	httpListener, err := net.Listen("tcp", app.Config.HTTPServerAddress)
+	if err != nil {
+		grpcListener.Close()
+		return fmt.Errorf("cannot create HTTP listener: %w", err)
+	}
+
+	app.mu.Lock()
+	app.grpcAddr = grpcListener.Addr()
+	app.httpAddr = httpListener.Addr()
+	app.mu.Unlock()
+
+	workerCtx, stopWorkers := context.WithCancel(context.Background())
+	defer stopWorkers()
+
+	servers, serversCtx := errgroup.WithContext(ctx)
+
+	servers.Go(func() error {
+		log.Info().Msgf("start gRPC server at %s", grpcListener.Addr())
+		if err := grpcServer.Serve(grpcListener); err != nil && !errors.Is(err, grpc.ErrServerStopped) {
+			return fmt.Errorf("gRPC server: %w", err)
+		}
+		return nil
+	})
+
+	servers.Go(func() error {
+		log.Info().Msgf("start HTTP server (%s) at %s", app.httpServerMode(), httpListener.Addr())
+		if err := httpServer.Serve(httpListener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			return fmt.Errorf("HTTP server: %w", err)
+		}
+		return nil
+	})
+
+	var workers sync.WaitGroup
+	for name, worker := range app.workers {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			log.Info().Str("worker", name).Msg("start background worker")
+			if err := worker.Run(workerCtx); err != nil && !errors.Is(err, context.Canceled) {
+				log.Error().Err(err).Str("worker", name).Msg("background worker stopped")
+			}
+		}()
+	}
+
+	app.Readiness.SetReady(true)
+	healthServer.SetServingStatus("", healthpb.HealthCheckResponse_SERVING)
+
+	servers.Go(func() error {
+		<-serversCtx.Done()
+
+		log.Info().Msg("shutting down: draining in-flight requests")
+		app.Readiness.SetReady(false)
+		healthServer.Shutdown()
+
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), app.shutdownTimeout())
+		defer cancel()
+
+		err := httpServer.Shutdown(shutdownCtx)
+		if err != nil {
+			log.Error().Err(err).Msg("cannot gracefully shut down HTTP server")
+		}
+
+		stopped := make(chan struct{})
+		go func() {
+			grpcServer.GracefulStop()
+			close(stopped)
+		}()
+
+		select {
+		case <-stopped:
+		case <-shutdownCtx.Done():
+			log.Error().Msg("cannot gracefully shut down gRPC server: forcing stop")
+			grpcServer.Stop()
+		}
+
+		stopWorkers()
+		drained := make(chan struct{})
+		go func() {
+			workers.Wait()
+			close(drained)
+		}()
+
+		select {
+		case <-drained:
+		case <-shutdownCtx.Done():
+			log.Error().Msg("background workers did not stop before the shutdown timeout")
+		}
+
+		return nil
+	})
+
+	err = servers.Wait()
+	log.Info().Msg("server runtime stopped")
+	return err
+}
+
+func (app *App) newHTTPHandler(ctx context.Context, grpcAPI *gapi.Server) (http.Handler, error) {
+	var handler http.Handler
+
+	switch app.httpServerMode() {
+	case utils.HTTPServerModeGin:
+		restAPI, err := rest.NewServer(app.Config, app.Store, app.Services)
+		if err != nil {
+			return nil, err
+		}
+		handler = restAPI.Router
+	case utils.HTTPServerModeGateway:
+		gateway, err := grpcAPI.NewGatewayHandler(ctx)
+		if err != nil {
+			return nil, err
+		}
+		handler = gateway
+	default:
+		return nil, fmt.Errorf("unsupported HTTP server mode: %s", app.Config.HTTPServerMode)
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/v1/readyz", app.Readiness)
+	mux.Handle("/metrics", metrics.Handler())
+	mux.Handle("/", handler)
+
+	return mux, nil
+}
+
+func (app *App) httpServerMode() string {
+	if app.Config.HTTPServerMode == "" {
+		return utils.HTTPServerModeGin
+	}
+	return app.Config.HTTPServerMode
+}
+
+func (app *App) shutdownTimeout() time.Duration {
+	if app.Config.ShutdownTimeout <= 0 {
+		return defaultShutdownTimeout
+	}
+	return app.Config.ShutdownTimeout
+}
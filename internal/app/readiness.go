@@ -0,0 +1,35 @@
+package app
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync/atomic"
+)
+
+// Readiness reports whether the runtime accepts new traffic. It flips to
+// false as soon as shutdown starts so load balancers stop routing to us
+// while in-flight requests drain.
+type Readiness struct {
+	ready atomic.Bool
+}
+
+func (readiness *Readiness) SetReady(ready bool) {
+	readiness.ready.Store(ready)
+}
+
+func (readiness *Readiness) IsReady() bool {
+	return readiness.ready.Load()
+}
+
+func (readiness *Readiness) ServeHTTP(res http.ResponseWriter, req *http.Request) {
+	status := "READY"
+	statusCode := http.StatusOK
+	if !readiness.IsReady() {
+		status = "DRAINING"
+		statusCode = http.StatusServiceUnavailable
+	}
+
+	res.Header().Set("Content-Type", "application/json")
+	res.WriteHeader(statusCode)
+	_ = json.NewEncoder(res).Encode(map[string]string{"status": status})
+}
@@ -0,0 +1,130 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/NhutHuyDev/sgbank/internal/app"
+	"github.com/NhutHuyDev/sgbank/pkg/utils"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func newTestApp(t *testing.T, mode string) *app.App {
+	config := utils.Config{
+		HTTPServerAddress: "127.0.0.1:0",
+		GRPCServerAddress: "127.0.0.1:0",
+		TokenSymmetricKey: utils.RandomString(32),
+		HTTPServerMode:    mode,
+		ShutdownTimeout:   5 * time.Second,
+	}
+
+	runtime, err := app.New(config, nil)
+	require.NoError(t, err)
+
+	return runtime
+}
+
+func startTestApp(t *testing.T, runtime *app.App) (context.CancelFunc, chan error) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	done := make(chan error, 1)
+	go func() {
+		done <- runtime.Run(ctx)
+	}()
+
+	require.Eventually(t, runtime.Readiness.IsReady, 5*time.Second, 10*time.Millisecond)
+
+	return cancel, done
+}
+
+func TestAppServesAndDrains(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	testCases := []struct {
+		name       string
+		mode       string
+		path       string
+		statusCode int
+	}{
+		{
+			name:       "Gin",
+			mode:       utils.HTTPServerModeGin,
+			path:       "/v1/healthz",
+			statusCode: http.StatusOK,
+		},
+		{
+			name:       "Gateway",
+			mode:       utils.HTTPServerModeGateway,
+			path:       "/v1/login_user",
+			statusCode: http.StatusNotImplemented,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			runtime := newTestApp(t, tc.mode)
+
+			workerStopped := make(chan struct{})
+			runtime.AddWorker("test", app.WorkerFunc(func(ctx context.Context) error {
+				<-ctx.Done()
+				close(workerStopped)
+				return ctx.Err()
+			}))
+
+			cancel, done := startTestApp(t, runtime)
+			baseURL := fmt.Sprintf("http://%s", runtime.HTTPAddr())
+
+			res, err := http.Get(baseURL + "/v1/readyz")
+			require.NoError(t, err)
+			res.Body.Close()
+			require.Equal(t, http.StatusOK, res.StatusCode)
+
+			res, err = http.Get(baseURL + "/metrics")
+			require.NoError(t, err)
+			res.Body.Close()
+			require.Equal(t, http.StatusOK, res.StatusCode)
+
+			res, err = http.Get(baseURL + tc.path)
+			require.NoError(t, err)
+			res.Body.Close()
+			require.Equal(t, tc.statusCode, res.StatusCode)
+
+			conn, err := grpc.NewClient(runtime.GRPCAddr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+			require.NoError(t, err)
+			defer conn.Close()
+
+			healthRes, err := healthpb.NewHealthClient(conn).Check(context.Background(), &healthpb.HealthCheckRequest{})
+			require.NoError(t, err)
+			require.Equal(t, healthpb.HealthCheckResponse_SERVING, healthRes.Status)
+
+			cancel()
+
+			select {
+			case err := <-done:
+				require.NoError(t, err)
+			case <-time.After(10 * time.Second):
+				t.Fatal("runtime did not shut down")
+			}
+
+			require.False(t, runtime.Readiness.IsReady())
+			select {
+			case <-workerStopped:
+			default:
+				t.Fatal("worker was not drained")
+			}
+		})
+	}
+}
+
+func TestAppInvalidMode(t *testing.T) {
+	runtime := newTestApp(t, "unknown")
+	err := runtime.Run(context.Background())
+	require.Error(t, err)
+}
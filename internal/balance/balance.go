@@ -0,0 +1,200 @@
+// Package balance computes the balance accounts had in the past. A balance
+// at a time starts from the daily balance the end of day recorded for the
+// last business day closed by then, so only the entries posted since are
+// summed, and falls back to walking back from the current balance for
+// accounts without one.
+package balance
+
+import (
+	"context"
+	"time"
+
+	"github.com/NhutHuyDev/sgbank/internal/domain"
+	"github.com/NhutHuyDev/sgbank/internal/infra/db"
+	"github.com/NhutHuyDev/sgbank/internal/money"
+)
+
+// Intervals of balance history buckets. Weeks start on Monday; buckets
+// start at midnight UTC.
+const (
+	IntervalDay   = "day"
+	IntervalWeek  = "week"
+	IntervalMonth = "month"
+)
+
+// MaxPoints is the most buckets a history returns.
+const MaxPoints = 400
+
+// Point is the balance of an account over a bucket of a history: Closing
+// is Opening plus Credits minus Debits.
+type Point struct {
+	Start   time.Time `json:"start"`
+	End     time.Time `json:"end"`
+	Opening int64     `json:"opening"`
+	Credits int64     `json:"credits"`
+	Debits  int64     `json:"debits"`
+	Closing int64     `json:"closing"`
+}
+
+type Service struct {
+	store db.Store
+}
+
+func NewService(store db.Store) *Service {
+	return &Service{store: store}
+}
+
+// At returns the balance of an account after every entry created before
+// at, which is zero before the account was opened.
+func (service *Service) At(ctx context.Context, account db.Account, at time.Time) (int64, error) {
+	if at.Before(account.CreatedAt) {
+		return 0, nil
+	}
+
+	return service.store.GetAccountBalanceBefore(ctx, db.GetAccountBalanceBeforeParams{
+		AccountID: account.ID,
+		Before:    at,
+	})
+}
+
+type HistoryParams struct {
+	// Interval is the size of the buckets, day by default.
+	Interval string
+	// From is in the first bucket, 30 days, 12 weeks or 12 months before To
+	// by default.
+	From time.Time
+	// To ends the last bucket, now by default.
+	To time.Time
+}
+
+// History returns the balance of an account over the buckets of an
+// interval from the one From is in up to To. The last bucket ends at To
+// when To is not the end of a bucket.
+func (service *Service) History(ctx context.Context, account db.Account, arg HistoryParams) ([]Point, error) {
+	if arg.Interval == "" {
+		arg.Interval = IntervalDay
+	}
+	if arg.To.IsZero() {
+		arg.To = time.Now()
+	}
+	if arg.From.IsZero() {
+		arg.From = defaultFrom(arg.Interval, arg.To)
+	}
+
+	if err := validate(arg); err != nil {
+		return nil, err
+	}
+
+	start := Truncate(arg.From, arg.Interval)
+
+	opening, err := service.At(ctx, account, start)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := service.store.SumEntriesByPeriod(ctx, db.SumEntriesByPeriodParams{
+		Period:      arg.Interval,
+		AccountID:   account.ID,
+		CreatedFrom: start,
+		CreatedTo:   arg.To,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sums := make(map[time.Time]db.SumEntriesByPeriodRow, len(rows))
+	for _, row := range rows {
+		sums[row.PeriodStart.UTC()] = row
+	}
+
+	var points []Point
+	for start.Before(arg.To) {
+		end := Next(start, arg.Interval)
+		if end.After(arg.To) {
+			end = arg.To
+		}
+
+		sum := sums[start]
+		closing, err := money.Add(opening, sum.Credits)
+		if err == nil {
+			closing, err = money.Sub(closing, sum.Debits)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		points = append(points, Point{
+			Start:   start,
+			End:     end,
+			Opening: opening,
+			Credits: sum.Credits,
+			Debits:  sum.Debits,
+			Closing: closing,
+		})
+
+		opening = closing
+		start = Next(start, arg.Interval)
+	}
+
+	return points, nil
+}
+
+func validate(arg HistoryParams) error {
+	switch arg.Interval {
+	case IntervalDay, IntervalWeek, IntervalMonth:
+	default:
+		return domain.NewValidationError(domain.FieldViolation{Field: "interval", Description: "must be one of day, week, month"})
+	}
+
+	if !arg.From.Before(arg.To) {
+		return domain.NewValidationError(domain.FieldViolation{Field: "from", Description: "must be before to"})
+	}
+
+	points := 0
+	for start := Truncate(arg.From, arg.Interval); start.Before(arg.To); start = Next(start, arg.Interval) {
+		points++
+		if points > MaxPoints {
+			return domain.NewValidationError(domain.FieldViolation{Field: "from", Description: "the history must not have more than 400 points"})
+		}
+	}
+
+	return nil
+}
+
+func defaultFrom(interval string, to time.Time) time.Time {
+	switch interval {
+	case IntervalWeek:
+		return to.AddDate(0, 0, -7*12)
+	case IntervalMonth:
+		return to.AddDate(0, -12, 0)
+	default:
+		return to.AddDate(0, 0, -30)
+	}
+}
+
+// Truncate returns the start of the bucket of an interval t is in.
+func Truncate(t time.Time, interval string) time.Time {
+	t = t.UTC()
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+
+	switch interval {
+	case IntervalWeek:
+		return day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+	case IntervalMonth:
+		return day.AddDate(0, 0, 1-day.Day())
+	default:
+		return day
+	}
+}
+
+// Next returns the start of the bucket after the one starting at start.
+func Next(start time.Time, interval string) time.Time {
+	switch interval {
+	case IntervalWeek:
+		return start.AddDate(0, 0, 7)
+	case IntervalMonth:
+		return start.AddDate(0, 1, 0)
+	default:
+		return start.AddDate(0, 0, 1)
+	}
+}
@@ -0,0 +1,106 @@
+package test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/NhutHuyDev/sgbank/internal/balance"
+	"github.com/NhutHuyDev/sgbank/internal/domain"
+	"github.com/NhutHuyDev/sgbank/internal/infra/db"
+	mockdb "github.com/NhutHuyDev/sgbank/internal/infra/db/mock"
+	"github.com/NhutHuyDev/sgbank/pkg/utils"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAtBeforeAccountOpened(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().GetAccountBalanceBefore(gomock.Any(), gomock.Any()).Times(0)
+
+	account := db.Account{ID: 7, Currency: utils.USD, CreatedAt: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)}
+
+	amount, err := balance.NewService(store).At(context.Background(), account, account.CreatedAt.Add(-time.Hour))
+	require.NoError(t, err)
+	require.Zero(t, amount)
+}
+
+func TestHistory(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	account := db.Account{ID: 7, Currency: utils.USD, CreatedAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+
+	// Wednesday to the Monday two weeks later
+	from := time.Date(2024, 3, 6, 15, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 3, 18, 12, 0, 0, 0, time.UTC)
+	monday := time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC)
+
+	store.EXPECT().
+		GetAccountBalanceBefore(gomock.Any(), gomock.Eq(db.GetAccountBalanceBeforeParams{AccountID: account.ID, Before: monday})).
+		Times(1).
+		Return(int64(100), nil)
+	store.EXPECT().
+		SumEntriesByPeriod(gomock.Any(), gomock.Eq(db.SumEntriesByPeriodParams{
+			Period:      balance.IntervalWeek,
+			AccountID:   account.ID,
+			CreatedFrom: monday,
+			CreatedTo:   to,
+		})).
+		Times(1).
+		Return([]db.SumEntriesByPeriodRow{
+			{PeriodStart: monday, Credits: 50, Debits: 20},
+			{PeriodStart: monday.AddDate(0, 0, 14), Credits: 0, Debits: 30},
+		}, nil)
+
+	points, err := balance.NewService(store).History(context.Background(), account, balance.HistoryParams{
+		Interval: balance.IntervalWeek,
+		From:     from,
+		To:       to,
+	})
+	require.NoError(t, err)
+	require.Equal(t, []balance.Point{
+		{Start: monday, End: monday.AddDate(0, 0, 7), Opening: 100, Credits: 50, Debits: 20, Closing: 130},
+		{Start: monday.AddDate(0, 0, 7), End: monday.AddDate(0, 0, 14), Opening: 130, Closing: 130},
+		{Start: monday.AddDate(0, 0, 14), End: to, Opening: 130, Debits: 30, Closing: 100},
+	}, points)
+}
+
+func TestHistoryInvalid(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().SumEntriesByPeriod(gomock.Any(), gomock.Any()).Times(0)
+
+	service := balance.NewService(store)
+	to := time.Date(2024, 3, 18, 12, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name string
+		arg  balance.HistoryParams
+	}{
+		{name: "Interval", arg: balance.HistoryParams{Interval: "year", To: to}},
+		{name: "FromAfterTo", arg: balance.HistoryParams{From: to.Add(time.Hour), To: to}},
+		{name: "TooManyPoints", arg: balance.HistoryParams{From: to.AddDate(-2, 0, 0), To: to}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := service.History(context.Background(), db.Account{ID: 7}, tc.arg)
+			require.ErrorIs(t, err, domain.ErrInvalidArgument)
+		})
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	at := time.Date(2024, 3, 10, 23, 30, 0, 0, time.FixedZone("UTC+7", 7*60*60))
+
+	require.Equal(t, time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC), balance.Truncate(at, balance.IntervalDay))
+	require.Equal(t, time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC), balance.Truncate(at, balance.IntervalWeek))
+	require.Equal(t, time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), balance.Truncate(at, balance.IntervalMonth))
+}
@@ -0,0 +1,76 @@
+package bank
+
+import (
+	"context"
+	"errors"
+
+	"github.com/NhutHuyDev/sgbank/internal/accountno"
+	"github.com/NhutHuyDev/sgbank/internal/domain"
+	"github.com/NhutHuyDev/sgbank/internal/infra/db"
+)
+
+// Username owns the bank's internal accounts. It is created by the
+// migrations and cannot be registered since it is not alphanumeric.
+const Username = "sgbank-system"
+
+// Roles of users. Admins are bank staff: they approve overdrafts and see
+// the reports under /v1/admin. Users are promoted by setting users.role.
+const (
+	RoleDepositor = "depositor"
+	RoleAdmin     = "admin"
+)
+
+// Account products. Internal products are held by the bank and cannot be
+// opened through the APIs.
+const (
+	ProductChecking        = "checking"
+	ProductSavings         = "savings"
+	ProductInterestExpense = "interest_expense"
+	ProductInterestIncome  = "interest_income"
+	ProductFeeRevenue      = "fee_revenue"
+	ProductDisputeCredits  = "dispute_credits"
+	ProductEscrow          = "escrow"
+)
+
+// Accounts finds the bank's internal accounts, one per currency and
+// internal product, opening them on first use.
+type Accounts struct {
+	store          db.Store
+	accountNumbers *accountno.Generator
+}
+
+func NewAccounts(store db.Store, accountNumbers *accountno.Generator) *Accounts {
+	return &Accounts{store: store, accountNumbers: accountNumbers}
+}
+
+// Get returns the bank's account for product in currency.
+func (accounts *Accounts) Get(ctx context.Context, currency string, product string) (db.Account, error) {
+	arg := db.GetAccountByOwnerProductParams{
+		Owner:    Username,
+		Currency: currency,
+		Product:  product,
+	}
+
+	account, err := accounts.store.GetAccountByOwnerProduct(ctx, arg)
+	if !errors.Is(err, domain.ErrAccountNotFound) {
+		return account, err
+	}
+
+	accountNumber, err := accounts.accountNumbers.Generate()
+	if err != nil {
+		return db.Account{}, err
+	}
+
+	account, err = accounts.store.CreateAccount(ctx, db.CreateAccountParams{
+		Owner:         Username,
+		Currency:      currency,
+		AccountNumber: accountNumber,
+		Product:       product,
+	})
+	// opened concurrently by another request
+	if errors.Is(err, domain.ErrDuplicate) {
+		return accounts.store.GetAccountByOwnerProduct(ctx, arg)
+	}
+
+	return account, err
+}
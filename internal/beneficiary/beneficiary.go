@@ -0,0 +1,136 @@
+package beneficiary
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/NhutHuyDev/sgbank/internal/accountno"
+	"github.com/NhutHuyDev/sgbank/internal/domain"
+	"github.com/NhutHuyDev/sgbank/internal/infra/db"
+	"github.com/NhutHuyDev/sgbank/pkg/utils"
+)
+
+const (
+	DefaultCoolingOff      = 24 * time.Hour
+	DefaultCoolingOffLimit = 100000
+)
+
+// Service manages saved payees. A newly added beneficiary is in a
+// cooling-off period during which the owner may send it at most
+// coolingOffLimit in total, limiting the damage of a hijacked session that
+// adds an attacker's account and drains the owner's funds.
+type Service struct {
+	store           db.Store
+	coolingOff      time.Duration
+	coolingOffLimit int64
+}
+
+func NewService(store db.Store, coolingOff time.Duration, coolingOffLimit int64) *Service {
+	if coolingOff <= 0 {
+		coolingOff = DefaultCoolingOff
+	}
+
+	if coolingOffLimit <= 0 {
+		coolingOffLimit = DefaultCoolingOffLimit
+	}
+
+	return &Service{store: store, coolingOff: coolingOff, coolingOffLimit: coolingOffLimit}
+}
+
+// FromConfig builds the service from BENEFICIARY_COOLING_OFF and
+// BENEFICIARY_COOLING_OFF_LIMIT, using the defaults when they are not set.
+func FromConfig(store db.Store, config utils.Config) *Service {
+	return NewService(store, config.BeneficiaryCoolingOff, config.BeneficiaryCoolingOffLimit)
+}
+
+// CoolingOffUntil returns the end of the cooling-off period of beneficiary.
+func (service *Service) CoolingOffUntil(beneficiary db.Beneficiary) time.Time {
+	return beneficiary.CreatedAt.Add(service.coolingOff)
+}
+
+// Create saves a beneficiary for owner. The number must carry valid check
+// digits; the beneficiary is marked verified when it already resolves to an
+// account in currency.
+func (service *Service) Create(ctx context.Context, arg db.CreateBeneficiaryParams) (db.Beneficiary, error) {
+	arg.AccountNumber = accountno.Normalize(arg.AccountNumber)
+	if err := accountno.Validate(arg.AccountNumber); err != nil {
+		return db.Beneficiary{}, domain.NewValidationError(domain.FieldViolation{Field: "account_number", Description: err.Error()})
+	}
+
+	account, err := service.store.GetAccountByNumber(ctx, arg.AccountNumber)
+	switch {
+	case err == nil:
+		if account.Currency != arg.Currency {
+			return db.Beneficiary{}, fmt.Errorf("%w: beneficiary account is not in %s", domain.ErrCurrencyMismatch, arg.Currency)
+		}
+		arg.IsVerified = true
+	case errors.Is(err, domain.ErrAccountNotFound):
+		arg.IsVerified = false
+	default:
+		return db.Beneficiary{}, err
+	}
+
+	return service.store.CreateBeneficiary(ctx, arg)
+}
+
+// Get returns the beneficiary if it belongs to owner.
+func (service *Service) Get(ctx context.Context, owner string, id int64) (db.Beneficiary, error) {
+	beneficiary, err := service.store.GetBeneficiary(ctx, id)
+	if err != nil {
+		return beneficiary, err
+	}
+
+	if beneficiary.Owner != owner {
+		return db.Beneficiary{}, fmt.Errorf("%w: beneficiary doesn't belong to the authenticated user", domain.ErrForbidden)
+	}
+
+	return beneficiary, nil
+}
+
+// ResolveTransfer returns the destination account for a transfer of amount
+// to the beneficiary, enforcing ownership, currency and the cooling-off limit.
+func (service *Service) ResolveTransfer(ctx context.Context, owner string, id int64, currency string, amount int64) (db.Account, error) {
+	beneficiary, err := service.Get(ctx, owner, id)
+	if err != nil {
+		return db.Account{}, err
+	}
+
+	if beneficiary.Currency != currency {
+		return db.Account{}, fmt.Errorf("%w: beneficiary [%d]: %s vs %s", domain.ErrCurrencyMismatch, beneficiary.ID, beneficiary.Currency, currency)
+	}
+
+	account, err := service.store.GetAccountByNumber(ctx, beneficiary.AccountNumber)
+	if err != nil {
+		return account, err
+	}
+
+	if account.Currency != currency {
+		return db.Account{}, fmt.Errorf("%w: beneficiary account is not in %s", domain.ErrCurrencyMismatch, currency)
+	}
+
+	if !beneficiary.IsVerified {
+		if _, err := service.store.MarkBeneficiaryVerified(ctx, beneficiary.ID); err != nil {
+			return db.Account{}, err
+		}
+	}
+
+	if time.Now().Before(service.CoolingOffUntil(beneficiary)) {
+		sent, err := service.store.SumTransfersToAccountSince(ctx, db.SumTransfersToAccountSinceParams{
+			Owner:       owner,
+			ToAccountID: account.ID,
+			Since:       beneficiary.CreatedAt,
+		})
+		if err != nil {
+			return db.Account{}, err
+		}
+
+		if sent+amount > service.coolingOffLimit {
+			return db.Account{}, fmt.Errorf("%w: %d of %d already sent until %s", domain.ErrCoolingOffLimit,
+				sent, service.coolingOffLimit, service.CoolingOffUntil(beneficiary).Format(time.RFC3339))
+		}
+	}
+
+	return account, nil
+}
@@ -0,0 +1,206 @@
+package test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/NhutHuyDev/sgbank/internal/accountno"
+	"github.com/NhutHuyDev/sgbank/internal/beneficiary"
+	"github.com/NhutHuyDev/sgbank/internal/domain"
+	"github.com/NhutHuyDev/sgbank/internal/infra/db"
+	mockdb "github.com/NhutHuyDev/sgbank/internal/infra/db/mock"
+	"github.com/NhutHuyDev/sgbank/pkg/utils"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func randomAccountNumber(t *testing.T) string {
+	generator, err := accountno.NewGenerator(accountno.DefaultBankCode, accountno.DefaultBranchCode)
+	require.NoError(t, err)
+
+	number, err := generator.Generate()
+	require.NoError(t, err)
+
+	return number
+}
+
+func TestCreate(t *testing.T) {
+	number := randomAccountNumber(t)
+	account := db.Account{ID: 9, Owner: "bob", Currency: utils.USD, AccountNumber: number}
+
+	testCases := []struct {
+		name       string
+		arg        db.CreateBeneficiaryParams
+		buildStubs func(store *mockdb.MockStore)
+		check      func(t *testing.T, err error)
+	}{
+		{
+			name: "Verified",
+			arg:  db.CreateBeneficiaryParams{Owner: "alice", AccountNumber: number, Nickname: "Bob", Currency: utils.USD},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccountByNumber(gomock.Any(), gomock.Eq(number)).Times(1).Return(account, nil)
+				store.EXPECT().CreateBeneficiary(gomock.Any(), gomock.Eq(db.CreateBeneficiaryParams{
+					Owner: "alice", AccountNumber: number, Nickname: "Bob", Currency: utils.USD, IsVerified: true,
+				})).Times(1)
+			},
+			check: func(t *testing.T, err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name: "UnknownAccount",
+			arg:  db.CreateBeneficiaryParams{Owner: "alice", AccountNumber: number, Nickname: "Bob", Currency: utils.USD},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccountByNumber(gomock.Any(), gomock.Eq(number)).Times(1).Return(db.Account{}, domain.ErrAccountNotFound)
+				store.EXPECT().CreateBeneficiary(gomock.Any(), gomock.Eq(db.CreateBeneficiaryParams{
+					Owner: "alice", AccountNumber: number, Nickname: "Bob", Currency: utils.USD, IsVerified: false,
+				})).Times(1)
+			},
+			check: func(t *testing.T, err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name: "CurrencyMismatch",
+			arg:  db.CreateBeneficiaryParams{Owner: "alice", AccountNumber: number, Nickname: "Bob", Currency: utils.EUR},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccountByNumber(gomock.Any(), gomock.Eq(number)).Times(1).Return(account, nil)
+				store.EXPECT().CreateBeneficiary(gomock.Any(), gomock.Any()).Times(0)
+			},
+			check: func(t *testing.T, err error) {
+				require.ErrorIs(t, err, domain.ErrCurrencyMismatch)
+			},
+		},
+		{
+			name: "InvalidCheckDigits",
+			arg:  db.CreateBeneficiaryParams{Owner: "alice", AccountNumber: "SG0000010001000000000042", Nickname: "Bob", Currency: utils.USD},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccountByNumber(gomock.Any(), gomock.Any()).Times(0)
+			},
+			check: func(t *testing.T, err error) {
+				require.ErrorIs(t, err, domain.ErrInvalidArgument)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			service := beneficiary.NewService(store, time.Hour, 1000)
+			_, err := service.Create(context.Background(), tc.arg)
+			tc.check(t, err)
+		})
+	}
+}
+
+func TestResolveTransfer(t *testing.T) {
+	number := randomAccountNumber(t)
+	account := db.Account{ID: 9, Owner: "bob", Currency: utils.USD, AccountNumber: number}
+	saved := db.Beneficiary{ID: 3, Owner: "alice", AccountNumber: number, Currency: utils.USD, IsVerified: true}
+
+	newBeneficiary := saved
+	newBeneficiary.CreatedAt = time.Now().Add(-time.Minute)
+
+	oldBeneficiary := saved
+	oldBeneficiary.CreatedAt = time.Now().Add(-2 * time.Hour)
+
+	testCases := []struct {
+		name       string
+		owner      string
+		amount     int64
+		buildStubs func(store *mockdb.MockStore)
+		check      func(t *testing.T, account db.Account, err error)
+	}{
+		{
+			name:   "WithinCoolingOffLimit",
+			owner:  "alice",
+			amount: 400,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetBeneficiary(gomock.Any(), gomock.Eq(saved.ID)).Times(1).Return(newBeneficiary, nil)
+				store.EXPECT().GetAccountByNumber(gomock.Any(), gomock.Eq(number)).Times(1).Return(account, nil)
+				store.EXPECT().SumTransfersToAccountSince(gomock.Any(), gomock.Eq(db.SumTransfersToAccountSinceParams{
+					Owner:       "alice",
+					ToAccountID: account.ID,
+					Since:       newBeneficiary.CreatedAt,
+				})).Times(1).Return(int64(600), nil)
+			},
+			check: func(t *testing.T, found db.Account, err error) {
+				require.NoError(t, err)
+				require.Equal(t, account.ID, found.ID)
+			},
+		},
+		{
+			name:   "CoolingOffLimitExceeded",
+			owner:  "alice",
+			amount: 401,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetBeneficiary(gomock.Any(), gomock.Eq(saved.ID)).Times(1).Return(newBeneficiary, nil)
+				store.EXPECT().GetAccountByNumber(gomock.Any(), gomock.Any()).Times(1).Return(account, nil)
+				store.EXPECT().SumTransfersToAccountSince(gomock.Any(), gomock.Any()).Times(1).Return(int64(600), nil)
+			},
+			check: func(t *testing.T, found db.Account, err error) {
+				require.ErrorIs(t, err, domain.ErrCoolingOffLimit)
+			},
+		},
+		{
+			name:   "AfterCoolingOff",
+			owner:  "alice",
+			amount: 5000,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetBeneficiary(gomock.Any(), gomock.Eq(saved.ID)).Times(1).Return(oldBeneficiary, nil)
+				store.EXPECT().GetAccountByNumber(gomock.Any(), gomock.Any()).Times(1).Return(account, nil)
+				store.EXPECT().SumTransfersToAccountSince(gomock.Any(), gomock.Any()).Times(0)
+			},
+			check: func(t *testing.T, found db.Account, err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name:   "MarksUnverifiedAsVerified",
+			owner:  "alice",
+			amount: 10,
+			buildStubs: func(store *mockdb.MockStore) {
+				unverified := oldBeneficiary
+				unverified.IsVerified = false
+				store.EXPECT().GetBeneficiary(gomock.Any(), gomock.Eq(saved.ID)).Times(1).Return(unverified, nil)
+				store.EXPECT().GetAccountByNumber(gomock.Any(), gomock.Any()).Times(1).Return(account, nil)
+				store.EXPECT().MarkBeneficiaryVerified(gomock.Any(), gomock.Eq(saved.ID)).Times(1)
+			},
+			check: func(t *testing.T, found db.Account, err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name:   "OtherOwner",
+			owner:  "mallory",
+			amount: 10,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetBeneficiary(gomock.Any(), gomock.Eq(saved.ID)).Times(1).Return(oldBeneficiary, nil)
+				store.EXPECT().GetAccountByNumber(gomock.Any(), gomock.Any()).Times(0)
+			},
+			check: func(t *testing.T, found db.Account, err error) {
+				require.ErrorIs(t, err, domain.ErrForbidden)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			service := beneficiary.NewService(store, time.Hour, 1000)
+			found, err := service.ResolveTransfer(context.Background(), tc.owner, saved.ID, utils.USD, tc.amount)
+			tc.check(t, found, err)
+		})
+	}
+}
@@ -0,0 +1,104 @@
+// Package blob stores files by key. Store is the interface an object store
+// such as S3 would implement; Disk keeps the files in a local directory.
+package blob
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+
+	"github.com/NhutHuyDev/sgbank/internal/domain"
+)
+
+// keyPattern allows keys made of path segments of letters, digits, dots,
+// dashes and underscores, so a key cannot leave the store.
+var keyPattern = regexp.MustCompile(`^[A-Za-z0-9_-][A-Za-z0-9._-]*(/[A-Za-z0-9_-][A-Za-z0-9._-]*)*$`)
+
+type Store interface {
+	// Put stores the content of r under key, replacing any file with that key.
+	Put(ctx context.Context, key string, r io.Reader) error
+	// Open reads the file with key. It fails with ErrNotFound when there is
+	// none.
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+	// Delete removes the file with key, if any.
+	Delete(ctx context.Context, key string) error
+}
+
+// Disk is a Store in a local directory.
+type Disk struct {
+	dir string
+}
+
+func NewDisk(dir string) *Disk {
+	return &Disk{dir: dir}
+}
+
+func (disk *Disk) Put(_ context.Context, key string, r io.Reader) error {
+	path, err := disk.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+
+	// write to a temporary file first so a failed upload never replaces a
+	// stored file with part of a new one
+	file, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+
+	if _, err := io.Copy(file, r); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(file.Name(), path)
+}
+
+func (disk *Disk) Open(_ context.Context, key string) (io.ReadCloser, error) {
+	path, err := disk.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("%w: file %s", domain.ErrNotFound, key)
+	}
+
+	return file, err
+}
+
+func (disk *Disk) Delete(_ context.Context, key string) error {
+	path, err := disk.path(key)
+	if err != nil {
+		return err
+	}
+
+	err = os.Remove(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+
+	return err
+}
+
+func (disk *Disk) path(key string) (string, error) {
+	if !keyPattern.MatchString(key) {
+		return "", fmt.Errorf("invalid blob key %q", key)
+	}
+
+	return filepath.Join(disk.dir, filepath.FromSlash(key)), nil
+}
@@ -0,0 +1,100 @@
+package category
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/NhutHuyDev/sgbank/internal/domain"
+	"github.com/NhutHuyDev/sgbank/internal/infra/db"
+	"github.com/NhutHuyDev/sgbank/internal/memo"
+)
+
+const MaxCategoryLength = 50
+
+// Service manages the rules that categorize a user's entries. When a
+// transfer is posted, each side's entry takes the category of its owner's
+// highest-priority matching rule; entries matching no rule stay
+// uncategorized.
+type Service struct {
+	store db.Store
+}
+
+func NewService(store db.Store) *Service {
+	return &Service{store: store}
+}
+
+// Create validates and saves a rule. A rule must match on at least one of
+// description, merchant category and counterparty account.
+func (service *Service) Create(ctx context.Context, arg db.CreateCategorizationRuleParams) (db.CategorizationRule, error) {
+	arg.Category = strings.TrimSpace(arg.Category)
+	arg.DescriptionContains = strings.TrimSpace(arg.DescriptionContains)
+	arg.MerchantCategory = strings.TrimSpace(arg.MerchantCategory)
+
+	var violations []domain.FieldViolation
+	if description := validateCategory(arg.Category); description != "" {
+		violations = append(violations, domain.FieldViolation{Field: "category", Description: description})
+	}
+
+	if description := memo.ValidateDescription(arg.DescriptionContains); description != "" {
+		violations = append(violations, domain.FieldViolation{Field: "description_contains", Description: description})
+	}
+
+	if description := memo.ValidateMerchantCategory(arg.MerchantCategory); description != "" {
+		violations = append(violations, domain.FieldViolation{Field: "merchant_category", Description: description})
+	}
+
+	if arg.DescriptionContains == "" && arg.MerchantCategory == "" && !arg.CounterpartyAccountID.Valid {
+		violations = append(violations, domain.FieldViolation{
+			Field:       "description_contains",
+			Description: "one of description_contains, merchant_category and counterparty_account_id is required",
+		})
+	}
+
+	if violations != nil {
+		return db.CategorizationRule{}, domain.NewValidationError(violations...)
+	}
+
+	if arg.CounterpartyAccountID.Valid {
+		if _, err := service.store.GetAccount(ctx, arg.CounterpartyAccountID.Int64); err != nil {
+			return db.CategorizationRule{}, err
+		}
+	}
+
+	return service.store.CreateCategorizationRule(ctx, arg)
+}
+
+// List returns owner's rules in the order they are tried.
+func (service *Service) List(ctx context.Context, owner string) ([]db.CategorizationRule, error) {
+	return service.store.ListCategorizationRules(ctx, owner)
+}
+
+// Delete removes the rule if it belongs to owner. Entries it already
+// categorized keep their category.
+func (service *Service) Delete(ctx context.Context, owner string, id int64) error {
+	rule, err := service.store.GetCategorizationRule(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if rule.Owner != owner {
+		return fmt.Errorf("%w: categorization rule doesn't belong to the authenticated user", domain.ErrForbidden)
+	}
+
+	return service.store.DeleteCategorizationRule(ctx, id)
+}
+
+func validateCategory(category string) string {
+	if category == "" || len([]rune(category)) > MaxCategoryLength {
+		return fmt.Sprintf("must be between 1 and %d characters", MaxCategoryLength)
+	}
+
+	for _, r := range category {
+		if !(unicode.IsLetter(r) || unicode.IsDigit(r) || r == ' ' || r == '-' || r == '_') {
+			return "must only contain letters, digits, spaces, - and _"
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,99 @@
+package test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/NhutHuyDev/sgbank/internal/category"
+	"github.com/NhutHuyDev/sgbank/internal/domain"
+	"github.com/NhutHuyDev/sgbank/internal/infra/db"
+	mockdb "github.com/NhutHuyDev/sgbank/internal/infra/db/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreate(t *testing.T) {
+	testCases := []struct {
+		name       string
+		arg        db.CreateCategorizationRuleParams
+		buildStubs func(store *mockdb.MockStore)
+		check      func(t *testing.T, err error)
+	}{
+		{
+			name: "OK",
+			arg:  db.CreateCategorizationRuleParams{Owner: "alice", Category: " Groceries ", DescriptionContains: "market", MerchantCategory: "5411"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateCategorizationRule(gomock.Any(), gomock.Eq(db.CreateCategorizationRuleParams{
+					Owner: "alice", Category: "Groceries", DescriptionContains: "market", MerchantCategory: "5411",
+				})).Times(1)
+			},
+			check: func(t *testing.T, err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name: "UnknownCounterparty",
+			arg:  db.CreateCategorizationRuleParams{Owner: "alice", Category: "Rent", CounterpartyAccountID: sql.NullInt64{Int64: 7, Valid: true}},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(int64(7))).Times(1).Return(db.Account{}, domain.ErrAccountNotFound)
+				store.EXPECT().CreateCategorizationRule(gomock.Any(), gomock.Any()).Times(0)
+			},
+			check: func(t *testing.T, err error) {
+				require.ErrorIs(t, err, domain.ErrAccountNotFound)
+			},
+		},
+		{
+			name: "Invalid",
+			arg:  db.CreateCategorizationRuleParams{Owner: "alice", Category: "<b>", MerchantCategory: "54"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateCategorizationRule(gomock.Any(), gomock.Any()).Times(0)
+			},
+			check: func(t *testing.T, err error) {
+				var validationErr *domain.ValidationError
+				require.ErrorAs(t, err, &validationErr)
+				require.Len(t, validationErr.Violations, 2)
+				require.Equal(t, "category", validationErr.Violations[0].Field)
+				require.Equal(t, "merchant_category", validationErr.Violations[1].Field)
+			},
+		},
+		{
+			name: "NoMatcher",
+			arg:  db.CreateCategorizationRuleParams{Owner: "alice", Category: "Other"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateCategorizationRule(gomock.Any(), gomock.Any()).Times(0)
+			},
+			check: func(t *testing.T, err error) {
+				require.ErrorIs(t, err, domain.ErrInvalidArgument)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			_, err := category.NewService(store).Create(context.Background(), tc.arg)
+			tc.check(t, err)
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	service := category.NewService(store)
+	rule := db.CategorizationRule{ID: 4, Owner: "alice", Category: "Rent"}
+
+	store.EXPECT().GetCategorizationRule(gomock.Any(), gomock.Eq(rule.ID)).Times(2).Return(rule, nil)
+	store.EXPECT().DeleteCategorizationRule(gomock.Any(), gomock.Eq(rule.ID)).Times(1).Return(nil)
+
+	require.ErrorIs(t, service.Delete(context.Background(), "mallory", rule.ID), domain.ErrForbidden)
+	require.NoError(t, service.Delete(context.Background(), "alice", rule.ID))
+}
@@ -0,0 +1,188 @@
+// Package currency keeps the currencies table in memory so requests can be
+// validated and amounts formatted without a query. Enabling a currency in the
+// table takes effect on every instance at its next refresh.
+package currency
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/NhutHuyDev/sgbank/internal/domain"
+	"github.com/NhutHuyDev/sgbank/internal/infra/db"
+	"github.com/NhutHuyDev/sgbank/internal/money"
+	"github.com/NhutHuyDev/sgbank/pkg/utils"
+)
+
+const MaxNameLength = 100
+
+var codePattern = regexp.MustCompile(`^[A-Z]{3}$`)
+
+// defaults are the currencies the bank supported before the currencies
+// table existed. A registry starts with them so it is usable before its
+// first refresh.
+var defaults = []db.Currency{
+	{Code: utils.USD, Name: "US Dollar", Exponent: 2, Enabled: true},
+	{Code: utils.EUR, Name: "Euro", Exponent: 2, Enabled: true},
+	{Code: utils.CAD, Name: "Canadian Dollar", Exponent: 2, Enabled: true},
+}
+
+// Default is the registry shared by the HTTP and gRPC servers and refreshed
+// by the worker.
+var Default = NewRegistry()
+
+// Registry is a snapshot of the currencies table. It is safe for concurrent
+// use.
+type Registry struct {
+	mu         sync.RWMutex
+	currencies map[string]db.Currency
+}
+
+func NewRegistry() *Registry {
+	registry := &Registry{}
+	registry.Load(defaults)
+	return registry
+}
+
+// Load replaces the registry's currencies.
+func (registry *Registry) Load(currencies []db.Currency) {
+	byCode := make(map[string]db.Currency, len(currencies))
+	for _, currency := range currencies {
+		byCode[currency.Code] = currency
+	}
+
+	registry.mu.Lock()
+	registry.currencies = byCode
+	registry.mu.Unlock()
+}
+
+// Lookup returns the currency with code, enabled or not. Disabled currencies
+// are still needed to format the balances of existing accounts.
+func (registry *Registry) Lookup(code string) (money.Currency, bool) {
+	registry.mu.RLock()
+	currency, ok := registry.currencies[code]
+	registry.mu.RUnlock()
+
+	return money.Currency{Code: currency.Code, Exponent: int(currency.Exponent)}, ok
+}
+
+// IsSupported reports whether new accounts and transfers may use code.
+func (registry *Registry) IsSupported(code string) bool {
+	registry.mu.RLock()
+	defer registry.mu.RUnlock()
+
+	return registry.currencies[code].Enabled
+}
+
+// Enabled lists the supported currencies ordered by code.
+func (registry *Registry) Enabled() []db.Currency {
+	registry.mu.RLock()
+	currencies := make([]db.Currency, 0, len(registry.currencies))
+	for _, currency := range registry.currencies {
+		if currency.Enabled {
+			currencies = append(currencies, currency)
+		}
+	}
+	registry.mu.RUnlock()
+
+	sort.Slice(currencies, func(i, j int) bool {
+		return currencies[i].Code < currencies[j].Code
+	})
+	return currencies
+}
+
+// Amount returns minor units of code, or false when code is unknown.
+func (registry *Registry) Amount(minor int64, code string) (money.Amount, bool) {
+	currency, ok := registry.Lookup(code)
+	return money.New(minor, currency), ok
+}
+
+// Format formats minor units of code as a decimal in major units, or
+// returns an empty string when code is unknown.
+func (registry *Registry) Format(minor int64, code string) string {
+	amount, ok := registry.Amount(minor, code)
+	if !ok {
+		return ""
+	}
+
+	return amount.String()
+}
+
+// Service manages the currencies table and keeps a registry in step with it.
+type Service struct {
+	store    db.Store
+	registry *Registry
+}
+
+func NewService(store db.Store, registry *Registry) *Service {
+	return &Service{store: store, registry: registry}
+}
+
+// Registry returns the registry the service keeps up to date.
+func (service *Service) Registry() *Registry {
+	return service.registry
+}
+
+// Refresh reloads the registry from the currencies table.
+func (service *Service) Refresh(ctx context.Context) error {
+	currencies, err := service.store.ListCurrencies(ctx)
+	if err != nil {
+		return err
+	}
+
+	service.registry.Load(currencies)
+	return nil
+}
+
+type UpsertParams struct {
+	Code     string
+	Name     string
+	Exponent int32
+	Enabled  bool
+}
+
+// Upsert adds a currency or renames, enables or disables an existing one.
+// The exponent of an existing currency cannot change: balances are stored in
+// its minor unit.
+func (service *Service) Upsert(ctx context.Context, arg UpsertParams) (db.Currency, error) {
+	arg.Code = strings.ToUpper(strings.TrimSpace(arg.Code))
+	arg.Name = strings.TrimSpace(arg.Name)
+
+	var violations []domain.FieldViolation
+	if !codePattern.MatchString(arg.Code) {
+		violations = append(violations, domain.FieldViolation{Field: "code", Description: "must be a three-letter ISO 4217 code"})
+	}
+	if arg.Name == "" || len(arg.Name) > MaxNameLength {
+		violations = append(violations, domain.FieldViolation{Field: "name", Description: fmt.Sprintf("must be 1 to %d characters", MaxNameLength)})
+	}
+	if arg.Exponent < 0 || arg.Exponent > money.MaxExponent {
+		violations = append(violations, domain.FieldViolation{Field: "exponent", Description: fmt.Sprintf("must be between 0 and %d", money.MaxExponent)})
+	}
+	if violations != nil {
+		return db.Currency{}, domain.NewValidationError(violations...)
+	}
+
+	existing, err := service.store.GetCurrency(ctx, arg.Code)
+	switch {
+	case err == nil && existing.Exponent != arg.Exponent:
+		return db.Currency{}, fmt.Errorf("%w: the exponent of %s is %d", domain.ErrInvalidArgument, existing.Code, existing.Exponent)
+	case err != nil && !errors.Is(err, domain.ErrNotFound):
+		return db.Currency{}, err
+	}
+
+	currency, err := service.store.UpsertCurrency(ctx, db.UpsertCurrencyParams{
+		Code:     arg.Code,
+		Name:     arg.Name,
+		Exponent: arg.Exponent,
+		Enabled:  arg.Enabled,
+	})
+	if err != nil {
+		return db.Currency{}, err
+	}
+
+	return currency, service.Refresh(ctx)
+}
@@ -0,0 +1,85 @@
+package test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/NhutHuyDev/sgbank/internal/currency"
+	"github.com/NhutHuyDev/sgbank/internal/domain"
+	"github.com/NhutHuyDev/sgbank/internal/infra/db"
+	mockdb "github.com/NhutHuyDev/sgbank/internal/infra/db/mock"
+	"github.com/NhutHuyDev/sgbank/internal/money"
+	"github.com/NhutHuyDev/sgbank/pkg/utils"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistry(t *testing.T) {
+	registry := currency.NewRegistry()
+
+	for _, code := range []string{utils.USD, utils.EUR, utils.CAD} {
+		require.True(t, registry.IsSupported(code))
+	}
+	require.False(t, registry.IsSupported("JPY"))
+	require.Equal(t, "12.50", registry.Format(1250, utils.USD))
+	require.Empty(t, registry.Format(1250, "JPY"))
+
+	registry.Load([]db.Currency{
+		{Code: utils.USD, Exponent: 2, Enabled: true},
+		{Code: utils.CAD, Exponent: 2},
+		{Code: "JPY", Exponent: 0, Enabled: true},
+		{Code: "KWD", Exponent: 3, Enabled: true},
+	})
+
+	require.False(t, registry.IsSupported(utils.CAD))
+	require.True(t, registry.IsSupported("JPY"))
+	require.Equal(t, "1250", registry.Format(1250, "JPY"))
+	require.Equal(t, "1.250", registry.Format(1250, "KWD"))
+	// disabled currencies still format existing balances
+	require.Equal(t, "12.50", registry.Format(1250, utils.CAD))
+
+	c, ok := registry.Lookup("KWD")
+	require.True(t, ok)
+	require.Equal(t, money.Currency{Code: "KWD", Exponent: 3}, c)
+
+	var enabled []string
+	for _, c := range registry.Enabled() {
+		enabled = append(enabled, c.Code)
+	}
+	require.Equal(t, []string{"JPY", "KWD", utils.USD}, enabled)
+}
+
+func TestUpsert(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	registry := currency.NewRegistry()
+	service := currency.NewService(store, registry)
+
+	jpy := db.Currency{Code: "JPY", Name: "Yen", Exponent: 0, Enabled: true}
+
+	store.EXPECT().GetCurrency(gomock.Any(), gomock.Eq("JPY")).Times(1).Return(db.Currency{Code: "JPY", Name: "Yen"}, nil)
+	store.EXPECT().UpsertCurrency(gomock.Any(), gomock.Eq(db.UpsertCurrencyParams{
+		Code:    "JPY",
+		Name:    "Yen",
+		Enabled: true,
+	})).Times(1).Return(jpy, nil)
+	store.EXPECT().ListCurrencies(gomock.Any()).Times(1).Return([]db.Currency{jpy}, nil)
+
+	got, err := service.Upsert(context.Background(), currency.UpsertParams{Code: " jpy", Name: "Yen", Enabled: true})
+	require.NoError(t, err)
+	require.Equal(t, jpy, got)
+	require.True(t, registry.IsSupported("JPY"))
+	require.False(t, registry.IsSupported(utils.USD))
+
+	// the exponent of an existing currency is fixed
+	store.EXPECT().GetCurrency(gomock.Any(), gomock.Eq("JPY")).Times(1).Return(jpy, nil)
+	_, err = service.Upsert(context.Background(), currency.UpsertParams{Code: "JPY", Name: "Yen", Exponent: 2})
+	require.ErrorIs(t, err, domain.ErrInvalidArgument)
+
+	_, err = service.Upsert(context.Background(), currency.UpsertParams{Code: "YENS", Name: "", Exponent: 5})
+	var validationErr *domain.ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	require.Len(t, validationErr.Violations, 3)
+}
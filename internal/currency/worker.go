@@ -0,0 +1,43 @@
+package currency
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+const DefaultRefreshInterval = time.Minute
+
+// Worker reloads the registry from the currencies table, so currencies
+// enabled on another instance or directly in the database are picked up
+// without a restart.
+type Worker struct {
+	service  *Service
+	interval time.Duration
+}
+
+func NewWorker(service *Service, interval time.Duration) *Worker {
+	if interval <= 0 {
+		interval = DefaultRefreshInterval
+	}
+
+	return &Worker{service: service, interval: interval}
+}
+
+func (worker *Worker) Run(ctx context.Context) error {
+	ticker := time.NewTicker(worker.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := worker.service.Refresh(ctx); err != nil {
+			log.Error().Err(err).Msg("cannot refresh currencies")
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
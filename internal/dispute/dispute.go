@@ -0,0 +1,681 @@
+// Package dispute lets customers contest a transfer they sent. A dispute
+// is opened with a reason and evidence, optionally with a provisional
+// credit of the amount while it is decided, and support staff take it
+// under review and decide it. A won dispute reverses the amount from the
+// payee; a lost or withdrawn one takes a provisional credit back. Every
+// dispute is due within an SLA, and the staff are warned before it ends and
+// when it is missed.
+package dispute
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/NhutHuyDev/sgbank/internal/bank"
+	"github.com/NhutHuyDev/sgbank/internal/blob"
+	"github.com/NhutHuyDev/sgbank/internal/domain"
+	"github.com/NhutHuyDev/sgbank/internal/infra/db"
+	"github.com/NhutHuyDev/sgbank/internal/logging"
+	"github.com/NhutHuyDev/sgbank/internal/notify"
+	"github.com/NhutHuyDev/sgbank/pkg/utils"
+	"github.com/google/uuid"
+)
+
+type Status string
+
+const (
+	StatusOpen        Status = db.DisputeOpen
+	StatusUnderReview Status = db.DisputeUnderReview
+	StatusWon         Status = db.DisputeWon
+	StatusLost        Status = db.DisputeLost
+	StatusWithdrawn   Status = db.DisputeWithdrawn
+)
+
+// transitions lists the statuses a dispute may move to from each status.
+// Won, lost and withdrawn are final.
+var transitions = map[Status][]Status{
+	StatusOpen:        {StatusUnderReview, StatusWon, StatusLost, StatusWithdrawn},
+	StatusUnderReview: {StatusWon, StatusLost, StatusWithdrawn},
+}
+
+func CanTransition(from Status, to Status) bool {
+	return slices.Contains(transitions[from], to)
+}
+
+// Reasons a transfer may be disputed for.
+var Reasons = []string{"unauthorized", "not_received", "not_as_described", "duplicate", "incorrect_amount", "other"}
+
+// ContentTypes are the types an evidence file may have, detected from its
+// content.
+var ContentTypes = []string{"application/pdf", "image/jpeg", "image/png"}
+
+// Notification kinds. The customer is told when their dispute is taken
+// under review and decided; the assignee, or every admin while the dispute
+// is unassigned, about withdrawals and the SLA.
+const (
+	KindUnderReview = "dispute.under_review"
+	KindWon         = "dispute.won"
+	KindLost        = "dispute.lost"
+	KindWithdrawn   = "dispute.withdrawn"
+	KindSLAWarning  = "dispute.sla_warning"
+	KindSLABreached = "dispute.sla_breached"
+)
+
+const (
+	DefaultWindow              = 60 * 24 * time.Hour
+	DefaultSLA                 = 10 * 24 * time.Hour
+	DefaultSLAWarning          = 2 * 24 * time.Hour
+	DefaultStorageDir          = "data/disputes"
+	MaxEvidenceSize            = 5 << 20
+	MaxFiles                   = 5
+	MaxFileNameLength          = 200
+	MaxDescriptionLength       = 500
+	MaxResolutionLength        = 500
+	PageSize                   = 100
+	slaClaimPageSize     int32 = 100
+)
+
+// Policy holds the time limits of disputes and the provisional credits
+// offered.
+type Policy struct {
+	// Window is how long after a transfer it may be disputed.
+	Window time.Duration
+	// SLA is how long support has to decide a dispute.
+	SLA time.Duration
+	// SLAWarning is how long before the end of the SLA support is warned.
+	SLAWarning time.Duration
+	// ProvisionalCreditLimit is the largest amount credited while a dispute
+	// is decided, in minor units; 0 offers no provisional credits.
+	ProvisionalCreditLimit int64
+}
+
+// File is an evidence file.
+type File struct {
+	FileName string
+	Content  io.Reader
+}
+
+// Case is a dispute with its evidence and history.
+type Case struct {
+	Dispute  db.Dispute           `json:"dispute"`
+	Evidence []db.DisputeEvidence `json:"evidence"`
+	Events   []db.DisputeEvent    `json:"events"`
+}
+
+type Service struct {
+	store    db.Store
+	accounts *bank.Accounts
+	files    blob.Store
+	notifier notify.Notifier
+	policy   Policy
+}
+
+func NewService(store db.Store, accounts *bank.Accounts, files blob.Store, notifier notify.Notifier, policy Policy) *Service {
+	return &Service{store: store, accounts: accounts, files: files, notifier: notifier, policy: policy}
+}
+
+// FromConfig builds the service with its evidence on disk in
+// DISPUTE_STORAGE_DIR, using the defaults for the DISPUTE_* durations that
+// are not set.
+func FromConfig(store db.Store, accounts *bank.Accounts, notifier notify.Notifier, config utils.Config) *Service {
+	dir := config.DisputeStorageDir
+	if dir == "" {
+		dir = DefaultStorageDir
+	}
+
+	policy := Policy{
+		Window:                 config.DisputeWindow,
+		SLA:                    config.DisputeSLA,
+		SLAWarning:             config.DisputeSLAWarning,
+		ProvisionalCreditLimit: config.DisputeProvisionalCreditLimit,
+	}
+	if policy.Window <= 0 {
+		policy.Window = DefaultWindow
+	}
+	if policy.SLA <= 0 {
+		policy.SLA = DefaultSLA
+	}
+	if policy.SLAWarning <= 0 {
+		policy.SLAWarning = DefaultSLAWarning
+	}
+
+	return NewService(store, accounts, blob.NewDisk(dir), notifier, policy)
+}
+
+func (service *Service) Policy() Policy {
+	return service.policy
+}
+
+type OpenParams struct {
+	Username    string
+	TransferID  int64
+	Reason      string
+	Description string
+	// Amount is the amount disputed; 0 disputes the whole transfer.
+	Amount            int64
+	ProvisionalCredit bool
+	Files             []File
+}
+
+// Open opens a dispute on a transfer the user sent, due within the SLA.
+// Postings by the bank, such as chargebacks and overdraft interest, and
+// payments to the bank's own accounts cannot be disputed; a payment into
+// escrow is disputed through the escrow.
+func (service *Service) Open(ctx context.Context, arg OpenParams) (db.OpenDisputeTxResult, error) {
+	var result db.OpenDisputeTxResult
+
+	arg.Description = strings.TrimSpace(arg.Description)
+	if err := validateOpen(arg); err != nil {
+		return result, err
+	}
+
+	transfer, err := service.store.GetTransfer(ctx, arg.TransferID)
+	if err != nil {
+		return result, err
+	}
+
+	account, err := service.store.GetAccount(ctx, transfer.FromAccountID)
+	if err != nil {
+		return result, err
+	}
+
+	if account.Owner != arg.Username {
+		return result, fmt.Errorf("%w: transfer wasn't sent from an account of the authenticated user", domain.ErrForbidden)
+	}
+
+	if transfer.Kind != db.TransferPayment {
+		return result, fmt.Errorf("%w: the transfer was posted by the bank", domain.ErrNotDisputable)
+	}
+
+	toAccount, err := service.store.GetAccount(ctx, transfer.ToAccountID)
+	if err != nil {
+		return result, err
+	}
+
+	if toAccount.Owner == bank.Username {
+		if toAccount.Product == bank.ProductEscrow {
+			return result, fmt.Errorf("%w: a payment into escrow is disputed through the escrow", domain.ErrNotDisputable)
+		}
+		return result, fmt.Errorf("%w: the transfer was paid to the bank", domain.ErrNotDisputable)
+	}
+
+	if time.Since(transfer.CreatedAt) > service.policy.Window {
+		return result, fmt.Errorf("%w: transfers can be disputed within %s", domain.ErrDisputeWindowClosed, service.policy.Window)
+	}
+
+	if arg.Amount == 0 {
+		arg.Amount = transfer.Amount
+	}
+	if arg.Amount > transfer.Amount {
+		return result, domain.NewValidationError(domain.FieldViolation{Field: "amount", Description: fmt.Sprintf("must be at most the amount of the transfer, %d", transfer.Amount)})
+	}
+
+	var creditAccountID int64
+	if arg.ProvisionalCredit {
+		if arg.Amount > service.policy.ProvisionalCreditLimit {
+			return result, domain.NewValidationError(domain.FieldViolation{
+				Field:       "provisional_credit",
+				Description: fmt.Sprintf("is available for disputes of up to %d", service.policy.ProvisionalCreditLimit),
+			})
+		}
+
+		credits, err := service.accounts.Get(ctx, account.Currency, bank.ProductDisputeCredits)
+		if err != nil {
+			return result, err
+		}
+		creditAccountID = credits.ID
+	}
+
+	evidence := make([]db.CreateDisputeEvidenceParams, 0, len(arg.Files))
+	defer func() {
+		if err != nil {
+			service.deleteFiles(ctx, evidence)
+		}
+	}()
+
+	for i, file := range arg.Files {
+		var stored db.CreateDisputeEvidenceParams
+		stored, err = service.storeFile(ctx, arg.Username, file, fmt.Sprintf("files[%d]", i))
+		if err != nil {
+			return result, err
+		}
+		evidence = append(evidence, stored)
+	}
+
+	result, err = service.store.OpenDisputeTx(ctx, db.OpenDisputeTxParams{
+		CreateDisputeParams: db.CreateDisputeParams{
+			TransferID:  transfer.ID,
+			Username:    arg.Username,
+			Reason:      arg.Reason,
+			Description: arg.Description,
+			Amount:      arg.Amount,
+			Currency:    account.Currency,
+			DueAt:       time.Now().Add(service.policy.SLA),
+		},
+		Evidence:        evidence,
+		CreditAccountID: creditAccountID,
+	})
+	return result, err
+}
+
+func validateOpen(arg OpenParams) error {
+	var violations []domain.FieldViolation
+
+	if !slices.Contains(Reasons, arg.Reason) {
+		violations = append(violations, domain.FieldViolation{Field: "reason", Description: fmt.Sprintf("must be one of %s", strings.Join(Reasons, ", "))})
+	}
+	if len(arg.Description) > MaxDescriptionLength {
+		violations = append(violations, domain.FieldViolation{Field: "description", Description: fmt.Sprintf("must be at most %d characters", MaxDescriptionLength)})
+	}
+	if arg.Amount < 0 {
+		violations = append(violations, domain.FieldViolation{Field: "amount", Description: "must be positive"})
+	}
+	if len(arg.Files) > MaxFiles {
+		violations = append(violations, domain.FieldViolation{Field: "files", Description: fmt.Sprintf("must be at most %d", MaxFiles)})
+	}
+
+	if violations != nil {
+		return domain.NewValidationError(violations...)
+	}
+
+	return nil
+}
+
+type EvidenceParams struct {
+	DisputeID int64
+	Username  string
+	Note      string
+	// File is nil for a note alone.
+	File *File
+}
+
+// AddEvidence adds a note, a file or both to an undecided dispute of the
+// user.
+func (service *Service) AddEvidence(ctx context.Context, arg EvidenceParams) (db.DisputeEvidence, error) {
+	arg.Note = strings.TrimSpace(arg.Note)
+	if arg.Note == "" && arg.File == nil {
+		return db.DisputeEvidence{}, domain.NewValidationError(domain.FieldViolation{Field: "note", Description: "is required without a file"})
+	}
+	if len(arg.Note) > MaxDescriptionLength {
+		return db.DisputeEvidence{}, domain.NewValidationError(domain.FieldViolation{Field: "note", Description: fmt.Sprintf("must be at most %d characters", MaxDescriptionLength)})
+	}
+
+	dispute, err := service.get(ctx, arg.Username, arg.DisputeID)
+	if err != nil {
+		return db.DisputeEvidence{}, err
+	}
+
+	if !IsOpen(Status(dispute.Status)) {
+		return db.DisputeEvidence{}, fmt.Errorf("%w: dispute is %s", domain.ErrInvalidTransition, dispute.Status)
+	}
+
+	evidence := db.CreateDisputeEvidenceParams{}
+	if arg.File != nil {
+		evidence, err = service.storeFile(ctx, arg.Username, *arg.File, "file")
+		if err != nil {
+			return db.DisputeEvidence{}, err
+		}
+	}
+	evidence.DisputeID = dispute.ID
+	evidence.SubmittedBy = arg.Username
+	evidence.Note = arg.Note
+
+	created, err := service.store.CreateDisputeEvidence(ctx, evidence)
+	if err != nil {
+		service.deleteFiles(ctx, []db.CreateDisputeEvidenceParams{evidence})
+	}
+	return created, err
+}
+
+// IsOpen reports whether a dispute in status is still to be decided.
+func IsOpen(status Status) bool {
+	return len(transitions[status]) > 0
+}
+
+// storeFile checks the size and type of an evidence file and puts it in
+// the document store.
+func (service *Service) storeFile(ctx context.Context, username string, file File, field string) (db.CreateDisputeEvidenceParams, error) {
+	name := filepath.Base(file.FileName)
+	if name == "." || len(name) > MaxFileNameLength {
+		return db.CreateDisputeEvidenceParams{}, domain.NewValidationError(domain.FieldViolation{
+			Field:       field,
+			Description: fmt.Sprintf("file name must be 1 to %d characters", MaxFileNameLength),
+		})
+	}
+
+	content, err := io.ReadAll(io.LimitReader(file.Content, MaxEvidenceSize+1))
+	if err != nil {
+		return db.CreateDisputeEvidenceParams{}, err
+	}
+
+	if len(content) == 0 || len(content) > MaxEvidenceSize {
+		return db.CreateDisputeEvidenceParams{}, domain.NewValidationError(domain.FieldViolation{
+			Field:       field,
+			Description: fmt.Sprintf("must be 1 to %d bytes", MaxEvidenceSize),
+		})
+	}
+
+	contentType := http.DetectContentType(content)
+	if !slices.Contains(ContentTypes, contentType) {
+		return db.CreateDisputeEvidenceParams{}, domain.NewValidationError(domain.FieldViolation{
+			Field:       field,
+			Description: fmt.Sprintf("must be one of %s, not %s", strings.Join(ContentTypes, ", "), contentType),
+		})
+	}
+
+	sum := sha256.Sum256(content)
+	key := fmt.Sprintf("%s/%s", username, uuid.NewString())
+	if err := service.files.Put(ctx, key, bytes.NewReader(content)); err != nil {
+		return db.CreateDisputeEvidenceParams{}, err
+	}
+
+	return db.CreateDisputeEvidenceParams{
+		FileName:    name,
+		ContentType: contentType,
+		Size:        int64(len(content)),
+		Sha256:      hex.EncodeToString(sum[:]),
+		StorageKey:  key,
+	}, nil
+}
+
+func (service *Service) deleteFiles(ctx context.Context, evidence []db.CreateDisputeEvidenceParams) {
+	for _, file := range evidence {
+		if file.StorageKey == "" {
+			continue
+		}
+		if err := service.files.Delete(ctx, file.StorageKey); err != nil {
+			logging.FromContext(ctx).Warn().Err(err).Str("storage_key", file.StorageKey).Msg("cannot delete evidence of failed dispute")
+		}
+	}
+}
+
+// List returns the disputes of the user, newest first.
+func (service *Service) List(ctx context.Context, username string) ([]db.Dispute, error) {
+	return service.store.ListUserDisputes(ctx, username)
+}
+
+// Get returns a dispute of the user with its evidence and history.
+func (service *Service) Get(ctx context.Context, username string, id int64) (Case, error) {
+	if _, err := service.get(ctx, username, id); err != nil {
+		return Case{}, err
+	}
+
+	return service.Case(ctx, id)
+}
+
+func (service *Service) get(ctx context.Context, username string, id int64) (db.Dispute, error) {
+	dispute, err := service.store.GetDispute(ctx, id)
+	if err != nil {
+		return dispute, err
+	}
+
+	if dispute.Username != username {
+		return db.Dispute{}, fmt.Errorf("%w: dispute wasn't opened by the authenticated user", domain.ErrForbidden)
+	}
+
+	return dispute, nil
+}
+
+// Case returns any dispute with its evidence and history.
+func (service *Service) Case(ctx context.Context, id int64) (Case, error) {
+	dispute, err := service.store.GetDispute(ctx, id)
+	if err != nil {
+		return Case{}, err
+	}
+
+	evidence, err := service.store.ListDisputeEvidence(ctx, id)
+	if err != nil {
+		return Case{}, err
+	}
+
+	events, err := service.store.ListDisputeEvents(ctx, id)
+	if err != nil {
+		return Case{}, err
+	}
+
+	return Case{Dispute: dispute, Evidence: evidence, Events: events}, nil
+}
+
+// OpenEvidence returns an evidence file of a dispute and its content, which
+// the caller closes. A note without a file is not found.
+func (service *Service) OpenEvidence(ctx context.Context, disputeID, evidenceID int64) (db.DisputeEvidence, io.ReadCloser, error) {
+	evidence, err := service.store.GetDisputeEvidence(ctx, db.GetDisputeEvidenceParams{ID: evidenceID, DisputeID: disputeID})
+	if err != nil {
+		return evidence, nil, err
+	}
+
+	if evidence.StorageKey == "" {
+		return evidence, nil, fmt.Errorf("%w: evidence [%d] has no file", domain.ErrNotFound, evidence.ID)
+	}
+
+	content, err := service.files.Open(ctx, evidence.StorageKey)
+	return evidence, content, err
+}
+
+type QueueParams struct {
+	// Status and AssignedTo filter the disputes when not empty.
+	Status     string
+	AssignedTo string
+	AfterID    int64
+	PageSize   int32
+}
+
+// Queue lists disputes for support, oldest first.
+func (service *Service) Queue(ctx context.Context, arg QueueParams) ([]db.Dispute, error) {
+	return service.store.ListDisputes(ctx, db.ListDisputesParams{
+		AfterID:    arg.AfterID,
+		Status:     sql.NullString{String: arg.Status, Valid: arg.Status != ""},
+		AssignedTo: sql.NullString{String: arg.AssignedTo, Valid: arg.AssignedTo != ""},
+		PageLimit:  arg.PageSize,
+	})
+}
+
+// Review takes an open dispute under review and assigns it to the agent.
+func (service *Service) Review(ctx context.Context, id int64, agent string, note string) (db.TransitionDisputeTxResult, error) {
+	result, err := service.transition(ctx, transitionParams{ID: id, To: StatusUnderReview, Actor: agent, Note: note})
+	if err != nil {
+		return result, err
+	}
+
+	service.notifier.Notify(ctx, notify.Notification{
+		Username: result.Dispute.Username,
+		Kind:     KindUnderReview,
+		Message:  fmt.Sprintf("your dispute %d of transfer %d is under review", result.Dispute.ID, result.Dispute.TransferID),
+	})
+
+	return result, nil
+}
+
+type ResolveParams struct {
+	ID    int64
+	Agent string
+	// Outcome is StatusWon or StatusLost.
+	Outcome    Status
+	Resolution string
+}
+
+// Resolve decides an undecided dispute. The resolution is required and
+// shown to the customer.
+func (service *Service) Resolve(ctx context.Context, arg ResolveParams) (db.TransitionDisputeTxResult, error) {
+	var result db.TransitionDisputeTxResult
+
+	arg.Resolution = strings.TrimSpace(arg.Resolution)
+
+	var violations []domain.FieldViolation
+	if arg.Outcome != StatusWon && arg.Outcome != StatusLost {
+		violations = append(violations, domain.FieldViolation{Field: "outcome", Description: fmt.Sprintf("must be %s or %s", StatusWon, StatusLost)})
+	}
+	if arg.Resolution == "" || len(arg.Resolution) > MaxResolutionLength {
+		violations = append(violations, domain.FieldViolation{Field: "resolution", Description: fmt.Sprintf("must be 1 to %d characters", MaxResolutionLength)})
+	}
+	if violations != nil {
+		return result, domain.NewValidationError(violations...)
+	}
+
+	result, err := service.transition(ctx, transitionParams{ID: arg.ID, To: arg.Outcome, Actor: arg.Agent, Note: arg.Resolution})
+	if err != nil {
+		return result, err
+	}
+
+	notification := notify.Notification{
+		Username: result.Dispute.Username,
+		Kind:     KindWon,
+		Message: fmt.Sprintf("your dispute %d of transfer %d was decided in your favour, %d %s is credited back: %s",
+			result.Dispute.ID, result.Dispute.TransferID, result.Dispute.Amount, result.Dispute.Currency, arg.Resolution),
+	}
+	if arg.Outcome == StatusLost {
+		notification.Kind = KindLost
+		notification.Message = fmt.Sprintf("your dispute %d of transfer %d was declined: %s", result.Dispute.ID, result.Dispute.TransferID, arg.Resolution)
+	}
+	service.notifier.Notify(ctx, notification)
+
+	return result, nil
+}
+
+// Withdraw lets the customer drop an undecided dispute. A provisional
+// credit is taken back.
+func (service *Service) Withdraw(ctx context.Context, username string, id int64, note string) (db.TransitionDisputeTxResult, error) {
+	if _, err := service.get(ctx, username, id); err != nil {
+		return db.TransitionDisputeTxResult{}, err
+	}
+
+	result, err := service.transition(ctx, transitionParams{ID: id, To: StatusWithdrawn, Actor: username, Note: strings.TrimSpace(note)})
+	if err != nil {
+		return result, err
+	}
+
+	for _, recipient := range service.staff(ctx, result.Dispute) {
+		service.notifier.Notify(ctx, notify.Notification{
+			Username: recipient,
+			Kind:     KindWithdrawn,
+			Message:  fmt.Sprintf("dispute %d of transfer %d was withdrawn by %s", result.Dispute.ID, result.Dispute.TransferID, username),
+		})
+	}
+
+	return result, nil
+}
+
+type transitionParams struct {
+	ID    int64
+	To    Status
+	Actor string
+	Note  string
+}
+
+func (service *Service) transition(ctx context.Context, arg transitionParams) (db.TransitionDisputeTxResult, error) {
+	var result db.TransitionDisputeTxResult
+
+	if len(arg.Note) > MaxResolutionLength {
+		return result, domain.NewValidationError(domain.FieldViolation{Field: "note", Description: fmt.Sprintf("must be at most %d characters", MaxResolutionLength)})
+	}
+
+	dispute, err := service.store.GetDispute(ctx, arg.ID)
+	if err != nil {
+		return result, err
+	}
+
+	// the credits account is only used when money moves
+	var creditAccountID int64
+	if arg.To == StatusWon || (arg.To != StatusUnderReview && dispute.ProvisionalCreditTransferID.Valid) {
+		credits, err := service.accounts.Get(ctx, dispute.Currency, bank.ProductDisputeCredits)
+		if err != nil {
+			return result, err
+		}
+		creditAccountID = credits.ID
+	}
+
+	return service.store.TransitionDisputeTx(ctx, db.TransitionDisputeTxParams{
+		ID:              arg.ID,
+		Status:          string(arg.To),
+		Actor:           arg.Actor,
+		Note:            arg.Note,
+		CreditAccountID: creditAccountID,
+		Authorize: func(dispute db.Dispute) error {
+			if !CanTransition(Status(dispute.Status), arg.To) {
+				return fmt.Errorf("%w: dispute is %s", domain.ErrInvalidTransition, dispute.Status)
+			}
+			return nil
+		},
+	})
+}
+
+// staff returns who hears about a dispute: its assignee, or every admin
+// while it is unassigned.
+func (service *Service) staff(ctx context.Context, dispute db.Dispute) []string {
+	if dispute.AssignedTo.Valid {
+		return []string{dispute.AssignedTo.String}
+	}
+
+	admins, err := service.store.ListUsernamesByRole(ctx, bank.RoleAdmin)
+	if err != nil {
+		logging.FromContext(ctx).Error().Err(err).Int64("dispute_id", dispute.ID).Msg("cannot list admins to notify")
+		return nil
+	}
+
+	return admins
+}
+
+// CheckSLA warns the staff about the undecided disputes due within the
+// SLA warning and reports those past their due date, each once. It
+// returns the number of disputes it notified about.
+func (service *Service) CheckSLA(ctx context.Context) (int, error) {
+	notified := 0
+
+	for {
+		disputes, err := service.store.ClaimDisputeSLAWarnings(ctx, db.ClaimDisputeSLAWarningsParams{
+			WarnBefore: time.Now().Add(service.policy.SLAWarning),
+			PageLimit:  slaClaimPageSize,
+		})
+		if err != nil {
+			return notified, err
+		}
+
+		for _, dispute := range disputes {
+			service.notifyStaff(ctx, dispute, KindSLAWarning, fmt.Sprintf("dispute %d of transfer %d is due by %s",
+				dispute.ID, dispute.TransferID, dispute.DueAt.UTC().Format(time.RFC3339)))
+			notified++
+		}
+
+		if len(disputes) < int(slaClaimPageSize) {
+			break
+		}
+	}
+
+	for {
+		disputes, err := service.store.ClaimDisputeSLABreaches(ctx, slaClaimPageSize)
+		if err != nil {
+			return notified, err
+		}
+
+		for _, dispute := range disputes {
+			service.notifyStaff(ctx, dispute, KindSLABreached, fmt.Sprintf("dispute %d of transfer %d is overdue since %s",
+				dispute.ID, dispute.TransferID, dispute.DueAt.UTC().Format(time.RFC3339)))
+			notified++
+		}
+
+		if len(disputes) < int(slaClaimPageSize) {
+			return notified, nil
+		}
+	}
+}
+
+func (service *Service) notifyStaff(ctx context.Context, dispute db.Dispute, kind string, message string) {
+	for _, recipient := range service.staff(ctx, dispute) {
+		service.notifier.Notify(ctx, notify.Notification{Username: recipient, Kind: kind, Message: message})
+	}
+}
+
+// Overdue reports whether an undecided dispute is past its due date.
+func Overdue(dispute db.Dispute, now time.Time) bool {
+	return IsOpen(Status(dispute.Status)) && now.After(dispute.DueAt)
+}
@@ -0,0 +1,333 @@
+package test
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/NhutHuyDev/sgbank/internal/accountno"
+	"github.com/NhutHuyDev/sgbank/internal/bank"
+	"github.com/NhutHuyDev/sgbank/internal/blob"
+	"github.com/NhutHuyDev/sgbank/internal/dispute"
+	"github.com/NhutHuyDev/sgbank/internal/domain"
+	"github.com/NhutHuyDev/sgbank/internal/infra/db"
+	mockdb "github.com/NhutHuyDev/sgbank/internal/infra/db/mock"
+	"github.com/NhutHuyDev/sgbank/internal/notify"
+	"github.com/NhutHuyDev/sgbank/pkg/utils"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+// pngHeader is enough of a PNG file for its type to be detected.
+var pngHeader = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+
+type recordingNotifier struct {
+	notifications []notify.Notification
+}
+
+func (notifier *recordingNotifier) Notify(_ context.Context, notification notify.Notification) {
+	notifier.notifications = append(notifier.notifications, notification)
+}
+
+var policy = dispute.Policy{
+	Window:                 30 * 24 * time.Hour,
+	SLA:                    10 * 24 * time.Hour,
+	SLAWarning:             24 * time.Hour,
+	ProvisionalCreditLimit: 500,
+}
+
+var credits = db.Account{ID: 99, Owner: bank.Username, Currency: utils.USD, Product: bank.ProductDisputeCredits}
+
+var escrowAccount = db.Account{ID: 98, Owner: bank.Username, Currency: utils.USD, Product: bank.ProductEscrow}
+
+func newService(t *testing.T, store db.Store) (*dispute.Service, *recordingNotifier) {
+	generator, err := accountno.NewGenerator(accountno.DefaultBankCode, accountno.DefaultBranchCode)
+	require.NoError(t, err)
+
+	notifier := &recordingNotifier{}
+	return dispute.NewService(store, bank.NewAccounts(store, generator), blob.NewDisk(t.TempDir()), notifier, policy), notifier
+}
+
+func expectCredits(store *mockdb.MockStore) {
+	store.EXPECT().GetAccountByOwnerProduct(gomock.Any(), gomock.Eq(db.GetAccountByOwnerProductParams{
+		Owner:    bank.Username,
+		Currency: utils.USD,
+		Product:  bank.ProductDisputeCredits,
+	})).Times(1).Return(credits, nil)
+}
+
+func TestCanTransition(t *testing.T) {
+	require.True(t, dispute.CanTransition(dispute.StatusOpen, dispute.StatusUnderReview))
+	require.True(t, dispute.CanTransition(dispute.StatusOpen, dispute.StatusWon))
+	require.True(t, dispute.CanTransition(dispute.StatusUnderReview, dispute.StatusWithdrawn))
+	require.False(t, dispute.CanTransition(dispute.StatusUnderReview, dispute.StatusOpen))
+	require.False(t, dispute.CanTransition(dispute.StatusWon, dispute.StatusLost))
+	require.False(t, dispute.CanTransition(dispute.StatusWithdrawn, dispute.StatusUnderReview))
+}
+
+func TestOpen(t *testing.T) {
+	account := db.Account{ID: 1, Owner: "alice", Currency: utils.USD}
+	transfer := db.Transfer{ID: 7, FromAccountID: account.ID, ToAccountID: 2, Amount: 400, CreatedAt: time.Now().Add(-time.Hour), Kind: db.TransferPayment}
+
+	testCases := []struct {
+		name       string
+		arg        dispute.OpenParams
+		transfer   db.Transfer
+		buildStubs func(store *mockdb.MockStore)
+		check      func(t *testing.T, result db.OpenDisputeTxResult, err error)
+	}{
+		{
+			name:     "ProvisionalCreditWithEvidence",
+			arg:      dispute.OpenParams{Reason: "not_received", ProvisionalCredit: true, Files: []dispute.File{{FileName: "receipt.png", Content: bytes.NewReader(pngHeader)}}},
+			transfer: transfer,
+			buildStubs: func(store *mockdb.MockStore) {
+				expectCredits(store)
+				store.EXPECT().OpenDisputeTx(gomock.Any(), gomock.Any()).Times(1).
+					DoAndReturn(func(_ any, arg db.OpenDisputeTxParams) (db.OpenDisputeTxResult, error) {
+						require.Equal(t, "alice", arg.Username)
+						require.Equal(t, transfer.Amount, arg.Amount)
+						require.Equal(t, utils.USD, arg.Currency)
+						require.Equal(t, credits.ID, arg.CreditAccountID)
+						require.WithinDuration(t, time.Now().Add(policy.SLA), arg.DueAt, time.Minute)
+						require.Len(t, arg.Evidence, 1)
+						require.Equal(t, "image/png", arg.Evidence[0].ContentType)
+						require.NotEmpty(t, arg.Evidence[0].StorageKey)
+						return db.OpenDisputeTxResult{Dispute: db.Dispute{ID: 3}}, nil
+					})
+			},
+			check: func(t *testing.T, result db.OpenDisputeTxResult, err error) {
+				require.NoError(t, err)
+				require.EqualValues(t, 3, result.Dispute.ID)
+			},
+		},
+		{
+			name:     "PartialAmount",
+			arg:      dispute.OpenParams{Reason: "incorrect_amount", Amount: 100},
+			transfer: transfer,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().OpenDisputeTx(gomock.Any(), gomock.Any()).Times(1).
+					DoAndReturn(func(_ any, arg db.OpenDisputeTxParams) (db.OpenDisputeTxResult, error) {
+						require.EqualValues(t, 100, arg.Amount)
+						require.Zero(t, arg.CreditAccountID)
+						return db.OpenDisputeTxResult{}, nil
+					})
+			},
+			check: func(t *testing.T, result db.OpenDisputeTxResult, err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name:     "NotSender",
+			arg:      dispute.OpenParams{Reason: "other"},
+			transfer: db.Transfer{ID: 8, FromAccountID: 2, ToAccountID: account.ID, Amount: 100, CreatedAt: time.Now(), Kind: db.TransferPayment},
+			check: func(t *testing.T, result db.OpenDisputeTxResult, err error) {
+				require.ErrorIs(t, err, domain.ErrForbidden)
+			},
+		},
+		{
+			name:     "Chargeback",
+			arg:      dispute.OpenParams{Reason: "other"},
+			transfer: db.Transfer{ID: 11, FromAccountID: account.ID, ToAccountID: 2, Amount: 100, CreatedAt: time.Now(), Kind: db.TransferForced},
+			check: func(t *testing.T, result db.OpenDisputeTxResult, err error) {
+				require.ErrorIs(t, err, domain.ErrNotDisputable)
+			},
+		},
+		{
+			name:     "PaidToBank",
+			arg:      dispute.OpenParams{Reason: "other"},
+			transfer: db.Transfer{ID: 12, FromAccountID: account.ID, ToAccountID: credits.ID, Amount: 100, CreatedAt: time.Now(), Kind: db.TransferPayment},
+			check: func(t *testing.T, result db.OpenDisputeTxResult, err error) {
+				require.ErrorIs(t, err, domain.ErrNotDisputable)
+			},
+		},
+		{
+			name:     "Escrow",
+			arg:      dispute.OpenParams{Reason: "not_received"},
+			transfer: db.Transfer{ID: 13, FromAccountID: account.ID, ToAccountID: escrowAccount.ID, Amount: 100, CreatedAt: time.Now(), Kind: db.TransferPayment},
+			check: func(t *testing.T, result db.OpenDisputeTxResult, err error) {
+				require.ErrorIs(t, err, domain.ErrNotDisputable)
+				require.ErrorContains(t, err, "escrow")
+			},
+		},
+		{
+			name:     "WindowClosed",
+			arg:      dispute.OpenParams{Reason: "other"},
+			transfer: db.Transfer{ID: 9, FromAccountID: account.ID, ToAccountID: 2, Amount: 100, CreatedAt: time.Now().Add(-policy.Window - time.Hour), Kind: db.TransferPayment},
+			check: func(t *testing.T, result db.OpenDisputeTxResult, err error) {
+				require.ErrorIs(t, err, domain.ErrDisputeWindowClosed)
+			},
+		},
+		{
+			name:     "AmountAboveTransfer",
+			arg:      dispute.OpenParams{Reason: "other", Amount: 401},
+			transfer: transfer,
+			check: func(t *testing.T, result db.OpenDisputeTxResult, err error) {
+				require.ErrorIs(t, err, domain.ErrInvalidArgument)
+			},
+		},
+		{
+			name:     "ProvisionalCreditAboveLimit",
+			arg:      dispute.OpenParams{Reason: "other", ProvisionalCredit: true},
+			transfer: db.Transfer{ID: 10, FromAccountID: account.ID, ToAccountID: 2, Amount: 501, CreatedAt: time.Now(), Kind: db.TransferPayment},
+			check: func(t *testing.T, result db.OpenDisputeTxResult, err error) {
+				require.ErrorIs(t, err, domain.ErrInvalidArgument)
+			},
+		},
+		{
+			name:     "UnsupportedEvidence",
+			arg:      dispute.OpenParams{Reason: "other", Files: []dispute.File{{FileName: "notes.txt", Content: bytes.NewReader([]byte("plain text"))}}},
+			transfer: transfer,
+			check: func(t *testing.T, result db.OpenDisputeTxResult, err error) {
+				require.ErrorIs(t, err, domain.ErrInvalidArgument)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			service, _ := newService(t, store)
+
+			store.EXPECT().GetTransfer(gomock.Any(), gomock.Eq(tc.transfer.ID)).Times(1).Return(tc.transfer, nil)
+			store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).AnyTimes().
+				DoAndReturn(func(_ any, id int64) (db.Account, error) {
+					switch id {
+					case account.ID:
+						return account, nil
+					case credits.ID:
+						return credits, nil
+					case escrowAccount.ID:
+						return escrowAccount, nil
+					}
+					return db.Account{ID: id, Owner: "bob", Currency: utils.USD}, nil
+				})
+			if tc.buildStubs != nil {
+				tc.buildStubs(store)
+			} else {
+				store.EXPECT().OpenDisputeTx(gomock.Any(), gomock.Any()).Times(0)
+			}
+
+			tc.arg.Username = "alice"
+			tc.arg.TransferID = tc.transfer.ID
+			result, err := service.Open(context.Background(), tc.arg)
+			tc.check(t, result, err)
+		})
+	}
+}
+
+func TestOpenUnknownReason(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	service, _ := newService(t, store)
+
+	store.EXPECT().GetTransfer(gomock.Any(), gomock.Any()).Times(0)
+
+	_, err := service.Open(context.Background(), dispute.OpenParams{Username: "alice", TransferID: 1, Reason: "changed_my_mind"})
+	require.ErrorIs(t, err, domain.ErrInvalidArgument)
+}
+
+func TestResolve(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	service, notifier := newService(t, store)
+
+	open := db.Dispute{ID: 3, TransferID: 7, Username: "alice", Amount: 400, Currency: utils.USD, Status: db.DisputeUnderReview}
+	won := open
+	won.Status = db.DisputeWon
+
+	store.EXPECT().GetDispute(gomock.Any(), gomock.Eq(open.ID)).Times(1).Return(open, nil)
+	expectCredits(store)
+	store.EXPECT().TransitionDisputeTx(gomock.Any(), gomock.Any()).Times(1).
+		DoAndReturn(func(_ any, arg db.TransitionDisputeTxParams) (db.TransitionDisputeTxResult, error) {
+			require.Equal(t, db.DisputeWon, arg.Status)
+			require.Equal(t, "agent", arg.Actor)
+			require.Equal(t, credits.ID, arg.CreditAccountID)
+			require.NoError(t, arg.Authorize(open))
+			require.ErrorIs(t, arg.Authorize(won), domain.ErrInvalidTransition)
+			return db.TransitionDisputeTxResult{Dispute: won}, nil
+		})
+
+	ctx := context.Background()
+	_, err := service.Resolve(ctx, dispute.ResolveParams{ID: open.ID, Agent: "agent", Outcome: dispute.StatusWon, Resolution: "merchant did not reply"})
+	require.NoError(t, err)
+	require.Len(t, notifier.notifications, 1)
+	require.Equal(t, "alice", notifier.notifications[0].Username)
+	require.Equal(t, dispute.KindWon, notifier.notifications[0].Kind)
+
+	_, err = service.Resolve(ctx, dispute.ResolveParams{ID: open.ID, Agent: "agent", Outcome: dispute.StatusWithdrawn, Resolution: "no"})
+	require.ErrorIs(t, err, domain.ErrInvalidArgument)
+
+	_, err = service.Resolve(ctx, dispute.ResolveParams{ID: open.ID, Agent: "agent", Outcome: dispute.StatusLost})
+	require.ErrorIs(t, err, domain.ErrInvalidArgument)
+}
+
+func TestWithdrawNotifiesAdmins(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	service, notifier := newService(t, store)
+
+	open := db.Dispute{ID: 3, TransferID: 7, Username: "alice", Amount: 400, Currency: utils.USD, Status: db.DisputeOpen}
+	withdrawn := open
+	withdrawn.Status = db.DisputeWithdrawn
+
+	store.EXPECT().GetDispute(gomock.Any(), gomock.Eq(open.ID)).Times(2).Return(open, nil)
+	store.EXPECT().TransitionDisputeTx(gomock.Any(), gomock.Any()).Times(1).
+		DoAndReturn(func(_ any, arg db.TransitionDisputeTxParams) (db.TransitionDisputeTxResult, error) {
+			// no provisional credit to take back
+			require.Zero(t, arg.CreditAccountID)
+			return db.TransitionDisputeTxResult{Dispute: withdrawn}, nil
+		})
+	store.EXPECT().ListUsernamesByRole(gomock.Any(), gomock.Eq(bank.RoleAdmin)).Times(1).Return([]string{"root", "ops"}, nil)
+
+	_, err := service.Withdraw(context.Background(), "alice", open.ID, "")
+	require.NoError(t, err)
+	require.Len(t, notifier.notifications, 2)
+	require.Equal(t, dispute.KindWithdrawn, notifier.notifications[1].Kind)
+
+	store.EXPECT().GetDispute(gomock.Any(), gomock.Eq(open.ID)).Times(1).Return(open, nil)
+	_, err = service.Withdraw(context.Background(), "bob", open.ID, "")
+	require.ErrorIs(t, err, domain.ErrForbidden)
+}
+
+func TestCheckSLA(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	service, notifier := newService(t, store)
+
+	due := db.Dispute{ID: 3, TransferID: 7, Status: db.DisputeUnderReview, AssignedTo: sql.NullString{String: "agent", Valid: true}, DueAt: time.Now().Add(time.Hour)}
+	overdue := db.Dispute{ID: 4, TransferID: 8, Status: db.DisputeOpen, DueAt: time.Now().Add(-time.Hour)}
+
+	store.EXPECT().ClaimDisputeSLAWarnings(gomock.Any(), gomock.Any()).Times(1).
+		DoAndReturn(func(_ any, arg db.ClaimDisputeSLAWarningsParams) ([]db.Dispute, error) {
+			require.WithinDuration(t, time.Now().Add(policy.SLAWarning), arg.WarnBefore, time.Minute)
+			return []db.Dispute{due}, nil
+		})
+	store.EXPECT().ClaimDisputeSLABreaches(gomock.Any(), gomock.Any()).Times(1).Return([]db.Dispute{overdue}, nil)
+	store.EXPECT().ListUsernamesByRole(gomock.Any(), gomock.Eq(bank.RoleAdmin)).Times(1).Return([]string{"root"}, nil)
+
+	notified, err := service.CheckSLA(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 2, notified)
+	require.Equal(t, []notify.Notification{
+		{Username: "agent", Kind: dispute.KindSLAWarning, Message: notifier.notifications[0].Message},
+		{Username: "root", Kind: dispute.KindSLABreached, Message: notifier.notifications[1].Message},
+	}, notifier.notifications)
+
+	require.True(t, dispute.Overdue(overdue, time.Now()))
+	require.False(t, dispute.Overdue(due, time.Now()))
+}
@@ -0,0 +1,42 @@
+package dispute
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+const DefaultCheckInterval = time.Minute
+
+// Worker sends the SLA warnings and breach notifications of disputes. Each
+// is claimed by one worker, so several instances may run at once.
+type Worker struct {
+	service  *Service
+	interval time.Duration
+}
+
+func NewWorker(service *Service, interval time.Duration) *Worker {
+	if interval <= 0 {
+		interval = DefaultCheckInterval
+	}
+
+	return &Worker{service: service, interval: interval}
+}
+
+func (worker *Worker) Run(ctx context.Context) error {
+	ticker := time.NewTicker(worker.interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := worker.service.CheckSLA(ctx); err != nil {
+			log.Error().Err(err).Msg("cannot check dispute SLAs")
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
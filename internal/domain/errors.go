@@ -0,0 +1,111 @@
+package domain
+
+import (
+	"errors"
+	"net/http"
+
+	"google.golang.org/grpc/codes"
+)
+
+// Kind groups errors that clients handle the same way. Each kind maps to
+// exactly one HTTP status and one gRPC code, so the REST and gRPC APIs always
+// agree on how an error is reported.
+type Kind int
+
+const (
+	KindInternal Kind = iota
+	KindInvalidArgument
+	KindUnauthenticated
+	KindPermissionDenied
+	KindNotFound
+	KindConflict
+	KindAborted
+	KindFailedPrecondition
+	KindResourceExhausted
+)
+
+var kindStatus = map[Kind]struct {
+	httpStatus int
+	grpcCode   codes.Code
+}{
+	KindInternal:           {http.StatusInternalServerError, codes.Internal},
+	KindInvalidArgument:    {http.StatusBadRequest, codes.InvalidArgument},
+	KindUnauthenticated:    {http.StatusUnauthorized, codes.Unauthenticated},
+	KindPermissionDenied:   {http.StatusForbidden, codes.PermissionDenied},
+	KindNotFound:           {http.StatusNotFound, codes.NotFound},
+	KindConflict:           {http.StatusConflict, codes.AlreadyExists},
+	KindAborted:            {http.StatusConflict, codes.Aborted},
+	KindFailedPrecondition: {http.StatusUnprocessableEntity, codes.FailedPrecondition},
+	KindResourceExhausted:  {http.StatusTooManyRequests, codes.ResourceExhausted},
+}
+
+func (kind Kind) HTTPStatus() int {
+	return kindStatus[kind].httpStatus
+}
+
+func (kind Kind) GRPCCode() codes.Code {
+	return kindStatus[kind].grpcCode
+}
+
+// Error is a domain error. Code is the stable machine-readable identifier
+// reported by both APIs; Message is the human-readable summary.
+type Error struct {
+	Kind    Kind
+	Code    string
+	Message string
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+var (
+	ErrInternal                   = &Error{Kind: KindInternal, Code: "internal", Message: "internal error"}
+	ErrInvalidArgument            = &Error{Kind: KindInvalidArgument, Code: "invalid_argument", Message: "invalid parameters"}
+	ErrUnauthenticated            = &Error{Kind: KindUnauthenticated, Code: "unauthenticated", Message: "unauthenticated"}
+	ErrInvalidCredential          = &Error{Kind: KindUnauthenticated, Code: "invalid_credentials", Message: "incorrect username or password"}
+	ErrForbidden                  = &Error{Kind: KindPermissionDenied, Code: "forbidden", Message: "permission denied"}
+	ErrNotFound                   = &Error{Kind: KindNotFound, Code: "not_found", Message: "resource not found"}
+	ErrAccountNotFound            = &Error{Kind: KindNotFound, Code: "account_not_found", Message: "account not found"}
+	ErrUserNotFound               = &Error{Kind: KindNotFound, Code: "user_not_found", Message: "user not found"}
+	ErrSessionNotFound            = &Error{Kind: KindNotFound, Code: "session_not_found", Message: "session not found"}
+	ErrTransferNotFound           = &Error{Kind: KindNotFound, Code: "transfer_not_found", Message: "transfer not found"}
+	ErrEntryNotFound              = &Error{Kind: KindNotFound, Code: "entry_not_found", Message: "entry not found"}
+	ErrBeneficiaryNotFound        = &Error{Kind: KindNotFound, Code: "beneficiary_not_found", Message: "beneficiary not found"}
+	ErrPaymentRequestNotFound     = &Error{Kind: KindNotFound, Code: "payment_request_not_found", Message: "payment request not found"}
+	ErrTransferBatchNotFound      = &Error{Kind: KindNotFound, Code: "transfer_batch_not_found", Message: "transfer batch not found"}
+	ErrCategorizationRuleNotFound = &Error{Kind: KindNotFound, Code: "categorization_rule_not_found", Message: "categorization rule not found"}
+	ErrFraudDecisionNotFound      = &Error{Kind: KindNotFound, Code: "fraud_decision_not_found", Message: "fraud decision not found"}
+	ErrKYCSubmissionNotFound      = &Error{Kind: KindNotFound, Code: "kyc_submission_not_found", Message: "verification submission not found"}
+	ErrDisputeNotFound            = &Error{Kind: KindNotFound, Code: "dispute_not_found", Message: "dispute not found"}
+	ErrEscrowNotFound             = &Error{Kind: KindNotFound, Code: "escrow_not_found", Message: "escrow not found"}
+	ErrReferenceNotFound          = &Error{Kind: KindNotFound, Code: "reference_not_found", Message: "referenced resource not found"}
+	ErrDuplicate                  = &Error{Kind: KindConflict, Code: "duplicate", Message: "resource already exists"}
+	ErrTxConflict                 = &Error{Kind: KindAborted, Code: "transaction_conflict", Message: "transaction aborted by a concurrent update, try again"}
+	ErrInsufficientFunds          = &Error{Kind: KindFailedPrecondition, Code: "insufficient_funds", Message: "the balance of the from account is insufficient"}
+	ErrCurrencyMismatch           = &Error{Kind: KindInvalidArgument, Code: "currency_mismatch", Message: "currency mismatch"}
+	ErrRecipientNotFound          = &Error{Kind: KindNotFound, Code: "recipient_not_found", Message: "no recipient matches the identifier in the requested currency"}
+	ErrTooManyRequests            = &Error{Kind: KindResourceExhausted, Code: "too_many_requests", Message: "too many requests, try again later"}
+	ErrInvalidTransition          = &Error{Kind: KindFailedPrecondition, Code: "invalid_state_transition", Message: "the action is not allowed in the current state"}
+	ErrBatchInProgress            = &Error{Kind: KindFailedPrecondition, Code: "transfer_batch_in_progress", Message: "the transfer batch is still being processed"}
+	ErrCoolingOffLimit            = &Error{Kind: KindFailedPrecondition, Code: "cooling_off_limit", Message: "the amount exceeds the limit for a newly added beneficiary"}
+	ErrBusinessDayClosed          = &Error{Kind: KindFailedPrecondition, Code: "business_day_closed", Message: "the business day is closed to postings"}
+	ErrTransferBlocked            = &Error{Kind: KindPermissionDenied, Code: "transfer_blocked", Message: "the transfer was blocked by the risk rules"}
+	ErrEODInProgress              = &Error{Kind: KindFailedPrecondition, Code: "end_of_day_in_progress", Message: "the end of day of an earlier business date has not finished"}
+	ErrSanctionsMatch             = &Error{Kind: KindPermissionDenied, Code: "sanctions_match", Message: "the name matches an entry of a sanctions list"}
+	ErrSanctionsListInvalid       = &Error{Kind: KindFailedPrecondition, Code: "sanctions_list_invalid", Message: "a sanctions list file cannot be read"}
+	ErrKYCLimitExceeded           = &Error{Kind: KindFailedPrecondition, Code: "kyc_limit_exceeded", Message: "the operation exceeds the limits of the verification level"}
+	ErrDisputeWindowClosed        = &Error{Kind: KindFailedPrecondition, Code: "dispute_window_closed", Message: "the transfer is too old to be disputed"}
+	ErrNotDisputable              = &Error{Kind: KindFailedPrecondition, Code: "transfer_not_disputable", Message: "the transfer cannot be disputed"}
+)
+
+// Lookup returns the domain error in err's chain, or ErrInternal when err
+// did not originate from the domain.
+func Lookup(err error) *Error {
+	var domainErr *Error
+	if errors.As(err, &domainErr) {
+		return domainErr
+	}
+
+	return ErrInternal
+}
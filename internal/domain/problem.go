@@ -0,0 +1,46 @@
+package domain
+
+import "errors"
+
+const (
+	ProblemContentType = "application/problem+json"
+	problemTypePrefix  = "urn:sgbank:problem:"
+)
+
+// Problem is an RFC 7807 problem details body. Code carries the same
+// identifier the gRPC API reports in errdetails.ErrorInfo.Reason.
+type Problem struct {
+	Type          string           `json:"type"`
+	Title         string           `json:"title"`
+	Status        int              `json:"status"`
+	Detail        string           `json:"detail,omitempty"`
+	Instance      string           `json:"instance,omitempty"`
+	Code          string           `json:"code"`
+	RequestID     string           `json:"request_id,omitempty"`
+	InvalidParams []FieldViolation `json:"invalid_params,omitempty"`
+}
+
+// NewProblem builds the problem details for err. Internal errors are reported
+// without detail so driver messages never reach clients.
+func NewProblem(err error) Problem {
+	domainErr := Lookup(err)
+
+	problem := Problem{
+		Type:   problemTypePrefix + domainErr.Code,
+		Title:  domainErr.Message,
+		Status: domainErr.Kind.HTTPStatus(),
+		Detail: err.Error(),
+		Code:   domainErr.Code,
+	}
+
+	var validationErr *ValidationError
+	if errors.As(err, &validationErr) {
+		problem.InvalidParams = validationErr.Violations
+	}
+
+	if domainErr.Kind == KindInternal {
+		problem.Detail = ""
+	}
+
+	return problem
+}
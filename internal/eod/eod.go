@@ -0,0 +1,307 @@
+// Package eod closes business days. Postings go to the one open business
+// day; closing it opens the next day and then runs the end-of-day steps of
+// the closed date in order. Each step is recorded in eod_steps, so a run that
+// failed or whose instance stopped resumes from the first step that has not
+// succeeded, and the day is closed once they all have.
+package eod
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/NhutHuyDev/sgbank/internal/domain"
+	"github.com/NhutHuyDev/sgbank/internal/infra/db"
+	"github.com/NhutHuyDev/sgbank/internal/interest"
+	"github.com/NhutHuyDev/sgbank/pkg/utils"
+)
+
+// Statuses of business days.
+const (
+	DayOpen    = "open"
+	DayClosing = "closing"
+	DayClosed  = "closed"
+)
+
+// Statuses of end-of-day steps.
+const (
+	StepPending   = "pending"
+	StepRunning   = "running"
+	StepSucceeded = "succeeded"
+	StepFailed    = "failed"
+)
+
+// Steps every service runs first and, with FromConfig, next.
+const (
+	StepDailyBalances = "daily-balances"
+	StepInterest      = "interest"
+)
+
+// Lease is how long a step may run before another worker takes it over,
+// e.g. after a crash.
+const Lease = 30 * time.Minute
+
+// StepFunc runs an end-of-day step for a business date. It may run again for
+// the same date after a failure, so it must be idempotent.
+type StepFunc func(ctx context.Context, businessDate time.Time) error
+
+type step struct {
+	name string
+	run  StepFunc
+}
+
+// Day is a business day with its end-of-day steps, which are empty until
+// the day starts closing.
+type Day struct {
+	db.BusinessDay
+	Steps []db.EodStep `json:"steps"`
+}
+
+// Closed reports whether every step of the day succeeded.
+func (day Day) Closed() bool {
+	return day.Status == DayClosed
+}
+
+// Failed returns the step of the day that failed, if any.
+func (day Day) Failed() (db.EodStep, bool) {
+	for _, step := range day.Steps {
+		if step.Status == StepFailed {
+			return step, true
+		}
+	}
+
+	return db.EodStep{}, false
+}
+
+// Service closes business days and runs their steps. Steps are registered
+// before the service is used; the built-in first step records the balance
+// of every account at the end of the day in daily_balances.
+type Service struct {
+	store db.Store
+	steps []step
+}
+
+func NewService(store db.Store) *Service {
+	service := &Service{store: store}
+	service.Register(StepDailyBalances, service.snapshotBalances)
+	return service
+}
+
+// FromConfig returns a service that accrues and capitalizes interest after
+// recording the daily balances.
+func FromConfig(store db.Store, config utils.Config) (*Service, error) {
+	interestService, err := interest.FromConfig(store, config)
+	if err != nil {
+		return nil, err
+	}
+
+	service := NewService(store)
+	service.Register(StepInterest, interestService.Run)
+	return service, nil
+}
+
+// Register adds a step run after the steps registered before it. Days that
+// already started closing keep the steps they started with. It panics when
+// the name is taken.
+func (service *Service) Register(name string, run StepFunc) {
+	for _, s := range service.steps {
+		if s.name == name {
+			panic(fmt.Sprintf("eod: step %q registered twice", name))
+		}
+	}
+
+	service.steps = append(service.steps, step{name: name, run: run})
+}
+
+// Steps returns the names of the registered steps in the order they run.
+func (service *Service) Steps() []string {
+	names := make([]string, 0, len(service.steps))
+	for _, s := range service.steps {
+		names = append(names, s.name)
+	}
+
+	return names
+}
+
+// BusinessDate returns the open business day, which postings go to.
+func (service *Service) BusinessDate(ctx context.Context) (db.BusinessDay, error) {
+	return service.store.GetOpenBusinessDay(ctx)
+}
+
+// Closing returns the business day that is closing, or ErrNotFound.
+func (service *Service) Closing(ctx context.Context) (Day, error) {
+	day, err := service.store.GetClosingBusinessDay(ctx)
+	if err != nil {
+		return Day{}, err
+	}
+
+	return service.day(ctx, day)
+}
+
+// Day returns a business day with its steps.
+func (service *Service) Day(ctx context.Context, businessDate time.Time) (Day, error) {
+	day, err := service.store.GetBusinessDay(ctx, interest.Date(businessDate))
+	if err != nil {
+		return Day{}, err
+	}
+
+	return service.day(ctx, day)
+}
+
+func (service *Service) day(ctx context.Context, day db.BusinessDay) (Day, error) {
+	steps, err := service.store.ListEODSteps(ctx, day.BusinessDate)
+	if err != nil {
+		return Day{}, err
+	}
+
+	return Day{BusinessDay: day, Steps: steps}, nil
+}
+
+// Close starts closing the open business day and runs its steps. It fails
+// with ErrEODInProgress while an earlier day is closing. A failed step does
+// not fail Close: the day is returned still closing, with the error on the
+// step, and Resume runs it again.
+func (service *Service) Close(ctx context.Context) (Day, error) {
+	open, err := service.store.GetOpenBusinessDay(ctx)
+	if err != nil {
+		return Day{}, err
+	}
+
+	result, err := service.store.CloseBusinessDayTx(ctx, db.CloseBusinessDayTxParams{
+		BusinessDate: open.BusinessDate,
+		Steps:        service.Steps(),
+	})
+	if err != nil {
+		return Day{}, err
+	}
+
+	return service.run(ctx, Day{BusinessDay: result.Closing, Steps: result.Steps})
+}
+
+// Resume runs the steps of the closing business day from the first one
+// that has not succeeded. It fails with ErrNotFound when no day is closing.
+func (service *Service) Resume(ctx context.Context) (Day, error) {
+	day, err := service.Closing(ctx)
+	if errors.Is(err, domain.ErrNotFound) {
+		return Day{}, fmt.Errorf("%w: no business day is closing", domain.ErrNotFound)
+	}
+	if err != nil {
+		return Day{}, err
+	}
+
+	return service.run(ctx, day)
+}
+
+// RunDue resumes the closing business day, then closes every open day that
+// ended before now, one at a time. It stops at the first day whose steps do
+// not all succeed, and returns the error of its failed step; a step running
+// on another instance is not an error.
+func (service *Service) RunDue(ctx context.Context, now time.Time) error {
+	day, err := service.Resume(ctx)
+	switch {
+	case errors.Is(err, domain.ErrNotFound):
+	case err != nil:
+		return err
+	case !day.Closed():
+		return stepError(day)
+	}
+
+	today := interest.Date(now)
+	for {
+		open, err := service.store.GetOpenBusinessDay(ctx)
+		if err != nil {
+			return err
+		}
+		if !open.BusinessDate.Before(today) {
+			return nil
+		}
+
+		day, err := service.Close(ctx)
+		if err != nil {
+			return err
+		}
+		if !day.Closed() {
+			return stepError(day)
+		}
+	}
+}
+
+func stepError(day Day) error {
+	step, ok := day.Failed()
+	if !ok {
+		return nil
+	}
+
+	return fmt.Errorf("business date %s: step %s failed: %s", day.BusinessDate.Format(time.DateOnly), step.Name, step.Error)
+}
+
+// run runs the steps of a closing day in order and closes the day once they
+// all succeeded. It stops at a step that fails, or that another worker is
+// running.
+func (service *Service) run(ctx context.Context, day Day) (Day, error) {
+	for _, s := range day.Steps {
+		if s.Status == StepSucceeded {
+			continue
+		}
+
+		claimed, err := service.store.ClaimEODStep(ctx, db.ClaimEODStepParams{
+			BusinessDate: day.BusinessDate,
+			Name:         s.Name,
+			StaleBefore:  time.Now().Add(-Lease),
+		})
+		if errors.Is(err, domain.ErrNotFound) {
+			return service.day(ctx, day.BusinessDay)
+		}
+		if err != nil {
+			return Day{}, err
+		}
+
+		status, message := StepSucceeded, ""
+		if err := service.runStep(ctx, claimed.Name, day.BusinessDate); err != nil {
+			status, message = StepFailed, err.Error()
+		}
+
+		_, err = service.store.FinishEODStep(ctx, db.FinishEODStepParams{
+			BusinessDate: day.BusinessDate,
+			Name:         claimed.Name,
+			Status:       status,
+			Error:        message,
+		})
+		if err != nil {
+			return Day{}, err
+		}
+
+		if status == StepFailed {
+			return service.day(ctx, day.BusinessDay)
+		}
+	}
+
+	closed, err := service.store.FinishClosingBusinessDay(ctx, day.BusinessDate)
+	if errors.Is(err, domain.ErrNotFound) {
+		// another worker closed it first
+		return service.Day(ctx, day.BusinessDate)
+	}
+	if err != nil {
+		return Day{}, err
+	}
+
+	return service.day(ctx, closed)
+}
+
+func (service *Service) runStep(ctx context.Context, name string, businessDate time.Time) error {
+	for _, s := range service.steps {
+		if s.name == name {
+			return s.run(ctx, businessDate)
+		}
+	}
+
+	return fmt.Errorf("step %s is not registered", name)
+}
+
+// snapshotBalances records the balance of every account at the end of
+// businessDate.
+func (service *Service) snapshotBalances(ctx context.Context, businessDate time.Time) error {
+	_, err := service.store.SnapshotDailyBalances(ctx, businessDate)
+	return err
+}
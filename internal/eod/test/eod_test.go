@@ -0,0 +1,138 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/NhutHuyDev/sgbank/internal/domain"
+	"github.com/NhutHuyDev/sgbank/internal/eod"
+	"github.com/NhutHuyDev/sgbank/internal/infra/db"
+	mockdb "github.com/NhutHuyDev/sgbank/internal/infra/db/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+var businessDate = time.Date(2024, time.October, 31, 0, 0, 0, 0, time.UTC)
+
+func step(name string, position int32, status string) db.EodStep {
+	return db.EodStep{BusinessDate: businessDate, Name: name, Position: position, Status: status}
+}
+
+func TestClose(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	service := eod.NewService(store)
+
+	var ran []time.Time
+	service.Register("statements", func(_ context.Context, date time.Time) error {
+		ran = append(ran, date)
+		return nil
+	})
+	require.Equal(t, []string{eod.StepDailyBalances, "statements"}, service.Steps())
+
+	steps := []db.EodStep{step(eod.StepDailyBalances, 1, eod.StepPending), step("statements", 2, eod.StepPending)}
+	store.EXPECT().GetOpenBusinessDay(gomock.Any()).Times(1).Return(db.BusinessDay{BusinessDate: businessDate, Status: eod.DayOpen}, nil)
+	store.EXPECT().
+		CloseBusinessDayTx(gomock.Any(), gomock.Eq(db.CloseBusinessDayTxParams{BusinessDate: businessDate, Steps: service.Steps()})).
+		Times(1).
+		Return(db.CloseBusinessDayTxResult{Closing: db.BusinessDay{BusinessDate: businessDate, Status: eod.DayClosing}, Steps: steps}, nil)
+
+	gomock.InOrder(
+		store.EXPECT().ClaimEODStep(gomock.Any(), gomock.Any()).Times(1).Return(steps[0], nil),
+		store.EXPECT().SnapshotDailyBalances(gomock.Any(), gomock.Eq(businessDate)).Times(1).Return(int64(3), nil),
+		store.EXPECT().FinishEODStep(gomock.Any(), gomock.Eq(db.FinishEODStepParams{
+			BusinessDate: businessDate,
+			Name:         eod.StepDailyBalances,
+			Status:       eod.StepSucceeded,
+		})).Times(1),
+		store.EXPECT().ClaimEODStep(gomock.Any(), gomock.Any()).Times(1).Return(steps[1], nil),
+		store.EXPECT().FinishEODStep(gomock.Any(), gomock.Eq(db.FinishEODStepParams{
+			BusinessDate: businessDate,
+			Name:         "statements",
+			Status:       eod.StepSucceeded,
+		})).Times(1),
+		store.EXPECT().
+			FinishClosingBusinessDay(gomock.Any(), gomock.Eq(businessDate)).
+			Times(1).
+			Return(db.BusinessDay{BusinessDate: businessDate, Status: eod.DayClosed}, nil),
+	)
+	store.EXPECT().ListEODSteps(gomock.Any(), gomock.Eq(businessDate)).Times(1).Return(steps, nil)
+
+	day, err := service.Close(context.Background())
+	require.NoError(t, err)
+	require.True(t, day.Closed())
+	require.Equal(t, []time.Time{businessDate}, ran)
+}
+
+func TestResumeFromFailedStep(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	service := eod.NewService(store)
+	service.Register("statements", func(context.Context, time.Time) error {
+		return errors.New("disk full")
+	})
+
+	closing := db.BusinessDay{BusinessDate: businessDate, Status: eod.DayClosing}
+	failed := step("statements", 2, eod.StepFailed)
+	failed.Error = "disk full"
+
+	store.EXPECT().GetClosingBusinessDay(gomock.Any()).Times(1).Return(closing, nil)
+	gomock.InOrder(
+		store.EXPECT().
+			ListEODSteps(gomock.Any(), gomock.Eq(businessDate)).
+			Times(1).
+			Return([]db.EodStep{step(eod.StepDailyBalances, 1, eod.StepSucceeded), step("statements", 2, eod.StepFailed)}, nil),
+		store.EXPECT().
+			ListEODSteps(gomock.Any(), gomock.Eq(businessDate)).
+			Times(1).
+			Return([]db.EodStep{step(eod.StepDailyBalances, 1, eod.StepSucceeded), failed}, nil),
+	)
+	// the step that succeeded is not run again
+	store.EXPECT().SnapshotDailyBalances(gomock.Any(), gomock.Any()).Times(0)
+	store.EXPECT().
+		ClaimEODStep(gomock.Any(), gomock.Any()).
+		Times(1).
+		DoAndReturn(func(_ context.Context, arg db.ClaimEODStepParams) (db.EodStep, error) {
+			require.Equal(t, "statements", arg.Name)
+			require.WithinDuration(t, time.Now().Add(-eod.Lease), arg.StaleBefore, time.Minute)
+			return step("statements", 2, eod.StepRunning), nil
+		})
+	store.EXPECT().
+		FinishEODStep(gomock.Any(), gomock.Eq(db.FinishEODStepParams{
+			BusinessDate: businessDate,
+			Name:         "statements",
+			Status:       eod.StepFailed,
+			Error:        "disk full",
+		})).
+		Times(1)
+	store.EXPECT().FinishClosingBusinessDay(gomock.Any(), gomock.Any()).Times(0)
+
+	day, err := service.Resume(context.Background())
+	require.NoError(t, err)
+	require.False(t, day.Closed())
+
+	failedStep, ok := day.Failed()
+	require.True(t, ok)
+	require.Equal(t, "statements", failedStep.Name)
+}
+
+func TestRunDue(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	service := eod.NewService(store)
+
+	store.EXPECT().GetClosingBusinessDay(gomock.Any()).Times(1).Return(db.BusinessDay{}, domain.ErrNotFound)
+	store.EXPECT().GetOpenBusinessDay(gomock.Any()).Times(1).Return(db.BusinessDay{BusinessDate: businessDate, Status: eod.DayOpen}, nil)
+	store.EXPECT().CloseBusinessDayTx(gomock.Any(), gomock.Any()).Times(0)
+
+	// the open day has not ended yet
+	require.NoError(t, service.RunDue(context.Background(), businessDate.Add(23*time.Hour)))
+}
@@ -0,0 +1,43 @@
+package eod
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+const DefaultRunInterval = time.Minute
+
+// Worker closes the business days that have ended on every tick and
+// resumes a day whose steps did not all succeed. Steps are claimed one
+// worker at a time, so several instances may run at once.
+type Worker struct {
+	service  *Service
+	interval time.Duration
+}
+
+func NewWorker(service *Service, interval time.Duration) *Worker {
+	if interval <= 0 {
+		interval = DefaultRunInterval
+	}
+
+	return &Worker{service: service, interval: interval}
+}
+
+func (worker *Worker) Run(ctx context.Context) error {
+	ticker := time.NewTicker(worker.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := worker.service.RunDue(ctx, time.Now()); err != nil {
+			log.Error().Err(err).Msg("cannot run end of day")
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
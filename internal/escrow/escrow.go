@@ -0,0 +1,469 @@
+// Package escrow holds a payment until the payer receives what they paid
+// for. The amount moves from the payer's account into the bank's escrow
+// account at once, cleared and charged a fee like any other payment unless
+// the fraud rules hold it for review. It is released to the payee when the
+// payer confirms or when the escrow times out, and refunded to the payer
+// when the payee cancels. A payer who disputes an escrow stops the timeout,
+// and an admin then releases or refunds it.
+package escrow
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/NhutHuyDev/sgbank/internal/bank"
+	"github.com/NhutHuyDev/sgbank/internal/domain"
+	"github.com/NhutHuyDev/sgbank/internal/infra/db"
+	"github.com/NhutHuyDev/sgbank/internal/logging"
+	"github.com/NhutHuyDev/sgbank/internal/memo"
+	"github.com/NhutHuyDev/sgbank/internal/notify"
+	"github.com/NhutHuyDev/sgbank/internal/payment"
+	"github.com/NhutHuyDev/sgbank/pkg/utils"
+)
+
+type Status string
+
+const (
+	StatusHeld     Status = db.EscrowHeld
+	StatusDisputed Status = db.EscrowDisputed
+	StatusReleased Status = db.EscrowReleased
+	StatusRefunded Status = db.EscrowRefunded
+)
+
+// transitions lists the statuses an escrow may move to from each status.
+// Released and refunded are final.
+var transitions = map[Status][]Status{
+	StatusHeld:     {StatusReleased, StatusRefunded, StatusDisputed},
+	StatusDisputed: {StatusReleased, StatusRefunded},
+}
+
+func CanTransition(from Status, to Status) bool {
+	return slices.Contains(transitions[from], to)
+}
+
+// Notification kinds. The payee hears about new, released and disputed
+// escrows, the payer about refunds and releases on timeout, and every admin
+// about disputes.
+const (
+	KindCreated  = "escrow.created"
+	KindReleased = "escrow.released"
+	KindRefunded = "escrow.refunded"
+	KindDisputed = "escrow.disputed"
+)
+
+const (
+	DefaultReleaseAfter          = 14 * 24 * time.Hour
+	DefaultMaxReleaseAfter       = 90 * 24 * time.Hour
+	MaxNoteLength                = 500
+	PageSize                     = 100
+	releasePageSize        int32 = 100
+)
+
+// Policy holds when escrows are released without confirmation.
+type Policy struct {
+	// DefaultReleaseAfter is how long an escrow is held when the payer does
+	// not choose.
+	DefaultReleaseAfter time.Duration
+	// MaxReleaseAfter is the longest a payer may hold an escrow.
+	MaxReleaseAfter time.Duration
+}
+
+type Service struct {
+	store    db.Store
+	accounts *bank.Accounts
+	notifier notify.Notifier
+	payments *payment.Service
+	policy   Policy
+}
+
+func NewService(store db.Store, accounts *bank.Accounts, notifier notify.Notifier, payments *payment.Service, policy Policy) *Service {
+	return &Service{store: store, accounts: accounts, notifier: notifier, payments: payments, policy: policy}
+}
+
+// FromConfig builds the service with the ESCROW_* durations, using the
+// defaults for those that are not set.
+func FromConfig(store db.Store, accounts *bank.Accounts, notifier notify.Notifier, payments *payment.Service, config utils.Config) *Service {
+	policy := Policy{
+		DefaultReleaseAfter: config.EscrowDefaultReleaseAfter,
+		MaxReleaseAfter:     config.EscrowMaxReleaseAfter,
+	}
+	if policy.DefaultReleaseAfter <= 0 {
+		policy.DefaultReleaseAfter = DefaultReleaseAfter
+	}
+	if policy.MaxReleaseAfter <= 0 {
+		policy.MaxReleaseAfter = DefaultMaxReleaseAfter
+	}
+
+	return NewService(store, accounts, notifier, payments, policy)
+}
+
+type CreateParams struct {
+	Payer         string
+	FromAccountID int64
+	ToAccountID   int64
+	Amount        int64
+	Currency      string
+	Description   string
+	ReleaseAt     time.Time // zero for DefaultReleaseAfter
+	ClientIP      string
+}
+
+// CreateResult is a funded escrow, or a payment into escrow held for review
+// when Held.
+type CreateResult struct {
+	db.CreateEscrowTxResult
+	Payment payment.Clearance
+}
+
+// Held reports whether the payment into escrow waits for a fraud review;
+// the escrow is created once the review approves the payment.
+func (result CreateResult) Held() bool {
+	return result.Payment.Held()
+}
+
+// Create moves the amount from an account of the payer into escrow for the
+// owner of the to account, once the payment is cleared.
+func (service *Service) Create(ctx context.Context, arg CreateParams) (CreateResult, error) {
+	var result CreateResult
+
+	now := time.Now()
+	if arg.ReleaseAt.IsZero() {
+		arg.ReleaseAt = now.Add(service.policy.DefaultReleaseAfter)
+	}
+
+	var violations []domain.FieldViolation
+	if arg.FromAccountID == arg.ToAccountID {
+		violations = append(violations, domain.FieldViolation{Field: "to_account_id", Description: "must differ from from_account_id"})
+	}
+	if description := memo.ValidateDescription(arg.Description); description != "" {
+		violations = append(violations, domain.FieldViolation{Field: "description", Description: description})
+	}
+	if !arg.ReleaseAt.After(now) || arg.ReleaseAt.After(now.Add(service.policy.MaxReleaseAfter)) {
+		violations = append(violations, domain.FieldViolation{Field: "release_at", Description: fmt.Sprintf("must be in the future and within %s", service.policy.MaxReleaseAfter)})
+	}
+	if violations != nil {
+		return result, domain.NewValidationError(violations...)
+	}
+
+	fromAccount, err := service.store.GetAccount(ctx, arg.FromAccountID)
+	if err != nil {
+		return result, err
+	}
+
+	if fromAccount.Owner != arg.Payer {
+		return result, fmt.Errorf("%w: from account doesn't belong to the authenticated user", domain.ErrForbidden)
+	}
+
+	toAccount, err := service.store.GetAccount(ctx, arg.ToAccountID)
+	if err != nil {
+		return result, err
+	}
+
+	if toAccount.Owner == arg.Payer || toAccount.Owner == bank.Username {
+		return result, domain.NewValidationError(domain.FieldViolation{Field: "to_account_id", Description: "must belong to another customer"})
+	}
+
+	for _, account := range []db.Account{fromAccount, toAccount} {
+		if account.Currency != arg.Currency {
+			return result, fmt.Errorf("%w: account [%d]: %s vs %s", domain.ErrCurrencyMismatch, account.ID, account.Currency, arg.Currency)
+		}
+	}
+
+	escrowAccount, err := service.accounts.Get(ctx, arg.Currency, bank.ProductEscrow)
+	if err != nil {
+		return result, err
+	}
+
+	params := db.CreateEscrowTxParams{
+		Payer:           arg.Payer,
+		Payee:           toAccount.Owner,
+		FromAccountID:   fromAccount.ID,
+		ToAccountID:     toAccount.ID,
+		EscrowAccountID: escrowAccount.ID,
+		Amount:          arg.Amount,
+		Currency:        arg.Currency,
+		Description:     arg.Description,
+		ReleaseAt:       arg.ReleaseAt,
+	}
+	// the payee is the counterparty that is screened, though the amount
+	// is paid into the escrow account
+	result.Payment, err = service.payments.Clear(ctx, payment.Payment{
+		Username: arg.Payer,
+		ClientIP: arg.ClientIP,
+		Currency: arg.Currency,
+		Params: db.TransferTxParams{
+			FromAccountID: fromAccount.ID,
+			ToAccountID:   escrowAccount.ID,
+			Amount:        arg.Amount,
+			Description:   arg.Description,
+		},
+		ToAccount: toAccount,
+		Kind:      db.HeldEscrow,
+		Payload:   params,
+	})
+	if err != nil || result.Held() {
+		return result, err
+	}
+
+	params.Fee, params.FeeAccountID = result.Payment.Params.Fee, result.Payment.Params.FeeAccountID
+	result.CreateEscrowTxResult, err = service.store.CreateEscrowTx(ctx, params)
+	if err != nil {
+		return result, err
+	}
+	result.Payment = service.payments.Posted(ctx, result.Payment, result.Funding.Transfer.ID)
+
+	service.NotifyCreated(ctx, result.Escrow)
+
+	return result, nil
+}
+
+// NotifyCreated tells the payee an escrow was funded for them, e.g. once a
+// held payment into escrow is approved.
+func (service *Service) NotifyCreated(ctx context.Context, escrow db.Escrow) {
+	service.notifier.Notify(ctx, notify.Notification{
+		Username: escrow.Payee,
+		Kind:     KindCreated,
+		Message: fmt.Sprintf("%s put %d %s in escrow %d for you, released by %s: %s",
+			escrow.Payer, escrow.Amount, escrow.Currency, escrow.ID, escrow.ReleaseAt.UTC().Format(time.RFC3339), escrow.Description),
+	})
+}
+
+// Get returns the escrow if username is its payer or payee.
+func (service *Service) Get(ctx context.Context, username string, id int64) (db.Escrow, error) {
+	escrow, err := service.store.GetEscrow(ctx, id)
+	if err != nil {
+		return escrow, err
+	}
+
+	if escrow.Payer != username && escrow.Payee != username {
+		return db.Escrow{}, fmt.Errorf("%w: escrow doesn't involve the authenticated user", domain.ErrForbidden)
+	}
+
+	return escrow, nil
+}
+
+// List returns the escrows the user pays or is paid by, newest first.
+func (service *Service) List(ctx context.Context, username string) ([]db.Escrow, error) {
+	return service.store.ListUserEscrows(ctx, username)
+}
+
+// Queue lists escrows for admins, oldest first; status filters them when
+// not empty.
+func (service *Service) Queue(ctx context.Context, status string, afterID int64, pageSize int32) ([]db.Escrow, error) {
+	return service.store.ListEscrows(ctx, db.ListEscrowsParams{
+		AfterID:   afterID,
+		Status:    sql.NullString{String: status, Valid: status != ""},
+		PageLimit: pageSize,
+	})
+}
+
+// Confirm lets the payer release an undecided escrow to the payee.
+func (service *Service) Confirm(ctx context.Context, username string, id int64) (db.SettleEscrowTxResult, error) {
+	result, err := service.settle(ctx, db.SettleEscrowTxParams{
+		ID:     id,
+		Status: string(StatusReleased),
+		Actor:  username,
+		Authorize: func(escrow db.Escrow) error {
+			if escrow.Payer != username {
+				return fmt.Errorf("%w: only the payer can confirm an escrow", domain.ErrForbidden)
+			}
+			return checkTransition(escrow, StatusReleased)
+		},
+	})
+	if err != nil {
+		return result, err
+	}
+
+	service.notifyReleased(ctx, result.Escrow)
+	return result, nil
+}
+
+// Cancel lets the payee refund an undecided escrow to the payer.
+func (service *Service) Cancel(ctx context.Context, username string, id int64, note string) (db.SettleEscrowTxResult, error) {
+	result, err := service.settle(ctx, db.SettleEscrowTxParams{
+		ID:     id,
+		Status: string(StatusRefunded),
+		Actor:  username,
+		Note:   strings.TrimSpace(note),
+		Authorize: func(escrow db.Escrow) error {
+			if escrow.Payee != username {
+				return fmt.Errorf("%w: only the payee can cancel an escrow", domain.ErrForbidden)
+			}
+			return checkTransition(escrow, StatusRefunded)
+		},
+	})
+	if err != nil {
+		return result, err
+	}
+
+	service.notifyRefunded(ctx, result.Escrow)
+	return result, nil
+}
+
+// Dispute lets the payer stop the release of a held escrow on timeout
+// until an admin decides it.
+func (service *Service) Dispute(ctx context.Context, username string, id int64, reason string) (db.SettleEscrowTxResult, error) {
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return db.SettleEscrowTxResult{}, domain.NewValidationError(domain.FieldViolation{Field: "reason", Description: "is required"})
+	}
+
+	result, err := service.settle(ctx, db.SettleEscrowTxParams{
+		ID:     id,
+		Status: string(StatusDisputed),
+		Actor:  username,
+		Note:   reason,
+		Authorize: func(escrow db.Escrow) error {
+			if escrow.Payer != username {
+				return fmt.Errorf("%w: only the payer can dispute an escrow", domain.ErrForbidden)
+			}
+			return checkTransition(escrow, StatusDisputed)
+		},
+	})
+	if err != nil {
+		return result, err
+	}
+
+	escrow := result.Escrow
+	message := fmt.Sprintf("%s disputed escrow %d of %d %s: %s", escrow.Payer, escrow.ID, escrow.Amount, escrow.Currency, reason)
+	service.notifier.Notify(ctx, notify.Notification{Username: escrow.Payee, Kind: KindDisputed, Message: message})
+
+	admins, err := service.store.ListUsernamesByRole(ctx, bank.RoleAdmin)
+	if err != nil {
+		logging.FromContext(ctx).Error().Err(err).Int64("escrow_id", escrow.ID).Msg("cannot list admins to notify")
+	}
+	for _, admin := range admins {
+		service.notifier.Notify(ctx, notify.Notification{Username: admin, Kind: KindDisputed, Message: message})
+	}
+
+	return result, nil
+}
+
+type ResolveParams struct {
+	ID    int64
+	Admin string
+	// Outcome is StatusReleased or StatusRefunded.
+	Outcome Status
+	Note    string
+}
+
+// Resolve lets an admin release or refund a disputed escrow.
+func (service *Service) Resolve(ctx context.Context, arg ResolveParams) (db.SettleEscrowTxResult, error) {
+	if arg.Outcome != StatusReleased && arg.Outcome != StatusRefunded {
+		return db.SettleEscrowTxResult{}, domain.NewValidationError(domain.FieldViolation{
+			Field:       "outcome",
+			Description: fmt.Sprintf("must be %s or %s", StatusReleased, StatusRefunded),
+		})
+	}
+
+	result, err := service.settle(ctx, db.SettleEscrowTxParams{
+		ID:     arg.ID,
+		Status: string(arg.Outcome),
+		Actor:  arg.Admin,
+		Note:   strings.TrimSpace(arg.Note),
+		Authorize: func(escrow db.Escrow) error {
+			if Status(escrow.Status) != StatusDisputed {
+				return fmt.Errorf("%w: only disputed escrows are resolved, escrow is %s", domain.ErrInvalidTransition, escrow.Status)
+			}
+			return nil
+		},
+	})
+	if err != nil {
+		return result, err
+	}
+
+	if arg.Outcome == StatusReleased {
+		service.notifyReleased(ctx, result.Escrow)
+	} else {
+		service.notifyRefunded(ctx, result.Escrow)
+	}
+
+	return result, nil
+}
+
+// ReleaseDue releases the held escrows past their release time to their
+// payees. It returns the number released.
+func (service *Service) ReleaseDue(ctx context.Context) (int, error) {
+	released := 0
+
+	for {
+		ids, err := service.store.ListDueEscrowIDs(ctx, releasePageSize)
+		if err != nil {
+			return released, err
+		}
+
+		progress := false
+		for _, id := range ids {
+			result, err := service.settle(ctx, db.SettleEscrowTxParams{
+				ID:     id,
+				Status: string(StatusReleased),
+				Authorize: func(escrow db.Escrow) error {
+					// confirmed, cancelled or disputed since it was listed
+					if Status(escrow.Status) != StatusHeld || time.Now().Before(escrow.ReleaseAt) {
+						return fmt.Errorf("%w: escrow is no longer due", domain.ErrInvalidTransition)
+					}
+					return nil
+				},
+			})
+			if err != nil {
+				logging.FromContext(ctx).Error().Err(err).Int64("escrow_id", id).Msg("cannot release escrow")
+				continue
+			}
+
+			progress = true
+			released++
+			service.notifyReleased(ctx, result.Escrow)
+			service.notifier.Notify(ctx, notify.Notification{
+				Username: result.Escrow.Payer,
+				Kind:     KindReleased,
+				Message:  fmt.Sprintf("escrow %d of %d %s was released to %s as it was not confirmed or disputed in time", result.Escrow.ID, result.Escrow.Amount, result.Escrow.Currency, result.Escrow.Payee),
+			})
+		}
+
+		if len(ids) < int(releasePageSize) || !progress {
+			return released, nil
+		}
+	}
+}
+
+func (service *Service) settle(ctx context.Context, arg db.SettleEscrowTxParams) (db.SettleEscrowTxResult, error) {
+	if len(arg.Note) > MaxNoteLength {
+		return db.SettleEscrowTxResult{}, domain.NewValidationError(domain.FieldViolation{Field: "note", Description: fmt.Sprintf("must be at most %d characters", MaxNoteLength)})
+	}
+
+	return service.store.SettleEscrowTx(ctx, arg)
+}
+
+func (service *Service) notifyReleased(ctx context.Context, escrow db.Escrow) {
+	service.notifier.Notify(ctx, notify.Notification{
+		Username: escrow.Payee,
+		Kind:     KindReleased,
+		Message:  fmt.Sprintf("escrow %d of %d %s from %s was released to you", escrow.ID, escrow.Amount, escrow.Currency, escrow.Payer),
+	})
+}
+
+func (service *Service) notifyRefunded(ctx context.Context, escrow db.Escrow) {
+	service.notifier.Notify(ctx, notify.Notification{
+		Username: escrow.Payer,
+		Kind:     KindRefunded,
+		Message:  fmt.Sprintf("escrow %d of %d %s to %s was refunded to you", escrow.ID, escrow.Amount, escrow.Currency, escrow.Payee),
+	})
+}
+
+func checkTransition(escrow db.Escrow, to Status) error {
+	if !CanTransition(Status(escrow.Status), to) {
+		return fmt.Errorf("%w: escrow is %s", domain.ErrInvalidTransition, escrow.Status)
+	}
+
+	return nil
+}
+
+// Overdue reports whether a held escrow is past its release time and waits
+// for the worker.
+func Overdue(escrow db.Escrow, now time.Time) bool {
+	return Status(escrow.Status) == StatusHeld && !now.Before(escrow.ReleaseAt)
+}
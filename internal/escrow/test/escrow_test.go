@@ -0,0 +1,389 @@
+package test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/NhutHuyDev/sgbank/internal/bank"
+	"github.com/NhutHuyDev/sgbank/internal/domain"
+	"github.com/NhutHuyDev/sgbank/internal/escrow"
+	"github.com/NhutHuyDev/sgbank/internal/fraud"
+	"github.com/NhutHuyDev/sgbank/internal/infra/db"
+	mockdb "github.com/NhutHuyDev/sgbank/internal/infra/db/mock"
+	"github.com/NhutHuyDev/sgbank/internal/kyc"
+	"github.com/NhutHuyDev/sgbank/internal/payment/paymenttest"
+	"github.com/NhutHuyDev/sgbank/internal/screening"
+	"github.com/NhutHuyDev/sgbank/pkg/utils"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+var policy = escrow.Policy{DefaultReleaseAfter: 7 * 24 * time.Hour, MaxReleaseAfter: 30 * 24 * time.Hour}
+
+var escrowAccount = db.Account{ID: 99, Owner: bank.Username, Currency: utils.USD, Product: bank.ProductEscrow}
+
+// payeeAccount is the account alice pays into escrow for.
+var payeeAccount = db.Account{ID: 2, Owner: "bob", Currency: utils.USD}
+
+// newService holds any payment into escrow for a payee the payer never
+// paid, and screens against empty lists.
+func newService(t *testing.T, store db.Store) (*escrow.Service, *paymenttest.Notifier) {
+	notifier := &paymenttest.Notifier{}
+	payments := paymenttest.NewService(t, store, notifier, screening.NewLists())
+
+	return escrow.NewService(store, paymenttest.NewAccounts(t, store), notifier, payments, policy), notifier
+}
+
+// expectCleared lets the payment into escrow through the checks without a
+// fee, or holds it for review when held.
+func expectCleared(store *mockdb.MockStore, held bool) {
+	store.EXPECT().GetUserKycTransferLimits(gomock.Any(), gomock.Any()).Times(1).Return(db.GetUserKycTransferLimitsRow{Level: kyc.LevelFull, Name: "Fully verified"}, nil)
+	store.EXPECT().GetUserTier(gomock.Any(), gomock.Any()).Times(1).Return(db.UserTier{Code: "standard", Name: "Standard"}, nil)
+	store.EXPECT().GetFeeSchedule(gomock.Any(), gomock.Any()).Times(1).Return(db.FeeSchedule{}, domain.ErrNotFound)
+	expectFraudDecision(store, held)
+}
+
+func expectFraudDecision(store *mockdb.MockStore, held bool) {
+	store.EXPECT().HasTransferredTo(gomock.Any(), gomock.Eq(db.HasTransferredToParams{Owner: "alice", ToAccountID: payeeAccount.ID})).
+		Times(1).
+		Return(!held, nil)
+	store.EXPECT().CreateFraudDecision(gomock.Any(), gomock.Any()).Times(1).
+		DoAndReturn(paymenttest.EchoFraudDecision)
+	if !held {
+		store.EXPECT().SetFraudDecisionTransfer(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+	}
+}
+
+// expectSettle runs the Authorize of SettleEscrowTx against current and
+// returns current moved to the asked status.
+func expectSettle(t *testing.T, store *mockdb.MockStore, current db.Escrow) {
+	store.EXPECT().SettleEscrowTx(gomock.Any(), gomock.Any()).Times(1).
+		DoAndReturn(func(_ any, arg db.SettleEscrowTxParams) (db.SettleEscrowTxResult, error) {
+			require.Equal(t, current.ID, arg.ID)
+			if err := arg.Authorize(current); err != nil {
+				return db.SettleEscrowTxResult{}, err
+			}
+
+			current.Status = arg.Status
+			return db.SettleEscrowTxResult{Escrow: current}, nil
+		})
+}
+
+func TestCanTransition(t *testing.T) {
+	require.True(t, escrow.CanTransition(escrow.StatusHeld, escrow.StatusReleased))
+	require.True(t, escrow.CanTransition(escrow.StatusHeld, escrow.StatusDisputed))
+	require.True(t, escrow.CanTransition(escrow.StatusDisputed, escrow.StatusRefunded))
+	require.False(t, escrow.CanTransition(escrow.StatusDisputed, escrow.StatusHeld))
+	require.False(t, escrow.CanTransition(escrow.StatusReleased, escrow.StatusRefunded))
+	require.False(t, escrow.CanTransition(escrow.StatusRefunded, escrow.StatusDisputed))
+}
+
+func TestCreate(t *testing.T) {
+	from := db.Account{ID: 1, Owner: "alice", Currency: utils.USD}
+	to := payeeAccount
+	valid := escrow.CreateParams{Payer: "alice", FromAccountID: from.ID, ToAccountID: to.ID, Amount: 300, Currency: utils.USD, Description: "camera"}
+
+	testCases := []struct {
+		name       string
+		arg        func() escrow.CreateParams
+		buildStubs func(store *mockdb.MockStore)
+		check      func(t *testing.T, notifier *paymenttest.Notifier, err error)
+	}{
+		{
+			name: "OK",
+			arg:  func() escrow.CreateParams { return valid },
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(from.ID)).Times(1).Return(from, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(to.ID)).Times(1).Return(to, nil)
+				store.EXPECT().GetAccountByOwnerProduct(gomock.Any(), gomock.Eq(db.GetAccountByOwnerProductParams{
+					Owner:    bank.Username,
+					Currency: utils.USD,
+					Product:  bank.ProductEscrow,
+				})).Times(1).Return(escrowAccount, nil)
+				expectCleared(store, false)
+				store.EXPECT().CreateEscrowTx(gomock.Any(), gomock.Any()).Times(1).
+					DoAndReturn(func(_ any, arg db.CreateEscrowTxParams) (db.CreateEscrowTxResult, error) {
+						require.Equal(t, "bob", arg.Payee)
+						require.Equal(t, escrowAccount.ID, arg.EscrowAccountID)
+						require.WithinDuration(t, time.Now().Add(policy.DefaultReleaseAfter), arg.ReleaseAt, time.Minute)
+						return db.CreateEscrowTxResult{Escrow: db.Escrow{ID: 5, Payer: arg.Payer, Payee: arg.Payee, ReleaseAt: arg.ReleaseAt}}, nil
+					})
+			},
+			check: func(t *testing.T, notifier *paymenttest.Notifier, err error) {
+				require.NoError(t, err)
+				require.Len(t, notifier.Notifications, 1)
+				require.Equal(t, "bob", notifier.Notifications[0].Username)
+				require.Equal(t, escrow.KindCreated, notifier.Notifications[0].Kind)
+			},
+		},
+		{
+			name: "Held",
+			arg:  func() escrow.CreateParams { return valid },
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(from.ID)).Times(1).Return(from, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(to.ID)).Times(1).Return(to, nil)
+				store.EXPECT().GetAccountByOwnerProduct(gomock.Any(), gomock.Any()).Times(1).Return(escrowAccount, nil)
+				expectCleared(store, true)
+				store.EXPECT().CreateEscrowTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			check: func(t *testing.T, notifier *paymenttest.Notifier, err error) {
+				require.NoError(t, err)
+				// alice is told the payment waits for review; bob hears of
+				// the escrow once it is approved
+				require.Len(t, notifier.Notifications, 1)
+				require.Equal(t, "alice", notifier.Notifications[0].Username)
+				require.Equal(t, fraud.KindHeld, notifier.Notifications[0].Kind)
+			},
+		},
+		{
+			name: "KYCLimitExceeded",
+			arg:  func() escrow.CreateParams { return valid },
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(from.ID)).Times(1).Return(from, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(to.ID)).Times(1).Return(to, nil)
+				store.EXPECT().GetAccountByOwnerProduct(gomock.Any(), gomock.Any()).Times(1).Return(escrowAccount, nil)
+				store.EXPECT().GetUserKycTransferLimits(gomock.Any(), gomock.Any()).Times(1).
+					Return(db.GetUserKycTransferLimitsRow{Level: kyc.LevelUnverified, MaxTransferAmount: sql.NullInt64{Int64: 100, Valid: true}}, nil)
+				store.EXPECT().CreateFraudDecision(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().CreateEscrowTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			check: func(t *testing.T, notifier *paymenttest.Notifier, err error) {
+				require.ErrorIs(t, err, domain.ErrKYCLimitExceeded)
+			},
+		},
+		{
+			name: "NotPayerAccount",
+			arg: func() escrow.CreateParams {
+				arg := valid
+				arg.Payer = "mallory"
+				return arg
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(from.ID)).Times(1).Return(from, nil)
+			},
+			check: func(t *testing.T, notifier *paymenttest.Notifier, err error) {
+				require.ErrorIs(t, err, domain.ErrForbidden)
+			},
+		},
+		{
+			name: "CurrencyMismatch",
+			arg: func() escrow.CreateParams {
+				arg := valid
+				arg.Currency = utils.EUR
+				return arg
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(from.ID)).Times(1).Return(from, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(to.ID)).Times(1).Return(to, nil)
+			},
+			check: func(t *testing.T, notifier *paymenttest.Notifier, err error) {
+				require.ErrorIs(t, err, domain.ErrCurrencyMismatch)
+			},
+		},
+		{
+			name: "ReleaseTooLate",
+			arg: func() escrow.CreateParams {
+				arg := valid
+				arg.ReleaseAt = time.Now().Add(policy.MaxReleaseAfter + time.Hour)
+				return arg
+			},
+			check: func(t *testing.T, notifier *paymenttest.Notifier, err error) {
+				require.ErrorIs(t, err, domain.ErrInvalidArgument)
+			},
+		},
+		{
+			name: "SameAccount",
+			arg: func() escrow.CreateParams {
+				arg := valid
+				arg.ToAccountID = from.ID
+				return arg
+			},
+			check: func(t *testing.T, notifier *paymenttest.Notifier, err error) {
+				require.ErrorIs(t, err, domain.ErrInvalidArgument)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			if tc.buildStubs != nil {
+				tc.buildStubs(store)
+			}
+
+			service, notifier := newService(t, store)
+			_, err := service.Create(context.Background(), tc.arg())
+			tc.check(t, notifier, err)
+		})
+	}
+}
+
+func TestCreateChargesFee(t *testing.T) {
+	from := db.Account{ID: 1, Owner: "alice", Currency: utils.USD}
+	to := payeeAccount
+	revenue := db.Account{ID: 90, Owner: bank.Username, Currency: utils.USD, Product: bank.ProductFeeRevenue}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(from.ID)).Times(1).Return(from, nil)
+	store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(to.ID)).Times(1).Return(to, nil)
+	store.EXPECT().GetAccountByOwnerProduct(gomock.Any(), gomock.Any()).Times(2).
+		DoAndReturn(func(_ any, arg db.GetAccountByOwnerProductParams) (db.Account, error) {
+			if arg.Product == bank.ProductFeeRevenue {
+				return revenue, nil
+			}
+			return escrowAccount, nil
+		})
+	store.EXPECT().GetUserKycTransferLimits(gomock.Any(), gomock.Any()).Times(1).Return(db.GetUserKycTransferLimitsRow{Level: kyc.LevelFull, Name: "Fully verified"}, nil)
+	store.EXPECT().GetUserTier(gomock.Any(), gomock.Eq("alice")).Times(1).Return(db.UserTier{Code: "standard"}, nil)
+	store.EXPECT().GetFeeSchedule(gomock.Any(), gomock.Eq(utils.USD)).Times(1).Return(db.FeeSchedule{ID: 3, Currency: utils.USD}, nil)
+	store.EXPECT().ListFeeScheduleBands(gomock.Any(), gomock.Eq(int64(3))).Times(1).Return([]db.FeeScheduleBand{{FlatFee: 5}}, nil)
+	expectFraudDecision(store, false)
+	store.EXPECT().CreateEscrowTx(gomock.Any(), gomock.Any()).Times(1).
+		DoAndReturn(func(_ any, arg db.CreateEscrowTxParams) (db.CreateEscrowTxResult, error) {
+			require.Equal(t, int64(300), arg.Amount)
+			require.Equal(t, int64(5), arg.Fee)
+			require.Equal(t, revenue.ID, arg.FeeAccountID)
+			return db.CreateEscrowTxResult{Escrow: db.Escrow{ID: 5, Payer: arg.Payer, Payee: arg.Payee, ReleaseAt: arg.ReleaseAt}}, nil
+		})
+
+	service, _ := newService(t, store)
+	result, err := service.Create(context.Background(), escrow.CreateParams{Payer: "alice", FromAccountID: from.ID, ToAccountID: to.ID, Amount: 300, Currency: utils.USD})
+	require.NoError(t, err)
+	require.False(t, result.Held())
+	require.Equal(t, db.HeldEscrow, result.Payment.Decision.Kind)
+	// the decision names the payee, the transfer the escrow account
+	require.Equal(t, to.ID, result.Payment.Decision.ToAccountID)
+	require.Equal(t, escrowAccount.ID, result.Payment.Params.ToAccountID)
+	require.Equal(t, int64(305), result.Payment.Fee.Total)
+}
+
+func TestConfirmCancelAndDispute(t *testing.T) {
+	held := db.Escrow{ID: 5, Payer: "alice", Payee: "bob", Amount: 300, Currency: utils.USD, Status: db.EscrowHeld}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	service, notifier := newService(t, store)
+	ctx := context.Background()
+
+	// only the payer confirms
+	expectSettle(t, store, held)
+	_, err := service.Confirm(ctx, "bob", held.ID)
+	require.ErrorIs(t, err, domain.ErrForbidden)
+
+	expectSettle(t, store, held)
+	result, err := service.Confirm(ctx, "alice", held.ID)
+	require.NoError(t, err)
+	require.Equal(t, db.EscrowReleased, result.Escrow.Status)
+	require.Equal(t, "bob", notifier.Notifications[0].Username)
+	require.Equal(t, escrow.KindReleased, notifier.Notifications[0].Kind)
+
+	// a released escrow is final
+	released := held
+	released.Status = db.EscrowReleased
+	expectSettle(t, store, released)
+	_, err = service.Cancel(ctx, "bob", held.ID, "")
+	require.ErrorIs(t, err, domain.ErrInvalidTransition)
+
+	// only the payee cancels
+	expectSettle(t, store, held)
+	_, err = service.Cancel(ctx, "alice", held.ID, "")
+	require.ErrorIs(t, err, domain.ErrForbidden)
+
+	_, err = service.Dispute(ctx, "alice", held.ID, " ")
+	require.ErrorIs(t, err, domain.ErrInvalidArgument)
+
+	notifier.Notifications = nil
+	expectSettle(t, store, held)
+	store.EXPECT().ListUsernamesByRole(gomock.Any(), gomock.Eq(bank.RoleAdmin)).Times(1).Return([]string{"admin"}, nil)
+	result, err = service.Dispute(ctx, "alice", held.ID, "never arrived")
+	require.NoError(t, err)
+	require.Equal(t, db.EscrowDisputed, result.Escrow.Status)
+	require.Len(t, notifier.Notifications, 2)
+	require.Equal(t, "bob", notifier.Notifications[0].Username)
+	require.Equal(t, "admin", notifier.Notifications[1].Username)
+}
+
+func TestResolve(t *testing.T) {
+	disputed := db.Escrow{ID: 5, Payer: "alice", Payee: "bob", Status: db.EscrowDisputed}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	service, notifier := newService(t, store)
+	ctx := context.Background()
+
+	_, err := service.Resolve(ctx, escrow.ResolveParams{ID: disputed.ID, Admin: "admin", Outcome: escrow.StatusDisputed})
+	require.ErrorIs(t, err, domain.ErrInvalidArgument)
+
+	// held escrows are not the admins' to decide
+	held := disputed
+	held.Status = db.EscrowHeld
+	expectSettle(t, store, held)
+	_, err = service.Resolve(ctx, escrow.ResolveParams{ID: disputed.ID, Admin: "admin", Outcome: escrow.StatusReleased})
+	require.ErrorIs(t, err, domain.ErrInvalidTransition)
+
+	expectSettle(t, store, disputed)
+	result, err := service.Resolve(ctx, escrow.ResolveParams{ID: disputed.ID, Admin: "admin", Outcome: escrow.StatusRefunded, Note: "seller agreed"})
+	require.NoError(t, err)
+	require.Equal(t, db.EscrowRefunded, result.Escrow.Status)
+	require.Len(t, notifier.Notifications, 1)
+	require.Equal(t, "alice", notifier.Notifications[0].Username)
+	require.Equal(t, escrow.KindRefunded, notifier.Notifications[0].Kind)
+}
+
+func TestReleaseDue(t *testing.T) {
+	due := db.Escrow{ID: 5, Payer: "alice", Payee: "bob", Status: db.EscrowHeld, ReleaseAt: time.Now().Add(-time.Minute)}
+	// confirmed since it was listed
+	settled := db.Escrow{ID: 6, Payer: "alice", Payee: "bob", Status: db.EscrowReleased, ReleaseAt: time.Now().Add(-time.Minute)}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().ListDueEscrowIDs(gomock.Any(), gomock.Any()).Times(1).Return([]int64{due.ID, settled.ID}, nil)
+	expectSettle(t, store, due)
+	expectSettle(t, store, settled)
+
+	service, notifier := newService(t, store)
+	released, err := service.ReleaseDue(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 1, released)
+
+	require.Len(t, notifier.Notifications, 2)
+	require.Equal(t, "bob", notifier.Notifications[0].Username)
+	require.Equal(t, "alice", notifier.Notifications[1].Username)
+	for _, notification := range notifier.Notifications {
+		require.Equal(t, escrow.KindReleased, notification.Kind)
+	}
+}
+
+func TestReleaseDueListError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().ListDueEscrowIDs(gomock.Any(), gomock.Any()).Times(1).Return(nil, errors.New("db down"))
+
+	service, _ := newService(t, store)
+	_, err := service.ReleaseDue(context.Background())
+	require.Error(t, err)
+}
+
+func TestOverdue(t *testing.T) {
+	now := time.Now()
+	require.True(t, escrow.Overdue(db.Escrow{Status: db.EscrowHeld, ReleaseAt: now}, now))
+	require.False(t, escrow.Overdue(db.Escrow{Status: db.EscrowHeld, ReleaseAt: now.Add(time.Hour)}, now))
+	require.False(t, escrow.Overdue(db.Escrow{Status: db.EscrowDisputed, ReleaseAt: now}, now))
+}
@@ -0,0 +1,43 @@
+package escrow
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+const DefaultReleaseInterval = time.Minute
+
+// Worker releases held escrows past their release time to their payees.
+// Each escrow is locked while it is released, so several instances may run
+// at once.
+type Worker struct {
+	service  *Service
+	interval time.Duration
+}
+
+func NewWorker(service *Service, interval time.Duration) *Worker {
+	if interval <= 0 {
+		interval = DefaultReleaseInterval
+	}
+
+	return &Worker{service: service, interval: interval}
+}
+
+func (worker *Worker) Run(ctx context.Context) error {
+	ticker := time.NewTicker(worker.interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := worker.service.ReleaseDue(ctx); err != nil {
+			log.Error().Err(err).Msg("cannot release due escrows")
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,179 @@
+package fee
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/NhutHuyDev/sgbank/internal/bank"
+	"github.com/NhutHuyDev/sgbank/internal/domain"
+	"github.com/NhutHuyDev/sgbank/internal/infra/db"
+	"github.com/NhutHuyDev/sgbank/internal/money"
+)
+
+// RatePrecision is the denominator of percentage rates: 5000 is 0.5%.
+const RatePrecision = 1_000_000
+
+var ErrOverflow = errors.New("fee does not fit in 64 bits")
+
+// Breakdown is the fee a schedule charges on an amount.
+type Breakdown struct {
+	FlatFee       int64 `json:"flat_fee"`
+	PercentageFee int64 `json:"percentage_fee"`
+	// RatePpm is the percentage rate of the band the amount fell in, in
+	// parts per million.
+	RatePpm int64 `json:"rate_ppm"`
+	// Fee is FlatFee plus PercentageFee, raised to the schedule's minimum
+	// and capped at its maximum.
+	Fee int64 `json:"fee"`
+}
+
+// Calculate returns the fee schedule charges on amount. bands must be
+// ordered by UpTo with the unbounded band last, as ListFeeScheduleBands
+// returns them. The first band whose UpTo is at least amount applies to the
+// whole amount: its flat fee plus its rate of amount, rounded half to even.
+// An amount above every band is charged by the top band.
+func Calculate(schedule db.FeeSchedule, bands []db.FeeScheduleBand, amount int64) (Breakdown, error) {
+	var breakdown Breakdown
+
+	if len(bands) > 0 {
+		band := bands[len(bands)-1]
+		for _, candidate := range bands {
+			if !candidate.UpTo.Valid || amount <= candidate.UpTo.Int64 {
+				band = candidate
+				break
+			}
+		}
+
+		percentage, err := percentageOf(amount, band.RatePpm)
+		if err != nil {
+			return breakdown, err
+		}
+
+		if _, err := money.Add(band.FlatFee, percentage); err != nil {
+			return breakdown, ErrOverflow
+		}
+
+		breakdown.FlatFee = band.FlatFee
+		breakdown.PercentageFee = percentage
+		breakdown.RatePpm = band.RatePpm
+	}
+
+	breakdown.Fee = max(breakdown.FlatFee+breakdown.PercentageFee, schedule.MinFee)
+	if schedule.MaxFee.Valid {
+		breakdown.Fee = min(breakdown.Fee, schedule.MaxFee.Int64)
+	}
+
+	return breakdown, nil
+}
+
+func percentageOf(amount int64, ratePpm int64) (int64, error) {
+	if amount <= 0 || ratePpm <= 0 {
+		return 0, nil
+	}
+
+	numerator := new(big.Int).Mul(big.NewInt(amount), big.NewInt(ratePpm))
+	denominator := big.NewInt(RatePrecision)
+
+	quotient, remainder := new(big.Int).QuoRem(numerator, denominator, new(big.Int))
+	switch remainder.Lsh(remainder, 1).Cmp(denominator) {
+	case 1:
+		quotient.Add(quotient, big.NewInt(1))
+	case 0:
+		if quotient.Bit(0) == 1 {
+			quotient.Add(quotient, big.NewInt(1))
+		}
+	}
+
+	if !quotient.IsInt64() {
+		return 0, ErrOverflow
+	}
+
+	return quotient.Int64(), nil
+}
+
+// Quote is what a user pays to transfer Amount: the transfer itself plus
+// Fee, debited from the from account.
+type Quote struct {
+	Currency string `json:"currency"`
+	Amount   int64  `json:"amount"`
+	Breakdown
+	// Tier is the payer's tier. Fees are waived for some tiers, in which
+	// case Waived is set and Fee is zero.
+	Tier   string `json:"tier"`
+	Waived bool   `json:"waived"`
+	Total  int64  `json:"total"`
+}
+
+// Service prices transfers with the fee schedule of their currency. A
+// currency without a schedule charges no fee.
+type Service struct {
+	store db.Store
+	bank  *bank.Accounts
+}
+
+func NewService(store db.Store, bankAccounts *bank.Accounts) *Service {
+	return &Service{store: store, bank: bankAccounts}
+}
+
+// Quote returns the fee username pays to transfer amount in currency.
+func (service *Service) Quote(ctx context.Context, username string, currency string, amount int64) (Quote, error) {
+	quote := Quote{Currency: currency, Amount: amount, Total: amount}
+
+	tier, err := service.store.GetUserTier(ctx, username)
+	if err != nil {
+		return quote, err
+	}
+	quote.Tier = tier.Code
+
+	schedule, err := service.store.GetFeeSchedule(ctx, currency)
+	if errors.Is(err, domain.ErrNotFound) {
+		return quote, nil
+	}
+	if err != nil {
+		return quote, err
+	}
+
+	bands, err := service.store.ListFeeScheduleBands(ctx, schedule.ID)
+	if err != nil {
+		return quote, err
+	}
+
+	quote.Breakdown, err = Calculate(schedule, bands, amount)
+	if errors.Is(err, ErrOverflow) {
+		return quote, fmt.Errorf("%w: the fee on amount %d is too large", domain.ErrInvalidArgument, amount)
+	}
+	if err != nil {
+		return quote, err
+	}
+
+	if tier.FeesWaived {
+		quote.Waived = true
+		quote.Fee = 0
+	}
+
+	quote.Total, err = money.Add(amount, quote.Fee)
+	if err != nil {
+		return quote, fmt.Errorf("%w: the fee on amount %d is too large", domain.ErrInvalidArgument, amount)
+	}
+	return quote, nil
+}
+
+// Apply quotes arg for username, the owner of its from account, and
+// charges the fee to the bank's fee revenue account in currency.
+func (service *Service) Apply(ctx context.Context, username string, currency string, arg db.TransferTxParams) (db.TransferTxParams, Quote, error) {
+	quote, err := service.Quote(ctx, username, currency, arg.Amount)
+	if err != nil || quote.Fee == 0 {
+		return arg, quote, err
+	}
+
+	revenue, err := service.bank.Get(ctx, currency, bank.ProductFeeRevenue)
+	if err != nil {
+		return arg, quote, err
+	}
+
+	arg.Fee = quote.Fee
+	arg.FeeAccountID = revenue.ID
+	return arg, quote, nil
+}
@@ -0,0 +1,140 @@
+package test
+
+import (
+	"context"
+	"database/sql"
+	"math"
+	"testing"
+
+	"github.com/NhutHuyDev/sgbank/internal/accountno"
+	"github.com/NhutHuyDev/sgbank/internal/bank"
+	"github.com/NhutHuyDev/sgbank/internal/domain"
+	"github.com/NhutHuyDev/sgbank/internal/fee"
+	"github.com/NhutHuyDev/sgbank/internal/infra/db"
+	mockdb "github.com/NhutHuyDev/sgbank/internal/infra/db/mock"
+	"github.com/NhutHuyDev/sgbank/pkg/utils"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func upTo(amount int64) sql.NullInt64 {
+	return sql.NullInt64{Int64: amount, Valid: true}
+}
+
+func TestCalculate(t *testing.T) {
+	schedule := db.FeeSchedule{MinFee: 5, MaxFee: upTo(1000)}
+	bands := []db.FeeScheduleBand{
+		{UpTo: upTo(10000), FlatFee: 25},
+		{UpTo: upTo(100000), FlatFee: 10, RatePpm: 2500},
+		{RatePpm: 5000},
+	}
+
+	testCases := []struct {
+		name   string
+		amount int64
+		want   fee.Breakdown
+	}{
+		{name: "Flat", amount: 10000, want: fee.Breakdown{FlatFee: 25, Fee: 25}},
+		// 10001 * 0.25% = 25.0025
+		{name: "Tiered", amount: 10001, want: fee.Breakdown{FlatFee: 10, PercentageFee: 25, RatePpm: 2500, Fee: 35}},
+		// 100100 * 0.5% = 500.5 rounds to the even neighbour
+		{name: "HalfToEven", amount: 100100, want: fee.Breakdown{PercentageFee: 500, RatePpm: 5000, Fee: 500}},
+		{name: "Capped", amount: 1000000, want: fee.Breakdown{PercentageFee: 5000, RatePpm: 5000, Fee: 1000}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := fee.Calculate(schedule, bands, tc.amount)
+			require.NoError(t, err)
+			require.Equal(t, tc.want, got)
+		})
+	}
+
+	// no band covers the amount: the top band charges it
+	got, err := fee.Calculate(schedule, bands[:2], 200000)
+	require.NoError(t, err)
+	require.Equal(t, fee.Breakdown{FlatFee: 10, PercentageFee: 500, RatePpm: 2500, Fee: 510}, got)
+
+	// without bands only the minimum is charged
+	got, err = fee.Calculate(schedule, nil, 20000)
+	require.NoError(t, err)
+	require.Equal(t, fee.Breakdown{Fee: 5}, got)
+
+	_, err = fee.Calculate(db.FeeSchedule{}, []db.FeeScheduleBand{{RatePpm: 2 * fee.RatePrecision}}, math.MaxInt64)
+	require.ErrorIs(t, err, fee.ErrOverflow)
+}
+
+func newService(t *testing.T, store db.Store) *fee.Service {
+	generator, err := accountno.NewGenerator(accountno.DefaultBankCode, accountno.DefaultBranchCode)
+	require.NoError(t, err)
+
+	return fee.NewService(store, bank.NewAccounts(store, generator))
+}
+
+func TestApply(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	service := newService(t, store)
+
+	schedule := db.FeeSchedule{ID: 1, Currency: utils.EUR}
+	revenue := db.Account{ID: 9, Owner: bank.Username, Currency: utils.EUR, Product: bank.ProductFeeRevenue}
+
+	store.EXPECT().GetUserTier(gomock.Any(), gomock.Eq("alice")).Times(1).Return(db.UserTier{Code: "standard"}, nil)
+	store.EXPECT().GetFeeSchedule(gomock.Any(), gomock.Eq(utils.EUR)).Times(1).Return(schedule, nil)
+	store.EXPECT().ListFeeScheduleBands(gomock.Any(), gomock.Eq(schedule.ID)).Times(1).
+		Return([]db.FeeScheduleBand{{FlatFee: 30}}, nil)
+	store.EXPECT().GetAccountByOwnerProduct(gomock.Any(), gomock.Eq(db.GetAccountByOwnerProductParams{
+		Owner:    bank.Username,
+		Currency: utils.EUR,
+		Product:  bank.ProductFeeRevenue,
+	})).Times(1).Return(revenue, nil)
+
+	arg, quote, err := service.Apply(context.Background(), "alice", utils.EUR, db.TransferTxParams{
+		FromAccountID: 1,
+		ToAccountID:   2,
+		Amount:        100,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(30), arg.Fee)
+	require.Equal(t, revenue.ID, arg.FeeAccountID)
+	require.Equal(t, int64(130), quote.Total)
+}
+
+func TestQuoteWaived(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	service := newService(t, store)
+
+	schedule := db.FeeSchedule{ID: 1, Currency: utils.EUR}
+	store.EXPECT().GetUserTier(gomock.Any(), gomock.Any()).Times(1).Return(db.UserTier{Code: "premium", FeesWaived: true}, nil)
+	store.EXPECT().GetFeeSchedule(gomock.Any(), gomock.Any()).Times(1).Return(schedule, nil)
+	store.EXPECT().ListFeeScheduleBands(gomock.Any(), gomock.Any()).Times(1).
+		Return([]db.FeeScheduleBand{{FlatFee: 30}}, nil)
+
+	quote, err := service.Quote(context.Background(), "bob", utils.EUR, 100)
+	require.NoError(t, err)
+	require.True(t, quote.Waived)
+	require.Zero(t, quote.Fee)
+	require.Equal(t, int64(30), quote.FlatFee)
+	require.Equal(t, int64(100), quote.Total)
+}
+
+func TestQuoteTooLarge(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	service := newService(t, store)
+
+	store.EXPECT().GetUserTier(gomock.Any(), gomock.Any()).Times(1).Return(db.UserTier{Code: "standard"}, nil)
+	store.EXPECT().GetFeeSchedule(gomock.Any(), gomock.Any()).Times(1).Return(db.FeeSchedule{ID: 1}, nil)
+	store.EXPECT().ListFeeScheduleBands(gomock.Any(), gomock.Any()).Times(1).
+		Return([]db.FeeScheduleBand{{FlatFee: 30}}, nil)
+
+	_, err := service.Quote(context.Background(), "carol", utils.EUR, math.MaxInt64)
+	require.ErrorIs(t, err, domain.ErrInvalidArgument)
+}
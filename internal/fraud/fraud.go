@@ -0,0 +1,480 @@
+// Package fraud scores transfers against risk rules before they are posted.
+// Every rule that matches adds its score; a transfer whose total reaches
+// the hold score waits in a review queue until a staff member approves or
+// rejects it, and one that reaches the block score is refused. Each
+// decision is stored with the rules that matched.
+package fraud
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/NhutHuyDev/sgbank/internal/domain"
+	"github.com/NhutHuyDev/sgbank/internal/infra/db"
+	"github.com/NhutHuyDev/sgbank/internal/logging"
+	"github.com/NhutHuyDev/sgbank/internal/notify"
+	"github.com/NhutHuyDev/sgbank/pkg/utils"
+)
+
+// Rules.
+const (
+	// RuleVelocity matches when the sender already made VelocityLimit
+	// transfers within VelocityWindow.
+	RuleVelocity = "velocity"
+	// RuleNewBeneficiary matches a transfer of at least LargeAmount to an
+	// account the sender never paid before.
+	RuleNewBeneficiary = "new_beneficiary_large_amount"
+	// RuleUnusualIP matches when the sender signed in before, but never
+	// from the client IP of the transfer.
+	RuleUnusualIP = "unusual_ip"
+	// RuleRoundAmount matches a multiple of RoundAmount when the sender
+	// already sent another one within VelocityWindow.
+	RuleRoundAmount = "round_amount"
+)
+
+// Outcomes of an assessment.
+const (
+	OutcomeAllow = "allow"
+	OutcomeHold  = "hold"
+	OutcomeBlock = "block"
+)
+
+// Statuses of decisions.
+const (
+	StatusAllowed  = "allowed"
+	StatusBlocked  = "blocked"
+	StatusPending  = db.FraudDecisionPending
+	StatusApproved = db.FraudDecisionApproved
+	StatusRejected = db.FraudDecisionRejected
+)
+
+const (
+	KindHeld     = "transfer.held"
+	KindApproved = "transfer.approved"
+	KindRejected = "transfer.rejected"
+)
+
+// KnownIPAge is how old a session must be for its client IP to count as
+// known, so the sign-in that started the current session does not.
+const KnownIPAge = 24 * time.Hour
+
+const (
+	MaxNoteLength = 200
+	PageSize      = 100
+)
+
+type Config struct {
+	HoldScore  int32
+	BlockScore int32
+	// Scores is the score each rule adds when it matches. Rules without a
+	// positive score are off.
+	Scores         map[string]int32
+	VelocityWindow time.Duration
+	VelocityLimit  int64
+	LargeAmount    int64
+	RoundAmount    int64
+}
+
+var DefaultConfig = Config{
+	HoldScore:  50,
+	BlockScore: 100,
+	Scores: map[string]int32{
+		RuleVelocity:       40,
+		RuleNewBeneficiary: 60,
+		RuleUnusualIP:      30,
+		RuleRoundAmount:    20,
+	},
+	VelocityWindow: time.Hour,
+	VelocityLimit:  5,
+	LargeAmount:    100000,
+	RoundAmount:    10000,
+}
+
+// Hit is a rule that matched a transfer.
+type Hit struct {
+	Rule   string `json:"rule"`
+	Score  int32  `json:"score"`
+	Detail string `json:"detail"`
+}
+
+// Assessment is the score of a transfer and what to do with it.
+type Assessment struct {
+	Score   int32  `json:"score"`
+	Outcome string `json:"outcome"`
+	Hits    []Hit  `json:"hits"`
+}
+
+// Transfer is a transfer about to be posted.
+type Transfer struct {
+	Username string
+	ClientIP string
+	Currency string
+	Params   db.TransferTxParams
+	// BeneficiaryID is the account the transfer pays when the money goes
+	// through one of the bank's accounts, such as the payee's account of an
+	// escrow, and 0 when it is Params.ToAccountID. The rules and the
+	// recorded decision name the beneficiary.
+	BeneficiaryID int64
+	// Kind is what the transfer pays, db.HeldTransfer when empty, and
+	// Payload the parameters of the transaction that posts it once it is
+	// approved; see db.ApproveHeldTransferTx.
+	Kind    string
+	Payload any
+	// Immediate marks a transfer that cannot wait for a review, such as a
+	// row of an all-or-nothing batch. The rules block it instead of holding
+	// it.
+	Immediate bool
+}
+
+func (transfer Transfer) beneficiaryID() int64 {
+	if transfer.BeneficiaryID != 0 {
+		return transfer.BeneficiaryID
+	}
+
+	return transfer.Params.ToAccountID
+}
+
+type Service struct {
+	store    db.Store
+	notifier notify.Notifier
+	config   Config
+}
+
+func NewService(store db.Store, notifier notify.Notifier, config Config) *Service {
+	return &Service{store: store, notifier: notifier, config: config}
+}
+
+// FromConfig builds the service from the FRAUD_* settings, using
+// DefaultConfig for those that are not set. FRAUD_RULE_SCORES overrides
+// the scores of the rules it lists, e.g. "velocity=40,round_amount=0".
+func FromConfig(store db.Store, notifier notify.Notifier, config utils.Config) (*Service, error) {
+	fraudConfig := DefaultConfig
+	fraudConfig.Scores = make(map[string]int32, len(DefaultConfig.Scores))
+	for rule, score := range DefaultConfig.Scores {
+		fraudConfig.Scores[rule] = score
+	}
+
+	if config.FraudHoldScore > 0 {
+		fraudConfig.HoldScore = int32(config.FraudHoldScore)
+	}
+	if config.FraudBlockScore > 0 {
+		fraudConfig.BlockScore = int32(config.FraudBlockScore)
+	}
+	if config.FraudVelocityWindow > 0 {
+		fraudConfig.VelocityWindow = config.FraudVelocityWindow
+	}
+	if config.FraudVelocityLimit > 0 {
+		fraudConfig.VelocityLimit = config.FraudVelocityLimit
+	}
+	if config.FraudLargeAmount > 0 {
+		fraudConfig.LargeAmount = config.FraudLargeAmount
+	}
+	if config.FraudRoundAmount > 0 {
+		fraudConfig.RoundAmount = config.FraudRoundAmount
+	}
+
+	if err := parseScores(config.FraudRuleScores, fraudConfig.Scores); err != nil {
+		return nil, fmt.Errorf("invalid FRAUD_RULE_SCORES: %w", err)
+	}
+
+	return NewService(store, notifier, fraudConfig), nil
+}
+
+func parseScores(value string, scores map[string]int32) error {
+	for _, pair := range strings.Split(value, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+
+		rule, score, found := strings.Cut(pair, "=")
+		if !found {
+			return fmt.Errorf("%q is not rule=score", pair)
+		}
+
+		rule = strings.TrimSpace(rule)
+		if _, ok := DefaultConfig.Scores[rule]; !ok {
+			return fmt.Errorf("unknown rule %q", rule)
+		}
+
+		n, err := strconv.ParseInt(strings.TrimSpace(score), 10, 32)
+		if err != nil {
+			return fmt.Errorf("score of %s: %w", rule, err)
+		}
+
+		scores[rule] = int32(n)
+	}
+
+	return nil
+}
+
+func (service *Service) enabled(rule string) bool {
+	return service.config.Scores[rule] > 0
+}
+
+// Evaluate runs the rules against a transfer.
+func (service *Service) Evaluate(ctx context.Context, transfer Transfer) (Assessment, error) {
+	config := service.config
+	amount := transfer.Params.Amount
+	now := time.Now()
+
+	var hits []Hit
+	hit := func(rule string, detail string) {
+		hits = append(hits, Hit{Rule: rule, Score: config.Scores[rule], Detail: detail})
+	}
+
+	if service.enabled(RuleVelocity) || service.enabled(RuleRoundAmount) {
+		recent, err := service.store.CountRecentTransfers(ctx, db.CountRecentTransfersParams{
+			Owner:        transfer.Username,
+			CreatedSince: now.Add(-config.VelocityWindow),
+			RoundAmount:  config.RoundAmount,
+		})
+		if err != nil {
+			return Assessment{}, err
+		}
+
+		if service.enabled(RuleVelocity) && recent.Transfers >= config.VelocityLimit {
+			hit(RuleVelocity, fmt.Sprintf("%d transfers in the last %s", recent.Transfers+1, config.VelocityWindow))
+		}
+
+		if service.enabled(RuleRoundAmount) && config.RoundAmount > 0 && amount%config.RoundAmount == 0 && recent.RoundTransfers > 0 {
+			hit(RuleRoundAmount, fmt.Sprintf("%d transfers of multiples of %d in the last %s", recent.RoundTransfers+1, config.RoundAmount, config.VelocityWindow))
+		}
+	}
+
+	if service.enabled(RuleNewBeneficiary) && amount >= config.LargeAmount {
+		paid, err := service.store.HasTransferredTo(ctx, db.HasTransferredToParams{
+			Owner:       transfer.Username,
+			ToAccountID: transfer.beneficiaryID(),
+		})
+		if err != nil {
+			return Assessment{}, err
+		}
+
+		if !paid {
+			hit(RuleNewBeneficiary, fmt.Sprintf("first transfer to account [%d], of at least %d", transfer.beneficiaryID(), config.LargeAmount))
+		}
+	}
+
+	if service.enabled(RuleUnusualIP) && transfer.ClientIP != "" {
+		sessions, err := service.store.CountSessionsFromClientIP(ctx, db.CountSessionsFromClientIPParams{
+			Username:      transfer.Username,
+			ClientIp:      transfer.ClientIP,
+			CreatedBefore: now.Add(-KnownIPAge),
+		})
+		if err != nil {
+			return Assessment{}, err
+		}
+
+		if sessions.Sessions > 0 && sessions.FromClientIp == 0 {
+			hit(RuleUnusualIP, fmt.Sprintf("no earlier sign-in from %s", transfer.ClientIP))
+		}
+	}
+
+	return service.assess(hits), nil
+}
+
+func (service *Service) assess(hits []Hit) Assessment {
+	assessment := Assessment{Outcome: OutcomeAllow, Hits: hits}
+	if assessment.Hits == nil {
+		assessment.Hits = []Hit{}
+	}
+
+	for _, hit := range hits {
+		assessment.Score += hit.Score
+	}
+
+	switch {
+	case assessment.Score >= service.config.BlockScore:
+		assessment.Outcome = OutcomeBlock
+	case assessment.Score >= service.config.HoldScore:
+		assessment.Outcome = OutcomeHold
+	}
+
+	return assessment
+}
+
+// Decide evaluates a transfer and records the decision. A held transfer
+// gets a pending decision and its sender is told it waits for review; a
+// blocked one fails with ErrTransferBlocked. The caller posts the transfer
+// when it is allowed and links it with Link.
+func (service *Service) Decide(ctx context.Context, transfer Transfer) (db.FraudDecision, error) {
+	assessment, err := service.Evaluate(ctx, transfer)
+	if err != nil {
+		return db.FraudDecision{}, err
+	}
+
+	status := StatusAllowed
+	switch {
+	case assessment.Outcome == OutcomeBlock, assessment.Outcome == OutcomeHold && transfer.Immediate:
+		status = StatusBlocked
+	case assessment.Outcome == OutcomeHold:
+		status = StatusPending
+	}
+
+	decision, err := service.record(ctx, transfer, assessment, status)
+	if err != nil {
+		return db.FraudDecision{}, err
+	}
+
+	switch status {
+	case StatusBlocked:
+		return decision, fmt.Errorf("%w: fraud decision [%d]", domain.ErrTransferBlocked, decision.ID)
+	case StatusPending:
+		service.notifier.Notify(ctx, notify.Notification{
+			Username: transfer.Username,
+			Kind:     KindHeld,
+			Message:  fmt.Sprintf("your transfer of %d %s is held for review", decision.Amount, decision.Currency),
+		})
+	}
+
+	return decision, nil
+}
+
+// Link records the transfer posted for an allowed decision. A failure is
+// only logged, since the transfer is posted by then.
+func (service *Service) Link(ctx context.Context, decision db.FraudDecision, transferID int64) db.FraudDecision {
+	err := service.store.SetFraudDecisionTransfer(ctx, db.SetFraudDecisionTransferParams{
+		ID:         decision.ID,
+		TransferID: sql.NullInt64{Int64: transferID, Valid: true},
+	})
+	if err != nil {
+		logging.FromContext(ctx).Warn().Err(err).Int64("fraud_decision_id", decision.ID).Msg("cannot link fraud decision to transfer")
+		return decision
+	}
+
+	decision.TransferID = sql.NullInt64{Int64: transferID, Valid: true}
+	return decision
+}
+
+func (service *Service) record(ctx context.Context, transfer Transfer, assessment Assessment, status string) (db.FraudDecision, error) {
+	hits, err := json.Marshal(assessment.Hits)
+	if err != nil {
+		return db.FraudDecision{}, err
+	}
+
+	params, err := json.Marshal(transfer.Params)
+	if err != nil {
+		return db.FraudDecision{}, err
+	}
+
+	kind, payload := transfer.Kind, json.RawMessage(`{}`)
+	if kind == "" {
+		kind = db.HeldTransfer
+	}
+	if transfer.Payload != nil {
+		payload, err = json.Marshal(transfer.Payload)
+		if err != nil {
+			return db.FraudDecision{}, err
+		}
+	}
+
+	return service.store.CreateFraudDecision(ctx, db.CreateFraudDecisionParams{
+		Username:      transfer.Username,
+		FromAccountID: transfer.Params.FromAccountID,
+		ToAccountID:   transfer.beneficiaryID(),
+		Amount:        transfer.Params.Amount,
+		Currency:      transfer.Currency,
+		ClientIp:      transfer.ClientIP,
+		Score:         assessment.Score,
+		Outcome:       assessment.Outcome,
+		Hits:          hits,
+		Transfer:      params,
+		Status:        status,
+		Kind:          kind,
+		Payload:       payload,
+	})
+}
+
+// Pending lists the transfers held for review, oldest first, after the
+// decision afterID.
+func (service *Service) Pending(ctx context.Context, afterID int64, pageSize int32) ([]db.FraudDecision, error) {
+	return service.store.ListPendingFraudDecisions(ctx, db.ListPendingFraudDecisionsParams{
+		AfterID:   afterID,
+		PageLimit: pageSize,
+	})
+}
+
+type ReviewParams struct {
+	ID       int64
+	Reviewer string
+	Note     string
+}
+
+// Approve posts a held transfer and settles what it pays, e.g. a payment
+// request. It fails with ErrInvalidTransition when the decision is not
+// pending, and leaves it pending when the transfer fails.
+func (service *Service) Approve(ctx context.Context, arg ReviewParams) (db.ApproveHeldTransferTxResult, error) {
+	if err := validateNote(arg.Note); err != nil {
+		return db.ApproveHeldTransferTxResult{}, err
+	}
+
+	result, err := service.store.ApproveHeldTransferTx(ctx, db.ApproveHeldTransferTxParams{
+		ID:         arg.ID,
+		ReviewedBy: arg.Reviewer,
+		ReviewNote: strings.TrimSpace(arg.Note),
+	})
+	if err != nil {
+		return result, err
+	}
+
+	service.notifier.Notify(ctx, notify.Notification{
+		Username: result.Decision.Username,
+		Kind:     KindApproved,
+		Message:  fmt.Sprintf("your transfer of %d %s was approved", result.Decision.Amount, result.Decision.Currency),
+	})
+
+	return result, nil
+}
+
+// Reject refuses a held transfer, and fails the batch row it would have
+// posted. It fails with ErrInvalidTransition when the decision is not
+// pending.
+func (service *Service) Reject(ctx context.Context, arg ReviewParams) (db.FraudDecision, error) {
+	if err := validateNote(arg.Note); err != nil {
+		return db.FraudDecision{}, err
+	}
+
+	decision, err := service.store.GetFraudDecision(ctx, arg.ID)
+	if err != nil {
+		return decision, err
+	}
+
+	if decision.Status != StatusPending {
+		return decision, fmt.Errorf("%w: fraud decision [%d] is %s", domain.ErrInvalidTransition, decision.ID, decision.Status)
+	}
+
+	result, err := service.store.RejectHeldTransferTx(ctx, db.RejectHeldTransferTxParams{
+		ID:         arg.ID,
+		ReviewedBy: arg.Reviewer,
+		ReviewNote: strings.TrimSpace(arg.Note),
+	})
+	if err != nil {
+		return decision, err
+	}
+	decision = result.Decision
+
+	service.notifier.Notify(ctx, notify.Notification{
+		Username: decision.Username,
+		Kind:     KindRejected,
+		Message:  fmt.Sprintf("your transfer of %d %s was rejected", decision.Amount, decision.Currency),
+	})
+
+	return decision, nil
+}
+
+func validateNote(note string) error {
+	if len(strings.TrimSpace(note)) > MaxNoteLength {
+		return domain.NewValidationError(domain.FieldViolation{
+			Field:       "note",
+			Description: fmt.Sprintf("must be at most %d characters", MaxNoteLength),
+		})
+	}
+
+	return nil
+}
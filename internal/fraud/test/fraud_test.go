@@ -0,0 +1,237 @@
+package test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/NhutHuyDev/sgbank/internal/domain"
+	"github.com/NhutHuyDev/sgbank/internal/fraud"
+	"github.com/NhutHuyDev/sgbank/internal/infra/db"
+	mockdb "github.com/NhutHuyDev/sgbank/internal/infra/db/mock"
+	"github.com/NhutHuyDev/sgbank/internal/notify"
+	"github.com/NhutHuyDev/sgbank/pkg/utils"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+type recordingNotifier struct {
+	notifications []notify.Notification
+}
+
+func (notifier *recordingNotifier) Notify(_ context.Context, notification notify.Notification) {
+	notifier.notifications = append(notifier.notifications, notification)
+}
+
+func newTransfer(amount int64) fraud.Transfer {
+	return fraud.Transfer{
+		Username: "alice",
+		ClientIP: "203.0.113.7",
+		Currency: utils.USD,
+		Params:   db.TransferTxParams{FromAccountID: 1, ToAccountID: 2, Amount: amount},
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	testCases := []struct {
+		name       string
+		amount     int64
+		recent     db.CountRecentTransfersRow
+		paid       bool
+		sessions   db.CountSessionsFromClientIPRow
+		outcome    string
+		score      int32
+		rulesMatch []string
+	}{
+		{
+			name:     "Allow",
+			amount:   500,
+			recent:   db.CountRecentTransfersRow{Transfers: 1},
+			paid:     true,
+			sessions: db.CountSessionsFromClientIPRow{Sessions: 3, FromClientIp: 1},
+			outcome:  fraud.OutcomeAllow,
+		},
+		{
+			name:       "HoldNewBeneficiary",
+			amount:     150000,
+			recent:     db.CountRecentTransfersRow{Transfers: 1},
+			sessions:   db.CountSessionsFromClientIPRow{Sessions: 3, FromClientIp: 1},
+			outcome:    fraud.OutcomeHold,
+			score:      60,
+			rulesMatch: []string{fraud.RuleNewBeneficiary},
+		},
+		{
+			name:       "HoldVelocityRoundAmountUnusualIP",
+			amount:     20000,
+			recent:     db.CountRecentTransfersRow{Transfers: 5, RoundTransfers: 2},
+			paid:       true,
+			sessions:   db.CountSessionsFromClientIPRow{Sessions: 3},
+			outcome:    fraud.OutcomeHold,
+			score:      90,
+			rulesMatch: []string{fraud.RuleVelocity, fraud.RuleRoundAmount, fraud.RuleUnusualIP},
+		},
+		{
+			name:       "Block",
+			amount:     200000,
+			recent:     db.CountRecentTransfersRow{Transfers: 6},
+			sessions:   db.CountSessionsFromClientIPRow{Sessions: 3, FromClientIp: 1},
+			outcome:    fraud.OutcomeBlock,
+			score:      100,
+			rulesMatch: []string{fraud.RuleVelocity, fraud.RuleNewBeneficiary},
+		},
+		{
+			name:     "FirstSignIn",
+			amount:   500,
+			paid:     true,
+			sessions: db.CountSessionsFromClientIPRow{},
+			outcome:  fraud.OutcomeAllow,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().CountRecentTransfers(gomock.Any(), gomock.Any()).Times(1).Return(tc.recent, nil)
+			store.EXPECT().HasTransferredTo(gomock.Any(), gomock.Any()).AnyTimes().Return(tc.paid, nil)
+			store.EXPECT().CountSessionsFromClientIP(gomock.Any(), gomock.Any()).Times(1).Return(tc.sessions, nil)
+
+			service := fraud.NewService(store, &recordingNotifier{}, fraud.DefaultConfig)
+
+			assessment, err := service.Evaluate(context.Background(), newTransfer(tc.amount))
+			require.NoError(t, err)
+			require.Equal(t, tc.outcome, assessment.Outcome)
+			require.Equal(t, tc.score, assessment.Score)
+
+			rules := make([]string, 0, len(assessment.Hits))
+			for _, hit := range assessment.Hits {
+				rules = append(rules, hit.Rule)
+			}
+			require.ElementsMatch(t, tc.rulesMatch, rules)
+		})
+	}
+}
+
+func TestFromConfig(t *testing.T) {
+	_, err := fraud.FromConfig(nil, nil, utils.Config{FraudRuleScores: "velocity=10,geo=5"})
+	require.ErrorContains(t, err, "unknown rule")
+
+	service, err := fraud.FromConfig(nil, nil, utils.Config{FraudRuleScores: "velocity=0,new_beneficiary_large_amount=0,unusual_ip=0,round_amount=0"})
+	require.NoError(t, err)
+
+	// every rule is off, so the store is never read
+	assessment, err := service.Evaluate(context.Background(), newTransfer(1000000))
+	require.NoError(t, err)
+	require.Equal(t, fraud.OutcomeAllow, assessment.Outcome)
+	require.Empty(t, assessment.Hits)
+}
+
+func TestDecideHeld(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	notifier := &recordingNotifier{}
+
+	config := fraud.DefaultConfig
+	config.Scores = map[string]int32{fraud.RuleNewBeneficiary: 60}
+
+	store.EXPECT().HasTransferredTo(gomock.Any(), gomock.Eq(db.HasTransferredToParams{Owner: "alice", ToAccountID: 2})).Times(1).Return(false, nil)
+	store.EXPECT().
+		CreateFraudDecision(gomock.Any(), gomock.Any()).
+		Times(1).
+		DoAndReturn(func(_ context.Context, arg db.CreateFraudDecisionParams) (db.FraudDecision, error) {
+			require.Equal(t, fraud.OutcomeHold, arg.Outcome)
+			require.Equal(t, fraud.StatusPending, arg.Status)
+			require.Equal(t, int32(60), arg.Score)
+			require.JSONEq(t, `{"from_account_id":1,"to_account_id":2,"amount":150000,"description":"","external_reference":"","merchant_category":"","fee":0,"fee_account_id":0,"business_date":"0001-01-01T00:00:00Z"}`, string(arg.Transfer))
+			require.Equal(t, db.HeldTransfer, arg.Kind)
+			require.JSONEq(t, `{}`, string(arg.Payload))
+			return db.FraudDecision{ID: 9, Username: arg.Username, Amount: arg.Amount, Currency: arg.Currency, Status: arg.Status}, nil
+		})
+
+	decision, err := fraud.NewService(store, notifier, config).Decide(context.Background(), newTransfer(150000))
+	require.NoError(t, err)
+	require.Equal(t, int64(9), decision.ID)
+	require.Equal(t, fraud.StatusPending, decision.Status)
+
+	require.Len(t, notifier.notifications, 1)
+	require.Equal(t, fraud.KindHeld, notifier.notifications[0].Kind)
+	require.Equal(t, "alice", notifier.notifications[0].Username)
+}
+
+func TestDecideBeneficiary(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+
+	config := fraud.DefaultConfig
+	config.Scores = map[string]int32{fraud.RuleNewBeneficiary: 60}
+
+	// a payment into escrow posts to the escrow account 99 for account 2
+	transfer := newTransfer(150000)
+	transfer.Params.ToAccountID = 99
+	transfer.BeneficiaryID = 2
+	transfer.Kind = db.HeldEscrow
+
+	store.EXPECT().HasTransferredTo(gomock.Any(), gomock.Eq(db.HasTransferredToParams{Owner: "alice", ToAccountID: 2})).Times(1).Return(false, nil)
+	store.EXPECT().
+		CreateFraudDecision(gomock.Any(), gomock.Any()).
+		Times(1).
+		DoAndReturn(func(_ context.Context, arg db.CreateFraudDecisionParams) (db.FraudDecision, error) {
+			require.Equal(t, int64(2), arg.ToAccountID)
+			require.Contains(t, string(arg.Transfer), `"to_account_id":99`)
+			return db.FraudDecision{ID: 9, Status: arg.Status}, nil
+		})
+
+	decision, err := fraud.NewService(store, &recordingNotifier{}, config).Decide(context.Background(), transfer)
+	require.NoError(t, err)
+	require.Equal(t, fraud.StatusPending, decision.Status)
+}
+
+func TestDecideImmediateBlocksHold(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	notifier := &recordingNotifier{}
+
+	config := fraud.DefaultConfig
+	config.Scores = map[string]int32{fraud.RuleNewBeneficiary: 60}
+
+	transfer := newTransfer(150000)
+	transfer.Kind = db.HeldTransferBatchItem
+	transfer.Payload = db.PostTransferBatchItemTxParams{FromAccountID: 1, Item: db.TransferBatchItem{ID: 5}}
+	transfer.Immediate = true
+
+	store.EXPECT().HasTransferredTo(gomock.Any(), gomock.Any()).Times(1).Return(false, nil)
+	store.EXPECT().
+		CreateFraudDecision(gomock.Any(), gomock.Any()).
+		Times(1).
+		DoAndReturn(func(_ context.Context, arg db.CreateFraudDecisionParams) (db.FraudDecision, error) {
+			require.Equal(t, fraud.OutcomeHold, arg.Outcome)
+			require.Equal(t, fraud.StatusBlocked, arg.Status)
+			require.Equal(t, db.HeldTransferBatchItem, arg.Kind)
+			require.Contains(t, string(arg.Payload), `"from_account_id":1`)
+			return db.FraudDecision{ID: 9, Status: arg.Status}, nil
+		})
+
+	decision, err := fraud.NewService(store, notifier, config).Decide(context.Background(), transfer)
+	require.ErrorIs(t, err, domain.ErrTransferBlocked)
+	require.Equal(t, fraud.StatusBlocked, decision.Status)
+	require.Empty(t, notifier.notifications)
+}
+
+func TestRejectNotPending(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().GetFraudDecision(gomock.Any(), gomock.Eq(int64(9))).Times(1).Return(db.FraudDecision{ID: 9, Status: fraud.StatusApproved}, nil)
+	store.EXPECT().RejectHeldTransferTx(gomock.Any(), gomock.Any()).Times(0)
+
+	_, err := fraud.NewService(store, &recordingNotifier{}, fraud.DefaultConfig).Reject(context.Background(), fraud.ReviewParams{ID: 9, Reviewer: "admin"})
+	require.ErrorIs(t, err, domain.ErrInvalidTransition)
+}
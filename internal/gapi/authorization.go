@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/NhutHuyDev/sgbank/internal/domain"
+	"github.com/NhutHuyDev/sgbank/internal/logging"
 	"github.com/NhutHuyDev/sgbank/internal/token"
 	"google.golang.org/grpc/metadata"
 )
@@ -17,30 +19,32 @@ const (
 func (server *Server) authorizeUser(ctx context.Context) (*token.Payload, error) {
 	md, ok := metadata.FromIncomingContext(ctx)
 	if !ok {
-		return nil, fmt.Errorf("missing metadata")
+		return nil, fmt.Errorf("%w: missing metadata", domain.ErrUnauthenticated)
 	}
 
 	values := md.Get(authorizationHeader)
 	if len(values) == 0 {
-		return nil, fmt.Errorf("missing authorization header")
+		return nil, fmt.Errorf("%w: missing authorization header", domain.ErrUnauthenticated)
 	}
 
 	authHeader := values[0]
 	fields := strings.Fields(authHeader)
 	if len(fields) < 2 {
-		return nil, fmt.Errorf("invalid authorization header format")
+		return nil, fmt.Errorf("%w: invalid authorization header format", domain.ErrUnauthenticated)
 	}
 
 	authScheme := strings.ToLower(fields[0])
 	if authScheme != authorizationScheme {
-		return nil, fmt.Errorf("unsupported authorization type: %s", authScheme)
+		return nil, fmt.Errorf("%w: unsupported authorization type: %s", domain.ErrUnauthenticated, authScheme)
 	}
 
 	accessToken := fields[1]
 	payload, err := server.TokenMaker.VerifyToken(accessToken)
 	if err != nil {
-		return nil, fmt.Errorf("invalid access token: %s", authScheme)
+		return nil, fmt.Errorf("%w: invalid access token: %w", domain.ErrUnauthenticated, err)
 	}
 
+	logging.SetUsername(ctx, payload.Username)
+
 	return payload, nil
 }
@@ -1,6 +1,7 @@
 package gapi
 
 import (
+	"github.com/NhutHuyDev/sgbank/internal/currency"
 	"github.com/NhutHuyDev/sgbank/internal/infra/db"
 	"github.com/NhutHuyDev/sgbank/pb"
 	"google.golang.org/protobuf/types/known/timestamppb"
@@ -15,3 +16,36 @@ func convertUser(user db.User) *pb.User {
 		CreatedAt:         timestamppb.New(user.CreatedAt),
 	}
 }
+
+func convertEntry(registry *currency.Registry, row db.EntryPageRow, currencyCode string) *pb.Entry {
+	return &pb.Entry{
+		Id:                    row.Entry.ID,
+		AccountId:             row.Entry.AccountID,
+		Amount:                row.Entry.Amount,
+		TransferId:            row.Entry.TransferID,
+		CounterpartyAccountId: row.CounterpartyAccountID,
+		CreatedAt:             timestamppb.New(row.Entry.CreatedAt),
+		Description:           row.Entry.Description,
+		ExternalReference:     row.Entry.ExternalReference,
+		MerchantCategory:      row.Entry.MerchantCategory,
+		Category:              row.Entry.Category,
+		AmountDecimal:         registry.Format(row.Entry.Amount, currencyCode),
+	}
+}
+
+func convertTransfer(registry *currency.Registry, row db.TransferPageRow) *pb.Transfer {
+	return &pb.Transfer{
+		Id:                row.Transfer.ID,
+		FromAccountId:     row.Transfer.FromAccountID,
+		ToAccountId:       row.Transfer.ToAccountID,
+		Amount:            row.Transfer.Amount,
+		Currency:          row.Currency,
+		CreatedAt:         timestamppb.New(row.Transfer.CreatedAt),
+		Description:       row.Transfer.Description,
+		ExternalReference: row.Transfer.ExternalReference,
+		MerchantCategory:  row.Transfer.MerchantCategory,
+		Fee:               row.Transfer.Fee,
+		AmountDecimal:     registry.Format(row.Transfer.Amount, row.Currency),
+		FeeDecimal:        registry.Format(row.Transfer.Fee, row.Currency),
+	}
+}
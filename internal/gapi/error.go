@@ -1,30 +1,110 @@
 package gapi
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/NhutHuyDev/sgbank/internal/domain"
+	"github.com/NhutHuyDev/sgbank/internal/logging"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	"google.golang.org/genproto/googleapis/rpc/errdetails"
-	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
 )
 
-func fieldViolation(field string, err error) *errdetails.BadRequest_FieldViolation {
-	return &errdetails.BadRequest_FieldViolation{
+const errorDomain = "sgbank"
+
+func fieldViolation(field string, err error) domain.FieldViolation {
+	return domain.FieldViolation{
 		Field:       field,
 		Description: err.Error(),
 	}
 }
 
-func invalidArgumentError(violations []*errdetails.BadRequest_FieldViolation) error {
-	badRequest := &errdetails.BadRequest{FieldViolations: violations}
-	statusInvalid := status.New(codes.InvalidArgument, "invalid paramaters")
+func invalidArgumentError(violations []domain.FieldViolation) error {
+	return domain.NewValidationError(violations...)
+}
+
+// statusError converts err into a gRPC status carrying the domain error code
+// in errdetails.ErrorInfo, the same code the REST API reports in its
+// problem details.
+func statusError(ctx context.Context, err error) error {
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	domainErr := domain.Lookup(err)
+
+	message := err.Error()
+	if domainErr.Kind == domain.KindInternal {
+		logging.FromContext(ctx).Error().Err(err).Msg("internal error")
+		message = domainErr.Message
+	}
+
+	details := []protoadapt.MessageV1{
+		&errdetails.ErrorInfo{
+			Reason: domainErr.Code,
+			Domain: errorDomain,
+		},
+	}
+
+	var validationErr *domain.ValidationError
+	if errors.As(err, &validationErr) {
+		badRequest := &errdetails.BadRequest{}
+		for _, violation := range validationErr.Violations {
+			badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+				Field:       violation.Field,
+				Description: violation.Description,
+			})
+		}
+		details = append(details, badRequest)
+	}
 
-	statusDetails, err := statusInvalid.WithDetails(badRequest)
+	if requestID := logging.RequestID(ctx); requestID != "" {
+		details = append(details, &errdetails.RequestInfo{RequestId: requestID})
+	}
+
+	st := status.New(domainErr.Kind.GRPCCode(), message)
+	stWithDetails, detailsErr := st.WithDetails(details...)
+	if detailsErr != nil {
+		return st.Err()
+	}
+
+	return stWithDetails.Err()
+}
+
+// ErrorInterceptor maps errors returned by the RPC handlers to gRPC statuses.
+func ErrorInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	result, err := handler(ctx, req)
 	if err != nil {
-		return statusInvalid.Err()
+		return result, statusError(ctx, err)
 	}
 
-	return statusDetails.Err()
+	return result, nil
 }
 
-func unauthenticatedError(err error) error {
-	return status.Errorf(codes.Unauthenticated, "unauthorized: %s", err)
+// gatewayErrorHandler renders errors from the in-process handlers as the
+// same problem details the Gin server writes. The gateway calls the handlers
+// directly, bypassing ErrorInterceptor, so it sees domain errors here.
+// Routing errors raised by the gateway itself keep the default rendering.
+func gatewayErrorHandler(ctx context.Context, mux *runtime.ServeMux, marshaler runtime.Marshaler, res http.ResponseWriter, req *http.Request, err error) {
+	if _, ok := status.FromError(err); ok {
+		runtime.DefaultHTTPErrorHandler(ctx, mux, marshaler, res, req, err)
+		return
+	}
+
+	problem := domain.NewProblem(err)
+	problem.Instance = req.URL.Path
+	problem.RequestID = logging.RequestID(req.Context())
+
+	if problem.Code == domain.ErrInternal.Code {
+		logging.FromContext(req.Context()).Error().Err(err).Msg("internal error")
+	}
+
+	res.Header().Set("Content-Type", domain.ProblemContentType)
+	res.WriteHeader(problem.Status)
+	_ = json.NewEncoder(res).Encode(problem)
 }
@@ -5,15 +5,26 @@ import (
 	"net/http"
 	"time"
 
-	"github.com/rs/zerolog/log"
-
+	"github.com/NhutHuyDev/sgbank/internal/logging"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 )
 
 func GrpcLogger(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
 	startTime := time.Now()
+
+	mtdt := extractMetadata(ctx)
+	requestID := logging.RequestIDOrNew(mtdt.RequestID)
+	_ = grpc.SetHeader(ctx, metadata.Pairs(logging.RequestIDMetadataKey, requestID))
+
+	ctx = logging.NewContext(ctx, requestID, map[string]interface{}{
+		"protocol":  "Grpc",
+		"method":    info.FullMethod,
+		"client_ip": mtdt.ClientIP,
+	})
+
 	result, err := handler(ctx, req)
 	duration := time.Since(startTime)
 
@@ -22,13 +33,13 @@ func GrpcLogger(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo
 		statusCode = st.Code()
 	}
 
-	logger := log.Info()
+	logger := logging.FromContext(ctx)
+	event := logger.Info()
 	if err != nil {
-		logger = log.Error().Err(err)
+		event = logger.Error().Err(err)
 	}
 
-	logger.Str("protocol", "Grpc").
-		Int("status_code", int(statusCode)).
+	event.Int("status_code", int(statusCode)).
 		Str("status_text", statusCode.String()).
 		Dur("duration", duration).
 		Msg("received a gRPC request")
@@ -36,41 +47,6 @@ func GrpcLogger(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo
 	return result, err
 }
 
-type ResponseRecorder struct {
-	http.ResponseWriter
-	StatusCode int
-	Body       []byte
-}
-
-func (rec *ResponseRecorder) WriteHeader(statusCode int) {
-	rec.StatusCode = statusCode
-	rec.ResponseWriter.WriteHeader(statusCode)
-}
-
-func (rec *ResponseRecorder) Write(body []byte) (int, error) {
-	rec.Body = body
-	return rec.ResponseWriter.Write(body)
-}
-
 func HttpLogger(handler http.Handler) http.Handler {
-	return http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
-		startTime := time.Now()
-		rec := &ResponseRecorder{
-			ResponseWriter: res,
-			StatusCode:     http.StatusOK,
-		}
-		handler.ServeHTTP(rec, req)
-		duration := time.Since(startTime)
-
-		logger := log.Info()
-		if rec.StatusCode != http.StatusOK {
-			logger = log.Error().Bytes("body", rec.Body)
-		}
-
-		logger.Str("protocol", "Grpc").
-			Int("status_code", rec.StatusCode).
-			Str("status_text", http.StatusText(rec.StatusCode)).
-			Dur("duration", duration).
-			Msg("received a HTTP request")
-	})
+	return logging.HTTPMiddleware(handler, "HTTP gateway")
 }
@@ -3,6 +3,7 @@ package gapi
 import (
 	"context"
 
+	"github.com/NhutHuyDev/sgbank/internal/logging"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/peer"
 )
@@ -16,9 +17,14 @@ const (
 type Metadata struct {
 	UserAgent string
 	ClientIP  string
+	RequestID string
 }
 
 func (server *Server) extractMetaData(ctx context.Context) *Metadata {
+	return extractMetadata(ctx)
+}
+
+func extractMetadata(ctx context.Context) *Metadata {
 	mtdt := &Metadata{}
 
 	if md, ok := metadata.FromIncomingContext(ctx); ok {
@@ -34,6 +40,10 @@ func (server *Server) extractMetaData(ctx context.Context) *Metadata {
 			mtdt.ClientIP = clientIPs[0]
 		}
 
+		if requestIDs := md.Get(logging.RequestIDMetadataKey); len(requestIDs) > 0 {
+			mtdt.RequestID = requestIDs[0]
+		}
+
 		if p, ok := peer.FromContext(ctx); ok {
 			mtdt.ClientIP = p.Addr.String()
 		}
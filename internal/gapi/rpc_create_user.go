@@ -2,21 +2,20 @@ package gapi
 
 import (
 	"context"
+	"fmt"
 
+	"github.com/NhutHuyDev/sgbank/internal/domain"
 	"github.com/NhutHuyDev/sgbank/internal/infra/db"
+	"github.com/NhutHuyDev/sgbank/internal/screening"
 	"github.com/NhutHuyDev/sgbank/pb"
 	"github.com/NhutHuyDev/sgbank/pkg/secure"
 	"github.com/NhutHuyDev/sgbank/pkg/val"
-	"github.com/lib/pq"
-	"google.golang.org/genproto/googleapis/rpc/errdetails"
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
 )
 
 func (server *Server) CreateUser(ctx context.Context, req *pb.CreateUserRequest) (*pb.CreateUserResponse, error) {
 	authPayload, err := server.authorizeUser(ctx)
 	if err != nil {
-		return nil, unauthenticatedError(err)
+		return nil, err
 	}
 
 	violations := validateCreateUserRequest(req)
@@ -25,12 +24,21 @@ func (server *Server) CreateUser(ctx context.Context, req *pb.CreateUserRequest)
 	}
 
 	if authPayload.Username != req.GetUsername() {
-		return nil, status.Errorf(codes.PermissionDenied, "cannot update other user's info")
+		return nil, fmt.Errorf("%w: cannot update other user's info", domain.ErrForbidden)
+	}
+
+	_, err = server.Screening.Screen(ctx, screening.Subject{
+		Kind:     screening.SubjectSignup,
+		Name:     req.GetFullName(),
+		Username: req.GetUsername(),
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	hashedPassword, err := secure.HashPassword(req.Password)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to hash password: %s", err)
+		return nil, fmt.Errorf("failed to hash password: %w", err)
 	}
 
 	arg := db.CreateUserParams{
@@ -42,14 +50,7 @@ func (server *Server) CreateUser(ctx context.Context, req *pb.CreateUserRequest)
 
 	user, err := server.Store.CreateUser(ctx, arg)
 	if err != nil {
-		if pqErr, ok := err.(*pq.Error); ok {
-			switch pqErr.Code.Name() {
-			case "unique_violation":
-				return nil, status.Errorf(codes.AlreadyExists, "username already exists: %s", err)
-			}
-		}
-
-		return nil, status.Errorf(codes.Internal, "failed to create user: %s", err)
+		return nil, err
 	}
 
 	rsp := &pb.CreateUserResponse{
@@ -59,7 +60,7 @@ func (server *Server) CreateUser(ctx context.Context, req *pb.CreateUserRequest)
 	return rsp, nil
 }
 
-func validateCreateUserRequest(req *pb.CreateUserRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+func validateCreateUserRequest(req *pb.CreateUserRequest) (violations []domain.FieldViolation) {
 	if err := val.ValidateUsername(req.GetUsername()); err != nil {
 		violations = append(violations, fieldViolation("username", err))
 	}
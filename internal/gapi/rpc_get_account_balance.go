@@ -0,0 +1,51 @@
+package gapi
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/NhutHuyDev/sgbank/internal/domain"
+	"github.com/NhutHuyDev/sgbank/pb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (server *Server) GetAccountBalance(ctx context.Context, req *pb.GetAccountBalanceRequest) (*pb.GetAccountBalanceResponse, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.GetAccountId() < 1 {
+		return nil, invalidArgumentError([]domain.FieldViolation{
+			fieldViolation("account_id", fmt.Errorf("must be a valid account ID")),
+		})
+	}
+
+	account, err := server.Store.GetAccount(ctx, req.GetAccountId())
+	if err != nil {
+		return nil, err
+	}
+
+	if account.Owner != authPayload.Username {
+		return nil, fmt.Errorf("%w: account doesn't belong to the authenticated user", domain.ErrForbidden)
+	}
+
+	at := time.Now()
+	if value := timestampValue(req.GetAt()); value != nil {
+		at = *value
+	}
+
+	amount, err := server.Balances.At(ctx, account, at)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.GetAccountBalanceResponse{
+		AccountId:      account.ID,
+		Currency:       account.Currency,
+		At:             timestamppb.New(at),
+		Balance:        amount,
+		BalanceDecimal: server.Currencies.Format(amount, account.Currency),
+	}, nil
+}
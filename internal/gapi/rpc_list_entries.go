@@ -0,0 +1,80 @@
+package gapi
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/NhutHuyDev/sgbank/internal/domain"
+	"github.com/NhutHuyDev/sgbank/internal/pagination"
+	"github.com/NhutHuyDev/sgbank/pb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (server *Server) ListEntries(ctx context.Context, req *pb.ListEntriesRequest) (*pb.ListEntriesResponse, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.GetAccountId() < 1 {
+		return nil, invalidArgumentError([]domain.FieldViolation{
+			fieldViolation("account_id", fmt.Errorf("must be a valid account ID")),
+		})
+	}
+
+	filter := pagination.Filter{
+		From:                  timestampValue(req.GetFrom()),
+		To:                    timestampValue(req.GetTo()),
+		MinAmount:             req.MinAmount,
+		MaxAmount:             req.MaxAmount,
+		Direction:             req.GetDirection(),
+		CounterpartyAccountID: req.CounterpartyAccountId,
+		Query:                 req.GetQ(),
+		Category:              req.GetCategory(),
+	}
+	if err := filter.Validate(pagination.DirectionCredit, pagination.DirectionDebit); err != nil {
+		return nil, err
+	}
+
+	accountID := req.GetAccountId()
+	page, err := server.Paginator.Page(req.GetPageSize(), req.GetSort(), req.GetCursor(), filter.Scope("entries", authPayload.Username, &accountID))
+	if err != nil {
+		return nil, err
+	}
+
+	account, err := server.Store.GetAccount(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	if account.Owner != authPayload.Username {
+		return nil, fmt.Errorf("%w: account doesn't belong to the authenticated user", domain.ErrForbidden)
+	}
+
+	rows, err := server.Store.ListEntriesPage(ctx, pagination.EntryPageFilter(accountID, filter, page), page.Order)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, nextCursor := pagination.Trim(server.Paginator, page, rows, pagination.EntryKey)
+
+	rsp := &pb.ListEntriesResponse{
+		Entries:    make([]*pb.Entry, 0, len(rows)),
+		NextCursor: nextCursor,
+	}
+	for _, row := range rows {
+		rsp.Entries = append(rsp.Entries, convertEntry(server.Currencies, row, account.Currency))
+	}
+
+	return rsp, nil
+}
+
+func timestampValue(timestamp *timestamppb.Timestamp) *time.Time {
+	if timestamp == nil {
+		return nil
+	}
+
+	value := timestamp.AsTime()
+	return &value
+}
@@ -0,0 +1,70 @@
+package gapi
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/NhutHuyDev/sgbank/internal/domain"
+	"github.com/NhutHuyDev/sgbank/internal/pagination"
+	"github.com/NhutHuyDev/sgbank/pb"
+)
+
+func (server *Server) ListTransfers(ctx context.Context, req *pb.ListTransfersRequest) (*pb.ListTransfersResponse, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Currency != "" && !server.Currencies.IsSupported(req.GetCurrency()) {
+		return nil, invalidArgumentError([]domain.FieldViolation{
+			fieldViolation("currency", fmt.Errorf("unsupported currency")),
+		})
+	}
+
+	filter := pagination.Filter{
+		From:                  timestampValue(req.GetFrom()),
+		To:                    timestampValue(req.GetTo()),
+		MinAmount:             req.MinAmount,
+		MaxAmount:             req.MaxAmount,
+		Direction:             req.GetDirection(),
+		CounterpartyAccountID: req.CounterpartyAccountId,
+		Currency:              req.GetCurrency(),
+		Query:                 req.GetQ(),
+	}
+	if err := filter.Validate(pagination.DirectionIncoming, pagination.DirectionOutgoing); err != nil {
+		return nil, err
+	}
+
+	page, err := server.Paginator.Page(req.GetPageSize(), req.GetSort(), req.GetCursor(), filter.Scope("transfers", authPayload.Username, req.AccountId))
+	if err != nil {
+		return nil, err
+	}
+
+	if req.AccountId != nil {
+		account, err := server.Store.GetAccount(ctx, req.GetAccountId())
+		if err != nil {
+			return nil, err
+		}
+
+		if account.Owner != authPayload.Username {
+			return nil, fmt.Errorf("%w: account doesn't belong to the authenticated user", domain.ErrForbidden)
+		}
+	}
+
+	rows, err := server.Store.ListTransfersPage(ctx, pagination.TransferPageFilter(authPayload.Username, req.AccountId, filter, page), page.Order)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, nextCursor := pagination.Trim(server.Paginator, page, rows, pagination.TransferKey)
+
+	rsp := &pb.ListTransfersResponse{
+		Transfers:  make([]*pb.Transfer, 0, len(rows)),
+		NextCursor: nextCursor,
+	}
+	for _, row := range rows {
+		rsp.Transfers = append(rsp.Transfers, convertTransfer(server.Currencies, row))
+	}
+
+	return rsp, nil
+}
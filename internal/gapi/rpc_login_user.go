@@ -2,34 +2,29 @@ package gapi
 
 import (
 	"context"
-	"database/sql"
+	"fmt"
 
+	"github.com/NhutHuyDev/sgbank/internal/domain"
 	"github.com/NhutHuyDev/sgbank/internal/infra/db"
 	"github.com/NhutHuyDev/sgbank/pb"
 	"github.com/NhutHuyDev/sgbank/pkg/secure"
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 func (server *Server) LoginUser(ctx context.Context, req *pb.LoginUserRequest) (*pb.LoginUserResponse, error) {
 	user, err := server.Store.GetUser(ctx, req.Username)
 	if err != nil {
-		if err == sql.ErrNoRows {
-			return nil, status.Errorf(codes.NotFound, "user not found: %s", err)
-		}
-
-		return nil, status.Errorf(codes.Internal, "failed to get user: %s", err)
+		return nil, err
 	}
 
 	err = secure.CheckPassword(req.Password, user.HashedPassword)
 	if err != nil {
-		return nil, status.Errorf(codes.InvalidArgument, "incorrect password: %s", err)
+		return nil, domain.ErrInvalidCredential
 	}
 
 	refreshToken, refreshPayload, err := server.TokenMaker.CreateToken(user.Username, server.Config.AccessTokenDuration)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to create refresh token: %s", err)
+		return nil, fmt.Errorf("failed to create refresh token: %w", err)
 	}
 
 	mtdt := server.extractMetaData(ctx)
@@ -43,12 +38,12 @@ func (server *Server) LoginUser(ctx context.Context, req *pb.LoginUserRequest) (
 		CreatedAt:    refreshPayload.ExpiredAt,
 	})
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to create session: %s", err)
+		return nil, err
 	}
 
 	accessToken, accessPayload, err := server.TokenMaker.CreateToken(user.Username, server.Config.AccessTokenDuration)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to create access token: %s", err)
+		return nil, fmt.Errorf("failed to create access token: %w", err)
 	}
 
 	rsp := &pb.LoginUserResponse{
@@ -3,15 +3,14 @@ package gapi
 import (
 	"context"
 	"database/sql"
+	"fmt"
 	"time"
 
+	"github.com/NhutHuyDev/sgbank/internal/domain"
 	"github.com/NhutHuyDev/sgbank/internal/infra/db"
 	"github.com/NhutHuyDev/sgbank/pb"
 	"github.com/NhutHuyDev/sgbank/pkg/secure"
 	"github.com/NhutHuyDev/sgbank/pkg/val"
-	"google.golang.org/genproto/googleapis/rpc/errdetails"
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
 )
 
 func (server *Server) UpdateUser(ctx context.Context, req *pb.UpdateUserRequest) (*pb.UpdateUserResponse, error) {
@@ -36,7 +35,7 @@ func (server *Server) UpdateUser(ctx context.Context, req *pb.UpdateUserRequest)
 	if req.Password != nil {
 		hashedPassword, err := secure.HashPassword(req.GetPassword())
 		if err != nil {
-			return nil, status.Errorf(codes.Internal, "failed to hash password: %s", err)
+			return nil, fmt.Errorf("failed to hash password: %w", err)
 		}
 
 		arg.HashedPassword = sql.NullString{
@@ -52,11 +51,7 @@ func (server *Server) UpdateUser(ctx context.Context, req *pb.UpdateUserRequest)
 
 	user, err := server.Store.UpdateUser(ctx, arg)
 	if err != nil {
-		if err == sql.ErrNoRows {
-			return nil, status.Errorf(codes.NotFound, "user not found: %s", err)
-		}
-
-		return nil, status.Errorf(codes.Internal, "failed to update user: %s", err)
+		return nil, err
 	}
 
 	rsp := &pb.UpdateUserResponse{
@@ -66,7 +61,7 @@ func (server *Server) UpdateUser(ctx context.Context, req *pb.UpdateUserRequest)
 	return rsp, nil
 }
 
-func validateUpdateUserRequest(req *pb.UpdateUserRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+func validateUpdateUserRequest(req *pb.UpdateUserRequest) (violations []domain.FieldViolation) {
 	if err := val.ValidateUsername(req.GetUsername()); err != nil {
 		violations = append(violations, fieldViolation("username", err))
 	}
@@ -1,14 +1,25 @@
 package gapi
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"net"
+	"net/http"
 
+	"github.com/NhutHuyDev/sgbank/internal/balance"
+	"github.com/NhutHuyDev/sgbank/internal/currency"
 	"github.com/NhutHuyDev/sgbank/internal/infra/db"
+	"github.com/NhutHuyDev/sgbank/internal/metrics"
+	"github.com/NhutHuyDev/sgbank/internal/pagination"
+	"github.com/NhutHuyDev/sgbank/internal/screening"
+	"github.com/NhutHuyDev/sgbank/internal/services"
 	"github.com/NhutHuyDev/sgbank/internal/token"
 	"github.com/NhutHuyDev/sgbank/pb"
 	"github.com/NhutHuyDev/sgbank/pkg/utils"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/reflection"
 )
@@ -18,29 +29,78 @@ type Server struct {
 	Config     utils.Config
 	Store      db.Store
 	TokenMaker token.Maker
+	Paginator  *pagination.Paginator
+	Currencies *currency.Registry
+	Balances   *balance.Service
+	Screening  *screening.Service
 }
 
-func NewServer(config utils.Config, store db.Store) (*Server, error) {
+// NewServer serves the gRPC API with the services of set.
+func NewServer(config utils.Config, store db.Store, set *services.Set) (*Server, error) {
 	tokenMaker, err := token.NewPasetoMaker(config.TokenSymmetricKey)
 	if err != nil {
 		return nil, fmt.Errorf("cannot create token maker: %w", err)
 	}
 
+	paginator, err := pagination.FromConfig(config)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create paginator: %w", err)
+	}
+
 	server := &Server{
 		Config:     config,
 		Store:      store,
 		TokenMaker: tokenMaker,
+		Paginator:  paginator,
+		Currencies: set.Currencies.Registry(),
+		Balances:   set.Balances,
+		Screening:  set.Screening,
 	}
 	return server, nil
 }
 
-func (server *Server) Start(address string) error {
-	grpcLogger := grpc.UnaryInterceptor(GrpcLogger)
+// NewGrpcServer builds a gRPC server with the sgbank service and reflection registered.
+func (server *Server) NewGrpcServer(opts ...grpc.ServerOption) *grpc.Server {
+	interceptors := grpc.ChainUnaryInterceptor(GrpcLogger, metrics.UnaryServerInterceptor, ErrorInterceptor)
+	statsHandler := grpc.StatsHandler(otelgrpc.NewServerHandler())
+	opts = append([]grpc.ServerOption{statsHandler, interceptors}, opts...)
 
-	grpcServer := grpc.NewServer(grpcLogger)
+	grpcServer := grpc.NewServer(opts...)
 	pb.RegisterSgbankServer(grpcServer, server)
 	reflection.Register(grpcServer)
 
+	return grpcServer
+}
+
+// NewGatewayHandler builds the grpc-gateway REST mapping of the sgbank service,
+// calling the server in-process. Incoming W3C trace-context headers are
+// extracted so the gRPC handlers continue the caller's trace.
+func (server *Server) NewGatewayHandler(ctx context.Context) (http.Handler, error) {
+	grpcMux := runtime.NewServeMux(
+		runtime.WithMiddlewares(metrics.GatewayMiddleware()),
+		runtime.WithErrorHandler(gatewayErrorHandler),
+	)
+
+	err := pb.RegisterSgbankHandlerServer(ctx, grpcMux, server)
+	if err != nil {
+		return nil, fmt.Errorf("cannot register handler server: %w", err)
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/", grpcMux)
+
+	handler := otelhttp.NewHandler(HttpLogger(mux), "grpc-gateway",
+		otelhttp.WithSpanNameFormatter(func(operation string, req *http.Request) string {
+			return req.Method + " " + req.URL.Path
+		}),
+	)
+
+	return handler, nil
+}
+
+func (server *Server) Start(address string) error {
+	grpcServer := server.NewGrpcServer()
+
 	listener, err := net.Listen("tcp", address)
 	if err != nil {
 		log.Fatal("cannot create listener", err)
@@ -0,0 +1,93 @@
+package test
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+
+	"github.com/NhutHuyDev/sgbank/internal/domain"
+	"github.com/NhutHuyDev/sgbank/internal/gapi"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestErrorInterceptor(t *testing.T) {
+	info := &grpc.UnaryServerInfo{FullMethod: "/pb.Sgbank/CreateUser"}
+
+	testCases := []struct {
+		name    string
+		err     error
+		code    codes.Code
+		reason  string
+		message string
+	}{
+		{
+			name:   "InsufficientFunds",
+			err:    domain.ErrInsufficientFunds,
+			code:   codes.FailedPrecondition,
+			reason: domain.ErrInsufficientFunds.Code,
+		},
+		{
+			name:   "WrappedNotFound",
+			err:    fmt.Errorf("%w: account [1]", domain.ErrAccountNotFound),
+			code:   codes.NotFound,
+			reason: domain.ErrAccountNotFound.Code,
+		},
+		{
+			name:   "Duplicate",
+			err:    fmt.Errorf("%w: %w", domain.ErrDuplicate, sql.ErrNoRows),
+			code:   codes.AlreadyExists,
+			reason: domain.ErrDuplicate.Code,
+		},
+		{
+			name:    "Internal",
+			err:     sql.ErrConnDone,
+			code:    codes.Internal,
+			reason:  domain.ErrInternal.Code,
+			message: domain.ErrInternal.Message,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := gapi.ErrorInterceptor(context.Background(), nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+				return nil, tc.err
+			})
+
+			st, ok := status.FromError(err)
+			require.True(t, ok)
+			require.Equal(t, tc.code, st.Code())
+			if tc.message != "" {
+				require.Equal(t, tc.message, st.Message())
+			}
+
+			require.NotEmpty(t, st.Details())
+			errorInfo, ok := st.Details()[0].(*errdetails.ErrorInfo)
+			require.True(t, ok)
+			require.Equal(t, tc.reason, errorInfo.Reason)
+			require.Equal(t, "sgbank", errorInfo.Domain)
+		})
+	}
+}
+
+func TestErrorInterceptorValidation(t *testing.T) {
+	info := &grpc.UnaryServerInfo{FullMethod: "/pb.Sgbank/CreateUser"}
+
+	_, err := gapi.ErrorInterceptor(context.Background(), nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, domain.NewValidationError(domain.FieldViolation{Field: "username", Description: "too short"})
+	})
+
+	st, ok := status.FromError(err)
+	require.True(t, ok)
+	require.Equal(t, codes.InvalidArgument, st.Code())
+	require.Len(t, st.Details(), 2)
+
+	badRequest, ok := st.Details()[1].(*errdetails.BadRequest)
+	require.True(t, ok)
+	require.Len(t, badRequest.FieldViolations, 1)
+	require.Equal(t, "username", badRequest.FieldViolations[0].Field)
+}
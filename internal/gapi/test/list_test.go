@@ -0,0 +1,115 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/NhutHuyDev/sgbank/internal/domain"
+	"github.com/NhutHuyDev/sgbank/internal/gapi"
+	"github.com/NhutHuyDev/sgbank/internal/infra/db"
+	mockdb "github.com/NhutHuyDev/sgbank/internal/infra/db/mock"
+	"github.com/NhutHuyDev/sgbank/internal/services"
+	"github.com/NhutHuyDev/sgbank/pb"
+	"github.com/NhutHuyDev/sgbank/pkg/utils"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func newTestServer(t *testing.T, store db.Store) *gapi.Server {
+	config := utils.Config{
+		TokenSymmetricKey:   utils.RandomString(32),
+		AccessTokenDuration: time.Minute,
+	}
+
+	set, err := services.FromConfig(store, config)
+	require.NoError(t, err)
+
+	server, err := gapi.NewServer(config, store, set)
+	require.NoError(t, err)
+
+	return server
+}
+
+func newContextWithBearerToken(t *testing.T, server *gapi.Server, username string) context.Context {
+	accessToken, _, err := server.TokenMaker.CreateToken(username, time.Minute)
+	require.NoError(t, err)
+
+	md := metadata.MD{"authorization": []string{fmt.Sprintf("bearer %s", accessToken)}}
+	return metadata.NewIncomingContext(context.Background(), md)
+}
+
+func TestListEntriesRPC(t *testing.T) {
+	account := db.Account{ID: 7, Owner: utils.RandomOwner(), Currency: utils.USD}
+	transferID := int64(3)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	server := newTestServer(t, store)
+
+	store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+	store.EXPECT().
+		ListEntriesPage(gomock.Any(), gomock.Any(), gomock.Eq(db.SortAsc)).
+		Times(1).
+		Return([]db.EntryPageRow{
+			{Entry: db.Entry{ID: 1, AccountID: account.ID, Amount: 5, TransferID: &transferID}, CounterpartyAccountID: 8},
+			{Entry: db.Entry{ID: 2, AccountID: account.ID, Amount: 5}},
+		}, nil)
+
+	ctx := newContextWithBearerToken(t, server, account.Owner)
+	rsp, err := server.ListEntries(ctx, &pb.ListEntriesRequest{AccountId: account.ID, PageSize: 1, Sort: "asc"})
+	require.NoError(t, err)
+	require.Len(t, rsp.Entries, 1)
+	require.Equal(t, int64(8), rsp.Entries[0].CounterpartyAccountId)
+	require.Equal(t, transferID, rsp.Entries[0].GetTransferId())
+	require.NotEmpty(t, rsp.NextCursor)
+
+	_, err = server.ListEntries(ctx, &pb.ListEntriesRequest{AccountId: account.ID, Cursor: rsp.NextCursor})
+	require.ErrorIs(t, err, domain.ErrInvalidArgument)
+}
+
+func TestListTransfersRPCForbidden(t *testing.T) {
+	account := db.Account{ID: 7, Owner: "someone_else", Currency: utils.USD}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	server := newTestServer(t, store)
+
+	store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+	store.EXPECT().ListTransfersPage(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+	ctx := newContextWithBearerToken(t, server, utils.RandomOwner())
+	_, err := server.ListTransfers(ctx, &pb.ListTransfersRequest{AccountId: &account.ID})
+	require.ErrorIs(t, err, domain.ErrForbidden)
+}
+
+func TestGetAccountBalanceRPC(t *testing.T) {
+	account := db.Account{ID: 7, Owner: utils.RandomOwner(), Currency: utils.USD, CreatedAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	at := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	server := newTestServer(t, store)
+
+	store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+	store.EXPECT().
+		GetAccountBalanceBefore(gomock.Any(), gomock.Eq(db.GetAccountBalanceBeforeParams{AccountID: account.ID, Before: at})).
+		Times(1).
+		Return(int64(250), nil)
+
+	ctx := newContextWithBearerToken(t, server, account.Owner)
+	rsp, err := server.GetAccountBalance(ctx, &pb.GetAccountBalanceRequest{AccountId: account.ID, At: timestamppb.New(at)})
+	require.NoError(t, err)
+	require.Equal(t, int64(250), rsp.Balance)
+	require.Equal(t, "2.50", rsp.BalanceDecimal)
+	require.Equal(t, utils.USD, rsp.Currency)
+}
@@ -0,0 +1,262 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/NhutHuyDev/sgbank/internal/domain"
+)
+
+// Statuses of fraud decisions held for review.
+const (
+	FraudDecisionPending  = "pending"
+	FraudDecisionApproved = "approved"
+	FraudDecisionRejected = "rejected"
+)
+
+// Kinds of held transfers. The payload of a decision is the parameters of
+// the transaction that posts a transfer of its kind.
+const (
+	// HeldTransfer is a plain transfer; its payload is unused.
+	HeldTransfer = "transfer"
+	// HeldPaymentRequest pays a payment request with
+	// PayPaymentRequestTxParams.
+	HeldPaymentRequest = "payment_request"
+	// HeldTransferBatchItem posts a row of a best-effort transfer batch
+	// with PostTransferBatchItemTxParams.
+	HeldTransferBatchItem = "transfer_batch_item"
+	// HeldEscrow funds an escrow with CreateEscrowTxParams.
+	HeldEscrow = "escrow"
+)
+
+type ApproveHeldTransferTxParams struct {
+	ID         int64  `json:"id"`
+	ReviewedBy string `json:"reviewed_by"`
+	ReviewNote string `json:"review_note"`
+}
+
+type ApproveHeldTransferTxResult struct {
+	Decision FraudDecision `json:"decision"`
+	TransferTxResult
+	// PaymentRequest is the request the transfer paid, if any.
+	PaymentRequest *PaymentRequest `json:"payment_request,omitempty"`
+	// TransferBatchItem is the batch row the transfer posted, if any.
+	TransferBatchItem *TransferBatchItem `json:"transfer_batch_item,omitempty"`
+	// Escrow is the escrow the transfer funded, if any.
+	Escrow *Escrow `json:"escrow,omitempty"`
+}
+
+// ApproveHeldTransferTx posts a transfer the fraud rules held for review,
+// with whatever its kind settles along with it, and marks its decision
+// approved, in one transaction. The decision row is locked first, so two
+// reviewers approving at once post it only once; a transfer that fails,
+// e.g. for insufficient funds or a payment request that is no longer
+// pending, leaves it pending.
+func (store *StoreSQL) ApproveHeldTransferTx(ctx context.Context, arg ApproveHeldTransferTxParams) (ApproveHeldTransferTxResult, error) {
+	var result ApproveHeldTransferTxResult
+
+	retries, err := store.execTx(ctx, TxOptions{}, func(q *Queries) error {
+		result = ApproveHeldTransferTxResult{}
+
+		decision, err := lockPendingFraudDecision(ctx, q, arg.ID)
+		if err != nil {
+			return err
+		}
+
+		err = postHeldTransfer(ctx, q, decision, &result)
+		if err != nil {
+			return err
+		}
+
+		result.Decision, err = q.ReviewFraudDecision(ctx, ReviewFraudDecisionParams{
+			ID:         decision.ID,
+			Status:     FraudDecisionApproved,
+			TransferID: sql.NullInt64{Int64: result.Transfer.ID, Valid: true},
+			ReviewedBy: arg.ReviewedBy,
+			ReviewNote: arg.ReviewNote,
+		})
+		return err
+	})
+
+	result.Retries = retries
+	return result, translateError(ctx, err, domain.ErrAccountNotFound)
+}
+
+func lockPendingFraudDecision(ctx context.Context, q *Queries, id int64) (FraudDecision, error) {
+	decision, err := q.GetFraudDecisionForUpdate(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return decision, domain.ErrFraudDecisionNotFound
+	}
+	if err != nil {
+		return decision, err
+	}
+
+	if decision.Status != FraudDecisionPending {
+		return decision, fmt.Errorf("%w: fraud decision [%d] is %s", domain.ErrInvalidTransition, decision.ID, decision.Status)
+	}
+
+	return decision, nil
+}
+
+func postHeldTransfer(ctx context.Context, q *Queries, decision FraudDecision, result *ApproveHeldTransferTxResult) error {
+	switch decision.Kind {
+	case HeldTransfer:
+		var params TransferTxParams
+		if err := decodeHeldTransfer(decision, decision.Transfer, &params); err != nil {
+			return err
+		}
+
+		var err error
+		result.TransferTxResult, err = transfer(ctx, q, params)
+		return err
+
+	case HeldPaymentRequest:
+		var params PayPaymentRequestTxParams
+		if err := decodeHeldTransfer(decision, decision.Payload, &params); err != nil {
+			return err
+		}
+		params.Authorize = authorizeHeldPayment
+		if err := decodeHeldFee(decision, &params.Fee, &params.FeeAccountID); err != nil {
+			return err
+		}
+
+		paid, err := payPaymentRequest(ctx, q, params)
+		if err != nil {
+			return err
+		}
+		result.TransferTxResult, result.PaymentRequest = paid.TransferTxResult, &paid.PaymentRequest
+		return nil
+
+	case HeldTransferBatchItem:
+		var params PostTransferBatchItemTxParams
+		if err := decodeHeldTransfer(decision, decision.Payload, &params); err != nil {
+			return err
+		}
+		if err := decodeHeldFee(decision, &params.Fee, &params.FeeAccountID); err != nil {
+			return err
+		}
+
+		posted, err := postTransferBatchItem(ctx, q, params)
+		if err != nil {
+			return err
+		}
+		result.TransferTxResult, result.TransferBatchItem = posted.TransferTxResult, &posted.Item
+
+		_, err = q.UpdateTransferBatchProgress(ctx, posted.Item.BatchID)
+		return err
+
+	case HeldEscrow:
+		var params CreateEscrowTxParams
+		if err := decodeHeldTransfer(decision, decision.Payload, &params); err != nil {
+			return err
+		}
+		if err := decodeHeldFee(decision, &params.Fee, &params.FeeAccountID); err != nil {
+			return err
+		}
+
+		created, err := fundEscrow(ctx, q, params)
+		if err != nil {
+			return err
+		}
+		result.TransferTxResult, result.Escrow = created.Funding, &created.Escrow
+		return nil
+	}
+
+	return fmt.Errorf("fraud decision [%d] holds a transfer of unknown kind %q", decision.ID, decision.Kind)
+}
+
+func decodeHeldTransfer(decision FraudDecision, data json.RawMessage, v any) error {
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("cannot decode the %s of fraud decision [%d]: %w", decision.Kind, decision.ID, err)
+	}
+
+	return nil
+}
+
+// decodeHeldFee reads the fee of a held payment from the transfer the fraud
+// rules reviewed, since its payload was built before the fee was quoted.
+func decodeHeldFee(decision FraudDecision, fee *int64, feeAccountID *int64) error {
+	var params TransferTxParams
+	if err := decodeHeldTransfer(decision, decision.Transfer, &params); err != nil {
+		return err
+	}
+
+	*fee, *feeAccountID = params.Fee, params.FeeAccountID
+	return nil
+}
+
+// authorizeHeldPayment lets a held payment pay its request only while the
+// request is still pending.
+func authorizeHeldPayment(request PaymentRequest) error {
+	if request.Status != "pending" || !time.Now().Before(request.ExpiresAt) {
+		return fmt.Errorf("%w: payment request [%d] is no longer pending", domain.ErrInvalidTransition, request.ID)
+	}
+
+	return nil
+}
+
+type RejectHeldTransferTxParams struct {
+	ID         int64  `json:"id"`
+	ReviewedBy string `json:"reviewed_by"`
+	ReviewNote string `json:"review_note"`
+}
+
+type RejectHeldTransferTxResult struct {
+	Decision FraudDecision `json:"decision"`
+	// TransferBatchItem is the batch row that failed with the transfer, if
+	// any.
+	TransferBatchItem *TransferBatchItem `json:"transfer_batch_item,omitempty"`
+}
+
+// RejectHeldTransferTx marks a held transfer rejected, and fails the batch
+// row it would have posted, in one transaction.
+func (store *StoreSQL) RejectHeldTransferTx(ctx context.Context, arg RejectHeldTransferTxParams) (RejectHeldTransferTxResult, error) {
+	var result RejectHeldTransferTxResult
+
+	_, err := store.execTx(ctx, TxOptions{}, func(q *Queries) error {
+		result = RejectHeldTransferTxResult{}
+
+		decision, err := lockPendingFraudDecision(ctx, q, arg.ID)
+		if err != nil {
+			return err
+		}
+
+		if decision.Kind == HeldTransferBatchItem {
+			var params PostTransferBatchItemTxParams
+			if err := decodeHeldTransfer(decision, decision.Payload, &params); err != nil {
+				return err
+			}
+
+			item, err := q.UpdateTransferBatchItem(ctx, UpdateTransferBatchItemParams{
+				ID:     params.Item.ID,
+				Status: TransferBatchItemFailed,
+				Error:  fmt.Sprintf("rejected by fraud review [%d]", decision.ID),
+			})
+			if errors.Is(err, sql.ErrNoRows) {
+				return fmt.Errorf("%w: transfer batch row [%d] is no longer held", domain.ErrInvalidTransition, params.Item.ID)
+			}
+			if err != nil {
+				return err
+			}
+			result.TransferBatchItem = &item
+
+			if _, err := q.UpdateTransferBatchProgress(ctx, item.BatchID); err != nil {
+				return err
+			}
+		}
+
+		result.Decision, err = q.ReviewFraudDecision(ctx, ReviewFraudDecisionParams{
+			ID:         decision.ID,
+			Status:     FraudDecisionRejected,
+			ReviewedBy: arg.ReviewedBy,
+			ReviewNote: arg.ReviewNote,
+		})
+		return err
+	})
+
+	return result, translateError(ctx, err, domain.ErrFraudDecisionNotFound)
+}
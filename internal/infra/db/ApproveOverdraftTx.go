@@ -0,0 +1,53 @@
+package db
+
+import (
+	"context"
+
+	"github.com/NhutHuyDev/sgbank/internal/domain"
+)
+
+type ApproveOverdraftTxParams struct {
+	AccountID      int64  `json:"account_id"`
+	OverdraftLimit int64  `json:"overdraft_limit"`
+	ApprovedBy     string `json:"approved_by"`
+	Reason         string `json:"reason"`
+}
+
+type ApproveOverdraftTxResult struct {
+	Account  Account           `json:"account"`
+	Approval OverdraftApproval `json:"approval"`
+}
+
+// ApproveOverdraftTx sets the overdraft limit of an account and records who
+// approved it. The account row is locked first, like in TransferTx, so a
+// limit never changes halfway through a transfer.
+func (store *StoreSQL) ApproveOverdraftTx(ctx context.Context, arg ApproveOverdraftTxParams) (ApproveOverdraftTxResult, error) {
+	var result ApproveOverdraftTxResult
+
+	_, err := store.execTx(ctx, TxOptions{}, func(q *Queries) error {
+		var err error
+		result = ApproveOverdraftTxResult{}
+
+		if _, err = q.GetAccountForUpdate(ctx, arg.AccountID); err != nil {
+			return err
+		}
+
+		result.Account, err = q.SetAccountOverdraftLimit(ctx, SetAccountOverdraftLimitParams{
+			ID:             arg.AccountID,
+			OverdraftLimit: arg.OverdraftLimit,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.Approval, err = q.CreateOverdraftApproval(ctx, CreateOverdraftApprovalParams{
+			AccountID:      arg.AccountID,
+			OverdraftLimit: arg.OverdraftLimit,
+			ApprovedBy:     arg.ApprovedBy,
+			Reason:         arg.Reason,
+		})
+		return err
+	})
+
+	return result, translateError(ctx, err, domain.ErrAccountNotFound)
+}
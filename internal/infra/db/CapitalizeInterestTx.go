@@ -0,0 +1,113 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/NhutHuyDev/sgbank/internal/domain"
+)
+
+// InterestScale is the number of accrual units, interest_accruals.amount_micros,
+// in one minor unit of a currency.
+const InterestScale = 1_000_000
+
+type CapitalizeInterestTxParams struct {
+	AccountID int64 `json:"account_id"`
+	// ExpenseAccountID is the bank's interest expense account in the
+	// currency of the account; it pays the interest.
+	ExpenseAccountID int64 `json:"expense_account_id"`
+	// IncomeAccountID is the bank's interest income account in the currency
+	// of the account; it receives the interest charged on overdrafts.
+	IncomeAccountID int64 `json:"income_account_id"`
+	// Period is the first day of the month being capitalized.
+	Period      time.Time `json:"period"`
+	Description string    `json:"description"`
+}
+
+type CapitalizeInterestTxResult struct {
+	Capitalization InterestCapitalization `json:"capitalization"`
+	// Transfer is nil when the interest rounded down to nothing, or when the
+	// period had already been capitalized.
+	Transfer *TransferTxResult `json:"transfer"`
+}
+
+// CapitalizeInterestTx posts the interest an account accrued before the end
+// of Period, plus the fraction of a minor unit carried from its previous
+// capitalization, as a transfer from the expense account, or, when
+// overdraft interest outweighs it, as a transfer to the income account.
+// The remaining fraction is carried forward. Capitalizing a period twice returns the
+// first capitalization and posts nothing.
+func (store *StoreSQL) CapitalizeInterestTx(ctx context.Context, arg CapitalizeInterestTxParams) (CapitalizeInterestTxResult, error) {
+	var result CapitalizeInterestTxResult
+
+	_, err := store.execTx(ctx, TxOptions{}, func(q *Queries) error {
+		result = CapitalizeInterestTxResult{}
+
+		existing, err := q.GetInterestCapitalization(ctx, GetInterestCapitalizationParams{AccountID: arg.AccountID, Period: arg.Period})
+		if err == nil {
+			result.Capitalization = existing
+			return nil
+		}
+		if !errors.Is(err, sql.ErrNoRows) {
+			return err
+		}
+
+		end := arg.Period.AddDate(0, 1, 0)
+		accrued, err := q.SumUncapitalizedInterest(ctx, SumUncapitalizedInterestParams{AccountID: arg.AccountID, Before: end})
+		if err != nil {
+			return err
+		}
+
+		previous, err := q.GetLastInterestCapitalization(ctx, arg.AccountID)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return err
+		}
+		accrued += previous.CarryMicros
+
+		params := CreateInterestCapitalizationParams{
+			AccountID:     arg.AccountID,
+			Period:        arg.Period,
+			AccruedMicros: accrued,
+			Amount:        accrued / InterestScale,
+			CarryMicros:   accrued % InterestScale,
+		}
+
+		if params.Amount != 0 {
+			posting := TransferTxParams{
+				FromAccountID: arg.ExpenseAccountID,
+				ToAccountID:   arg.AccountID,
+				Amount:        params.Amount,
+				Description:   arg.Description,
+				internal:      true,
+			}
+			// overdraft interest is charged even beyond the overdraft limit
+			if params.Amount < 0 {
+				posting.FromAccountID, posting.ToAccountID = arg.AccountID, arg.IncomeAccountID
+				posting.Amount = -params.Amount
+				posting.internal, posting.forced = false, true
+			}
+
+			posted, err := transfer(ctx, q, posting)
+			if err != nil {
+				return err
+			}
+			result.Transfer = &posted
+			params.TransferID = sql.NullInt64{Int64: posted.Transfer.ID, Valid: true}
+		}
+
+		result.Capitalization, err = q.CreateInterestCapitalization(ctx, params)
+		if err != nil {
+			return err
+		}
+
+		return q.MarkInterestCapitalized(ctx, MarkInterestCapitalizedParams{
+			CapitalizationID: sql.NullInt64{Int64: result.Capitalization.ID, Valid: true},
+			AccountID:        arg.AccountID,
+			Before:           end,
+		})
+	})
+
+	return result, translateError(ctx, err, domain.ErrAccountNotFound)
+}
@@ -0,0 +1,75 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/NhutHuyDev/sgbank/internal/domain"
+)
+
+type CloseBusinessDayTxParams struct {
+	BusinessDate time.Time `json:"business_date"`
+	// Steps are the names of the end-of-day steps to run for the day, in
+	// order.
+	Steps []string `json:"steps"`
+}
+
+type CloseBusinessDayTxResult struct {
+	Closing BusinessDay `json:"closing"`
+	Next    BusinessDay `json:"next"`
+	Steps   []EodStep   `json:"steps"`
+}
+
+// CloseBusinessDayTx starts closing the open business day and opens the
+// next one, so later postings go to the next day. It waits for the
+// transfers posting to the day to commit, and fails with ErrEODInProgress
+// while an earlier day is still closing.
+func (store *StoreSQL) CloseBusinessDayTx(ctx context.Context, arg CloseBusinessDayTxParams) (CloseBusinessDayTxResult, error) {
+	var result CloseBusinessDayTxResult
+
+	_, err := store.execTx(ctx, TxOptions{}, func(q *Queries) error {
+		var err error
+		result = CloseBusinessDayTxResult{}
+
+		closing, err := q.GetClosingBusinessDay(ctx)
+		switch {
+		case err == nil:
+			return fmt.Errorf("%w: business date %s is closing", domain.ErrEODInProgress, closing.BusinessDate.Format(time.DateOnly))
+		case !errors.Is(err, sql.ErrNoRows):
+			return err
+		}
+
+		result.Closing, err = q.StartClosingBusinessDay(ctx, arg.BusinessDate)
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("%w: business date %s is not open", domain.ErrInvalidTransition, arg.BusinessDate.Format(time.DateOnly))
+		}
+		if err != nil {
+			return err
+		}
+
+		result.Next, err = q.CreateBusinessDay(ctx, result.Closing.BusinessDate.AddDate(0, 0, 1))
+		if err != nil {
+			return err
+		}
+
+		result.Steps = make([]EodStep, 0, len(arg.Steps))
+		for i, name := range arg.Steps {
+			step, err := q.CreateEODStep(ctx, CreateEODStepParams{
+				BusinessDate: result.Closing.BusinessDate,
+				Name:         name,
+				Position:     int32(i + 1),
+			})
+			if err != nil {
+				return err
+			}
+			result.Steps = append(result.Steps, step)
+		}
+
+		return nil
+	})
+
+	return result, translateError(ctx, err, domain.ErrNotFound)
+}
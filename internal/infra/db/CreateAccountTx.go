@@ -0,0 +1,57 @@
+package db
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/NhutHuyDev/sgbank/internal/domain"
+)
+
+// CreateAccountTx opens an account for arg.Owner if their KYC level allows
+// another one. The owner's user row is locked while their accounts are
+// counted, so requests made at the same time cannot together open more
+// accounts than the level allows.
+func (store *StoreSQL) CreateAccountTx(ctx context.Context, arg CreateAccountParams) (Account, error) {
+	var account Account
+
+	_, err := store.execTx(ctx, TxOptions{}, func(q *Queries) error {
+		var err error
+
+		if _, err = q.GetUserForUpdate(ctx, arg.Owner); err != nil {
+			return err
+		}
+
+		if err = CheckAccountLimit(ctx, q, arg.Owner); err != nil {
+			return err
+		}
+
+		account, err = q.CreateAccount(ctx, arg)
+		return err
+	})
+
+	return account, translateError(ctx, err, domain.ErrUserNotFound)
+}
+
+// CheckAccountLimit fails with ErrKYCLimitExceeded when username already has
+// as many accounts as their KYC level allows.
+func CheckAccountLimit(ctx context.Context, q Querier, username string) error {
+	level, err := q.GetUserKycLimits(ctx, username)
+	if err != nil {
+		return err
+	}
+
+	if !level.MaxAccounts.Valid {
+		return nil
+	}
+
+	accounts, err := q.CountAccountsByOwner(ctx, username)
+	if err != nil {
+		return err
+	}
+
+	if accounts >= int64(level.MaxAccounts.Int32) {
+		return fmt.Errorf("%w: level %d (%s) allows %d accounts", domain.ErrKYCLimitExceeded, level.Level, level.Name, level.MaxAccounts.Int32)
+	}
+
+	return nil
+}
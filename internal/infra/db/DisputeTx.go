@@ -0,0 +1,232 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/NhutHuyDev/sgbank/internal/domain"
+)
+
+// Statuses of disputes. Won, lost and withdrawn are final.
+const (
+	DisputeOpen        = "open"
+	DisputeUnderReview = "under_review"
+	DisputeWon         = "won"
+	DisputeLost        = "lost"
+	DisputeWithdrawn   = "withdrawn"
+)
+
+type OpenDisputeTxParams struct {
+	CreateDisputeParams
+	// Evidence is stored with the dispute; its DisputeID is filled in by the
+	// transaction.
+	Evidence []CreateDisputeEvidenceParams `json:"evidence"`
+	// CreditAccountID is the bank's provisional dispute credits account in
+	// the currency of the dispute. When set, the amount is credited to the
+	// account the transfer was sent from at once.
+	CreditAccountID int64 `json:"credit_account_id"`
+}
+
+type OpenDisputeTxResult struct {
+	Dispute  Dispute           `json:"dispute"`
+	Evidence []DisputeEvidence `json:"evidence"`
+	// ProvisionalCredit is nil when no provisional credit was given.
+	ProvisionalCredit *TransferTxResult `json:"provisional_credit,omitempty"`
+}
+
+// OpenDisputeTx opens a dispute on a transfer with its evidence and, when
+// asked, posts the provisional credit. A transfer with a dispute that was
+// not withdrawn gets ErrDuplicate.
+func (store *StoreSQL) OpenDisputeTx(ctx context.Context, arg OpenDisputeTxParams) (OpenDisputeTxResult, error) {
+	var result OpenDisputeTxResult
+
+	_, err := store.execTx(ctx, TxOptions{}, func(q *Queries) error {
+		result = OpenDisputeTxResult{Evidence: make([]DisputeEvidence, 0, len(arg.Evidence))}
+
+		dispute, err := q.CreateDispute(ctx, arg.CreateDisputeParams)
+		if err != nil {
+			return err
+		}
+
+		for _, evidenceArg := range arg.Evidence {
+			evidenceArg.DisputeID = dispute.ID
+			evidence, err := q.CreateDisputeEvidence(ctx, evidenceArg)
+			if err != nil {
+				return err
+			}
+			result.Evidence = append(result.Evidence, evidence)
+		}
+
+		_, err = q.CreateDisputeEvent(ctx, CreateDisputeEventParams{
+			DisputeID: dispute.ID,
+			Actor:     dispute.Username,
+			ToStatus:  dispute.Status,
+			Note:      dispute.Description,
+		})
+		if err != nil {
+			return err
+		}
+
+		if arg.CreditAccountID != 0 {
+			disputed, err := q.GetTransfer(ctx, dispute.TransferID)
+			if err != nil {
+				return err
+			}
+
+			credit, err := transfer(ctx, q, TransferTxParams{
+				FromAccountID: arg.CreditAccountID,
+				ToAccountID:   disputed.FromAccountID,
+				Amount:        dispute.Amount,
+				Description:   fmt.Sprintf("Provisional credit for dispute %d", dispute.ID),
+				internal:      true,
+			})
+			if err != nil {
+				return err
+			}
+			result.ProvisionalCredit = &credit
+
+			dispute, err = q.UpdateDispute(ctx, UpdateDisputeParams{
+				ID:                          dispute.ID,
+				Status:                      dispute.Status,
+				ProvisionalCreditTransferID: sql.NullInt64{Int64: credit.Transfer.ID, Valid: true},
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		result.Dispute = dispute
+		return nil
+	})
+
+	return result, translateError(ctx, err, domain.ErrTransferNotFound)
+}
+
+type TransitionDisputeTxParams struct {
+	ID     int64  `json:"id"`
+	Status string `json:"status"`
+	// Actor is the user moving the dispute: the customer withdrawing it or
+	// the support agent reviewing or deciding it.
+	Actor string `json:"actor"`
+	// Note is recorded on the event, and is the resolution of a final status.
+	Note string `json:"note"`
+	// CreditAccountID is the bank's provisional dispute credits account in
+	// the currency of the dispute. It is required when the dispute is won or
+	// loses a provisional credit.
+	CreditAccountID int64 `json:"credit_account_id"`
+	// Authorize runs while the dispute row is locked and rejects transitions
+	// the actor may not make, e.g. because the dispute is already decided.
+	Authorize func(dispute Dispute) error `json:"-"`
+}
+
+type TransitionDisputeTxResult struct {
+	Dispute Dispute      `json:"dispute"`
+	Event   DisputeEvent `json:"event"`
+	// Transfer is the reversal of a won dispute, or the recovery of the
+	// provisional credit of a lost or withdrawn one; nil when no money moved.
+	Transfer *TransferTxResult `json:"transfer,omitempty"`
+}
+
+// TransitionDisputeTx moves a dispute to another status and posts what
+// that implies in the same transaction. Taking it under review assigns it
+// to the actor. A won dispute reverses the disputed amount from the payee:
+// to the customer, or to the credits account when the customer already has
+// a provisional credit. A lost or withdrawn dispute takes back a provisional
+// credit. The reversals may overdraw the account they are taken from.
+func (store *StoreSQL) TransitionDisputeTx(ctx context.Context, arg TransitionDisputeTxParams) (TransitionDisputeTxResult, error) {
+	var result TransitionDisputeTxResult
+
+	_, err := store.execTx(ctx, TxOptions{}, func(q *Queries) error {
+		result = TransitionDisputeTxResult{}
+
+		dispute, err := q.GetDisputeForUpdate(ctx, arg.ID)
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.ErrDisputeNotFound
+		}
+		if err != nil {
+			return err
+		}
+
+		if arg.Authorize != nil {
+			if err := arg.Authorize(dispute); err != nil {
+				return err
+			}
+		}
+
+		update := UpdateDisputeParams{ID: dispute.ID, Status: arg.Status}
+		switch arg.Status {
+		case DisputeUnderReview:
+			update.AssignedTo = sql.NullString{String: arg.Actor, Valid: true}
+		case DisputeWon, DisputeLost, DisputeWithdrawn:
+			update.Resolution = sql.NullString{String: arg.Note, Valid: true}
+			update.ResolvedBy = sql.NullString{String: arg.Actor, Valid: true}
+			update.ResolvedAt = sql.NullTime{Time: time.Now(), Valid: true}
+
+			result.Transfer, err = settleDispute(ctx, q, dispute, arg)
+			if err != nil {
+				return err
+			}
+			if result.Transfer != nil {
+				update.ResolutionTransferID = sql.NullInt64{Int64: result.Transfer.Transfer.ID, Valid: true}
+			}
+		}
+
+		result.Dispute, err = q.UpdateDispute(ctx, update)
+		if err != nil {
+			return err
+		}
+
+		result.Event, err = q.CreateDisputeEvent(ctx, CreateDisputeEventParams{
+			DisputeID:  dispute.ID,
+			Actor:      arg.Actor,
+			FromStatus: dispute.Status,
+			ToStatus:   arg.Status,
+			Note:       arg.Note,
+		})
+		return err
+	})
+
+	return result, translateError(ctx, err, domain.ErrAccountNotFound)
+}
+
+// settleDispute posts the money movement of a decided dispute, if any.
+func settleDispute(ctx context.Context, q *Queries, dispute Dispute, arg TransitionDisputeTxParams) (*TransferTxResult, error) {
+	credited := dispute.ProvisionalCreditTransferID.Valid
+	if arg.Status != DisputeWon && !credited {
+		return nil, nil
+	}
+
+	if arg.CreditAccountID == 0 {
+		return nil, fmt.Errorf("%w: no provisional dispute credits account", domain.ErrInvalidArgument)
+	}
+
+	disputed, err := q.GetTransfer(ctx, dispute.TransferID)
+	if err != nil {
+		return nil, err
+	}
+
+	// every leg debits a customer account, which is charged back even when
+	// its balance does not cover the amount
+	posting := TransferTxParams{Amount: dispute.Amount, forced: true}
+	switch {
+	case arg.Status == DisputeWon && credited:
+		posting.FromAccountID, posting.ToAccountID = disputed.ToAccountID, arg.CreditAccountID
+		posting.Description = fmt.Sprintf("Reversal for dispute %d", dispute.ID)
+	case arg.Status == DisputeWon:
+		posting.FromAccountID, posting.ToAccountID = disputed.ToAccountID, disputed.FromAccountID
+		posting.Description = fmt.Sprintf("Reversal for dispute %d", dispute.ID)
+	default:
+		posting.FromAccountID, posting.ToAccountID = disputed.FromAccountID, arg.CreditAccountID
+		posting.Description = fmt.Sprintf("Provisional credit for dispute %d reversed", dispute.ID)
+	}
+
+	result, err := transfer(ctx, q, posting)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
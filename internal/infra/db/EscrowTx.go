@@ -0,0 +1,176 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/NhutHuyDev/sgbank/internal/domain"
+)
+
+// Statuses of escrows. Released and refunded are final.
+const (
+	EscrowHeld     = "held"
+	EscrowDisputed = "disputed"
+	EscrowReleased = "released"
+	EscrowRefunded = "refunded"
+)
+
+type CreateEscrowTxParams struct {
+	Payer           string    `json:"payer"`
+	Payee           string    `json:"payee"`
+	FromAccountID   int64     `json:"from_account_id"`
+	ToAccountID     int64     `json:"to_account_id"`
+	EscrowAccountID int64     `json:"escrow_account_id"`
+	Amount          int64     `json:"amount"`
+	Currency        string    `json:"currency"`
+	Description     string    `json:"description"`
+	ReleaseAt       time.Time `json:"release_at"`
+	// Fee is charged to the payer on top of the amount and credited to
+	// FeeAccountID, as in TransferTxParams.
+	Fee          int64 `json:"fee,omitempty"`
+	FeeAccountID int64 `json:"fee_account_id,omitempty"`
+}
+
+type CreateEscrowTxResult struct {
+	Escrow Escrow `json:"escrow"`
+	// Funding moves the amount from the payer into the escrow account.
+	Funding TransferTxResult `json:"funding"`
+}
+
+// CreateEscrowTx moves the amount from the payer's account into the bank's
+// escrow account, with its fee, and records the escrow in one transaction.
+// The payer's balance and KYC limits are checked as for any transfer.
+func (store *StoreSQL) CreateEscrowTx(ctx context.Context, arg CreateEscrowTxParams) (CreateEscrowTxResult, error) {
+	var result CreateEscrowTxResult
+
+	retries, err := store.execTx(ctx, TxOptions{}, func(q *Queries) error {
+		var err error
+		result, err = fundEscrow(ctx, q, arg)
+		return err
+	})
+
+	result.Funding.Retries = retries
+	return result, translateError(ctx, err, domain.ErrAccountNotFound)
+}
+
+func fundEscrow(ctx context.Context, q *Queries, arg CreateEscrowTxParams) (CreateEscrowTxResult, error) {
+	var result CreateEscrowTxResult
+
+	var err error
+	result.Funding, err = transfer(ctx, q, TransferTxParams{
+		FromAccountID: arg.FromAccountID,
+		ToAccountID:   arg.EscrowAccountID,
+		Amount:        arg.Amount,
+		Description:   arg.Description,
+		Fee:           arg.Fee,
+		FeeAccountID:  arg.FeeAccountID,
+	})
+	if err != nil {
+		return result, err
+	}
+
+	result.Escrow, err = q.CreateEscrow(ctx, CreateEscrowParams{
+		Payer:             arg.Payer,
+		Payee:             arg.Payee,
+		FromAccountID:     arg.FromAccountID,
+		ToAccountID:       arg.ToAccountID,
+		EscrowAccountID:   arg.EscrowAccountID,
+		Amount:            arg.Amount,
+		Currency:          arg.Currency,
+		Description:       arg.Description,
+		ReleaseAt:         arg.ReleaseAt,
+		FundingTransferID: result.Funding.Transfer.ID,
+	})
+	return result, err
+}
+
+type SettleEscrowTxParams struct {
+	ID int64 `json:"id"`
+	// Status is released, refunded or disputed.
+	Status string `json:"status"`
+	// Actor is the user moving the escrow; empty when a held escrow is
+	// released on timeout.
+	Actor string `json:"actor"`
+	// Note is the dispute reason when disputed, and the note of a release or
+	// refund.
+	Note string `json:"note"`
+	// Authorize runs while the escrow row is locked and rejects moves the
+	// actor may not make, e.g. because the escrow is already settled.
+	Authorize func(escrow Escrow) error `json:"-"`
+}
+
+type SettleEscrowTxResult struct {
+	Escrow Escrow `json:"escrow"`
+	// Transfer moves the amount out of the escrow account; nil when the
+	// escrow is disputed.
+	Transfer *TransferTxResult `json:"transfer,omitempty"`
+}
+
+// SettleEscrowTx moves an escrow to another status and posts what that
+// implies in the same transaction: a release pays the amount from the
+// escrow account to the payee, a refund back to the payer, and a dispute
+// only stops the release on timeout. The escrow row is locked before the
+// accounts, which are locked in the order of TransferTx.
+func (store *StoreSQL) SettleEscrowTx(ctx context.Context, arg SettleEscrowTxParams) (SettleEscrowTxResult, error) {
+	var result SettleEscrowTxResult
+
+	_, err := store.execTx(ctx, TxOptions{}, func(q *Queries) error {
+		result = SettleEscrowTxResult{}
+
+		escrow, err := q.GetEscrowForUpdate(ctx, arg.ID)
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.ErrEscrowNotFound
+		}
+		if err != nil {
+			return err
+		}
+
+		if arg.Authorize != nil {
+			if err := arg.Authorize(escrow); err != nil {
+				return err
+			}
+		}
+
+		now := sql.NullTime{Time: time.Now(), Valid: true}
+		update := UpdateEscrowParams{ID: escrow.ID, Status: arg.Status}
+
+		switch arg.Status {
+		case EscrowDisputed:
+			update.DisputeReason = sql.NullString{String: arg.Note, Valid: true}
+			update.DisputedAt = now
+		case EscrowReleased, EscrowRefunded:
+			posting := TransferTxParams{
+				FromAccountID: escrow.EscrowAccountID,
+				ToAccountID:   escrow.ToAccountID,
+				Amount:        escrow.Amount,
+				Description:   fmt.Sprintf("Release of escrow %d", escrow.ID),
+				internal:      true,
+			}
+			if arg.Status == EscrowRefunded {
+				posting.ToAccountID = escrow.FromAccountID
+				posting.Description = fmt.Sprintf("Refund of escrow %d", escrow.ID)
+			}
+
+			settlement, err := transfer(ctx, q, posting)
+			if err != nil {
+				return err
+			}
+			result.Transfer = &settlement
+
+			update.SettlementTransferID = sql.NullInt64{Int64: settlement.Transfer.ID, Valid: true}
+			update.Note = sql.NullString{String: arg.Note, Valid: true}
+			update.SettledBy = sql.NullString{String: arg.Actor, Valid: arg.Actor != ""}
+			update.SettledAt = now
+		default:
+			return fmt.Errorf("%w: unknown escrow status %q", domain.ErrInvalidArgument, arg.Status)
+		}
+
+		result.Escrow, err = q.UpdateEscrow(ctx, update)
+		return err
+	})
+
+	return result, translateError(ctx, err, domain.ErrAccountNotFound)
+}
@@ -0,0 +1,117 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/NhutHuyDev/sgbank/internal/domain"
+)
+
+// Statuses of KYC submissions.
+const (
+	KycSubmissionPending  = "pending"
+	KycSubmissionApproved = "approved"
+	KycSubmissionRejected = "rejected"
+)
+
+type CreateKycSubmissionTxParams struct {
+	CreateKycSubmissionParams
+	// Documents are the stored files; their SubmissionID is filled in by the
+	// transaction.
+	Documents []CreateKycDocumentParams `json:"documents"`
+}
+
+type CreateKycSubmissionTxResult struct {
+	Submission KycSubmission `json:"submission"`
+	Documents  []KycDocument `json:"documents"`
+}
+
+// CreateKycSubmissionTx stores a submission with all of its documents, so a
+// reviewer never sees a submission with only part of them. A user with a
+// submission pending already gets ErrDuplicate.
+func (store *StoreSQL) CreateKycSubmissionTx(ctx context.Context, arg CreateKycSubmissionTxParams) (CreateKycSubmissionTxResult, error) {
+	var result CreateKycSubmissionTxResult
+
+	_, err := store.execTx(ctx, TxOptions{}, func(q *Queries) error {
+		var err error
+		result = CreateKycSubmissionTxResult{Documents: make([]KycDocument, 0, len(arg.Documents))}
+
+		result.Submission, err = q.CreateKycSubmission(ctx, arg.CreateKycSubmissionParams)
+		if err != nil {
+			return err
+		}
+
+		for _, documentArg := range arg.Documents {
+			documentArg.SubmissionID = result.Submission.ID
+			document, err := q.CreateKycDocument(ctx, documentArg)
+			if err != nil {
+				return err
+			}
+			result.Documents = append(result.Documents, document)
+		}
+
+		return nil
+	})
+
+	return result, translateError(ctx, err, domain.ErrNotFound)
+}
+
+type ReviewKycSubmissionTxParams struct {
+	ID         int64  `json:"id"`
+	Status     string `json:"status"`
+	Reason     string `json:"reason"`
+	ReviewedBy string `json:"reviewed_by"`
+}
+
+type ReviewKycSubmissionTxResult struct {
+	Submission KycSubmission `json:"submission"`
+	User       User          `json:"user"`
+}
+
+// ReviewKycSubmissionTx approves or rejects a pending submission. Approving
+// raises the level of the user to the level requested in the same
+// transaction. The submission row is locked first, so two reviewers cannot
+// both decide it.
+func (store *StoreSQL) ReviewKycSubmissionTx(ctx context.Context, arg ReviewKycSubmissionTxParams) (ReviewKycSubmissionTxResult, error) {
+	var result ReviewKycSubmissionTxResult
+
+	_, err := store.execTx(ctx, TxOptions{}, func(q *Queries) error {
+		result = ReviewKycSubmissionTxResult{}
+
+		submission, err := q.GetKycSubmissionForUpdate(ctx, arg.ID)
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.ErrKYCSubmissionNotFound
+		}
+		if err != nil {
+			return err
+		}
+
+		if submission.Status != KycSubmissionPending {
+			return fmt.Errorf("%w: verification submission [%d] is %s", domain.ErrInvalidTransition, submission.ID, submission.Status)
+		}
+
+		result.Submission, err = q.ReviewKycSubmission(ctx, ReviewKycSubmissionParams{
+			ID:         submission.ID,
+			Status:     arg.Status,
+			Reason:     arg.Reason,
+			ReviewedBy: arg.ReviewedBy,
+		})
+		if err != nil {
+			return err
+		}
+
+		if arg.Status == KycSubmissionApproved {
+			result.User, err = q.RaiseUserKycLevel(ctx, RaiseUserKycLevelParams{
+				Username: submission.Username,
+				Level:    submission.Level,
+			})
+		} else {
+			result.User, err = q.GetUser(ctx, submission.Username)
+		}
+		return err
+	})
+
+	return result, translateError(ctx, err, domain.ErrUserNotFound)
+}
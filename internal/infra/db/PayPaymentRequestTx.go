@@ -0,0 +1,84 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/NhutHuyDev/sgbank/internal/domain"
+)
+
+type PayPaymentRequestTxParams struct {
+	ID            int64  `json:"id"`
+	Payer         string `json:"payer"`
+	FromAccountID int64  `json:"from_account_id"`
+	// Fee is charged to the payer on top of the requested amount and
+	// credited to FeeAccountID, as in TransferTxParams.
+	Fee          int64 `json:"fee,omitempty"`
+	FeeAccountID int64 `json:"fee_account_id,omitempty"`
+	// Status is the status the request moves to once the transfer is posted.
+	Status string `json:"status"`
+	// Authorize runs while the request row is locked and rejects payments
+	// the payer may not make, e.g. because the request is no longer pending.
+	Authorize func(request PaymentRequest) error `json:"-"`
+}
+
+type PayPaymentRequestTxResult struct {
+	PaymentRequest PaymentRequest `json:"payment_request"`
+	TransferTxResult
+}
+
+// PayPaymentRequestTx pays a payment request: it locks the request, posts
+// the transfer from the payer's account to the requester's account and
+// records the payer and transfer on the request, all in one transaction.
+func (store *StoreSQL) PayPaymentRequestTx(ctx context.Context, arg PayPaymentRequestTxParams) (PayPaymentRequestTxResult, error) {
+	var result PayPaymentRequestTxResult
+
+	retries, err := store.execTx(ctx, TxOptions{}, func(q *Queries) error {
+		var err error
+		result, err = payPaymentRequest(ctx, q, arg)
+		return err
+	})
+
+	result.Retries = retries
+	return result, translateError(ctx, err, domain.ErrAccountNotFound)
+}
+
+func payPaymentRequest(ctx context.Context, q *Queries, arg PayPaymentRequestTxParams) (PayPaymentRequestTxResult, error) {
+	var result PayPaymentRequestTxResult
+
+	request, err := q.GetPaymentRequestForUpdate(ctx, arg.ID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return result, domain.ErrPaymentRequestNotFound
+	}
+	if err != nil {
+		return result, err
+	}
+
+	if arg.Authorize != nil {
+		if err := arg.Authorize(request); err != nil {
+			return result, err
+		}
+	}
+
+	result.TransferTxResult, err = transfer(ctx, q, TransferTxParams{
+		FromAccountID: arg.FromAccountID,
+		ToAccountID:   request.ToAccountID,
+		Amount:        request.Amount,
+		Description:   request.Memo,
+		Fee:           arg.Fee,
+		FeeAccountID:  arg.FeeAccountID,
+	})
+	if err != nil {
+		return result, err
+	}
+
+	result.PaymentRequest, err = q.UpdatePaymentRequestStatus(ctx, UpdatePaymentRequestStatusParams{
+		ID:         request.ID,
+		FromStatus: request.Status,
+		ToStatus:   arg.Status,
+		Payer:      sql.NullString{String: arg.Payer, Valid: true},
+		TransferID: sql.NullInt64{Int64: result.Transfer.ID, Valid: true},
+	})
+	return result, err
+}
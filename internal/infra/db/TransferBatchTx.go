@@ -0,0 +1,210 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"slices"
+
+	"github.com/NhutHuyDev/sgbank/internal/domain"
+)
+
+type CreateTransferBatchTxParams struct {
+	CreateTransferBatchParams
+	// Items are the validated rows; their BatchID is filled in by the transaction.
+	Items []CreateTransferBatchItemParams `json:"items"`
+}
+
+type CreateTransferBatchTxResult struct {
+	Batch TransferBatch       `json:"batch"`
+	Items []TransferBatchItem `json:"items"`
+}
+
+// CreateTransferBatchTx stores a batch and all of its rows, so a worker never
+// sees a batch with only part of its rows.
+func (store *StoreSQL) CreateTransferBatchTx(ctx context.Context, arg CreateTransferBatchTxParams) (CreateTransferBatchTxResult, error) {
+	var result CreateTransferBatchTxResult
+
+	_, err := store.execTx(ctx, TxOptions{}, func(q *Queries) error {
+		var err error
+		result = CreateTransferBatchTxResult{Items: make([]TransferBatchItem, 0, len(arg.Items))}
+
+		result.Batch, err = q.CreateTransferBatch(ctx, arg.CreateTransferBatchParams)
+		if err != nil {
+			return err
+		}
+
+		for _, itemArg := range arg.Items {
+			itemArg.BatchID = result.Batch.ID
+			item, err := q.CreateTransferBatchItem(ctx, itemArg)
+			if err != nil {
+				return err
+			}
+			result.Items = append(result.Items, item)
+		}
+
+		return nil
+	})
+
+	return result, translateError(ctx, err, domain.ErrNotFound)
+}
+
+// Statuses of transfer batch rows.
+const (
+	TransferBatchItemPending   = "pending"
+	TransferBatchItemHeld      = "held"
+	TransferBatchItemSucceeded = "succeeded"
+	TransferBatchItemFailed    = "failed"
+)
+
+type PostTransferBatchItemTxParams struct {
+	FromAccountID int64             `json:"from_account_id"`
+	Item          TransferBatchItem `json:"item"`
+	// Fee is charged for the row on top of its amount and credited to
+	// FeeAccountID, as in TransferTxParams.
+	Fee          int64 `json:"fee,omitempty"`
+	FeeAccountID int64 `json:"fee_account_id,omitempty"`
+}
+
+type PostTransferBatchItemTxResult struct {
+	Item TransferBatchItem `json:"item"`
+	TransferTxResult
+}
+
+// PostTransferBatchItemTx posts one pending row of a best-effort batch, or
+// a row held for fraud review, and marks it succeeded in the same
+// transaction. If another worker already
+// settled the row the transfer is rolled back and ErrInvalidTransition is
+// returned, so a row is never paid twice.
+func (store *StoreSQL) PostTransferBatchItemTx(ctx context.Context, arg PostTransferBatchItemTxParams) (PostTransferBatchItemTxResult, error) {
+	var result PostTransferBatchItemTxResult
+
+	retries, err := store.execTx(ctx, TxOptions{}, func(q *Queries) error {
+		var err error
+		result, err = postTransferBatchItem(ctx, q, arg)
+		return err
+	})
+
+	result.Retries = retries
+	return result, translateError(ctx, err, domain.ErrAccountNotFound)
+}
+
+func postTransferBatchItem(ctx context.Context, q *Queries, arg PostTransferBatchItemTxParams) (PostTransferBatchItemTxResult, error) {
+	var result PostTransferBatchItemTxResult
+	var err error
+
+	result.TransferTxResult, err = transfer(ctx, q, TransferTxParams{
+		FromAccountID: arg.FromAccountID,
+		ToAccountID:   arg.Item.ToAccountID,
+		Amount:        arg.Item.Amount,
+		Description:   arg.Item.Reference,
+		Fee:           arg.Fee,
+		FeeAccountID:  arg.FeeAccountID,
+	})
+	if err != nil {
+		return result, err
+	}
+
+	result.Item, err = settleTransferBatchItem(ctx, q, arg.Item.ID, result.Transfer.ID)
+	return result, err
+}
+
+type PostTransferBatchTxParams struct {
+	FromAccountID int64               `json:"from_account_id"`
+	Items         []TransferBatchItem `json:"items"`
+	// Fees are the fees charged per row, keyed by item ID, and credited to
+	// FeeAccountID.
+	Fees         map[int64]int64 `json:"fees,omitempty"`
+	FeeAccountID int64           `json:"fee_account_id,omitempty"`
+}
+
+type PostTransferBatchTxResult struct {
+	Items []TransferBatchItem `json:"items"`
+	// FailedRow is the row number of the transfer that rolled the batch
+	// back, or 0 when the batch was posted.
+	FailedRow int32 `json:"failed_row"`
+	// Transfers are the transfers of Items, in the same order.
+	Transfers []TransferTxResult `json:"transfers"`
+	Retries   int                `json:"-"`
+}
+
+// PostTransferBatchTx posts every row of an all-or-nothing batch in one
+// transaction. All accounts involved are locked up front in ascending ID
+// order, the same order TransferTx uses, so the batch cannot deadlock with
+// concurrent transfers.
+func (store *StoreSQL) PostTransferBatchTx(ctx context.Context, arg PostTransferBatchTxParams) (PostTransferBatchTxResult, error) {
+	var result PostTransferBatchTxResult
+
+	accountIDs := []int64{arg.FromAccountID}
+	for _, item := range arg.Items {
+		accountIDs = append(accountIDs, item.ToAccountID)
+	}
+	if arg.FeeAccountID != 0 {
+		accountIDs = append(accountIDs, arg.FeeAccountID)
+	}
+	slices.Sort(accountIDs)
+	accountIDs = slices.Compact(accountIDs)
+
+	retries, err := store.execTx(ctx, TxOptions{}, func(q *Queries) error {
+		result = PostTransferBatchTxResult{Items: make([]TransferBatchItem, 0, len(arg.Items))}
+
+		if err := lockAccounts(ctx, q, accountIDs); err != nil {
+			return err
+		}
+
+		for _, item := range arg.Items {
+			transferResult, err := transfer(ctx, q, TransferTxParams{
+				FromAccountID: arg.FromAccountID,
+				ToAccountID:   item.ToAccountID,
+				Amount:        item.Amount,
+				Description:   item.Reference,
+				Fee:           arg.Fees[item.ID],
+				FeeAccountID:  arg.FeeAccountID,
+			})
+			if err != nil {
+				result.FailedRow = item.RowNumber
+				return err
+			}
+
+			settled, err := settleTransferBatchItem(ctx, q, item.ID, transferResult.Transfer.ID)
+			if err != nil {
+				result.FailedRow = item.RowNumber
+				return err
+			}
+			result.Items = append(result.Items, settled)
+			result.Transfers = append(result.Transfers, transferResult)
+		}
+
+		return nil
+	})
+
+	result.Retries = retries
+	return result, translateError(ctx, err, domain.ErrAccountNotFound)
+}
+
+func settleTransferBatchItem(ctx context.Context, q *Queries, itemID int64, transferID int64) (TransferBatchItem, error) {
+	item, err := q.UpdateTransferBatchItem(ctx, UpdateTransferBatchItemParams{
+		ID:         itemID,
+		Status:     TransferBatchItemSucceeded,
+		TransferID: sql.NullInt64{Int64: transferID, Valid: true},
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return item, domain.ErrInvalidTransition
+	}
+
+	return item, err
+}
+
+// lockAccounts locks accountIDs, which must be sorted ascending.
+func lockAccounts(ctx context.Context, q *Queries, accountIDs []int64) (err error) {
+	ctx, span := startStepSpan(ctx, "TransferBatchTx.lockAccounts", accountIDs...)
+	defer func() { endStepSpan(span, err) }()
+
+	for _, accountID := range accountIDs {
+		if _, err = q.GetAccountForUpdate(ctx, accountID); err != nil {
+			return
+		}
+	}
+
+	return
+}
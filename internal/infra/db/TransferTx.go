@@ -2,13 +2,47 @@ package db
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
+	"math"
+	"slices"
+	"time"
+
+	"github.com/NhutHuyDev/sgbank/internal/domain"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 )
 
+var tracer = otel.Tracer("github.com/NhutHuyDev/sgbank/internal/infra/db")
+
 type TransferTxParams struct {
-	FromAccountID int64 `json:"from_account_id"`
-	ToAccountID   int64 `json:"to_account_id"`
-	Amount        int64 `json:"amount"`
+	FromAccountID     int64  `json:"from_account_id"`
+	ToAccountID       int64  `json:"to_account_id"`
+	Amount            int64  `json:"amount"`
+	Description       string `json:"description"`
+	ExternalReference string `json:"external_reference"`
+	MerchantCategory  string `json:"merchant_category"`
+	// Fee is charged to the from account on top of Amount and credited to
+	// FeeAccountID, which is required when Fee is positive.
+	Fee          int64 `json:"fee"`
+	FeeAccountID int64 `json:"fee_account_id"`
+	// BusinessDate is the UTC date of the business day to post to. Zero
+	// posts to the open day; an earlier day is closed to postings.
+	BusinessDate time.Time `json:"business_date"`
+	// internal marks a posting from one of the bank's own accounts, such as
+	// interest paid from the interest expense account, which may go
+	// negative and is not held to KYC limits. Only transactions in this
+	// package set it.
+	internal bool
+	// forced marks a posting the bank takes from a customer account whether
+	// or not its balance covers it, such as a chargeback or overdraft
+	// interest. The account may go below its overdraft limit, and entering
+	// overdraft is tracked even when it has none. It is not held to KYC
+	// limits. Only transactions in this package set it.
+	forced bool
 }
 
 type TransferTxResult struct {
@@ -17,113 +51,371 @@ type TransferTxResult struct {
 	ToAccount   Account  `json:"to_account"`
 	FromEntry   Entry    `json:"from_entry"`
 	ToEntry     Entry    `json:"to_entry"`
+	// FeeEntry debits the fee from the from account. It is nil when no fee
+	// was charged.
+	FeeEntry *Entry `json:"fee_entry,omitempty"`
+	// Retries is how many times the transaction was retried after a
+	// deadlock or serialization failure. It is set even when TransferTx fails.
+	Retries int `json:"-"`
 }
 
 func (store *StoreSQL) TransferTx(ctx context.Context, arg TransferTxParams) (TransferTxResult, error) {
 	var result TransferTxResult
 
-	err := store.execTx(ctx, func(q *Queries) error {
+	retries, err := store.execTx(ctx, TxOptions{}, func(q *Queries) error {
 		var err error
+		result, err = transfer(ctx, q, arg)
+		return err
+	})
 
-		var fromAccount Account
-
-		if arg.FromAccountID < arg.ToAccountID {
-			fromAccount, _, err = blockAccounts(ctx, q, arg.FromAccountID, arg.ToAccountID)
-			if err != nil {
-				return err
-			}
-		} else {
-			_, fromAccount, err = blockAccounts(ctx, q, arg.ToAccountID, arg.FromAccountID)
-			if err != nil {
-				return err
-			}
-		}
+	result.Retries = retries
+	return result, translateError(ctx, err, domain.ErrAccountNotFound)
+}
+
+// Kinds of transfers.
+const (
+	// TransferPayment is a payment by a customer.
+	TransferPayment = "payment"
+	// TransferInternal is a posting from one of the bank's own accounts.
+	TransferInternal = "internal"
+	// TransferForced is a posting the bank took from a customer account,
+	// such as a chargeback or overdraft interest.
+	TransferForced = "forced"
+)
+
+// FeeDescription describes the entry debiting a transfer fee.
+const FeeDescription = "Transfer fee"
+
+// transfer moves arg.Amount between two accounts, and arg.Fee to the fee
+// account, inside the caller's transaction. All of them must be in the same
+// currency, and a payment by a customer must be within the KYC limits of the
+// owner of the from account. The accounts are always locked
+// in ascending ID order so concurrent transfers in opposite directions
+// cannot deadlock; other transactions that post a transfer must go through
+// here.
+func transfer(ctx context.Context, q *Queries, arg TransferTxParams) (TransferTxResult, error) {
+	var result TransferTxResult
+
+	if arg.Fee < 0 || arg.Fee > math.MaxInt64-arg.Amount || (arg.Fee > 0 && arg.FeeAccountID == 0) {
+		return result, fmt.Errorf("%w: invalid fee %d for amount %d", domain.ErrInvalidArgument, arg.Fee, arg.Amount)
+	}
+
+	var err error
+	arg.BusinessDate, err = postingDate(ctx, q, arg.BusinessDate)
+	if err != nil {
+		return result, err
+	}
+
+	changes := map[int64]int64{}
+	changes[arg.FromAccountID] -= arg.Amount + arg.Fee
+	changes[arg.ToAccountID] += arg.Amount
+	if arg.Fee > 0 {
+		changes[arg.FeeAccountID] += arg.Fee
+	}
+
+	accounts, err := blockAccounts(ctx, q, changes)
+	if err != nil {
+		return result, err
+	}
+
+	fromAccount, toAccount := accounts[arg.FromAccountID], accounts[arg.ToAccountID]
+	if toAccount.Currency != fromAccount.Currency {
+		return result, fmt.Errorf("%w: account [%d]: %s vs account [%d]: %s", domain.ErrCurrencyMismatch, fromAccount.ID, fromAccount.Currency, toAccount.ID, toAccount.Currency)
+	}
+	if feeAccount := accounts[arg.FeeAccountID]; arg.Fee > 0 && feeAccount.Currency != fromAccount.Currency {
+		return result, fmt.Errorf("%w: fee account [%d]: %s vs %s", domain.ErrCurrencyMismatch, feeAccount.ID, feeAccount.Currency, fromAccount.Currency)
+	}
+	if fromAccount.Balance-(arg.Amount+arg.Fee) < -fromAccount.OverdraftLimit && !arg.internal && !arg.forced {
+		return result, domain.ErrInsufficientFunds
+	}
 
-		if fromAccount.Balance < arg.Amount {
-			return fmt.Errorf("the balance of the from account is insufficient")
+	if !arg.internal && !arg.forced {
+		// payments from any account of the owner wait for each other here, so
+		// they cannot both fit in what is left of the daily limit
+		_, err = q.GetUserForUpdate(ctx, fromAccount.Owner)
+		if err != nil {
+			return result, err
 		}
 
-		result.Transfer, err = q.CreateTransfer(ctx, CreateTransferParams(arg))
+		err = CheckKYCLimits(ctx, q, fromAccount.Owner, fromAccount.Currency, arg.Amount)
 		if err != nil {
-			return err
+			return result, err
 		}
+	}
 
-		result.FromEntry, err = q.CreateEntry(ctx, CreateEntryParams{
-			AccountID: arg.FromAccountID,
-			Amount:    -arg.Amount,
-		})
+	kind := TransferPayment
+	switch {
+	case arg.forced:
+		kind = TransferForced
+	case arg.internal:
+		kind = TransferInternal
+	}
+
+	result.Transfer, err = q.CreateTransfer(ctx, CreateTransferParams{
+		FromAccountID:     arg.FromAccountID,
+		ToAccountID:       arg.ToAccountID,
+		Amount:            arg.Amount,
+		Fee:               arg.Fee,
+		Description:       arg.Description,
+		ExternalReference: arg.ExternalReference,
+		MerchantCategory:  arg.MerchantCategory,
+		Kind:              kind,
+		BusinessDate:      sql.NullTime{Time: arg.BusinessDate, Valid: true},
+	})
+	if err != nil {
+		return result, err
+	}
+
+	result.FromEntry, err = createTransferEntry(ctx, q, arg, result.Transfer.ID, fromAccount.Owner, arg.FromAccountID, -arg.Amount, arg.ToAccountID)
+	if err != nil {
+		return result, err
+	}
+
+	result.ToEntry, err = createTransferEntry(ctx, q, arg, result.Transfer.ID, toAccount.Owner, arg.ToAccountID, arg.Amount, arg.FromAccountID)
+	if err != nil {
+		return result, err
+	}
+
+	if arg.Fee > 0 {
+		feeArg := arg
+		feeArg.Description = FeeDescription
+		feeArg.MerchantCategory = ""
+
+		feeEntry, err := createTransferEntry(ctx, q, feeArg, result.Transfer.ID, fromAccount.Owner, arg.FromAccountID, -arg.Fee, arg.FeeAccountID)
 		if err != nil {
-			return err
+			return result, err
 		}
+		result.FeeEntry = &feeEntry
 
-		result.ToEntry, err = q.CreateEntry(ctx, CreateEntryParams{
-			AccountID: arg.ToAccountID,
-			Amount:    arg.Amount,
-		})
+		_, err = createTransferEntry(ctx, q, feeArg, result.Transfer.ID, accounts[arg.FeeAccountID].Owner, arg.FeeAccountID, arg.Fee, arg.FromAccountID)
 		if err != nil {
-			return err
+			return result, err
 		}
+	}
 
-		if arg.FromAccountID < arg.ToAccountID {
-			result.FromAccount, result.ToAccount, err = updateBalanceForAccounts(ctx, q, arg.FromAccountID, -arg.Amount, arg.ToAccountID, +arg.Amount)
-			if err != nil {
-				return err
-			}
-		} else {
-			result.ToAccount, result.FromAccount, err = updateBalanceForAccounts(ctx, q, arg.ToAccountID, arg.Amount, arg.FromAccountID, -arg.Amount)
-			if err != nil {
-				return err
-			}
+	updated, err := updateBalanceForAccounts(ctx, q, changes)
+	if err != nil {
+		return result, err
+	}
+
+	for _, accountID := range sortedAccountIDs(changes) {
+		forced := arg.forced && accountID == arg.FromAccountID
+		updated[accountID], err = trackOverdraft(ctx, q, accounts[accountID], updated[accountID], forced)
+		if err != nil {
+			return result, err
 		}
+	}
+
+	result.FromAccount, result.ToAccount = updated[arg.FromAccountID], updated[arg.ToAccountID]
+	return result, nil
+}
 
+// postingDate returns the business date a transfer posts to: the open
+// business day, which it locks in share mode so the day cannot start
+// closing before the transaction commits. A requested date before the open
+// day is closed to postings.
+func postingDate(ctx context.Context, q *Queries, requested time.Time) (time.Time, error) {
+	day, err := q.GetOpenBusinessDayForShare(ctx)
+	if errors.Is(err, sql.ErrNoRows) {
+		return time.Time{}, fmt.Errorf("%w: no business day is open", domain.ErrBusinessDayClosed)
+	}
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	if requested.IsZero() {
+		return day.BusinessDate, nil
+	}
+
+	date, open := requested.Format(time.DateOnly), day.BusinessDate.Format(time.DateOnly)
+	switch {
+	case date < open:
+		return time.Time{}, fmt.Errorf("%w: business date %s is closed, the open business date is %s", domain.ErrBusinessDayClosed, date, open)
+	case date > open:
+		return time.Time{}, fmt.Errorf("%w: business date %s is not open yet, the open business date is %s", domain.ErrInvalidArgument, date, open)
+	}
+
+	return day.BusinessDate, nil
+}
+
+// CheckKYCLimits fails with ErrKYCLimitExceeded when amount is above the
+// limit per transfer in currency of the KYC level of username, or would take
+// the payments they made in currency since midnight UTC above its daily
+// limit. Forced and internal postings do not count. A level has no limits in
+// a currency it has none set for.
+func CheckKYCLimits(ctx context.Context, q Querier, username string, currency string, amount int64) error {
+	limits, err := q.GetUserKycTransferLimits(ctx, GetUserKycTransferLimitsParams{Username: username, Currency: currency})
+	if err != nil {
+		return err
+	}
+
+	if limits.MaxTransferAmount.Valid && amount > limits.MaxTransferAmount.Int64 {
+		return fmt.Errorf("%w: level %d (%s) allows transfers of up to %d %s", domain.ErrKYCLimitExceeded,
+			limits.Level, limits.Name, limits.MaxTransferAmount.Int64, currency)
+	}
+
+	if !limits.DailyTransferLimit.Valid {
 		return nil
+	}
+
+	sent, err := q.SumTransfersFromOwnerSince(ctx, SumTransfersFromOwnerSinceParams{
+		Owner:    username,
+		Currency: currency,
+		Since:    time.Now().UTC().Truncate(24 * time.Hour),
 	})
+	if err != nil {
+		return err
+	}
+
+	if sent+amount > limits.DailyTransferLimit.Int64 {
+		return fmt.Errorf("%w: %d of the daily limit of %d %s of level %d (%s) already sent", domain.ErrKYCLimitExceeded,
+			sent, limits.DailyTransferLimit.Int64, currency, limits.Level, limits.Name)
+	}
 
-	return result, err
+	return nil
 }
 
-func blockAccounts(
-	ctx context.Context,
-	q *Queries,
-	accountID1 int64,
-	accountID2 int64,
-) (account1 Account, account2 Account, err error) {
-	account1, err = q.GetAccountForUpdate(ctx, accountID1)
-	if err != nil {
-		return
+// Kinds of overdraft events.
+const (
+	OverdraftEntered = "entered"
+	OverdraftLeft    = "left"
+)
+
+// trackOverdraft records an overdraft event when a posting takes an
+// account with an overdraft limit below zero, or an overdrawn account back
+// to zero or above. A forced posting is tracked even when the account has
+// no limit. The bank's own accounts have no limit and are never forced, so
+// they are not tracked. It returns the account as updated.
+func trackOverdraft(ctx context.Context, q *Queries, before Account, after Account, forced bool) (Account, error) {
+	var kind string
+	var since *time.Time
+
+	switch {
+	case before.Balance >= 0 && after.Balance < 0 && (after.OverdraftLimit > 0 || forced):
+		kind = OverdraftEntered
+		now := time.Now()
+		since = &now
+	case after.Balance >= 0 && after.OverdrawnSince != nil:
+		kind = OverdraftLeft
+	default:
+		return after, nil
 	}
 
-	account2, err = q.GetAccountForUpdate(ctx, accountID2)
+	err := q.SetAccountOverdrawnSince(ctx, SetAccountOverdrawnSinceParams{ID: after.ID, OverdrawnSince: since})
 	if err != nil {
-		return
+		return after, err
 	}
+	after.OverdrawnSince = since
 
-	return
+	_, err = q.CreateOverdraftEvent(ctx, CreateOverdraftEventParams{
+		AccountID: after.ID,
+		Kind:      kind,
+		Balance:   after.Balance,
+	})
+	return after, err
 }
 
-func updateBalanceForAccounts(
+// createTransferEntry posts one side of a transfer, categorized by the rules
+// of the owner of the account it is posted to.
+func createTransferEntry(
 	ctx context.Context,
 	q *Queries,
-	accountID1 int64,
-	amount1 int64,
-	accountID2 int64,
-	amount2 int64,
-) (account1 Account, account2 Account, err error) {
-	account1, err = q.AddAccountBalance(ctx, AddAccountBalanceParams{
-		ID:     accountID1,
-		Amount: amount1,
+	arg TransferTxParams,
+	transferID int64,
+	owner string,
+	accountID int64,
+	amount int64,
+	counterpartyAccountID int64,
+) (Entry, error) {
+	category, err := q.MatchCategorizationRule(ctx, MatchCategorizationRuleParams{
+		Owner:                 owner,
+		Description:           arg.Description,
+		MerchantCategory:      arg.MerchantCategory,
+		CounterpartyAccountID: counterpartyAccountID,
 	})
-	if err != nil {
-		return
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return Entry{}, err
 	}
 
-	account2, err = q.AddAccountBalance(ctx, AddAccountBalanceParams{
-		ID:     accountID2,
-		Amount: amount2,
+	return q.CreateEntry(ctx, CreateEntryParams{
+		AccountID:         accountID,
+		Amount:            amount,
+		TransferID:        &transferID,
+		Description:       arg.Description,
+		ExternalReference: arg.ExternalReference,
+		MerchantCategory:  arg.MerchantCategory,
+		Category:          category,
+		BusinessDate:      sql.NullTime{Time: arg.BusinessDate, Valid: true},
 	})
-	if err != nil {
-		return
+}
+
+// blockAccounts locks the accounts of changes in ascending ID order.
+func blockAccounts(
+	ctx context.Context,
+	q *Queries,
+	changes map[int64]int64,
+) (accounts map[int64]Account, err error) {
+	accountIDs := sortedAccountIDs(changes)
+	ctx, span := startStepSpan(ctx, "TransferTx.blockAccounts", accountIDs...)
+	defer func() { endStepSpan(span, err) }()
+
+	accounts = make(map[int64]Account, len(accountIDs))
+	for _, accountID := range accountIDs {
+		accounts[accountID], err = q.GetAccountForUpdate(ctx, accountID)
+		if err != nil {
+			return
+		}
 	}
 
 	return
 }
+
+// updateBalanceForAccounts adds changes to the balances of their accounts,
+// in the same order as blockAccounts.
+func updateBalanceForAccounts(
+	ctx context.Context,
+	q *Queries,
+	changes map[int64]int64,
+) (accounts map[int64]Account, err error) {
+	accountIDs := sortedAccountIDs(changes)
+	ctx, span := startStepSpan(ctx, "TransferTx.updateBalanceForAccounts", accountIDs...)
+	defer func() { endStepSpan(span, err) }()
+
+	accounts = make(map[int64]Account, len(accountIDs))
+	for _, accountID := range accountIDs {
+		accounts[accountID], err = q.AddAccountBalance(ctx, AddAccountBalanceParams{
+			ID:     accountID,
+			Amount: changes[accountID],
+		})
+		if err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+func sortedAccountIDs(changes map[int64]int64) []int64 {
+	accountIDs := make([]int64, 0, len(changes))
+	for accountID := range changes {
+		accountIDs = append(accountIDs, accountID)
+	}
+	slices.Sort(accountIDs)
+	return accountIDs
+}
+
+// startStepSpan times a step of a transaction, e.g. waiting for the row locks
+// taken by blockAccounts.
+func startStepSpan(ctx context.Context, name string, accountIDs ...int64) (context.Context, trace.Span) {
+	return tracer.Start(ctx, name, trace.WithAttributes(
+		attribute.Int64Slice("sgbank.account_ids", accountIDs),
+	))
+}
+
+func endStepSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
@@ -0,0 +1,68 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: account_products.sql
+
+package db
+
+import (
+	"context"
+)
+
+const getAccountProduct = `-- name: GetAccountProduct :one
+SELECT code, name, annual_rate_ppm, is_internal, created_at, overdraft_rate_ppm, gl_account, overdraft_gl_account FROM account_products WHERE code = $1 LIMIT 1
+`
+
+func (q *Queries) GetAccountProduct(ctx context.Context, code string) (AccountProduct, error) {
+	row := q.db.QueryRowContext(ctx, getAccountProduct, code)
+	var i AccountProduct
+	err := row.Scan(
+		&i.Code,
+		&i.Name,
+		&i.AnnualRatePpm,
+		&i.IsInternal,
+		&i.CreatedAt,
+		&i.OverdraftRatePpm,
+		&i.GlAccount,
+		&i.OverdraftGlAccount,
+	)
+	return i, err
+}
+
+const listAccountProducts = `-- name: ListAccountProducts :many
+SELECT code, name, annual_rate_ppm, is_internal, created_at, overdraft_rate_ppm, gl_account, overdraft_gl_account FROM account_products
+WHERE NOT is_internal
+ORDER BY code
+`
+
+func (q *Queries) ListAccountProducts(ctx context.Context) ([]AccountProduct, error) {
+	rows, err := q.db.QueryContext(ctx, listAccountProducts)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []AccountProduct{}
+	for rows.Next() {
+		var i AccountProduct
+		if err := rows.Scan(
+			&i.Code,
+			&i.Name,
+			&i.AnnualRatePpm,
+			&i.IsInternal,
+			&i.CreatedAt,
+			&i.OverdraftRatePpm,
+			&i.GlAccount,
+			&i.OverdraftGlAccount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -13,7 +13,7 @@ const addAccountBalance = `-- name: AddAccountBalance :one
 UPDATE accounts
 SET balance = balance + $1
 WHERE id = $2
-RETURNING id, owner, balance, currency, created_at
+RETURNING id, owner, balance, currency, created_at, account_number, product, overdraft_limit, overdrawn_since
 `
 
 type AddAccountBalanceParams struct {
@@ -30,24 +30,36 @@ func (q *Queries) AddAccountBalance(ctx context.Context, arg AddAccountBalancePa
 		&i.Balance,
 		&i.Currency,
 		&i.CreatedAt,
+		&i.AccountNumber,
+		&i.Product,
+		&i.OverdraftLimit,
+		&i.OverdrawnSince,
 	)
 	return i, err
 }
 
 const createAccount = `-- name: CreateAccount :one
-INSERT INTO accounts (owner, balance, currency)
-VALUES ($1, $2, $3)
-RETURNING id, owner, balance, currency, created_at
+INSERT INTO accounts (owner, balance, currency, account_number, product)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING id, owner, balance, currency, created_at, account_number, product, overdraft_limit, overdrawn_since
 `
 
 type CreateAccountParams struct {
-	Owner    string `json:"owner"`
-	Balance  int64  `json:"balance"`
-	Currency string `json:"currency"`
+	Owner         string `json:"owner"`
+	Balance       int64  `json:"balance"`
+	Currency      string `json:"currency"`
+	AccountNumber string `json:"account_number"`
+	Product       string `json:"product"`
 }
 
 func (q *Queries) CreateAccount(ctx context.Context, arg CreateAccountParams) (Account, error) {
-	row := q.db.QueryRowContext(ctx, createAccount, arg.Owner, arg.Balance, arg.Currency)
+	row := q.db.QueryRowContext(ctx, createAccount,
+		arg.Owner,
+		arg.Balance,
+		arg.Currency,
+		arg.AccountNumber,
+		arg.Product,
+	)
 	var i Account
 	err := row.Scan(
 		&i.ID,
@@ -55,6 +67,10 @@ func (q *Queries) CreateAccount(ctx context.Context, arg CreateAccountParams) (A
 		&i.Balance,
 		&i.Currency,
 		&i.CreatedAt,
+		&i.AccountNumber,
+		&i.Product,
+		&i.OverdraftLimit,
+		&i.OverdrawnSince,
 	)
 	return i, err
 }
@@ -70,7 +86,7 @@ func (q *Queries) DeleteAccount(ctx context.Context, id int64) error {
 }
 
 const getAccount = `-- name: GetAccount :one
-SELECT id, owner, balance, currency, created_at FROM accounts WHERE id = $1 LIMIT 1
+SELECT id, owner, balance, currency, created_at, account_number, product, overdraft_limit, overdrawn_since FROM accounts WHERE id = $1 LIMIT 1
 `
 
 func (q *Queries) GetAccount(ctx context.Context, id int64) (Account, error) {
@@ -82,12 +98,96 @@ func (q *Queries) GetAccount(ctx context.Context, id int64) (Account, error) {
 		&i.Balance,
 		&i.Currency,
 		&i.CreatedAt,
+		&i.AccountNumber,
+		&i.Product,
+		&i.OverdraftLimit,
+		&i.OverdrawnSince,
+	)
+	return i, err
+}
+
+const getAccountByNumber = `-- name: GetAccountByNumber :one
+SELECT id, owner, balance, currency, created_at, account_number, product, overdraft_limit, overdrawn_since FROM accounts WHERE account_number = $1 LIMIT 1
+`
+
+func (q *Queries) GetAccountByNumber(ctx context.Context, accountNumber string) (Account, error) {
+	row := q.db.QueryRowContext(ctx, getAccountByNumber, accountNumber)
+	var i Account
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.Balance,
+		&i.Currency,
+		&i.CreatedAt,
+		&i.AccountNumber,
+		&i.Product,
+		&i.OverdraftLimit,
+		&i.OverdrawnSince,
+	)
+	return i, err
+}
+
+const getAccountByOwnerCurrency = `-- name: GetAccountByOwnerCurrency :one
+SELECT id, owner, balance, currency, created_at, account_number, product, overdraft_limit, overdrawn_since FROM accounts
+WHERE owner = $1 AND currency = $2 AND product = 'checking'
+LIMIT 1
+`
+
+type GetAccountByOwnerCurrencyParams struct {
+	Owner    string `json:"owner"`
+	Currency string `json:"currency"`
+}
+
+// Returns owner's checking account in currency, where payments to the
+// owner are credited.
+func (q *Queries) GetAccountByOwnerCurrency(ctx context.Context, arg GetAccountByOwnerCurrencyParams) (Account, error) {
+	row := q.db.QueryRowContext(ctx, getAccountByOwnerCurrency, arg.Owner, arg.Currency)
+	var i Account
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.Balance,
+		&i.Currency,
+		&i.CreatedAt,
+		&i.AccountNumber,
+		&i.Product,
+		&i.OverdraftLimit,
+		&i.OverdrawnSince,
+	)
+	return i, err
+}
+
+const getAccountByOwnerProduct = `-- name: GetAccountByOwnerProduct :one
+SELECT id, owner, balance, currency, created_at, account_number, product, overdraft_limit, overdrawn_since FROM accounts
+WHERE owner = $1 AND currency = $2 AND product = $3
+LIMIT 1
+`
+
+type GetAccountByOwnerProductParams struct {
+	Owner    string `json:"owner"`
+	Currency string `json:"currency"`
+	Product  string `json:"product"`
+}
+
+func (q *Queries) GetAccountByOwnerProduct(ctx context.Context, arg GetAccountByOwnerProductParams) (Account, error) {
+	row := q.db.QueryRowContext(ctx, getAccountByOwnerProduct, arg.Owner, arg.Currency, arg.Product)
+	var i Account
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.Balance,
+		&i.Currency,
+		&i.CreatedAt,
+		&i.AccountNumber,
+		&i.Product,
+		&i.OverdraftLimit,
+		&i.OverdrawnSince,
 	)
 	return i, err
 }
 
 const getAccountForUpdate = `-- name: GetAccountForUpdate :one
-SELECT id, owner, balance, currency, created_at FROM accounts WHERE id = $1 LIMIT 1 FOR NO KEY UPDATE
+SELECT id, owner, balance, currency, created_at, account_number, product, overdraft_limit, overdrawn_since FROM accounts WHERE id = $1 LIMIT 1 FOR NO KEY UPDATE
 `
 
 func (q *Queries) GetAccountForUpdate(ctx context.Context, id int64) (Account, error) {
@@ -99,12 +199,16 @@ func (q *Queries) GetAccountForUpdate(ctx context.Context, id int64) (Account, e
 		&i.Balance,
 		&i.Currency,
 		&i.CreatedAt,
+		&i.AccountNumber,
+		&i.Product,
+		&i.OverdraftLimit,
+		&i.OverdrawnSince,
 	)
 	return i, err
 }
 
 const listAccounts = `-- name: ListAccounts :many
-SELECT id, owner, balance, currency, created_at FROM accounts
+SELECT id, owner, balance, currency, created_at, account_number, product, overdraft_limit, overdrawn_since FROM accounts
 WHERE owner = $1
 ORDER BY id 
 LIMIT $2 OFFSET $3
@@ -131,6 +235,57 @@ func (q *Queries) ListAccounts(ctx context.Context, arg ListAccountsParams) ([]A
 			&i.Balance,
 			&i.Currency,
 			&i.CreatedAt,
+			&i.AccountNumber,
+			&i.Product,
+			&i.OverdraftLimit,
+			&i.OverdrawnSince,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listAccountsAfter = `-- name: ListAccountsAfter :many
+SELECT id, owner, balance, currency, created_at, account_number, product, overdraft_limit, overdrawn_since FROM accounts
+WHERE owner = $1
+    AND id > $2
+ORDER BY id
+LIMIT $3
+`
+
+type ListAccountsAfterParams struct {
+	Owner     string `json:"owner"`
+	AfterID   int64  `json:"after_id"`
+	PageLimit int32  `json:"page_limit"`
+}
+
+func (q *Queries) ListAccountsAfter(ctx context.Context, arg ListAccountsAfterParams) ([]Account, error) {
+	rows, err := q.db.QueryContext(ctx, listAccountsAfter, arg.Owner, arg.AfterID, arg.PageLimit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Account{}
+	for rows.Next() {
+		var i Account
+		if err := rows.Scan(
+			&i.ID,
+			&i.Owner,
+			&i.Balance,
+			&i.Currency,
+			&i.CreatedAt,
+			&i.AccountNumber,
+			&i.Product,
+			&i.OverdraftLimit,
+			&i.OverdrawnSince,
 		); err != nil {
 			return nil, err
 		}
@@ -149,7 +304,7 @@ const updatedAccount = `-- name: UpdatedAccount :one
 UPDATE accounts
 SET balance = $2
 WHERE id = $1
-RETURNING id, owner, balance, currency, created_at
+RETURNING id, owner, balance, currency, created_at, account_number, product, overdraft_limit, overdrawn_since
 `
 
 type UpdatedAccountParams struct {
@@ -166,6 +321,10 @@ func (q *Queries) UpdatedAccount(ctx context.Context, arg UpdatedAccountParams)
 		&i.Balance,
 		&i.Currency,
 		&i.CreatedAt,
+		&i.AccountNumber,
+		&i.Product,
+		&i.OverdraftLimit,
+		&i.OverdrawnSince,
 	)
 	return i, err
 }
@@ -0,0 +1,110 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: balances.sql
+
+package db
+
+import (
+	"context"
+	"time"
+)
+
+const getAccountBalanceBefore = `-- name: GetAccountBalanceBefore :one
+WITH snapshot AS (
+    SELECT b.business_date, b.balance
+    FROM daily_balances b
+    JOIN business_days d ON d.business_date = b.business_date
+    WHERE b.account_id = $2 AND d.closing_at <= $1
+    ORDER BY b.business_date DESC
+    LIMIT 1
+)
+SELECT (CASE
+    WHEN EXISTS (SELECT 1 FROM snapshot) THEN
+        (SELECT s.balance FROM snapshot s) + COALESCE((
+            SELECT SUM(e.amount) FROM entries e
+            WHERE e.account_id = a.id
+                AND e.business_date > (SELECT s.business_date FROM snapshot s)
+                AND e.created_at < $1
+        ), 0)
+    ELSE
+        a.balance - COALESCE((
+            SELECT SUM(e.amount) FROM entries e
+            WHERE e.account_id = a.id AND e.created_at >= $1
+        ), 0)
+    END)::bigint AS balance
+FROM accounts a
+WHERE a.id = $2
+`
+
+type GetAccountBalanceBeforeParams struct {
+	Before    time.Time `json:"before"`
+	AccountID int64     `json:"account_id"`
+}
+
+// Returns the balance of an account before a time: the daily balance of the
+// last business day that started closing by then plus the entries posted to
+// later days before it, or, without such a daily balance, the current
+// balance minus the entries created since.
+func (q *Queries) GetAccountBalanceBefore(ctx context.Context, arg GetAccountBalanceBeforeParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, getAccountBalanceBefore, arg.Before, arg.AccountID)
+	var balance int64
+	err := row.Scan(&balance)
+	return balance, err
+}
+
+const sumEntriesByPeriod = `-- name: SumEntriesByPeriod :many
+SELECT
+    (date_trunc($1::text, e.created_at AT TIME ZONE 'UTC') AT TIME ZONE 'UTC')::timestamptz AS period_start,
+    COALESCE(SUM(e.amount) FILTER (WHERE e.amount > 0), 0)::bigint AS credits,
+    COALESCE(-SUM(e.amount) FILTER (WHERE e.amount < 0), 0)::bigint AS debits
+FROM entries e
+WHERE e.account_id = $2
+    AND e.created_at >= $3
+    AND e.created_at < $4
+GROUP BY 1
+ORDER BY 1
+`
+
+type SumEntriesByPeriodParams struct {
+	Period      string    `json:"period"`
+	AccountID   int64     `json:"account_id"`
+	CreatedFrom time.Time `json:"created_from"`
+	CreatedTo   time.Time `json:"created_to"`
+}
+
+type SumEntriesByPeriodRow struct {
+	PeriodStart time.Time `json:"period_start"`
+	Credits     int64     `json:"credits"`
+	Debits      int64     `json:"debits"`
+}
+
+// Sums the credits and debits of an account's entries created from from to
+// before to, by UTC day, week (from Monday) or month.
+func (q *Queries) SumEntriesByPeriod(ctx context.Context, arg SumEntriesByPeriodParams) ([]SumEntriesByPeriodRow, error) {
+	rows, err := q.db.QueryContext(ctx, sumEntriesByPeriod,
+		arg.Period,
+		arg.AccountID,
+		arg.CreatedFrom,
+		arg.CreatedTo,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []SumEntriesByPeriodRow{}
+	for rows.Next() {
+		var i SumEntriesByPeriodRow
+		if err := rows.Scan(&i.PeriodStart, &i.Credits, &i.Debits); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
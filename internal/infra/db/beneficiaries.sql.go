@@ -0,0 +1,174 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: beneficiaries.sql
+
+package db
+
+import (
+	"context"
+)
+
+const createBeneficiary = `-- name: CreateBeneficiary :one
+INSERT INTO beneficiaries (
+    owner,
+    account_number,
+    nickname,
+    currency,
+    is_verified
+) VALUES (
+    $1, $2, $3, $4, $5
+) RETURNING id, owner, account_number, nickname, currency, is_verified, created_at
+`
+
+type CreateBeneficiaryParams struct {
+	Owner         string `json:"owner"`
+	AccountNumber string `json:"account_number"`
+	Nickname      string `json:"nickname"`
+	Currency      string `json:"currency"`
+	IsVerified    bool   `json:"is_verified"`
+}
+
+func (q *Queries) CreateBeneficiary(ctx context.Context, arg CreateBeneficiaryParams) (Beneficiary, error) {
+	row := q.db.QueryRowContext(ctx, createBeneficiary,
+		arg.Owner,
+		arg.AccountNumber,
+		arg.Nickname,
+		arg.Currency,
+		arg.IsVerified,
+	)
+	var i Beneficiary
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.AccountNumber,
+		&i.Nickname,
+		&i.Currency,
+		&i.IsVerified,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const deleteBeneficiary = `-- name: DeleteBeneficiary :exec
+DELETE FROM beneficiaries
+WHERE id = $1
+`
+
+func (q *Queries) DeleteBeneficiary(ctx context.Context, id int64) error {
+	_, err := q.db.ExecContext(ctx, deleteBeneficiary, id)
+	return err
+}
+
+const getBeneficiary = `-- name: GetBeneficiary :one
+SELECT id, owner, account_number, nickname, currency, is_verified, created_at FROM beneficiaries WHERE id = $1 LIMIT 1
+`
+
+func (q *Queries) GetBeneficiary(ctx context.Context, id int64) (Beneficiary, error) {
+	row := q.db.QueryRowContext(ctx, getBeneficiary, id)
+	var i Beneficiary
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.AccountNumber,
+		&i.Nickname,
+		&i.Currency,
+		&i.IsVerified,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const listBeneficiariesAfter = `-- name: ListBeneficiariesAfter :many
+SELECT id, owner, account_number, nickname, currency, is_verified, created_at FROM beneficiaries
+WHERE owner = $1
+    AND id > $2
+ORDER BY id
+LIMIT $3
+`
+
+type ListBeneficiariesAfterParams struct {
+	Owner     string `json:"owner"`
+	AfterID   int64  `json:"after_id"`
+	PageLimit int32  `json:"page_limit"`
+}
+
+func (q *Queries) ListBeneficiariesAfter(ctx context.Context, arg ListBeneficiariesAfterParams) ([]Beneficiary, error) {
+	rows, err := q.db.QueryContext(ctx, listBeneficiariesAfter, arg.Owner, arg.AfterID, arg.PageLimit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Beneficiary{}
+	for rows.Next() {
+		var i Beneficiary
+		if err := rows.Scan(
+			&i.ID,
+			&i.Owner,
+			&i.AccountNumber,
+			&i.Nickname,
+			&i.Currency,
+			&i.IsVerified,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const markBeneficiaryVerified = `-- name: MarkBeneficiaryVerified :one
+UPDATE beneficiaries
+SET is_verified = true
+WHERE id = $1
+RETURNING id, owner, account_number, nickname, currency, is_verified, created_at
+`
+
+func (q *Queries) MarkBeneficiaryVerified(ctx context.Context, id int64) (Beneficiary, error) {
+	row := q.db.QueryRowContext(ctx, markBeneficiaryVerified, id)
+	var i Beneficiary
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.AccountNumber,
+		&i.Nickname,
+		&i.Currency,
+		&i.IsVerified,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const updateBeneficiaryNickname = `-- name: UpdateBeneficiaryNickname :one
+UPDATE beneficiaries
+SET nickname = $2
+WHERE id = $1
+RETURNING id, owner, account_number, nickname, currency, is_verified, created_at
+`
+
+type UpdateBeneficiaryNicknameParams struct {
+	ID       int64  `json:"id"`
+	Nickname string `json:"nickname"`
+}
+
+func (q *Queries) UpdateBeneficiaryNickname(ctx context.Context, arg UpdateBeneficiaryNicknameParams) (Beneficiary, error) {
+	row := q.db.QueryRowContext(ctx, updateBeneficiaryNickname, arg.ID, arg.Nickname)
+	var i Beneficiary
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.AccountNumber,
+		&i.Nickname,
+		&i.Currency,
+		&i.IsVerified,
+		&i.CreatedAt,
+	)
+	return i, err
+}
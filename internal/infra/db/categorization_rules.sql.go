@@ -0,0 +1,155 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: categorization_rules.sql
+
+package db
+
+import (
+	"context"
+	"database/sql"
+)
+
+const createCategorizationRule = `-- name: CreateCategorizationRule :one
+INSERT INTO categorization_rules (
+    owner,
+    category,
+    description_contains,
+    merchant_category,
+    counterparty_account_id,
+    priority
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+) RETURNING id, owner, category, description_contains, merchant_category, counterparty_account_id, priority, created_at
+`
+
+type CreateCategorizationRuleParams struct {
+	Owner                 string        `json:"owner"`
+	Category              string        `json:"category"`
+	DescriptionContains   string        `json:"description_contains"`
+	MerchantCategory      string        `json:"merchant_category"`
+	CounterpartyAccountID sql.NullInt64 `json:"counterparty_account_id"`
+	Priority              int32         `json:"priority"`
+}
+
+func (q *Queries) CreateCategorizationRule(ctx context.Context, arg CreateCategorizationRuleParams) (CategorizationRule, error) {
+	row := q.db.QueryRowContext(ctx, createCategorizationRule,
+		arg.Owner,
+		arg.Category,
+		arg.DescriptionContains,
+		arg.MerchantCategory,
+		arg.CounterpartyAccountID,
+		arg.Priority,
+	)
+	var i CategorizationRule
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.Category,
+		&i.DescriptionContains,
+		&i.MerchantCategory,
+		&i.CounterpartyAccountID,
+		&i.Priority,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const deleteCategorizationRule = `-- name: DeleteCategorizationRule :exec
+DELETE FROM categorization_rules WHERE id = $1
+`
+
+func (q *Queries) DeleteCategorizationRule(ctx context.Context, id int64) error {
+	_, err := q.db.ExecContext(ctx, deleteCategorizationRule, id)
+	return err
+}
+
+const getCategorizationRule = `-- name: GetCategorizationRule :one
+SELECT id, owner, category, description_contains, merchant_category, counterparty_account_id, priority, created_at FROM categorization_rules WHERE id = $1 LIMIT 1
+`
+
+func (q *Queries) GetCategorizationRule(ctx context.Context, id int64) (CategorizationRule, error) {
+	row := q.db.QueryRowContext(ctx, getCategorizationRule, id)
+	var i CategorizationRule
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.Category,
+		&i.DescriptionContains,
+		&i.MerchantCategory,
+		&i.CounterpartyAccountID,
+		&i.Priority,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const listCategorizationRules = `-- name: ListCategorizationRules :many
+SELECT id, owner, category, description_contains, merchant_category, counterparty_account_id, priority, created_at FROM categorization_rules
+WHERE owner = $1
+ORDER BY priority DESC, id
+`
+
+func (q *Queries) ListCategorizationRules(ctx context.Context, owner string) ([]CategorizationRule, error) {
+	rows, err := q.db.QueryContext(ctx, listCategorizationRules, owner)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []CategorizationRule{}
+	for rows.Next() {
+		var i CategorizationRule
+		if err := rows.Scan(
+			&i.ID,
+			&i.Owner,
+			&i.Category,
+			&i.DescriptionContains,
+			&i.MerchantCategory,
+			&i.CounterpartyAccountID,
+			&i.Priority,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const matchCategorizationRule = `-- name: MatchCategorizationRule :one
+SELECT category FROM categorization_rules
+WHERE owner = $1
+    AND (merchant_category = '' OR merchant_category = $2::varchar)
+    AND (description_contains = ''
+        OR strpos(lower($3::varchar), lower(description_contains)) > 0)
+    AND (counterparty_account_id IS NULL OR counterparty_account_id = $4::bigint)
+ORDER BY priority DESC, id
+LIMIT 1
+`
+
+type MatchCategorizationRuleParams struct {
+	Owner                 string `json:"owner"`
+	MerchantCategory      string `json:"merchant_category"`
+	Description           string `json:"description"`
+	CounterpartyAccountID int64  `json:"counterparty_account_id"`
+}
+
+// Returns the category of owner's highest-priority rule matching an entry;
+// the oldest rule wins a tie. Empty matchers match anything.
+func (q *Queries) MatchCategorizationRule(ctx context.Context, arg MatchCategorizationRuleParams) (string, error) {
+	row := q.db.QueryRowContext(ctx, matchCategorizationRule,
+		arg.Owner,
+		arg.MerchantCategory,
+		arg.Description,
+		arg.CounterpartyAccountID,
+	)
+	var category string
+	err := row.Scan(&category)
+	return category, err
+}
@@ -0,0 +1,99 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: currencies.sql
+
+package db
+
+import (
+	"context"
+)
+
+const getCurrency = `-- name: GetCurrency :one
+SELECT code, name, exponent, enabled, updated_at, created_at FROM currencies WHERE code = $1 LIMIT 1
+`
+
+func (q *Queries) GetCurrency(ctx context.Context, code string) (Currency, error) {
+	row := q.db.QueryRowContext(ctx, getCurrency, code)
+	var i Currency
+	err := row.Scan(
+		&i.Code,
+		&i.Name,
+		&i.Exponent,
+		&i.Enabled,
+		&i.UpdatedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const listCurrencies = `-- name: ListCurrencies :many
+SELECT code, name, exponent, enabled, updated_at, created_at FROM currencies
+ORDER BY code
+`
+
+func (q *Queries) ListCurrencies(ctx context.Context) ([]Currency, error) {
+	rows, err := q.db.QueryContext(ctx, listCurrencies)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Currency{}
+	for rows.Next() {
+		var i Currency
+		if err := rows.Scan(
+			&i.Code,
+			&i.Name,
+			&i.Exponent,
+			&i.Enabled,
+			&i.UpdatedAt,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const upsertCurrency = `-- name: UpsertCurrency :one
+INSERT INTO currencies (code, name, exponent, enabled)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (code) DO UPDATE
+SET name = EXCLUDED.name,
+    enabled = EXCLUDED.enabled,
+    updated_at = now()
+RETURNING code, name, exponent, enabled, updated_at, created_at
+`
+
+type UpsertCurrencyParams struct {
+	Code     string `json:"code"`
+	Name     string `json:"name"`
+	Exponent int32  `json:"exponent"`
+	Enabled  bool   `json:"enabled"`
+}
+
+func (q *Queries) UpsertCurrency(ctx context.Context, arg UpsertCurrencyParams) (Currency, error) {
+	row := q.db.QueryRowContext(ctx, upsertCurrency,
+		arg.Code,
+		arg.Name,
+		arg.Exponent,
+		arg.Enabled,
+	)
+	var i Currency
+	err := row.Scan(
+		&i.Code,
+		&i.Name,
+		&i.Exponent,
+		&i.Enabled,
+		&i.UpdatedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
@@ -0,0 +1,668 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: disputes.sql
+
+package db
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+const claimDisputeSLABreaches = `-- name: ClaimDisputeSLABreaches :many
+UPDATE disputes
+SET sla_breached_at = now()
+WHERE id IN (
+    SELECT d.id FROM disputes d
+    WHERE d.status IN ('open', 'under_review')
+        AND d.sla_breached_at IS NULL
+        AND d.due_at <= now()
+    ORDER BY d.due_at
+    LIMIT $1
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING id, transfer_id, username, reason, description, amount, currency, status, provisional_credit_transfer_id, resolution_transfer_id, assigned_to, resolution, resolved_by, due_at, sla_warned_at, sla_breached_at, resolved_at, updated_at, created_at
+`
+
+// Marks the undecided disputes past their due date that were not reported
+// yet.
+func (q *Queries) ClaimDisputeSLABreaches(ctx context.Context, pageLimit int32) ([]Dispute, error) {
+	rows, err := q.db.QueryContext(ctx, claimDisputeSLABreaches, pageLimit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Dispute{}
+	for rows.Next() {
+		var i Dispute
+		if err := rows.Scan(
+			&i.ID,
+			&i.TransferID,
+			&i.Username,
+			&i.Reason,
+			&i.Description,
+			&i.Amount,
+			&i.Currency,
+			&i.Status,
+			&i.ProvisionalCreditTransferID,
+			&i.ResolutionTransferID,
+			&i.AssignedTo,
+			&i.Resolution,
+			&i.ResolvedBy,
+			&i.DueAt,
+			&i.SlaWarnedAt,
+			&i.SlaBreachedAt,
+			&i.ResolvedAt,
+			&i.UpdatedAt,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const claimDisputeSLAWarnings = `-- name: ClaimDisputeSLAWarnings :many
+UPDATE disputes
+SET sla_warned_at = now()
+WHERE id IN (
+    SELECT d.id FROM disputes d
+    WHERE d.status IN ('open', 'under_review')
+        AND d.sla_warned_at IS NULL
+        AND d.due_at > now()
+        AND d.due_at <= $1
+    ORDER BY d.due_at
+    LIMIT $2
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING id, transfer_id, username, reason, description, amount, currency, status, provisional_credit_transfer_id, resolution_transfer_id, assigned_to, resolution, resolved_by, due_at, sla_warned_at, sla_breached_at, resolved_at, updated_at, created_at
+`
+
+type ClaimDisputeSLAWarningsParams struct {
+	WarnBefore time.Time `json:"warn_before"`
+	PageLimit  int32     `json:"page_limit"`
+}
+
+// Marks the undecided disputes due before warn_before, and not overdue yet,
+// that were not warned about. SKIP LOCKED lets several instances claim at once.
+func (q *Queries) ClaimDisputeSLAWarnings(ctx context.Context, arg ClaimDisputeSLAWarningsParams) ([]Dispute, error) {
+	rows, err := q.db.QueryContext(ctx, claimDisputeSLAWarnings, arg.WarnBefore, arg.PageLimit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Dispute{}
+	for rows.Next() {
+		var i Dispute
+		if err := rows.Scan(
+			&i.ID,
+			&i.TransferID,
+			&i.Username,
+			&i.Reason,
+			&i.Description,
+			&i.Amount,
+			&i.Currency,
+			&i.Status,
+			&i.ProvisionalCreditTransferID,
+			&i.ResolutionTransferID,
+			&i.AssignedTo,
+			&i.Resolution,
+			&i.ResolvedBy,
+			&i.DueAt,
+			&i.SlaWarnedAt,
+			&i.SlaBreachedAt,
+			&i.ResolvedAt,
+			&i.UpdatedAt,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const createDispute = `-- name: CreateDispute :one
+INSERT INTO disputes (
+    transfer_id,
+    username,
+    reason,
+    description,
+    amount,
+    currency,
+    due_at
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7
+) RETURNING id, transfer_id, username, reason, description, amount, currency, status, provisional_credit_transfer_id, resolution_transfer_id, assigned_to, resolution, resolved_by, due_at, sla_warned_at, sla_breached_at, resolved_at, updated_at, created_at
+`
+
+type CreateDisputeParams struct {
+	TransferID  int64     `json:"transfer_id"`
+	Username    string    `json:"username"`
+	Reason      string    `json:"reason"`
+	Description string    `json:"description"`
+	Amount      int64     `json:"amount"`
+	Currency    string    `json:"currency"`
+	DueAt       time.Time `json:"due_at"`
+}
+
+func (q *Queries) CreateDispute(ctx context.Context, arg CreateDisputeParams) (Dispute, error) {
+	row := q.db.QueryRowContext(ctx, createDispute,
+		arg.TransferID,
+		arg.Username,
+		arg.Reason,
+		arg.Description,
+		arg.Amount,
+		arg.Currency,
+		arg.DueAt,
+	)
+	var i Dispute
+	err := row.Scan(
+		&i.ID,
+		&i.TransferID,
+		&i.Username,
+		&i.Reason,
+		&i.Description,
+		&i.Amount,
+		&i.Currency,
+		&i.Status,
+		&i.ProvisionalCreditTransferID,
+		&i.ResolutionTransferID,
+		&i.AssignedTo,
+		&i.Resolution,
+		&i.ResolvedBy,
+		&i.DueAt,
+		&i.SlaWarnedAt,
+		&i.SlaBreachedAt,
+		&i.ResolvedAt,
+		&i.UpdatedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const createDisputeEvent = `-- name: CreateDisputeEvent :one
+INSERT INTO dispute_events (
+    dispute_id,
+    actor,
+    from_status,
+    to_status,
+    note
+) VALUES (
+    $1, $2, $3, $4, $5
+) RETURNING id, dispute_id, actor, from_status, to_status, note, created_at
+`
+
+type CreateDisputeEventParams struct {
+	DisputeID  int64  `json:"dispute_id"`
+	Actor      string `json:"actor"`
+	FromStatus string `json:"from_status"`
+	ToStatus   string `json:"to_status"`
+	Note       string `json:"note"`
+}
+
+func (q *Queries) CreateDisputeEvent(ctx context.Context, arg CreateDisputeEventParams) (DisputeEvent, error) {
+	row := q.db.QueryRowContext(ctx, createDisputeEvent,
+		arg.DisputeID,
+		arg.Actor,
+		arg.FromStatus,
+		arg.ToStatus,
+		arg.Note,
+	)
+	var i DisputeEvent
+	err := row.Scan(
+		&i.ID,
+		&i.DisputeID,
+		&i.Actor,
+		&i.FromStatus,
+		&i.ToStatus,
+		&i.Note,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const createDisputeEvidence = `-- name: CreateDisputeEvidence :one
+INSERT INTO dispute_evidence (
+    dispute_id,
+    submitted_by,
+    note,
+    file_name,
+    content_type,
+    size,
+    sha256,
+    storage_key
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8
+) RETURNING id, dispute_id, submitted_by, note, file_name, content_type, size, sha256, storage_key, created_at
+`
+
+type CreateDisputeEvidenceParams struct {
+	DisputeID   int64  `json:"dispute_id"`
+	SubmittedBy string `json:"submitted_by"`
+	Note        string `json:"note"`
+	FileName    string `json:"file_name"`
+	ContentType string `json:"content_type"`
+	Size        int64  `json:"size"`
+	Sha256      string `json:"sha256"`
+	StorageKey  string `json:"storage_key"`
+}
+
+func (q *Queries) CreateDisputeEvidence(ctx context.Context, arg CreateDisputeEvidenceParams) (DisputeEvidence, error) {
+	row := q.db.QueryRowContext(ctx, createDisputeEvidence,
+		arg.DisputeID,
+		arg.SubmittedBy,
+		arg.Note,
+		arg.FileName,
+		arg.ContentType,
+		arg.Size,
+		arg.Sha256,
+		arg.StorageKey,
+	)
+	var i DisputeEvidence
+	err := row.Scan(
+		&i.ID,
+		&i.DisputeID,
+		&i.SubmittedBy,
+		&i.Note,
+		&i.FileName,
+		&i.ContentType,
+		&i.Size,
+		&i.Sha256,
+		&i.StorageKey,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getDispute = `-- name: GetDispute :one
+SELECT id, transfer_id, username, reason, description, amount, currency, status, provisional_credit_transfer_id, resolution_transfer_id, assigned_to, resolution, resolved_by, due_at, sla_warned_at, sla_breached_at, resolved_at, updated_at, created_at FROM disputes
+WHERE id = $1 LIMIT 1
+`
+
+func (q *Queries) GetDispute(ctx context.Context, id int64) (Dispute, error) {
+	row := q.db.QueryRowContext(ctx, getDispute, id)
+	var i Dispute
+	err := row.Scan(
+		&i.ID,
+		&i.TransferID,
+		&i.Username,
+		&i.Reason,
+		&i.Description,
+		&i.Amount,
+		&i.Currency,
+		&i.Status,
+		&i.ProvisionalCreditTransferID,
+		&i.ResolutionTransferID,
+		&i.AssignedTo,
+		&i.Resolution,
+		&i.ResolvedBy,
+		&i.DueAt,
+		&i.SlaWarnedAt,
+		&i.SlaBreachedAt,
+		&i.ResolvedAt,
+		&i.UpdatedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getDisputeEvidence = `-- name: GetDisputeEvidence :one
+SELECT id, dispute_id, submitted_by, note, file_name, content_type, size, sha256, storage_key, created_at FROM dispute_evidence
+WHERE id = $1 AND dispute_id = $2
+LIMIT 1
+`
+
+type GetDisputeEvidenceParams struct {
+	ID        int64 `json:"id"`
+	DisputeID int64 `json:"dispute_id"`
+}
+
+func (q *Queries) GetDisputeEvidence(ctx context.Context, arg GetDisputeEvidenceParams) (DisputeEvidence, error) {
+	row := q.db.QueryRowContext(ctx, getDisputeEvidence, arg.ID, arg.DisputeID)
+	var i DisputeEvidence
+	err := row.Scan(
+		&i.ID,
+		&i.DisputeID,
+		&i.SubmittedBy,
+		&i.Note,
+		&i.FileName,
+		&i.ContentType,
+		&i.Size,
+		&i.Sha256,
+		&i.StorageKey,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getDisputeForUpdate = `-- name: GetDisputeForUpdate :one
+SELECT id, transfer_id, username, reason, description, amount, currency, status, provisional_credit_transfer_id, resolution_transfer_id, assigned_to, resolution, resolved_by, due_at, sla_warned_at, sla_breached_at, resolved_at, updated_at, created_at FROM disputes
+WHERE id = $1 LIMIT 1
+FOR NO KEY UPDATE
+`
+
+func (q *Queries) GetDisputeForUpdate(ctx context.Context, id int64) (Dispute, error) {
+	row := q.db.QueryRowContext(ctx, getDisputeForUpdate, id)
+	var i Dispute
+	err := row.Scan(
+		&i.ID,
+		&i.TransferID,
+		&i.Username,
+		&i.Reason,
+		&i.Description,
+		&i.Amount,
+		&i.Currency,
+		&i.Status,
+		&i.ProvisionalCreditTransferID,
+		&i.ResolutionTransferID,
+		&i.AssignedTo,
+		&i.Resolution,
+		&i.ResolvedBy,
+		&i.DueAt,
+		&i.SlaWarnedAt,
+		&i.SlaBreachedAt,
+		&i.ResolvedAt,
+		&i.UpdatedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const listDisputeEvents = `-- name: ListDisputeEvents :many
+SELECT id, dispute_id, actor, from_status, to_status, note, created_at FROM dispute_events
+WHERE dispute_id = $1
+ORDER BY id
+`
+
+func (q *Queries) ListDisputeEvents(ctx context.Context, disputeID int64) ([]DisputeEvent, error) {
+	rows, err := q.db.QueryContext(ctx, listDisputeEvents, disputeID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []DisputeEvent{}
+	for rows.Next() {
+		var i DisputeEvent
+		if err := rows.Scan(
+			&i.ID,
+			&i.DisputeID,
+			&i.Actor,
+			&i.FromStatus,
+			&i.ToStatus,
+			&i.Note,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listDisputeEvidence = `-- name: ListDisputeEvidence :many
+SELECT id, dispute_id, submitted_by, note, file_name, content_type, size, sha256, storage_key, created_at FROM dispute_evidence
+WHERE dispute_id = $1
+ORDER BY id
+`
+
+func (q *Queries) ListDisputeEvidence(ctx context.Context, disputeID int64) ([]DisputeEvidence, error) {
+	rows, err := q.db.QueryContext(ctx, listDisputeEvidence, disputeID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []DisputeEvidence{}
+	for rows.Next() {
+		var i DisputeEvidence
+		if err := rows.Scan(
+			&i.ID,
+			&i.DisputeID,
+			&i.SubmittedBy,
+			&i.Note,
+			&i.FileName,
+			&i.ContentType,
+			&i.Size,
+			&i.Sha256,
+			&i.StorageKey,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listDisputes = `-- name: ListDisputes :many
+SELECT id, transfer_id, username, reason, description, amount, currency, status, provisional_credit_transfer_id, resolution_transfer_id, assigned_to, resolution, resolved_by, due_at, sla_warned_at, sla_breached_at, resolved_at, updated_at, created_at FROM disputes
+WHERE id > $1
+    AND ($2::varchar IS NULL OR status = $2)
+    AND ($3::varchar IS NULL OR assigned_to = $3)
+ORDER BY id
+LIMIT $4
+`
+
+type ListDisputesParams struct {
+	AfterID    int64          `json:"after_id"`
+	Status     sql.NullString `json:"status"`
+	AssignedTo sql.NullString `json:"assigned_to"`
+	PageLimit  int32          `json:"page_limit"`
+}
+
+// The support queue: disputes oldest first, optionally with a status or
+// assignee.
+func (q *Queries) ListDisputes(ctx context.Context, arg ListDisputesParams) ([]Dispute, error) {
+	rows, err := q.db.QueryContext(ctx, listDisputes,
+		arg.AfterID,
+		arg.Status,
+		arg.AssignedTo,
+		arg.PageLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Dispute{}
+	for rows.Next() {
+		var i Dispute
+		if err := rows.Scan(
+			&i.ID,
+			&i.TransferID,
+			&i.Username,
+			&i.Reason,
+			&i.Description,
+			&i.Amount,
+			&i.Currency,
+			&i.Status,
+			&i.ProvisionalCreditTransferID,
+			&i.ResolutionTransferID,
+			&i.AssignedTo,
+			&i.Resolution,
+			&i.ResolvedBy,
+			&i.DueAt,
+			&i.SlaWarnedAt,
+			&i.SlaBreachedAt,
+			&i.ResolvedAt,
+			&i.UpdatedAt,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listUserDisputes = `-- name: ListUserDisputes :many
+SELECT id, transfer_id, username, reason, description, amount, currency, status, provisional_credit_transfer_id, resolution_transfer_id, assigned_to, resolution, resolved_by, due_at, sla_warned_at, sla_breached_at, resolved_at, updated_at, created_at FROM disputes
+WHERE username = $1
+ORDER BY id DESC
+`
+
+func (q *Queries) ListUserDisputes(ctx context.Context, username string) ([]Dispute, error) {
+	rows, err := q.db.QueryContext(ctx, listUserDisputes, username)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Dispute{}
+	for rows.Next() {
+		var i Dispute
+		if err := rows.Scan(
+			&i.ID,
+			&i.TransferID,
+			&i.Username,
+			&i.Reason,
+			&i.Description,
+			&i.Amount,
+			&i.Currency,
+			&i.Status,
+			&i.ProvisionalCreditTransferID,
+			&i.ResolutionTransferID,
+			&i.AssignedTo,
+			&i.Resolution,
+			&i.ResolvedBy,
+			&i.DueAt,
+			&i.SlaWarnedAt,
+			&i.SlaBreachedAt,
+			&i.ResolvedAt,
+			&i.UpdatedAt,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listUsernamesByRole = `-- name: ListUsernamesByRole :many
+SELECT username FROM users
+WHERE role = $1
+ORDER BY username
+`
+
+func (q *Queries) ListUsernamesByRole(ctx context.Context, role string) ([]string, error) {
+	rows, err := q.db.QueryContext(ctx, listUsernamesByRole, role)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []string{}
+	for rows.Next() {
+		var username string
+		if err := rows.Scan(&username); err != nil {
+			return nil, err
+		}
+		items = append(items, username)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const updateDispute = `-- name: UpdateDispute :one
+UPDATE disputes
+SET status = $1,
+    assigned_to = COALESCE($2, assigned_to),
+    resolution = COALESCE($3, resolution),
+    resolved_by = COALESCE($4, resolved_by),
+    resolved_at = COALESCE($5, resolved_at),
+    provisional_credit_transfer_id = COALESCE($6, provisional_credit_transfer_id),
+    resolution_transfer_id = COALESCE($7, resolution_transfer_id),
+    updated_at = now()
+WHERE id = $8
+RETURNING id, transfer_id, username, reason, description, amount, currency, status, provisional_credit_transfer_id, resolution_transfer_id, assigned_to, resolution, resolved_by, due_at, sla_warned_at, sla_breached_at, resolved_at, updated_at, created_at
+`
+
+type UpdateDisputeParams struct {
+	Status                      string         `json:"status"`
+	AssignedTo                  sql.NullString `json:"assigned_to"`
+	Resolution                  sql.NullString `json:"resolution"`
+	ResolvedBy                  sql.NullString `json:"resolved_by"`
+	ResolvedAt                  sql.NullTime   `json:"resolved_at"`
+	ProvisionalCreditTransferID sql.NullInt64  `json:"provisional_credit_transfer_id"`
+	ResolutionTransferID        sql.NullInt64  `json:"resolution_transfer_id"`
+	ID                          int64          `json:"id"`
+}
+
+// Sets the status of a dispute and the fields given.
+func (q *Queries) UpdateDispute(ctx context.Context, arg UpdateDisputeParams) (Dispute, error) {
+	row := q.db.QueryRowContext(ctx, updateDispute,
+		arg.Status,
+		arg.AssignedTo,
+		arg.Resolution,
+		arg.ResolvedBy,
+		arg.ResolvedAt,
+		arg.ProvisionalCreditTransferID,
+		arg.ResolutionTransferID,
+		arg.ID,
+	)
+	var i Dispute
+	err := row.Scan(
+		&i.ID,
+		&i.TransferID,
+		&i.Username,
+		&i.Reason,
+		&i.Description,
+		&i.Amount,
+		&i.Currency,
+		&i.Status,
+		&i.ProvisionalCreditTransferID,
+		&i.ResolutionTransferID,
+		&i.AssignedTo,
+		&i.Resolution,
+		&i.ResolvedBy,
+		&i.DueAt,
+		&i.SlaWarnedAt,
+		&i.SlaBreachedAt,
+		&i.ResolvedAt,
+		&i.UpdatedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
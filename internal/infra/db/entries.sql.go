@@ -7,33 +7,57 @@ package db
 
 import (
 	"context"
+	"database/sql"
 )
 
 const createEntry = `-- name: CreateEntry :one
-INSERT INTO entries (account_id, amount) 
-VALUES ($1, $2)
-RETURNING id, account_id, amount, created_at
+INSERT INTO entries (account_id, amount, transfer_id, description, external_reference, merchant_category, category, business_date)
+VALUES ($1, $2, $3, $4, $5, $6, $7,
+    COALESCE($8::date, (SELECT d.business_date FROM business_days d WHERE d.status = 'open')))
+RETURNING id, account_id, amount, created_at, transfer_id, description, external_reference, merchant_category, category, business_date
 `
 
 type CreateEntryParams struct {
-	AccountID int64 `json:"account_id"`
-	Amount    int64 `json:"amount"`
+	AccountID         int64        `json:"account_id"`
+	Amount            int64        `json:"amount"`
+	TransferID        *int64       `json:"transfer_id"`
+	Description       string       `json:"description"`
+	ExternalReference string       `json:"external_reference"`
+	MerchantCategory  string       `json:"merchant_category"`
+	Category          string       `json:"category"`
+	BusinessDate      sql.NullTime `json:"business_date"`
 }
 
+// Posts to business_date, or to the open business day when it is null.
 func (q *Queries) CreateEntry(ctx context.Context, arg CreateEntryParams) (Entry, error) {
-	row := q.db.QueryRowContext(ctx, createEntry, arg.AccountID, arg.Amount)
+	row := q.db.QueryRowContext(ctx, createEntry,
+		arg.AccountID,
+		arg.Amount,
+		arg.TransferID,
+		arg.Description,
+		arg.ExternalReference,
+		arg.MerchantCategory,
+		arg.Category,
+		arg.BusinessDate,
+	)
 	var i Entry
 	err := row.Scan(
 		&i.ID,
 		&i.AccountID,
 		&i.Amount,
 		&i.CreatedAt,
+		&i.TransferID,
+		&i.Description,
+		&i.ExternalReference,
+		&i.MerchantCategory,
+		&i.Category,
+		&i.BusinessDate,
 	)
 	return i, err
 }
 
 const getEntry = `-- name: GetEntry :one
-SELECT id, account_id, amount, created_at FROM entries WHERE id = $1 LIMIT 1
+SELECT id, account_id, amount, created_at, transfer_id, description, external_reference, merchant_category, category, business_date FROM entries WHERE id = $1 LIMIT 1
 `
 
 func (q *Queries) GetEntry(ctx context.Context, id int64) (Entry, error) {
@@ -44,12 +68,204 @@ func (q *Queries) GetEntry(ctx context.Context, id int64) (Entry, error) {
 		&i.AccountID,
 		&i.Amount,
 		&i.CreatedAt,
+		&i.TransferID,
+		&i.Description,
+		&i.ExternalReference,
+		&i.MerchantCategory,
+		&i.Category,
+		&i.BusinessDate,
 	)
 	return i, err
 }
 
+const listEntriesAsc = `-- name: ListEntriesAsc :many
+SELECT e.id, e.account_id, e.amount, e.created_at, e.transfer_id, e.description, e.external_reference, e.merchant_category, e.category, e.business_date,
+    COALESCE(CASE WHEN t.from_account_id = e.account_id THEN t.to_account_id ELSE t.from_account_id END, 0)::bigint AS counterparty_account_id
+FROM entries e
+LEFT JOIN transfers t ON t.id = e.transfer_id
+WHERE e.account_id = $1
+    AND ($2::timestamptz IS NULL OR e.created_at >= $2::timestamptz)
+    AND ($3::timestamptz IS NULL OR e.created_at < $3::timestamptz)
+    AND ($4::bigint IS NULL OR abs(e.amount) >= $4::bigint)
+    AND ($5::bigint IS NULL OR abs(e.amount) <= $5::bigint)
+    AND ($6::text IS NULL
+        OR ($6::text = 'credit' AND e.amount > 0)
+        OR ($6::text = 'debit' AND e.amount < 0))
+    AND ($7::bigint IS NULL
+        OR (t.from_account_id = e.account_id AND t.to_account_id = $7::bigint)
+        OR (t.to_account_id = e.account_id AND t.from_account_id = $7::bigint))
+    AND ($8::varchar IS NULL OR e.category = $8::varchar)
+    AND ($9::text IS NULL
+        OR to_tsvector('simple', e.description || ' ' || e.external_reference) @@ websearch_to_tsquery('simple', $9::text))
+    AND ($10::timestamptz IS NULL
+        OR (e.created_at, e.id) > ($10::timestamptz, $11::bigint))
+ORDER BY e.created_at ASC, e.id ASC
+LIMIT $12
+`
+
+type ListEntriesAscParams struct {
+	AccountID             int64          `json:"account_id"`
+	CreatedFrom           sql.NullTime   `json:"created_from"`
+	CreatedTo             sql.NullTime   `json:"created_to"`
+	MinAmount             sql.NullInt64  `json:"min_amount"`
+	MaxAmount             sql.NullInt64  `json:"max_amount"`
+	Direction             sql.NullString `json:"direction"`
+	CounterpartyAccountID sql.NullInt64  `json:"counterparty_account_id"`
+	Category              sql.NullString `json:"category"`
+	Query                 sql.NullString `json:"query"`
+	AfterCreatedAt        sql.NullTime   `json:"after_created_at"`
+	AfterID               sql.NullInt64  `json:"after_id"`
+	PageLimit             int32          `json:"page_limit"`
+}
+
+type ListEntriesAscRow struct {
+	Entry                 Entry `json:"entry"`
+	CounterpartyAccountID int64 `json:"counterparty_account_id"`
+}
+
+func (q *Queries) ListEntriesAsc(ctx context.Context, arg ListEntriesAscParams) ([]ListEntriesAscRow, error) {
+	rows, err := q.db.QueryContext(ctx, listEntriesAsc,
+		arg.AccountID,
+		arg.CreatedFrom,
+		arg.CreatedTo,
+		arg.MinAmount,
+		arg.MaxAmount,
+		arg.Direction,
+		arg.CounterpartyAccountID,
+		arg.Category,
+		arg.Query,
+		arg.AfterCreatedAt,
+		arg.AfterID,
+		arg.PageLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListEntriesAscRow{}
+	for rows.Next() {
+		var i ListEntriesAscRow
+		if err := rows.Scan(
+			&i.Entry.ID,
+			&i.Entry.AccountID,
+			&i.Entry.Amount,
+			&i.Entry.CreatedAt,
+			&i.Entry.TransferID,
+			&i.Entry.Description,
+			&i.Entry.ExternalReference,
+			&i.Entry.MerchantCategory,
+			&i.Entry.Category,
+			&i.Entry.BusinessDate,
+			&i.CounterpartyAccountID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listEntriesDesc = `-- name: ListEntriesDesc :many
+SELECT e.id, e.account_id, e.amount, e.created_at, e.transfer_id, e.description, e.external_reference, e.merchant_category, e.category, e.business_date,
+    COALESCE(CASE WHEN t.from_account_id = e.account_id THEN t.to_account_id ELSE t.from_account_id END, 0)::bigint AS counterparty_account_id
+FROM entries e
+LEFT JOIN transfers t ON t.id = e.transfer_id
+WHERE e.account_id = $1
+    AND ($2::timestamptz IS NULL OR e.created_at >= $2::timestamptz)
+    AND ($3::timestamptz IS NULL OR e.created_at < $3::timestamptz)
+    AND ($4::bigint IS NULL OR abs(e.amount) >= $4::bigint)
+    AND ($5::bigint IS NULL OR abs(e.amount) <= $5::bigint)
+    AND ($6::text IS NULL
+        OR ($6::text = 'credit' AND e.amount > 0)
+        OR ($6::text = 'debit' AND e.amount < 0))
+    AND ($7::bigint IS NULL
+        OR (t.from_account_id = e.account_id AND t.to_account_id = $7::bigint)
+        OR (t.to_account_id = e.account_id AND t.from_account_id = $7::bigint))
+    AND ($8::varchar IS NULL OR e.category = $8::varchar)
+    AND ($9::text IS NULL
+        OR to_tsvector('simple', e.description || ' ' || e.external_reference) @@ websearch_to_tsquery('simple', $9::text))
+    AND ($10::timestamptz IS NULL
+        OR (e.created_at, e.id) < ($10::timestamptz, $11::bigint))
+ORDER BY e.created_at DESC, e.id DESC
+LIMIT $12
+`
+
+type ListEntriesDescParams struct {
+	AccountID             int64          `json:"account_id"`
+	CreatedFrom           sql.NullTime   `json:"created_from"`
+	CreatedTo             sql.NullTime   `json:"created_to"`
+	MinAmount             sql.NullInt64  `json:"min_amount"`
+	MaxAmount             sql.NullInt64  `json:"max_amount"`
+	Direction             sql.NullString `json:"direction"`
+	CounterpartyAccountID sql.NullInt64  `json:"counterparty_account_id"`
+	Category              sql.NullString `json:"category"`
+	Query                 sql.NullString `json:"query"`
+	AfterCreatedAt        sql.NullTime   `json:"after_created_at"`
+	AfterID               sql.NullInt64  `json:"after_id"`
+	PageLimit             int32          `json:"page_limit"`
+}
+
+type ListEntriesDescRow struct {
+	Entry                 Entry `json:"entry"`
+	CounterpartyAccountID int64 `json:"counterparty_account_id"`
+}
+
+func (q *Queries) ListEntriesDesc(ctx context.Context, arg ListEntriesDescParams) ([]ListEntriesDescRow, error) {
+	rows, err := q.db.QueryContext(ctx, listEntriesDesc,
+		arg.AccountID,
+		arg.CreatedFrom,
+		arg.CreatedTo,
+		arg.MinAmount,
+		arg.MaxAmount,
+		arg.Direction,
+		arg.CounterpartyAccountID,
+		arg.Category,
+		arg.Query,
+		arg.AfterCreatedAt,
+		arg.AfterID,
+		arg.PageLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListEntriesDescRow{}
+	for rows.Next() {
+		var i ListEntriesDescRow
+		if err := rows.Scan(
+			&i.Entry.ID,
+			&i.Entry.AccountID,
+			&i.Entry.Amount,
+			&i.Entry.CreatedAt,
+			&i.Entry.TransferID,
+			&i.Entry.Description,
+			&i.Entry.ExternalReference,
+			&i.Entry.MerchantCategory,
+			&i.Entry.Category,
+			&i.Entry.BusinessDate,
+			&i.CounterpartyAccountID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listEntry = `-- name: ListEntry :many
-SELECT id, account_id, amount, created_at FROM entries
+SELECT id, account_id, amount, created_at, transfer_id, description, external_reference, merchant_category, category, business_date FROM entries
 WHERE account_id = $1
 ORDER BY id
 LIMIT $2 OFFSET $3
@@ -75,6 +291,12 @@ func (q *Queries) ListEntry(ctx context.Context, arg ListEntryParams) ([]Entry,
 			&i.AccountID,
 			&i.Amount,
 			&i.CreatedAt,
+			&i.TransferID,
+			&i.Description,
+			&i.ExternalReference,
+			&i.MerchantCategory,
+			&i.Category,
+			&i.BusinessDate,
 		); err != nil {
 			return nil, err
 		}
@@ -0,0 +1,350 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: eod.sql
+
+package db
+
+import (
+	"context"
+	"time"
+)
+
+const claimEODStep = `-- name: ClaimEODStep :one
+UPDATE eod_steps
+SET status = 'running',
+    attempts = attempts + 1,
+    error = '',
+    started_at = now(),
+    finished_at = NULL
+WHERE business_date = $1 AND name = $2
+    AND (status IN ('pending', 'failed')
+        OR (status = 'running' AND started_at < $3::timestamptz))
+RETURNING business_date, name, position, status, attempts, error, started_at, finished_at
+`
+
+type ClaimEODStepParams struct {
+	BusinessDate time.Time `json:"business_date"`
+	Name         string    `json:"name"`
+	StaleBefore  time.Time `json:"stale_before"`
+}
+
+// Claims a pending or failed step, or a running step whose worker started
+// it before stale_before, for the calling worker.
+func (q *Queries) ClaimEODStep(ctx context.Context, arg ClaimEODStepParams) (EodStep, error) {
+	row := q.db.QueryRowContext(ctx, claimEODStep, arg.BusinessDate, arg.Name, arg.StaleBefore)
+	var i EodStep
+	err := row.Scan(
+		&i.BusinessDate,
+		&i.Name,
+		&i.Position,
+		&i.Status,
+		&i.Attempts,
+		&i.Error,
+		&i.StartedAt,
+		&i.FinishedAt,
+	)
+	return i, err
+}
+
+const createBusinessDay = `-- name: CreateBusinessDay :one
+INSERT INTO business_days (business_date) VALUES ($1)
+RETURNING business_date, status, opened_at, closing_at, closed_at
+`
+
+func (q *Queries) CreateBusinessDay(ctx context.Context, businessDate time.Time) (BusinessDay, error) {
+	row := q.db.QueryRowContext(ctx, createBusinessDay, businessDate)
+	var i BusinessDay
+	err := row.Scan(
+		&i.BusinessDate,
+		&i.Status,
+		&i.OpenedAt,
+		&i.ClosingAt,
+		&i.ClosedAt,
+	)
+	return i, err
+}
+
+const createEODStep = `-- name: CreateEODStep :one
+INSERT INTO eod_steps (
+    business_date,
+    name,
+    position
+) VALUES (
+    $1, $2, $3
+) RETURNING business_date, name, position, status, attempts, error, started_at, finished_at
+`
+
+type CreateEODStepParams struct {
+	BusinessDate time.Time `json:"business_date"`
+	Name         string    `json:"name"`
+	Position     int32     `json:"position"`
+}
+
+func (q *Queries) CreateEODStep(ctx context.Context, arg CreateEODStepParams) (EodStep, error) {
+	row := q.db.QueryRowContext(ctx, createEODStep, arg.BusinessDate, arg.Name, arg.Position)
+	var i EodStep
+	err := row.Scan(
+		&i.BusinessDate,
+		&i.Name,
+		&i.Position,
+		&i.Status,
+		&i.Attempts,
+		&i.Error,
+		&i.StartedAt,
+		&i.FinishedAt,
+	)
+	return i, err
+}
+
+const finishClosingBusinessDay = `-- name: FinishClosingBusinessDay :one
+UPDATE business_days
+SET status = 'closed',
+    closed_at = now()
+WHERE business_days.business_date = $1 AND business_days.status = 'closing'
+    AND NOT EXISTS (
+        SELECT 1 FROM eod_steps s
+        WHERE s.business_date = $1 AND s.status <> 'succeeded')
+RETURNING business_date, status, opened_at, closing_at, closed_at
+`
+
+// Affects no row while a step of the day has not succeeded.
+func (q *Queries) FinishClosingBusinessDay(ctx context.Context, businessDate time.Time) (BusinessDay, error) {
+	row := q.db.QueryRowContext(ctx, finishClosingBusinessDay, businessDate)
+	var i BusinessDay
+	err := row.Scan(
+		&i.BusinessDate,
+		&i.Status,
+		&i.OpenedAt,
+		&i.ClosingAt,
+		&i.ClosedAt,
+	)
+	return i, err
+}
+
+const finishEODStep = `-- name: FinishEODStep :one
+UPDATE eod_steps
+SET status = $1,
+    error = $2,
+    finished_at = now()
+WHERE business_date = $3 AND name = $4
+    AND status = 'running'
+RETURNING business_date, name, position, status, attempts, error, started_at, finished_at
+`
+
+type FinishEODStepParams struct {
+	Status       string    `json:"status"`
+	Error        string    `json:"error"`
+	BusinessDate time.Time `json:"business_date"`
+	Name         string    `json:"name"`
+}
+
+func (q *Queries) FinishEODStep(ctx context.Context, arg FinishEODStepParams) (EodStep, error) {
+	row := q.db.QueryRowContext(ctx, finishEODStep,
+		arg.Status,
+		arg.Error,
+		arg.BusinessDate,
+		arg.Name,
+	)
+	var i EodStep
+	err := row.Scan(
+		&i.BusinessDate,
+		&i.Name,
+		&i.Position,
+		&i.Status,
+		&i.Attempts,
+		&i.Error,
+		&i.StartedAt,
+		&i.FinishedAt,
+	)
+	return i, err
+}
+
+const getBusinessDay = `-- name: GetBusinessDay :one
+SELECT business_date, status, opened_at, closing_at, closed_at FROM business_days
+WHERE business_date = $1
+LIMIT 1
+`
+
+func (q *Queries) GetBusinessDay(ctx context.Context, businessDate time.Time) (BusinessDay, error) {
+	row := q.db.QueryRowContext(ctx, getBusinessDay, businessDate)
+	var i BusinessDay
+	err := row.Scan(
+		&i.BusinessDate,
+		&i.Status,
+		&i.OpenedAt,
+		&i.ClosingAt,
+		&i.ClosedAt,
+	)
+	return i, err
+}
+
+const getClosingBusinessDay = `-- name: GetClosingBusinessDay :one
+SELECT business_date, status, opened_at, closing_at, closed_at FROM business_days
+WHERE status = 'closing'
+ORDER BY business_date
+LIMIT 1
+`
+
+func (q *Queries) GetClosingBusinessDay(ctx context.Context) (BusinessDay, error) {
+	row := q.db.QueryRowContext(ctx, getClosingBusinessDay)
+	var i BusinessDay
+	err := row.Scan(
+		&i.BusinessDate,
+		&i.Status,
+		&i.OpenedAt,
+		&i.ClosingAt,
+		&i.ClosedAt,
+	)
+	return i, err
+}
+
+const getDailyBalance = `-- name: GetDailyBalance :one
+SELECT account_id, business_date, balance, created_at FROM daily_balances
+WHERE account_id = $1 AND business_date = $2
+LIMIT 1
+`
+
+type GetDailyBalanceParams struct {
+	AccountID    int64     `json:"account_id"`
+	BusinessDate time.Time `json:"business_date"`
+}
+
+func (q *Queries) GetDailyBalance(ctx context.Context, arg GetDailyBalanceParams) (DailyBalance, error) {
+	row := q.db.QueryRowContext(ctx, getDailyBalance, arg.AccountID, arg.BusinessDate)
+	var i DailyBalance
+	err := row.Scan(
+		&i.AccountID,
+		&i.BusinessDate,
+		&i.Balance,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getOpenBusinessDay = `-- name: GetOpenBusinessDay :one
+SELECT business_date, status, opened_at, closing_at, closed_at FROM business_days
+WHERE status = 'open'
+LIMIT 1
+`
+
+func (q *Queries) GetOpenBusinessDay(ctx context.Context) (BusinessDay, error) {
+	row := q.db.QueryRowContext(ctx, getOpenBusinessDay)
+	var i BusinessDay
+	err := row.Scan(
+		&i.BusinessDate,
+		&i.Status,
+		&i.OpenedAt,
+		&i.ClosingAt,
+		&i.ClosedAt,
+	)
+	return i, err
+}
+
+const getOpenBusinessDayForShare = `-- name: GetOpenBusinessDayForShare :one
+SELECT business_date, status, opened_at, closing_at, closed_at FROM business_days
+WHERE status = 'open'
+LIMIT 1
+FOR SHARE
+`
+
+// Closing the day waits for the transactions holding this lock, so no
+// posting lands in a day after it started closing.
+func (q *Queries) GetOpenBusinessDayForShare(ctx context.Context) (BusinessDay, error) {
+	row := q.db.QueryRowContext(ctx, getOpenBusinessDayForShare)
+	var i BusinessDay
+	err := row.Scan(
+		&i.BusinessDate,
+		&i.Status,
+		&i.OpenedAt,
+		&i.ClosingAt,
+		&i.ClosedAt,
+	)
+	return i, err
+}
+
+const listEODSteps = `-- name: ListEODSteps :many
+SELECT business_date, name, position, status, attempts, error, started_at, finished_at FROM eod_steps
+WHERE business_date = $1
+ORDER BY position
+`
+
+func (q *Queries) ListEODSteps(ctx context.Context, businessDate time.Time) ([]EodStep, error) {
+	rows, err := q.db.QueryContext(ctx, listEODSteps, businessDate)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []EodStep{}
+	for rows.Next() {
+		var i EodStep
+		if err := rows.Scan(
+			&i.BusinessDate,
+			&i.Name,
+			&i.Position,
+			&i.Status,
+			&i.Attempts,
+			&i.Error,
+			&i.StartedAt,
+			&i.FinishedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const snapshotDailyBalances = `-- name: SnapshotDailyBalances :execrows
+INSERT INTO daily_balances (account_id, business_date, balance)
+SELECT
+    a.id,
+    d.business_date,
+    a.balance - COALESCE((
+        SELECT SUM(e.amount) FROM entries e
+        WHERE e.account_id = a.id AND e.business_date > d.business_date
+    ), 0)
+FROM accounts a
+JOIN business_days d ON d.business_date = $1
+WHERE a.created_at < d.closing_at
+ON CONFLICT (account_id, business_date) DO NOTHING
+`
+
+// Records the balance at the end of a closing business date of every
+// account opened before the day started closing: its current balance minus
+// every entry posted to a later day. Accounts already recorded are skipped,
+// so it may run again.
+func (q *Queries) SnapshotDailyBalances(ctx context.Context, businessDate time.Time) (int64, error) {
+	result, err := q.db.ExecContext(ctx, snapshotDailyBalances, businessDate)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const startClosingBusinessDay = `-- name: StartClosingBusinessDay :one
+UPDATE business_days
+SET status = 'closing',
+    closing_at = now()
+WHERE business_date = $1 AND status = 'open'
+RETURNING business_date, status, opened_at, closing_at, closed_at
+`
+
+func (q *Queries) StartClosingBusinessDay(ctx context.Context, businessDate time.Time) (BusinessDay, error) {
+	row := q.db.QueryRowContext(ctx, startClosingBusinessDay, businessDate)
+	var i BusinessDay
+	err := row.Scan(
+		&i.BusinessDate,
+		&i.Status,
+		&i.OpenedAt,
+		&i.ClosingAt,
+		&i.ClosedAt,
+	)
+	return i, err
+}
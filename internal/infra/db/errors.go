@@ -0,0 +1,92 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/NhutHuyDev/sgbank/internal/domain"
+	"github.com/NhutHuyDev/sgbank/internal/logging"
+	"github.com/lib/pq"
+)
+
+const (
+	foreignKeyViolation = "23503"
+	uniqueViolation     = "23505"
+	checkViolation      = "23514"
+)
+
+// constraintMessages describe the constraints a request can break in the
+// words clients see, which name neither the schema nor the driver.
+var constraintMessages = map[string]string{
+	"users_pkey":                                        "username is already taken",
+	"users_email_key":                                   "email is already registered",
+	"owner_currency_product_key":                        "an account of this product in this currency already exists",
+	"accounts_account_number_idx":                       "account number is already in use",
+	"accounts_owner_fkey":                               "owner does not exist",
+	"accounts_currency_fkey":                            "currency is not supported",
+	"accounts_product_fkey":                             "product does not exist",
+	"beneficiaries_owner_account_number_idx":            "the account is already a saved beneficiary",
+	"fee_schedules_currency_key":                        "the currency already has a fee schedule",
+	"fee_schedules_currency_fkey":                       "currency is not supported",
+	"fee_schedule_bands_schedule_id_up_to_idx":          "the fee schedule already has a band up to this amount",
+	"fee_schedule_bands_schedule_id_idx":                "the fee schedule already has an unbounded band",
+	"screening_whitelist_name_entry_id_idx":             "the name is already whitelisted for this entry",
+	"kyc_submissions_username_idx":                      "a verification submission is already pending",
+	"disputes_transfer_id_idx":                          "the transfer is already disputed",
+	"categorization_rules_counterparty_account_id_fkey": "counterparty account does not exist",
+}
+
+// constraintMessage describes constraint for clients, or returns fallback
+// for a constraint without a description.
+func constraintMessage(constraint string, fallback string) string {
+	if message, ok := constraintMessages[constraint]; ok {
+		return message
+	}
+	return fallback
+}
+
+// translateError converts driver errors into domain errors so callers never
+// need to inspect sql.ErrNoRows or pq.Error themselves. notFound is returned
+// when the query matched no row. A constraint violation is described by
+// constraintMessage and the driver error, which names the schema, is only
+// logged.
+func translateError(ctx context.Context, err error, notFound *domain.Error) error {
+	if err == nil {
+		return nil
+	}
+
+	var domainErr *domain.Error
+	if errors.As(err, &domainErr) {
+		return err
+	}
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return notFound
+	}
+
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		var translated error
+		switch string(pqErr.Code) {
+		case uniqueViolation:
+			translated = fmt.Errorf("%w: %s", domain.ErrDuplicate, constraintMessage(pqErr.Constraint, "conflicts with an existing resource"))
+		case foreignKeyViolation:
+			translated = fmt.Errorf("%w: %s", domain.ErrReferenceNotFound, constraintMessage(pqErr.Constraint, "refers to a resource that does not exist"))
+		case checkViolation:
+			translated = fmt.Errorf("%w: %s", domain.ErrInvalidArgument, constraintMessage(pqErr.Constraint, "a value is out of range"))
+		}
+
+		if translated != nil {
+			logging.FromContext(ctx).Info().Err(err).Str("constraint", pqErr.Constraint).Msg("constraint violated")
+			return translated
+		}
+	}
+
+	if IsRetryableError(err) {
+		return fmt.Errorf("%w: %w", domain.ErrTxConflict, err)
+	}
+
+	return err
+}
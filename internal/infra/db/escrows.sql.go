@@ -0,0 +1,352 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: escrows.sql
+
+package db
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+const createEscrow = `-- name: CreateEscrow :one
+INSERT INTO escrows (
+    payer,
+    payee,
+    from_account_id,
+    to_account_id,
+    escrow_account_id,
+    amount,
+    currency,
+    description,
+    release_at,
+    funding_transfer_id
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10
+) RETURNING id, payer, payee, from_account_id, to_account_id, escrow_account_id, amount, currency, description, status, release_at, funding_transfer_id, settlement_transfer_id, dispute_reason, disputed_at, note, settled_by, settled_at, updated_at, created_at
+`
+
+type CreateEscrowParams struct {
+	Payer             string    `json:"payer"`
+	Payee             string    `json:"payee"`
+	FromAccountID     int64     `json:"from_account_id"`
+	ToAccountID       int64     `json:"to_account_id"`
+	EscrowAccountID   int64     `json:"escrow_account_id"`
+	Amount            int64     `json:"amount"`
+	Currency          string    `json:"currency"`
+	Description       string    `json:"description"`
+	ReleaseAt         time.Time `json:"release_at"`
+	FundingTransferID int64     `json:"funding_transfer_id"`
+}
+
+func (q *Queries) CreateEscrow(ctx context.Context, arg CreateEscrowParams) (Escrow, error) {
+	row := q.db.QueryRowContext(ctx, createEscrow,
+		arg.Payer,
+		arg.Payee,
+		arg.FromAccountID,
+		arg.ToAccountID,
+		arg.EscrowAccountID,
+		arg.Amount,
+		arg.Currency,
+		arg.Description,
+		arg.ReleaseAt,
+		arg.FundingTransferID,
+	)
+	var i Escrow
+	err := row.Scan(
+		&i.ID,
+		&i.Payer,
+		&i.Payee,
+		&i.FromAccountID,
+		&i.ToAccountID,
+		&i.EscrowAccountID,
+		&i.Amount,
+		&i.Currency,
+		&i.Description,
+		&i.Status,
+		&i.ReleaseAt,
+		&i.FundingTransferID,
+		&i.SettlementTransferID,
+		&i.DisputeReason,
+		&i.DisputedAt,
+		&i.Note,
+		&i.SettledBy,
+		&i.SettledAt,
+		&i.UpdatedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getEscrow = `-- name: GetEscrow :one
+SELECT id, payer, payee, from_account_id, to_account_id, escrow_account_id, amount, currency, description, status, release_at, funding_transfer_id, settlement_transfer_id, dispute_reason, disputed_at, note, settled_by, settled_at, updated_at, created_at FROM escrows
+WHERE id = $1 LIMIT 1
+`
+
+func (q *Queries) GetEscrow(ctx context.Context, id int64) (Escrow, error) {
+	row := q.db.QueryRowContext(ctx, getEscrow, id)
+	var i Escrow
+	err := row.Scan(
+		&i.ID,
+		&i.Payer,
+		&i.Payee,
+		&i.FromAccountID,
+		&i.ToAccountID,
+		&i.EscrowAccountID,
+		&i.Amount,
+		&i.Currency,
+		&i.Description,
+		&i.Status,
+		&i.ReleaseAt,
+		&i.FundingTransferID,
+		&i.SettlementTransferID,
+		&i.DisputeReason,
+		&i.DisputedAt,
+		&i.Note,
+		&i.SettledBy,
+		&i.SettledAt,
+		&i.UpdatedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getEscrowForUpdate = `-- name: GetEscrowForUpdate :one
+SELECT id, payer, payee, from_account_id, to_account_id, escrow_account_id, amount, currency, description, status, release_at, funding_transfer_id, settlement_transfer_id, dispute_reason, disputed_at, note, settled_by, settled_at, updated_at, created_at FROM escrows
+WHERE id = $1 LIMIT 1
+FOR NO KEY UPDATE
+`
+
+func (q *Queries) GetEscrowForUpdate(ctx context.Context, id int64) (Escrow, error) {
+	row := q.db.QueryRowContext(ctx, getEscrowForUpdate, id)
+	var i Escrow
+	err := row.Scan(
+		&i.ID,
+		&i.Payer,
+		&i.Payee,
+		&i.FromAccountID,
+		&i.ToAccountID,
+		&i.EscrowAccountID,
+		&i.Amount,
+		&i.Currency,
+		&i.Description,
+		&i.Status,
+		&i.ReleaseAt,
+		&i.FundingTransferID,
+		&i.SettlementTransferID,
+		&i.DisputeReason,
+		&i.DisputedAt,
+		&i.Note,
+		&i.SettledBy,
+		&i.SettledAt,
+		&i.UpdatedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const listDueEscrowIDs = `-- name: ListDueEscrowIDs :many
+SELECT id FROM escrows
+WHERE status = 'held' AND release_at <= now()
+ORDER BY release_at
+LIMIT $1
+`
+
+// Held escrows past their release time, oldest first.
+func (q *Queries) ListDueEscrowIDs(ctx context.Context, pageLimit int32) ([]int64, error) {
+	rows, err := q.db.QueryContext(ctx, listDueEscrowIDs, pageLimit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []int64{}
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listEscrows = `-- name: ListEscrows :many
+SELECT id, payer, payee, from_account_id, to_account_id, escrow_account_id, amount, currency, description, status, release_at, funding_transfer_id, settlement_transfer_id, dispute_reason, disputed_at, note, settled_by, settled_at, updated_at, created_at FROM escrows
+WHERE id > $1
+    AND ($2::varchar IS NULL OR status = $2)
+ORDER BY id
+LIMIT $3
+`
+
+type ListEscrowsParams struct {
+	AfterID   int64          `json:"after_id"`
+	Status    sql.NullString `json:"status"`
+	PageLimit int32          `json:"page_limit"`
+}
+
+// Escrows oldest first, optionally with a status.
+func (q *Queries) ListEscrows(ctx context.Context, arg ListEscrowsParams) ([]Escrow, error) {
+	rows, err := q.db.QueryContext(ctx, listEscrows, arg.AfterID, arg.Status, arg.PageLimit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Escrow{}
+	for rows.Next() {
+		var i Escrow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Payer,
+			&i.Payee,
+			&i.FromAccountID,
+			&i.ToAccountID,
+			&i.EscrowAccountID,
+			&i.Amount,
+			&i.Currency,
+			&i.Description,
+			&i.Status,
+			&i.ReleaseAt,
+			&i.FundingTransferID,
+			&i.SettlementTransferID,
+			&i.DisputeReason,
+			&i.DisputedAt,
+			&i.Note,
+			&i.SettledBy,
+			&i.SettledAt,
+			&i.UpdatedAt,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listUserEscrows = `-- name: ListUserEscrows :many
+SELECT id, payer, payee, from_account_id, to_account_id, escrow_account_id, amount, currency, description, status, release_at, funding_transfer_id, settlement_transfer_id, dispute_reason, disputed_at, note, settled_by, settled_at, updated_at, created_at FROM escrows
+WHERE payer = $1 OR payee = $1
+ORDER BY id DESC
+`
+
+// Escrows the user pays or is paid by, newest first.
+func (q *Queries) ListUserEscrows(ctx context.Context, username string) ([]Escrow, error) {
+	rows, err := q.db.QueryContext(ctx, listUserEscrows, username)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Escrow{}
+	for rows.Next() {
+		var i Escrow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Payer,
+			&i.Payee,
+			&i.FromAccountID,
+			&i.ToAccountID,
+			&i.EscrowAccountID,
+			&i.Amount,
+			&i.Currency,
+			&i.Description,
+			&i.Status,
+			&i.ReleaseAt,
+			&i.FundingTransferID,
+			&i.SettlementTransferID,
+			&i.DisputeReason,
+			&i.DisputedAt,
+			&i.Note,
+			&i.SettledBy,
+			&i.SettledAt,
+			&i.UpdatedAt,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const updateEscrow = `-- name: UpdateEscrow :one
+UPDATE escrows
+SET status = $1,
+    settlement_transfer_id = COALESCE($2, settlement_transfer_id),
+    dispute_reason = COALESCE($3, dispute_reason),
+    disputed_at = COALESCE($4, disputed_at),
+    note = COALESCE($5, note),
+    settled_by = COALESCE($6, settled_by),
+    settled_at = COALESCE($7, settled_at),
+    updated_at = now()
+WHERE id = $8
+RETURNING id, payer, payee, from_account_id, to_account_id, escrow_account_id, amount, currency, description, status, release_at, funding_transfer_id, settlement_transfer_id, dispute_reason, disputed_at, note, settled_by, settled_at, updated_at, created_at
+`
+
+type UpdateEscrowParams struct {
+	Status               string         `json:"status"`
+	SettlementTransferID sql.NullInt64  `json:"settlement_transfer_id"`
+	DisputeReason        sql.NullString `json:"dispute_reason"`
+	DisputedAt           sql.NullTime   `json:"disputed_at"`
+	Note                 sql.NullString `json:"note"`
+	SettledBy            sql.NullString `json:"settled_by"`
+	SettledAt            sql.NullTime   `json:"settled_at"`
+	ID                   int64          `json:"id"`
+}
+
+// Sets the status of an escrow and the fields given.
+func (q *Queries) UpdateEscrow(ctx context.Context, arg UpdateEscrowParams) (Escrow, error) {
+	row := q.db.QueryRowContext(ctx, updateEscrow,
+		arg.Status,
+		arg.SettlementTransferID,
+		arg.DisputeReason,
+		arg.DisputedAt,
+		arg.Note,
+		arg.SettledBy,
+		arg.SettledAt,
+		arg.ID,
+	)
+	var i Escrow
+	err := row.Scan(
+		&i.ID,
+		&i.Payer,
+		&i.Payee,
+		&i.FromAccountID,
+		&i.ToAccountID,
+		&i.EscrowAccountID,
+		&i.Amount,
+		&i.Currency,
+		&i.Description,
+		&i.Status,
+		&i.ReleaseAt,
+		&i.FundingTransferID,
+		&i.SettlementTransferID,
+		&i.DisputeReason,
+		&i.DisputedAt,
+		&i.Note,
+		&i.SettledBy,
+		&i.SettledAt,
+		&i.UpdatedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
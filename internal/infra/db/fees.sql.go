@@ -0,0 +1,133 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: fees.sql
+
+package db
+
+import (
+	"context"
+	"database/sql"
+)
+
+const createFeeSchedule = `-- name: CreateFeeSchedule :one
+INSERT INTO fee_schedules (currency, min_fee, max_fee)
+VALUES ($1, $2, $3)
+RETURNING id, currency, min_fee, max_fee, created_at
+`
+
+type CreateFeeScheduleParams struct {
+	Currency string        `json:"currency"`
+	MinFee   int64         `json:"min_fee"`
+	MaxFee   sql.NullInt64 `json:"max_fee"`
+}
+
+func (q *Queries) CreateFeeSchedule(ctx context.Context, arg CreateFeeScheduleParams) (FeeSchedule, error) {
+	row := q.db.QueryRowContext(ctx, createFeeSchedule, arg.Currency, arg.MinFee, arg.MaxFee)
+	var i FeeSchedule
+	err := row.Scan(
+		&i.ID,
+		&i.Currency,
+		&i.MinFee,
+		&i.MaxFee,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const createFeeScheduleBand = `-- name: CreateFeeScheduleBand :one
+INSERT INTO fee_schedule_bands (schedule_id, up_to, flat_fee, rate_ppm)
+VALUES ($1, $2, $3, $4)
+RETURNING id, schedule_id, up_to, flat_fee, rate_ppm
+`
+
+type CreateFeeScheduleBandParams struct {
+	ScheduleID int64         `json:"schedule_id"`
+	UpTo       sql.NullInt64 `json:"up_to"`
+	FlatFee    int64         `json:"flat_fee"`
+	RatePpm    int64         `json:"rate_ppm"`
+}
+
+func (q *Queries) CreateFeeScheduleBand(ctx context.Context, arg CreateFeeScheduleBandParams) (FeeScheduleBand, error) {
+	row := q.db.QueryRowContext(ctx, createFeeScheduleBand,
+		arg.ScheduleID,
+		arg.UpTo,
+		arg.FlatFee,
+		arg.RatePpm,
+	)
+	var i FeeScheduleBand
+	err := row.Scan(
+		&i.ID,
+		&i.ScheduleID,
+		&i.UpTo,
+		&i.FlatFee,
+		&i.RatePpm,
+	)
+	return i, err
+}
+
+const getFeeSchedule = `-- name: GetFeeSchedule :one
+SELECT id, currency, min_fee, max_fee, created_at FROM fee_schedules WHERE currency = $1 LIMIT 1
+`
+
+func (q *Queries) GetFeeSchedule(ctx context.Context, currency string) (FeeSchedule, error) {
+	row := q.db.QueryRowContext(ctx, getFeeSchedule, currency)
+	var i FeeSchedule
+	err := row.Scan(
+		&i.ID,
+		&i.Currency,
+		&i.MinFee,
+		&i.MaxFee,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getUserTier = `-- name: GetUserTier :one
+SELECT t.code, t.name, t.fees_waived FROM user_tiers t
+JOIN users u ON u.tier = t.code
+WHERE u.username = $1
+LIMIT 1
+`
+
+func (q *Queries) GetUserTier(ctx context.Context, username string) (UserTier, error) {
+	row := q.db.QueryRowContext(ctx, getUserTier, username)
+	var i UserTier
+	err := row.Scan(&i.Code, &i.Name, &i.FeesWaived)
+	return i, err
+}
+
+const listFeeScheduleBands = `-- name: ListFeeScheduleBands :many
+SELECT id, schedule_id, up_to, flat_fee, rate_ppm FROM fee_schedule_bands
+WHERE schedule_id = $1
+ORDER BY up_to ASC NULLS LAST
+`
+
+func (q *Queries) ListFeeScheduleBands(ctx context.Context, scheduleID int64) ([]FeeScheduleBand, error) {
+	rows, err := q.db.QueryContext(ctx, listFeeScheduleBands, scheduleID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []FeeScheduleBand{}
+	for rows.Next() {
+		var i FeeScheduleBand
+		if err := rows.Scan(
+			&i.ID,
+			&i.ScheduleID,
+			&i.UpTo,
+			&i.FlatFee,
+			&i.RatePpm,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -0,0 +1,361 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: fraud.sql
+
+package db
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+)
+
+const countRecentTransfers = `-- name: CountRecentTransfers :one
+SELECT
+    count(*) AS transfers,
+    count(*) FILTER (WHERE t.amount % NULLIF($1::bigint, 0) = 0) AS round_transfers
+FROM transfers t
+JOIN accounts a ON a.id = t.from_account_id
+WHERE a.owner = $2 AND t.created_at >= $3
+`
+
+type CountRecentTransfersParams struct {
+	RoundAmount  int64     `json:"round_amount"`
+	Owner        string    `json:"owner"`
+	CreatedSince time.Time `json:"created_since"`
+}
+
+type CountRecentTransfersRow struct {
+	Transfers      int64 `json:"transfers"`
+	RoundTransfers int64 `json:"round_transfers"`
+}
+
+// Counts the transfers from accounts of an owner created since a time, and
+// those of them whose amount is a multiple of round_amount.
+func (q *Queries) CountRecentTransfers(ctx context.Context, arg CountRecentTransfersParams) (CountRecentTransfersRow, error) {
+	row := q.db.QueryRowContext(ctx, countRecentTransfers, arg.RoundAmount, arg.Owner, arg.CreatedSince)
+	var i CountRecentTransfersRow
+	err := row.Scan(&i.Transfers, &i.RoundTransfers)
+	return i, err
+}
+
+const countSessionsFromClientIP = `-- name: CountSessionsFromClientIP :one
+SELECT
+    count(*) AS sessions,
+    count(*) FILTER (WHERE client_ip = $1) AS from_client_ip
+FROM sessions
+WHERE username = $2 AND created_at < $3
+`
+
+type CountSessionsFromClientIPParams struct {
+	ClientIp      string    `json:"client_ip"`
+	Username      string    `json:"username"`
+	CreatedBefore time.Time `json:"created_before"`
+}
+
+type CountSessionsFromClientIPRow struct {
+	Sessions     int64 `json:"sessions"`
+	FromClientIp int64 `json:"from_client_ip"`
+}
+
+// Counts the sessions of a user created before a time, and those of them
+// signed in from client_ip.
+func (q *Queries) CountSessionsFromClientIP(ctx context.Context, arg CountSessionsFromClientIPParams) (CountSessionsFromClientIPRow, error) {
+	row := q.db.QueryRowContext(ctx, countSessionsFromClientIP, arg.ClientIp, arg.Username, arg.CreatedBefore)
+	var i CountSessionsFromClientIPRow
+	err := row.Scan(&i.Sessions, &i.FromClientIp)
+	return i, err
+}
+
+const createFraudDecision = `-- name: CreateFraudDecision :one
+INSERT INTO fraud_decisions (
+    username,
+    from_account_id,
+    to_account_id,
+    amount,
+    currency,
+    client_ip,
+    score,
+    outcome,
+    hits,
+    transfer,
+    status,
+    kind,
+    payload
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13
+) RETURNING id, username, from_account_id, to_account_id, amount, currency, client_ip, score, outcome, hits, transfer, status, transfer_id, reviewed_by, review_note, reviewed_at, created_at, kind, payload
+`
+
+type CreateFraudDecisionParams struct {
+	Username      string          `json:"username"`
+	FromAccountID int64           `json:"from_account_id"`
+	ToAccountID   int64           `json:"to_account_id"`
+	Amount        int64           `json:"amount"`
+	Currency      string          `json:"currency"`
+	ClientIp      string          `json:"client_ip"`
+	Score         int32           `json:"score"`
+	Outcome       string          `json:"outcome"`
+	Hits          json.RawMessage `json:"hits"`
+	Transfer      json.RawMessage `json:"transfer"`
+	Status        string          `json:"status"`
+	Kind          string          `json:"kind"`
+	Payload       json.RawMessage `json:"payload"`
+}
+
+func (q *Queries) CreateFraudDecision(ctx context.Context, arg CreateFraudDecisionParams) (FraudDecision, error) {
+	row := q.db.QueryRowContext(ctx, createFraudDecision,
+		arg.Username,
+		arg.FromAccountID,
+		arg.ToAccountID,
+		arg.Amount,
+		arg.Currency,
+		arg.ClientIp,
+		arg.Score,
+		arg.Outcome,
+		arg.Hits,
+		arg.Transfer,
+		arg.Status,
+		arg.Kind,
+		arg.Payload,
+	)
+	var i FraudDecision
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.FromAccountID,
+		&i.ToAccountID,
+		&i.Amount,
+		&i.Currency,
+		&i.ClientIp,
+		&i.Score,
+		&i.Outcome,
+		&i.Hits,
+		&i.Transfer,
+		&i.Status,
+		&i.TransferID,
+		&i.ReviewedBy,
+		&i.ReviewNote,
+		&i.ReviewedAt,
+		&i.CreatedAt,
+		&i.Kind,
+		&i.Payload,
+	)
+	return i, err
+}
+
+const getFraudDecision = `-- name: GetFraudDecision :one
+SELECT id, username, from_account_id, to_account_id, amount, currency, client_ip, score, outcome, hits, transfer, status, transfer_id, reviewed_by, review_note, reviewed_at, created_at, kind, payload FROM fraud_decisions WHERE id = $1 LIMIT 1
+`
+
+func (q *Queries) GetFraudDecision(ctx context.Context, id int64) (FraudDecision, error) {
+	row := q.db.QueryRowContext(ctx, getFraudDecision, id)
+	var i FraudDecision
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.FromAccountID,
+		&i.ToAccountID,
+		&i.Amount,
+		&i.Currency,
+		&i.ClientIp,
+		&i.Score,
+		&i.Outcome,
+		&i.Hits,
+		&i.Transfer,
+		&i.Status,
+		&i.TransferID,
+		&i.ReviewedBy,
+		&i.ReviewNote,
+		&i.ReviewedAt,
+		&i.CreatedAt,
+		&i.Kind,
+		&i.Payload,
+	)
+	return i, err
+}
+
+const getFraudDecisionForUpdate = `-- name: GetFraudDecisionForUpdate :one
+SELECT id, username, from_account_id, to_account_id, amount, currency, client_ip, score, outcome, hits, transfer, status, transfer_id, reviewed_by, review_note, reviewed_at, created_at, kind, payload FROM fraud_decisions WHERE id = $1 LIMIT 1 FOR NO KEY UPDATE
+`
+
+func (q *Queries) GetFraudDecisionForUpdate(ctx context.Context, id int64) (FraudDecision, error) {
+	row := q.db.QueryRowContext(ctx, getFraudDecisionForUpdate, id)
+	var i FraudDecision
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.FromAccountID,
+		&i.ToAccountID,
+		&i.Amount,
+		&i.Currency,
+		&i.ClientIp,
+		&i.Score,
+		&i.Outcome,
+		&i.Hits,
+		&i.Transfer,
+		&i.Status,
+		&i.TransferID,
+		&i.ReviewedBy,
+		&i.ReviewNote,
+		&i.ReviewedAt,
+		&i.CreatedAt,
+		&i.Kind,
+		&i.Payload,
+	)
+	return i, err
+}
+
+const hasTransferredTo = `-- name: HasTransferredTo :one
+SELECT (
+    EXISTS (
+        SELECT 1 FROM transfers t
+        JOIN accounts a ON a.id = t.from_account_id
+        WHERE a.owner = $1 AND t.to_account_id = $2
+    )
+    OR EXISTS (
+        SELECT 1 FROM escrows e
+        WHERE e.payer = $1 AND e.to_account_id = $2
+    )
+)::bool
+`
+
+type HasTransferredToParams struct {
+	Owner       string `json:"owner"`
+	ToAccountID int64  `json:"to_account_id"`
+}
+
+// Reports whether an owner has sent money to an account before, directly
+// or through an escrow for it.
+func (q *Queries) HasTransferredTo(ctx context.Context, arg HasTransferredToParams) (bool, error) {
+	row := q.db.QueryRowContext(ctx, hasTransferredTo, arg.Owner, arg.ToAccountID)
+	var column_1 bool
+	err := row.Scan(&column_1)
+	return column_1, err
+}
+
+const listPendingFraudDecisions = `-- name: ListPendingFraudDecisions :many
+SELECT id, username, from_account_id, to_account_id, amount, currency, client_ip, score, outcome, hits, transfer, status, transfer_id, reviewed_by, review_note, reviewed_at, created_at, kind, payload FROM fraud_decisions
+WHERE status = 'pending' AND id > $1
+ORDER BY id
+LIMIT $2
+`
+
+type ListPendingFraudDecisionsParams struct {
+	AfterID   int64 `json:"after_id"`
+	PageLimit int32 `json:"page_limit"`
+}
+
+func (q *Queries) ListPendingFraudDecisions(ctx context.Context, arg ListPendingFraudDecisionsParams) ([]FraudDecision, error) {
+	rows, err := q.db.QueryContext(ctx, listPendingFraudDecisions, arg.AfterID, arg.PageLimit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []FraudDecision{}
+	for rows.Next() {
+		var i FraudDecision
+		if err := rows.Scan(
+			&i.ID,
+			&i.Username,
+			&i.FromAccountID,
+			&i.ToAccountID,
+			&i.Amount,
+			&i.Currency,
+			&i.ClientIp,
+			&i.Score,
+			&i.Outcome,
+			&i.Hits,
+			&i.Transfer,
+			&i.Status,
+			&i.TransferID,
+			&i.ReviewedBy,
+			&i.ReviewNote,
+			&i.ReviewedAt,
+			&i.CreatedAt,
+			&i.Kind,
+			&i.Payload,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const reviewFraudDecision = `-- name: ReviewFraudDecision :one
+UPDATE fraud_decisions
+SET status = $1,
+    transfer_id = $2,
+    reviewed_by = $3::varchar,
+    review_note = $4,
+    reviewed_at = now()
+WHERE id = $5 AND status = 'pending'
+RETURNING id, username, from_account_id, to_account_id, amount, currency, client_ip, score, outcome, hits, transfer, status, transfer_id, reviewed_by, review_note, reviewed_at, created_at, kind, payload
+`
+
+type ReviewFraudDecisionParams struct {
+	Status     string        `json:"status"`
+	TransferID sql.NullInt64 `json:"transfer_id"`
+	ReviewedBy string        `json:"reviewed_by"`
+	ReviewNote string        `json:"review_note"`
+	ID         int64         `json:"id"`
+}
+
+// Moves a pending decision to approved or rejected.
+func (q *Queries) ReviewFraudDecision(ctx context.Context, arg ReviewFraudDecisionParams) (FraudDecision, error) {
+	row := q.db.QueryRowContext(ctx, reviewFraudDecision,
+		arg.Status,
+		arg.TransferID,
+		arg.ReviewedBy,
+		arg.ReviewNote,
+		arg.ID,
+	)
+	var i FraudDecision
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.FromAccountID,
+		&i.ToAccountID,
+		&i.Amount,
+		&i.Currency,
+		&i.ClientIp,
+		&i.Score,
+		&i.Outcome,
+		&i.Hits,
+		&i.Transfer,
+		&i.Status,
+		&i.TransferID,
+		&i.ReviewedBy,
+		&i.ReviewNote,
+		&i.ReviewedAt,
+		&i.CreatedAt,
+		&i.Kind,
+		&i.Payload,
+	)
+	return i, err
+}
+
+const setFraudDecisionTransfer = `-- name: SetFraudDecisionTransfer :exec
+UPDATE fraud_decisions
+SET transfer_id = $1
+WHERE id = $2
+`
+
+type SetFraudDecisionTransferParams struct {
+	TransferID sql.NullInt64 `json:"transfer_id"`
+	ID         int64         `json:"id"`
+}
+
+func (q *Queries) SetFraudDecisionTransfer(ctx context.Context, arg SetFraudDecisionTransferParams) error {
+	_, err := q.db.ExecContext(ctx, setFraudDecisionTransfer, arg.TransferID, arg.ID)
+	return err
+}
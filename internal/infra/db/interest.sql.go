@@ -0,0 +1,276 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: interest.sql
+
+package db
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+const createInterestAccrual = `-- name: CreateInterestAccrual :execrows
+INSERT INTO interest_accruals (
+    account_id,
+    business_date,
+    closing_balance,
+    annual_rate_ppm,
+    day_count,
+    amount_micros
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+) ON CONFLICT (account_id, business_date) DO NOTHING
+`
+
+type CreateInterestAccrualParams struct {
+	AccountID      int64     `json:"account_id"`
+	BusinessDate   time.Time `json:"business_date"`
+	ClosingBalance int64     `json:"closing_balance"`
+	AnnualRatePpm  int64     `json:"annual_rate_ppm"`
+	DayCount       int32     `json:"day_count"`
+	AmountMicros   int64     `json:"amount_micros"`
+}
+
+// Affects no row when the account already accrued for the business date.
+func (q *Queries) CreateInterestAccrual(ctx context.Context, arg CreateInterestAccrualParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, createInterestAccrual,
+		arg.AccountID,
+		arg.BusinessDate,
+		arg.ClosingBalance,
+		arg.AnnualRatePpm,
+		arg.DayCount,
+		arg.AmountMicros,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const createInterestCapitalization = `-- name: CreateInterestCapitalization :one
+INSERT INTO interest_capitalizations (
+    account_id,
+    period,
+    accrued_micros,
+    amount,
+    carry_micros,
+    transfer_id
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+) RETURNING id, account_id, period, accrued_micros, amount, carry_micros, transfer_id, created_at
+`
+
+type CreateInterestCapitalizationParams struct {
+	AccountID     int64         `json:"account_id"`
+	Period        time.Time     `json:"period"`
+	AccruedMicros int64         `json:"accrued_micros"`
+	Amount        int64         `json:"amount"`
+	CarryMicros   int64         `json:"carry_micros"`
+	TransferID    sql.NullInt64 `json:"transfer_id"`
+}
+
+func (q *Queries) CreateInterestCapitalization(ctx context.Context, arg CreateInterestCapitalizationParams) (InterestCapitalization, error) {
+	row := q.db.QueryRowContext(ctx, createInterestCapitalization,
+		arg.AccountID,
+		arg.Period,
+		arg.AccruedMicros,
+		arg.Amount,
+		arg.CarryMicros,
+		arg.TransferID,
+	)
+	var i InterestCapitalization
+	err := row.Scan(
+		&i.ID,
+		&i.AccountID,
+		&i.Period,
+		&i.AccruedMicros,
+		&i.Amount,
+		&i.CarryMicros,
+		&i.TransferID,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getInterestCapitalization = `-- name: GetInterestCapitalization :one
+SELECT id, account_id, period, accrued_micros, amount, carry_micros, transfer_id, created_at FROM interest_capitalizations
+WHERE account_id = $1 AND period = $2
+LIMIT 1
+`
+
+type GetInterestCapitalizationParams struct {
+	AccountID int64     `json:"account_id"`
+	Period    time.Time `json:"period"`
+}
+
+func (q *Queries) GetInterestCapitalization(ctx context.Context, arg GetInterestCapitalizationParams) (InterestCapitalization, error) {
+	row := q.db.QueryRowContext(ctx, getInterestCapitalization, arg.AccountID, arg.Period)
+	var i InterestCapitalization
+	err := row.Scan(
+		&i.ID,
+		&i.AccountID,
+		&i.Period,
+		&i.AccruedMicros,
+		&i.Amount,
+		&i.CarryMicros,
+		&i.TransferID,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getLastInterestCapitalization = `-- name: GetLastInterestCapitalization :one
+SELECT id, account_id, period, accrued_micros, amount, carry_micros, transfer_id, created_at FROM interest_capitalizations
+WHERE account_id = $1
+ORDER BY period DESC
+LIMIT 1
+`
+
+func (q *Queries) GetLastInterestCapitalization(ctx context.Context, accountID int64) (InterestCapitalization, error) {
+	row := q.db.QueryRowContext(ctx, getLastInterestCapitalization, accountID)
+	var i InterestCapitalization
+	err := row.Scan(
+		&i.ID,
+		&i.AccountID,
+		&i.Period,
+		&i.AccruedMicros,
+		&i.Amount,
+		&i.CarryMicros,
+		&i.TransferID,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const listAccountsWithUncapitalizedInterest = `-- name: ListAccountsWithUncapitalizedInterest :many
+SELECT DISTINCT account_id FROM interest_accruals
+WHERE capitalization_id IS NULL AND business_date < $1
+ORDER BY account_id
+`
+
+func (q *Queries) ListAccountsWithUncapitalizedInterest(ctx context.Context, before time.Time) ([]int64, error) {
+	rows, err := q.db.QueryContext(ctx, listAccountsWithUncapitalizedInterest, before)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []int64{}
+	for rows.Next() {
+		var account_id int64
+		if err := rows.Scan(&account_id); err != nil {
+			return nil, err
+		}
+		items = append(items, account_id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listInterestBearingAccounts = `-- name: ListInterestBearingAccounts :many
+SELECT
+    a.id,
+    a.currency,
+    p.annual_rate_ppm,
+    p.overdraft_rate_ppm,
+    b.balance AS closing_balance
+FROM daily_balances b
+JOIN accounts a ON a.id = b.account_id
+JOIN account_products p ON p.code = a.product
+WHERE b.business_date = $1
+    AND (p.annual_rate_ppm > 0
+        OR (p.overdraft_rate_ppm > 0 AND (a.overdraft_limit > 0 OR a.overdrawn_since IS NOT NULL)))
+    AND a.id > $2
+ORDER BY a.id
+LIMIT $3
+`
+
+type ListInterestBearingAccountsParams struct {
+	BusinessDate time.Time `json:"business_date"`
+	AfterID      int64     `json:"after_id"`
+	PageLimit    int32     `json:"page_limit"`
+}
+
+type ListInterestBearingAccountsRow struct {
+	ID               int64  `json:"id"`
+	Currency         string `json:"currency"`
+	AnnualRatePpm    int64  `json:"annual_rate_ppm"`
+	OverdraftRatePpm int64  `json:"overdraft_rate_ppm"`
+	ClosingBalance   int64  `json:"closing_balance"`
+}
+
+// Returns a page of the accounts in the daily balances of business_date
+// whose product pays interest, or charges overdraft interest on an account
+// that may be overdrawn, with their balance at the end of that day.
+func (q *Queries) ListInterestBearingAccounts(ctx context.Context, arg ListInterestBearingAccountsParams) ([]ListInterestBearingAccountsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listInterestBearingAccounts, arg.BusinessDate, arg.AfterID, arg.PageLimit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListInterestBearingAccountsRow{}
+	for rows.Next() {
+		var i ListInterestBearingAccountsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Currency,
+			&i.AnnualRatePpm,
+			&i.OverdraftRatePpm,
+			&i.ClosingBalance,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const markInterestCapitalized = `-- name: MarkInterestCapitalized :exec
+UPDATE interest_accruals
+SET capitalization_id = $1
+WHERE account_id = $2
+    AND capitalization_id IS NULL
+    AND business_date < $3
+`
+
+type MarkInterestCapitalizedParams struct {
+	CapitalizationID sql.NullInt64 `json:"capitalization_id"`
+	AccountID        int64         `json:"account_id"`
+	Before           time.Time     `json:"before"`
+}
+
+func (q *Queries) MarkInterestCapitalized(ctx context.Context, arg MarkInterestCapitalizedParams) error {
+	_, err := q.db.ExecContext(ctx, markInterestCapitalized, arg.CapitalizationID, arg.AccountID, arg.Before)
+	return err
+}
+
+const sumUncapitalizedInterest = `-- name: SumUncapitalizedInterest :one
+SELECT COALESCE(SUM(amount_micros), 0)::bigint FROM interest_accruals
+WHERE account_id = $1
+    AND capitalization_id IS NULL
+    AND business_date < $2
+`
+
+type SumUncapitalizedInterestParams struct {
+	AccountID int64     `json:"account_id"`
+	Before    time.Time `json:"before"`
+}
+
+func (q *Queries) SumUncapitalizedInterest(ctx context.Context, arg SumUncapitalizedInterestParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, sumUncapitalizedInterest, arg.AccountID, arg.Before)
+	var column_1 int64
+	err := row.Scan(&column_1)
+	return column_1, err
+}
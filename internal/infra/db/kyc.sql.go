@@ -0,0 +1,551 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: kyc.sql
+
+package db
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+const countAccountsByOwner = `-- name: CountAccountsByOwner :one
+SELECT count(*) FROM accounts
+WHERE owner = $1
+`
+
+func (q *Queries) CountAccountsByOwner(ctx context.Context, owner string) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countAccountsByOwner, owner)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const createKycDocument = `-- name: CreateKycDocument :one
+INSERT INTO kyc_documents (
+    submission_id,
+    kind,
+    file_name,
+    content_type,
+    size,
+    sha256,
+    storage_key
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7
+) RETURNING id, submission_id, kind, file_name, content_type, size, sha256, storage_key, created_at
+`
+
+type CreateKycDocumentParams struct {
+	SubmissionID int64  `json:"submission_id"`
+	Kind         string `json:"kind"`
+	FileName     string `json:"file_name"`
+	ContentType  string `json:"content_type"`
+	Size         int64  `json:"size"`
+	Sha256       string `json:"sha256"`
+	StorageKey   string `json:"storage_key"`
+}
+
+func (q *Queries) CreateKycDocument(ctx context.Context, arg CreateKycDocumentParams) (KycDocument, error) {
+	row := q.db.QueryRowContext(ctx, createKycDocument,
+		arg.SubmissionID,
+		arg.Kind,
+		arg.FileName,
+		arg.ContentType,
+		arg.Size,
+		arg.Sha256,
+		arg.StorageKey,
+	)
+	var i KycDocument
+	err := row.Scan(
+		&i.ID,
+		&i.SubmissionID,
+		&i.Kind,
+		&i.FileName,
+		&i.ContentType,
+		&i.Size,
+		&i.Sha256,
+		&i.StorageKey,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const createKycSubmission = `-- name: CreateKycSubmission :one
+INSERT INTO kyc_submissions (
+    username,
+    level
+) VALUES (
+    $1, $2
+) RETURNING id, username, level, status, reason, reviewed_by, reviewed_at, created_at
+`
+
+type CreateKycSubmissionParams struct {
+	Username string `json:"username"`
+	Level    int32  `json:"level"`
+}
+
+func (q *Queries) CreateKycSubmission(ctx context.Context, arg CreateKycSubmissionParams) (KycSubmission, error) {
+	row := q.db.QueryRowContext(ctx, createKycSubmission, arg.Username, arg.Level)
+	var i KycSubmission
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.Level,
+		&i.Status,
+		&i.Reason,
+		&i.ReviewedBy,
+		&i.ReviewedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getKycDocument = `-- name: GetKycDocument :one
+SELECT id, submission_id, kind, file_name, content_type, size, sha256, storage_key, created_at FROM kyc_documents
+WHERE id = $1 AND submission_id = $2
+LIMIT 1
+`
+
+type GetKycDocumentParams struct {
+	ID           int64 `json:"id"`
+	SubmissionID int64 `json:"submission_id"`
+}
+
+func (q *Queries) GetKycDocument(ctx context.Context, arg GetKycDocumentParams) (KycDocument, error) {
+	row := q.db.QueryRowContext(ctx, getKycDocument, arg.ID, arg.SubmissionID)
+	var i KycDocument
+	err := row.Scan(
+		&i.ID,
+		&i.SubmissionID,
+		&i.Kind,
+		&i.FileName,
+		&i.ContentType,
+		&i.Size,
+		&i.Sha256,
+		&i.StorageKey,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getKycLevel = `-- name: GetKycLevel :one
+SELECT level, name, max_accounts FROM kyc_levels
+WHERE level = $1 LIMIT 1
+`
+
+func (q *Queries) GetKycLevel(ctx context.Context, level int32) (KycLevel, error) {
+	row := q.db.QueryRowContext(ctx, getKycLevel, level)
+	var i KycLevel
+	err := row.Scan(&i.Level, &i.Name, &i.MaxAccounts)
+	return i, err
+}
+
+const getKycSubmission = `-- name: GetKycSubmission :one
+SELECT id, username, level, status, reason, reviewed_by, reviewed_at, created_at FROM kyc_submissions
+WHERE id = $1 LIMIT 1
+`
+
+func (q *Queries) GetKycSubmission(ctx context.Context, id int64) (KycSubmission, error) {
+	row := q.db.QueryRowContext(ctx, getKycSubmission, id)
+	var i KycSubmission
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.Level,
+		&i.Status,
+		&i.Reason,
+		&i.ReviewedBy,
+		&i.ReviewedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getKycSubmissionForUpdate = `-- name: GetKycSubmissionForUpdate :one
+SELECT id, username, level, status, reason, reviewed_by, reviewed_at, created_at FROM kyc_submissions
+WHERE id = $1 LIMIT 1
+FOR NO KEY UPDATE
+`
+
+func (q *Queries) GetKycSubmissionForUpdate(ctx context.Context, id int64) (KycSubmission, error) {
+	row := q.db.QueryRowContext(ctx, getKycSubmissionForUpdate, id)
+	var i KycSubmission
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.Level,
+		&i.Status,
+		&i.Reason,
+		&i.ReviewedBy,
+		&i.ReviewedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getUserKycLimits = `-- name: GetUserKycLimits :one
+SELECT l.level, l.name, l.max_accounts
+FROM users u
+JOIN kyc_levels l ON l.level = u.kyc_level
+WHERE u.username = $1 LIMIT 1
+`
+
+func (q *Queries) GetUserKycLimits(ctx context.Context, username string) (KycLevel, error) {
+	row := q.db.QueryRowContext(ctx, getUserKycLimits, username)
+	var i KycLevel
+	err := row.Scan(&i.Level, &i.Name, &i.MaxAccounts)
+	return i, err
+}
+
+const getUserKycTransferLimits = `-- name: GetUserKycTransferLimits :one
+SELECT k.level, k.name, l.max_transfer_amount, l.daily_transfer_limit
+FROM users u
+JOIN kyc_levels k ON k.level = u.kyc_level
+LEFT JOIN kyc_level_limits l ON l.level = k.level AND l.currency = $1
+WHERE u.username = $2 LIMIT 1
+`
+
+type GetUserKycTransferLimitsParams struct {
+	Currency string `json:"currency"`
+	Username string `json:"username"`
+}
+
+type GetUserKycTransferLimitsRow struct {
+	Level              int32         `json:"level"`
+	Name               string        `json:"name"`
+	MaxTransferAmount  sql.NullInt64 `json:"max_transfer_amount"`
+	DailyTransferLimit sql.NullInt64 `json:"daily_transfer_limit"`
+}
+
+func (q *Queries) GetUserKycTransferLimits(ctx context.Context, arg GetUserKycTransferLimitsParams) (GetUserKycTransferLimitsRow, error) {
+	row := q.db.QueryRowContext(ctx, getUserKycTransferLimits, arg.Currency, arg.Username)
+	var i GetUserKycTransferLimitsRow
+	err := row.Scan(
+		&i.Level,
+		&i.Name,
+		&i.MaxTransferAmount,
+		&i.DailyTransferLimit,
+	)
+	return i, err
+}
+
+const listKycDocuments = `-- name: ListKycDocuments :many
+SELECT id, submission_id, kind, file_name, content_type, size, sha256, storage_key, created_at FROM kyc_documents
+WHERE submission_id = $1
+ORDER BY id
+`
+
+func (q *Queries) ListKycDocuments(ctx context.Context, submissionID int64) ([]KycDocument, error) {
+	rows, err := q.db.QueryContext(ctx, listKycDocuments, submissionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []KycDocument{}
+	for rows.Next() {
+		var i KycDocument
+		if err := rows.Scan(
+			&i.ID,
+			&i.SubmissionID,
+			&i.Kind,
+			&i.FileName,
+			&i.ContentType,
+			&i.Size,
+			&i.Sha256,
+			&i.StorageKey,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listKycLevelLimits = `-- name: ListKycLevelLimits :many
+SELECT level, currency, max_transfer_amount, daily_transfer_limit FROM kyc_level_limits
+ORDER BY level, currency
+`
+
+func (q *Queries) ListKycLevelLimits(ctx context.Context) ([]KycLevelLimit, error) {
+	rows, err := q.db.QueryContext(ctx, listKycLevelLimits)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []KycLevelLimit{}
+	for rows.Next() {
+		var i KycLevelLimit
+		if err := rows.Scan(
+			&i.Level,
+			&i.Currency,
+			&i.MaxTransferAmount,
+			&i.DailyTransferLimit,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listKycLevels = `-- name: ListKycLevels :many
+SELECT level, name, max_accounts FROM kyc_levels
+ORDER BY level
+`
+
+func (q *Queries) ListKycLevels(ctx context.Context) ([]KycLevel, error) {
+	rows, err := q.db.QueryContext(ctx, listKycLevels)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []KycLevel{}
+	for rows.Next() {
+		var i KycLevel
+		if err := rows.Scan(&i.Level, &i.Name, &i.MaxAccounts); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listPendingKycSubmissions = `-- name: ListPendingKycSubmissions :many
+SELECT id, username, level, status, reason, reviewed_by, reviewed_at, created_at FROM kyc_submissions
+WHERE status = 'pending' AND id > $1
+ORDER BY id
+LIMIT $2
+`
+
+type ListPendingKycSubmissionsParams struct {
+	AfterID   int64 `json:"after_id"`
+	PageLimit int32 `json:"page_limit"`
+}
+
+func (q *Queries) ListPendingKycSubmissions(ctx context.Context, arg ListPendingKycSubmissionsParams) ([]KycSubmission, error) {
+	rows, err := q.db.QueryContext(ctx, listPendingKycSubmissions, arg.AfterID, arg.PageLimit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []KycSubmission{}
+	for rows.Next() {
+		var i KycSubmission
+		if err := rows.Scan(
+			&i.ID,
+			&i.Username,
+			&i.Level,
+			&i.Status,
+			&i.Reason,
+			&i.ReviewedBy,
+			&i.ReviewedAt,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listUserKycSubmissions = `-- name: ListUserKycSubmissions :many
+SELECT id, username, level, status, reason, reviewed_by, reviewed_at, created_at FROM kyc_submissions
+WHERE username = $1
+ORDER BY id DESC
+`
+
+func (q *Queries) ListUserKycSubmissions(ctx context.Context, username string) ([]KycSubmission, error) {
+	rows, err := q.db.QueryContext(ctx, listUserKycSubmissions, username)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []KycSubmission{}
+	for rows.Next() {
+		var i KycSubmission
+		if err := rows.Scan(
+			&i.ID,
+			&i.Username,
+			&i.Level,
+			&i.Status,
+			&i.Reason,
+			&i.ReviewedBy,
+			&i.ReviewedAt,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const raiseUserKycLevel = `-- name: RaiseUserKycLevel :one
+UPDATE users
+SET kyc_level = GREATEST(kyc_level, $1)
+WHERE username = $2
+RETURNING username, hashed_password, full_name, email, password_changed_at, created_at, is_email_verified, tier, role, kyc_level
+`
+
+type RaiseUserKycLevelParams struct {
+	Level    int32  `json:"level"`
+	Username string `json:"username"`
+}
+
+// Raises the level of a user; a level is never lowered by an approval.
+func (q *Queries) RaiseUserKycLevel(ctx context.Context, arg RaiseUserKycLevelParams) (User, error) {
+	row := q.db.QueryRowContext(ctx, raiseUserKycLevel, arg.Level, arg.Username)
+	var i User
+	err := row.Scan(
+		&i.Username,
+		&i.HashedPassword,
+		&i.FullName,
+		&i.Email,
+		&i.PasswordChangedAt,
+		&i.CreatedAt,
+		&i.IsEmailVerified,
+		&i.Tier,
+		&i.Role,
+		&i.KycLevel,
+	)
+	return i, err
+}
+
+const reviewKycSubmission = `-- name: ReviewKycSubmission :one
+UPDATE kyc_submissions
+SET status = $1,
+    reason = $2,
+    reviewed_by = $3::varchar,
+    reviewed_at = now()
+WHERE id = $4 AND status = 'pending'
+RETURNING id, username, level, status, reason, reviewed_by, reviewed_at, created_at
+`
+
+type ReviewKycSubmissionParams struct {
+	Status     string `json:"status"`
+	Reason     string `json:"reason"`
+	ReviewedBy string `json:"reviewed_by"`
+	ID         int64  `json:"id"`
+}
+
+// Moves a pending submission to approved or rejected.
+func (q *Queries) ReviewKycSubmission(ctx context.Context, arg ReviewKycSubmissionParams) (KycSubmission, error) {
+	row := q.db.QueryRowContext(ctx, reviewKycSubmission,
+		arg.Status,
+		arg.Reason,
+		arg.ReviewedBy,
+		arg.ID,
+	)
+	var i KycSubmission
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.Level,
+		&i.Status,
+		&i.Reason,
+		&i.ReviewedBy,
+		&i.ReviewedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const sumTransfersFromOwnerSince = `-- name: SumTransfersFromOwnerSince :one
+SELECT COALESCE(sum(t.amount), 0)::bigint AS total
+FROM transfers t
+JOIN accounts fa ON fa.id = t.from_account_id
+WHERE fa.owner = $1
+    AND fa.currency = $2
+    AND t.kind = 'payment'
+    AND t.created_at >= $3
+`
+
+type SumTransfersFromOwnerSinceParams struct {
+	Owner    string    `json:"owner"`
+	Currency string    `json:"currency"`
+	Since    time.Time `json:"since"`
+}
+
+func (q *Queries) SumTransfersFromOwnerSince(ctx context.Context, arg SumTransfersFromOwnerSinceParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, sumTransfersFromOwnerSince, arg.Owner, arg.Currency, arg.Since)
+	var total int64
+	err := row.Scan(&total)
+	return total, err
+}
+
+const sumTransfersFromOwnerSinceByCurrency = `-- name: SumTransfersFromOwnerSinceByCurrency :many
+SELECT fa.currency, COALESCE(sum(t.amount), 0)::bigint AS total
+FROM transfers t
+JOIN accounts fa ON fa.id = t.from_account_id
+WHERE fa.owner = $1
+    AND t.kind = 'payment'
+    AND t.created_at >= $2
+GROUP BY fa.currency
+ORDER BY fa.currency
+`
+
+type SumTransfersFromOwnerSinceByCurrencyParams struct {
+	Owner string    `json:"owner"`
+	Since time.Time `json:"since"`
+}
+
+type SumTransfersFromOwnerSinceByCurrencyRow struct {
+	Currency string `json:"currency"`
+	Total    int64  `json:"total"`
+}
+
+func (q *Queries) SumTransfersFromOwnerSinceByCurrency(ctx context.Context, arg SumTransfersFromOwnerSinceByCurrencyParams) ([]SumTransfersFromOwnerSinceByCurrencyRow, error) {
+	rows, err := q.db.QueryContext(ctx, sumTransfersFromOwnerSinceByCurrency, arg.Owner, arg.Since)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []SumTransfersFromOwnerSinceByCurrencyRow{}
+	for rows.Next() {
+		var i SumTransfersFromOwnerSinceByCurrencyRow
+		if err := rows.Scan(&i.Currency, &i.Total); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -0,0 +1,113 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: ledger.sql
+
+package db
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+const listGLAccounts = `-- name: ListGLAccounts :many
+SELECT code, name, type, created_at FROM gl_accounts
+ORDER BY code
+`
+
+func (q *Queries) ListGLAccounts(ctx context.Context) ([]GlAccount, error) {
+	rows, err := q.db.QueryContext(ctx, listGLAccounts)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GlAccount{}
+	for rows.Next() {
+		var i GlAccount
+		if err := rows.Scan(
+			&i.Code,
+			&i.Name,
+			&i.Type,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const sumGLBalances = `-- name: SumGLBalances :many
+WITH balances AS (
+  SELECT e.account_id, SUM(e.amount)::bigint AS balance
+  FROM entries e
+  WHERE e.created_at < $1
+  GROUP BY e.account_id
+), unmatched AS (
+  SELECT a.currency, SUM(e.amount)::bigint AS balance
+  FROM entries e
+  JOIN accounts a ON a.id = e.account_id
+  WHERE e.created_at < $1 AND e.transfer_id IS NULL
+  GROUP BY a.currency
+)
+SELECT a.currency::varchar AS currency,
+  COALESCE(CASE WHEN b.balance < 0 THEN p.overdraft_gl_account END, p.gl_account)::varchar AS gl_account,
+  SUM(b.balance)::bigint AS balance
+FROM balances b
+JOIN accounts a ON a.id = b.account_id
+JOIN account_products p ON p.code = a.product
+WHERE $2::varchar IS NULL OR a.currency = $2
+GROUP BY 1, 2
+UNION ALL
+SELECT u.currency::varchar, $3::varchar, (-u.balance)::bigint
+FROM unmatched u
+WHERE u.balance <> 0 AND ($2::varchar IS NULL OR u.currency = $2)
+ORDER BY 1, 2
+`
+
+type SumGLBalancesParams struct {
+	Before          time.Time      `json:"before"`
+	Currency        sql.NullString `json:"currency"`
+	SuspenseAccount string         `json:"suspense_account"`
+}
+
+type SumGLBalancesRow struct {
+	Currency  string `json:"currency"`
+	GlAccount string `json:"gl_account"`
+	Balance   int64  `json:"balance"`
+}
+
+// Sums the entries created before the given time per currency and general
+// ledger account, positive for credits. Accounts with a negative balance are
+// reported in the overdraft account of their product. Entries that are not
+// part of a transfer have no offsetting entry, so they are offset in the
+// suspense account to keep the ledger balanced.
+func (q *Queries) SumGLBalances(ctx context.Context, arg SumGLBalancesParams) ([]SumGLBalancesRow, error) {
+	rows, err := q.db.QueryContext(ctx, sumGLBalances, arg.Before, arg.Currency, arg.SuspenseAccount)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []SumGLBalancesRow{}
+	for rows.Next() {
+		var i SumGLBalancesRow
+		if err := rows.Scan(&i.Currency, &i.GlAccount, &i.Balance); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
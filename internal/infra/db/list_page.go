@@ -0,0 +1,87 @@
+package db
+
+import (
+	"context"
+
+	"github.com/NhutHuyDev/sgbank/internal/domain"
+)
+
+// SortOrder is the direction a keyset-paginated list is walked in, by
+// (created_at, id).
+type SortOrder string
+
+const (
+	SortDesc SortOrder = "desc"
+	SortAsc  SortOrder = "asc"
+)
+
+// EntryPageFilter selects one page of an account's entries. It has the same
+// fields as the generated ListEntriesAsc/ListEntriesDesc params; AfterCreatedAt
+// and AfterID hold the keyset position of the last row of the previous page.
+type EntryPageFilter ListEntriesDescParams
+
+type EntryPageRow ListEntriesDescRow
+
+// TransferPageFilter selects one page of the transfers touching a user's
+// accounts, like EntryPageFilter does for entries.
+type TransferPageFilter ListTransfersDescParams
+
+type TransferPageRow ListTransfersDescRow
+
+// ListEntriesPage runs the ascending or descending variant of the entries
+// keyset query so Postgres can walk the (account_id, created_at, id) index in
+// either direction.
+func (store *StoreSQL) ListEntriesPage(ctx context.Context, filter EntryPageFilter, order SortOrder) ([]EntryPageRow, error) {
+	var page []EntryPageRow
+
+	if order == SortAsc {
+		rows, err := store.Queries.ListEntriesAsc(ctx, ListEntriesAscParams(filter))
+		if err != nil {
+			return nil, translateError(ctx, err, domain.ErrEntryNotFound)
+		}
+		for _, row := range rows {
+			page = append(page, EntryPageRow(row))
+		}
+	} else {
+		rows, err := store.Queries.ListEntriesDesc(ctx, ListEntriesDescParams(filter))
+		if err != nil {
+			return nil, translateError(ctx, err, domain.ErrEntryNotFound)
+		}
+		for _, row := range rows {
+			page = append(page, EntryPageRow(row))
+		}
+	}
+
+	if page == nil {
+		page = []EntryPageRow{}
+	}
+	return page, nil
+}
+
+// ListTransfersPage is the transfers counterpart of ListEntriesPage.
+func (store *StoreSQL) ListTransfersPage(ctx context.Context, filter TransferPageFilter, order SortOrder) ([]TransferPageRow, error) {
+	var page []TransferPageRow
+
+	if order == SortAsc {
+		rows, err := store.Queries.ListTransfersAsc(ctx, ListTransfersAscParams(filter))
+		if err != nil {
+			return nil, translateError(ctx, err, domain.ErrTransferNotFound)
+		}
+		for _, row := range rows {
+			page = append(page, TransferPageRow(row))
+		}
+	} else {
+		rows, err := store.Queries.ListTransfersDesc(ctx, ListTransfersDescParams(filter))
+		if err != nil {
+			return nil, translateError(ctx, err, domain.ErrTransferNotFound)
+		}
+		for _, row := range rows {
+			page = append(page, TransferPageRow(row))
+		}
+	}
+
+	if page == nil {
+		page = []TransferPageRow{}
+	}
+	return page, nil
+}
@@ -7,6 +7,7 @@ package mockdb
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	db "github.com/NhutHuyDev/sgbank/internal/infra/db"
 	gomock "github.com/golang/mock/gomock"
@@ -51,228 +52,2457 @@ func (mr *MockStoreMockRecorder) AddAccountBalance(arg0, arg1 interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddAccountBalance", reflect.TypeOf((*MockStore)(nil).AddAccountBalance), arg0, arg1)
 }
 
+// ApproveHeldTransferTx mocks base method.
+func (m *MockStore) ApproveHeldTransferTx(arg0 context.Context, arg1 db.ApproveHeldTransferTxParams) (db.ApproveHeldTransferTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApproveHeldTransferTx", arg0, arg1)
+	ret0, _ := ret[0].(db.ApproveHeldTransferTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ApproveHeldTransferTx indicates an expected call of ApproveHeldTransferTx.
+func (mr *MockStoreMockRecorder) ApproveHeldTransferTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApproveHeldTransferTx", reflect.TypeOf((*MockStore)(nil).ApproveHeldTransferTx), arg0, arg1)
+}
+
+// ApproveOverdraftTx mocks base method.
+func (m *MockStore) ApproveOverdraftTx(arg0 context.Context, arg1 db.ApproveOverdraftTxParams) (db.ApproveOverdraftTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApproveOverdraftTx", arg0, arg1)
+	ret0, _ := ret[0].(db.ApproveOverdraftTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ApproveOverdraftTx indicates an expected call of ApproveOverdraftTx.
+func (mr *MockStoreMockRecorder) ApproveOverdraftTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApproveOverdraftTx", reflect.TypeOf((*MockStore)(nil).ApproveOverdraftTx), arg0, arg1)
+}
+
+// CapitalizeInterestTx mocks base method.
+func (m *MockStore) CapitalizeInterestTx(arg0 context.Context, arg1 db.CapitalizeInterestTxParams) (db.CapitalizeInterestTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CapitalizeInterestTx", arg0, arg1)
+	ret0, _ := ret[0].(db.CapitalizeInterestTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CapitalizeInterestTx indicates an expected call of CapitalizeInterestTx.
+func (mr *MockStoreMockRecorder) CapitalizeInterestTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CapitalizeInterestTx", reflect.TypeOf((*MockStore)(nil).CapitalizeInterestTx), arg0, arg1)
+}
+
+// ClaimDisputeSLABreaches mocks base method.
+func (m *MockStore) ClaimDisputeSLABreaches(arg0 context.Context, arg1 int32) ([]db.Dispute, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimDisputeSLABreaches", arg0, arg1)
+	ret0, _ := ret[0].([]db.Dispute)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimDisputeSLABreaches indicates an expected call of ClaimDisputeSLABreaches.
+func (mr *MockStoreMockRecorder) ClaimDisputeSLABreaches(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimDisputeSLABreaches", reflect.TypeOf((*MockStore)(nil).ClaimDisputeSLABreaches), arg0, arg1)
+}
+
+// ClaimDisputeSLAWarnings mocks base method.
+func (m *MockStore) ClaimDisputeSLAWarnings(arg0 context.Context, arg1 db.ClaimDisputeSLAWarningsParams) ([]db.Dispute, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimDisputeSLAWarnings", arg0, arg1)
+	ret0, _ := ret[0].([]db.Dispute)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimDisputeSLAWarnings indicates an expected call of ClaimDisputeSLAWarnings.
+func (mr *MockStoreMockRecorder) ClaimDisputeSLAWarnings(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimDisputeSLAWarnings", reflect.TypeOf((*MockStore)(nil).ClaimDisputeSLAWarnings), arg0, arg1)
+}
+
+// ClaimEODStep mocks base method.
+func (m *MockStore) ClaimEODStep(arg0 context.Context, arg1 db.ClaimEODStepParams) (db.EodStep, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimEODStep", arg0, arg1)
+	ret0, _ := ret[0].(db.EodStep)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimEODStep indicates an expected call of ClaimEODStep.
+func (mr *MockStoreMockRecorder) ClaimEODStep(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimEODStep", reflect.TypeOf((*MockStore)(nil).ClaimEODStep), arg0, arg1)
+}
+
+// ClaimOverdraftEvents mocks base method.
+func (m *MockStore) ClaimOverdraftEvents(arg0 context.Context, arg1 int32) ([]db.ClaimOverdraftEventsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimOverdraftEvents", arg0, arg1)
+	ret0, _ := ret[0].([]db.ClaimOverdraftEventsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimOverdraftEvents indicates an expected call of ClaimOverdraftEvents.
+func (mr *MockStoreMockRecorder) ClaimOverdraftEvents(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimOverdraftEvents", reflect.TypeOf((*MockStore)(nil).ClaimOverdraftEvents), arg0, arg1)
+}
+
+// ClaimTransferBatch mocks base method.
+func (m *MockStore) ClaimTransferBatch(arg0 context.Context, arg1 time.Time) (db.TransferBatch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimTransferBatch", arg0, arg1)
+	ret0, _ := ret[0].(db.TransferBatch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimTransferBatch indicates an expected call of ClaimTransferBatch.
+func (mr *MockStoreMockRecorder) ClaimTransferBatch(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimTransferBatch", reflect.TypeOf((*MockStore)(nil).ClaimTransferBatch), arg0, arg1)
+}
+
+// CloseBusinessDayTx mocks base method.
+func (m *MockStore) CloseBusinessDayTx(arg0 context.Context, arg1 db.CloseBusinessDayTxParams) (db.CloseBusinessDayTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloseBusinessDayTx", arg0, arg1)
+	ret0, _ := ret[0].(db.CloseBusinessDayTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CloseBusinessDayTx indicates an expected call of CloseBusinessDayTx.
+func (mr *MockStoreMockRecorder) CloseBusinessDayTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseBusinessDayTx", reflect.TypeOf((*MockStore)(nil).CloseBusinessDayTx), arg0, arg1)
+}
+
+// CountAccountsByOwner mocks base method.
+func (m *MockStore) CountAccountsByOwner(arg0 context.Context, arg1 string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountAccountsByOwner", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountAccountsByOwner indicates an expected call of CountAccountsByOwner.
+func (mr *MockStoreMockRecorder) CountAccountsByOwner(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountAccountsByOwner", reflect.TypeOf((*MockStore)(nil).CountAccountsByOwner), arg0, arg1)
+}
+
+// CountRecentTransfers mocks base method.
+func (m *MockStore) CountRecentTransfers(arg0 context.Context, arg1 db.CountRecentTransfersParams) (db.CountRecentTransfersRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountRecentTransfers", arg0, arg1)
+	ret0, _ := ret[0].(db.CountRecentTransfersRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountRecentTransfers indicates an expected call of CountRecentTransfers.
+func (mr *MockStoreMockRecorder) CountRecentTransfers(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountRecentTransfers", reflect.TypeOf((*MockStore)(nil).CountRecentTransfers), arg0, arg1)
+}
+
+// CountRecipientLookupsSince mocks base method.
+func (m *MockStore) CountRecipientLookupsSince(arg0 context.Context, arg1 db.CountRecipientLookupsSinceParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountRecipientLookupsSince", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountRecipientLookupsSince indicates an expected call of CountRecipientLookupsSince.
+func (mr *MockStoreMockRecorder) CountRecipientLookupsSince(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountRecipientLookupsSince", reflect.TypeOf((*MockStore)(nil).CountRecipientLookupsSince), arg0, arg1)
+}
+
+// CountSessionsFromClientIP mocks base method.
+func (m *MockStore) CountSessionsFromClientIP(arg0 context.Context, arg1 db.CountSessionsFromClientIPParams) (db.CountSessionsFromClientIPRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountSessionsFromClientIP", arg0, arg1)
+	ret0, _ := ret[0].(db.CountSessionsFromClientIPRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountSessionsFromClientIP indicates an expected call of CountSessionsFromClientIP.
+func (mr *MockStoreMockRecorder) CountSessionsFromClientIP(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountSessionsFromClientIP", reflect.TypeOf((*MockStore)(nil).CountSessionsFromClientIP), arg0, arg1)
+}
+
 // CreateAccount mocks base method.
 func (m *MockStore) CreateAccount(arg0 context.Context, arg1 db.CreateAccountParams) (db.Account, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateAccount", arg0, arg1)
-	ret0, _ := ret[0].(db.Account)
+	ret := m.ctrl.Call(m, "CreateAccount", arg0, arg1)
+	ret0, _ := ret[0].(db.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAccount indicates an expected call of CreateAccount.
+func (mr *MockStoreMockRecorder) CreateAccount(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAccount", reflect.TypeOf((*MockStore)(nil).CreateAccount), arg0, arg1)
+}
+
+// CreateAccountTx mocks base method.
+func (m *MockStore) CreateAccountTx(arg0 context.Context, arg1 db.CreateAccountParams) (db.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAccountTx", arg0, arg1)
+	ret0, _ := ret[0].(db.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAccountTx indicates an expected call of CreateAccountTx.
+func (mr *MockStoreMockRecorder) CreateAccountTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAccountTx", reflect.TypeOf((*MockStore)(nil).CreateAccountTx), arg0, arg1)
+}
+
+// CreateBeneficiary mocks base method.
+func (m *MockStore) CreateBeneficiary(arg0 context.Context, arg1 db.CreateBeneficiaryParams) (db.Beneficiary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBeneficiary", arg0, arg1)
+	ret0, _ := ret[0].(db.Beneficiary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateBeneficiary indicates an expected call of CreateBeneficiary.
+func (mr *MockStoreMockRecorder) CreateBeneficiary(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBeneficiary", reflect.TypeOf((*MockStore)(nil).CreateBeneficiary), arg0, arg1)
+}
+
+// CreateBusinessDay mocks base method.
+func (m *MockStore) CreateBusinessDay(arg0 context.Context, arg1 time.Time) (db.BusinessDay, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBusinessDay", arg0, arg1)
+	ret0, _ := ret[0].(db.BusinessDay)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateBusinessDay indicates an expected call of CreateBusinessDay.
+func (mr *MockStoreMockRecorder) CreateBusinessDay(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBusinessDay", reflect.TypeOf((*MockStore)(nil).CreateBusinessDay), arg0, arg1)
+}
+
+// CreateCategorizationRule mocks base method.
+func (m *MockStore) CreateCategorizationRule(arg0 context.Context, arg1 db.CreateCategorizationRuleParams) (db.CategorizationRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCategorizationRule", arg0, arg1)
+	ret0, _ := ret[0].(db.CategorizationRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCategorizationRule indicates an expected call of CreateCategorizationRule.
+func (mr *MockStoreMockRecorder) CreateCategorizationRule(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCategorizationRule", reflect.TypeOf((*MockStore)(nil).CreateCategorizationRule), arg0, arg1)
+}
+
+// CreateDispute mocks base method.
+func (m *MockStore) CreateDispute(arg0 context.Context, arg1 db.CreateDisputeParams) (db.Dispute, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateDispute", arg0, arg1)
+	ret0, _ := ret[0].(db.Dispute)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateDispute indicates an expected call of CreateDispute.
+func (mr *MockStoreMockRecorder) CreateDispute(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDispute", reflect.TypeOf((*MockStore)(nil).CreateDispute), arg0, arg1)
+}
+
+// CreateDisputeEvent mocks base method.
+func (m *MockStore) CreateDisputeEvent(arg0 context.Context, arg1 db.CreateDisputeEventParams) (db.DisputeEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateDisputeEvent", arg0, arg1)
+	ret0, _ := ret[0].(db.DisputeEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateDisputeEvent indicates an expected call of CreateDisputeEvent.
+func (mr *MockStoreMockRecorder) CreateDisputeEvent(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDisputeEvent", reflect.TypeOf((*MockStore)(nil).CreateDisputeEvent), arg0, arg1)
+}
+
+// CreateDisputeEvidence mocks base method.
+func (m *MockStore) CreateDisputeEvidence(arg0 context.Context, arg1 db.CreateDisputeEvidenceParams) (db.DisputeEvidence, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateDisputeEvidence", arg0, arg1)
+	ret0, _ := ret[0].(db.DisputeEvidence)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateDisputeEvidence indicates an expected call of CreateDisputeEvidence.
+func (mr *MockStoreMockRecorder) CreateDisputeEvidence(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDisputeEvidence", reflect.TypeOf((*MockStore)(nil).CreateDisputeEvidence), arg0, arg1)
+}
+
+// CreateEODStep mocks base method.
+func (m *MockStore) CreateEODStep(arg0 context.Context, arg1 db.CreateEODStepParams) (db.EodStep, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateEODStep", arg0, arg1)
+	ret0, _ := ret[0].(db.EodStep)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateEODStep indicates an expected call of CreateEODStep.
+func (mr *MockStoreMockRecorder) CreateEODStep(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEODStep", reflect.TypeOf((*MockStore)(nil).CreateEODStep), arg0, arg1)
+}
+
+// CreateEntry mocks base method.
+func (m *MockStore) CreateEntry(arg0 context.Context, arg1 db.CreateEntryParams) (db.Entry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateEntry", arg0, arg1)
+	ret0, _ := ret[0].(db.Entry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateEntry indicates an expected call of CreateEntry.
+func (mr *MockStoreMockRecorder) CreateEntry(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEntry", reflect.TypeOf((*MockStore)(nil).CreateEntry), arg0, arg1)
+}
+
+// CreateEscrow mocks base method.
+func (m *MockStore) CreateEscrow(arg0 context.Context, arg1 db.CreateEscrowParams) (db.Escrow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateEscrow", arg0, arg1)
+	ret0, _ := ret[0].(db.Escrow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateEscrow indicates an expected call of CreateEscrow.
+func (mr *MockStoreMockRecorder) CreateEscrow(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEscrow", reflect.TypeOf((*MockStore)(nil).CreateEscrow), arg0, arg1)
+}
+
+// CreateEscrowTx mocks base method.
+func (m *MockStore) CreateEscrowTx(arg0 context.Context, arg1 db.CreateEscrowTxParams) (db.CreateEscrowTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateEscrowTx", arg0, arg1)
+	ret0, _ := ret[0].(db.CreateEscrowTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateEscrowTx indicates an expected call of CreateEscrowTx.
+func (mr *MockStoreMockRecorder) CreateEscrowTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEscrowTx", reflect.TypeOf((*MockStore)(nil).CreateEscrowTx), arg0, arg1)
+}
+
+// CreateFeeSchedule mocks base method.
+func (m *MockStore) CreateFeeSchedule(arg0 context.Context, arg1 db.CreateFeeScheduleParams) (db.FeeSchedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateFeeSchedule", arg0, arg1)
+	ret0, _ := ret[0].(db.FeeSchedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateFeeSchedule indicates an expected call of CreateFeeSchedule.
+func (mr *MockStoreMockRecorder) CreateFeeSchedule(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFeeSchedule", reflect.TypeOf((*MockStore)(nil).CreateFeeSchedule), arg0, arg1)
+}
+
+// CreateFeeScheduleBand mocks base method.
+func (m *MockStore) CreateFeeScheduleBand(arg0 context.Context, arg1 db.CreateFeeScheduleBandParams) (db.FeeScheduleBand, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateFeeScheduleBand", arg0, arg1)
+	ret0, _ := ret[0].(db.FeeScheduleBand)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateFeeScheduleBand indicates an expected call of CreateFeeScheduleBand.
+func (mr *MockStoreMockRecorder) CreateFeeScheduleBand(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFeeScheduleBand", reflect.TypeOf((*MockStore)(nil).CreateFeeScheduleBand), arg0, arg1)
+}
+
+// CreateFraudDecision mocks base method.
+func (m *MockStore) CreateFraudDecision(arg0 context.Context, arg1 db.CreateFraudDecisionParams) (db.FraudDecision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateFraudDecision", arg0, arg1)
+	ret0, _ := ret[0].(db.FraudDecision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateFraudDecision indicates an expected call of CreateFraudDecision.
+func (mr *MockStoreMockRecorder) CreateFraudDecision(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFraudDecision", reflect.TypeOf((*MockStore)(nil).CreateFraudDecision), arg0, arg1)
+}
+
+// CreateInterestAccrual mocks base method.
+func (m *MockStore) CreateInterestAccrual(arg0 context.Context, arg1 db.CreateInterestAccrualParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateInterestAccrual", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateInterestAccrual indicates an expected call of CreateInterestAccrual.
+func (mr *MockStoreMockRecorder) CreateInterestAccrual(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateInterestAccrual", reflect.TypeOf((*MockStore)(nil).CreateInterestAccrual), arg0, arg1)
+}
+
+// CreateInterestCapitalization mocks base method.
+func (m *MockStore) CreateInterestCapitalization(arg0 context.Context, arg1 db.CreateInterestCapitalizationParams) (db.InterestCapitalization, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateInterestCapitalization", arg0, arg1)
+	ret0, _ := ret[0].(db.InterestCapitalization)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateInterestCapitalization indicates an expected call of CreateInterestCapitalization.
+func (mr *MockStoreMockRecorder) CreateInterestCapitalization(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateInterestCapitalization", reflect.TypeOf((*MockStore)(nil).CreateInterestCapitalization), arg0, arg1)
+}
+
+// CreateKycDocument mocks base method.
+func (m *MockStore) CreateKycDocument(arg0 context.Context, arg1 db.CreateKycDocumentParams) (db.KycDocument, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateKycDocument", arg0, arg1)
+	ret0, _ := ret[0].(db.KycDocument)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateKycDocument indicates an expected call of CreateKycDocument.
+func (mr *MockStoreMockRecorder) CreateKycDocument(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateKycDocument", reflect.TypeOf((*MockStore)(nil).CreateKycDocument), arg0, arg1)
+}
+
+// CreateKycSubmission mocks base method.
+func (m *MockStore) CreateKycSubmission(arg0 context.Context, arg1 db.CreateKycSubmissionParams) (db.KycSubmission, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateKycSubmission", arg0, arg1)
+	ret0, _ := ret[0].(db.KycSubmission)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateKycSubmission indicates an expected call of CreateKycSubmission.
+func (mr *MockStoreMockRecorder) CreateKycSubmission(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateKycSubmission", reflect.TypeOf((*MockStore)(nil).CreateKycSubmission), arg0, arg1)
+}
+
+// CreateKycSubmissionTx mocks base method.
+func (m *MockStore) CreateKycSubmissionTx(arg0 context.Context, arg1 db.CreateKycSubmissionTxParams) (db.CreateKycSubmissionTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateKycSubmissionTx", arg0, arg1)
+	ret0, _ := ret[0].(db.CreateKycSubmissionTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateKycSubmissionTx indicates an expected call of CreateKycSubmissionTx.
+func (mr *MockStoreMockRecorder) CreateKycSubmissionTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateKycSubmissionTx", reflect.TypeOf((*MockStore)(nil).CreateKycSubmissionTx), arg0, arg1)
+}
+
+// CreateNotification mocks base method.
+func (m *MockStore) CreateNotification(arg0 context.Context, arg1 db.CreateNotificationParams) (db.Notification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateNotification", arg0, arg1)
+	ret0, _ := ret[0].(db.Notification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateNotification indicates an expected call of CreateNotification.
+func (mr *MockStoreMockRecorder) CreateNotification(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateNotification", reflect.TypeOf((*MockStore)(nil).CreateNotification), arg0, arg1)
+}
+
+// CreateOverdraftApproval mocks base method.
+func (m *MockStore) CreateOverdraftApproval(arg0 context.Context, arg1 db.CreateOverdraftApprovalParams) (db.OverdraftApproval, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOverdraftApproval", arg0, arg1)
+	ret0, _ := ret[0].(db.OverdraftApproval)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateOverdraftApproval indicates an expected call of CreateOverdraftApproval.
+func (mr *MockStoreMockRecorder) CreateOverdraftApproval(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOverdraftApproval", reflect.TypeOf((*MockStore)(nil).CreateOverdraftApproval), arg0, arg1)
+}
+
+// CreateOverdraftEvent mocks base method.
+func (m *MockStore) CreateOverdraftEvent(arg0 context.Context, arg1 db.CreateOverdraftEventParams) (db.OverdraftEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOverdraftEvent", arg0, arg1)
+	ret0, _ := ret[0].(db.OverdraftEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateOverdraftEvent indicates an expected call of CreateOverdraftEvent.
+func (mr *MockStoreMockRecorder) CreateOverdraftEvent(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOverdraftEvent", reflect.TypeOf((*MockStore)(nil).CreateOverdraftEvent), arg0, arg1)
+}
+
+// CreatePaymentRequest mocks base method.
+func (m *MockStore) CreatePaymentRequest(arg0 context.Context, arg1 db.CreatePaymentRequestParams) (db.PaymentRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePaymentRequest", arg0, arg1)
+	ret0, _ := ret[0].(db.PaymentRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePaymentRequest indicates an expected call of CreatePaymentRequest.
+func (mr *MockStoreMockRecorder) CreatePaymentRequest(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePaymentRequest", reflect.TypeOf((*MockStore)(nil).CreatePaymentRequest), arg0, arg1)
+}
+
+// CreateRecipientLookup mocks base method.
+func (m *MockStore) CreateRecipientLookup(arg0 context.Context, arg1 db.CreateRecipientLookupParams) (db.RecipientLookup, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRecipientLookup", arg0, arg1)
+	ret0, _ := ret[0].(db.RecipientLookup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateRecipientLookup indicates an expected call of CreateRecipientLookup.
+func (mr *MockStoreMockRecorder) CreateRecipientLookup(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRecipientLookup", reflect.TypeOf((*MockStore)(nil).CreateRecipientLookup), arg0, arg1)
+}
+
+// CreateScreeningMatch mocks base method.
+func (m *MockStore) CreateScreeningMatch(arg0 context.Context, arg1 db.CreateScreeningMatchParams) (db.ScreeningMatch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateScreeningMatch", arg0, arg1)
+	ret0, _ := ret[0].(db.ScreeningMatch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateScreeningMatch indicates an expected call of CreateScreeningMatch.
+func (mr *MockStoreMockRecorder) CreateScreeningMatch(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateScreeningMatch", reflect.TypeOf((*MockStore)(nil).CreateScreeningMatch), arg0, arg1)
+}
+
+// CreateScreeningWhitelistEntry mocks base method.
+func (m *MockStore) CreateScreeningWhitelistEntry(arg0 context.Context, arg1 db.CreateScreeningWhitelistEntryParams) (db.ScreeningWhitelist, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateScreeningWhitelistEntry", arg0, arg1)
+	ret0, _ := ret[0].(db.ScreeningWhitelist)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateScreeningWhitelistEntry indicates an expected call of CreateScreeningWhitelistEntry.
+func (mr *MockStoreMockRecorder) CreateScreeningWhitelistEntry(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateScreeningWhitelistEntry", reflect.TypeOf((*MockStore)(nil).CreateScreeningWhitelistEntry), arg0, arg1)
+}
+
+// CreateSession mocks base method.
+func (m *MockStore) CreateSession(arg0 context.Context, arg1 db.CreateSessionParams) (db.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSession", arg0, arg1)
+	ret0, _ := ret[0].(db.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSession indicates an expected call of CreateSession.
+func (mr *MockStoreMockRecorder) CreateSession(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSession", reflect.TypeOf((*MockStore)(nil).CreateSession), arg0, arg1)
+}
+
+// CreateTransfer mocks base method.
+func (m *MockStore) CreateTransfer(arg0 context.Context, arg1 db.CreateTransferParams) (db.Transfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTransfer", arg0, arg1)
+	ret0, _ := ret[0].(db.Transfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTransfer indicates an expected call of CreateTransfer.
+func (mr *MockStoreMockRecorder) CreateTransfer(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTransfer", reflect.TypeOf((*MockStore)(nil).CreateTransfer), arg0, arg1)
+}
+
+// CreateTransferBatch mocks base method.
+func (m *MockStore) CreateTransferBatch(arg0 context.Context, arg1 db.CreateTransferBatchParams) (db.TransferBatch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTransferBatch", arg0, arg1)
+	ret0, _ := ret[0].(db.TransferBatch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTransferBatch indicates an expected call of CreateTransferBatch.
+func (mr *MockStoreMockRecorder) CreateTransferBatch(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTransferBatch", reflect.TypeOf((*MockStore)(nil).CreateTransferBatch), arg0, arg1)
+}
+
+// CreateTransferBatchItem mocks base method.
+func (m *MockStore) CreateTransferBatchItem(arg0 context.Context, arg1 db.CreateTransferBatchItemParams) (db.TransferBatchItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTransferBatchItem", arg0, arg1)
+	ret0, _ := ret[0].(db.TransferBatchItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTransferBatchItem indicates an expected call of CreateTransferBatchItem.
+func (mr *MockStoreMockRecorder) CreateTransferBatchItem(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTransferBatchItem", reflect.TypeOf((*MockStore)(nil).CreateTransferBatchItem), arg0, arg1)
+}
+
+// CreateTransferBatchTx mocks base method.
+func (m *MockStore) CreateTransferBatchTx(arg0 context.Context, arg1 db.CreateTransferBatchTxParams) (db.CreateTransferBatchTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTransferBatchTx", arg0, arg1)
+	ret0, _ := ret[0].(db.CreateTransferBatchTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTransferBatchTx indicates an expected call of CreateTransferBatchTx.
+func (mr *MockStoreMockRecorder) CreateTransferBatchTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTransferBatchTx", reflect.TypeOf((*MockStore)(nil).CreateTransferBatchTx), arg0, arg1)
+}
+
+// CreateUser mocks base method.
+func (m *MockStore) CreateUser(arg0 context.Context, arg1 db.CreateUserParams) (db.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateUser", arg0, arg1)
+	ret0, _ := ret[0].(db.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateUser indicates an expected call of CreateUser.
+func (mr *MockStoreMockRecorder) CreateUser(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockStore)(nil).CreateUser), arg0, arg1)
+}
+
+// DeleteAccount mocks base method.
+func (m *MockStore) DeleteAccount(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAccount", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAccount indicates an expected call of DeleteAccount.
+func (mr *MockStoreMockRecorder) DeleteAccount(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAccount", reflect.TypeOf((*MockStore)(nil).DeleteAccount), arg0, arg1)
+}
+
+// DeleteBeneficiary mocks base method.
+func (m *MockStore) DeleteBeneficiary(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBeneficiary", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteBeneficiary indicates an expected call of DeleteBeneficiary.
+func (mr *MockStoreMockRecorder) DeleteBeneficiary(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBeneficiary", reflect.TypeOf((*MockStore)(nil).DeleteBeneficiary), arg0, arg1)
+}
+
+// DeleteCategorizationRule mocks base method.
+func (m *MockStore) DeleteCategorizationRule(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCategorizationRule", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCategorizationRule indicates an expected call of DeleteCategorizationRule.
+func (mr *MockStoreMockRecorder) DeleteCategorizationRule(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCategorizationRule", reflect.TypeOf((*MockStore)(nil).DeleteCategorizationRule), arg0, arg1)
+}
+
+// DeleteScreeningWhitelistEntry mocks base method.
+func (m *MockStore) DeleteScreeningWhitelistEntry(arg0 context.Context, arg1 int64) (db.ScreeningWhitelist, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteScreeningWhitelistEntry", arg0, arg1)
+	ret0, _ := ret[0].(db.ScreeningWhitelist)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteScreeningWhitelistEntry indicates an expected call of DeleteScreeningWhitelistEntry.
+func (mr *MockStoreMockRecorder) DeleteScreeningWhitelistEntry(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteScreeningWhitelistEntry", reflect.TypeOf((*MockStore)(nil).DeleteScreeningWhitelistEntry), arg0, arg1)
+}
+
+// FailPendingTransferBatchItems mocks base method.
+func (m *MockStore) FailPendingTransferBatchItems(arg0 context.Context, arg1 db.FailPendingTransferBatchItemsParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FailPendingTransferBatchItems", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FailPendingTransferBatchItems indicates an expected call of FailPendingTransferBatchItems.
+func (mr *MockStoreMockRecorder) FailPendingTransferBatchItems(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FailPendingTransferBatchItems", reflect.TypeOf((*MockStore)(nil).FailPendingTransferBatchItems), arg0, arg1)
+}
+
+// FinishClosingBusinessDay mocks base method.
+func (m *MockStore) FinishClosingBusinessDay(arg0 context.Context, arg1 time.Time) (db.BusinessDay, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FinishClosingBusinessDay", arg0, arg1)
+	ret0, _ := ret[0].(db.BusinessDay)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FinishClosingBusinessDay indicates an expected call of FinishClosingBusinessDay.
+func (mr *MockStoreMockRecorder) FinishClosingBusinessDay(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FinishClosingBusinessDay", reflect.TypeOf((*MockStore)(nil).FinishClosingBusinessDay), arg0, arg1)
+}
+
+// FinishEODStep mocks base method.
+func (m *MockStore) FinishEODStep(arg0 context.Context, arg1 db.FinishEODStepParams) (db.EodStep, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FinishEODStep", arg0, arg1)
+	ret0, _ := ret[0].(db.EodStep)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FinishEODStep indicates an expected call of FinishEODStep.
+func (mr *MockStoreMockRecorder) FinishEODStep(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FinishEODStep", reflect.TypeOf((*MockStore)(nil).FinishEODStep), arg0, arg1)
+}
+
+// FinishTransferBatch mocks base method.
+func (m *MockStore) FinishTransferBatch(arg0 context.Context, arg1 db.FinishTransferBatchParams) (db.TransferBatch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FinishTransferBatch", arg0, arg1)
+	ret0, _ := ret[0].(db.TransferBatch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FinishTransferBatch indicates an expected call of FinishTransferBatch.
+func (mr *MockStoreMockRecorder) FinishTransferBatch(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FinishTransferBatch", reflect.TypeOf((*MockStore)(nil).FinishTransferBatch), arg0, arg1)
+}
+
+// GetAccount mocks base method.
+func (m *MockStore) GetAccount(arg0 context.Context, arg1 int64) (db.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccount", arg0, arg1)
+	ret0, _ := ret[0].(db.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccount indicates an expected call of GetAccount.
+func (mr *MockStoreMockRecorder) GetAccount(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccount", reflect.TypeOf((*MockStore)(nil).GetAccount), arg0, arg1)
+}
+
+// GetAccountBalanceBefore mocks base method.
+func (m *MockStore) GetAccountBalanceBefore(arg0 context.Context, arg1 db.GetAccountBalanceBeforeParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccountBalanceBefore", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccountBalanceBefore indicates an expected call of GetAccountBalanceBefore.
+func (mr *MockStoreMockRecorder) GetAccountBalanceBefore(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountBalanceBefore", reflect.TypeOf((*MockStore)(nil).GetAccountBalanceBefore), arg0, arg1)
+}
+
+// GetAccountByNumber mocks base method.
+func (m *MockStore) GetAccountByNumber(arg0 context.Context, arg1 string) (db.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccountByNumber", arg0, arg1)
+	ret0, _ := ret[0].(db.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccountByNumber indicates an expected call of GetAccountByNumber.
+func (mr *MockStoreMockRecorder) GetAccountByNumber(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountByNumber", reflect.TypeOf((*MockStore)(nil).GetAccountByNumber), arg0, arg1)
+}
+
+// GetAccountByOwnerCurrency mocks base method.
+func (m *MockStore) GetAccountByOwnerCurrency(arg0 context.Context, arg1 db.GetAccountByOwnerCurrencyParams) (db.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccountByOwnerCurrency", arg0, arg1)
+	ret0, _ := ret[0].(db.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccountByOwnerCurrency indicates an expected call of GetAccountByOwnerCurrency.
+func (mr *MockStoreMockRecorder) GetAccountByOwnerCurrency(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountByOwnerCurrency", reflect.TypeOf((*MockStore)(nil).GetAccountByOwnerCurrency), arg0, arg1)
+}
+
+// GetAccountByOwnerProduct mocks base method.
+func (m *MockStore) GetAccountByOwnerProduct(arg0 context.Context, arg1 db.GetAccountByOwnerProductParams) (db.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccountByOwnerProduct", arg0, arg1)
+	ret0, _ := ret[0].(db.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccountByOwnerProduct indicates an expected call of GetAccountByOwnerProduct.
+func (mr *MockStoreMockRecorder) GetAccountByOwnerProduct(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountByOwnerProduct", reflect.TypeOf((*MockStore)(nil).GetAccountByOwnerProduct), arg0, arg1)
+}
+
+// GetAccountForUpdate mocks base method.
+func (m *MockStore) GetAccountForUpdate(arg0 context.Context, arg1 int64) (db.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccountForUpdate", arg0, arg1)
+	ret0, _ := ret[0].(db.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccountForUpdate indicates an expected call of GetAccountForUpdate.
+func (mr *MockStoreMockRecorder) GetAccountForUpdate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountForUpdate", reflect.TypeOf((*MockStore)(nil).GetAccountForUpdate), arg0, arg1)
+}
+
+// GetAccountProduct mocks base method.
+func (m *MockStore) GetAccountProduct(arg0 context.Context, arg1 string) (db.AccountProduct, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccountProduct", arg0, arg1)
+	ret0, _ := ret[0].(db.AccountProduct)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccountProduct indicates an expected call of GetAccountProduct.
+func (mr *MockStoreMockRecorder) GetAccountProduct(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountProduct", reflect.TypeOf((*MockStore)(nil).GetAccountProduct), arg0, arg1)
+}
+
+// GetBeneficiary mocks base method.
+func (m *MockStore) GetBeneficiary(arg0 context.Context, arg1 int64) (db.Beneficiary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBeneficiary", arg0, arg1)
+	ret0, _ := ret[0].(db.Beneficiary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBeneficiary indicates an expected call of GetBeneficiary.
+func (mr *MockStoreMockRecorder) GetBeneficiary(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBeneficiary", reflect.TypeOf((*MockStore)(nil).GetBeneficiary), arg0, arg1)
+}
+
+// GetBusinessDay mocks base method.
+func (m *MockStore) GetBusinessDay(arg0 context.Context, arg1 time.Time) (db.BusinessDay, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBusinessDay", arg0, arg1)
+	ret0, _ := ret[0].(db.BusinessDay)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBusinessDay indicates an expected call of GetBusinessDay.
+func (mr *MockStoreMockRecorder) GetBusinessDay(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBusinessDay", reflect.TypeOf((*MockStore)(nil).GetBusinessDay), arg0, arg1)
+}
+
+// GetCategorizationRule mocks base method.
+func (m *MockStore) GetCategorizationRule(arg0 context.Context, arg1 int64) (db.CategorizationRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCategorizationRule", arg0, arg1)
+	ret0, _ := ret[0].(db.CategorizationRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCategorizationRule indicates an expected call of GetCategorizationRule.
+func (mr *MockStoreMockRecorder) GetCategorizationRule(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCategorizationRule", reflect.TypeOf((*MockStore)(nil).GetCategorizationRule), arg0, arg1)
+}
+
+// GetClosingBusinessDay mocks base method.
+func (m *MockStore) GetClosingBusinessDay(arg0 context.Context) (db.BusinessDay, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetClosingBusinessDay", arg0)
+	ret0, _ := ret[0].(db.BusinessDay)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetClosingBusinessDay indicates an expected call of GetClosingBusinessDay.
+func (mr *MockStoreMockRecorder) GetClosingBusinessDay(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetClosingBusinessDay", reflect.TypeOf((*MockStore)(nil).GetClosingBusinessDay), arg0)
+}
+
+// GetCurrency mocks base method.
+func (m *MockStore) GetCurrency(arg0 context.Context, arg1 string) (db.Currency, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCurrency", arg0, arg1)
+	ret0, _ := ret[0].(db.Currency)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCurrency indicates an expected call of GetCurrency.
+func (mr *MockStoreMockRecorder) GetCurrency(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCurrency", reflect.TypeOf((*MockStore)(nil).GetCurrency), arg0, arg1)
+}
+
+// GetDailyBalance mocks base method.
+func (m *MockStore) GetDailyBalance(arg0 context.Context, arg1 db.GetDailyBalanceParams) (db.DailyBalance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDailyBalance", arg0, arg1)
+	ret0, _ := ret[0].(db.DailyBalance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDailyBalance indicates an expected call of GetDailyBalance.
+func (mr *MockStoreMockRecorder) GetDailyBalance(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDailyBalance", reflect.TypeOf((*MockStore)(nil).GetDailyBalance), arg0, arg1)
+}
+
+// GetDispute mocks base method.
+func (m *MockStore) GetDispute(arg0 context.Context, arg1 int64) (db.Dispute, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDispute", arg0, arg1)
+	ret0, _ := ret[0].(db.Dispute)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDispute indicates an expected call of GetDispute.
+func (mr *MockStoreMockRecorder) GetDispute(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDispute", reflect.TypeOf((*MockStore)(nil).GetDispute), arg0, arg1)
+}
+
+// GetDisputeEvidence mocks base method.
+func (m *MockStore) GetDisputeEvidence(arg0 context.Context, arg1 db.GetDisputeEvidenceParams) (db.DisputeEvidence, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDisputeEvidence", arg0, arg1)
+	ret0, _ := ret[0].(db.DisputeEvidence)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDisputeEvidence indicates an expected call of GetDisputeEvidence.
+func (mr *MockStoreMockRecorder) GetDisputeEvidence(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDisputeEvidence", reflect.TypeOf((*MockStore)(nil).GetDisputeEvidence), arg0, arg1)
+}
+
+// GetDisputeForUpdate mocks base method.
+func (m *MockStore) GetDisputeForUpdate(arg0 context.Context, arg1 int64) (db.Dispute, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDisputeForUpdate", arg0, arg1)
+	ret0, _ := ret[0].(db.Dispute)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDisputeForUpdate indicates an expected call of GetDisputeForUpdate.
+func (mr *MockStoreMockRecorder) GetDisputeForUpdate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDisputeForUpdate", reflect.TypeOf((*MockStore)(nil).GetDisputeForUpdate), arg0, arg1)
+}
+
+// GetEntry mocks base method.
+func (m *MockStore) GetEntry(arg0 context.Context, arg1 int64) (db.Entry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEntry", arg0, arg1)
+	ret0, _ := ret[0].(db.Entry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEntry indicates an expected call of GetEntry.
+func (mr *MockStoreMockRecorder) GetEntry(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEntry", reflect.TypeOf((*MockStore)(nil).GetEntry), arg0, arg1)
+}
+
+// GetEscrow mocks base method.
+func (m *MockStore) GetEscrow(arg0 context.Context, arg1 int64) (db.Escrow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEscrow", arg0, arg1)
+	ret0, _ := ret[0].(db.Escrow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEscrow indicates an expected call of GetEscrow.
+func (mr *MockStoreMockRecorder) GetEscrow(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEscrow", reflect.TypeOf((*MockStore)(nil).GetEscrow), arg0, arg1)
+}
+
+// GetEscrowForUpdate mocks base method.
+func (m *MockStore) GetEscrowForUpdate(arg0 context.Context, arg1 int64) (db.Escrow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEscrowForUpdate", arg0, arg1)
+	ret0, _ := ret[0].(db.Escrow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEscrowForUpdate indicates an expected call of GetEscrowForUpdate.
+func (mr *MockStoreMockRecorder) GetEscrowForUpdate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEscrowForUpdate", reflect.TypeOf((*MockStore)(nil).GetEscrowForUpdate), arg0, arg1)
+}
+
+// GetFeeSchedule mocks base method.
+func (m *MockStore) GetFeeSchedule(arg0 context.Context, arg1 string) (db.FeeSchedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFeeSchedule", arg0, arg1)
+	ret0, _ := ret[0].(db.FeeSchedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFeeSchedule indicates an expected call of GetFeeSchedule.
+func (mr *MockStoreMockRecorder) GetFeeSchedule(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFeeSchedule", reflect.TypeOf((*MockStore)(nil).GetFeeSchedule), arg0, arg1)
+}
+
+// GetFraudDecision mocks base method.
+func (m *MockStore) GetFraudDecision(arg0 context.Context, arg1 int64) (db.FraudDecision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFraudDecision", arg0, arg1)
+	ret0, _ := ret[0].(db.FraudDecision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFraudDecision indicates an expected call of GetFraudDecision.
+func (mr *MockStoreMockRecorder) GetFraudDecision(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFraudDecision", reflect.TypeOf((*MockStore)(nil).GetFraudDecision), arg0, arg1)
+}
+
+// GetFraudDecisionForUpdate mocks base method.
+func (m *MockStore) GetFraudDecisionForUpdate(arg0 context.Context, arg1 int64) (db.FraudDecision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFraudDecisionForUpdate", arg0, arg1)
+	ret0, _ := ret[0].(db.FraudDecision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFraudDecisionForUpdate indicates an expected call of GetFraudDecisionForUpdate.
+func (mr *MockStoreMockRecorder) GetFraudDecisionForUpdate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFraudDecisionForUpdate", reflect.TypeOf((*MockStore)(nil).GetFraudDecisionForUpdate), arg0, arg1)
+}
+
+// GetInterestCapitalization mocks base method.
+func (m *MockStore) GetInterestCapitalization(arg0 context.Context, arg1 db.GetInterestCapitalizationParams) (db.InterestCapitalization, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInterestCapitalization", arg0, arg1)
+	ret0, _ := ret[0].(db.InterestCapitalization)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInterestCapitalization indicates an expected call of GetInterestCapitalization.
+func (mr *MockStoreMockRecorder) GetInterestCapitalization(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInterestCapitalization", reflect.TypeOf((*MockStore)(nil).GetInterestCapitalization), arg0, arg1)
+}
+
+// GetKycDocument mocks base method.
+func (m *MockStore) GetKycDocument(arg0 context.Context, arg1 db.GetKycDocumentParams) (db.KycDocument, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetKycDocument", arg0, arg1)
+	ret0, _ := ret[0].(db.KycDocument)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetKycDocument indicates an expected call of GetKycDocument.
+func (mr *MockStoreMockRecorder) GetKycDocument(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetKycDocument", reflect.TypeOf((*MockStore)(nil).GetKycDocument), arg0, arg1)
+}
+
+// GetKycLevel mocks base method.
+func (m *MockStore) GetKycLevel(arg0 context.Context, arg1 int32) (db.KycLevel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetKycLevel", arg0, arg1)
+	ret0, _ := ret[0].(db.KycLevel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetKycLevel indicates an expected call of GetKycLevel.
+func (mr *MockStoreMockRecorder) GetKycLevel(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetKycLevel", reflect.TypeOf((*MockStore)(nil).GetKycLevel), arg0, arg1)
+}
+
+// GetKycSubmission mocks base method.
+func (m *MockStore) GetKycSubmission(arg0 context.Context, arg1 int64) (db.KycSubmission, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetKycSubmission", arg0, arg1)
+	ret0, _ := ret[0].(db.KycSubmission)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetKycSubmission indicates an expected call of GetKycSubmission.
+func (mr *MockStoreMockRecorder) GetKycSubmission(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetKycSubmission", reflect.TypeOf((*MockStore)(nil).GetKycSubmission), arg0, arg1)
+}
+
+// GetKycSubmissionForUpdate mocks base method.
+func (m *MockStore) GetKycSubmissionForUpdate(arg0 context.Context, arg1 int64) (db.KycSubmission, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetKycSubmissionForUpdate", arg0, arg1)
+	ret0, _ := ret[0].(db.KycSubmission)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetKycSubmissionForUpdate indicates an expected call of GetKycSubmissionForUpdate.
+func (mr *MockStoreMockRecorder) GetKycSubmissionForUpdate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetKycSubmissionForUpdate", reflect.TypeOf((*MockStore)(nil).GetKycSubmissionForUpdate), arg0, arg1)
+}
+
+// GetLastInterestCapitalization mocks base method.
+func (m *MockStore) GetLastInterestCapitalization(arg0 context.Context, arg1 int64) (db.InterestCapitalization, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLastInterestCapitalization", arg0, arg1)
+	ret0, _ := ret[0].(db.InterestCapitalization)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLastInterestCapitalization indicates an expected call of GetLastInterestCapitalization.
+func (mr *MockStoreMockRecorder) GetLastInterestCapitalization(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLastInterestCapitalization", reflect.TypeOf((*MockStore)(nil).GetLastInterestCapitalization), arg0, arg1)
+}
+
+// GetOpenBusinessDay mocks base method.
+func (m *MockStore) GetOpenBusinessDay(arg0 context.Context) (db.BusinessDay, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOpenBusinessDay", arg0)
+	ret0, _ := ret[0].(db.BusinessDay)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOpenBusinessDay indicates an expected call of GetOpenBusinessDay.
+func (mr *MockStoreMockRecorder) GetOpenBusinessDay(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOpenBusinessDay", reflect.TypeOf((*MockStore)(nil).GetOpenBusinessDay), arg0)
+}
+
+// GetOpenBusinessDayForShare mocks base method.
+func (m *MockStore) GetOpenBusinessDayForShare(arg0 context.Context) (db.BusinessDay, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOpenBusinessDayForShare", arg0)
+	ret0, _ := ret[0].(db.BusinessDay)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOpenBusinessDayForShare indicates an expected call of GetOpenBusinessDayForShare.
+func (mr *MockStoreMockRecorder) GetOpenBusinessDayForShare(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOpenBusinessDayForShare", reflect.TypeOf((*MockStore)(nil).GetOpenBusinessDayForShare), arg0)
+}
+
+// GetPaymentRequest mocks base method.
+func (m *MockStore) GetPaymentRequest(arg0 context.Context, arg1 int64) (db.PaymentRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPaymentRequest", arg0, arg1)
+	ret0, _ := ret[0].(db.PaymentRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPaymentRequest indicates an expected call of GetPaymentRequest.
+func (mr *MockStoreMockRecorder) GetPaymentRequest(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPaymentRequest", reflect.TypeOf((*MockStore)(nil).GetPaymentRequest), arg0, arg1)
+}
+
+// GetPaymentRequestForUpdate mocks base method.
+func (m *MockStore) GetPaymentRequestForUpdate(arg0 context.Context, arg1 int64) (db.PaymentRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPaymentRequestForUpdate", arg0, arg1)
+	ret0, _ := ret[0].(db.PaymentRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPaymentRequestForUpdate indicates an expected call of GetPaymentRequestForUpdate.
+func (mr *MockStoreMockRecorder) GetPaymentRequestForUpdate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPaymentRequestForUpdate", reflect.TypeOf((*MockStore)(nil).GetPaymentRequestForUpdate), arg0, arg1)
+}
+
+// GetSession mocks base method.
+func (m *MockStore) GetSession(arg0 context.Context, arg1 uuid.UUID) (db.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSession", arg0, arg1)
+	ret0, _ := ret[0].(db.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSession indicates an expected call of GetSession.
+func (mr *MockStoreMockRecorder) GetSession(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSession", reflect.TypeOf((*MockStore)(nil).GetSession), arg0, arg1)
+}
+
+// GetTransfer mocks base method.
+func (m *MockStore) GetTransfer(arg0 context.Context, arg1 int64) (db.Transfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTransfer", arg0, arg1)
+	ret0, _ := ret[0].(db.Transfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTransfer indicates an expected call of GetTransfer.
+func (mr *MockStoreMockRecorder) GetTransfer(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransfer", reflect.TypeOf((*MockStore)(nil).GetTransfer), arg0, arg1)
+}
+
+// GetTransferBatch mocks base method.
+func (m *MockStore) GetTransferBatch(arg0 context.Context, arg1 int64) (db.TransferBatch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTransferBatch", arg0, arg1)
+	ret0, _ := ret[0].(db.TransferBatch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTransferBatch indicates an expected call of GetTransferBatch.
+func (mr *MockStoreMockRecorder) GetTransferBatch(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransferBatch", reflect.TypeOf((*MockStore)(nil).GetTransferBatch), arg0, arg1)
+}
+
+// GetUser mocks base method.
+func (m *MockStore) GetUser(arg0 context.Context, arg1 string) (db.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUser", arg0, arg1)
+	ret0, _ := ret[0].(db.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUser indicates an expected call of GetUser.
+func (mr *MockStoreMockRecorder) GetUser(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*MockStore)(nil).GetUser), arg0, arg1)
+}
+
+// GetUserByVerifiedEmail mocks base method.
+func (m *MockStore) GetUserByVerifiedEmail(arg0 context.Context, arg1 string) (db.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserByVerifiedEmail", arg0, arg1)
+	ret0, _ := ret[0].(db.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserByVerifiedEmail indicates an expected call of GetUserByVerifiedEmail.
+func (mr *MockStoreMockRecorder) GetUserByVerifiedEmail(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByVerifiedEmail", reflect.TypeOf((*MockStore)(nil).GetUserByVerifiedEmail), arg0, arg1)
+}
+
+// GetUserForUpdate mocks base method.
+func (m *MockStore) GetUserForUpdate(arg0 context.Context, arg1 string) (db.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserForUpdate", arg0, arg1)
+	ret0, _ := ret[0].(db.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserForUpdate indicates an expected call of GetUserForUpdate.
+func (mr *MockStoreMockRecorder) GetUserForUpdate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserForUpdate", reflect.TypeOf((*MockStore)(nil).GetUserForUpdate), arg0, arg1)
+}
+
+// GetUserKycLimits mocks base method.
+func (m *MockStore) GetUserKycLimits(arg0 context.Context, arg1 string) (db.KycLevel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserKycLimits", arg0, arg1)
+	ret0, _ := ret[0].(db.KycLevel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserKycLimits indicates an expected call of GetUserKycLimits.
+func (mr *MockStoreMockRecorder) GetUserKycLimits(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserKycLimits", reflect.TypeOf((*MockStore)(nil).GetUserKycLimits), arg0, arg1)
+}
+
+// GetUserKycTransferLimits mocks base method.
+func (m *MockStore) GetUserKycTransferLimits(arg0 context.Context, arg1 db.GetUserKycTransferLimitsParams) (db.GetUserKycTransferLimitsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserKycTransferLimits", arg0, arg1)
+	ret0, _ := ret[0].(db.GetUserKycTransferLimitsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserKycTransferLimits indicates an expected call of GetUserKycTransferLimits.
+func (mr *MockStoreMockRecorder) GetUserKycTransferLimits(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserKycTransferLimits", reflect.TypeOf((*MockStore)(nil).GetUserKycTransferLimits), arg0, arg1)
+}
+
+// GetUserTier mocks base method.
+func (m *MockStore) GetUserTier(arg0 context.Context, arg1 string) (db.UserTier, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserTier", arg0, arg1)
+	ret0, _ := ret[0].(db.UserTier)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserTier indicates an expected call of GetUserTier.
+func (mr *MockStoreMockRecorder) GetUserTier(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserTier", reflect.TypeOf((*MockStore)(nil).GetUserTier), arg0, arg1)
+}
+
+// HasTransferredTo mocks base method.
+func (m *MockStore) HasTransferredTo(arg0 context.Context, arg1 db.HasTransferredToParams) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasTransferredTo", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HasTransferredTo indicates an expected call of HasTransferredTo.
+func (mr *MockStoreMockRecorder) HasTransferredTo(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasTransferredTo", reflect.TypeOf((*MockStore)(nil).HasTransferredTo), arg0, arg1)
+}
+
+// ListAccountProducts mocks base method.
+func (m *MockStore) ListAccountProducts(arg0 context.Context) ([]db.AccountProduct, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAccountProducts", arg0)
+	ret0, _ := ret[0].([]db.AccountProduct)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAccountProducts indicates an expected call of ListAccountProducts.
+func (mr *MockStoreMockRecorder) ListAccountProducts(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAccountProducts", reflect.TypeOf((*MockStore)(nil).ListAccountProducts), arg0)
+}
+
+// ListAccounts mocks base method.
+func (m *MockStore) ListAccounts(arg0 context.Context, arg1 db.ListAccountsParams) ([]db.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAccounts", arg0, arg1)
+	ret0, _ := ret[0].([]db.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAccounts indicates an expected call of ListAccounts.
+func (mr *MockStoreMockRecorder) ListAccounts(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAccounts", reflect.TypeOf((*MockStore)(nil).ListAccounts), arg0, arg1)
+}
+
+// ListAccountsAfter mocks base method.
+func (m *MockStore) ListAccountsAfter(arg0 context.Context, arg1 db.ListAccountsAfterParams) ([]db.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAccountsAfter", arg0, arg1)
+	ret0, _ := ret[0].([]db.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAccountsAfter indicates an expected call of ListAccountsAfter.
+func (mr *MockStoreMockRecorder) ListAccountsAfter(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAccountsAfter", reflect.TypeOf((*MockStore)(nil).ListAccountsAfter), arg0, arg1)
+}
+
+// ListAccountsWithUncapitalizedInterest mocks base method.
+func (m *MockStore) ListAccountsWithUncapitalizedInterest(arg0 context.Context, arg1 time.Time) ([]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAccountsWithUncapitalizedInterest", arg0, arg1)
+	ret0, _ := ret[0].([]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAccountsWithUncapitalizedInterest indicates an expected call of ListAccountsWithUncapitalizedInterest.
+func (mr *MockStoreMockRecorder) ListAccountsWithUncapitalizedInterest(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAccountsWithUncapitalizedInterest", reflect.TypeOf((*MockStore)(nil).ListAccountsWithUncapitalizedInterest), arg0, arg1)
+}
+
+// ListBeneficiariesAfter mocks base method.
+func (m *MockStore) ListBeneficiariesAfter(arg0 context.Context, arg1 db.ListBeneficiariesAfterParams) ([]db.Beneficiary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListBeneficiariesAfter", arg0, arg1)
+	ret0, _ := ret[0].([]db.Beneficiary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListBeneficiariesAfter indicates an expected call of ListBeneficiariesAfter.
+func (mr *MockStoreMockRecorder) ListBeneficiariesAfter(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBeneficiariesAfter", reflect.TypeOf((*MockStore)(nil).ListBeneficiariesAfter), arg0, arg1)
+}
+
+// ListCategorizationRules mocks base method.
+func (m *MockStore) ListCategorizationRules(arg0 context.Context, arg1 string) ([]db.CategorizationRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCategorizationRules", arg0, arg1)
+	ret0, _ := ret[0].([]db.CategorizationRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCategorizationRules indicates an expected call of ListCategorizationRules.
+func (mr *MockStoreMockRecorder) ListCategorizationRules(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCategorizationRules", reflect.TypeOf((*MockStore)(nil).ListCategorizationRules), arg0, arg1)
+}
+
+// ListCurrencies mocks base method.
+func (m *MockStore) ListCurrencies(arg0 context.Context) ([]db.Currency, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCurrencies", arg0)
+	ret0, _ := ret[0].([]db.Currency)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCurrencies indicates an expected call of ListCurrencies.
+func (mr *MockStoreMockRecorder) ListCurrencies(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCurrencies", reflect.TypeOf((*MockStore)(nil).ListCurrencies), arg0)
+}
+
+// ListDisputeEvents mocks base method.
+func (m *MockStore) ListDisputeEvents(arg0 context.Context, arg1 int64) ([]db.DisputeEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDisputeEvents", arg0, arg1)
+	ret0, _ := ret[0].([]db.DisputeEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDisputeEvents indicates an expected call of ListDisputeEvents.
+func (mr *MockStoreMockRecorder) ListDisputeEvents(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDisputeEvents", reflect.TypeOf((*MockStore)(nil).ListDisputeEvents), arg0, arg1)
+}
+
+// ListDisputeEvidence mocks base method.
+func (m *MockStore) ListDisputeEvidence(arg0 context.Context, arg1 int64) ([]db.DisputeEvidence, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDisputeEvidence", arg0, arg1)
+	ret0, _ := ret[0].([]db.DisputeEvidence)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDisputeEvidence indicates an expected call of ListDisputeEvidence.
+func (mr *MockStoreMockRecorder) ListDisputeEvidence(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDisputeEvidence", reflect.TypeOf((*MockStore)(nil).ListDisputeEvidence), arg0, arg1)
+}
+
+// ListDisputes mocks base method.
+func (m *MockStore) ListDisputes(arg0 context.Context, arg1 db.ListDisputesParams) ([]db.Dispute, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDisputes", arg0, arg1)
+	ret0, _ := ret[0].([]db.Dispute)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDisputes indicates an expected call of ListDisputes.
+func (mr *MockStoreMockRecorder) ListDisputes(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDisputes", reflect.TypeOf((*MockStore)(nil).ListDisputes), arg0, arg1)
+}
+
+// ListDueEscrowIDs mocks base method.
+func (m *MockStore) ListDueEscrowIDs(arg0 context.Context, arg1 int32) ([]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDueEscrowIDs", arg0, arg1)
+	ret0, _ := ret[0].([]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDueEscrowIDs indicates an expected call of ListDueEscrowIDs.
+func (mr *MockStoreMockRecorder) ListDueEscrowIDs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDueEscrowIDs", reflect.TypeOf((*MockStore)(nil).ListDueEscrowIDs), arg0, arg1)
+}
+
+// ListEODSteps mocks base method.
+func (m *MockStore) ListEODSteps(arg0 context.Context, arg1 time.Time) ([]db.EodStep, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListEODSteps", arg0, arg1)
+	ret0, _ := ret[0].([]db.EodStep)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListEODSteps indicates an expected call of ListEODSteps.
+func (mr *MockStoreMockRecorder) ListEODSteps(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEODSteps", reflect.TypeOf((*MockStore)(nil).ListEODSteps), arg0, arg1)
+}
+
+// ListEntriesAsc mocks base method.
+func (m *MockStore) ListEntriesAsc(arg0 context.Context, arg1 db.ListEntriesAscParams) ([]db.ListEntriesAscRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListEntriesAsc", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListEntriesAscRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListEntriesAsc indicates an expected call of ListEntriesAsc.
+func (mr *MockStoreMockRecorder) ListEntriesAsc(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEntriesAsc", reflect.TypeOf((*MockStore)(nil).ListEntriesAsc), arg0, arg1)
+}
+
+// ListEntriesDesc mocks base method.
+func (m *MockStore) ListEntriesDesc(arg0 context.Context, arg1 db.ListEntriesDescParams) ([]db.ListEntriesDescRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListEntriesDesc", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListEntriesDescRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListEntriesDesc indicates an expected call of ListEntriesDesc.
+func (mr *MockStoreMockRecorder) ListEntriesDesc(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEntriesDesc", reflect.TypeOf((*MockStore)(nil).ListEntriesDesc), arg0, arg1)
+}
+
+// ListEntriesPage mocks base method.
+func (m *MockStore) ListEntriesPage(arg0 context.Context, arg1 db.EntryPageFilter, arg2 db.SortOrder) ([]db.EntryPageRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListEntriesPage", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]db.EntryPageRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListEntriesPage indicates an expected call of ListEntriesPage.
+func (mr *MockStoreMockRecorder) ListEntriesPage(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEntriesPage", reflect.TypeOf((*MockStore)(nil).ListEntriesPage), arg0, arg1, arg2)
+}
+
+// ListEntry mocks base method.
+func (m *MockStore) ListEntry(arg0 context.Context, arg1 db.ListEntryParams) ([]db.Entry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListEntry", arg0, arg1)
+	ret0, _ := ret[0].([]db.Entry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListEntry indicates an expected call of ListEntry.
+func (mr *MockStoreMockRecorder) ListEntry(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEntry", reflect.TypeOf((*MockStore)(nil).ListEntry), arg0, arg1)
+}
+
+// ListEscrows mocks base method.
+func (m *MockStore) ListEscrows(arg0 context.Context, arg1 db.ListEscrowsParams) ([]db.Escrow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListEscrows", arg0, arg1)
+	ret0, _ := ret[0].([]db.Escrow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListEscrows indicates an expected call of ListEscrows.
+func (mr *MockStoreMockRecorder) ListEscrows(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEscrows", reflect.TypeOf((*MockStore)(nil).ListEscrows), arg0, arg1)
+}
+
+// ListFeeScheduleBands mocks base method.
+func (m *MockStore) ListFeeScheduleBands(arg0 context.Context, arg1 int64) ([]db.FeeScheduleBand, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListFeeScheduleBands", arg0, arg1)
+	ret0, _ := ret[0].([]db.FeeScheduleBand)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListFeeScheduleBands indicates an expected call of ListFeeScheduleBands.
+func (mr *MockStoreMockRecorder) ListFeeScheduleBands(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFeeScheduleBands", reflect.TypeOf((*MockStore)(nil).ListFeeScheduleBands), arg0, arg1)
+}
+
+// ListGLAccounts mocks base method.
+func (m *MockStore) ListGLAccounts(arg0 context.Context) ([]db.GlAccount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListGLAccounts", arg0)
+	ret0, _ := ret[0].([]db.GlAccount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListGLAccounts indicates an expected call of ListGLAccounts.
+func (mr *MockStoreMockRecorder) ListGLAccounts(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListGLAccounts", reflect.TypeOf((*MockStore)(nil).ListGLAccounts), arg0)
+}
+
+// ListInterestBearingAccounts mocks base method.
+func (m *MockStore) ListInterestBearingAccounts(arg0 context.Context, arg1 db.ListInterestBearingAccountsParams) ([]db.ListInterestBearingAccountsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListInterestBearingAccounts", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListInterestBearingAccountsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListInterestBearingAccounts indicates an expected call of ListInterestBearingAccounts.
+func (mr *MockStoreMockRecorder) ListInterestBearingAccounts(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListInterestBearingAccounts", reflect.TypeOf((*MockStore)(nil).ListInterestBearingAccounts), arg0, arg1)
+}
+
+// ListKycDocuments mocks base method.
+func (m *MockStore) ListKycDocuments(arg0 context.Context, arg1 int64) ([]db.KycDocument, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListKycDocuments", arg0, arg1)
+	ret0, _ := ret[0].([]db.KycDocument)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListKycDocuments indicates an expected call of ListKycDocuments.
+func (mr *MockStoreMockRecorder) ListKycDocuments(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListKycDocuments", reflect.TypeOf((*MockStore)(nil).ListKycDocuments), arg0, arg1)
+}
+
+// ListKycLevelLimits mocks base method.
+func (m *MockStore) ListKycLevelLimits(arg0 context.Context) ([]db.KycLevelLimit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListKycLevelLimits", arg0)
+	ret0, _ := ret[0].([]db.KycLevelLimit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListKycLevelLimits indicates an expected call of ListKycLevelLimits.
+func (mr *MockStoreMockRecorder) ListKycLevelLimits(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListKycLevelLimits", reflect.TypeOf((*MockStore)(nil).ListKycLevelLimits), arg0)
+}
+
+// ListKycLevels mocks base method.
+func (m *MockStore) ListKycLevels(arg0 context.Context) ([]db.KycLevel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListKycLevels", arg0)
+	ret0, _ := ret[0].([]db.KycLevel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListKycLevels indicates an expected call of ListKycLevels.
+func (mr *MockStoreMockRecorder) ListKycLevels(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListKycLevels", reflect.TypeOf((*MockStore)(nil).ListKycLevels), arg0)
+}
+
+// ListNotificationsBefore mocks base method.
+func (m *MockStore) ListNotificationsBefore(arg0 context.Context, arg1 db.ListNotificationsBeforeParams) ([]db.Notification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListNotificationsBefore", arg0, arg1)
+	ret0, _ := ret[0].([]db.Notification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListNotificationsBefore indicates an expected call of ListNotificationsBefore.
+func (mr *MockStoreMockRecorder) ListNotificationsBefore(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListNotificationsBefore", reflect.TypeOf((*MockStore)(nil).ListNotificationsBefore), arg0, arg1)
+}
+
+// ListOverdraftApprovals mocks base method.
+func (m *MockStore) ListOverdraftApprovals(arg0 context.Context, arg1 int64) ([]db.OverdraftApproval, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOverdraftApprovals", arg0, arg1)
+	ret0, _ := ret[0].([]db.OverdraftApproval)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOverdraftApprovals indicates an expected call of ListOverdraftApprovals.
+func (mr *MockStoreMockRecorder) ListOverdraftApprovals(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOverdraftApprovals", reflect.TypeOf((*MockStore)(nil).ListOverdraftApprovals), arg0, arg1)
+}
+
+// ListOverdrawnAccounts mocks base method.
+func (m *MockStore) ListOverdrawnAccounts(arg0 context.Context, arg1 db.ListOverdrawnAccountsParams) ([]db.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOverdrawnAccounts", arg0, arg1)
+	ret0, _ := ret[0].([]db.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOverdrawnAccounts indicates an expected call of ListOverdrawnAccounts.
+func (mr *MockStoreMockRecorder) ListOverdrawnAccounts(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOverdrawnAccounts", reflect.TypeOf((*MockStore)(nil).ListOverdrawnAccounts), arg0, arg1)
+}
+
+// ListPaymentRequestsBefore mocks base method.
+func (m *MockStore) ListPaymentRequestsBefore(arg0 context.Context, arg1 db.ListPaymentRequestsBeforeParams) ([]db.PaymentRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPaymentRequestsBefore", arg0, arg1)
+	ret0, _ := ret[0].([]db.PaymentRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPaymentRequestsBefore indicates an expected call of ListPaymentRequestsBefore.
+func (mr *MockStoreMockRecorder) ListPaymentRequestsBefore(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPaymentRequestsBefore", reflect.TypeOf((*MockStore)(nil).ListPaymentRequestsBefore), arg0, arg1)
+}
+
+// ListPendingFraudDecisions mocks base method.
+func (m *MockStore) ListPendingFraudDecisions(arg0 context.Context, arg1 db.ListPendingFraudDecisionsParams) ([]db.FraudDecision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPendingFraudDecisions", arg0, arg1)
+	ret0, _ := ret[0].([]db.FraudDecision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPendingFraudDecisions indicates an expected call of ListPendingFraudDecisions.
+func (mr *MockStoreMockRecorder) ListPendingFraudDecisions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPendingFraudDecisions", reflect.TypeOf((*MockStore)(nil).ListPendingFraudDecisions), arg0, arg1)
+}
+
+// ListPendingKycSubmissions mocks base method.
+func (m *MockStore) ListPendingKycSubmissions(arg0 context.Context, arg1 db.ListPendingKycSubmissionsParams) ([]db.KycSubmission, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPendingKycSubmissions", arg0, arg1)
+	ret0, _ := ret[0].([]db.KycSubmission)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPendingKycSubmissions indicates an expected call of ListPendingKycSubmissions.
+func (mr *MockStoreMockRecorder) ListPendingKycSubmissions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPendingKycSubmissions", reflect.TypeOf((*MockStore)(nil).ListPendingKycSubmissions), arg0, arg1)
+}
+
+// ListPendingTransferBatchItems mocks base method.
+func (m *MockStore) ListPendingTransferBatchItems(arg0 context.Context, arg1 int64) ([]db.TransferBatchItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPendingTransferBatchItems", arg0, arg1)
+	ret0, _ := ret[0].([]db.TransferBatchItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPendingTransferBatchItems indicates an expected call of ListPendingTransferBatchItems.
+func (mr *MockStoreMockRecorder) ListPendingTransferBatchItems(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPendingTransferBatchItems", reflect.TypeOf((*MockStore)(nil).ListPendingTransferBatchItems), arg0, arg1)
+}
+
+// ListScreeningMatches mocks base method.
+func (m *MockStore) ListScreeningMatches(arg0 context.Context, arg1 db.ListScreeningMatchesParams) ([]db.ScreeningMatch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListScreeningMatches", arg0, arg1)
+	ret0, _ := ret[0].([]db.ScreeningMatch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListScreeningMatches indicates an expected call of ListScreeningMatches.
+func (mr *MockStoreMockRecorder) ListScreeningMatches(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListScreeningMatches", reflect.TypeOf((*MockStore)(nil).ListScreeningMatches), arg0, arg1)
+}
+
+// ListScreeningWhitelist mocks base method.
+func (m *MockStore) ListScreeningWhitelist(arg0 context.Context) ([]db.ScreeningWhitelist, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListScreeningWhitelist", arg0)
+	ret0, _ := ret[0].([]db.ScreeningWhitelist)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListScreeningWhitelist indicates an expected call of ListScreeningWhitelist.
+func (mr *MockStoreMockRecorder) ListScreeningWhitelist(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListScreeningWhitelist", reflect.TypeOf((*MockStore)(nil).ListScreeningWhitelist), arg0)
+}
+
+// ListTransferBatchItems mocks base method.
+func (m *MockStore) ListTransferBatchItems(arg0 context.Context, arg1 int64) ([]db.TransferBatchItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTransferBatchItems", arg0, arg1)
+	ret0, _ := ret[0].([]db.TransferBatchItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTransferBatchItems indicates an expected call of ListTransferBatchItems.
+func (mr *MockStoreMockRecorder) ListTransferBatchItems(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTransferBatchItems", reflect.TypeOf((*MockStore)(nil).ListTransferBatchItems), arg0, arg1)
+}
+
+// ListTransferBatchesBefore mocks base method.
+func (m *MockStore) ListTransferBatchesBefore(arg0 context.Context, arg1 db.ListTransferBatchesBeforeParams) ([]db.TransferBatch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTransferBatchesBefore", arg0, arg1)
+	ret0, _ := ret[0].([]db.TransferBatch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTransferBatchesBefore indicates an expected call of ListTransferBatchesBefore.
+func (mr *MockStoreMockRecorder) ListTransferBatchesBefore(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTransferBatchesBefore", reflect.TypeOf((*MockStore)(nil).ListTransferBatchesBefore), arg0, arg1)
+}
+
+// ListTransfers mocks base method.
+func (m *MockStore) ListTransfers(arg0 context.Context, arg1 db.ListTransfersParams) ([]db.Transfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTransfers", arg0, arg1)
+	ret0, _ := ret[0].([]db.Transfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTransfers indicates an expected call of ListTransfers.
+func (mr *MockStoreMockRecorder) ListTransfers(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTransfers", reflect.TypeOf((*MockStore)(nil).ListTransfers), arg0, arg1)
+}
+
+// ListTransfersAsc mocks base method.
+func (m *MockStore) ListTransfersAsc(arg0 context.Context, arg1 db.ListTransfersAscParams) ([]db.ListTransfersAscRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTransfersAsc", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListTransfersAscRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTransfersAsc indicates an expected call of ListTransfersAsc.
+func (mr *MockStoreMockRecorder) ListTransfersAsc(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTransfersAsc", reflect.TypeOf((*MockStore)(nil).ListTransfersAsc), arg0, arg1)
+}
+
+// ListTransfersDesc mocks base method.
+func (m *MockStore) ListTransfersDesc(arg0 context.Context, arg1 db.ListTransfersDescParams) ([]db.ListTransfersDescRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTransfersDesc", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListTransfersDescRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTransfersDesc indicates an expected call of ListTransfersDesc.
+func (mr *MockStoreMockRecorder) ListTransfersDesc(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTransfersDesc", reflect.TypeOf((*MockStore)(nil).ListTransfersDesc), arg0, arg1)
+}
+
+// ListTransfersPage mocks base method.
+func (m *MockStore) ListTransfersPage(arg0 context.Context, arg1 db.TransferPageFilter, arg2 db.SortOrder) ([]db.TransferPageRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTransfersPage", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]db.TransferPageRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTransfersPage indicates an expected call of ListTransfersPage.
+func (mr *MockStoreMockRecorder) ListTransfersPage(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTransfersPage", reflect.TypeOf((*MockStore)(nil).ListTransfersPage), arg0, arg1, arg2)
+}
+
+// ListUserDisputes mocks base method.
+func (m *MockStore) ListUserDisputes(arg0 context.Context, arg1 string) ([]db.Dispute, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUserDisputes", arg0, arg1)
+	ret0, _ := ret[0].([]db.Dispute)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUserDisputes indicates an expected call of ListUserDisputes.
+func (mr *MockStoreMockRecorder) ListUserDisputes(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUserDisputes", reflect.TypeOf((*MockStore)(nil).ListUserDisputes), arg0, arg1)
+}
+
+// ListUserEscrows mocks base method.
+func (m *MockStore) ListUserEscrows(arg0 context.Context, arg1 string) ([]db.Escrow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUserEscrows", arg0, arg1)
+	ret0, _ := ret[0].([]db.Escrow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUserEscrows indicates an expected call of ListUserEscrows.
+func (mr *MockStoreMockRecorder) ListUserEscrows(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUserEscrows", reflect.TypeOf((*MockStore)(nil).ListUserEscrows), arg0, arg1)
+}
+
+// ListUserKycSubmissions mocks base method.
+func (m *MockStore) ListUserKycSubmissions(arg0 context.Context, arg1 string) ([]db.KycSubmission, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUserKycSubmissions", arg0, arg1)
+	ret0, _ := ret[0].([]db.KycSubmission)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUserKycSubmissions indicates an expected call of ListUserKycSubmissions.
+func (mr *MockStoreMockRecorder) ListUserKycSubmissions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUserKycSubmissions", reflect.TypeOf((*MockStore)(nil).ListUserKycSubmissions), arg0, arg1)
+}
+
+// ListUsernamesByRole mocks base method.
+func (m *MockStore) ListUsernamesByRole(arg0 context.Context, arg1 string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUsernamesByRole", arg0, arg1)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUsernamesByRole indicates an expected call of ListUsernamesByRole.
+func (mr *MockStoreMockRecorder) ListUsernamesByRole(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUsernamesByRole", reflect.TypeOf((*MockStore)(nil).ListUsernamesByRole), arg0, arg1)
+}
+
+// ListWhitelistedEntryIDs mocks base method.
+func (m *MockStore) ListWhitelistedEntryIDs(arg0 context.Context, arg1 string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWhitelistedEntryIDs", arg0, arg1)
+	ret0, _ := ret[0].([]string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateAccount indicates an expected call of CreateAccount.
-func (mr *MockStoreMockRecorder) CreateAccount(arg0, arg1 interface{}) *gomock.Call {
+// ListWhitelistedEntryIDs indicates an expected call of ListWhitelistedEntryIDs.
+func (mr *MockStoreMockRecorder) ListWhitelistedEntryIDs(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAccount", reflect.TypeOf((*MockStore)(nil).CreateAccount), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWhitelistedEntryIDs", reflect.TypeOf((*MockStore)(nil).ListWhitelistedEntryIDs), arg0, arg1)
 }
 
-// CreateEntry mocks base method.
-func (m *MockStore) CreateEntry(arg0 context.Context, arg1 db.CreateEntryParams) (db.Entry, error) {
+// MarkBeneficiaryVerified mocks base method.
+func (m *MockStore) MarkBeneficiaryVerified(arg0 context.Context, arg1 int64) (db.Beneficiary, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateEntry", arg0, arg1)
-	ret0, _ := ret[0].(db.Entry)
+	ret := m.ctrl.Call(m, "MarkBeneficiaryVerified", arg0, arg1)
+	ret0, _ := ret[0].(db.Beneficiary)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateEntry indicates an expected call of CreateEntry.
-func (mr *MockStoreMockRecorder) CreateEntry(arg0, arg1 interface{}) *gomock.Call {
+// MarkBeneficiaryVerified indicates an expected call of MarkBeneficiaryVerified.
+func (mr *MockStoreMockRecorder) MarkBeneficiaryVerified(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEntry", reflect.TypeOf((*MockStore)(nil).CreateEntry), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkBeneficiaryVerified", reflect.TypeOf((*MockStore)(nil).MarkBeneficiaryVerified), arg0, arg1)
 }
 
-// CreateSession mocks base method.
-func (m *MockStore) CreateSession(arg0 context.Context, arg1 db.CreateSessionParams) (db.Session, error) {
+// MarkInterestCapitalized mocks base method.
+func (m *MockStore) MarkInterestCapitalized(arg0 context.Context, arg1 db.MarkInterestCapitalizedParams) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateSession", arg0, arg1)
-	ret0, _ := ret[0].(db.Session)
+	ret := m.ctrl.Call(m, "MarkInterestCapitalized", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkInterestCapitalized indicates an expected call of MarkInterestCapitalized.
+func (mr *MockStoreMockRecorder) MarkInterestCapitalized(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkInterestCapitalized", reflect.TypeOf((*MockStore)(nil).MarkInterestCapitalized), arg0, arg1)
+}
+
+// MatchCategorizationRule mocks base method.
+func (m *MockStore) MatchCategorizationRule(arg0 context.Context, arg1 db.MatchCategorizationRuleParams) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MatchCategorizationRule", arg0, arg1)
+	ret0, _ := ret[0].(string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateSession indicates an expected call of CreateSession.
-func (mr *MockStoreMockRecorder) CreateSession(arg0, arg1 interface{}) *gomock.Call {
+// MatchCategorizationRule indicates an expected call of MatchCategorizationRule.
+func (mr *MockStoreMockRecorder) MatchCategorizationRule(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSession", reflect.TypeOf((*MockStore)(nil).CreateSession), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MatchCategorizationRule", reflect.TypeOf((*MockStore)(nil).MatchCategorizationRule), arg0, arg1)
 }
 
-// CreateTransfer mocks base method.
-func (m *MockStore) CreateTransfer(arg0 context.Context, arg1 db.CreateTransferParams) (db.Transfer, error) {
+// OpenDisputeTx mocks base method.
+func (m *MockStore) OpenDisputeTx(arg0 context.Context, arg1 db.OpenDisputeTxParams) (db.OpenDisputeTxResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateTransfer", arg0, arg1)
-	ret0, _ := ret[0].(db.Transfer)
+	ret := m.ctrl.Call(m, "OpenDisputeTx", arg0, arg1)
+	ret0, _ := ret[0].(db.OpenDisputeTxResult)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateTransfer indicates an expected call of CreateTransfer.
-func (mr *MockStoreMockRecorder) CreateTransfer(arg0, arg1 interface{}) *gomock.Call {
+// OpenDisputeTx indicates an expected call of OpenDisputeTx.
+func (mr *MockStoreMockRecorder) OpenDisputeTx(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTransfer", reflect.TypeOf((*MockStore)(nil).CreateTransfer), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OpenDisputeTx", reflect.TypeOf((*MockStore)(nil).OpenDisputeTx), arg0, arg1)
 }
 
-// CreateUser mocks base method.
-func (m *MockStore) CreateUser(arg0 context.Context, arg1 db.CreateUserParams) (db.User, error) {
+// PayPaymentRequestTx mocks base method.
+func (m *MockStore) PayPaymentRequestTx(arg0 context.Context, arg1 db.PayPaymentRequestTxParams) (db.PayPaymentRequestTxResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateUser", arg0, arg1)
+	ret := m.ctrl.Call(m, "PayPaymentRequestTx", arg0, arg1)
+	ret0, _ := ret[0].(db.PayPaymentRequestTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PayPaymentRequestTx indicates an expected call of PayPaymentRequestTx.
+func (mr *MockStoreMockRecorder) PayPaymentRequestTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PayPaymentRequestTx", reflect.TypeOf((*MockStore)(nil).PayPaymentRequestTx), arg0, arg1)
+}
+
+// PostTransferBatchItemTx mocks base method.
+func (m *MockStore) PostTransferBatchItemTx(arg0 context.Context, arg1 db.PostTransferBatchItemTxParams) (db.PostTransferBatchItemTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PostTransferBatchItemTx", arg0, arg1)
+	ret0, _ := ret[0].(db.PostTransferBatchItemTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PostTransferBatchItemTx indicates an expected call of PostTransferBatchItemTx.
+func (mr *MockStoreMockRecorder) PostTransferBatchItemTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PostTransferBatchItemTx", reflect.TypeOf((*MockStore)(nil).PostTransferBatchItemTx), arg0, arg1)
+}
+
+// PostTransferBatchTx mocks base method.
+func (m *MockStore) PostTransferBatchTx(arg0 context.Context, arg1 db.PostTransferBatchTxParams) (db.PostTransferBatchTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PostTransferBatchTx", arg0, arg1)
+	ret0, _ := ret[0].(db.PostTransferBatchTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PostTransferBatchTx indicates an expected call of PostTransferBatchTx.
+func (mr *MockStoreMockRecorder) PostTransferBatchTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PostTransferBatchTx", reflect.TypeOf((*MockStore)(nil).PostTransferBatchTx), arg0, arg1)
+}
+
+// RaiseUserKycLevel mocks base method.
+func (m *MockStore) RaiseUserKycLevel(arg0 context.Context, arg1 db.RaiseUserKycLevelParams) (db.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RaiseUserKycLevel", arg0, arg1)
 	ret0, _ := ret[0].(db.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateUser indicates an expected call of CreateUser.
-func (mr *MockStoreMockRecorder) CreateUser(arg0, arg1 interface{}) *gomock.Call {
+// RaiseUserKycLevel indicates an expected call of RaiseUserKycLevel.
+func (mr *MockStoreMockRecorder) RaiseUserKycLevel(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockStore)(nil).CreateUser), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RaiseUserKycLevel", reflect.TypeOf((*MockStore)(nil).RaiseUserKycLevel), arg0, arg1)
 }
 
-// DeleteAccount mocks base method.
-func (m *MockStore) DeleteAccount(arg0 context.Context, arg1 int64) error {
+// RejectHeldTransferTx mocks base method.
+func (m *MockStore) RejectHeldTransferTx(arg0 context.Context, arg1 db.RejectHeldTransferTxParams) (db.RejectHeldTransferTxResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteAccount", arg0, arg1)
+	ret := m.ctrl.Call(m, "RejectHeldTransferTx", arg0, arg1)
+	ret0, _ := ret[0].(db.RejectHeldTransferTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RejectHeldTransferTx indicates an expected call of RejectHeldTransferTx.
+func (mr *MockStoreMockRecorder) RejectHeldTransferTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RejectHeldTransferTx", reflect.TypeOf((*MockStore)(nil).RejectHeldTransferTx), arg0, arg1)
+}
+
+// ReviewFraudDecision mocks base method.
+func (m *MockStore) ReviewFraudDecision(arg0 context.Context, arg1 db.ReviewFraudDecisionParams) (db.FraudDecision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReviewFraudDecision", arg0, arg1)
+	ret0, _ := ret[0].(db.FraudDecision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReviewFraudDecision indicates an expected call of ReviewFraudDecision.
+func (mr *MockStoreMockRecorder) ReviewFraudDecision(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReviewFraudDecision", reflect.TypeOf((*MockStore)(nil).ReviewFraudDecision), arg0, arg1)
+}
+
+// ReviewKycSubmission mocks base method.
+func (m *MockStore) ReviewKycSubmission(arg0 context.Context, arg1 db.ReviewKycSubmissionParams) (db.KycSubmission, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReviewKycSubmission", arg0, arg1)
+	ret0, _ := ret[0].(db.KycSubmission)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReviewKycSubmission indicates an expected call of ReviewKycSubmission.
+func (mr *MockStoreMockRecorder) ReviewKycSubmission(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReviewKycSubmission", reflect.TypeOf((*MockStore)(nil).ReviewKycSubmission), arg0, arg1)
+}
+
+// ReviewKycSubmissionTx mocks base method.
+func (m *MockStore) ReviewKycSubmissionTx(arg0 context.Context, arg1 db.ReviewKycSubmissionTxParams) (db.ReviewKycSubmissionTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReviewKycSubmissionTx", arg0, arg1)
+	ret0, _ := ret[0].(db.ReviewKycSubmissionTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReviewKycSubmissionTx indicates an expected call of ReviewKycSubmissionTx.
+func (mr *MockStoreMockRecorder) ReviewKycSubmissionTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReviewKycSubmissionTx", reflect.TypeOf((*MockStore)(nil).ReviewKycSubmissionTx), arg0, arg1)
+}
+
+// SetAccountOverdraftLimit mocks base method.
+func (m *MockStore) SetAccountOverdraftLimit(arg0 context.Context, arg1 db.SetAccountOverdraftLimitParams) (db.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetAccountOverdraftLimit", arg0, arg1)
+	ret0, _ := ret[0].(db.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetAccountOverdraftLimit indicates an expected call of SetAccountOverdraftLimit.
+func (mr *MockStoreMockRecorder) SetAccountOverdraftLimit(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetAccountOverdraftLimit", reflect.TypeOf((*MockStore)(nil).SetAccountOverdraftLimit), arg0, arg1)
+}
+
+// SetAccountOverdrawnSince mocks base method.
+func (m *MockStore) SetAccountOverdrawnSince(arg0 context.Context, arg1 db.SetAccountOverdrawnSinceParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetAccountOverdrawnSince", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// DeleteAccount indicates an expected call of DeleteAccount.
-func (mr *MockStoreMockRecorder) DeleteAccount(arg0, arg1 interface{}) *gomock.Call {
+// SetAccountOverdrawnSince indicates an expected call of SetAccountOverdrawnSince.
+func (mr *MockStoreMockRecorder) SetAccountOverdrawnSince(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAccount", reflect.TypeOf((*MockStore)(nil).DeleteAccount), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetAccountOverdrawnSince", reflect.TypeOf((*MockStore)(nil).SetAccountOverdrawnSince), arg0, arg1)
 }
 
-// GetAccount mocks base method.
-func (m *MockStore) GetAccount(arg0 context.Context, arg1 int64) (db.Account, error) {
+// SetFraudDecisionTransfer mocks base method.
+func (m *MockStore) SetFraudDecisionTransfer(arg0 context.Context, arg1 db.SetFraudDecisionTransferParams) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAccount", arg0, arg1)
-	ret0, _ := ret[0].(db.Account)
+	ret := m.ctrl.Call(m, "SetFraudDecisionTransfer", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetFraudDecisionTransfer indicates an expected call of SetFraudDecisionTransfer.
+func (mr *MockStoreMockRecorder) SetFraudDecisionTransfer(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetFraudDecisionTransfer", reflect.TypeOf((*MockStore)(nil).SetFraudDecisionTransfer), arg0, arg1)
+}
+
+// SettleEscrowTx mocks base method.
+func (m *MockStore) SettleEscrowTx(arg0 context.Context, arg1 db.SettleEscrowTxParams) (db.SettleEscrowTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SettleEscrowTx", arg0, arg1)
+	ret0, _ := ret[0].(db.SettleEscrowTxResult)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetAccount indicates an expected call of GetAccount.
-func (mr *MockStoreMockRecorder) GetAccount(arg0, arg1 interface{}) *gomock.Call {
+// SettleEscrowTx indicates an expected call of SettleEscrowTx.
+func (mr *MockStoreMockRecorder) SettleEscrowTx(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccount", reflect.TypeOf((*MockStore)(nil).GetAccount), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SettleEscrowTx", reflect.TypeOf((*MockStore)(nil).SettleEscrowTx), arg0, arg1)
 }
 
-// GetAccountForUpdate mocks base method.
-func (m *MockStore) GetAccountForUpdate(arg0 context.Context, arg1 int64) (db.Account, error) {
+// SnapshotDailyBalances mocks base method.
+func (m *MockStore) SnapshotDailyBalances(arg0 context.Context, arg1 time.Time) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAccountForUpdate", arg0, arg1)
-	ret0, _ := ret[0].(db.Account)
+	ret := m.ctrl.Call(m, "SnapshotDailyBalances", arg0, arg1)
+	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetAccountForUpdate indicates an expected call of GetAccountForUpdate.
-func (mr *MockStoreMockRecorder) GetAccountForUpdate(arg0, arg1 interface{}) *gomock.Call {
+// SnapshotDailyBalances indicates an expected call of SnapshotDailyBalances.
+func (mr *MockStoreMockRecorder) SnapshotDailyBalances(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountForUpdate", reflect.TypeOf((*MockStore)(nil).GetAccountForUpdate), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SnapshotDailyBalances", reflect.TypeOf((*MockStore)(nil).SnapshotDailyBalances), arg0, arg1)
 }
 
-// GetEntry mocks base method.
-func (m *MockStore) GetEntry(arg0 context.Context, arg1 int64) (db.Entry, error) {
+// StartClosingBusinessDay mocks base method.
+func (m *MockStore) StartClosingBusinessDay(arg0 context.Context, arg1 time.Time) (db.BusinessDay, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetEntry", arg0, arg1)
-	ret0, _ := ret[0].(db.Entry)
+	ret := m.ctrl.Call(m, "StartClosingBusinessDay", arg0, arg1)
+	ret0, _ := ret[0].(db.BusinessDay)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetEntry indicates an expected call of GetEntry.
-func (mr *MockStoreMockRecorder) GetEntry(arg0, arg1 interface{}) *gomock.Call {
+// StartClosingBusinessDay indicates an expected call of StartClosingBusinessDay.
+func (mr *MockStoreMockRecorder) StartClosingBusinessDay(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEntry", reflect.TypeOf((*MockStore)(nil).GetEntry), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartClosingBusinessDay", reflect.TypeOf((*MockStore)(nil).StartClosingBusinessDay), arg0, arg1)
 }
 
-// GetSession mocks base method.
-func (m *MockStore) GetSession(arg0 context.Context, arg1 uuid.UUID) (db.Session, error) {
+// SumEntriesByPeriod mocks base method.
+func (m *MockStore) SumEntriesByPeriod(arg0 context.Context, arg1 db.SumEntriesByPeriodParams) ([]db.SumEntriesByPeriodRow, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetSession", arg0, arg1)
-	ret0, _ := ret[0].(db.Session)
+	ret := m.ctrl.Call(m, "SumEntriesByPeriod", arg0, arg1)
+	ret0, _ := ret[0].([]db.SumEntriesByPeriodRow)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetSession indicates an expected call of GetSession.
-func (mr *MockStoreMockRecorder) GetSession(arg0, arg1 interface{}) *gomock.Call {
+// SumEntriesByPeriod indicates an expected call of SumEntriesByPeriod.
+func (mr *MockStoreMockRecorder) SumEntriesByPeriod(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSession", reflect.TypeOf((*MockStore)(nil).GetSession), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SumEntriesByPeriod", reflect.TypeOf((*MockStore)(nil).SumEntriesByPeriod), arg0, arg1)
 }
 
-// GetTransfer mocks base method.
-func (m *MockStore) GetTransfer(arg0 context.Context, arg1 int64) (db.Transfer, error) {
+// SumGLBalances mocks base method.
+func (m *MockStore) SumGLBalances(arg0 context.Context, arg1 db.SumGLBalancesParams) ([]db.SumGLBalancesRow, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetTransfer", arg0, arg1)
-	ret0, _ := ret[0].(db.Transfer)
+	ret := m.ctrl.Call(m, "SumGLBalances", arg0, arg1)
+	ret0, _ := ret[0].([]db.SumGLBalancesRow)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetTransfer indicates an expected call of GetTransfer.
-func (mr *MockStoreMockRecorder) GetTransfer(arg0, arg1 interface{}) *gomock.Call {
+// SumGLBalances indicates an expected call of SumGLBalances.
+func (mr *MockStoreMockRecorder) SumGLBalances(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransfer", reflect.TypeOf((*MockStore)(nil).GetTransfer), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SumGLBalances", reflect.TypeOf((*MockStore)(nil).SumGLBalances), arg0, arg1)
 }
 
-// GetUser mocks base method.
-func (m *MockStore) GetUser(arg0 context.Context, arg1 string) (db.User, error) {
+// SumTransfersFromOwnerSince mocks base method.
+func (m *MockStore) SumTransfersFromOwnerSince(arg0 context.Context, arg1 db.SumTransfersFromOwnerSinceParams) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUser", arg0, arg1)
-	ret0, _ := ret[0].(db.User)
+	ret := m.ctrl.Call(m, "SumTransfersFromOwnerSince", arg0, arg1)
+	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetUser indicates an expected call of GetUser.
-func (mr *MockStoreMockRecorder) GetUser(arg0, arg1 interface{}) *gomock.Call {
+// SumTransfersFromOwnerSince indicates an expected call of SumTransfersFromOwnerSince.
+func (mr *MockStoreMockRecorder) SumTransfersFromOwnerSince(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*MockStore)(nil).GetUser), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SumTransfersFromOwnerSince", reflect.TypeOf((*MockStore)(nil).SumTransfersFromOwnerSince), arg0, arg1)
 }
 
-// ListAccounts mocks base method.
-func (m *MockStore) ListAccounts(arg0 context.Context, arg1 db.ListAccountsParams) ([]db.Account, error) {
+// SumTransfersFromOwnerSinceByCurrency mocks base method.
+func (m *MockStore) SumTransfersFromOwnerSinceByCurrency(arg0 context.Context, arg1 db.SumTransfersFromOwnerSinceByCurrencyParams) ([]db.SumTransfersFromOwnerSinceByCurrencyRow, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListAccounts", arg0, arg1)
-	ret0, _ := ret[0].([]db.Account)
+	ret := m.ctrl.Call(m, "SumTransfersFromOwnerSinceByCurrency", arg0, arg1)
+	ret0, _ := ret[0].([]db.SumTransfersFromOwnerSinceByCurrencyRow)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListAccounts indicates an expected call of ListAccounts.
-func (mr *MockStoreMockRecorder) ListAccounts(arg0, arg1 interface{}) *gomock.Call {
+// SumTransfersFromOwnerSinceByCurrency indicates an expected call of SumTransfersFromOwnerSinceByCurrency.
+func (mr *MockStoreMockRecorder) SumTransfersFromOwnerSinceByCurrency(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAccounts", reflect.TypeOf((*MockStore)(nil).ListAccounts), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SumTransfersFromOwnerSinceByCurrency", reflect.TypeOf((*MockStore)(nil).SumTransfersFromOwnerSinceByCurrency), arg0, arg1)
 }
 
-// ListEntry mocks base method.
-func (m *MockStore) ListEntry(arg0 context.Context, arg1 db.ListEntryParams) ([]db.Entry, error) {
+// SumTransfersToAccountSince mocks base method.
+func (m *MockStore) SumTransfersToAccountSince(arg0 context.Context, arg1 db.SumTransfersToAccountSinceParams) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListEntry", arg0, arg1)
-	ret0, _ := ret[0].([]db.Entry)
+	ret := m.ctrl.Call(m, "SumTransfersToAccountSince", arg0, arg1)
+	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListEntry indicates an expected call of ListEntry.
-func (mr *MockStoreMockRecorder) ListEntry(arg0, arg1 interface{}) *gomock.Call {
+// SumTransfersToAccountSince indicates an expected call of SumTransfersToAccountSince.
+func (mr *MockStoreMockRecorder) SumTransfersToAccountSince(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEntry", reflect.TypeOf((*MockStore)(nil).ListEntry), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SumTransfersToAccountSince", reflect.TypeOf((*MockStore)(nil).SumTransfersToAccountSince), arg0, arg1)
 }
 
-// ListTransfers mocks base method.
-func (m *MockStore) ListTransfers(arg0 context.Context, arg1 db.ListTransfersParams) ([]db.Transfer, error) {
+// SumUncapitalizedInterest mocks base method.
+func (m *MockStore) SumUncapitalizedInterest(arg0 context.Context, arg1 db.SumUncapitalizedInterestParams) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListTransfers", arg0, arg1)
-	ret0, _ := ret[0].([]db.Transfer)
+	ret := m.ctrl.Call(m, "SumUncapitalizedInterest", arg0, arg1)
+	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListTransfers indicates an expected call of ListTransfers.
-func (mr *MockStoreMockRecorder) ListTransfers(arg0, arg1 interface{}) *gomock.Call {
+// SumUncapitalizedInterest indicates an expected call of SumUncapitalizedInterest.
+func (mr *MockStoreMockRecorder) SumUncapitalizedInterest(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTransfers", reflect.TypeOf((*MockStore)(nil).ListTransfers), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SumUncapitalizedInterest", reflect.TypeOf((*MockStore)(nil).SumUncapitalizedInterest), arg0, arg1)
+}
+
+// SummarizeOverdrafts mocks base method.
+func (m *MockStore) SummarizeOverdrafts(arg0 context.Context) ([]db.SummarizeOverdraftsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SummarizeOverdrafts", arg0)
+	ret0, _ := ret[0].([]db.SummarizeOverdraftsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SummarizeOverdrafts indicates an expected call of SummarizeOverdrafts.
+func (mr *MockStoreMockRecorder) SummarizeOverdrafts(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SummarizeOverdrafts", reflect.TypeOf((*MockStore)(nil).SummarizeOverdrafts), arg0)
 }
 
 // TransferTx mocks base method.
@@ -290,6 +2520,111 @@ func (mr *MockStoreMockRecorder) TransferTx(arg0, arg1 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TransferTx", reflect.TypeOf((*MockStore)(nil).TransferTx), arg0, arg1)
 }
 
+// TransitionDisputeTx mocks base method.
+func (m *MockStore) TransitionDisputeTx(arg0 context.Context, arg1 db.TransitionDisputeTxParams) (db.TransitionDisputeTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TransitionDisputeTx", arg0, arg1)
+	ret0, _ := ret[0].(db.TransitionDisputeTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TransitionDisputeTx indicates an expected call of TransitionDisputeTx.
+func (mr *MockStoreMockRecorder) TransitionDisputeTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TransitionDisputeTx", reflect.TypeOf((*MockStore)(nil).TransitionDisputeTx), arg0, arg1)
+}
+
+// UpdateBeneficiaryNickname mocks base method.
+func (m *MockStore) UpdateBeneficiaryNickname(arg0 context.Context, arg1 db.UpdateBeneficiaryNicknameParams) (db.Beneficiary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateBeneficiaryNickname", arg0, arg1)
+	ret0, _ := ret[0].(db.Beneficiary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateBeneficiaryNickname indicates an expected call of UpdateBeneficiaryNickname.
+func (mr *MockStoreMockRecorder) UpdateBeneficiaryNickname(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBeneficiaryNickname", reflect.TypeOf((*MockStore)(nil).UpdateBeneficiaryNickname), arg0, arg1)
+}
+
+// UpdateDispute mocks base method.
+func (m *MockStore) UpdateDispute(arg0 context.Context, arg1 db.UpdateDisputeParams) (db.Dispute, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateDispute", arg0, arg1)
+	ret0, _ := ret[0].(db.Dispute)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateDispute indicates an expected call of UpdateDispute.
+func (mr *MockStoreMockRecorder) UpdateDispute(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateDispute", reflect.TypeOf((*MockStore)(nil).UpdateDispute), arg0, arg1)
+}
+
+// UpdateEscrow mocks base method.
+func (m *MockStore) UpdateEscrow(arg0 context.Context, arg1 db.UpdateEscrowParams) (db.Escrow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateEscrow", arg0, arg1)
+	ret0, _ := ret[0].(db.Escrow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateEscrow indicates an expected call of UpdateEscrow.
+func (mr *MockStoreMockRecorder) UpdateEscrow(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateEscrow", reflect.TypeOf((*MockStore)(nil).UpdateEscrow), arg0, arg1)
+}
+
+// UpdatePaymentRequestStatus mocks base method.
+func (m *MockStore) UpdatePaymentRequestStatus(arg0 context.Context, arg1 db.UpdatePaymentRequestStatusParams) (db.PaymentRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePaymentRequestStatus", arg0, arg1)
+	ret0, _ := ret[0].(db.PaymentRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdatePaymentRequestStatus indicates an expected call of UpdatePaymentRequestStatus.
+func (mr *MockStoreMockRecorder) UpdatePaymentRequestStatus(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePaymentRequestStatus", reflect.TypeOf((*MockStore)(nil).UpdatePaymentRequestStatus), arg0, arg1)
+}
+
+// UpdateTransferBatchItem mocks base method.
+func (m *MockStore) UpdateTransferBatchItem(arg0 context.Context, arg1 db.UpdateTransferBatchItemParams) (db.TransferBatchItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTransferBatchItem", arg0, arg1)
+	ret0, _ := ret[0].(db.TransferBatchItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateTransferBatchItem indicates an expected call of UpdateTransferBatchItem.
+func (mr *MockStoreMockRecorder) UpdateTransferBatchItem(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTransferBatchItem", reflect.TypeOf((*MockStore)(nil).UpdateTransferBatchItem), arg0, arg1)
+}
+
+// UpdateTransferBatchProgress mocks base method.
+func (m *MockStore) UpdateTransferBatchProgress(arg0 context.Context, arg1 int64) (db.TransferBatch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTransferBatchProgress", arg0, arg1)
+	ret0, _ := ret[0].(db.TransferBatch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateTransferBatchProgress indicates an expected call of UpdateTransferBatchProgress.
+func (mr *MockStoreMockRecorder) UpdateTransferBatchProgress(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTransferBatchProgress", reflect.TypeOf((*MockStore)(nil).UpdateTransferBatchProgress), arg0, arg1)
+}
+
 // UpdateUser mocks base method.
 func (m *MockStore) UpdateUser(arg0 context.Context, arg1 db.UpdateUserParams) (db.User, error) {
 	m.ctrl.T.Helper()
@@ -319,3 +2654,18 @@ func (mr *MockStoreMockRecorder) UpdatedAccount(arg0, arg1 interface{}) *gomock.
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatedAccount", reflect.TypeOf((*MockStore)(nil).UpdatedAccount), arg0, arg1)
 }
+
+// UpsertCurrency mocks base method.
+func (m *MockStore) UpsertCurrency(arg0 context.Context, arg1 db.UpsertCurrencyParams) (db.Currency, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertCurrency", arg0, arg1)
+	ret0, _ := ret[0].(db.Currency)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertCurrency indicates an expected call of UpsertCurrency.
+func (mr *MockStoreMockRecorder) UpsertCurrency(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertCurrency", reflect.TypeOf((*MockStore)(nil).UpsertCurrency), arg0, arg1)
+}
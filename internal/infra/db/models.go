@@ -5,24 +5,402 @@
 package db
 
 import (
+	"database/sql"
+	"encoding/json"
 	"time"
 
 	"github.com/google/uuid"
 )
 
 type Account struct {
-	ID        int64     `json:"id"`
-	Owner     string    `json:"owner"`
+	ID            int64     `json:"id"`
+	Owner         string    `json:"owner"`
+	Balance       int64     `json:"balance"`
+	Currency      string    `json:"currency"`
+	CreatedAt     time.Time `json:"created_at"`
+	AccountNumber string    `json:"account_number"`
+	Product       string    `json:"product"`
+	// how far below zero the balance may go, approved by an admin
+	OverdraftLimit int64 `json:"overdraft_limit"`
+	// when the balance last went below zero within an overdraft limit, null when not overdrawn
+	OverdrawnSince *time.Time `json:"overdrawn_since"`
+}
+
+type AccountProduct struct {
+	Code string `json:"code"`
+	Name string `json:"name"`
+	// nominal annual rate in parts per million, e.g. 20000 for 2%
+	AnnualRatePpm int64     `json:"annual_rate_ppm"`
+	IsInternal    bool      `json:"is_internal"`
+	CreatedAt     time.Time `json:"created_at"`
+	// annual rate charged on overdrawn balances in parts per million
+	OverdraftRatePpm int64 `json:"overdraft_rate_ppm"`
+	// general ledger account the entries of accounts of the product are posted to
+	GlAccount string `json:"gl_account"`
+	// general ledger account overdrawn balances are reported in, gl_account when null
+	OverdraftGlAccount sql.NullString `json:"overdraft_gl_account"`
+}
+
+type Beneficiary struct {
+	ID            int64  `json:"id"`
+	Owner         string `json:"owner"`
+	AccountNumber string `json:"account_number"`
+	Nickname      string `json:"nickname"`
+	Currency      string `json:"currency"`
+	// the account number resolved to an account in currency
+	IsVerified bool      `json:"is_verified"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type BusinessDay struct {
+	BusinessDate time.Time `json:"business_date"`
+	// postings go to the one open day; a closing day runs its end-of-day steps
+	Status   string    `json:"status"`
+	OpenedAt time.Time `json:"opened_at"`
+	// when the end of day started; accounts opened later are not in its daily balances
+	ClosingAt sql.NullTime `json:"closing_at"`
+	ClosedAt  sql.NullTime `json:"closed_at"`
+}
+
+type CategorizationRule struct {
+	ID                    int64         `json:"id"`
+	Owner                 string        `json:"owner"`
+	Category              string        `json:"category"`
+	DescriptionContains   string        `json:"description_contains"`
+	MerchantCategory      string        `json:"merchant_category"`
+	CounterpartyAccountID sql.NullInt64 `json:"counterparty_account_id"`
+	Priority              int32         `json:"priority"`
+	CreatedAt             time.Time     `json:"created_at"`
+}
+
+type Currency struct {
+	Code      string    `json:"code"`
+	Name      string    `json:"name"`
+	Exponent  int32     `json:"exponent"`
+	Enabled   bool      `json:"enabled"`
+	UpdatedAt time.Time `json:"updated_at"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type DailyBalance struct {
+	AccountID    int64     `json:"account_id"`
+	BusinessDate time.Time `json:"business_date"`
+	// balance at the end of the business date
 	Balance   int64     `json:"balance"`
-	Currency  string    `json:"currency"`
 	CreatedAt time.Time `json:"created_at"`
 }
 
+type Dispute struct {
+	ID         int64 `json:"id"`
+	TransferID int64 `json:"transfer_id"`
+	// owner of the account the transfer was sent from, who opened the dispute
+	Username    string `json:"username"`
+	Reason      string `json:"reason"`
+	Description string `json:"description"`
+	// amount disputed, at most the amount of the transfer
+	Amount   int64  `json:"amount"`
+	Currency string `json:"currency"`
+	Status   string `json:"status"`
+	// transfer crediting the amount to the customer while the dispute is decided
+	ProvisionalCreditTransferID sql.NullInt64 `json:"provisional_credit_transfer_id"`
+	// reversal of the transfer when won, or recovery of the provisional credit when lost or withdrawn
+	ResolutionTransferID sql.NullInt64  `json:"resolution_transfer_id"`
+	AssignedTo           sql.NullString `json:"assigned_to"`
+	Resolution           string         `json:"resolution"`
+	ResolvedBy           sql.NullString `json:"resolved_by"`
+	// when the dispute must be decided by
+	DueAt         time.Time    `json:"due_at"`
+	SlaWarnedAt   sql.NullTime `json:"sla_warned_at"`
+	SlaBreachedAt sql.NullTime `json:"sla_breached_at"`
+	ResolvedAt    sql.NullTime `json:"resolved_at"`
+	UpdatedAt     time.Time    `json:"updated_at"`
+	CreatedAt     time.Time    `json:"created_at"`
+}
+
+type DisputeEvent struct {
+	ID        int64  `json:"id"`
+	DisputeID int64  `json:"dispute_id"`
+	Actor     string `json:"actor"`
+	// empty when the dispute was opened
+	FromStatus string    `json:"from_status"`
+	ToStatus   string    `json:"to_status"`
+	Note       string    `json:"note"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type DisputeEvidence struct {
+	ID          int64  `json:"id"`
+	DisputeID   int64  `json:"dispute_id"`
+	SubmittedBy string `json:"submitted_by"`
+	Note        string `json:"note"`
+	FileName    string `json:"file_name"`
+	ContentType string `json:"content_type"`
+	Size        int64  `json:"size"`
+	Sha256      string `json:"sha256"`
+	// key of the file in the document store; empty for a note without a file
+	StorageKey string    `json:"storage_key"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
 type Entry struct {
 	ID        int64 `json:"id"`
 	AccountID int64 `json:"account_id"`
 	// can be negative or positive
-	Amount    int64     `json:"amount"`
+	Amount            int64     `json:"amount"`
+	CreatedAt         time.Time `json:"created_at"`
+	TransferID        *int64    `json:"transfer_id"`
+	Description       string    `json:"description"`
+	ExternalReference string    `json:"external_reference"`
+	MerchantCategory  string    `json:"merchant_category"`
+	// set from the account owner's categorization rules
+	Category string `json:"category"`
+	// business day the entry was posted to
+	BusinessDate time.Time `json:"business_date"`
+}
+
+type EodStep struct {
+	BusinessDate time.Time `json:"business_date"`
+	Name         string    `json:"name"`
+	// steps run in ascending position, each once it succeeded
+	Position   int32        `json:"position"`
+	Status     string       `json:"status"`
+	Attempts   int32        `json:"attempts"`
+	Error      string       `json:"error"`
+	StartedAt  sql.NullTime `json:"started_at"`
+	FinishedAt sql.NullTime `json:"finished_at"`
+}
+
+type Escrow struct {
+	ID    int64  `json:"id"`
+	Payer string `json:"payer"`
+	// owner of the account the money is released to
+	Payee         string `json:"payee"`
+	FromAccountID int64  `json:"from_account_id"`
+	ToAccountID   int64  `json:"to_account_id"`
+	// bank escrow account holding the money
+	EscrowAccountID int64  `json:"escrow_account_id"`
+	Amount          int64  `json:"amount"`
+	Currency        string `json:"currency"`
+	Description     string `json:"description"`
+	Status          string `json:"status"`
+	// when a held escrow is released to the payee without confirmation
+	ReleaseAt time.Time `json:"release_at"`
+	// transfer from the payer into the escrow account
+	FundingTransferID int64 `json:"funding_transfer_id"`
+	// transfer out of the escrow account to the payee when released or to the payer when refunded
+	SettlementTransferID sql.NullInt64 `json:"settlement_transfer_id"`
+	DisputeReason        string        `json:"dispute_reason"`
+	DisputedAt           sql.NullTime  `json:"disputed_at"`
+	Note                 string        `json:"note"`
+	// user who released or refunded the escrow; null when released on timeout
+	SettledBy sql.NullString `json:"settled_by"`
+	SettledAt sql.NullTime   `json:"settled_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	CreatedAt time.Time      `json:"created_at"`
+}
+
+type FeeSchedule struct {
+	ID       int64  `json:"id"`
+	Currency string `json:"currency"`
+	MinFee   int64  `json:"min_fee"`
+	// no cap when null
+	MaxFee    sql.NullInt64 `json:"max_fee"`
+	CreatedAt time.Time     `json:"created_at"`
+}
+
+type FeeScheduleBand struct {
+	ID         int64 `json:"id"`
+	ScheduleID int64 `json:"schedule_id"`
+	// largest transfer amount the band applies to, unbounded when null
+	UpTo    sql.NullInt64 `json:"up_to"`
+	FlatFee int64         `json:"flat_fee"`
+	// percentage of the amount in parts per million, e.g. 5000 for 0.5%
+	RatePpm int64 `json:"rate_ppm"`
+}
+
+type FraudDecision struct {
+	ID            int64  `json:"id"`
+	Username      string `json:"username"`
+	FromAccountID int64  `json:"from_account_id"`
+	ToAccountID   int64  `json:"to_account_id"`
+	Amount        int64  `json:"amount"`
+	Currency      string `json:"currency"`
+	ClientIp      string `json:"client_ip"`
+	Score         int32  `json:"score"`
+	Outcome       string `json:"outcome"`
+	// rules that matched, each with its score and details
+	Hits json.RawMessage `json:"hits"`
+	// transfer parameters, posted when a held transfer is approved
+	Transfer json.RawMessage `json:"transfer"`
+	// allowed or blocked, or pending review until approved or rejected
+	Status     string         `json:"status"`
+	TransferID sql.NullInt64  `json:"transfer_id"`
+	ReviewedBy sql.NullString `json:"reviewed_by"`
+	ReviewNote string         `json:"review_note"`
+	ReviewedAt sql.NullTime   `json:"reviewed_at"`
+	CreatedAt  time.Time      `json:"created_at"`
+	// what the transfer pays: a plain transfer, a payment request, a row of a transfer batch or an escrow
+	Kind string `json:"kind"`
+	// what posting a held transfer of its kind settles besides the transfer, e.g. the payment request it pays
+	Payload json.RawMessage `json:"payload"`
+}
+
+type GlAccount struct {
+	Code      string    `json:"code"`
+	Name      string    `json:"name"`
+	Type      string    `json:"type"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type InterestAccrual struct {
+	ID           int64     `json:"id"`
+	AccountID    int64     `json:"account_id"`
+	BusinessDate time.Time `json:"business_date"`
+	// balance at the end of the business date (UTC)
+	ClosingBalance int64 `json:"closing_balance"`
+	AnnualRatePpm  int64 `json:"annual_rate_ppm"`
+	DayCount       int32 `json:"day_count"`
+	// interest in millionths of a minor unit
+	AmountMicros     int64         `json:"amount_micros"`
+	CapitalizationID sql.NullInt64 `json:"capitalization_id"`
+	CreatedAt        time.Time     `json:"created_at"`
+}
+
+type InterestCapitalization struct {
+	ID        int64 `json:"id"`
+	AccountID int64 `json:"account_id"`
+	// first day of the month the interest was accrued in
+	Period time.Time `json:"period"`
+	// accruals of the period plus the carry of the previous capitalization
+	AccruedMicros int64 `json:"accrued_micros"`
+	Amount        int64 `json:"amount"`
+	// fraction of a minor unit carried to the next capitalization
+	CarryMicros int64         `json:"carry_micros"`
+	TransferID  sql.NullInt64 `json:"transfer_id"`
+	CreatedAt   time.Time     `json:"created_at"`
+}
+
+type KycDocument struct {
+	ID           int64  `json:"id"`
+	SubmissionID int64  `json:"submission_id"`
+	Kind         string `json:"kind"`
+	FileName     string `json:"file_name"`
+	ContentType  string `json:"content_type"`
+	Size         int64  `json:"size"`
+	Sha256       string `json:"sha256"`
+	// key of the file in the document store
+	StorageKey string    `json:"storage_key"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type KycLevel struct {
+	Level int32  `json:"level"`
+	Name  string `json:"name"`
+	// no limit when null
+	MaxAccounts sql.NullInt32 `json:"max_accounts"`
+}
+
+type KycLevelLimit struct {
+	Level    int32  `json:"level"`
+	Currency string `json:"currency"`
+	// in minor units of the currency; no limit when null
+	MaxTransferAmount sql.NullInt64 `json:"max_transfer_amount"`
+	// total sent in the currency since midnight UTC, in its minor units; no limit when null
+	DailyTransferLimit sql.NullInt64 `json:"daily_transfer_limit"`
+}
+
+type KycSubmission struct {
+	ID       int64  `json:"id"`
+	Username string `json:"username"`
+	// level requested
+	Level      int32          `json:"level"`
+	Status     string         `json:"status"`
+	Reason     string         `json:"reason"`
+	ReviewedBy sql.NullString `json:"reviewed_by"`
+	ReviewedAt sql.NullTime   `json:"reviewed_at"`
+	CreatedAt  time.Time      `json:"created_at"`
+}
+
+type Notification struct {
+	ID        int64     `json:"id"`
+	Username  string    `json:"username"`
+	Kind      string    `json:"kind"`
+	Message   string    `json:"message"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type OverdraftApproval struct {
+	ID             int64     `json:"id"`
+	AccountID      int64     `json:"account_id"`
+	OverdraftLimit int64     `json:"overdraft_limit"`
+	ApprovedBy     string    `json:"approved_by"`
+	Reason         string    `json:"reason"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+type OverdraftEvent struct {
+	ID        int64  `json:"id"`
+	AccountID int64  `json:"account_id"`
+	Kind      string `json:"kind"`
+	// balance right after the transfer that crossed zero
+	Balance    int64        `json:"balance"`
+	NotifiedAt sql.NullTime `json:"notified_at"`
+	CreatedAt  time.Time    `json:"created_at"`
+}
+
+type PaymentRequest struct {
+	ID        int64  `json:"id"`
+	Requester string `json:"requester"`
+	// null for link requests until someone pays them
+	Payer       sql.NullString `json:"payer"`
+	ToAccountID int64          `json:"to_account_id"`
+	Amount      int64          `json:"amount"`
+	Currency    string         `json:"currency"`
+	Memo        string         `json:"memo"`
+	// pending, paid, declined, cancelled or expired
+	Status     string        `json:"status"`
+	TransferID sql.NullInt64 `json:"transfer_id"`
+	ExpiresAt  time.Time     `json:"expires_at"`
+	UpdatedAt  time.Time     `json:"updated_at"`
+	CreatedAt  time.Time     `json:"created_at"`
+}
+
+type RecipientLookup struct {
+	ID int64 `json:"id"`
+	// the user who looked the recipient up
+	Username   string `json:"username"`
+	Identifier string `json:"identifier"`
+	// lookup or transfer
+	Purpose   string    `json:"purpose"`
+	Found     bool      `json:"found"`
+	ClientIp  string    `json:"client_ip"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type ScreeningMatch struct {
+	ID      int64  `json:"id"`
+	Subject string `json:"subject"`
+	// name screened: the full name of a user signing up, or of the owner of the account a transfer is sent to
+	Name string `json:"name"`
+	// user signing up or sending the transfer; a blocked signup has no user
+	Username string `json:"username"`
+	// account a transfer is sent to
+	AccountID sql.NullInt64 `json:"account_id"`
+	List      string        `json:"list"`
+	EntryID   string        `json:"entry_id"`
+	EntryName string        `json:"entry_name"`
+	Score     float64       `json:"score"`
+	Action    string        `json:"action"`
+	CreatedAt time.Time     `json:"created_at"`
+}
+
+type ScreeningWhitelist struct {
+	ID int64 `json:"id"`
+	// normalized name, words sorted
+	Name      string    `json:"name"`
+	EntryID   string    `json:"entry_id"`
+	Reason    string    `json:"reason"`
+	CreatedBy string    `json:"created_by"`
 	CreatedAt time.Time `json:"created_at"`
 }
 
@@ -42,8 +420,54 @@ type Transfer struct {
 	FromAccountID int64 `json:"from_account_id"`
 	ToAccountID   int64 `json:"to_account_id"`
 	// must be positive
-	Amount    int64     `json:"amount"`
-	CreatedAt time.Time `json:"created_at"`
+	Amount            int64     `json:"amount"`
+	CreatedAt         time.Time `json:"created_at"`
+	Description       string    `json:"description"`
+	ExternalReference string    `json:"external_reference"`
+	// ISO 18245 merchant category code
+	MerchantCategory string `json:"merchant_category"`
+	// paid by the sender to the fee revenue account on top of amount
+	Fee          int64     `json:"fee"`
+	BusinessDate time.Time `json:"business_date"`
+	// payment by a customer, internal posting from one of the bank's accounts, or forced posting the bank took from a customer account, such as a chargeback or overdraft interest
+	Kind string `json:"kind"`
+}
+
+type TransferBatch struct {
+	ID            int64  `json:"id"`
+	Owner         string `json:"owner"`
+	FromAccountID int64  `json:"from_account_id"`
+	Currency      string `json:"currency"`
+	// all_or_nothing or best_effort
+	Mode string `json:"mode"`
+	// pending, processing, completed or failed
+	Status        string       `json:"status"`
+	TotalRows     int32        `json:"total_rows"`
+	TotalAmount   int64        `json:"total_amount"`
+	ProcessedRows int32        `json:"processed_rows"`
+	SucceededRows int32        `json:"succeeded_rows"`
+	FailedRows    int32        `json:"failed_rows"`
+	Error         string       `json:"error"`
+	CompletedAt   sql.NullTime `json:"completed_at"`
+	UpdatedAt     time.Time    `json:"updated_at"`
+	CreatedAt     time.Time    `json:"created_at"`
+	ClientIp      string       `json:"client_ip"`
+	HeldRows      int32        `json:"held_rows"`
+}
+
+type TransferBatchItem struct {
+	ID      int64 `json:"id"`
+	BatchID int64 `json:"batch_id"`
+	// position of the row in the uploaded file, from 1
+	RowNumber   int32  `json:"row_number"`
+	ToAccountID int64  `json:"to_account_id"`
+	Amount      int64  `json:"amount"`
+	Reference   string `json:"reference"`
+	// pending, held for fraud review, succeeded or failed
+	Status     string        `json:"status"`
+	Error      string        `json:"error"`
+	TransferID sql.NullInt64 `json:"transfer_id"`
+	UpdatedAt  time.Time     `json:"updated_at"`
 }
 
 type User struct {
@@ -53,4 +477,14 @@ type User struct {
 	Email             string    `json:"email"`
 	PasswordChangedAt time.Time `json:"password_changed_at"`
 	CreatedAt         time.Time `json:"created_at"`
+	IsEmailVerified   bool      `json:"is_email_verified"`
+	Tier              string    `json:"tier"`
+	Role              string    `json:"role"`
+	KycLevel          int32     `json:"kyc_level"`
+}
+
+type UserTier struct {
+	Code       string `json:"code"`
+	Name       string `json:"name"`
+	FeesWaived bool   `json:"fees_waived"`
 }
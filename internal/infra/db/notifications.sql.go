@@ -0,0 +1,82 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: notifications.sql
+
+package db
+
+import (
+	"context"
+)
+
+const createNotification = `-- name: CreateNotification :one
+INSERT INTO notifications (
+    username,
+    kind,
+    message
+) VALUES (
+    $1, $2, $3
+) RETURNING id, username, kind, message, created_at
+`
+
+type CreateNotificationParams struct {
+	Username string `json:"username"`
+	Kind     string `json:"kind"`
+	Message  string `json:"message"`
+}
+
+func (q *Queries) CreateNotification(ctx context.Context, arg CreateNotificationParams) (Notification, error) {
+	row := q.db.QueryRowContext(ctx, createNotification, arg.Username, arg.Kind, arg.Message)
+	var i Notification
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.Kind,
+		&i.Message,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const listNotificationsBefore = `-- name: ListNotificationsBefore :many
+SELECT id, username, kind, message, created_at FROM notifications
+WHERE username = $1
+    AND ($2::bigint = 0 OR id < $2::bigint)
+ORDER BY id DESC
+LIMIT $3
+`
+
+type ListNotificationsBeforeParams struct {
+	Username  string `json:"username"`
+	BeforeID  int64  `json:"before_id"`
+	PageLimit int32  `json:"page_limit"`
+}
+
+func (q *Queries) ListNotificationsBefore(ctx context.Context, arg ListNotificationsBeforeParams) ([]Notification, error) {
+	rows, err := q.db.QueryContext(ctx, listNotificationsBefore, arg.Username, arg.BeforeID, arg.PageLimit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Notification{}
+	for rows.Next() {
+		var i Notification
+		if err := rows.Scan(
+			&i.ID,
+			&i.Username,
+			&i.Kind,
+			&i.Message,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
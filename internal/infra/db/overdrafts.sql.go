@@ -0,0 +1,319 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: overdrafts.sql
+
+package db
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+const claimOverdraftEvents = `-- name: ClaimOverdraftEvents :many
+WITH claimed AS (
+    UPDATE overdraft_events
+    SET notified_at = now()
+    WHERE id IN (
+        SELECT id FROM overdraft_events
+        WHERE notified_at IS NULL
+        ORDER BY id
+        LIMIT $1
+        FOR UPDATE SKIP LOCKED
+    )
+    RETURNING id, account_id, kind, balance, created_at
+)
+SELECT c.id, c.account_id, c.kind, c.balance, c.created_at, a.owner, a.currency, a.account_number, a.overdraft_limit
+FROM claimed c
+JOIN accounts a ON a.id = c.account_id
+ORDER BY c.id
+`
+
+type ClaimOverdraftEventsRow struct {
+	ID             int64     `json:"id"`
+	AccountID      int64     `json:"account_id"`
+	Kind           string    `json:"kind"`
+	Balance        int64     `json:"balance"`
+	CreatedAt      time.Time `json:"created_at"`
+	Owner          string    `json:"owner"`
+	Currency       string    `json:"currency"`
+	AccountNumber  string    `json:"account_number"`
+	OverdraftLimit int64     `json:"overdraft_limit"`
+}
+
+// Marks a page of undelivered events notified and returns them. Events
+// claimed by a concurrent worker are skipped, so each is delivered once.
+func (q *Queries) ClaimOverdraftEvents(ctx context.Context, pageLimit int32) ([]ClaimOverdraftEventsRow, error) {
+	rows, err := q.db.QueryContext(ctx, claimOverdraftEvents, pageLimit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ClaimOverdraftEventsRow{}
+	for rows.Next() {
+		var i ClaimOverdraftEventsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.AccountID,
+			&i.Kind,
+			&i.Balance,
+			&i.CreatedAt,
+			&i.Owner,
+			&i.Currency,
+			&i.AccountNumber,
+			&i.OverdraftLimit,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const createOverdraftApproval = `-- name: CreateOverdraftApproval :one
+INSERT INTO overdraft_approvals (account_id, overdraft_limit, approved_by, reason)
+VALUES ($1, $2, $3, $4)
+RETURNING id, account_id, overdraft_limit, approved_by, reason, created_at
+`
+
+type CreateOverdraftApprovalParams struct {
+	AccountID      int64  `json:"account_id"`
+	OverdraftLimit int64  `json:"overdraft_limit"`
+	ApprovedBy     string `json:"approved_by"`
+	Reason         string `json:"reason"`
+}
+
+func (q *Queries) CreateOverdraftApproval(ctx context.Context, arg CreateOverdraftApprovalParams) (OverdraftApproval, error) {
+	row := q.db.QueryRowContext(ctx, createOverdraftApproval,
+		arg.AccountID,
+		arg.OverdraftLimit,
+		arg.ApprovedBy,
+		arg.Reason,
+	)
+	var i OverdraftApproval
+	err := row.Scan(
+		&i.ID,
+		&i.AccountID,
+		&i.OverdraftLimit,
+		&i.ApprovedBy,
+		&i.Reason,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const createOverdraftEvent = `-- name: CreateOverdraftEvent :one
+INSERT INTO overdraft_events (account_id, kind, balance)
+VALUES ($1, $2, $3)
+RETURNING id, account_id, kind, balance, notified_at, created_at
+`
+
+type CreateOverdraftEventParams struct {
+	AccountID int64  `json:"account_id"`
+	Kind      string `json:"kind"`
+	Balance   int64  `json:"balance"`
+}
+
+func (q *Queries) CreateOverdraftEvent(ctx context.Context, arg CreateOverdraftEventParams) (OverdraftEvent, error) {
+	row := q.db.QueryRowContext(ctx, createOverdraftEvent, arg.AccountID, arg.Kind, arg.Balance)
+	var i OverdraftEvent
+	err := row.Scan(
+		&i.ID,
+		&i.AccountID,
+		&i.Kind,
+		&i.Balance,
+		&i.NotifiedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const listOverdraftApprovals = `-- name: ListOverdraftApprovals :many
+SELECT id, account_id, overdraft_limit, approved_by, reason, created_at FROM overdraft_approvals
+WHERE account_id = $1
+ORDER BY created_at DESC, id DESC
+`
+
+func (q *Queries) ListOverdraftApprovals(ctx context.Context, accountID int64) ([]OverdraftApproval, error) {
+	rows, err := q.db.QueryContext(ctx, listOverdraftApprovals, accountID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []OverdraftApproval{}
+	for rows.Next() {
+		var i OverdraftApproval
+		if err := rows.Scan(
+			&i.ID,
+			&i.AccountID,
+			&i.OverdraftLimit,
+			&i.ApprovedBy,
+			&i.Reason,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listOverdrawnAccounts = `-- name: ListOverdrawnAccounts :many
+SELECT id, owner, balance, currency, created_at, account_number, product, overdraft_limit, overdrawn_since FROM accounts
+WHERE balance < 0 AND overdrawn_since IS NOT NULL
+    AND ($1::varchar IS NULL OR currency = $1::varchar)
+    AND id > $2
+ORDER BY id
+LIMIT $3
+`
+
+type ListOverdrawnAccountsParams struct {
+	Currency  sql.NullString `json:"currency"`
+	AfterID   int64          `json:"after_id"`
+	PageLimit int32          `json:"page_limit"`
+}
+
+// Like SummarizeOverdrafts, only lists customer accounts.
+func (q *Queries) ListOverdrawnAccounts(ctx context.Context, arg ListOverdrawnAccountsParams) ([]Account, error) {
+	rows, err := q.db.QueryContext(ctx, listOverdrawnAccounts, arg.Currency, arg.AfterID, arg.PageLimit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Account{}
+	for rows.Next() {
+		var i Account
+		if err := rows.Scan(
+			&i.ID,
+			&i.Owner,
+			&i.Balance,
+			&i.Currency,
+			&i.CreatedAt,
+			&i.AccountNumber,
+			&i.Product,
+			&i.OverdraftLimit,
+			&i.OverdrawnSince,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const setAccountOverdraftLimit = `-- name: SetAccountOverdraftLimit :one
+UPDATE accounts
+SET overdraft_limit = $1
+WHERE id = $2
+RETURNING id, owner, balance, currency, created_at, account_number, product, overdraft_limit, overdrawn_since
+`
+
+type SetAccountOverdraftLimitParams struct {
+	OverdraftLimit int64 `json:"overdraft_limit"`
+	ID             int64 `json:"id"`
+}
+
+func (q *Queries) SetAccountOverdraftLimit(ctx context.Context, arg SetAccountOverdraftLimitParams) (Account, error) {
+	row := q.db.QueryRowContext(ctx, setAccountOverdraftLimit, arg.OverdraftLimit, arg.ID)
+	var i Account
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.Balance,
+		&i.Currency,
+		&i.CreatedAt,
+		&i.AccountNumber,
+		&i.Product,
+		&i.OverdraftLimit,
+		&i.OverdrawnSince,
+	)
+	return i, err
+}
+
+const setAccountOverdrawnSince = `-- name: SetAccountOverdrawnSince :exec
+UPDATE accounts
+SET overdrawn_since = $1
+WHERE id = $2
+`
+
+type SetAccountOverdrawnSinceParams struct {
+	OverdrawnSince *time.Time `json:"overdrawn_since"`
+	ID             int64      `json:"id"`
+}
+
+func (q *Queries) SetAccountOverdrawnSince(ctx context.Context, arg SetAccountOverdrawnSinceParams) error {
+	_, err := q.db.ExecContext(ctx, setAccountOverdrawnSince, arg.OverdrawnSince, arg.ID)
+	return err
+}
+
+const summarizeOverdrafts = `-- name: SummarizeOverdrafts :many
+SELECT
+    currency,
+    count(*)::bigint AS accounts,
+    (-SUM(balance))::bigint AS overdrawn,
+    SUM(overdraft_limit)::bigint AS overdraft_limits,
+    count(*) FILTER (WHERE -balance > overdraft_limit)::bigint AS over_limit
+FROM accounts
+WHERE balance < 0 AND overdrawn_since IS NOT NULL
+GROUP BY currency
+ORDER BY currency
+`
+
+type SummarizeOverdraftsRow struct {
+	Currency        string `json:"currency"`
+	Accounts        int64  `json:"accounts"`
+	Overdrawn       int64  `json:"overdrawn"`
+	OverdraftLimits int64  `json:"overdraft_limits"`
+	OverLimit       int64  `json:"over_limit"`
+}
+
+// Totals of the customer accounts in overdraft per currency. The bank's own
+// accounts, which have no overdraft limit and are never marked overdrawn,
+// are left out.
+func (q *Queries) SummarizeOverdrafts(ctx context.Context) ([]SummarizeOverdraftsRow, error) {
+	rows, err := q.db.QueryContext(ctx, summarizeOverdrafts)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []SummarizeOverdraftsRow{}
+	for rows.Next() {
+		var i SummarizeOverdraftsRow
+		if err := rows.Scan(
+			&i.Currency,
+			&i.Accounts,
+			&i.Overdrawn,
+			&i.OverdraftLimits,
+			&i.OverLimit,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
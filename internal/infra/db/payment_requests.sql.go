@@ -0,0 +1,216 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: payment_requests.sql
+
+package db
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+const createPaymentRequest = `-- name: CreatePaymentRequest :one
+INSERT INTO payment_requests (
+    requester,
+    payer,
+    to_account_id,
+    amount,
+    currency,
+    memo,
+    expires_at
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7
+) RETURNING id, requester, payer, to_account_id, amount, currency, memo, status, transfer_id, expires_at, updated_at, created_at
+`
+
+type CreatePaymentRequestParams struct {
+	Requester   string         `json:"requester"`
+	Payer       sql.NullString `json:"payer"`
+	ToAccountID int64          `json:"to_account_id"`
+	Amount      int64          `json:"amount"`
+	Currency    string         `json:"currency"`
+	Memo        string         `json:"memo"`
+	ExpiresAt   time.Time      `json:"expires_at"`
+}
+
+func (q *Queries) CreatePaymentRequest(ctx context.Context, arg CreatePaymentRequestParams) (PaymentRequest, error) {
+	row := q.db.QueryRowContext(ctx, createPaymentRequest,
+		arg.Requester,
+		arg.Payer,
+		arg.ToAccountID,
+		arg.Amount,
+		arg.Currency,
+		arg.Memo,
+		arg.ExpiresAt,
+	)
+	var i PaymentRequest
+	err := row.Scan(
+		&i.ID,
+		&i.Requester,
+		&i.Payer,
+		&i.ToAccountID,
+		&i.Amount,
+		&i.Currency,
+		&i.Memo,
+		&i.Status,
+		&i.TransferID,
+		&i.ExpiresAt,
+		&i.UpdatedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getPaymentRequest = `-- name: GetPaymentRequest :one
+SELECT id, requester, payer, to_account_id, amount, currency, memo, status, transfer_id, expires_at, updated_at, created_at FROM payment_requests WHERE id = $1 LIMIT 1
+`
+
+func (q *Queries) GetPaymentRequest(ctx context.Context, id int64) (PaymentRequest, error) {
+	row := q.db.QueryRowContext(ctx, getPaymentRequest, id)
+	var i PaymentRequest
+	err := row.Scan(
+		&i.ID,
+		&i.Requester,
+		&i.Payer,
+		&i.ToAccountID,
+		&i.Amount,
+		&i.Currency,
+		&i.Memo,
+		&i.Status,
+		&i.TransferID,
+		&i.ExpiresAt,
+		&i.UpdatedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getPaymentRequestForUpdate = `-- name: GetPaymentRequestForUpdate :one
+SELECT id, requester, payer, to_account_id, amount, currency, memo, status, transfer_id, expires_at, updated_at, created_at FROM payment_requests WHERE id = $1 LIMIT 1 FOR NO KEY UPDATE
+`
+
+func (q *Queries) GetPaymentRequestForUpdate(ctx context.Context, id int64) (PaymentRequest, error) {
+	row := q.db.QueryRowContext(ctx, getPaymentRequestForUpdate, id)
+	var i PaymentRequest
+	err := row.Scan(
+		&i.ID,
+		&i.Requester,
+		&i.Payer,
+		&i.ToAccountID,
+		&i.Amount,
+		&i.Currency,
+		&i.Memo,
+		&i.Status,
+		&i.TransferID,
+		&i.ExpiresAt,
+		&i.UpdatedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const listPaymentRequestsBefore = `-- name: ListPaymentRequestsBefore :many
+SELECT id, requester, payer, to_account_id, amount, currency, memo, status, transfer_id, expires_at, updated_at, created_at FROM payment_requests
+WHERE (($1::text = 'outgoing' AND requester = $2)
+        OR ($1::text = 'incoming' AND payer = $2))
+    AND ($3::text IS NULL OR status = $3::text)
+    AND ($4::bigint = 0 OR id < $4::bigint)
+ORDER BY id DESC
+LIMIT $5
+`
+
+type ListPaymentRequestsBeforeParams struct {
+	Direction string         `json:"direction"`
+	Username  string         `json:"username"`
+	Status    sql.NullString `json:"status"`
+	BeforeID  int64          `json:"before_id"`
+	PageLimit int32          `json:"page_limit"`
+}
+
+func (q *Queries) ListPaymentRequestsBefore(ctx context.Context, arg ListPaymentRequestsBeforeParams) ([]PaymentRequest, error) {
+	rows, err := q.db.QueryContext(ctx, listPaymentRequestsBefore,
+		arg.Direction,
+		arg.Username,
+		arg.Status,
+		arg.BeforeID,
+		arg.PageLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []PaymentRequest{}
+	for rows.Next() {
+		var i PaymentRequest
+		if err := rows.Scan(
+			&i.ID,
+			&i.Requester,
+			&i.Payer,
+			&i.ToAccountID,
+			&i.Amount,
+			&i.Currency,
+			&i.Memo,
+			&i.Status,
+			&i.TransferID,
+			&i.ExpiresAt,
+			&i.UpdatedAt,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const updatePaymentRequestStatus = `-- name: UpdatePaymentRequestStatus :one
+UPDATE payment_requests
+SET status = $1,
+    payer = COALESCE($2, payer),
+    transfer_id = COALESCE($3, transfer_id),
+    updated_at = now()
+WHERE id = $4 AND status = $5
+RETURNING id, requester, payer, to_account_id, amount, currency, memo, status, transfer_id, expires_at, updated_at, created_at
+`
+
+type UpdatePaymentRequestStatusParams struct {
+	ToStatus   string         `json:"to_status"`
+	Payer      sql.NullString `json:"payer"`
+	TransferID sql.NullInt64  `json:"transfer_id"`
+	ID         int64          `json:"id"`
+	FromStatus string         `json:"from_status"`
+}
+
+func (q *Queries) UpdatePaymentRequestStatus(ctx context.Context, arg UpdatePaymentRequestStatusParams) (PaymentRequest, error) {
+	row := q.db.QueryRowContext(ctx, updatePaymentRequestStatus,
+		arg.ToStatus,
+		arg.Payer,
+		arg.TransferID,
+		arg.ID,
+		arg.FromStatus,
+	)
+	var i PaymentRequest
+	err := row.Scan(
+		&i.ID,
+		&i.Requester,
+		&i.Payer,
+		&i.ToAccountID,
+		&i.Amount,
+		&i.Currency,
+		&i.Memo,
+		&i.Status,
+		&i.TransferID,
+		&i.ExpiresAt,
+		&i.UpdatedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
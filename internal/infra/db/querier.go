@@ -6,29 +6,228 @@ package db
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 )
 
 type Querier interface {
 	AddAccountBalance(ctx context.Context, arg AddAccountBalanceParams) (Account, error)
+	// Marks the undecided disputes past their due date that were not reported
+	// yet.
+	ClaimDisputeSLABreaches(ctx context.Context, pageLimit int32) ([]Dispute, error)
+	// Marks the undecided disputes due before warn_before, and not overdue yet,
+	// that were not warned about. SKIP LOCKED lets several instances claim at once.
+	ClaimDisputeSLAWarnings(ctx context.Context, arg ClaimDisputeSLAWarningsParams) ([]Dispute, error)
+	// Claims a pending or failed step, or a running step whose worker started
+	// it before stale_before, for the calling worker.
+	ClaimEODStep(ctx context.Context, arg ClaimEODStepParams) (EodStep, error)
+	// Marks a page of undelivered events notified and returns them. Events
+	// claimed by a concurrent worker are skipped, so each is delivered once.
+	ClaimOverdraftEvents(ctx context.Context, pageLimit int32) ([]ClaimOverdraftEventsRow, error)
+	// Claims the oldest pending batch, or a processing batch whose worker has not
+	// reported progress since stale_before, for the calling worker.
+	ClaimTransferBatch(ctx context.Context, staleBefore time.Time) (TransferBatch, error)
+	CountAccountsByOwner(ctx context.Context, owner string) (int64, error)
+	// Counts the transfers from accounts of an owner created since a time, and
+	// those of them whose amount is a multiple of round_amount.
+	CountRecentTransfers(ctx context.Context, arg CountRecentTransfersParams) (CountRecentTransfersRow, error)
+	CountRecipientLookupsSince(ctx context.Context, arg CountRecipientLookupsSinceParams) (int64, error)
+	// Counts the sessions of a user created before a time, and those of them
+	// signed in from client_ip.
+	CountSessionsFromClientIP(ctx context.Context, arg CountSessionsFromClientIPParams) (CountSessionsFromClientIPRow, error)
 	CreateAccount(ctx context.Context, arg CreateAccountParams) (Account, error)
+	CreateBeneficiary(ctx context.Context, arg CreateBeneficiaryParams) (Beneficiary, error)
+	CreateBusinessDay(ctx context.Context, businessDate time.Time) (BusinessDay, error)
+	CreateCategorizationRule(ctx context.Context, arg CreateCategorizationRuleParams) (CategorizationRule, error)
+	CreateDispute(ctx context.Context, arg CreateDisputeParams) (Dispute, error)
+	CreateDisputeEvent(ctx context.Context, arg CreateDisputeEventParams) (DisputeEvent, error)
+	CreateDisputeEvidence(ctx context.Context, arg CreateDisputeEvidenceParams) (DisputeEvidence, error)
+	CreateEODStep(ctx context.Context, arg CreateEODStepParams) (EodStep, error)
+	// Posts to business_date, or to the open business day when it is null.
 	CreateEntry(ctx context.Context, arg CreateEntryParams) (Entry, error)
+	CreateEscrow(ctx context.Context, arg CreateEscrowParams) (Escrow, error)
+	CreateFeeSchedule(ctx context.Context, arg CreateFeeScheduleParams) (FeeSchedule, error)
+	CreateFeeScheduleBand(ctx context.Context, arg CreateFeeScheduleBandParams) (FeeScheduleBand, error)
+	CreateFraudDecision(ctx context.Context, arg CreateFraudDecisionParams) (FraudDecision, error)
+	// Affects no row when the account already accrued for the business date.
+	CreateInterestAccrual(ctx context.Context, arg CreateInterestAccrualParams) (int64, error)
+	CreateInterestCapitalization(ctx context.Context, arg CreateInterestCapitalizationParams) (InterestCapitalization, error)
+	CreateKycDocument(ctx context.Context, arg CreateKycDocumentParams) (KycDocument, error)
+	CreateKycSubmission(ctx context.Context, arg CreateKycSubmissionParams) (KycSubmission, error)
+	CreateNotification(ctx context.Context, arg CreateNotificationParams) (Notification, error)
+	CreateOverdraftApproval(ctx context.Context, arg CreateOverdraftApprovalParams) (OverdraftApproval, error)
+	CreateOverdraftEvent(ctx context.Context, arg CreateOverdraftEventParams) (OverdraftEvent, error)
+	CreatePaymentRequest(ctx context.Context, arg CreatePaymentRequestParams) (PaymentRequest, error)
+	CreateRecipientLookup(ctx context.Context, arg CreateRecipientLookupParams) (RecipientLookup, error)
+	CreateScreeningMatch(ctx context.Context, arg CreateScreeningMatchParams) (ScreeningMatch, error)
+	CreateScreeningWhitelistEntry(ctx context.Context, arg CreateScreeningWhitelistEntryParams) (ScreeningWhitelist, error)
 	CreateSession(ctx context.Context, arg CreateSessionParams) (Session, error)
+	// Posts to business_date, or to the open business day when it is null.
 	CreateTransfer(ctx context.Context, arg CreateTransferParams) (Transfer, error)
+	CreateTransferBatch(ctx context.Context, arg CreateTransferBatchParams) (TransferBatch, error)
+	CreateTransferBatchItem(ctx context.Context, arg CreateTransferBatchItemParams) (TransferBatchItem, error)
 	CreateUser(ctx context.Context, arg CreateUserParams) (User, error)
 	DeleteAccount(ctx context.Context, id int64) error
+	DeleteBeneficiary(ctx context.Context, id int64) error
+	DeleteCategorizationRule(ctx context.Context, id int64) error
+	DeleteScreeningWhitelistEntry(ctx context.Context, id int64) (ScreeningWhitelist, error)
+	FailPendingTransferBatchItems(ctx context.Context, arg FailPendingTransferBatchItemsParams) error
+	// Affects no row while a step of the day has not succeeded.
+	FinishClosingBusinessDay(ctx context.Context, businessDate time.Time) (BusinessDay, error)
+	FinishEODStep(ctx context.Context, arg FinishEODStepParams) (EodStep, error)
+	FinishTransferBatch(ctx context.Context, arg FinishTransferBatchParams) (TransferBatch, error)
 	GetAccount(ctx context.Context, id int64) (Account, error)
+	// Returns the balance of an account before a time: the daily balance of the
+	// last business day that started closing by then plus the entries posted to
+	// later days before it, or, without such a daily balance, the current
+	// balance minus the entries created since.
+	GetAccountBalanceBefore(ctx context.Context, arg GetAccountBalanceBeforeParams) (int64, error)
+	GetAccountByNumber(ctx context.Context, accountNumber string) (Account, error)
+	// Returns owner's checking account in currency, where payments to the
+	// owner are credited.
+	GetAccountByOwnerCurrency(ctx context.Context, arg GetAccountByOwnerCurrencyParams) (Account, error)
+	GetAccountByOwnerProduct(ctx context.Context, arg GetAccountByOwnerProductParams) (Account, error)
 	GetAccountForUpdate(ctx context.Context, id int64) (Account, error)
+	GetAccountProduct(ctx context.Context, code string) (AccountProduct, error)
+	GetBeneficiary(ctx context.Context, id int64) (Beneficiary, error)
+	GetBusinessDay(ctx context.Context, businessDate time.Time) (BusinessDay, error)
+	GetCategorizationRule(ctx context.Context, id int64) (CategorizationRule, error)
+	GetClosingBusinessDay(ctx context.Context) (BusinessDay, error)
+	GetCurrency(ctx context.Context, code string) (Currency, error)
+	GetDailyBalance(ctx context.Context, arg GetDailyBalanceParams) (DailyBalance, error)
+	GetDispute(ctx context.Context, id int64) (Dispute, error)
+	GetDisputeEvidence(ctx context.Context, arg GetDisputeEvidenceParams) (DisputeEvidence, error)
+	GetDisputeForUpdate(ctx context.Context, id int64) (Dispute, error)
 	GetEntry(ctx context.Context, id int64) (Entry, error)
+	GetEscrow(ctx context.Context, id int64) (Escrow, error)
+	GetEscrowForUpdate(ctx context.Context, id int64) (Escrow, error)
+	GetFeeSchedule(ctx context.Context, currency string) (FeeSchedule, error)
+	GetFraudDecision(ctx context.Context, id int64) (FraudDecision, error)
+	GetFraudDecisionForUpdate(ctx context.Context, id int64) (FraudDecision, error)
+	GetInterestCapitalization(ctx context.Context, arg GetInterestCapitalizationParams) (InterestCapitalization, error)
+	GetKycDocument(ctx context.Context, arg GetKycDocumentParams) (KycDocument, error)
+	GetKycLevel(ctx context.Context, level int32) (KycLevel, error)
+	GetKycSubmission(ctx context.Context, id int64) (KycSubmission, error)
+	GetKycSubmissionForUpdate(ctx context.Context, id int64) (KycSubmission, error)
+	GetLastInterestCapitalization(ctx context.Context, accountID int64) (InterestCapitalization, error)
+	GetOpenBusinessDay(ctx context.Context) (BusinessDay, error)
+	// Closing the day waits for the transactions holding this lock, so no
+	// posting lands in a day after it started closing.
+	GetOpenBusinessDayForShare(ctx context.Context) (BusinessDay, error)
+	GetPaymentRequest(ctx context.Context, id int64) (PaymentRequest, error)
+	GetPaymentRequestForUpdate(ctx context.Context, id int64) (PaymentRequest, error)
 	GetSession(ctx context.Context, id uuid.UUID) (Session, error)
 	GetTransfer(ctx context.Context, id int64) (Transfer, error)
+	GetTransferBatch(ctx context.Context, id int64) (TransferBatch, error)
 	GetUser(ctx context.Context, username string) (User, error)
+	GetUserByVerifiedEmail(ctx context.Context, email string) (User, error)
+	GetUserForUpdate(ctx context.Context, username string) (User, error)
+	GetUserKycLimits(ctx context.Context, username string) (KycLevel, error)
+	GetUserKycTransferLimits(ctx context.Context, arg GetUserKycTransferLimitsParams) (GetUserKycTransferLimitsRow, error)
+	GetUserTier(ctx context.Context, username string) (UserTier, error)
+	// Reports whether an owner has sent money to an account before, directly
+	// or through an escrow for it.
+	HasTransferredTo(ctx context.Context, arg HasTransferredToParams) (bool, error)
+	ListAccountProducts(ctx context.Context) ([]AccountProduct, error)
 	ListAccounts(ctx context.Context, arg ListAccountsParams) ([]Account, error)
+	ListAccountsAfter(ctx context.Context, arg ListAccountsAfterParams) ([]Account, error)
+	ListAccountsWithUncapitalizedInterest(ctx context.Context, before time.Time) ([]int64, error)
+	ListBeneficiariesAfter(ctx context.Context, arg ListBeneficiariesAfterParams) ([]Beneficiary, error)
+	ListCategorizationRules(ctx context.Context, owner string) ([]CategorizationRule, error)
+	ListCurrencies(ctx context.Context) ([]Currency, error)
+	ListDisputeEvents(ctx context.Context, disputeID int64) ([]DisputeEvent, error)
+	ListDisputeEvidence(ctx context.Context, disputeID int64) ([]DisputeEvidence, error)
+	// The support queue: disputes oldest first, optionally with a status or
+	// assignee.
+	ListDisputes(ctx context.Context, arg ListDisputesParams) ([]Dispute, error)
+	// Held escrows past their release time, oldest first.
+	ListDueEscrowIDs(ctx context.Context, pageLimit int32) ([]int64, error)
+	ListEODSteps(ctx context.Context, businessDate time.Time) ([]EodStep, error)
+	ListEntriesAsc(ctx context.Context, arg ListEntriesAscParams) ([]ListEntriesAscRow, error)
+	ListEntriesDesc(ctx context.Context, arg ListEntriesDescParams) ([]ListEntriesDescRow, error)
 	ListEntry(ctx context.Context, arg ListEntryParams) ([]Entry, error)
+	// Escrows oldest first, optionally with a status.
+	ListEscrows(ctx context.Context, arg ListEscrowsParams) ([]Escrow, error)
+	ListFeeScheduleBands(ctx context.Context, scheduleID int64) ([]FeeScheduleBand, error)
+	ListGLAccounts(ctx context.Context) ([]GlAccount, error)
+	// Returns a page of the accounts in the daily balances of business_date
+	// whose product pays interest, or charges overdraft interest on an account
+	// that may be overdrawn, with their balance at the end of that day.
+	ListInterestBearingAccounts(ctx context.Context, arg ListInterestBearingAccountsParams) ([]ListInterestBearingAccountsRow, error)
+	ListKycDocuments(ctx context.Context, submissionID int64) ([]KycDocument, error)
+	ListKycLevelLimits(ctx context.Context) ([]KycLevelLimit, error)
+	ListKycLevels(ctx context.Context) ([]KycLevel, error)
+	ListNotificationsBefore(ctx context.Context, arg ListNotificationsBeforeParams) ([]Notification, error)
+	ListOverdraftApprovals(ctx context.Context, accountID int64) ([]OverdraftApproval, error)
+	// Like SummarizeOverdrafts, only lists customer accounts.
+	ListOverdrawnAccounts(ctx context.Context, arg ListOverdrawnAccountsParams) ([]Account, error)
+	ListPaymentRequestsBefore(ctx context.Context, arg ListPaymentRequestsBeforeParams) ([]PaymentRequest, error)
+	ListPendingFraudDecisions(ctx context.Context, arg ListPendingFraudDecisionsParams) ([]FraudDecision, error)
+	ListPendingKycSubmissions(ctx context.Context, arg ListPendingKycSubmissionsParams) ([]KycSubmission, error)
+	ListPendingTransferBatchItems(ctx context.Context, batchID int64) ([]TransferBatchItem, error)
+	ListScreeningMatches(ctx context.Context, arg ListScreeningMatchesParams) ([]ScreeningMatch, error)
+	ListScreeningWhitelist(ctx context.Context) ([]ScreeningWhitelist, error)
+	ListTransferBatchItems(ctx context.Context, batchID int64) ([]TransferBatchItem, error)
+	ListTransferBatchesBefore(ctx context.Context, arg ListTransferBatchesBeforeParams) ([]TransferBatch, error)
 	ListTransfers(ctx context.Context, arg ListTransfersParams) ([]Transfer, error)
+	ListTransfersAsc(ctx context.Context, arg ListTransfersAscParams) ([]ListTransfersAscRow, error)
+	ListTransfersDesc(ctx context.Context, arg ListTransfersDescParams) ([]ListTransfersDescRow, error)
+	ListUserDisputes(ctx context.Context, username string) ([]Dispute, error)
+	// Escrows the user pays or is paid by, newest first.
+	ListUserEscrows(ctx context.Context, username string) ([]Escrow, error)
+	ListUserKycSubmissions(ctx context.Context, username string) ([]KycSubmission, error)
+	ListUsernamesByRole(ctx context.Context, role string) ([]string, error)
+	ListWhitelistedEntryIDs(ctx context.Context, name string) ([]string, error)
+	MarkBeneficiaryVerified(ctx context.Context, id int64) (Beneficiary, error)
+	MarkInterestCapitalized(ctx context.Context, arg MarkInterestCapitalizedParams) error
+	// Returns the category of owner's highest-priority rule matching an entry;
+	// the oldest rule wins a tie. Empty matchers match anything.
+	MatchCategorizationRule(ctx context.Context, arg MatchCategorizationRuleParams) (string, error)
+	// Raises the level of a user; a level is never lowered by an approval.
+	RaiseUserKycLevel(ctx context.Context, arg RaiseUserKycLevelParams) (User, error)
+	// Moves a pending decision to approved or rejected.
+	ReviewFraudDecision(ctx context.Context, arg ReviewFraudDecisionParams) (FraudDecision, error)
+	// Moves a pending submission to approved or rejected.
+	ReviewKycSubmission(ctx context.Context, arg ReviewKycSubmissionParams) (KycSubmission, error)
+	SetAccountOverdraftLimit(ctx context.Context, arg SetAccountOverdraftLimitParams) (Account, error)
+	SetAccountOverdrawnSince(ctx context.Context, arg SetAccountOverdrawnSinceParams) error
+	SetFraudDecisionTransfer(ctx context.Context, arg SetFraudDecisionTransferParams) error
+	// Records the balance at the end of a closing business date of every
+	// account opened before the day started closing: its current balance minus
+	// every entry posted to a later day. Accounts already recorded are skipped,
+	// so it may run again.
+	SnapshotDailyBalances(ctx context.Context, businessDate time.Time) (int64, error)
+	StartClosingBusinessDay(ctx context.Context, businessDate time.Time) (BusinessDay, error)
+	// Sums the credits and debits of an account's entries created from from to
+	// before to, by UTC day, week (from Monday) or month.
+	SumEntriesByPeriod(ctx context.Context, arg SumEntriesByPeriodParams) ([]SumEntriesByPeriodRow, error)
+	// Sums the entries created before the given time per currency and general
+	// ledger account, positive for credits. Accounts with a negative balance are
+	// reported in the overdraft account of their product. Entries that are not
+	// part of a transfer have no offsetting entry, so they are offset in the
+	// suspense account to keep the ledger balanced.
+	SumGLBalances(ctx context.Context, arg SumGLBalancesParams) ([]SumGLBalancesRow, error)
+	SumTransfersFromOwnerSince(ctx context.Context, arg SumTransfersFromOwnerSinceParams) (int64, error)
+	SumTransfersFromOwnerSinceByCurrency(ctx context.Context, arg SumTransfersFromOwnerSinceByCurrencyParams) ([]SumTransfersFromOwnerSinceByCurrencyRow, error)
+	SumTransfersToAccountSince(ctx context.Context, arg SumTransfersToAccountSinceParams) (int64, error)
+	SumUncapitalizedInterest(ctx context.Context, arg SumUncapitalizedInterestParams) (int64, error)
+	// Totals of the customer accounts in overdraft per currency. The bank's own
+	// accounts, which have no overdraft limit and are never marked overdrawn,
+	// are left out.
+	SummarizeOverdrafts(ctx context.Context) ([]SummarizeOverdraftsRow, error)
+	UpdateBeneficiaryNickname(ctx context.Context, arg UpdateBeneficiaryNicknameParams) (Beneficiary, error)
+	// Sets the status of a dispute and the fields given.
+	UpdateDispute(ctx context.Context, arg UpdateDisputeParams) (Dispute, error)
+	// Sets the status of an escrow and the fields given.
+	UpdateEscrow(ctx context.Context, arg UpdateEscrowParams) (Escrow, error)
+	UpdatePaymentRequestStatus(ctx context.Context, arg UpdatePaymentRequestStatusParams) (PaymentRequest, error)
+	// Settles a pending row, or a row held for fraud review.
+	UpdateTransferBatchItem(ctx context.Context, arg UpdateTransferBatchItemParams) (TransferBatchItem, error)
+	UpdateTransferBatchProgress(ctx context.Context, id int64) (TransferBatch, error)
 	UpdateUser(ctx context.Context, arg UpdateUserParams) (User, error)
 	UpdatedAccount(ctx context.Context, arg UpdatedAccountParams) (Account, error)
+	UpsertCurrency(ctx context.Context, arg UpsertCurrencyParams) (Currency, error)
 }
 
 var _ Querier = (*Queries)(nil)
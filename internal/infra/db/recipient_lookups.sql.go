@@ -0,0 +1,70 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: recipient_lookups.sql
+
+package db
+
+import (
+	"context"
+	"time"
+)
+
+const countRecipientLookupsSince = `-- name: CountRecipientLookupsSince :one
+SELECT count(*) FROM recipient_lookups
+WHERE username = $1
+    AND created_at >= $2
+`
+
+type CountRecipientLookupsSinceParams struct {
+	Username string    `json:"username"`
+	Since    time.Time `json:"since"`
+}
+
+func (q *Queries) CountRecipientLookupsSince(ctx context.Context, arg CountRecipientLookupsSinceParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countRecipientLookupsSince, arg.Username, arg.Since)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const createRecipientLookup = `-- name: CreateRecipientLookup :one
+INSERT INTO recipient_lookups (
+    username,
+    identifier,
+    purpose,
+    found,
+    client_ip
+) VALUES (
+    $1, $2, $3, $4, $5
+) RETURNING id, username, identifier, purpose, found, client_ip, created_at
+`
+
+type CreateRecipientLookupParams struct {
+	Username   string `json:"username"`
+	Identifier string `json:"identifier"`
+	Purpose    string `json:"purpose"`
+	Found      bool   `json:"found"`
+	ClientIp   string `json:"client_ip"`
+}
+
+func (q *Queries) CreateRecipientLookup(ctx context.Context, arg CreateRecipientLookupParams) (RecipientLookup, error) {
+	row := q.db.QueryRowContext(ctx, createRecipientLookup,
+		arg.Username,
+		arg.Identifier,
+		arg.Purpose,
+		arg.Found,
+		arg.ClientIp,
+	)
+	var i RecipientLookup
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.Identifier,
+		&i.Purpose,
+		&i.Found,
+		&i.ClientIp,
+		&i.CreatedAt,
+	)
+	return i, err
+}
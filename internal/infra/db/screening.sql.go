@@ -0,0 +1,237 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: screening.sql
+
+package db
+
+import (
+	"context"
+	"database/sql"
+)
+
+const createScreeningMatch = `-- name: CreateScreeningMatch :one
+INSERT INTO screening_matches (
+    subject,
+    name,
+    username,
+    account_id,
+    list,
+    entry_id,
+    entry_name,
+    score,
+    action
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9
+) RETURNING id, subject, name, username, account_id, list, entry_id, entry_name, score, action, created_at
+`
+
+type CreateScreeningMatchParams struct {
+	Subject   string        `json:"subject"`
+	Name      string        `json:"name"`
+	Username  string        `json:"username"`
+	AccountID sql.NullInt64 `json:"account_id"`
+	List      string        `json:"list"`
+	EntryID   string        `json:"entry_id"`
+	EntryName string        `json:"entry_name"`
+	Score     float64       `json:"score"`
+	Action    string        `json:"action"`
+}
+
+func (q *Queries) CreateScreeningMatch(ctx context.Context, arg CreateScreeningMatchParams) (ScreeningMatch, error) {
+	row := q.db.QueryRowContext(ctx, createScreeningMatch,
+		arg.Subject,
+		arg.Name,
+		arg.Username,
+		arg.AccountID,
+		arg.List,
+		arg.EntryID,
+		arg.EntryName,
+		arg.Score,
+		arg.Action,
+	)
+	var i ScreeningMatch
+	err := row.Scan(
+		&i.ID,
+		&i.Subject,
+		&i.Name,
+		&i.Username,
+		&i.AccountID,
+		&i.List,
+		&i.EntryID,
+		&i.EntryName,
+		&i.Score,
+		&i.Action,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const createScreeningWhitelistEntry = `-- name: CreateScreeningWhitelistEntry :one
+INSERT INTO screening_whitelist (
+    name,
+    entry_id,
+    reason,
+    created_by
+) VALUES (
+    $1, $2, $3, $4
+) RETURNING id, name, entry_id, reason, created_by, created_at
+`
+
+type CreateScreeningWhitelistEntryParams struct {
+	Name      string `json:"name"`
+	EntryID   string `json:"entry_id"`
+	Reason    string `json:"reason"`
+	CreatedBy string `json:"created_by"`
+}
+
+func (q *Queries) CreateScreeningWhitelistEntry(ctx context.Context, arg CreateScreeningWhitelistEntryParams) (ScreeningWhitelist, error) {
+	row := q.db.QueryRowContext(ctx, createScreeningWhitelistEntry,
+		arg.Name,
+		arg.EntryID,
+		arg.Reason,
+		arg.CreatedBy,
+	)
+	var i ScreeningWhitelist
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.EntryID,
+		&i.Reason,
+		&i.CreatedBy,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const deleteScreeningWhitelistEntry = `-- name: DeleteScreeningWhitelistEntry :one
+DELETE FROM screening_whitelist
+WHERE id = $1
+RETURNING id, name, entry_id, reason, created_by, created_at
+`
+
+func (q *Queries) DeleteScreeningWhitelistEntry(ctx context.Context, id int64) (ScreeningWhitelist, error) {
+	row := q.db.QueryRowContext(ctx, deleteScreeningWhitelistEntry, id)
+	var i ScreeningWhitelist
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.EntryID,
+		&i.Reason,
+		&i.CreatedBy,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const listScreeningMatches = `-- name: ListScreeningMatches :many
+SELECT id, subject, name, username, account_id, list, entry_id, entry_name, score, action, created_at FROM screening_matches
+WHERE id > $1
+  AND ($2::varchar IS NULL OR action = $2)
+ORDER BY id
+LIMIT $3
+`
+
+type ListScreeningMatchesParams struct {
+	AfterID   int64          `json:"after_id"`
+	Action    sql.NullString `json:"action"`
+	PageLimit int32          `json:"page_limit"`
+}
+
+func (q *Queries) ListScreeningMatches(ctx context.Context, arg ListScreeningMatchesParams) ([]ScreeningMatch, error) {
+	rows, err := q.db.QueryContext(ctx, listScreeningMatches, arg.AfterID, arg.Action, arg.PageLimit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ScreeningMatch{}
+	for rows.Next() {
+		var i ScreeningMatch
+		if err := rows.Scan(
+			&i.ID,
+			&i.Subject,
+			&i.Name,
+			&i.Username,
+			&i.AccountID,
+			&i.List,
+			&i.EntryID,
+			&i.EntryName,
+			&i.Score,
+			&i.Action,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listScreeningWhitelist = `-- name: ListScreeningWhitelist :many
+SELECT id, name, entry_id, reason, created_by, created_at FROM screening_whitelist
+ORDER BY id
+`
+
+func (q *Queries) ListScreeningWhitelist(ctx context.Context) ([]ScreeningWhitelist, error) {
+	rows, err := q.db.QueryContext(ctx, listScreeningWhitelist)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ScreeningWhitelist{}
+	for rows.Next() {
+		var i ScreeningWhitelist
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.EntryID,
+			&i.Reason,
+			&i.CreatedBy,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listWhitelistedEntryIDs = `-- name: ListWhitelistedEntryIDs :many
+SELECT entry_id FROM screening_whitelist
+WHERE name = $1
+`
+
+func (q *Queries) ListWhitelistedEntryIDs(ctx context.Context, name string) ([]string, error) {
+	rows, err := q.db.QueryContext(ctx, listWhitelistedEntryIDs, name)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []string{}
+	for rows.Next() {
+		var entry_id string
+		if err := rows.Scan(&entry_id); err != nil {
+			return nil, err
+		}
+		items = append(items, entry_id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -4,27 +4,83 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+
+	"github.com/NhutHuyDev/sgbank/internal/logging"
 )
 
 type Store interface {
 	TransferTx(ctx context.Context, arg TransferTxParams) (TransferTxResult, error)
+	CreateAccountTx(ctx context.Context, arg CreateAccountParams) (Account, error)
+	ListEntriesPage(ctx context.Context, filter EntryPageFilter, order SortOrder) ([]EntryPageRow, error)
+	ListTransfersPage(ctx context.Context, filter TransferPageFilter, order SortOrder) ([]TransferPageRow, error)
+	PayPaymentRequestTx(ctx context.Context, arg PayPaymentRequestTxParams) (PayPaymentRequestTxResult, error)
+	CreateTransferBatchTx(ctx context.Context, arg CreateTransferBatchTxParams) (CreateTransferBatchTxResult, error)
+	PostTransferBatchItemTx(ctx context.Context, arg PostTransferBatchItemTxParams) (PostTransferBatchItemTxResult, error)
+	PostTransferBatchTx(ctx context.Context, arg PostTransferBatchTxParams) (PostTransferBatchTxResult, error)
+	CapitalizeInterestTx(ctx context.Context, arg CapitalizeInterestTxParams) (CapitalizeInterestTxResult, error)
+	ApproveOverdraftTx(ctx context.Context, arg ApproveOverdraftTxParams) (ApproveOverdraftTxResult, error)
+	CloseBusinessDayTx(ctx context.Context, arg CloseBusinessDayTxParams) (CloseBusinessDayTxResult, error)
+	ApproveHeldTransferTx(ctx context.Context, arg ApproveHeldTransferTxParams) (ApproveHeldTransferTxResult, error)
+	RejectHeldTransferTx(ctx context.Context, arg RejectHeldTransferTxParams) (RejectHeldTransferTxResult, error)
+	CreateKycSubmissionTx(ctx context.Context, arg CreateKycSubmissionTxParams) (CreateKycSubmissionTxResult, error)
+	ReviewKycSubmissionTx(ctx context.Context, arg ReviewKycSubmissionTxParams) (ReviewKycSubmissionTxResult, error)
+	OpenDisputeTx(ctx context.Context, arg OpenDisputeTxParams) (OpenDisputeTxResult, error)
+	TransitionDisputeTx(ctx context.Context, arg TransitionDisputeTxParams) (TransitionDisputeTxResult, error)
+	CreateEscrowTx(ctx context.Context, arg CreateEscrowTxParams) (CreateEscrowTxResult, error)
+	SettleEscrowTx(ctx context.Context, arg SettleEscrowTxParams) (SettleEscrowTxResult, error)
 	Querier
 }
 
 type StoreSQL struct {
 	*Queries
-	db *sql.DB
+	db          *sql.DB
+	retryPolicy RetryPolicy
 }
 
 func NewStore(db *sql.DB) Store {
 	return &StoreSQL{
-		db:      db,
-		Queries: New(db),
+		db:          db,
+		Queries:     New(db),
+		retryPolicy: DefaultRetryPolicy,
+	}
+}
+
+// TxOptions configures a transaction run by execTx.
+type TxOptions struct {
+	Isolation sql.IsolationLevel
+	ReadOnly  bool
+}
+
+// execTx runs fn in a transaction, retrying the whole transaction when
+// Postgres aborts it with a serialization failure or a deadlock. It returns
+// the number of retries that were needed.
+func (store *StoreSQL) execTx(ctx context.Context, opts TxOptions, fn func(*Queries) error) (int, error) {
+	retries := 0
+
+	for attempt := 1; ; attempt++ {
+		err := store.execTxOnce(ctx, opts, fn)
+		if err == nil || !IsRetryableError(err) || attempt >= store.retryPolicy.MaxAttempts {
+			return retries, err
+		}
+
+		delay := store.retryPolicy.Backoff(attempt)
+		logging.FromContext(ctx).Warn().Err(err).
+			Int("attempt", attempt).
+			Dur("backoff", delay).
+			Msg("retrying transaction")
+
+		if err := sleepContext(ctx, delay); err != nil {
+			return retries, err
+		}
+		retries++
 	}
 }
 
-func (store *StoreSQL) execTx(ctx context.Context, fn func(*Queries) error) error {
-	tx, err := store.db.BeginTx(ctx, nil)
+func (store *StoreSQL) execTxOnce(ctx context.Context, opts TxOptions, fn func(*Queries) error) error {
+	tx, err := store.db.BeginTx(ctx, &sql.TxOptions{
+		Isolation: opts.Isolation,
+		ReadOnly:  opts.ReadOnly,
+	})
 	if err != nil {
 		return err
 	}
@@ -34,9 +90,11 @@ func (store *StoreSQL) execTx(ctx context.Context, fn func(*Queries) error) erro
 
 	if err != nil {
 		if rbErr := tx.Rollback(); rbErr != nil {
-			return fmt.Errorf("error: %v, rollback error: %v", err, rbErr)
+			logging.FromContext(ctx).Error().Err(rbErr).Msg("cannot roll back transaction")
+			return fmt.Errorf("error: %w, rollback error: %v", err, rbErr)
 		}
 
+		logging.FromContext(ctx).Debug().Err(err).Msg("transaction rolled back")
 		return err
 	}
 
@@ -0,0 +1,791 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/NhutHuyDev/sgbank/internal/domain"
+	"github.com/google/uuid"
+)
+
+// The methods below shadow the generated Queries so that every Store call
+// reports domain errors. Queries used inside execTx are left untouched so the
+// retry loop can still see the raw SQLSTATE.
+
+func (store *StoreSQL) AddAccountBalance(ctx context.Context, arg AddAccountBalanceParams) (Account, error) {
+	result, err := store.Queries.AddAccountBalance(ctx, arg)
+	return result, translateError(ctx, err, domain.ErrAccountNotFound)
+}
+
+func (store *StoreSQL) ClaimDisputeSLABreaches(ctx context.Context, pageLimit int32) ([]Dispute, error) {
+	result, err := store.Queries.ClaimDisputeSLABreaches(ctx, pageLimit)
+	return result, translateError(ctx, err, domain.ErrDisputeNotFound)
+}
+
+func (store *StoreSQL) ClaimDisputeSLAWarnings(ctx context.Context, arg ClaimDisputeSLAWarningsParams) ([]Dispute, error) {
+	result, err := store.Queries.ClaimDisputeSLAWarnings(ctx, arg)
+	return result, translateError(ctx, err, domain.ErrDisputeNotFound)
+}
+
+func (store *StoreSQL) ClaimEODStep(ctx context.Context, arg ClaimEODStepParams) (EodStep, error) {
+	result, err := store.Queries.ClaimEODStep(ctx, arg)
+	return result, translateError(ctx, err, domain.ErrNotFound)
+}
+
+func (store *StoreSQL) ClaimOverdraftEvents(ctx context.Context, pageLimit int32) ([]ClaimOverdraftEventsRow, error) {
+	result, err := store.Queries.ClaimOverdraftEvents(ctx, pageLimit)
+	return result, translateError(ctx, err, domain.ErrNotFound)
+}
+
+func (store *StoreSQL) ClaimTransferBatch(ctx context.Context, staleBefore time.Time) (TransferBatch, error) {
+	result, err := store.Queries.ClaimTransferBatch(ctx, staleBefore)
+	return result, translateError(ctx, err, domain.ErrTransferBatchNotFound)
+}
+
+func (store *StoreSQL) CountAccountsByOwner(ctx context.Context, owner string) (int64, error) {
+	result, err := store.Queries.CountAccountsByOwner(ctx, owner)
+	return result, translateError(ctx, err, domain.ErrAccountNotFound)
+}
+
+func (store *StoreSQL) CountRecentTransfers(ctx context.Context, arg CountRecentTransfersParams) (CountRecentTransfersRow, error) {
+	result, err := store.Queries.CountRecentTransfers(ctx, arg)
+	return result, translateError(ctx, err, domain.ErrTransferNotFound)
+}
+
+func (store *StoreSQL) CountRecipientLookupsSince(ctx context.Context, arg CountRecipientLookupsSinceParams) (int64, error) {
+	result, err := store.Queries.CountRecipientLookupsSince(ctx, arg)
+	return result, translateError(ctx, err, domain.ErrNotFound)
+}
+
+func (store *StoreSQL) CountSessionsFromClientIP(ctx context.Context, arg CountSessionsFromClientIPParams) (CountSessionsFromClientIPRow, error) {
+	result, err := store.Queries.CountSessionsFromClientIP(ctx, arg)
+	return result, translateError(ctx, err, domain.ErrSessionNotFound)
+}
+
+func (store *StoreSQL) CreateAccount(ctx context.Context, arg CreateAccountParams) (Account, error) {
+	result, err := store.Queries.CreateAccount(ctx, arg)
+	return result, translateError(ctx, err, domain.ErrAccountNotFound)
+}
+
+func (store *StoreSQL) CreateBeneficiary(ctx context.Context, arg CreateBeneficiaryParams) (Beneficiary, error) {
+	result, err := store.Queries.CreateBeneficiary(ctx, arg)
+	return result, translateError(ctx, err, domain.ErrBeneficiaryNotFound)
+}
+
+func (store *StoreSQL) CreateBusinessDay(ctx context.Context, businessDate time.Time) (BusinessDay, error) {
+	result, err := store.Queries.CreateBusinessDay(ctx, businessDate)
+	return result, translateError(ctx, err, domain.ErrNotFound)
+}
+
+func (store *StoreSQL) CreateCategorizationRule(ctx context.Context, arg CreateCategorizationRuleParams) (CategorizationRule, error) {
+	result, err := store.Queries.CreateCategorizationRule(ctx, arg)
+	return result, translateError(ctx, err, domain.ErrCategorizationRuleNotFound)
+}
+
+func (store *StoreSQL) CreateDispute(ctx context.Context, arg CreateDisputeParams) (Dispute, error) {
+	result, err := store.Queries.CreateDispute(ctx, arg)
+	return result, translateError(ctx, err, domain.ErrDisputeNotFound)
+}
+
+func (store *StoreSQL) CreateDisputeEvent(ctx context.Context, arg CreateDisputeEventParams) (DisputeEvent, error) {
+	result, err := store.Queries.CreateDisputeEvent(ctx, arg)
+	return result, translateError(ctx, err, domain.ErrNotFound)
+}
+
+func (store *StoreSQL) CreateDisputeEvidence(ctx context.Context, arg CreateDisputeEvidenceParams) (DisputeEvidence, error) {
+	result, err := store.Queries.CreateDisputeEvidence(ctx, arg)
+	return result, translateError(ctx, err, domain.ErrNotFound)
+}
+
+func (store *StoreSQL) CreateEODStep(ctx context.Context, arg CreateEODStepParams) (EodStep, error) {
+	result, err := store.Queries.CreateEODStep(ctx, arg)
+	return result, translateError(ctx, err, domain.ErrNotFound)
+}
+
+func (store *StoreSQL) CreateEntry(ctx context.Context, arg CreateEntryParams) (Entry, error) {
+	result, err := store.Queries.CreateEntry(ctx, arg)
+	return result, translateError(ctx, err, domain.ErrEntryNotFound)
+}
+
+func (store *StoreSQL) CreateEscrow(ctx context.Context, arg CreateEscrowParams) (Escrow, error) {
+	result, err := store.Queries.CreateEscrow(ctx, arg)
+	return result, translateError(ctx, err, domain.ErrEscrowNotFound)
+}
+
+func (store *StoreSQL) CreateFeeSchedule(ctx context.Context, arg CreateFeeScheduleParams) (FeeSchedule, error) {
+	result, err := store.Queries.CreateFeeSchedule(ctx, arg)
+	return result, translateError(ctx, err, domain.ErrNotFound)
+}
+
+func (store *StoreSQL) CreateFeeScheduleBand(ctx context.Context, arg CreateFeeScheduleBandParams) (FeeScheduleBand, error) {
+	result, err := store.Queries.CreateFeeScheduleBand(ctx, arg)
+	return result, translateError(ctx, err, domain.ErrNotFound)
+}
+
+func (store *StoreSQL) CreateFraudDecision(ctx context.Context, arg CreateFraudDecisionParams) (FraudDecision, error) {
+	result, err := store.Queries.CreateFraudDecision(ctx, arg)
+	return result, translateError(ctx, err, domain.ErrFraudDecisionNotFound)
+}
+
+func (store *StoreSQL) CreateInterestAccrual(ctx context.Context, arg CreateInterestAccrualParams) (int64, error) {
+	result, err := store.Queries.CreateInterestAccrual(ctx, arg)
+	return result, translateError(ctx, err, domain.ErrNotFound)
+}
+
+func (store *StoreSQL) CreateInterestCapitalization(ctx context.Context, arg CreateInterestCapitalizationParams) (InterestCapitalization, error) {
+	result, err := store.Queries.CreateInterestCapitalization(ctx, arg)
+	return result, translateError(ctx, err, domain.ErrNotFound)
+}
+
+func (store *StoreSQL) CreateKycDocument(ctx context.Context, arg CreateKycDocumentParams) (KycDocument, error) {
+	result, err := store.Queries.CreateKycDocument(ctx, arg)
+	return result, translateError(ctx, err, domain.ErrNotFound)
+}
+
+func (store *StoreSQL) CreateKycSubmission(ctx context.Context, arg CreateKycSubmissionParams) (KycSubmission, error) {
+	result, err := store.Queries.CreateKycSubmission(ctx, arg)
+	return result, translateError(ctx, err, domain.ErrKYCSubmissionNotFound)
+}
+
+func (store *StoreSQL) CreateNotification(ctx context.Context, arg CreateNotificationParams) (Notification, error) {
+	result, err := store.Queries.CreateNotification(ctx, arg)
+	return result, translateError(ctx, err, domain.ErrNotFound)
+}
+
+func (store *StoreSQL) CreateOverdraftApproval(ctx context.Context, arg CreateOverdraftApprovalParams) (OverdraftApproval, error) {
+	result, err := store.Queries.CreateOverdraftApproval(ctx, arg)
+	return result, translateError(ctx, err, domain.ErrNotFound)
+}
+
+func (store *StoreSQL) CreateOverdraftEvent(ctx context.Context, arg CreateOverdraftEventParams) (OverdraftEvent, error) {
+	result, err := store.Queries.CreateOverdraftEvent(ctx, arg)
+	return result, translateError(ctx, err, domain.ErrNotFound)
+}
+
+func (store *StoreSQL) CreatePaymentRequest(ctx context.Context, arg CreatePaymentRequestParams) (PaymentRequest, error) {
+	result, err := store.Queries.CreatePaymentRequest(ctx, arg)
+	return result, translateError(ctx, err, domain.ErrPaymentRequestNotFound)
+}
+
+func (store *StoreSQL) CreateRecipientLookup(ctx context.Context, arg CreateRecipientLookupParams) (RecipientLookup, error) {
+	result, err := store.Queries.CreateRecipientLookup(ctx, arg)
+	return result, translateError(ctx, err, domain.ErrNotFound)
+}
+
+func (store *StoreSQL) CreateScreeningMatch(ctx context.Context, arg CreateScreeningMatchParams) (ScreeningMatch, error) {
+	result, err := store.Queries.CreateScreeningMatch(ctx, arg)
+	return result, translateError(ctx, err, domain.ErrNotFound)
+}
+
+func (store *StoreSQL) CreateScreeningWhitelistEntry(ctx context.Context, arg CreateScreeningWhitelistEntryParams) (ScreeningWhitelist, error) {
+	result, err := store.Queries.CreateScreeningWhitelistEntry(ctx, arg)
+	return result, translateError(ctx, err, domain.ErrNotFound)
+}
+
+func (store *StoreSQL) CreateSession(ctx context.Context, arg CreateSessionParams) (Session, error) {
+	result, err := store.Queries.CreateSession(ctx, arg)
+	return result, translateError(ctx, err, domain.ErrSessionNotFound)
+}
+
+func (store *StoreSQL) CreateTransfer(ctx context.Context, arg CreateTransferParams) (Transfer, error) {
+	result, err := store.Queries.CreateTransfer(ctx, arg)
+	return result, translateError(ctx, err, domain.ErrTransferNotFound)
+}
+
+func (store *StoreSQL) CreateTransferBatch(ctx context.Context, arg CreateTransferBatchParams) (TransferBatch, error) {
+	result, err := store.Queries.CreateTransferBatch(ctx, arg)
+	return result, translateError(ctx, err, domain.ErrTransferBatchNotFound)
+}
+
+func (store *StoreSQL) CreateTransferBatchItem(ctx context.Context, arg CreateTransferBatchItemParams) (TransferBatchItem, error) {
+	result, err := store.Queries.CreateTransferBatchItem(ctx, arg)
+	return result, translateError(ctx, err, domain.ErrTransferBatchNotFound)
+}
+
+func (store *StoreSQL) CreateUser(ctx context.Context, arg CreateUserParams) (User, error) {
+	result, err := store.Queries.CreateUser(ctx, arg)
+	return result, translateError(ctx, err, domain.ErrUserNotFound)
+}
+
+func (store *StoreSQL) DeleteAccount(ctx context.Context, id int64) error {
+	return translateError(ctx, store.Queries.DeleteAccount(ctx, id), domain.ErrAccountNotFound)
+}
+
+func (store *StoreSQL) DeleteBeneficiary(ctx context.Context, id int64) error {
+	return translateError(ctx, store.Queries.DeleteBeneficiary(ctx, id), domain.ErrBeneficiaryNotFound)
+}
+
+func (store *StoreSQL) DeleteCategorizationRule(ctx context.Context, id int64) error {
+	return translateError(ctx, store.Queries.DeleteCategorizationRule(ctx, id), domain.ErrCategorizationRuleNotFound)
+}
+
+func (store *StoreSQL) DeleteScreeningWhitelistEntry(ctx context.Context, id int64) (ScreeningWhitelist, error) {
+	result, err := store.Queries.DeleteScreeningWhitelistEntry(ctx, id)
+	return result, translateError(ctx, err, domain.ErrNotFound)
+}
+
+func (store *StoreSQL) FailPendingTransferBatchItems(ctx context.Context, arg FailPendingTransferBatchItemsParams) error {
+	return translateError(ctx, store.Queries.FailPendingTransferBatchItems(ctx, arg), domain.ErrTransferBatchNotFound)
+}
+
+func (store *StoreSQL) FinishClosingBusinessDay(ctx context.Context, businessDate time.Time) (BusinessDay, error) {
+	result, err := store.Queries.FinishClosingBusinessDay(ctx, businessDate)
+	return result, translateError(ctx, err, domain.ErrNotFound)
+}
+
+func (store *StoreSQL) FinishEODStep(ctx context.Context, arg FinishEODStepParams) (EodStep, error) {
+	result, err := store.Queries.FinishEODStep(ctx, arg)
+	return result, translateError(ctx, err, domain.ErrNotFound)
+}
+
+func (store *StoreSQL) FinishTransferBatch(ctx context.Context, arg FinishTransferBatchParams) (TransferBatch, error) {
+	result, err := store.Queries.FinishTransferBatch(ctx, arg)
+	return result, translateError(ctx, err, domain.ErrTransferBatchNotFound)
+}
+
+func (store *StoreSQL) GetAccount(ctx context.Context, id int64) (Account, error) {
+	result, err := store.Queries.GetAccount(ctx, id)
+	return result, translateError(ctx, err, domain.ErrAccountNotFound)
+}
+
+func (store *StoreSQL) GetAccountBalanceBefore(ctx context.Context, arg GetAccountBalanceBeforeParams) (int64, error) {
+	result, err := store.Queries.GetAccountBalanceBefore(ctx, arg)
+	return result, translateError(ctx, err, domain.ErrAccountNotFound)
+}
+
+func (store *StoreSQL) GetAccountByNumber(ctx context.Context, accountNumber string) (Account, error) {
+	result, err := store.Queries.GetAccountByNumber(ctx, accountNumber)
+	return result, translateError(ctx, err, domain.ErrAccountNotFound)
+}
+
+func (store *StoreSQL) GetAccountByOwnerCurrency(ctx context.Context, arg GetAccountByOwnerCurrencyParams) (Account, error) {
+	result, err := store.Queries.GetAccountByOwnerCurrency(ctx, arg)
+	return result, translateError(ctx, err, domain.ErrAccountNotFound)
+}
+
+func (store *StoreSQL) GetAccountByOwnerProduct(ctx context.Context, arg GetAccountByOwnerProductParams) (Account, error) {
+	result, err := store.Queries.GetAccountByOwnerProduct(ctx, arg)
+	return result, translateError(ctx, err, domain.ErrAccountNotFound)
+}
+
+func (store *StoreSQL) GetAccountForUpdate(ctx context.Context, id int64) (Account, error) {
+	result, err := store.Queries.GetAccountForUpdate(ctx, id)
+	return result, translateError(ctx, err, domain.ErrAccountNotFound)
+}
+
+func (store *StoreSQL) GetAccountProduct(ctx context.Context, code string) (AccountProduct, error) {
+	result, err := store.Queries.GetAccountProduct(ctx, code)
+	return result, translateError(ctx, err, domain.ErrNotFound)
+}
+
+func (store *StoreSQL) GetBeneficiary(ctx context.Context, id int64) (Beneficiary, error) {
+	result, err := store.Queries.GetBeneficiary(ctx, id)
+	return result, translateError(ctx, err, domain.ErrBeneficiaryNotFound)
+}
+
+func (store *StoreSQL) GetBusinessDay(ctx context.Context, businessDate time.Time) (BusinessDay, error) {
+	result, err := store.Queries.GetBusinessDay(ctx, businessDate)
+	return result, translateError(ctx, err, domain.ErrNotFound)
+}
+
+func (store *StoreSQL) GetCategorizationRule(ctx context.Context, id int64) (CategorizationRule, error) {
+	result, err := store.Queries.GetCategorizationRule(ctx, id)
+	return result, translateError(ctx, err, domain.ErrCategorizationRuleNotFound)
+}
+
+func (store *StoreSQL) GetClosingBusinessDay(ctx context.Context) (BusinessDay, error) {
+	result, err := store.Queries.GetClosingBusinessDay(ctx)
+	return result, translateError(ctx, err, domain.ErrNotFound)
+}
+
+func (store *StoreSQL) GetCurrency(ctx context.Context, code string) (Currency, error) {
+	result, err := store.Queries.GetCurrency(ctx, code)
+	return result, translateError(ctx, err, domain.ErrNotFound)
+}
+
+func (store *StoreSQL) GetDailyBalance(ctx context.Context, arg GetDailyBalanceParams) (DailyBalance, error) {
+	result, err := store.Queries.GetDailyBalance(ctx, arg)
+	return result, translateError(ctx, err, domain.ErrNotFound)
+}
+
+func (store *StoreSQL) GetDispute(ctx context.Context, id int64) (Dispute, error) {
+	result, err := store.Queries.GetDispute(ctx, id)
+	return result, translateError(ctx, err, domain.ErrDisputeNotFound)
+}
+
+func (store *StoreSQL) GetDisputeEvidence(ctx context.Context, arg GetDisputeEvidenceParams) (DisputeEvidence, error) {
+	result, err := store.Queries.GetDisputeEvidence(ctx, arg)
+	return result, translateError(ctx, err, domain.ErrNotFound)
+}
+
+func (store *StoreSQL) GetDisputeForUpdate(ctx context.Context, id int64) (Dispute, error) {
+	result, err := store.Queries.GetDisputeForUpdate(ctx, id)
+	return result, translateError(ctx, err, domain.ErrDisputeNotFound)
+}
+
+func (store *StoreSQL) GetEntry(ctx context.Context, id int64) (Entry, error) {
+	result, err := store.Queries.GetEntry(ctx, id)
+	return result, translateError(ctx, err, domain.ErrEntryNotFound)
+}
+
+func (store *StoreSQL) GetEscrow(ctx context.Context, id int64) (Escrow, error) {
+	result, err := store.Queries.GetEscrow(ctx, id)
+	return result, translateError(ctx, err, domain.ErrEscrowNotFound)
+}
+
+func (store *StoreSQL) GetEscrowForUpdate(ctx context.Context, id int64) (Escrow, error) {
+	result, err := store.Queries.GetEscrowForUpdate(ctx, id)
+	return result, translateError(ctx, err, domain.ErrEscrowNotFound)
+}
+
+func (store *StoreSQL) GetFeeSchedule(ctx context.Context, currency string) (FeeSchedule, error) {
+	result, err := store.Queries.GetFeeSchedule(ctx, currency)
+	return result, translateError(ctx, err, domain.ErrNotFound)
+}
+
+func (store *StoreSQL) GetFraudDecision(ctx context.Context, id int64) (FraudDecision, error) {
+	result, err := store.Queries.GetFraudDecision(ctx, id)
+	return result, translateError(ctx, err, domain.ErrFraudDecisionNotFound)
+}
+
+func (store *StoreSQL) GetFraudDecisionForUpdate(ctx context.Context, id int64) (FraudDecision, error) {
+	result, err := store.Queries.GetFraudDecisionForUpdate(ctx, id)
+	return result, translateError(ctx, err, domain.ErrFraudDecisionNotFound)
+}
+
+func (store *StoreSQL) GetInterestCapitalization(ctx context.Context, arg GetInterestCapitalizationParams) (InterestCapitalization, error) {
+	result, err := store.Queries.GetInterestCapitalization(ctx, arg)
+	return result, translateError(ctx, err, domain.ErrNotFound)
+}
+
+func (store *StoreSQL) GetKycDocument(ctx context.Context, arg GetKycDocumentParams) (KycDocument, error) {
+	result, err := store.Queries.GetKycDocument(ctx, arg)
+	return result, translateError(ctx, err, domain.ErrNotFound)
+}
+
+func (store *StoreSQL) GetKycLevel(ctx context.Context, level int32) (KycLevel, error) {
+	result, err := store.Queries.GetKycLevel(ctx, level)
+	return result, translateError(ctx, err, domain.ErrNotFound)
+}
+
+func (store *StoreSQL) GetKycSubmission(ctx context.Context, id int64) (KycSubmission, error) {
+	result, err := store.Queries.GetKycSubmission(ctx, id)
+	return result, translateError(ctx, err, domain.ErrKYCSubmissionNotFound)
+}
+
+func (store *StoreSQL) GetKycSubmissionForUpdate(ctx context.Context, id int64) (KycSubmission, error) {
+	result, err := store.Queries.GetKycSubmissionForUpdate(ctx, id)
+	return result, translateError(ctx, err, domain.ErrKYCSubmissionNotFound)
+}
+
+func (store *StoreSQL) GetLastInterestCapitalization(ctx context.Context, accountID int64) (InterestCapitalization, error) {
+	result, err := store.Queries.GetLastInterestCapitalization(ctx, accountID)
+	return result, translateError(ctx, err, domain.ErrNotFound)
+}
+
+func (store *StoreSQL) GetOpenBusinessDay(ctx context.Context) (BusinessDay, error) {
+	result, err := store.Queries.GetOpenBusinessDay(ctx)
+	return result, translateError(ctx, err, domain.ErrNotFound)
+}
+
+func (store *StoreSQL) GetOpenBusinessDayForShare(ctx context.Context) (BusinessDay, error) {
+	result, err := store.Queries.GetOpenBusinessDayForShare(ctx)
+	return result, translateError(ctx, err, domain.ErrNotFound)
+}
+
+func (store *StoreSQL) GetPaymentRequest(ctx context.Context, id int64) (PaymentRequest, error) {
+	result, err := store.Queries.GetPaymentRequest(ctx, id)
+	return result, translateError(ctx, err, domain.ErrPaymentRequestNotFound)
+}
+
+func (store *StoreSQL) GetPaymentRequestForUpdate(ctx context.Context, id int64) (PaymentRequest, error) {
+	result, err := store.Queries.GetPaymentRequestForUpdate(ctx, id)
+	return result, translateError(ctx, err, domain.ErrPaymentRequestNotFound)
+}
+
+func (store *StoreSQL) GetSession(ctx context.Context, id uuid.UUID) (Session, error) {
+	result, err := store.Queries.GetSession(ctx, id)
+	return result, translateError(ctx, err, domain.ErrSessionNotFound)
+}
+
+func (store *StoreSQL) GetTransfer(ctx context.Context, id int64) (Transfer, error) {
+	result, err := store.Queries.GetTransfer(ctx, id)
+	return result, translateError(ctx, err, domain.ErrTransferNotFound)
+}
+
+func (store *StoreSQL) GetTransferBatch(ctx context.Context, id int64) (TransferBatch, error) {
+	result, err := store.Queries.GetTransferBatch(ctx, id)
+	return result, translateError(ctx, err, domain.ErrTransferBatchNotFound)
+}
+
+func (store *StoreSQL) GetUser(ctx context.Context, username string) (User, error) {
+	result, err := store.Queries.GetUser(ctx, username)
+	return result, translateError(ctx, err, domain.ErrUserNotFound)
+}
+
+func (store *StoreSQL) GetUserByVerifiedEmail(ctx context.Context, email string) (User, error) {
+	result, err := store.Queries.GetUserByVerifiedEmail(ctx, email)
+	return result, translateError(ctx, err, domain.ErrUserNotFound)
+}
+
+func (store *StoreSQL) GetUserForUpdate(ctx context.Context, username string) (User, error) {
+	result, err := store.Queries.GetUserForUpdate(ctx, username)
+	return result, translateError(ctx, err, domain.ErrUserNotFound)
+}
+
+func (store *StoreSQL) GetUserKycLimits(ctx context.Context, username string) (KycLevel, error) {
+	result, err := store.Queries.GetUserKycLimits(ctx, username)
+	return result, translateError(ctx, err, domain.ErrUserNotFound)
+}
+
+func (store *StoreSQL) GetUserKycTransferLimits(ctx context.Context, arg GetUserKycTransferLimitsParams) (GetUserKycTransferLimitsRow, error) {
+	result, err := store.Queries.GetUserKycTransferLimits(ctx, arg)
+	return result, translateError(ctx, err, domain.ErrUserNotFound)
+}
+
+func (store *StoreSQL) GetUserTier(ctx context.Context, username string) (UserTier, error) {
+	result, err := store.Queries.GetUserTier(ctx, username)
+	return result, translateError(ctx, err, domain.ErrUserNotFound)
+}
+
+func (store *StoreSQL) HasTransferredTo(ctx context.Context, arg HasTransferredToParams) (bool, error) {
+	result, err := store.Queries.HasTransferredTo(ctx, arg)
+	return result, translateError(ctx, err, domain.ErrTransferNotFound)
+}
+
+func (store *StoreSQL) ListAccountProducts(ctx context.Context) ([]AccountProduct, error) {
+	result, err := store.Queries.ListAccountProducts(ctx)
+	return result, translateError(ctx, err, domain.ErrNotFound)
+}
+
+func (store *StoreSQL) ListAccounts(ctx context.Context, arg ListAccountsParams) ([]Account, error) {
+	result, err := store.Queries.ListAccounts(ctx, arg)
+	return result, translateError(ctx, err, domain.ErrAccountNotFound)
+}
+
+func (store *StoreSQL) ListAccountsAfter(ctx context.Context, arg ListAccountsAfterParams) ([]Account, error) {
+	result, err := store.Queries.ListAccountsAfter(ctx, arg)
+	return result, translateError(ctx, err, domain.ErrAccountNotFound)
+}
+
+func (store *StoreSQL) ListAccountsWithUncapitalizedInterest(ctx context.Context, before time.Time) ([]int64, error) {
+	result, err := store.Queries.ListAccountsWithUncapitalizedInterest(ctx, before)
+	return result, translateError(ctx, err, domain.ErrAccountNotFound)
+}
+
+func (store *StoreSQL) ListBeneficiariesAfter(ctx context.Context, arg ListBeneficiariesAfterParams) ([]Beneficiary, error) {
+	result, err := store.Queries.ListBeneficiariesAfter(ctx, arg)
+	return result, translateError(ctx, err, domain.ErrBeneficiaryNotFound)
+}
+
+func (store *StoreSQL) ListCategorizationRules(ctx context.Context, owner string) ([]CategorizationRule, error) {
+	result, err := store.Queries.ListCategorizationRules(ctx, owner)
+	return result, translateError(ctx, err, domain.ErrCategorizationRuleNotFound)
+}
+
+func (store *StoreSQL) ListCurrencies(ctx context.Context) ([]Currency, error) {
+	result, err := store.Queries.ListCurrencies(ctx)
+	return result, translateError(ctx, err, domain.ErrNotFound)
+}
+
+func (store *StoreSQL) ListDisputeEvents(ctx context.Context, disputeID int64) ([]DisputeEvent, error) {
+	result, err := store.Queries.ListDisputeEvents(ctx, disputeID)
+	return result, translateError(ctx, err, domain.ErrNotFound)
+}
+
+func (store *StoreSQL) ListDisputeEvidence(ctx context.Context, disputeID int64) ([]DisputeEvidence, error) {
+	result, err := store.Queries.ListDisputeEvidence(ctx, disputeID)
+	return result, translateError(ctx, err, domain.ErrNotFound)
+}
+
+func (store *StoreSQL) ListDisputes(ctx context.Context, arg ListDisputesParams) ([]Dispute, error) {
+	result, err := store.Queries.ListDisputes(ctx, arg)
+	return result, translateError(ctx, err, domain.ErrDisputeNotFound)
+}
+
+func (store *StoreSQL) ListDueEscrowIDs(ctx context.Context, pageLimit int32) ([]int64, error) {
+	result, err := store.Queries.ListDueEscrowIDs(ctx, pageLimit)
+	return result, translateError(ctx, err, domain.ErrEscrowNotFound)
+}
+
+func (store *StoreSQL) ListEODSteps(ctx context.Context, businessDate time.Time) ([]EodStep, error) {
+	result, err := store.Queries.ListEODSteps(ctx, businessDate)
+	return result, translateError(ctx, err, domain.ErrNotFound)
+}
+
+func (store *StoreSQL) ListEntriesAsc(ctx context.Context, arg ListEntriesAscParams) ([]ListEntriesAscRow, error) {
+	result, err := store.Queries.ListEntriesAsc(ctx, arg)
+	return result, translateError(ctx, err, domain.ErrEntryNotFound)
+}
+
+func (store *StoreSQL) ListEntriesDesc(ctx context.Context, arg ListEntriesDescParams) ([]ListEntriesDescRow, error) {
+	result, err := store.Queries.ListEntriesDesc(ctx, arg)
+	return result, translateError(ctx, err, domain.ErrEntryNotFound)
+}
+
+func (store *StoreSQL) ListEntry(ctx context.Context, arg ListEntryParams) ([]Entry, error) {
+	result, err := store.Queries.ListEntry(ctx, arg)
+	return result, translateError(ctx, err, domain.ErrEntryNotFound)
+}
+
+func (store *StoreSQL) ListEscrows(ctx context.Context, arg ListEscrowsParams) ([]Escrow, error) {
+	result, err := store.Queries.ListEscrows(ctx, arg)
+	return result, translateError(ctx, err, domain.ErrEscrowNotFound)
+}
+
+func (store *StoreSQL) ListFeeScheduleBands(ctx context.Context, scheduleID int64) ([]FeeScheduleBand, error) {
+	result, err := store.Queries.ListFeeScheduleBands(ctx, scheduleID)
+	return result, translateError(ctx, err, domain.ErrNotFound)
+}
+
+func (store *StoreSQL) ListGLAccounts(ctx context.Context) ([]GlAccount, error) {
+	result, err := store.Queries.ListGLAccounts(ctx)
+	return result, translateError(ctx, err, domain.ErrAccountNotFound)
+}
+
+func (store *StoreSQL) ListInterestBearingAccounts(ctx context.Context, arg ListInterestBearingAccountsParams) ([]ListInterestBearingAccountsRow, error) {
+	result, err := store.Queries.ListInterestBearingAccounts(ctx, arg)
+	return result, translateError(ctx, err, domain.ErrAccountNotFound)
+}
+
+func (store *StoreSQL) ListKycDocuments(ctx context.Context, submissionID int64) ([]KycDocument, error) {
+	result, err := store.Queries.ListKycDocuments(ctx, submissionID)
+	return result, translateError(ctx, err, domain.ErrNotFound)
+}
+
+func (store *StoreSQL) ListKycLevelLimits(ctx context.Context) ([]KycLevelLimit, error) {
+	result, err := store.Queries.ListKycLevelLimits(ctx)
+	return result, translateError(ctx, err, domain.ErrNotFound)
+}
+
+func (store *StoreSQL) ListKycLevels(ctx context.Context) ([]KycLevel, error) {
+	result, err := store.Queries.ListKycLevels(ctx)
+	return result, translateError(ctx, err, domain.ErrNotFound)
+}
+
+func (store *StoreSQL) ListNotificationsBefore(ctx context.Context, arg ListNotificationsBeforeParams) ([]Notification, error) {
+	result, err := store.Queries.ListNotificationsBefore(ctx, arg)
+	return result, translateError(ctx, err, domain.ErrNotFound)
+}
+
+func (store *StoreSQL) ListOverdraftApprovals(ctx context.Context, accountID int64) ([]OverdraftApproval, error) {
+	result, err := store.Queries.ListOverdraftApprovals(ctx, accountID)
+	return result, translateError(ctx, err, domain.ErrNotFound)
+}
+
+func (store *StoreSQL) ListOverdrawnAccounts(ctx context.Context, arg ListOverdrawnAccountsParams) ([]Account, error) {
+	result, err := store.Queries.ListOverdrawnAccounts(ctx, arg)
+	return result, translateError(ctx, err, domain.ErrAccountNotFound)
+}
+
+func (store *StoreSQL) ListPaymentRequestsBefore(ctx context.Context, arg ListPaymentRequestsBeforeParams) ([]PaymentRequest, error) {
+	result, err := store.Queries.ListPaymentRequestsBefore(ctx, arg)
+	return result, translateError(ctx, err, domain.ErrPaymentRequestNotFound)
+}
+
+func (store *StoreSQL) ListPendingFraudDecisions(ctx context.Context, arg ListPendingFraudDecisionsParams) ([]FraudDecision, error) {
+	result, err := store.Queries.ListPendingFraudDecisions(ctx, arg)
+	return result, translateError(ctx, err, domain.ErrFraudDecisionNotFound)
+}
+
+func (store *StoreSQL) ListPendingKycSubmissions(ctx context.Context, arg ListPendingKycSubmissionsParams) ([]KycSubmission, error) {
+	result, err := store.Queries.ListPendingKycSubmissions(ctx, arg)
+	return result, translateError(ctx, err, domain.ErrKYCSubmissionNotFound)
+}
+
+func (store *StoreSQL) ListPendingTransferBatchItems(ctx context.Context, batchID int64) ([]TransferBatchItem, error) {
+	result, err := store.Queries.ListPendingTransferBatchItems(ctx, batchID)
+	return result, translateError(ctx, err, domain.ErrTransferBatchNotFound)
+}
+
+func (store *StoreSQL) ListScreeningMatches(ctx context.Context, arg ListScreeningMatchesParams) ([]ScreeningMatch, error) {
+	result, err := store.Queries.ListScreeningMatches(ctx, arg)
+	return result, translateError(ctx, err, domain.ErrNotFound)
+}
+
+func (store *StoreSQL) ListScreeningWhitelist(ctx context.Context) ([]ScreeningWhitelist, error) {
+	result, err := store.Queries.ListScreeningWhitelist(ctx)
+	return result, translateError(ctx, err, domain.ErrNotFound)
+}
+
+func (store *StoreSQL) ListTransferBatchItems(ctx context.Context, batchID int64) ([]TransferBatchItem, error) {
+	result, err := store.Queries.ListTransferBatchItems(ctx, batchID)
+	return result, translateError(ctx, err, domain.ErrTransferBatchNotFound)
+}
+
+func (store *StoreSQL) ListTransferBatchesBefore(ctx context.Context, arg ListTransferBatchesBeforeParams) ([]TransferBatch, error) {
+	result, err := store.Queries.ListTransferBatchesBefore(ctx, arg)
+	return result, translateError(ctx, err, domain.ErrTransferBatchNotFound)
+}
+
+func (store *StoreSQL) ListTransfers(ctx context.Context, arg ListTransfersParams) ([]Transfer, error) {
+	result, err := store.Queries.ListTransfers(ctx, arg)
+	return result, translateError(ctx, err, domain.ErrTransferNotFound)
+}
+
+func (store *StoreSQL) ListTransfersAsc(ctx context.Context, arg ListTransfersAscParams) ([]ListTransfersAscRow, error) {
+	result, err := store.Queries.ListTransfersAsc(ctx, arg)
+	return result, translateError(ctx, err, domain.ErrTransferNotFound)
+}
+
+func (store *StoreSQL) ListTransfersDesc(ctx context.Context, arg ListTransfersDescParams) ([]ListTransfersDescRow, error) {
+	result, err := store.Queries.ListTransfersDesc(ctx, arg)
+	return result, translateError(ctx, err, domain.ErrTransferNotFound)
+}
+
+func (store *StoreSQL) ListUserDisputes(ctx context.Context, username string) ([]Dispute, error) {
+	result, err := store.Queries.ListUserDisputes(ctx, username)
+	return result, translateError(ctx, err, domain.ErrDisputeNotFound)
+}
+
+func (store *StoreSQL) ListUserEscrows(ctx context.Context, username string) ([]Escrow, error) {
+	result, err := store.Queries.ListUserEscrows(ctx, username)
+	return result, translateError(ctx, err, domain.ErrEscrowNotFound)
+}
+
+func (store *StoreSQL) ListUserKycSubmissions(ctx context.Context, username string) ([]KycSubmission, error) {
+	result, err := store.Queries.ListUserKycSubmissions(ctx, username)
+	return result, translateError(ctx, err, domain.ErrKYCSubmissionNotFound)
+}
+
+func (store *StoreSQL) ListUsernamesByRole(ctx context.Context, role string) ([]string, error) {
+	result, err := store.Queries.ListUsernamesByRole(ctx, role)
+	return result, translateError(ctx, err, domain.ErrUserNotFound)
+}
+
+func (store *StoreSQL) ListWhitelistedEntryIDs(ctx context.Context, name string) ([]string, error) {
+	result, err := store.Queries.ListWhitelistedEntryIDs(ctx, name)
+	return result, translateError(ctx, err, domain.ErrEntryNotFound)
+}
+
+func (store *StoreSQL) MarkBeneficiaryVerified(ctx context.Context, id int64) (Beneficiary, error) {
+	result, err := store.Queries.MarkBeneficiaryVerified(ctx, id)
+	return result, translateError(ctx, err, domain.ErrBeneficiaryNotFound)
+}
+
+func (store *StoreSQL) MarkInterestCapitalized(ctx context.Context, arg MarkInterestCapitalizedParams) error {
+	return translateError(ctx, store.Queries.MarkInterestCapitalized(ctx, arg), domain.ErrNotFound)
+}
+
+func (store *StoreSQL) MatchCategorizationRule(ctx context.Context, arg MatchCategorizationRuleParams) (string, error) {
+	result, err := store.Queries.MatchCategorizationRule(ctx, arg)
+	return result, translateError(ctx, err, domain.ErrCategorizationRuleNotFound)
+}
+
+func (store *StoreSQL) RaiseUserKycLevel(ctx context.Context, arg RaiseUserKycLevelParams) (User, error) {
+	result, err := store.Queries.RaiseUserKycLevel(ctx, arg)
+	return result, translateError(ctx, err, domain.ErrNotFound)
+}
+
+func (store *StoreSQL) ReviewFraudDecision(ctx context.Context, arg ReviewFraudDecisionParams) (FraudDecision, error) {
+	result, err := store.Queries.ReviewFraudDecision(ctx, arg)
+	return result, translateError(ctx, err, domain.ErrFraudDecisionNotFound)
+}
+
+func (store *StoreSQL) ReviewKycSubmission(ctx context.Context, arg ReviewKycSubmissionParams) (KycSubmission, error) {
+	result, err := store.Queries.ReviewKycSubmission(ctx, arg)
+	return result, translateError(ctx, err, domain.ErrKYCSubmissionNotFound)
+}
+
+func (store *StoreSQL) SetAccountOverdraftLimit(ctx context.Context, arg SetAccountOverdraftLimitParams) (Account, error) {
+	result, err := store.Queries.SetAccountOverdraftLimit(ctx, arg)
+	return result, translateError(ctx, err, domain.ErrAccountNotFound)
+}
+
+func (store *StoreSQL) SetAccountOverdrawnSince(ctx context.Context, arg SetAccountOverdrawnSinceParams) error {
+	return translateError(ctx, store.Queries.SetAccountOverdrawnSince(ctx, arg), domain.ErrAccountNotFound)
+}
+
+func (store *StoreSQL) SetFraudDecisionTransfer(ctx context.Context, arg SetFraudDecisionTransferParams) error {
+	return translateError(ctx, store.Queries.SetFraudDecisionTransfer(ctx, arg), domain.ErrFraudDecisionNotFound)
+}
+
+func (store *StoreSQL) SnapshotDailyBalances(ctx context.Context, businessDate time.Time) (int64, error) {
+	result, err := store.Queries.SnapshotDailyBalances(ctx, businessDate)
+	return result, translateError(ctx, err, domain.ErrNotFound)
+}
+
+func (store *StoreSQL) StartClosingBusinessDay(ctx context.Context, businessDate time.Time) (BusinessDay, error) {
+	result, err := store.Queries.StartClosingBusinessDay(ctx, businessDate)
+	return result, translateError(ctx, err, domain.ErrNotFound)
+}
+
+func (store *StoreSQL) SumEntriesByPeriod(ctx context.Context, arg SumEntriesByPeriodParams) ([]SumEntriesByPeriodRow, error) {
+	result, err := store.Queries.SumEntriesByPeriod(ctx, arg)
+	return result, translateError(ctx, err, domain.ErrEntryNotFound)
+}
+
+func (store *StoreSQL) SumGLBalances(ctx context.Context, arg SumGLBalancesParams) ([]SumGLBalancesRow, error) {
+	result, err := store.Queries.SumGLBalances(ctx, arg)
+	return result, translateError(ctx, err, domain.ErrNotFound)
+}
+
+func (store *StoreSQL) SumTransfersFromOwnerSince(ctx context.Context, arg SumTransfersFromOwnerSinceParams) (int64, error) {
+	result, err := store.Queries.SumTransfersFromOwnerSince(ctx, arg)
+	return result, translateError(ctx, err, domain.ErrTransferNotFound)
+}
+
+func (store *StoreSQL) SumTransfersFromOwnerSinceByCurrency(ctx context.Context, arg SumTransfersFromOwnerSinceByCurrencyParams) ([]SumTransfersFromOwnerSinceByCurrencyRow, error) {
+	result, err := store.Queries.SumTransfersFromOwnerSinceByCurrency(ctx, arg)
+	return result, translateError(ctx, err, domain.ErrTransferNotFound)
+}
+
+func (store *StoreSQL) SumTransfersToAccountSince(ctx context.Context, arg SumTransfersToAccountSinceParams) (int64, error) {
+	result, err := store.Queries.SumTransfersToAccountSince(ctx, arg)
+	return result, translateError(ctx, err, domain.ErrAccountNotFound)
+}
+
+func (store *StoreSQL) SumUncapitalizedInterest(ctx context.Context, arg SumUncapitalizedInterestParams) (int64, error) {
+	result, err := store.Queries.SumUncapitalizedInterest(ctx, arg)
+	return result, translateError(ctx, err, domain.ErrNotFound)
+}
+
+func (store *StoreSQL) SummarizeOverdrafts(ctx context.Context) ([]SummarizeOverdraftsRow, error) {
+	result, err := store.Queries.SummarizeOverdrafts(ctx)
+	return result, translateError(ctx, err, domain.ErrNotFound)
+}
+
+func (store *StoreSQL) UpdateBeneficiaryNickname(ctx context.Context, arg UpdateBeneficiaryNicknameParams) (Beneficiary, error) {
+	result, err := store.Queries.UpdateBeneficiaryNickname(ctx, arg)
+	return result, translateError(ctx, err, domain.ErrBeneficiaryNotFound)
+}
+
+func (store *StoreSQL) UpdateDispute(ctx context.Context, arg UpdateDisputeParams) (Dispute, error) {
+	result, err := store.Queries.UpdateDispute(ctx, arg)
+	return result, translateError(ctx, err, domain.ErrDisputeNotFound)
+}
+
+func (store *StoreSQL) UpdateEscrow(ctx context.Context, arg UpdateEscrowParams) (Escrow, error) {
+	result, err := store.Queries.UpdateEscrow(ctx, arg)
+	return result, translateError(ctx, err, domain.ErrEscrowNotFound)
+}
+
+func (store *StoreSQL) UpdatePaymentRequestStatus(ctx context.Context, arg UpdatePaymentRequestStatusParams) (PaymentRequest, error) {
+	result, err := store.Queries.UpdatePaymentRequestStatus(ctx, arg)
+	return result, translateError(ctx, err, domain.ErrPaymentRequestNotFound)
+}
+
+func (store *StoreSQL) UpdateTransferBatchItem(ctx context.Context, arg UpdateTransferBatchItemParams) (TransferBatchItem, error) {
+	result, err := store.Queries.UpdateTransferBatchItem(ctx, arg)
+	return result, translateError(ctx, err, domain.ErrTransferBatchNotFound)
+}
+
+func (store *StoreSQL) UpdateTransferBatchProgress(ctx context.Context, id int64) (TransferBatch, error) {
+	result, err := store.Queries.UpdateTransferBatchProgress(ctx, id)
+	return result, translateError(ctx, err, domain.ErrTransferBatchNotFound)
+}
+
+func (store *StoreSQL) UpdateUser(ctx context.Context, arg UpdateUserParams) (User, error) {
+	result, err := store.Queries.UpdateUser(ctx, arg)
+	return result, translateError(ctx, err, domain.ErrUserNotFound)
+}
+
+func (store *StoreSQL) UpdatedAccount(ctx context.Context, arg UpdatedAccountParams) (Account, error) {
+	result, err := store.Queries.UpdatedAccount(ctx, arg)
+	return result, translateError(ctx, err, domain.ErrAccountNotFound)
+}
+
+func (store *StoreSQL) UpsertCurrency(ctx context.Context, arg UpsertCurrencyParams) (Currency, error) {
+	result, err := store.Queries.UpsertCurrency(ctx, arg)
+	return result, translateError(ctx, err, domain.ErrNotFound)
+}
@@ -6,18 +6,27 @@ import (
 	"testing"
 	"time"
 
+	"github.com/NhutHuyDev/sgbank/internal/accountno"
 	"github.com/NhutHuyDev/sgbank/internal/infra/db"
 	"github.com/NhutHuyDev/sgbank/pkg/utils"
 	"github.com/stretchr/testify/require"
 )
 
 func createRandomAccount(t *testing.T) db.Account {
+	return createRandomAccountIn(t, utils.RandomCurrency())
+}
+
+// createRandomAccountIn creates an account for a new user in currency, so
+// that money can move between it and other accounts in that currency.
+func createRandomAccountIn(t *testing.T, currency string) db.Account {
 	user := createRandomUser(t)
 
 	params := db.CreateAccountParams{
-		Owner:    user.Username,
-		Balance:  int64(utils.RandomMoney()),
-		Currency: utils.RandomCurrency(),
+		Owner:         user.Username,
+		Balance:       int64(utils.RandomMoney()),
+		Currency:      currency,
+		AccountNumber: randomAccountNumber(t),
+		Product:       "checking",
 	}
 
 	account, err := testQueries.CreateAccount(context.Background(), params)
@@ -28,6 +37,8 @@ func createRandomAccount(t *testing.T) db.Account {
 	require.Equal(t, params.Owner, account.Owner)
 	require.Equal(t, params.Balance, account.Balance)
 	require.Equal(t, params.Currency, account.Currency)
+	require.Equal(t, params.AccountNumber, account.AccountNumber)
+	require.Equal(t, params.Product, account.Product)
 
 	require.NotZero(t, account.ID)
 	require.NotZero(t, account.CreatedAt)
@@ -35,6 +46,16 @@ func createRandomAccount(t *testing.T) db.Account {
 	return account
 }
 
+func randomAccountNumber(t *testing.T) string {
+	generator, err := accountno.NewGenerator(accountno.DefaultBankCode, accountno.DefaultBranchCode)
+	require.NoError(t, err)
+
+	number, err := generator.Generate()
+	require.NoError(t, err)
+
+	return number
+}
+
 func TestCreateAccount(t *testing.T) {
 	createRandomAccount(t)
 }
@@ -54,6 +75,15 @@ func TestGetAccount(t *testing.T) {
 	require.WithinDuration(t, account1.CreatedAt, account2.CreatedAt, time.Second)
 }
 
+func TestGetAccountByNumber(t *testing.T) {
+	account1 := createRandomAccount(t)
+	account2, err := testQueries.GetAccountByNumber(context.Background(), account1.AccountNumber)
+
+	require.NoError(t, err)
+	require.Equal(t, account1.ID, account2.ID)
+	require.Equal(t, account1.AccountNumber, account2.AccountNumber)
+}
+
 func TestGetAccountForUpdate(t *testing.T) {
 	account1 := createRandomAccount(t)
 	account2, err := testQueries.GetAccountForUpdate(context.Background(), account1.ID)
@@ -0,0 +1,50 @@
+package test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/NhutHuyDev/sgbank/internal/infra/db"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetAccountBalanceBefore(t *testing.T) {
+	store := db.NewStore(testDB)
+	ctx := context.Background()
+
+	account1 := createFundedAccount(t)
+	account2 := createRandomAccountIn(t, account1.Currency)
+
+	_, err := store.TransferTx(ctx, db.TransferTxParams{FromAccountID: account1.ID, ToAccountID: account2.ID, Amount: 10})
+	require.NoError(t, err)
+
+	between := time.Now()
+
+	_, err = store.TransferTx(ctx, db.TransferTxParams{FromAccountID: account1.ID, ToAccountID: account2.ID, Amount: 20})
+	require.NoError(t, err)
+
+	balance, err := testQueries.GetAccountBalanceBefore(ctx, db.GetAccountBalanceBeforeParams{AccountID: account1.ID, Before: between})
+	require.NoError(t, err)
+	require.Equal(t, account1.Balance-10, balance)
+
+	balance, err = testQueries.GetAccountBalanceBefore(ctx, db.GetAccountBalanceBeforeParams{AccountID: account2.ID, Before: time.Now()})
+	require.NoError(t, err)
+	require.Equal(t, account2.Balance+30, balance)
+
+	rows, err := testQueries.SumEntriesByPeriod(ctx, db.SumEntriesByPeriodParams{
+		Period:      "day",
+		AccountID:   account1.ID,
+		CreatedFrom: account1.CreatedAt,
+		CreatedTo:   time.Now().Add(time.Second),
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, rows)
+
+	var debits int64
+	for _, row := range rows {
+		debits += row.Debits
+		require.Zero(t, row.Credits)
+	}
+	require.Equal(t, int64(30), debits)
+}
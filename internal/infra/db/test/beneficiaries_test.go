@@ -0,0 +1,79 @@
+package test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/NhutHuyDev/sgbank/internal/infra/db"
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomBeneficiary(t *testing.T, owner string, account db.Account) db.Beneficiary {
+	arg := db.CreateBeneficiaryParams{
+		Owner:         owner,
+		AccountNumber: account.AccountNumber,
+		Nickname:      "payee",
+		Currency:      account.Currency,
+	}
+
+	beneficiary, err := testQueries.CreateBeneficiary(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotZero(t, beneficiary.ID)
+	require.Equal(t, arg.AccountNumber, beneficiary.AccountNumber)
+	require.False(t, beneficiary.IsVerified)
+
+	return beneficiary
+}
+
+func TestBeneficiaryLifecycle(t *testing.T) {
+	owner := createRandomUser(t)
+	beneficiary := createRandomBeneficiary(t, owner.Username, createRandomAccount(t))
+	ctx := context.Background()
+
+	verified, err := testQueries.MarkBeneficiaryVerified(ctx, beneficiary.ID)
+	require.NoError(t, err)
+	require.True(t, verified.IsVerified)
+
+	renamed, err := testQueries.UpdateBeneficiaryNickname(ctx, db.UpdateBeneficiaryNicknameParams{
+		ID:       beneficiary.ID,
+		Nickname: "renamed",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "renamed", renamed.Nickname)
+
+	beneficiaries, err := testQueries.ListBeneficiariesAfter(ctx, db.ListBeneficiariesAfterParams{
+		Owner:     owner.Username,
+		PageLimit: 10,
+	})
+	require.NoError(t, err)
+	require.Len(t, beneficiaries, 1)
+
+	require.NoError(t, testQueries.DeleteBeneficiary(ctx, beneficiary.ID))
+	_, err = testQueries.GetBeneficiary(ctx, beneficiary.ID)
+	require.Error(t, err)
+}
+
+func TestSumTransfersToAccountSince(t *testing.T) {
+	store := db.NewStore(testDB)
+	from := createFundedAccount(t)
+	to := createRandomAccountIn(t, from.Currency)
+	since := time.Now().Add(-time.Minute)
+
+	for _, amount := range []int64{5, 7} {
+		_, err := store.TransferTx(context.Background(), db.TransferTxParams{
+			FromAccountID: from.ID,
+			ToAccountID:   to.ID,
+			Amount:        amount,
+		})
+		require.NoError(t, err)
+	}
+
+	total, err := testQueries.SumTransfersToAccountSince(context.Background(), db.SumTransfersToAccountSinceParams{
+		Owner:       from.Owner,
+		ToAccountID: to.ID,
+		Since:       since,
+	})
+	require.NoError(t, err)
+	require.EqualValues(t, 12, total)
+}
@@ -0,0 +1,76 @@
+package test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/NhutHuyDev/sgbank/internal/infra/db"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransferTxCategorizesEntries(t *testing.T) {
+	store := db.NewStore(testDB)
+	ctx := context.Background()
+
+	from := createFundedAccount(t)
+	to := createRandomAccountIn(t, from.Currency)
+
+	_, err := testQueries.CreateCategorizationRule(ctx, db.CreateCategorizationRuleParams{
+		Owner:               from.Owner,
+		Category:            "Groceries",
+		DescriptionContains: "market",
+	})
+	require.NoError(t, err)
+
+	// the more specific rule wins through its priority
+	_, err = testQueries.CreateCategorizationRule(ctx, db.CreateCategorizationRuleParams{
+		Owner:                 from.Owner,
+		Category:              "Rent",
+		CounterpartyAccountID: sql.NullInt64{Int64: to.ID, Valid: true},
+		Priority:              10,
+	})
+	require.NoError(t, err)
+
+	result, err := store.TransferTx(ctx, db.TransferTxParams{
+		FromAccountID:     from.ID,
+		ToAccountID:       to.ID,
+		Amount:            10,
+		Description:       "Rent for the flat above the Market",
+		ExternalReference: "LEASE-42",
+		MerchantCategory:  "6513",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "LEASE-42", result.Transfer.ExternalReference)
+	require.Equal(t, "Rent", result.FromEntry.Category)
+	require.Equal(t, "6513", result.FromEntry.MerchantCategory)
+	// rules are per user: the payee has none
+	require.Empty(t, result.ToEntry.Category)
+	require.Equal(t, result.Transfer.Description, result.ToEntry.Description)
+
+	rows, err := store.ListEntriesPage(ctx, db.EntryPageFilter{
+		AccountID: from.ID,
+		Query:     sql.NullString{String: `"flat above" -deposit`, Valid: true},
+		Category:  sql.NullString{String: "Rent", Valid: true},
+		PageLimit: 10,
+	}, db.SortDesc)
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	require.Equal(t, result.FromEntry.ID, rows[0].Entry.ID)
+
+	transfers, err := store.ListTransfersPage(ctx, db.TransferPageFilter{
+		Owner:     to.Owner,
+		Query:     sql.NullString{String: "lease or invoice", Valid: true},
+		PageLimit: 10,
+	}, db.SortAsc)
+	require.NoError(t, err)
+	require.Len(t, transfers, 1)
+
+	transfers, err = store.ListTransfersPage(ctx, db.TransferPageFilter{
+		Owner:     to.Owner,
+		Query:     sql.NullString{String: "groceries", Valid: true},
+		PageLimit: 10,
+	}, db.SortAsc)
+	require.NoError(t, err)
+	require.Empty(t, transfers)
+}
@@ -0,0 +1,73 @@
+package test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/NhutHuyDev/sgbank/internal/domain"
+	"github.com/NhutHuyDev/sgbank/internal/infra/db"
+	"github.com/NhutHuyDev/sgbank/pkg/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListCurrencies(t *testing.T) {
+	currencies, err := testQueries.ListCurrencies(context.Background())
+	require.NoError(t, err)
+
+	byCode := make(map[string]db.Currency, len(currencies))
+	for _, currency := range currencies {
+		byCode[currency.Code] = currency
+	}
+
+	for _, code := range []string{utils.USD, utils.EUR, utils.CAD} {
+		require.True(t, byCode[code].Enabled, code)
+		require.EqualValues(t, 2, byCode[code].Exponent, code)
+	}
+	require.EqualValues(t, 0, byCode["JPY"].Exponent)
+	require.EqualValues(t, 3, byCode["KWD"].Exponent)
+}
+
+func TestUpsertCurrency(t *testing.T) {
+	store := db.NewStore(testDB)
+
+	// XTS is the ISO 4217 code reserved for testing
+	currency, err := store.UpsertCurrency(context.Background(), db.UpsertCurrencyParams{
+		Code:     "XTS",
+		Name:     "Test",
+		Exponent: 3,
+		Enabled:  true,
+	})
+	require.NoError(t, err)
+	require.True(t, currency.Enabled)
+
+	// the exponent is not updated
+	currency, err = store.UpsertCurrency(context.Background(), db.UpsertCurrencyParams{
+		Code:     "XTS",
+		Name:     "Testing",
+		Exponent: 2,
+	})
+	require.NoError(t, err)
+	require.Equal(t, "Testing", currency.Name)
+	require.EqualValues(t, 3, currency.Exponent)
+	require.False(t, currency.Enabled)
+
+	got, err := store.GetCurrency(context.Background(), "XTS")
+	require.NoError(t, err)
+	require.Equal(t, currency, got)
+
+	_, err = store.GetCurrency(context.Background(), "XXX")
+	require.ErrorIs(t, err, domain.ErrNotFound)
+}
+
+func TestCreateAccountUnknownCurrency(t *testing.T) {
+	store := db.NewStore(testDB)
+	user := createRandomUser(t)
+
+	_, err := store.CreateAccount(context.Background(), db.CreateAccountParams{
+		Owner:         user.Username,
+		Currency:      "XXX",
+		AccountNumber: randomAccountNumber(t),
+		Product:       "checking",
+	})
+	require.ErrorIs(t, err, domain.ErrReferenceNotFound)
+}
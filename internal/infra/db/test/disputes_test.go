@@ -0,0 +1,221 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/NhutHuyDev/sgbank/internal/domain"
+	"github.com/NhutHuyDev/sgbank/internal/infra/db"
+	"github.com/stretchr/testify/require"
+)
+
+// createDisputedTransfer sends 200 from a funded account to another account
+// in its currency.
+func createDisputedTransfer(t *testing.T) (db.Account, db.Account, db.Transfer) {
+	store := db.NewStore(testDB)
+
+	customer := createFundedAccount(t)
+	payee := createProductAccount(t, createRandomUser(t).Username, customer.Currency, "checking")
+
+	result, err := store.TransferTx(context.Background(), db.TransferTxParams{FromAccountID: customer.ID, ToAccountID: payee.ID, Amount: 200})
+	require.NoError(t, err)
+	require.Equal(t, db.TransferPayment, result.Transfer.Kind)
+
+	return customer, payee, result.Transfer
+}
+
+func disputeCreditsAccount(t *testing.T, currency string) db.Account {
+	credits, err := testQueries.GetAccountByOwnerProduct(context.Background(), db.GetAccountByOwnerProductParams{
+		Owner:    "sgbank-system",
+		Currency: currency,
+		Product:  "dispute_credits",
+	})
+	if err != nil {
+		credits = createProductAccount(t, "sgbank-system", currency, "dispute_credits")
+	}
+
+	return credits
+}
+
+func openDispute(t *testing.T, customer db.Account, transfer db.Transfer, creditAccountID int64) db.OpenDisputeTxResult {
+	store := db.NewStore(testDB)
+
+	result, err := store.OpenDisputeTx(context.Background(), db.OpenDisputeTxParams{
+		CreateDisputeParams: db.CreateDisputeParams{
+			TransferID: transfer.ID,
+			Username:   customer.Owner,
+			Reason:     "not_received",
+			Amount:     transfer.Amount,
+			Currency:   customer.Currency,
+			DueAt:      time.Now().Add(time.Hour),
+		},
+		Evidence:        []db.CreateDisputeEvidenceParams{{SubmittedBy: customer.Owner, Note: "never arrived"}},
+		CreditAccountID: creditAccountID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, db.DisputeOpen, result.Dispute.Status)
+	require.Len(t, result.Evidence, 1)
+
+	return result
+}
+
+func TestOpenDisputeTxProvisionalCreditWon(t *testing.T) {
+	store := db.NewStore(testDB)
+	ctx := context.Background()
+
+	customer, payee, transfer := createDisputedTransfer(t)
+	credits := disputeCreditsAccount(t, customer.Currency)
+	admin := createRandomUser(t)
+
+	opened := openDispute(t, customer, transfer, credits.ID)
+	require.NotNil(t, opened.ProvisionalCredit)
+	require.EqualValues(t, opened.ProvisionalCredit.Transfer.ID, opened.Dispute.ProvisionalCreditTransferID.Int64)
+	require.Equal(t, customer.ID, opened.ProvisionalCredit.ToAccount.ID)
+	require.EqualValues(t, 1000, opened.ProvisionalCredit.ToAccount.Balance)
+
+	// a transfer has one dispute at a time
+	_, err := store.OpenDisputeTx(ctx, db.OpenDisputeTxParams{CreateDisputeParams: db.CreateDisputeParams{
+		TransferID: transfer.ID,
+		Username:   customer.Owner,
+		Reason:     "duplicate",
+		Amount:     1,
+		Currency:   customer.Currency,
+		DueAt:      time.Now(),
+	}})
+	require.True(t, errors.Is(err, domain.ErrDuplicate))
+
+	result, err := store.TransitionDisputeTx(ctx, db.TransitionDisputeTxParams{
+		ID:              opened.Dispute.ID,
+		Status:          db.DisputeWon,
+		Actor:           admin.Username,
+		Note:            "merchant did not reply",
+		CreditAccountID: credits.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, db.DisputeWon, result.Dispute.Status)
+	require.True(t, result.Dispute.ResolvedAt.Valid)
+	require.Equal(t, db.DisputeOpen, result.Event.FromStatus)
+
+	// the payee repays the provisional credit
+	require.NotNil(t, result.Transfer)
+	require.Equal(t, payee.ID, result.Transfer.FromAccount.ID)
+	require.Equal(t, credits.ID, result.Transfer.ToAccount.ID)
+	require.Zero(t, result.Transfer.FromAccount.Balance)
+
+	events, err := testQueries.ListDisputeEvents(ctx, opened.Dispute.ID)
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+}
+
+func TestTransitionDisputeTx(t *testing.T) {
+	store := db.NewStore(testDB)
+	ctx := context.Background()
+
+	customer, payee, transfer := createDisputedTransfer(t)
+	credits := disputeCreditsAccount(t, customer.Currency)
+	admin := createRandomUser(t)
+
+	opened := openDispute(t, customer, transfer, 0)
+	require.Nil(t, opened.ProvisionalCredit)
+
+	result, err := store.TransitionDisputeTx(ctx, db.TransitionDisputeTxParams{ID: opened.Dispute.ID, Status: db.DisputeUnderReview, Actor: admin.Username})
+	require.NoError(t, err)
+	require.Equal(t, admin.Username, result.Dispute.AssignedTo.String)
+	require.Nil(t, result.Transfer)
+
+	// the payee pays the customer back directly
+	result, err = store.TransitionDisputeTx(ctx, db.TransitionDisputeTxParams{
+		ID:              opened.Dispute.ID,
+		Status:          db.DisputeWon,
+		Actor:           admin.Username,
+		Note:            "refund due",
+		CreditAccountID: credits.ID,
+		Authorize: func(dispute db.Dispute) error {
+			require.Equal(t, db.DisputeUnderReview, dispute.Status)
+			return nil
+		},
+	})
+	require.NoError(t, err)
+	require.NotNil(t, result.Transfer)
+	require.Equal(t, payee.ID, result.Transfer.FromAccount.ID)
+	require.Equal(t, customer.ID, result.Transfer.ToAccount.ID)
+	require.EqualValues(t, 1000, result.Transfer.ToAccount.Balance)
+
+	// a decided dispute stays decided
+	_, err = store.TransitionDisputeTx(ctx, db.TransitionDisputeTxParams{
+		ID:     opened.Dispute.ID,
+		Status: db.DisputeLost,
+		Actor:  admin.Username,
+		Authorize: func(dispute db.Dispute) error {
+			return domain.ErrInvalidTransition
+		},
+	})
+	require.True(t, errors.Is(err, domain.ErrInvalidTransition))
+
+	_, err = store.TransitionDisputeTx(ctx, db.TransitionDisputeTxParams{ID: opened.Dispute.ID + 1000000, Status: db.DisputeLost})
+	require.True(t, errors.Is(err, domain.ErrDisputeNotFound))
+}
+
+func TestWithdrawDisputeTxTakesCreditBack(t *testing.T) {
+	store := db.NewStore(testDB)
+	ctx := context.Background()
+
+	customer, _, transfer := createDisputedTransfer(t)
+	credits := disputeCreditsAccount(t, customer.Currency)
+
+	opened := openDispute(t, customer, transfer, credits.ID)
+
+	result, err := store.TransitionDisputeTx(ctx, db.TransitionDisputeTxParams{
+		ID:              opened.Dispute.ID,
+		Status:          db.DisputeWithdrawn,
+		Actor:           customer.Owner,
+		CreditAccountID: credits.ID,
+	})
+	require.NoError(t, err)
+	require.NotNil(t, result.Transfer)
+	require.Equal(t, customer.ID, result.Transfer.FromAccount.ID)
+	require.EqualValues(t, 800, result.Transfer.FromAccount.Balance)
+
+	// a withdrawn dispute does not block a new one
+	openDispute(t, customer, transfer, 0)
+}
+
+func TestTransitionDisputeTxChargesBackBeyondBalance(t *testing.T) {
+	store := db.NewStore(testDB)
+	ctx := context.Background()
+
+	customer, payee, transfer := createDisputedTransfer(t)
+	credits := disputeCreditsAccount(t, customer.Currency)
+	admin := createRandomUser(t)
+
+	opened := openDispute(t, customer, transfer, 0)
+
+	// the payee spends most of the disputed money before the decision
+	_, err := store.TransferTx(ctx, db.TransferTxParams{
+		FromAccountID: payee.ID,
+		ToAccountID:   createRandomAccountIn(t, payee.Currency).ID,
+		Amount:        150,
+	})
+	require.NoError(t, err)
+
+	result, err := store.TransitionDisputeTx(ctx, db.TransitionDisputeTxParams{
+		ID:              opened.Dispute.ID,
+		Status:          db.DisputeWon,
+		Actor:           admin.Username,
+		CreditAccountID: credits.ID,
+	})
+	require.NoError(t, err)
+	require.NotNil(t, result.Transfer)
+	require.Equal(t, payee.ID, result.Transfer.FromAccount.ID)
+	require.EqualValues(t, -150, result.Transfer.FromAccount.Balance)
+	require.Equal(t, db.TransferForced, result.Transfer.Transfer.Kind)
+	require.Zero(t, result.Transfer.FromAccount.OverdraftLimit)
+	require.NotNil(t, result.Transfer.FromAccount.OverdrawnSince)
+	require.EqualValues(t, 1000, result.Transfer.ToAccount.Balance)
+
+	updated, err := testQueries.GetAccount(ctx, payee.ID)
+	require.NoError(t, err)
+	require.NotNil(t, updated.OverdrawnSince)
+}
@@ -0,0 +1,141 @@
+package test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/NhutHuyDev/sgbank/internal/domain"
+	"github.com/NhutHuyDev/sgbank/internal/infra/db"
+	"github.com/stretchr/testify/require"
+)
+
+// startClosingBusinessDay starts closing the open business day without
+// steps, first finishing a day left closing by an earlier run.
+func startClosingBusinessDay(t *testing.T) db.BusinessDay {
+	store := db.NewStore(testDB)
+	ctx := context.Background()
+
+	closing, err := testQueries.GetClosingBusinessDay(ctx)
+	if err == nil {
+		finishClosingBusinessDay(t, closing)
+	}
+
+	open, err := testQueries.GetOpenBusinessDay(ctx)
+	require.NoError(t, err)
+
+	result, err := store.CloseBusinessDayTx(ctx, db.CloseBusinessDayTxParams{BusinessDate: open.BusinessDate})
+	require.NoError(t, err)
+	require.Equal(t, "closing", result.Closing.Status)
+	require.Equal(t, "open", result.Next.Status)
+	require.Equal(t, open.BusinessDate.AddDate(0, 0, 1), result.Next.BusinessDate)
+
+	return result.Closing
+}
+
+// finishClosingBusinessDay records the daily balances of a closing day and
+// closes it.
+func finishClosingBusinessDay(t *testing.T, day db.BusinessDay) {
+	ctx := context.Background()
+
+	_, err := testQueries.SnapshotDailyBalances(ctx, day.BusinessDate)
+	require.NoError(t, err)
+
+	closed, err := testQueries.FinishClosingBusinessDay(ctx, day.BusinessDate)
+	require.NoError(t, err)
+	require.Equal(t, "closed", closed.Status)
+	require.True(t, closed.ClosedAt.Valid)
+}
+
+func TestCloseBusinessDayTx(t *testing.T) {
+	store := db.NewStore(testDB)
+	ctx := context.Background()
+
+	account1 := createFundedAccount(t)
+	account2 := createRandomAccountIn(t, account1.Currency)
+
+	before, err := store.TransferTx(ctx, db.TransferTxParams{FromAccountID: account1.ID, ToAccountID: account2.ID, Amount: 10})
+	require.NoError(t, err)
+
+	day := startClosingBusinessDay(t)
+	require.Equal(t, day.BusinessDate, before.Transfer.BusinessDate)
+
+	// another day cannot start closing before this one is closed
+	next, err := testQueries.GetOpenBusinessDay(ctx)
+	require.NoError(t, err)
+	_, err = store.CloseBusinessDayTx(ctx, db.CloseBusinessDayTxParams{BusinessDate: next.BusinessDate})
+	require.ErrorIs(t, err, domain.ErrEODInProgress)
+
+	// postings go to the next day
+	after, err := store.TransferTx(ctx, db.TransferTxParams{FromAccountID: account1.ID, ToAccountID: account2.ID, Amount: 5})
+	require.NoError(t, err)
+	require.Equal(t, next.BusinessDate, after.Transfer.BusinessDate)
+	require.Equal(t, next.BusinessDate, after.ToEntry.BusinessDate)
+
+	// and back-dated postings are rejected
+	_, err = store.TransferTx(ctx, db.TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        5,
+		BusinessDate:  day.BusinessDate,
+	})
+	require.ErrorIs(t, err, domain.ErrBusinessDayClosed)
+
+	finishClosingBusinessDay(t, day)
+
+	balance, err := testQueries.GetDailyBalance(ctx, db.GetDailyBalanceParams{AccountID: account2.ID, BusinessDate: day.BusinessDate})
+	require.NoError(t, err)
+	require.EqualValues(t, 10, balance.Balance)
+
+	// the snapshot is recorded once
+	rows, err := testQueries.SnapshotDailyBalances(ctx, day.BusinessDate)
+	require.NoError(t, err)
+	require.Zero(t, rows)
+}
+
+func TestClaimEODStep(t *testing.T) {
+	store := db.NewStore(testDB)
+	ctx := context.Background()
+
+	if closing, err := testQueries.GetClosingBusinessDay(ctx); err == nil {
+		finishClosingBusinessDay(t, closing)
+	}
+	open, err := testQueries.GetOpenBusinessDay(ctx)
+	require.NoError(t, err)
+
+	result, err := store.CloseBusinessDayTx(ctx, db.CloseBusinessDayTxParams{
+		BusinessDate: open.BusinessDate,
+		Steps:        []string{"first", "second"},
+	})
+	require.NoError(t, err)
+	require.Len(t, result.Steps, 2)
+	require.Equal(t, "pending", result.Steps[1].Status)
+	require.EqualValues(t, 2, result.Steps[1].Position)
+
+	claim := db.ClaimEODStepParams{BusinessDate: open.BusinessDate, Name: "first", StaleBefore: time.Now().Add(-time.Hour)}
+	step, err := testQueries.ClaimEODStep(ctx, claim)
+	require.NoError(t, err)
+	require.Equal(t, "running", step.Status)
+	require.EqualValues(t, 1, step.Attempts)
+
+	// a running step is not claimed again until it is stale
+	_, err = testQueries.ClaimEODStep(ctx, claim)
+	require.True(t, errors.Is(err, sql.ErrNoRows))
+
+	_, err = testQueries.FinishClosingBusinessDay(ctx, open.BusinessDate)
+	require.True(t, errors.Is(err, sql.ErrNoRows))
+
+	for _, name := range []string{"first", "second"} {
+		if name == "second" {
+			_, err = testQueries.ClaimEODStep(ctx, db.ClaimEODStepParams{BusinessDate: open.BusinessDate, Name: name, StaleBefore: time.Now()})
+			require.NoError(t, err)
+		}
+		step, err = testQueries.FinishEODStep(ctx, db.FinishEODStepParams{BusinessDate: open.BusinessDate, Name: name, Status: "succeeded"})
+		require.NoError(t, err)
+		require.True(t, step.FinishedAt.Valid)
+	}
+
+	finishClosingBusinessDay(t, result.Closing)
+}
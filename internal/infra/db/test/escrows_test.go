@@ -0,0 +1,153 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/NhutHuyDev/sgbank/internal/domain"
+	"github.com/NhutHuyDev/sgbank/internal/infra/db"
+	"github.com/stretchr/testify/require"
+)
+
+func escrowAccount(t *testing.T, currency string) db.Account {
+	account, err := testQueries.GetAccountByOwnerProduct(context.Background(), db.GetAccountByOwnerProductParams{
+		Owner:    "sgbank-system",
+		Currency: currency,
+		Product:  "escrow",
+	})
+	if err != nil {
+		account = createProductAccount(t, "sgbank-system", currency, "escrow")
+	}
+
+	return account
+}
+
+// createEscrow puts 300 of a funded account in escrow for another customer.
+func createEscrow(t *testing.T) (db.Account, db.Account, db.CreateEscrowTxResult) {
+	store := db.NewStore(testDB)
+
+	payer := createFundedAccount(t)
+	payee := createProductAccount(t, createRandomUser(t).Username, payer.Currency, "checking")
+	held := escrowAccount(t, payer.Currency)
+
+	result, err := store.CreateEscrowTx(context.Background(), db.CreateEscrowTxParams{
+		Payer:           payer.Owner,
+		Payee:           payee.Owner,
+		FromAccountID:   payer.ID,
+		ToAccountID:     payee.ID,
+		EscrowAccountID: held.ID,
+		Amount:          300,
+		Currency:        payer.Currency,
+		Description:     "camera",
+		ReleaseAt:       time.Now().Add(-time.Minute),
+	})
+	require.NoError(t, err)
+	require.Equal(t, db.EscrowHeld, result.Escrow.Status)
+	require.Equal(t, result.Funding.Transfer.ID, result.Escrow.FundingTransferID)
+	require.Equal(t, held.ID, result.Funding.ToAccount.ID)
+	require.EqualValues(t, 700, result.Funding.FromAccount.Balance)
+
+	return payer, payee, result
+}
+
+func TestCreateEscrowTxInsufficientFunds(t *testing.T) {
+	store := db.NewStore(testDB)
+
+	payer := createFundedAccount(t)
+	payee := createProductAccount(t, createRandomUser(t).Username, payer.Currency, "checking")
+
+	_, err := store.CreateEscrowTx(context.Background(), db.CreateEscrowTxParams{
+		Payer:           payer.Owner,
+		Payee:           payee.Owner,
+		FromAccountID:   payer.ID,
+		ToAccountID:     payee.ID,
+		EscrowAccountID: escrowAccount(t, payer.Currency).ID,
+		Amount:          payer.Balance + 1,
+		Currency:        payer.Currency,
+		ReleaseAt:       time.Now().Add(time.Hour),
+	})
+	require.True(t, errors.Is(err, domain.ErrInsufficientFunds))
+}
+
+func TestSettleEscrowTxRelease(t *testing.T) {
+	store := db.NewStore(testDB)
+	ctx := context.Background()
+
+	_, payee, created := createEscrow(t)
+
+	ids, err := testQueries.ListDueEscrowIDs(ctx, 1000)
+	require.NoError(t, err)
+	require.Contains(t, ids, created.Escrow.ID)
+
+	result, err := store.SettleEscrowTx(ctx, db.SettleEscrowTxParams{ID: created.Escrow.ID, Status: db.EscrowReleased})
+	require.NoError(t, err)
+	require.Equal(t, db.EscrowReleased, result.Escrow.Status)
+	require.False(t, result.Escrow.SettledBy.Valid)
+	require.True(t, result.Escrow.SettledAt.Valid)
+
+	require.NotNil(t, result.Transfer)
+	require.Equal(t, result.Transfer.Transfer.ID, result.Escrow.SettlementTransferID.Int64)
+	require.Equal(t, created.Escrow.EscrowAccountID, result.Transfer.FromAccount.ID)
+	require.Equal(t, payee.ID, result.Transfer.ToAccount.ID)
+	require.EqualValues(t, payee.Balance+300, result.Transfer.ToAccount.Balance)
+
+	ids, err = testQueries.ListDueEscrowIDs(ctx, 1000)
+	require.NoError(t, err)
+	require.NotContains(t, ids, created.Escrow.ID)
+}
+
+func TestSettleEscrowTxDisputeAndRefund(t *testing.T) {
+	store := db.NewStore(testDB)
+	ctx := context.Background()
+
+	payer, _, created := createEscrow(t)
+	admin := createRandomUser(t)
+
+	result, err := store.SettleEscrowTx(ctx, db.SettleEscrowTxParams{
+		ID:     created.Escrow.ID,
+		Status: db.EscrowDisputed,
+		Actor:  payer.Owner,
+		Note:   "never arrived",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "never arrived", result.Escrow.DisputeReason)
+	require.True(t, result.Escrow.DisputedAt.Valid)
+	require.Nil(t, result.Transfer)
+
+	// a disputed escrow is not released on timeout
+	ids, err := testQueries.ListDueEscrowIDs(ctx, 1000)
+	require.NoError(t, err)
+	require.NotContains(t, ids, created.Escrow.ID)
+
+	result, err = store.SettleEscrowTx(ctx, db.SettleEscrowTxParams{
+		ID:     created.Escrow.ID,
+		Status: db.EscrowRefunded,
+		Actor:  admin.Username,
+		Note:   "seller agreed",
+		Authorize: func(escrow db.Escrow) error {
+			require.Equal(t, db.EscrowDisputed, escrow.Status)
+			return nil
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, db.EscrowRefunded, result.Escrow.Status)
+	require.Equal(t, admin.Username, result.Escrow.SettledBy.String)
+	require.Equal(t, "never arrived", result.Escrow.DisputeReason)
+	require.Equal(t, payer.ID, result.Transfer.ToAccount.ID)
+	require.EqualValues(t, 1000, result.Transfer.ToAccount.Balance)
+
+	// a settled escrow stays settled
+	_, err = store.SettleEscrowTx(ctx, db.SettleEscrowTxParams{
+		ID:     created.Escrow.ID,
+		Status: db.EscrowReleased,
+		Authorize: func(escrow db.Escrow) error {
+			return domain.ErrInvalidTransition
+		},
+	})
+	require.True(t, errors.Is(err, domain.ErrInvalidTransition))
+
+	_, err = store.SettleEscrowTx(ctx, db.SettleEscrowTxParams{ID: created.Escrow.ID + 1000000, Status: db.EscrowReleased})
+	require.True(t, errors.Is(err, domain.ErrEscrowNotFound))
+}
@@ -0,0 +1,148 @@
+package test
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"testing"
+
+	"github.com/NhutHuyDev/sgbank/internal/domain"
+	"github.com/NhutHuyDev/sgbank/internal/infra/db"
+	"github.com/NhutHuyDev/sgbank/pkg/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransferTxFee(t *testing.T) {
+	store := db.NewStore(testDB)
+
+	account1 := createRandomAccount(t)
+	account2 := createRandomAccountIn(t, account1.Currency)
+	revenue := createRandomAccountIn(t, account1.Currency)
+
+	amount, fee := int64(10), int64(3)
+
+	result, err := store.TransferTx(context.Background(), db.TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        amount,
+		Description:   "rent",
+		Fee:           fee,
+		FeeAccountID:  revenue.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, fee, result.Transfer.Fee)
+	require.Equal(t, account1.Balance-amount-fee, result.FromAccount.Balance)
+	require.Equal(t, account2.Balance+amount, result.ToAccount.Balance)
+	require.Equal(t, -amount, result.FromEntry.Amount)
+
+	require.NotNil(t, result.FeeEntry)
+	require.Equal(t, account1.ID, result.FeeEntry.AccountID)
+	require.Equal(t, -fee, result.FeeEntry.Amount)
+	require.Equal(t, db.FeeDescription, result.FeeEntry.Description)
+	require.Equal(t, result.Transfer.ID, *result.FeeEntry.TransferID)
+
+	updatedRevenue, err := store.GetAccount(context.Background(), revenue.ID)
+	require.NoError(t, err)
+	require.Equal(t, revenue.Balance+fee, updatedRevenue.Balance)
+
+	// the fee must be covered too
+	_, err = store.TransferTx(context.Background(), db.TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        result.FromAccount.Balance,
+		Fee:           1,
+		FeeAccountID:  revenue.ID,
+	})
+	require.ErrorIs(t, err, domain.ErrInsufficientFunds)
+
+	_, err = store.TransferTx(context.Background(), db.TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        amount,
+		Fee:           1,
+	})
+	require.ErrorIs(t, err, domain.ErrInvalidArgument)
+}
+
+func TestFeeSchedule(t *testing.T) {
+	store := db.NewStore(testDB)
+
+	// a currency of its own so that runs do not collide on the unique key
+	currency := strings.ToUpper(utils.RandomString(6))
+	schedule, err := store.CreateFeeSchedule(context.Background(), db.CreateFeeScheduleParams{
+		Currency: currency,
+		MinFee:   10,
+		MaxFee:   sql.NullInt64{Int64: 500, Valid: true},
+	})
+	require.NoError(t, err)
+
+	_, err = store.CreateFeeScheduleBand(context.Background(), db.CreateFeeScheduleBandParams{
+		ScheduleID: schedule.ID,
+		RatePpm:    5000,
+	})
+	require.NoError(t, err)
+
+	_, err = store.CreateFeeScheduleBand(context.Background(), db.CreateFeeScheduleBandParams{
+		ScheduleID: schedule.ID,
+		UpTo:       sql.NullInt64{Int64: 1000, Valid: true},
+		FlatFee:    25,
+	})
+	require.NoError(t, err)
+
+	// only one unbounded band per schedule
+	_, err = store.CreateFeeScheduleBand(context.Background(), db.CreateFeeScheduleBandParams{
+		ScheduleID: schedule.ID,
+		RatePpm:    1000,
+	})
+	require.ErrorIs(t, err, domain.ErrDuplicate)
+
+	// clients see what was violated, not the driver's error
+	problem := domain.NewProblem(err)
+	require.NotContains(t, problem.Detail, "pq:")
+	require.NotContains(t, problem.Detail, "fee_schedule_bands")
+	require.Contains(t, problem.Detail, "unbounded band")
+
+	found, err := store.GetFeeSchedule(context.Background(), currency)
+	require.NoError(t, err)
+	require.Equal(t, schedule, found)
+
+	bands, err := store.ListFeeScheduleBands(context.Background(), schedule.ID)
+	require.NoError(t, err)
+	require.Len(t, bands, 2)
+	require.Equal(t, int64(1000), bands[0].UpTo.Int64)
+	require.False(t, bands[1].UpTo.Valid)
+
+	_, err = store.GetFeeSchedule(context.Background(), "XXX")
+	require.ErrorIs(t, err, domain.ErrNotFound)
+
+	user := createRandomUser(t)
+	tier, err := store.GetUserTier(context.Background(), user.Username)
+	require.NoError(t, err)
+	require.Equal(t, "standard", tier.Code)
+	require.False(t, tier.FeesWaived)
+}
+
+func TestPostTransferBatchTxFees(t *testing.T) {
+	store := db.NewStore(testDB)
+
+	from := createFundedAccount(t)
+	revenue := createRandomAccountIn(t, from.Currency)
+	created := createTransferBatch(t, from, "all_or_nothing", 40, 70)
+
+	result, err := store.PostTransferBatchTx(context.Background(), db.PostTransferBatchTxParams{
+		FromAccountID: from.ID,
+		Items:         created.Items,
+		Fees:          map[int64]int64{created.Items[0].ID: 2, created.Items[1].ID: 3},
+		FeeAccountID:  revenue.ID,
+	})
+	require.NoError(t, err)
+	require.Len(t, result.Items, 2)
+
+	updatedFrom, err := store.GetAccount(context.Background(), from.ID)
+	require.NoError(t, err)
+	require.Equal(t, from.Balance-40-70-5, updatedFrom.Balance)
+
+	updatedRevenue, err := store.GetAccount(context.Background(), revenue.ID)
+	require.NoError(t, err)
+	require.Equal(t, revenue.Balance+5, updatedRevenue.Balance)
+}
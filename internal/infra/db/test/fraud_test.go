@@ -0,0 +1,254 @@
+package test
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/NhutHuyDev/sgbank/internal/domain"
+	"github.com/NhutHuyDev/sgbank/internal/infra/db"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFraudRuleQueries(t *testing.T) {
+	store := db.NewStore(testDB)
+	ctx := context.Background()
+
+	account1 := createFundedAccount(t)
+	account2 := createRandomAccountIn(t, account1.Currency)
+	since := time.Now().Add(-time.Minute)
+
+	paid, err := testQueries.HasTransferredTo(ctx, db.HasTransferredToParams{Owner: account1.Owner, ToAccountID: account2.ID})
+	require.NoError(t, err)
+	require.False(t, paid)
+
+	for _, amount := range []int64{100, 150, 200} {
+		_, err := store.TransferTx(ctx, db.TransferTxParams{FromAccountID: account1.ID, ToAccountID: account2.ID, Amount: amount})
+		require.NoError(t, err)
+	}
+
+	paid, err = testQueries.HasTransferredTo(ctx, db.HasTransferredToParams{Owner: account1.Owner, ToAccountID: account2.ID})
+	require.NoError(t, err)
+	require.True(t, paid)
+
+	recent, err := testQueries.CountRecentTransfers(ctx, db.CountRecentTransfersParams{Owner: account1.Owner, CreatedSince: since, RoundAmount: 100})
+	require.NoError(t, err)
+	require.Equal(t, int64(3), recent.Transfers)
+	require.Equal(t, int64(2), recent.RoundTransfers)
+
+	sessions, err := testQueries.CountSessionsFromClientIP(ctx, db.CountSessionsFromClientIPParams{
+		Username:      account1.Owner,
+		ClientIp:      "203.0.113.7",
+		CreatedBefore: time.Now(),
+	})
+	require.NoError(t, err)
+	require.Zero(t, sessions.Sessions)
+}
+
+// createHeldDecision records a pending fraud decision holding a transfer of
+// amount from one account to another, of kind with payload.
+func createHeldDecision(t *testing.T, from db.Account, to db.Account, amount int64, kind string, payload any) db.FraudDecision {
+	params, err := json.Marshal(db.TransferTxParams{FromAccountID: from.ID, ToAccountID: to.ID, Amount: amount})
+	require.NoError(t, err)
+
+	data := json.RawMessage(`{}`)
+	if payload != nil {
+		data, err = json.Marshal(payload)
+		require.NoError(t, err)
+	}
+
+	decision, err := testQueries.CreateFraudDecision(context.Background(), db.CreateFraudDecisionParams{
+		Username:      from.Owner,
+		FromAccountID: from.ID,
+		ToAccountID:   to.ID,
+		Amount:        amount,
+		Currency:      from.Currency,
+		Score:         60,
+		Outcome:       "hold",
+		Hits:          json.RawMessage(`[{"rule":"new_beneficiary_large_amount","score":60,"detail":""}]`),
+		Transfer:      params,
+		Status:        db.FraudDecisionPending,
+		Kind:          kind,
+		Payload:       data,
+	})
+	require.NoError(t, err)
+
+	return decision
+}
+
+func TestApproveHeldTransferTx(t *testing.T) {
+	store := db.NewStore(testDB)
+	ctx := context.Background()
+
+	account1 := createFundedAccount(t)
+	account2 := createRandomAccountIn(t, account1.Currency)
+	reviewer := createRandomUser(t)
+
+	decision := createHeldDecision(t, account1, account2, 10, db.HeldTransfer, nil)
+
+	result, err := store.ApproveHeldTransferTx(ctx, db.ApproveHeldTransferTxParams{
+		ID:         decision.ID,
+		ReviewedBy: reviewer.Username,
+		ReviewNote: "confirmed",
+	})
+	require.NoError(t, err)
+	require.Equal(t, db.FraudDecisionApproved, result.Decision.Status)
+	require.Equal(t, result.Transfer.ID, result.Decision.TransferID.Int64)
+	require.Equal(t, reviewer.Username, result.Decision.ReviewedBy.String)
+	require.Equal(t, account1.Balance-10, result.FromAccount.Balance)
+	require.Nil(t, result.PaymentRequest)
+	require.Nil(t, result.TransferBatchItem)
+
+	_, err = store.ApproveHeldTransferTx(ctx, db.ApproveHeldTransferTxParams{ID: decision.ID, ReviewedBy: reviewer.Username})
+	require.ErrorIs(t, err, domain.ErrInvalidTransition)
+
+	_, err = store.ApproveHeldTransferTx(ctx, db.ApproveHeldTransferTxParams{ID: decision.ID + 1000000, ReviewedBy: reviewer.Username})
+	require.ErrorIs(t, err, domain.ErrFraudDecisionNotFound)
+}
+
+func TestApproveHeldPaymentRequest(t *testing.T) {
+	store := db.NewStore(testDB)
+	ctx := context.Background()
+
+	payerAccount := createFundedAccount(t)
+	requesterAccount := createRandomAccountIn(t, payerAccount.Currency)
+	reviewer := createRandomUser(t)
+
+	request, err := testQueries.CreatePaymentRequest(ctx, db.CreatePaymentRequestParams{
+		Requester:   requesterAccount.Owner,
+		Payer:       sql.NullString{String: payerAccount.Owner, Valid: true},
+		ToAccountID: requesterAccount.ID,
+		Amount:      30,
+		Currency:    requesterAccount.Currency,
+		ExpiresAt:   time.Now().Add(time.Hour),
+	})
+	require.NoError(t, err)
+
+	decision := createHeldDecision(t, payerAccount, requesterAccount, request.Amount, db.HeldPaymentRequest, db.PayPaymentRequestTxParams{
+		ID:            request.ID,
+		Payer:         payerAccount.Owner,
+		FromAccountID: payerAccount.ID,
+		Status:        "paid",
+	})
+
+	result, err := store.ApproveHeldTransferTx(ctx, db.ApproveHeldTransferTxParams{ID: decision.ID, ReviewedBy: reviewer.Username})
+	require.NoError(t, err)
+	require.Equal(t, db.FraudDecisionApproved, result.Decision.Status)
+	require.NotNil(t, result.PaymentRequest)
+	require.Equal(t, "paid", result.PaymentRequest.Status)
+	require.Equal(t, result.Transfer.ID, result.PaymentRequest.TransferID.Int64)
+	require.Equal(t, payerAccount.Balance-30, result.FromAccount.Balance)
+
+	// a request that stopped being pending while the payment was held is
+	// not paid, and its decision stays pending
+	cancelled, err := testQueries.CreatePaymentRequest(ctx, db.CreatePaymentRequestParams{
+		Requester:   requesterAccount.Owner,
+		Payer:       sql.NullString{String: payerAccount.Owner, Valid: true},
+		ToAccountID: requesterAccount.ID,
+		Amount:      30,
+		Currency:    requesterAccount.Currency,
+		ExpiresAt:   time.Now().Add(time.Hour),
+	})
+	require.NoError(t, err)
+
+	decision = createHeldDecision(t, payerAccount, requesterAccount, cancelled.Amount, db.HeldPaymentRequest, db.PayPaymentRequestTxParams{
+		ID:            cancelled.ID,
+		Payer:         payerAccount.Owner,
+		FromAccountID: payerAccount.ID,
+		Status:        "paid",
+	})
+
+	_, err = testQueries.UpdatePaymentRequestStatus(ctx, db.UpdatePaymentRequestStatusParams{ID: cancelled.ID, FromStatus: "pending", ToStatus: "cancelled"})
+	require.NoError(t, err)
+
+	_, err = store.ApproveHeldTransferTx(ctx, db.ApproveHeldTransferTxParams{ID: decision.ID, ReviewedBy: reviewer.Username})
+	require.ErrorIs(t, err, domain.ErrInvalidTransition)
+
+	decision, err = testQueries.GetFraudDecision(ctx, decision.ID)
+	require.NoError(t, err)
+	require.Equal(t, db.FraudDecisionPending, decision.Status)
+}
+
+func TestApproveHeldEscrow(t *testing.T) {
+	store := db.NewStore(testDB)
+	ctx := context.Background()
+
+	payer := createFundedAccount(t)
+	payee := createProductAccount(t, createRandomUser(t).Username, payer.Currency, "checking")
+	escrow := escrowAccount(t, payer.Currency)
+	reviewer := createRandomUser(t)
+
+	// the decision names the payee; the escrow account is in the payload
+	decision := createHeldDecision(t, payer, payee, 40, db.HeldEscrow, db.CreateEscrowTxParams{
+		Payer:           payer.Owner,
+		Payee:           payee.Owner,
+		FromAccountID:   payer.ID,
+		ToAccountID:     payee.ID,
+		EscrowAccountID: escrow.ID,
+		Amount:          40,
+		Currency:        payer.Currency,
+		ReleaseAt:       time.Now().Add(time.Hour),
+	})
+
+	result, err := store.ApproveHeldTransferTx(ctx, db.ApproveHeldTransferTxParams{ID: decision.ID, ReviewedBy: reviewer.Username})
+	require.NoError(t, err)
+	require.Equal(t, db.FraudDecisionApproved, result.Decision.Status)
+	require.NotNil(t, result.Escrow)
+	require.Equal(t, db.EscrowHeld, result.Escrow.Status)
+	require.Equal(t, payee.Owner, result.Escrow.Payee)
+	require.Equal(t, result.Transfer.ID, result.Escrow.FundingTransferID)
+	require.Equal(t, escrow.ID, result.Transfer.ToAccountID)
+	require.Equal(t, payer.Balance-40, result.FromAccount.Balance)
+
+	// paying through escrow counts as having paid the payee
+	paid, err := testQueries.HasTransferredTo(ctx, db.HasTransferredToParams{Owner: payer.Owner, ToAccountID: payee.ID})
+	require.NoError(t, err)
+	require.True(t, paid)
+}
+
+func TestReviewHeldTransferBatchItem(t *testing.T) {
+	store := db.NewStore(testDB)
+	ctx := context.Background()
+
+	from := createFundedAccount(t)
+	created := createTransferBatch(t, from, "best_effort", 40, 70)
+	reviewer := createRandomUser(t)
+
+	var decisions []db.FraudDecision
+	for _, item := range created.Items {
+		item, err := testQueries.UpdateTransferBatchItem(ctx, db.UpdateTransferBatchItemParams{ID: item.ID, Status: db.TransferBatchItemHeld})
+		require.NoError(t, err)
+
+		to, err := testQueries.GetAccount(ctx, item.ToAccountID)
+		require.NoError(t, err)
+
+		decisions = append(decisions, createHeldDecision(t, from, to, item.Amount, db.HeldTransferBatchItem, db.PostTransferBatchItemTxParams{
+			FromAccountID: from.ID,
+			Item:          item,
+		}))
+	}
+
+	approved, err := store.ApproveHeldTransferTx(ctx, db.ApproveHeldTransferTxParams{ID: decisions[0].ID, ReviewedBy: reviewer.Username})
+	require.NoError(t, err)
+	require.NotNil(t, approved.TransferBatchItem)
+	require.Equal(t, db.TransferBatchItemSucceeded, approved.TransferBatchItem.Status)
+	require.Equal(t, approved.Transfer.ID, approved.TransferBatchItem.TransferID.Int64)
+	require.Equal(t, from.Balance-40, approved.FromAccount.Balance)
+
+	rejected, err := store.RejectHeldTransferTx(ctx, db.RejectHeldTransferTxParams{ID: decisions[1].ID, ReviewedBy: reviewer.Username})
+	require.NoError(t, err)
+	require.Equal(t, db.FraudDecisionRejected, rejected.Decision.Status)
+	require.NotNil(t, rejected.TransferBatchItem)
+	require.Equal(t, db.TransferBatchItemFailed, rejected.TransferBatchItem.Status)
+
+	batch, err := testQueries.GetTransferBatch(ctx, created.Batch.ID)
+	require.NoError(t, err)
+	require.EqualValues(t, 1, batch.SucceededRows)
+	require.EqualValues(t, 1, batch.FailedRows)
+	require.Zero(t, batch.HeldRows)
+
+	_, err = store.RejectHeldTransferTx(ctx, db.RejectHeldTransferTxParams{ID: decisions[1].ID, ReviewedBy: reviewer.Username})
+	require.ErrorIs(t, err, domain.ErrInvalidTransition)
+}
@@ -0,0 +1,127 @@
+package test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/NhutHuyDev/sgbank/internal/infra/db"
+	"github.com/stretchr/testify/require"
+)
+
+func createProductAccount(t *testing.T, owner string, currency string, product string) db.Account {
+	account, err := testQueries.CreateAccount(context.Background(), db.CreateAccountParams{
+		Owner:         owner,
+		Currency:      currency,
+		AccountNumber: randomAccountNumber(t),
+		Product:       product,
+	})
+	require.NoError(t, err)
+
+	return account
+}
+
+func TestListInterestBearingAccounts(t *testing.T) {
+	store := db.NewStore(testDB)
+	ctx := context.Background()
+
+	checking := createFundedAccount(t)
+	savings := createProductAccount(t, checking.Owner, checking.Currency, "savings")
+
+	_, err := store.TransferTx(ctx, db.TransferTxParams{FromAccountID: checking.ID, ToAccountID: savings.ID, Amount: 300})
+	require.NoError(t, err)
+	day := startClosingBusinessDay(t)
+
+	_, err = store.TransferTx(ctx, db.TransferTxParams{FromAccountID: checking.ID, ToAccountID: savings.ID, Amount: 200})
+	require.NoError(t, err)
+	finishClosingBusinessDay(t, day)
+
+	var found *db.ListInterestBearingAccountsRow
+	arg := db.ListInterestBearingAccountsParams{BusinessDate: day.BusinessDate, AfterID: savings.ID - 1, PageLimit: 100}
+	rows, err := testQueries.ListInterestBearingAccounts(ctx, arg)
+	require.NoError(t, err)
+	for i := range rows {
+		require.NotEqual(t, checking.ID, rows[i].ID)
+		if rows[i].ID == savings.ID {
+			found = &rows[i]
+		}
+	}
+
+	// the transfer posted to the next business day is not counted
+	require.NotNil(t, found)
+	require.EqualValues(t, 300, found.ClosingBalance)
+	require.EqualValues(t, 20000, found.AnnualRatePpm)
+}
+
+func TestCapitalizeInterestTx(t *testing.T) {
+	store := db.NewStore(testDB)
+	ctx := context.Background()
+
+	customer := createRandomAccount(t)
+	savings := createProductAccount(t, customer.Owner, customer.Currency, "savings")
+	expense, err := testQueries.GetAccountByOwnerProduct(ctx, db.GetAccountByOwnerProductParams{
+		Owner:    "sgbank-system",
+		Currency: customer.Currency,
+		Product:  "interest_expense",
+	})
+	if err != nil {
+		expense = createProductAccount(t, "sgbank-system", customer.Currency, "interest_expense")
+	}
+	period := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	// two days of 0.75 minor units each
+	for day := 30; day <= 31; day++ {
+		rows, err := testQueries.CreateInterestAccrual(ctx, db.CreateInterestAccrualParams{
+			AccountID:      savings.ID,
+			BusinessDate:   period.AddDate(0, 0, day-1),
+			ClosingBalance: 13688,
+			AnnualRatePpm:  20000,
+			DayCount:       365,
+			AmountMicros:   750000,
+		})
+		require.NoError(t, err)
+		require.EqualValues(t, 1, rows)
+	}
+
+	// accruing a date again records nothing
+	rows, err := testQueries.CreateInterestAccrual(ctx, db.CreateInterestAccrualParams{
+		AccountID:    savings.ID,
+		BusinessDate: period.AddDate(0, 0, 30),
+		AmountMicros: 750000,
+	})
+	require.NoError(t, err)
+	require.Zero(t, rows)
+
+	arg := db.CapitalizeInterestTxParams{AccountID: savings.ID, ExpenseAccountID: expense.ID, Period: period, Description: "Interest January 2024"}
+	result, err := store.CapitalizeInterestTx(ctx, arg)
+	require.NoError(t, err)
+	require.EqualValues(t, 1, result.Capitalization.Amount)
+	require.EqualValues(t, 500000, result.Capitalization.CarryMicros)
+	require.NotNil(t, result.Transfer)
+	require.Equal(t, expense.Balance-1, result.Transfer.FromAccount.Balance)
+	require.EqualValues(t, 1, result.Transfer.ToAccount.Balance)
+
+	// capitalizing the period again posts nothing
+	again, err := store.CapitalizeInterestTx(ctx, arg)
+	require.NoError(t, err)
+	require.Nil(t, again.Transfer)
+	require.Equal(t, result.Capitalization.ID, again.Capitalization.ID)
+
+	// the carried half unit is paid with the next month's half unit
+	_, err = testQueries.CreateInterestAccrual(ctx, db.CreateInterestAccrualParams{
+		AccountID:    savings.ID,
+		BusinessDate: period.AddDate(0, 1, 0),
+		AmountMicros: 500000,
+	})
+	require.NoError(t, err)
+
+	arg.Period = period.AddDate(0, 1, 0)
+	result, err = store.CapitalizeInterestTx(ctx, arg)
+	require.NoError(t, err)
+	require.EqualValues(t, 1, result.Capitalization.Amount)
+	require.Zero(t, result.Capitalization.CarryMicros)
+
+	account, err := testQueries.GetAccount(ctx, savings.ID)
+	require.NoError(t, err)
+	require.EqualValues(t, 2, account.Balance)
+}
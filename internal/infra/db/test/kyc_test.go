@@ -0,0 +1,281 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/NhutHuyDev/sgbank/internal/domain"
+	"github.com/NhutHuyDev/sgbank/internal/infra/db"
+	"github.com/NhutHuyDev/sgbank/pkg/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func createKycSubmission(t *testing.T, username string, level int32) db.CreateKycSubmissionTxResult {
+	store := db.NewStore(testDB)
+
+	result, err := store.CreateKycSubmissionTx(context.Background(), db.CreateKycSubmissionTxParams{
+		CreateKycSubmissionParams: db.CreateKycSubmissionParams{Username: username, Level: level},
+		Documents: []db.CreateKycDocumentParams{{
+			Kind:        "id_document",
+			FileName:    "id.png",
+			ContentType: "image/png",
+			Size:        16,
+			Sha256:      utils.RandomString(64),
+			StorageKey:  username + "/" + utils.RandomString(12),
+		}},
+	})
+	require.NoError(t, err)
+	require.Equal(t, db.KycSubmissionPending, result.Submission.Status)
+	require.Len(t, result.Documents, 1)
+	require.Equal(t, result.Submission.ID, result.Documents[0].SubmissionID)
+
+	return result
+}
+
+func TestUserKycLimits(t *testing.T) {
+	store := db.NewStore(testDB)
+	ctx := context.Background()
+
+	user := createRandomUser(t)
+
+	level, err := store.GetUserKycLimits(ctx, user.Username)
+	require.NoError(t, err)
+	require.EqualValues(t, 0, level.Level)
+	require.True(t, level.MaxAccounts.Valid)
+
+	accounts, err := store.CountAccountsByOwner(ctx, user.Username)
+	require.NoError(t, err)
+	require.Zero(t, accounts)
+}
+
+func TestCreateKycSubmissionTxOnePending(t *testing.T) {
+	store := db.NewStore(testDB)
+
+	user := createRandomUser(t)
+	createKycSubmission(t, user.Username, 1)
+
+	_, err := store.CreateKycSubmissionTx(context.Background(), db.CreateKycSubmissionTxParams{
+		CreateKycSubmissionParams: db.CreateKycSubmissionParams{Username: user.Username, Level: 2},
+	})
+	require.True(t, errors.Is(err, domain.ErrDuplicate))
+}
+
+func TestReviewKycSubmissionTx(t *testing.T) {
+	store := db.NewStore(testDB)
+	ctx := context.Background()
+
+	admin := createRandomUser(t)
+	user := createRandomUser(t)
+	submission := createKycSubmission(t, user.Username, 2).Submission
+
+	result, err := store.ReviewKycSubmissionTx(ctx, db.ReviewKycSubmissionTxParams{
+		ID:         submission.ID,
+		Status:     db.KycSubmissionApproved,
+		ReviewedBy: admin.Username,
+	})
+	require.NoError(t, err)
+	require.Equal(t, db.KycSubmissionApproved, result.Submission.Status)
+	require.Equal(t, admin.Username, result.Submission.ReviewedBy.String)
+	require.True(t, result.Submission.ReviewedAt.Valid)
+	require.EqualValues(t, 2, result.User.KycLevel)
+
+	_, err = store.ReviewKycSubmissionTx(ctx, db.ReviewKycSubmissionTxParams{
+		ID:         submission.ID,
+		Status:     db.KycSubmissionRejected,
+		Reason:     "too late",
+		ReviewedBy: admin.Username,
+	})
+	require.True(t, errors.Is(err, domain.ErrInvalidTransition))
+
+	// an approval for a lower level does not lower the level
+	lower := createKycSubmission(t, user.Username, 1).Submission
+	result, err = store.ReviewKycSubmissionTx(ctx, db.ReviewKycSubmissionTxParams{
+		ID:         lower.ID,
+		Status:     db.KycSubmissionApproved,
+		ReviewedBy: admin.Username,
+	})
+	require.NoError(t, err)
+	require.EqualValues(t, 2, result.User.KycLevel)
+
+	_, err = store.ReviewKycSubmissionTx(ctx, db.ReviewKycSubmissionTxParams{ID: lower.ID + 1000000, Status: db.KycSubmissionApproved})
+	require.True(t, errors.Is(err, domain.ErrKYCSubmissionNotFound))
+}
+
+// createHeldAccount gives owner an account of product in currency that holds
+// balance.
+func createHeldAccount(t *testing.T, owner string, currency string, product string, balance int64) db.Account {
+	account := createProductAccount(t, owner, currency, product)
+
+	account, err := testQueries.UpdatedAccount(context.Background(), db.UpdatedAccountParams{ID: account.ID, Balance: balance})
+	require.NoError(t, err)
+
+	return account
+}
+
+func TestTransferTxKYCLimits(t *testing.T) {
+	store := db.NewStore(testDB)
+	ctx := context.Background()
+
+	// an unverified user may send up to 10000 a transfer and 20000 a day in
+	// each of USD and EUR
+	user := createRandomUser(t)
+	usd := createHeldAccount(t, user.Username, utils.USD, "checking", 100000)
+	eur := createHeldAccount(t, user.Username, utils.EUR, "checking", 100000)
+	usdPayee := createRandomAccountIn(t, utils.USD)
+	eurPayee := createRandomAccountIn(t, utils.EUR)
+
+	send := func(from db.Account, to db.Account, amount int64) error {
+		_, err := store.TransferTx(ctx, db.TransferTxParams{FromAccountID: from.ID, ToAccountID: to.ID, Amount: amount})
+		return err
+	}
+
+	require.ErrorIs(t, send(usd, usdPayee, 10001), domain.ErrKYCLimitExceeded)
+	require.NoError(t, send(usd, usdPayee, 10000))
+	require.NoError(t, send(usd, usdPayee, 10000))
+	require.ErrorIs(t, send(usd, usdPayee, 1), domain.ErrKYCLimitExceeded)
+
+	// what was sent in USD does not count towards the limit in EUR
+	require.NoError(t, send(eur, eurPayee, 10000))
+
+	sent, err := store.SumTransfersFromOwnerSinceByCurrency(ctx, db.SumTransfersFromOwnerSinceByCurrencyParams{
+		Owner: user.Username,
+		Since: time.Now().UTC().Truncate(24 * time.Hour),
+	})
+	require.NoError(t, err)
+	require.Equal(t, []db.SumTransfersFromOwnerSinceByCurrencyRow{{Currency: utils.EUR, Total: 10000}, {Currency: utils.USD, Total: 20000}}, sent)
+
+	// a fully verified user has no limits
+	_, err = store.RaiseUserKycLevel(ctx, db.RaiseUserKycLevelParams{Username: user.Username, Level: 2})
+	require.NoError(t, err)
+	require.NoError(t, send(usd, usdPayee, 10001))
+}
+
+// Only payments count towards the daily limit, not what the bank took from
+// the owner's accounts, such as a chargeback.
+func TestSumTransfersFromOwnerSinceOnlyPayments(t *testing.T) {
+	store := db.NewStore(testDB)
+	ctx := context.Background()
+
+	customer, payee, transfer := createDisputedTransfer(t)
+	credits := disputeCreditsAccount(t, customer.Currency)
+	admin := createRandomUser(t)
+
+	_, err := store.TransferTx(ctx, db.TransferTxParams{
+		FromAccountID: payee.ID,
+		ToAccountID:   createRandomAccountIn(t, payee.Currency).ID,
+		Amount:        50,
+	})
+	require.NoError(t, err)
+
+	opened := openDispute(t, customer, transfer, 0)
+	result, err := store.TransitionDisputeTx(ctx, db.TransitionDisputeTxParams{
+		ID:              opened.Dispute.ID,
+		Status:          db.DisputeWon,
+		Actor:           admin.Username,
+		CreditAccountID: credits.ID,
+	})
+	require.NoError(t, err)
+	require.NotNil(t, result.Transfer)
+	require.Equal(t, payee.ID, result.Transfer.FromAccount.ID)
+
+	since := time.Now().UTC().Truncate(24 * time.Hour)
+
+	sent, err := store.SumTransfersFromOwnerSince(ctx, db.SumTransfersFromOwnerSinceParams{
+		Owner:    payee.Owner,
+		Currency: payee.Currency,
+		Since:    since,
+	})
+	require.NoError(t, err)
+	require.EqualValues(t, 50, sent)
+
+	byCurrency, err := store.SumTransfersFromOwnerSinceByCurrency(ctx, db.SumTransfersFromOwnerSinceByCurrencyParams{
+		Owner: payee.Owner,
+		Since: since,
+	})
+	require.NoError(t, err)
+	require.Equal(t, []db.SumTransfersFromOwnerSinceByCurrencyRow{{Currency: payee.Currency, Total: 50}}, byCurrency)
+}
+
+// Payments from two accounts of the same owner do not wait for each other's
+// account locks, so only the lock on the owner keeps them from all fitting in
+// what is left of the daily limit.
+func TestTransferTxKYCDailyLimitConcurrent(t *testing.T) {
+	store := db.NewStore(testDB)
+
+	user := createRandomUser(t)
+	accounts := []db.Account{
+		createHeldAccount(t, user.Username, utils.USD, "checking", 100000),
+		createHeldAccount(t, user.Username, utils.USD, "savings", 100000),
+	}
+
+	n := 8
+	payees := make([]db.Account, n)
+	for i := range payees {
+		payees[i] = createRandomAccountIn(t, utils.USD)
+	}
+
+	errs := make(chan error, n)
+	for i := 0; i < n; i++ {
+		go func() {
+			_, err := store.TransferTx(context.Background(), db.TransferTxParams{
+				FromAccountID: accounts[i%len(accounts)].ID,
+				ToAccountID:   payees[i].ID,
+				Amount:        5000,
+			})
+			errs <- err
+		}()
+	}
+
+	succeeded := 0
+	for i := 0; i < n; i++ {
+		err := <-errs
+		if err == nil {
+			succeeded++
+			continue
+		}
+		require.ErrorIs(t, err, domain.ErrKYCLimitExceeded)
+	}
+	require.Equal(t, 4, succeeded)
+}
+
+// Requests to open accounts at the same time each count the owner's
+// accounts, so only the lock on the owner keeps them from together opening
+// more than the level allows.
+func TestCreateAccountTxKYCLimitConcurrent(t *testing.T) {
+	store := db.NewStore(testDB)
+
+	// an unverified user may hold one account
+	user := createRandomUser(t)
+
+	currencies := []string{utils.USD, utils.EUR, utils.CAD}
+	errs := make(chan error, len(currencies))
+	for _, currency := range currencies {
+		accountNumber := randomAccountNumber(t)
+		go func() {
+			_, err := store.CreateAccountTx(context.Background(), db.CreateAccountParams{
+				Owner:         user.Username,
+				Currency:      currency,
+				AccountNumber: accountNumber,
+				Product:       "checking",
+			})
+			errs <- err
+		}()
+	}
+
+	succeeded := 0
+	for range currencies {
+		err := <-errs
+		if err == nil {
+			succeeded++
+			continue
+		}
+		require.ErrorIs(t, err, domain.ErrKYCLimitExceeded)
+	}
+	require.Equal(t, 1, succeeded)
+
+	accounts, err := store.CountAccountsByOwner(context.Background(), user.Username)
+	require.NoError(t, err)
+	require.EqualValues(t, 1, accounts)
+}
@@ -0,0 +1,62 @@
+package test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/NhutHuyDev/sgbank/internal/infra/db"
+	"github.com/stretchr/testify/require"
+)
+
+func sumGLBalances(t *testing.T, currency string) map[string]int64 {
+	rows, err := testQueries.SumGLBalances(context.Background(), db.SumGLBalancesParams{
+		Before:          time.Now().Add(time.Minute),
+		Currency:        sql.NullString{String: currency, Valid: true},
+		SuspenseAccount: "1900",
+	})
+	require.NoError(t, err)
+
+	balances := make(map[string]int64)
+	var total int64
+	for _, row := range rows {
+		require.Equal(t, currency, row.Currency)
+		balances[row.GlAccount] += row.Balance
+		total += row.Balance
+	}
+	// debits equal credits
+	require.Zero(t, total)
+
+	return balances
+}
+
+func TestSumGLBalances(t *testing.T) {
+	store := db.NewStore(testDB)
+
+	account1 := createRandomAccount(t)
+	account2 := createProductAccount(t, createRandomUser(t).Username, account1.Currency, "checking")
+	revenue := createProductAccount(t, createRandomUser(t).Username, account1.Currency, "fee_revenue")
+
+	before := sumGLBalances(t, account1.Currency)
+
+	_, err := store.TransferTx(context.Background(), db.TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        10,
+		Fee:           3,
+		FeeAccountID:  revenue.ID,
+	})
+	require.NoError(t, err)
+
+	after := sumGLBalances(t, account1.Currency)
+	require.Equal(t, before["4000"]+3, after["4000"])
+	require.Equal(t, before["2000"]+before["1100"]-3, after["2000"]+after["1100"])
+
+	// an entry without a transfer is offset in suspense
+	_, err = testQueries.CreateEntry(context.Background(), db.CreateEntryParams{AccountID: account2.ID, Amount: 5})
+	require.NoError(t, err)
+
+	after = sumGLBalances(t, account1.Currency)
+	require.Equal(t, before["1900"]-5, after["1900"])
+}
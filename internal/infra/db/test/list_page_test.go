@@ -0,0 +1,117 @@
+package test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/NhutHuyDev/sgbank/internal/infra/db"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListEntriesPage(t *testing.T) {
+	store := db.NewStore(testDB)
+
+	account1 := createFundedAccount(t)
+	account2 := createRandomAccountIn(t, account1.Currency)
+
+	var transfers []db.TransferTxResult
+	for _, amount := range []int64{1, 2, 3, 4, 5} {
+		result, err := store.TransferTx(context.Background(), db.TransferTxParams{
+			FromAccountID: account1.ID,
+			ToAccountID:   account2.ID,
+			Amount:        amount,
+		})
+		require.NoError(t, err)
+		require.NotNil(t, result.FromEntry.TransferID)
+		require.Equal(t, result.Transfer.ID, *result.FromEntry.TransferID)
+		transfers = append(transfers, result)
+	}
+
+	filter := db.EntryPageFilter{
+		AccountID:             account1.ID,
+		Direction:             sql.NullString{String: "debit", Valid: true},
+		CounterpartyAccountID: sql.NullInt64{Int64: account2.ID, Valid: true},
+		PageLimit:             2,
+	}
+
+	var seen []int64
+	for {
+		rows, err := store.ListEntriesPage(context.Background(), filter, db.SortDesc)
+		require.NoError(t, err)
+		if len(rows) == 0 {
+			break
+		}
+
+		for _, row := range rows {
+			require.Equal(t, account2.ID, row.CounterpartyAccountID)
+			require.Negative(t, row.Entry.Amount)
+			seen = append(seen, row.Entry.ID)
+		}
+
+		last := rows[len(rows)-1].Entry
+		filter.AfterCreatedAt = sql.NullTime{Time: last.CreatedAt, Valid: true}
+		filter.AfterID = sql.NullInt64{Int64: last.ID, Valid: true}
+	}
+
+	require.Len(t, seen, len(transfers))
+	for i, result := range transfers {
+		require.Equal(t, result.FromEntry.ID, seen[len(seen)-1-i])
+	}
+
+	filter = db.EntryPageFilter{
+		AccountID: account1.ID,
+		MinAmount: sql.NullInt64{Int64: 2, Valid: true},
+		MaxAmount: sql.NullInt64{Int64: 3, Valid: true},
+		PageLimit: 10,
+	}
+	rows, err := store.ListEntriesPage(context.Background(), filter, db.SortAsc)
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+	require.Equal(t, int64(-2), rows[0].Entry.Amount)
+}
+
+func TestListTransfersPage(t *testing.T) {
+	store := db.NewStore(testDB)
+
+	account1 := createFundedAccount(t)
+	account2 := createRandomAccountIn(t, account1.Currency)
+
+	for i := 0; i < 3; i++ {
+		_, err := store.TransferTx(context.Background(), db.TransferTxParams{
+			FromAccountID: account1.ID,
+			ToAccountID:   account2.ID,
+			Amount:        10,
+		})
+		require.NoError(t, err)
+	}
+
+	filter := db.TransferPageFilter{
+		Owner:     account2.Owner,
+		Direction: sql.NullString{String: "incoming", Valid: true},
+		Currency:  sql.NullString{String: account1.Currency, Valid: true},
+		PageLimit: 10,
+	}
+	rows, err := store.ListTransfersPage(context.Background(), filter, db.SortAsc)
+	require.NoError(t, err)
+	require.Len(t, rows, 3)
+	require.Equal(t, account1.Currency, rows[0].Currency)
+	require.Less(t, rows[0].Transfer.ID, rows[1].Transfer.ID)
+
+	filter.Direction = sql.NullString{String: "outgoing", Valid: true}
+	rows, err = store.ListTransfersPage(context.Background(), filter, db.SortAsc)
+	require.NoError(t, err)
+	require.Empty(t, rows)
+}
+
+func createFundedAccount(t *testing.T) db.Account {
+	account := createRandomAccount(t)
+
+	account, err := testQueries.UpdatedAccount(context.Background(), db.UpdatedAccountParams{
+		ID:      account.ID,
+		Balance: 1000,
+	})
+	require.NoError(t, err)
+
+	return account
+}
@@ -0,0 +1,82 @@
+package test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/NhutHuyDev/sgbank/internal/domain"
+	"github.com/NhutHuyDev/sgbank/internal/infra/db"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransferTxOverdraft(t *testing.T) {
+	store := db.NewStore(testDB)
+	ctx := context.Background()
+
+	account1 := createRandomAccount(t)
+	account2 := createRandomAccountIn(t, account1.Currency)
+	admin := createRandomUser(t)
+	limit := int64(500)
+
+	approved, err := store.ApproveOverdraftTx(ctx, db.ApproveOverdraftTxParams{
+		AccountID:      account1.ID,
+		OverdraftLimit: limit,
+		ApprovedBy:     admin.Username,
+		Reason:         "test",
+	})
+	require.NoError(t, err)
+	require.Equal(t, limit, approved.Account.OverdraftLimit)
+	require.Equal(t, admin.Username, approved.Approval.ApprovedBy)
+
+	// beyond the limit
+	_, err = store.TransferTx(ctx, db.TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        account1.Balance + limit + 1,
+	})
+	require.ErrorIs(t, err, domain.ErrInsufficientFunds)
+
+	// down to the limit
+	result, err := store.TransferTx(ctx, db.TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        account1.Balance + limit,
+	})
+	require.NoError(t, err)
+	require.Equal(t, -limit, result.FromAccount.Balance)
+	require.NotNil(t, result.FromAccount.OverdrawnSince)
+
+	report, err := store.ListOverdrawnAccounts(ctx, db.ListOverdrawnAccountsParams{AfterID: account1.ID - 1, PageLimit: 1})
+	require.NoError(t, err)
+	require.Len(t, report, 1)
+	require.Equal(t, account1.ID, report[0].ID)
+
+	// back in credit
+	result, err = store.TransferTx(ctx, db.TransferTxParams{
+		FromAccountID: account2.ID,
+		ToAccountID:   account1.ID,
+		Amount:        limit,
+	})
+	require.NoError(t, err)
+	require.Zero(t, result.ToAccount.Balance)
+	require.Nil(t, result.ToAccount.OverdrawnSince)
+
+	var kinds []string
+	for {
+		events, err := store.ClaimOverdraftEvents(ctx, 100)
+		require.NoError(t, err)
+		for _, event := range events {
+			if event.AccountID == account1.ID {
+				kinds = append(kinds, event.Kind)
+			}
+		}
+		if len(events) < 100 {
+			break
+		}
+	}
+	require.Equal(t, []string{db.OverdraftEntered, db.OverdraftLeft}, kinds)
+
+	approvals, err := store.ListOverdraftApprovals(ctx, account1.ID)
+	require.NoError(t, err)
+	require.Len(t, approvals, 1)
+}
@@ -0,0 +1,84 @@
+package test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/NhutHuyDev/sgbank/internal/domain"
+	"github.com/NhutHuyDev/sgbank/internal/infra/db"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPayPaymentRequestTx(t *testing.T) {
+	store := db.NewStore(testDB)
+	ctx := context.Background()
+
+	payerAccount := createFundedAccount(t)
+	requesterAccount := createRandomAccountIn(t, payerAccount.Currency)
+
+	request, err := testQueries.CreatePaymentRequest(ctx, db.CreatePaymentRequestParams{
+		Requester:   requesterAccount.Owner,
+		Payer:       sql.NullString{String: payerAccount.Owner, Valid: true},
+		ToAccountID: requesterAccount.ID,
+		Amount:      30,
+		Currency:    requesterAccount.Currency,
+		ExpiresAt:   time.Now().Add(time.Hour),
+	})
+	require.NoError(t, err)
+	require.Equal(t, "pending", request.Status)
+
+	arg := db.PayPaymentRequestTxParams{
+		ID:            request.ID,
+		Payer:         payerAccount.Owner,
+		FromAccountID: payerAccount.ID,
+		Status:        "paid",
+		Authorize: func(request db.PaymentRequest) error {
+			if request.Status != "pending" {
+				return domain.ErrInvalidTransition
+			}
+			return nil
+		},
+	}
+
+	result, err := store.PayPaymentRequestTx(ctx, arg)
+	require.NoError(t, err)
+	require.Equal(t, "paid", result.PaymentRequest.Status)
+	require.Equal(t, result.Transfer.ID, result.PaymentRequest.TransferID.Int64)
+	require.Equal(t, payerAccount.Balance-30, result.FromAccount.Balance)
+	require.Equal(t, requesterAccount.Balance+30, result.ToAccount.Balance)
+
+	// paying twice is rejected and does not move money again
+	_, err = store.PayPaymentRequestTx(ctx, arg)
+	require.ErrorIs(t, err, domain.ErrInvalidTransition)
+
+	account, err := testQueries.GetAccount(ctx, payerAccount.ID)
+	require.NoError(t, err)
+	require.Equal(t, payerAccount.Balance-30, account.Balance)
+
+	_, err = store.PayPaymentRequestTx(ctx, db.PayPaymentRequestTxParams{ID: -1, FromAccountID: payerAccount.ID})
+	require.ErrorIs(t, err, domain.ErrPaymentRequestNotFound)
+}
+
+func TestNotifications(t *testing.T) {
+	user := createRandomUser(t)
+	ctx := context.Background()
+
+	for _, kind := range []string{"first", "second"} {
+		_, err := testQueries.CreateNotification(ctx, db.CreateNotificationParams{
+			Username: user.Username,
+			Kind:     kind,
+			Message:  kind,
+		})
+		require.NoError(t, err)
+	}
+
+	notifications, err := testQueries.ListNotificationsBefore(ctx, db.ListNotificationsBeforeParams{
+		Username:  user.Username,
+		PageLimit: 10,
+	})
+	require.NoError(t, err)
+	require.Len(t, notifications, 2)
+	require.Equal(t, "second", notifications[0].Kind)
+}
@@ -0,0 +1,72 @@
+package test
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/NhutHuyDev/sgbank/internal/infra/db"
+	"github.com/NhutHuyDev/sgbank/pkg/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetAccountByOwnerCurrency(t *testing.T) {
+	account := createRandomAccount(t)
+
+	found, err := testQueries.GetAccountByOwnerCurrency(context.Background(), db.GetAccountByOwnerCurrencyParams{
+		Owner:    account.Owner,
+		Currency: account.Currency,
+	})
+	require.NoError(t, err)
+	require.Equal(t, account.ID, found.ID)
+}
+
+func TestGetUserByVerifiedEmail(t *testing.T) {
+	user := createRandomUser(t)
+	ctx := context.Background()
+
+	_, err := testQueries.GetUserByVerifiedEmail(ctx, user.Email)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	_, err = testDB.ExecContext(ctx, "UPDATE users SET is_email_verified = true WHERE username = $1", user.Username)
+	require.NoError(t, err)
+
+	found, err := testQueries.GetUserByVerifiedEmail(ctx, strings.ToUpper(user.Email))
+	require.NoError(t, err)
+	require.Equal(t, user.Username, found.Username)
+
+	// changing the email drops the verification
+	updated, err := testQueries.UpdateUser(ctx, db.UpdateUserParams{
+		Username: user.Username,
+		Email:    sql.NullString{String: utils.RandomEmail(), Valid: true},
+	})
+	require.NoError(t, err)
+	require.False(t, updated.IsEmailVerified)
+}
+
+func TestRecipientLookups(t *testing.T) {
+	user := createRandomUser(t)
+	ctx := context.Background()
+	since := time.Now().Add(-time.Minute)
+
+	for i := 0; i < 2; i++ {
+		lookup, err := testQueries.CreateRecipientLookup(ctx, db.CreateRecipientLookupParams{
+			Username:   user.Username,
+			Identifier: utils.RandomOwner(),
+			Purpose:    "lookup",
+			Found:      i == 0,
+			ClientIp:   "127.0.0.1",
+		})
+		require.NoError(t, err)
+		require.NotZero(t, lookup.ID)
+	}
+
+	count, err := testQueries.CountRecipientLookupsSince(ctx, db.CountRecipientLookupsSinceParams{
+		Username: user.Username,
+		Since:    since,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(2), count)
+}
@@ -0,0 +1,71 @@
+package test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/NhutHuyDev/sgbank/internal/infra/db"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsRetryableError(t *testing.T) {
+	testCases := []struct {
+		name      string
+		err       error
+		retryable bool
+	}{
+		{
+			name:      "SerializationFailure",
+			err:       &pq.Error{Code: "40001"},
+			retryable: true,
+		},
+		{
+			name:      "DeadlockDetected",
+			err:       &pq.Error{Code: "40P01"},
+			retryable: true,
+		},
+		{
+			name:      "WrappedDeadlock",
+			err:       fmt.Errorf("error: %w, rollback error: %v", &pq.Error{Code: "40P01"}, errors.New("conn closed")),
+			retryable: true,
+		},
+		{
+			name:      "UniqueViolation",
+			err:       &pq.Error{Code: "23505"},
+			retryable: false,
+		},
+		{
+			name:      "PlainError",
+			err:       errors.New("the balance of the from account is insufficient"),
+			retryable: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.retryable, db.IsRetryableError(tc.err))
+		})
+	}
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	policy := db.RetryPolicy{
+		MaxAttempts: 5,
+		BaseDelay:   10 * time.Millisecond,
+		MaxDelay:    50 * time.Millisecond,
+	}
+
+	for attempt := 1; attempt <= 10; attempt++ {
+		for i := 0; i < 100; i++ {
+			delay := policy.Backoff(attempt)
+			require.Greater(t, delay, time.Duration(0))
+			require.LessOrEqual(t, delay, policy.MaxDelay)
+			if attempt == 1 {
+				require.LessOrEqual(t, delay, policy.BaseDelay)
+			}
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/NhutHuyDev/sgbank/internal/domain"
+	"github.com/NhutHuyDev/sgbank/internal/infra/db"
+	"github.com/NhutHuyDev/sgbank/pkg/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScreeningWhitelist(t *testing.T) {
+	store := db.NewStore(testDB)
+	ctx := context.Background()
+
+	admin := createRandomUser(t)
+	name := utils.RandomOwner() + " " + utils.RandomOwner()
+
+	entry, err := store.CreateScreeningWhitelistEntry(ctx, db.CreateScreeningWhitelistEntryParams{
+		Name:      name,
+		EntryID:   "2674",
+		Reason:    "different date of birth",
+		CreatedBy: admin.Username,
+	})
+	require.NoError(t, err)
+
+	_, err = store.CreateScreeningWhitelistEntry(ctx, db.CreateScreeningWhitelistEntryParams{
+		Name:      name,
+		EntryID:   "2674",
+		CreatedBy: admin.Username,
+	})
+	require.True(t, errors.Is(err, domain.ErrDuplicate))
+
+	ids, err := store.ListWhitelistedEntryIDs(ctx, name)
+	require.NoError(t, err)
+	require.Equal(t, []string{"2674"}, ids)
+
+	_, err = store.DeleteScreeningWhitelistEntry(ctx, entry.ID)
+	require.NoError(t, err)
+
+	_, err = store.DeleteScreeningWhitelistEntry(ctx, entry.ID)
+	require.True(t, errors.Is(err, domain.ErrNotFound))
+}
+
+func TestListScreeningMatches(t *testing.T) {
+	store := db.NewStore(testDB)
+	ctx := context.Background()
+
+	account := createRandomAccount(t)
+
+	flagged, err := store.CreateScreeningMatch(ctx, db.CreateScreeningMatchParams{
+		Subject:   "counterparty",
+		Name:      "Jose Garcia",
+		Username:  account.Owner,
+		AccountID: sql.NullInt64{Int64: account.ID, Valid: true},
+		List:      "sdn",
+		EntryID:   "9647",
+		EntryName: "MUNOZ GARCIA, Jose",
+		Score:     0.93,
+		Action:    "flag",
+	})
+	require.NoError(t, err)
+
+	blocked, err := store.CreateScreeningMatch(ctx, db.CreateScreeningMatchParams{
+		Subject:   "signup",
+		Name:      "Osama bin Laden",
+		Username:  utils.RandomOwner(),
+		List:      "sdn",
+		EntryID:   "2674",
+		EntryName: "BIN LADIN, Usama",
+		Score:     1,
+		Action:    "block",
+	})
+	require.NoError(t, err)
+
+	matches, err := store.ListScreeningMatches(ctx, db.ListScreeningMatchesParams{
+		AfterID:   flagged.ID - 1,
+		Action:    sql.NullString{String: "block", Valid: true},
+		PageLimit: 100,
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, matches)
+	for _, match := range matches {
+		require.Equal(t, "block", match.Action)
+	}
+	require.Equal(t, blocked.ID, matches[0].ID)
+}
@@ -6,7 +6,9 @@ import (
 	"sync"
 	"testing"
 
+	"github.com/NhutHuyDev/sgbank/internal/domain"
 	"github.com/NhutHuyDev/sgbank/internal/infra/db"
+	"github.com/NhutHuyDev/sgbank/pkg/utils"
 	"github.com/stretchr/testify/require"
 )
 
@@ -14,7 +16,7 @@ func TestTransferTx(t *testing.T) {
 	store := db.NewStore(testDB)
 
 	account1 := createRandomAccount(t)
-	account2 := createRandomAccount(t)
+	account2 := createRandomAccountIn(t, account1.Currency)
 
 	n := 5
 	amount := int64(10)
@@ -115,7 +117,7 @@ func TestTransferTxDeadlock(t *testing.T) {
 	store := db.NewStore(testDB)
 
 	account1 := createRandomAccount(t)
-	account2 := createRandomAccount(t)
+	account2 := createRandomAccountIn(t, account1.Currency)
 
 	n := 20
 	amount := int64(10)
@@ -162,7 +164,7 @@ func TestTransferTxCheckBalance(t *testing.T) {
 	store := db.NewStore(testDB)
 
 	account1 := createRandomAccount(t)
-	account2 := createRandomAccount(t)
+	account2 := createRandomAccountIn(t, account1.Currency)
 
 	fmt.Printf("account1's balance: %v \n", account1.Balance)
 	fmt.Printf("account2's balance: %v \n", account2.Balance)
@@ -202,6 +204,7 @@ func TestTransferTxCheckBalance(t *testing.T) {
 	var successCount, failCount int
 	for err := range errs {
 		if err != nil {
+			require.ErrorIs(t, err, domain.ErrInsufficientFunds)
 			failCount++
 		} else {
 			successCount++
@@ -224,3 +227,122 @@ func TestTransferTxCheckBalance(t *testing.T) {
 	require.Equal(t, account1.Balance+amount*int64(successCount), updatedAccount1.Balance)
 	require.Equal(t, account2.Balance-amount*int64(successCount), updatedAccount2.Balance)
 }
+
+// Hammer a small set of accounts with transfers in both directions. Lock
+// ordering prevents most deadlocks and execTx retries the rest, so none
+// may surface to the caller and no money may be created or lost.
+func TestTransferTxOppositeDirectionsHammer(t *testing.T) {
+	store := db.NewStore(testDB)
+
+	initialBalance := int64(1_000_000)
+	accounts := make([]db.Account, 4)
+	currency := utils.RandomCurrency()
+	for i := range accounts {
+		account := createRandomAccountIn(t, currency)
+
+		account, err := testQueries.UpdatedAccount(context.Background(), db.UpdatedAccountParams{
+			ID:      account.ID,
+			Balance: initialBalance,
+		})
+		require.NoError(t, err)
+
+		accounts[i] = account
+	}
+
+	n := 200
+	var wg sync.WaitGroup
+	errs := make(chan error, n)
+
+	for i := 0; i < n; i++ {
+		from := accounts[i%len(accounts)]
+		to := accounts[(i+1+i/len(accounts))%len(accounts)]
+		if from.ID == to.ID {
+			to = accounts[(i+2)%len(accounts)]
+		}
+
+		if i%2 == 1 {
+			from, to = to, from
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := store.TransferTx(context.Background(), db.TransferTxParams{
+				FromAccountID: from.ID,
+				ToAccountID:   to.ID,
+				Amount:        int64(utils.RandomInt(1, 100)),
+			})
+
+			errs <- err
+		}()
+	}
+
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		require.NoError(t, err)
+	}
+
+	total := int64(0)
+	for _, account := range accounts {
+		updated, err := testQueries.GetAccount(context.Background(), account.ID)
+		require.NoError(t, err)
+		total += updated.Balance
+	}
+
+	require.Equal(t, initialBalance*int64(len(accounts)), total)
+}
+
+func TestTransferTxCurrencyMismatch(t *testing.T) {
+	store := db.NewStore(testDB)
+
+	from := createRandomAccountIn(t, utils.USD)
+	to := createRandomAccountIn(t, utils.EUR)
+
+	_, err := store.TransferTx(context.Background(), db.TransferTxParams{
+		FromAccountID: from.ID,
+		ToAccountID:   to.ID,
+		Amount:        10,
+	})
+	require.ErrorIs(t, err, domain.ErrCurrencyMismatch)
+
+	revenue := createProductAccount(t, createRandomUser(t).Username, utils.EUR, "fee_revenue")
+	to = createRandomAccountIn(t, utils.USD)
+
+	_, err = store.TransferTx(context.Background(), db.TransferTxParams{
+		FromAccountID: from.ID,
+		ToAccountID:   to.ID,
+		Amount:        10,
+		Fee:           1,
+		FeeAccountID:  revenue.ID,
+	})
+	require.ErrorIs(t, err, domain.ErrCurrencyMismatch)
+
+	updated, err := testQueries.GetAccount(context.Background(), from.ID)
+	require.NoError(t, err)
+	require.Equal(t, from.Balance, updated.Balance)
+}
+
+func TestStoreDomainErrors(t *testing.T) {
+	store := db.NewStore(testDB)
+
+	_, err := store.GetAccount(context.Background(), -1)
+	require.ErrorIs(t, err, domain.ErrAccountNotFound)
+
+	_, err = store.TransferTx(context.Background(), db.TransferTxParams{
+		FromAccountID: -1,
+		ToAccountID:   -2,
+		Amount:        10,
+	})
+	require.ErrorIs(t, err, domain.ErrAccountNotFound)
+
+	account := createRandomAccount(t)
+	_, err = store.CreateAccount(context.Background(), db.CreateAccountParams{
+		Owner:         account.Owner,
+		Currency:      account.Currency,
+		AccountNumber: randomAccountNumber(t),
+		Product:       account.Product,
+	})
+	require.ErrorIs(t, err, domain.ErrDuplicate)
+}
@@ -0,0 +1,130 @@
+package test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/NhutHuyDev/sgbank/internal/domain"
+	"github.com/NhutHuyDev/sgbank/internal/infra/db"
+	"github.com/stretchr/testify/require"
+)
+
+func createTransferBatch(t *testing.T, from db.Account, mode string, amounts ...int64) db.CreateTransferBatchTxResult {
+	arg := db.CreateTransferBatchTxParams{
+		CreateTransferBatchParams: db.CreateTransferBatchParams{
+			Owner:         from.Owner,
+			FromAccountID: from.ID,
+			Currency:      from.Currency,
+			Mode:          mode,
+			TotalRows:     int32(len(amounts)),
+		},
+	}
+
+	for i, amount := range amounts {
+		to := createRandomAccountIn(t, from.Currency)
+		arg.TotalAmount += amount
+		arg.Items = append(arg.Items, db.CreateTransferBatchItemParams{
+			RowNumber:   int32(i + 1),
+			ToAccountID: to.ID,
+			Amount:      amount,
+		})
+	}
+
+	result, err := db.NewStore(testDB).CreateTransferBatchTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, "pending", result.Batch.Status)
+	require.Len(t, result.Items, len(amounts))
+
+	return result
+}
+
+func TestPostTransferBatchTx(t *testing.T) {
+	store := db.NewStore(testDB)
+	ctx := context.Background()
+
+	from := createFundedAccount(t)
+	created := createTransferBatch(t, from, "all_or_nothing", 400, 700)
+
+	// the second row overdraws the account, so the first is rolled back too
+	result, err := store.PostTransferBatchTx(ctx, db.PostTransferBatchTxParams{FromAccountID: from.ID, Items: created.Items})
+	require.ErrorIs(t, err, domain.ErrInsufficientFunds)
+	require.EqualValues(t, 2, result.FailedRow)
+
+	account, err := testQueries.GetAccount(ctx, from.ID)
+	require.NoError(t, err)
+	require.Equal(t, from.Balance, account.Balance)
+
+	result, err = store.PostTransferBatchTx(ctx, db.PostTransferBatchTxParams{FromAccountID: from.ID, Items: created.Items[:1]})
+	require.NoError(t, err)
+	require.Len(t, result.Items, 1)
+	require.Equal(t, "succeeded", result.Items[0].Status)
+	require.True(t, result.Items[0].TransferID.Valid)
+	require.Len(t, result.Transfers, 1)
+	require.Equal(t, result.Items[0].TransferID.Int64, result.Transfers[0].Transfer.ID)
+
+	account, err = testQueries.GetAccount(ctx, from.ID)
+	require.NoError(t, err)
+	require.Equal(t, from.Balance-400, account.Balance)
+}
+
+func TestPostTransferBatchItemTx(t *testing.T) {
+	store := db.NewStore(testDB)
+	ctx := context.Background()
+
+	from := createFundedAccount(t)
+	created := createTransferBatch(t, from, "best_effort", 100)
+	arg := db.PostTransferBatchItemTxParams{FromAccountID: from.ID, Item: created.Items[0]}
+
+	result, err := store.PostTransferBatchItemTx(ctx, arg)
+	require.NoError(t, err)
+	require.Equal(t, "succeeded", result.Item.Status)
+	require.Equal(t, result.Transfer.ID, result.Item.TransferID.Int64)
+	require.Equal(t, from.Balance-100, result.FromAccount.Balance)
+
+	// a row already posted is never paid again
+	_, err = store.PostTransferBatchItemTx(ctx, arg)
+	require.ErrorIs(t, err, domain.ErrInvalidTransition)
+
+	account, err := testQueries.GetAccount(ctx, from.ID)
+	require.NoError(t, err)
+	require.Equal(t, from.Balance-100, account.Balance)
+
+	batch, err := testQueries.UpdateTransferBatchProgress(ctx, created.Batch.ID)
+	require.NoError(t, err)
+	require.EqualValues(t, 1, batch.ProcessedRows)
+	require.EqualValues(t, 1, batch.SucceededRows)
+	require.Zero(t, batch.FailedRows)
+}
+
+func TestClaimTransferBatch(t *testing.T) {
+	ctx := context.Background()
+	created := createTransferBatch(t, createFundedAccount(t), "best_effort", 10)
+
+	// claim every queued batch, including those left over by other tests
+	var claimed []int64
+	for {
+		batch, err := testQueries.ClaimTransferBatch(ctx, time.Now().Add(-time.Hour))
+		if err != nil {
+			break
+		}
+		require.Equal(t, "processing", batch.Status)
+		claimed = append(claimed, batch.ID)
+	}
+	require.Contains(t, claimed, created.Batch.ID)
+
+	// a batch that is still making progress is not taken over...
+	_, err := testQueries.ClaimTransferBatch(ctx, time.Now().Add(-time.Hour))
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	// ...but one whose worker went quiet is
+	batch, err := testQueries.ClaimTransferBatch(ctx, time.Now().Add(time.Minute))
+	require.NoError(t, err)
+	require.Equal(t, "processing", batch.Status)
+
+	batch, err = testQueries.FinishTransferBatch(ctx, db.FinishTransferBatchParams{ID: created.Batch.ID, Status: "completed"})
+	require.NoError(t, err)
+	require.Equal(t, "completed", batch.Status)
+	require.True(t, batch.CompletedAt.Valid)
+}
@@ -7,22 +7,42 @@ package db
 
 import (
 	"context"
+	"database/sql"
+	"time"
 )
 
 const createTransfer = `-- name: CreateTransfer :one
-INSERT INTO transfers (from_account_id, to_account_id, amount) 
-VALUES ($1, $2, $3)
-RETURNING id, from_account_id, to_account_id, amount, created_at
+INSERT INTO transfers (from_account_id, to_account_id, amount, fee, description, external_reference, merchant_category, kind, business_date)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8,
+    COALESCE($9::date, (SELECT d.business_date FROM business_days d WHERE d.status = 'open')))
+RETURNING id, from_account_id, to_account_id, amount, created_at, description, external_reference, merchant_category, fee, business_date, kind
 `
 
 type CreateTransferParams struct {
-	FromAccountID int64 `json:"from_account_id"`
-	ToAccountID   int64 `json:"to_account_id"`
-	Amount        int64 `json:"amount"`
+	FromAccountID     int64        `json:"from_account_id"`
+	ToAccountID       int64        `json:"to_account_id"`
+	Amount            int64        `json:"amount"`
+	Fee               int64        `json:"fee"`
+	Description       string       `json:"description"`
+	ExternalReference string       `json:"external_reference"`
+	MerchantCategory  string       `json:"merchant_category"`
+	Kind              string       `json:"kind"`
+	BusinessDate      sql.NullTime `json:"business_date"`
 }
 
+// Posts to business_date, or to the open business day when it is null.
 func (q *Queries) CreateTransfer(ctx context.Context, arg CreateTransferParams) (Transfer, error) {
-	row := q.db.QueryRowContext(ctx, createTransfer, arg.FromAccountID, arg.ToAccountID, arg.Amount)
+	row := q.db.QueryRowContext(ctx, createTransfer,
+		arg.FromAccountID,
+		arg.ToAccountID,
+		arg.Amount,
+		arg.Fee,
+		arg.Description,
+		arg.ExternalReference,
+		arg.MerchantCategory,
+		arg.Kind,
+		arg.BusinessDate,
+	)
 	var i Transfer
 	err := row.Scan(
 		&i.ID,
@@ -30,12 +50,18 @@ func (q *Queries) CreateTransfer(ctx context.Context, arg CreateTransferParams)
 		&i.ToAccountID,
 		&i.Amount,
 		&i.CreatedAt,
+		&i.Description,
+		&i.ExternalReference,
+		&i.MerchantCategory,
+		&i.Fee,
+		&i.BusinessDate,
+		&i.Kind,
 	)
 	return i, err
 }
 
 const getTransfer = `-- name: GetTransfer :one
-SELECT id, from_account_id, to_account_id, amount, created_at FROM transfers WHERE id = $1 LIMIT 1
+SELECT id, from_account_id, to_account_id, amount, created_at, description, external_reference, merchant_category, fee, business_date, kind FROM transfers WHERE id = $1 LIMIT 1
 `
 
 func (q *Queries) GetTransfer(ctx context.Context, id int64) (Transfer, error) {
@@ -47,12 +73,18 @@ func (q *Queries) GetTransfer(ctx context.Context, id int64) (Transfer, error) {
 		&i.ToAccountID,
 		&i.Amount,
 		&i.CreatedAt,
+		&i.Description,
+		&i.ExternalReference,
+		&i.MerchantCategory,
+		&i.Fee,
+		&i.BusinessDate,
+		&i.Kind,
 	)
 	return i, err
 }
 
 const listTransfers = `-- name: ListTransfers :many
-SELECT id, from_account_id, to_account_id, amount, created_at FROM transfers
+SELECT id, from_account_id, to_account_id, amount, created_at, description, external_reference, merchant_category, fee, business_date, kind FROM transfers
 WHERE from_account_id = $1 OR to_account_id = $2
 ORDER BY id
 LIMIT $3 OFFSET $4
@@ -85,6 +117,12 @@ func (q *Queries) ListTransfers(ctx context.Context, arg ListTransfersParams) ([
 			&i.ToAccountID,
 			&i.Amount,
 			&i.CreatedAt,
+			&i.Description,
+			&i.ExternalReference,
+			&i.MerchantCategory,
+			&i.Fee,
+			&i.BusinessDate,
+			&i.Kind,
 		); err != nil {
 			return nil, err
 		}
@@ -98,3 +136,227 @@ func (q *Queries) ListTransfers(ctx context.Context, arg ListTransfersParams) ([
 	}
 	return items, nil
 }
+
+const listTransfersAsc = `-- name: ListTransfersAsc :many
+SELECT t.id, t.from_account_id, t.to_account_id, t.amount, t.created_at, t.description, t.external_reference, t.merchant_category, t.fee, t.business_date, t.kind, fa.currency
+FROM transfers t
+JOIN accounts fa ON fa.id = t.from_account_id
+JOIN accounts ta ON ta.id = t.to_account_id
+WHERE (fa.owner = $1 OR ta.owner = $1)
+    AND ($2::bigint IS NULL
+        OR t.from_account_id = $2::bigint
+        OR t.to_account_id = $2::bigint)
+    AND ($3::timestamptz IS NULL OR t.created_at >= $3::timestamptz)
+    AND ($4::timestamptz IS NULL OR t.created_at < $4::timestamptz)
+    AND ($5::bigint IS NULL OR t.amount >= $5::bigint)
+    AND ($6::bigint IS NULL OR t.amount <= $6::bigint)
+    AND ($7::text IS NULL
+        OR ($7::text = 'outgoing' AND fa.owner = $1
+            AND ($2::bigint IS NULL OR t.from_account_id = $2::bigint))
+        OR ($7::text = 'incoming' AND ta.owner = $1
+            AND ($2::bigint IS NULL OR t.to_account_id = $2::bigint)))
+    AND ($8::bigint IS NULL
+        OR (fa.owner = $1 AND t.to_account_id = $8::bigint)
+        OR (ta.owner = $1 AND t.from_account_id = $8::bigint))
+    AND ($9::varchar IS NULL OR fa.currency = $9::varchar)
+    AND ($10::text IS NULL
+        OR to_tsvector('simple', t.description || ' ' || t.external_reference) @@ websearch_to_tsquery('simple', $10::text))
+    AND ($11::timestamptz IS NULL
+        OR (t.created_at, t.id) > ($11::timestamptz, $12::bigint))
+ORDER BY t.created_at ASC, t.id ASC
+LIMIT $13
+`
+
+type ListTransfersAscParams struct {
+	Owner                 string         `json:"owner"`
+	AccountID             sql.NullInt64  `json:"account_id"`
+	CreatedFrom           sql.NullTime   `json:"created_from"`
+	CreatedTo             sql.NullTime   `json:"created_to"`
+	MinAmount             sql.NullInt64  `json:"min_amount"`
+	MaxAmount             sql.NullInt64  `json:"max_amount"`
+	Direction             sql.NullString `json:"direction"`
+	CounterpartyAccountID sql.NullInt64  `json:"counterparty_account_id"`
+	Currency              sql.NullString `json:"currency"`
+	Query                 sql.NullString `json:"query"`
+	AfterCreatedAt        sql.NullTime   `json:"after_created_at"`
+	AfterID               sql.NullInt64  `json:"after_id"`
+	PageLimit             int32          `json:"page_limit"`
+}
+
+type ListTransfersAscRow struct {
+	Transfer Transfer `json:"transfer"`
+	Currency string   `json:"currency"`
+}
+
+func (q *Queries) ListTransfersAsc(ctx context.Context, arg ListTransfersAscParams) ([]ListTransfersAscRow, error) {
+	rows, err := q.db.QueryContext(ctx, listTransfersAsc,
+		arg.Owner,
+		arg.AccountID,
+		arg.CreatedFrom,
+		arg.CreatedTo,
+		arg.MinAmount,
+		arg.MaxAmount,
+		arg.Direction,
+		arg.CounterpartyAccountID,
+		arg.Currency,
+		arg.Query,
+		arg.AfterCreatedAt,
+		arg.AfterID,
+		arg.PageLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListTransfersAscRow{}
+	for rows.Next() {
+		var i ListTransfersAscRow
+		if err := rows.Scan(
+			&i.Transfer.ID,
+			&i.Transfer.FromAccountID,
+			&i.Transfer.ToAccountID,
+			&i.Transfer.Amount,
+			&i.Transfer.CreatedAt,
+			&i.Transfer.Description,
+			&i.Transfer.ExternalReference,
+			&i.Transfer.MerchantCategory,
+			&i.Transfer.Fee,
+			&i.Transfer.BusinessDate,
+			&i.Transfer.Kind,
+			&i.Currency,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listTransfersDesc = `-- name: ListTransfersDesc :many
+SELECT t.id, t.from_account_id, t.to_account_id, t.amount, t.created_at, t.description, t.external_reference, t.merchant_category, t.fee, t.business_date, t.kind, fa.currency
+FROM transfers t
+JOIN accounts fa ON fa.id = t.from_account_id
+JOIN accounts ta ON ta.id = t.to_account_id
+WHERE (fa.owner = $1 OR ta.owner = $1)
+    AND ($2::bigint IS NULL
+        OR t.from_account_id = $2::bigint
+        OR t.to_account_id = $2::bigint)
+    AND ($3::timestamptz IS NULL OR t.created_at >= $3::timestamptz)
+    AND ($4::timestamptz IS NULL OR t.created_at < $4::timestamptz)
+    AND ($5::bigint IS NULL OR t.amount >= $5::bigint)
+    AND ($6::bigint IS NULL OR t.amount <= $6::bigint)
+    AND ($7::text IS NULL
+        OR ($7::text = 'outgoing' AND fa.owner = $1
+            AND ($2::bigint IS NULL OR t.from_account_id = $2::bigint))
+        OR ($7::text = 'incoming' AND ta.owner = $1
+            AND ($2::bigint IS NULL OR t.to_account_id = $2::bigint)))
+    AND ($8::bigint IS NULL
+        OR (fa.owner = $1 AND t.to_account_id = $8::bigint)
+        OR (ta.owner = $1 AND t.from_account_id = $8::bigint))
+    AND ($9::varchar IS NULL OR fa.currency = $9::varchar)
+    AND ($10::text IS NULL
+        OR to_tsvector('simple', t.description || ' ' || t.external_reference) @@ websearch_to_tsquery('simple', $10::text))
+    AND ($11::timestamptz IS NULL
+        OR (t.created_at, t.id) < ($11::timestamptz, $12::bigint))
+ORDER BY t.created_at DESC, t.id DESC
+LIMIT $13
+`
+
+type ListTransfersDescParams struct {
+	Owner                 string         `json:"owner"`
+	AccountID             sql.NullInt64  `json:"account_id"`
+	CreatedFrom           sql.NullTime   `json:"created_from"`
+	CreatedTo             sql.NullTime   `json:"created_to"`
+	MinAmount             sql.NullInt64  `json:"min_amount"`
+	MaxAmount             sql.NullInt64  `json:"max_amount"`
+	Direction             sql.NullString `json:"direction"`
+	CounterpartyAccountID sql.NullInt64  `json:"counterparty_account_id"`
+	Currency              sql.NullString `json:"currency"`
+	Query                 sql.NullString `json:"query"`
+	AfterCreatedAt        sql.NullTime   `json:"after_created_at"`
+	AfterID               sql.NullInt64  `json:"after_id"`
+	PageLimit             int32          `json:"page_limit"`
+}
+
+type ListTransfersDescRow struct {
+	Transfer Transfer `json:"transfer"`
+	Currency string   `json:"currency"`
+}
+
+func (q *Queries) ListTransfersDesc(ctx context.Context, arg ListTransfersDescParams) ([]ListTransfersDescRow, error) {
+	rows, err := q.db.QueryContext(ctx, listTransfersDesc,
+		arg.Owner,
+		arg.AccountID,
+		arg.CreatedFrom,
+		arg.CreatedTo,
+		arg.MinAmount,
+		arg.MaxAmount,
+		arg.Direction,
+		arg.CounterpartyAccountID,
+		arg.Currency,
+		arg.Query,
+		arg.AfterCreatedAt,
+		arg.AfterID,
+		arg.PageLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListTransfersDescRow{}
+	for rows.Next() {
+		var i ListTransfersDescRow
+		if err := rows.Scan(
+			&i.Transfer.ID,
+			&i.Transfer.FromAccountID,
+			&i.Transfer.ToAccountID,
+			&i.Transfer.Amount,
+			&i.Transfer.CreatedAt,
+			&i.Transfer.Description,
+			&i.Transfer.ExternalReference,
+			&i.Transfer.MerchantCategory,
+			&i.Transfer.Fee,
+			&i.Transfer.BusinessDate,
+			&i.Transfer.Kind,
+			&i.Currency,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const sumTransfersToAccountSince = `-- name: SumTransfersToAccountSince :one
+SELECT COALESCE(sum(t.amount), 0)::bigint AS total
+FROM transfers t
+JOIN accounts fa ON fa.id = t.from_account_id
+WHERE fa.owner = $1
+    AND t.to_account_id = $2
+    AND t.created_at >= $3
+`
+
+type SumTransfersToAccountSinceParams struct {
+	Owner       string    `json:"owner"`
+	ToAccountID int64     `json:"to_account_id"`
+	Since       time.Time `json:"since"`
+}
+
+func (q *Queries) SumTransfersToAccountSince(ctx context.Context, arg SumTransfersToAccountSinceParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, sumTransfersToAccountSince, arg.Owner, arg.ToAccountID, arg.Since)
+	var total int64
+	err := row.Scan(&total)
+	return total, err
+}
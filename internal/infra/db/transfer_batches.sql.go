@@ -0,0 +1,463 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: transfer_batches.sql
+
+package db
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+const claimTransferBatch = `-- name: ClaimTransferBatch :one
+UPDATE transfer_batches
+SET status = 'processing',
+    updated_at = now()
+WHERE id = (
+    SELECT b.id FROM transfer_batches b
+    WHERE b.status = 'pending'
+        OR (b.status = 'processing' AND b.updated_at < $1)
+    ORDER BY b.id
+    LIMIT 1
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING id, owner, from_account_id, currency, mode, status, total_rows, total_amount, processed_rows, succeeded_rows, failed_rows, error, completed_at, updated_at, created_at, client_ip, held_rows
+`
+
+// Claims the oldest pending batch, or a processing batch whose worker has not
+// reported progress since stale_before, for the calling worker.
+func (q *Queries) ClaimTransferBatch(ctx context.Context, staleBefore time.Time) (TransferBatch, error) {
+	row := q.db.QueryRowContext(ctx, claimTransferBatch, staleBefore)
+	var i TransferBatch
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.FromAccountID,
+		&i.Currency,
+		&i.Mode,
+		&i.Status,
+		&i.TotalRows,
+		&i.TotalAmount,
+		&i.ProcessedRows,
+		&i.SucceededRows,
+		&i.FailedRows,
+		&i.Error,
+		&i.CompletedAt,
+		&i.UpdatedAt,
+		&i.CreatedAt,
+		&i.ClientIp,
+		&i.HeldRows,
+	)
+	return i, err
+}
+
+const createTransferBatch = `-- name: CreateTransferBatch :one
+INSERT INTO transfer_batches (
+    owner,
+    from_account_id,
+    currency,
+    mode,
+    total_rows,
+    total_amount,
+    client_ip
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7
+) RETURNING id, owner, from_account_id, currency, mode, status, total_rows, total_amount, processed_rows, succeeded_rows, failed_rows, error, completed_at, updated_at, created_at, client_ip, held_rows
+`
+
+type CreateTransferBatchParams struct {
+	Owner         string `json:"owner"`
+	FromAccountID int64  `json:"from_account_id"`
+	Currency      string `json:"currency"`
+	Mode          string `json:"mode"`
+	TotalRows     int32  `json:"total_rows"`
+	TotalAmount   int64  `json:"total_amount"`
+	ClientIp      string `json:"client_ip"`
+}
+
+func (q *Queries) CreateTransferBatch(ctx context.Context, arg CreateTransferBatchParams) (TransferBatch, error) {
+	row := q.db.QueryRowContext(ctx, createTransferBatch,
+		arg.Owner,
+		arg.FromAccountID,
+		arg.Currency,
+		arg.Mode,
+		arg.TotalRows,
+		arg.TotalAmount,
+		arg.ClientIp,
+	)
+	var i TransferBatch
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.FromAccountID,
+		&i.Currency,
+		&i.Mode,
+		&i.Status,
+		&i.TotalRows,
+		&i.TotalAmount,
+		&i.ProcessedRows,
+		&i.SucceededRows,
+		&i.FailedRows,
+		&i.Error,
+		&i.CompletedAt,
+		&i.UpdatedAt,
+		&i.CreatedAt,
+		&i.ClientIp,
+		&i.HeldRows,
+	)
+	return i, err
+}
+
+const createTransferBatchItem = `-- name: CreateTransferBatchItem :one
+INSERT INTO transfer_batch_items (
+    batch_id,
+    row_number,
+    to_account_id,
+    amount,
+    reference
+) VALUES (
+    $1, $2, $3, $4, $5
+) RETURNING id, batch_id, row_number, to_account_id, amount, reference, status, error, transfer_id, updated_at
+`
+
+type CreateTransferBatchItemParams struct {
+	BatchID     int64  `json:"batch_id"`
+	RowNumber   int32  `json:"row_number"`
+	ToAccountID int64  `json:"to_account_id"`
+	Amount      int64  `json:"amount"`
+	Reference   string `json:"reference"`
+}
+
+func (q *Queries) CreateTransferBatchItem(ctx context.Context, arg CreateTransferBatchItemParams) (TransferBatchItem, error) {
+	row := q.db.QueryRowContext(ctx, createTransferBatchItem,
+		arg.BatchID,
+		arg.RowNumber,
+		arg.ToAccountID,
+		arg.Amount,
+		arg.Reference,
+	)
+	var i TransferBatchItem
+	err := row.Scan(
+		&i.ID,
+		&i.BatchID,
+		&i.RowNumber,
+		&i.ToAccountID,
+		&i.Amount,
+		&i.Reference,
+		&i.Status,
+		&i.Error,
+		&i.TransferID,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const failPendingTransferBatchItems = `-- name: FailPendingTransferBatchItems :exec
+UPDATE transfer_batch_items
+SET status = 'failed',
+    error = $1,
+    updated_at = now()
+WHERE batch_id = $2 AND status = 'pending'
+`
+
+type FailPendingTransferBatchItemsParams struct {
+	Error   string `json:"error"`
+	BatchID int64  `json:"batch_id"`
+}
+
+func (q *Queries) FailPendingTransferBatchItems(ctx context.Context, arg FailPendingTransferBatchItemsParams) error {
+	_, err := q.db.ExecContext(ctx, failPendingTransferBatchItems, arg.Error, arg.BatchID)
+	return err
+}
+
+const finishTransferBatch = `-- name: FinishTransferBatch :one
+UPDATE transfer_batches
+SET status = $1,
+    error = $2,
+    completed_at = now(),
+    updated_at = now()
+WHERE id = $3 AND status = 'processing'
+RETURNING id, owner, from_account_id, currency, mode, status, total_rows, total_amount, processed_rows, succeeded_rows, failed_rows, error, completed_at, updated_at, created_at, client_ip, held_rows
+`
+
+type FinishTransferBatchParams struct {
+	Status string `json:"status"`
+	Error  string `json:"error"`
+	ID     int64  `json:"id"`
+}
+
+func (q *Queries) FinishTransferBatch(ctx context.Context, arg FinishTransferBatchParams) (TransferBatch, error) {
+	row := q.db.QueryRowContext(ctx, finishTransferBatch, arg.Status, arg.Error, arg.ID)
+	var i TransferBatch
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.FromAccountID,
+		&i.Currency,
+		&i.Mode,
+		&i.Status,
+		&i.TotalRows,
+		&i.TotalAmount,
+		&i.ProcessedRows,
+		&i.SucceededRows,
+		&i.FailedRows,
+		&i.Error,
+		&i.CompletedAt,
+		&i.UpdatedAt,
+		&i.CreatedAt,
+		&i.ClientIp,
+		&i.HeldRows,
+	)
+	return i, err
+}
+
+const getTransferBatch = `-- name: GetTransferBatch :one
+SELECT id, owner, from_account_id, currency, mode, status, total_rows, total_amount, processed_rows, succeeded_rows, failed_rows, error, completed_at, updated_at, created_at, client_ip, held_rows FROM transfer_batches WHERE id = $1 LIMIT 1
+`
+
+func (q *Queries) GetTransferBatch(ctx context.Context, id int64) (TransferBatch, error) {
+	row := q.db.QueryRowContext(ctx, getTransferBatch, id)
+	var i TransferBatch
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.FromAccountID,
+		&i.Currency,
+		&i.Mode,
+		&i.Status,
+		&i.TotalRows,
+		&i.TotalAmount,
+		&i.ProcessedRows,
+		&i.SucceededRows,
+		&i.FailedRows,
+		&i.Error,
+		&i.CompletedAt,
+		&i.UpdatedAt,
+		&i.CreatedAt,
+		&i.ClientIp,
+		&i.HeldRows,
+	)
+	return i, err
+}
+
+const listPendingTransferBatchItems = `-- name: ListPendingTransferBatchItems :many
+SELECT id, batch_id, row_number, to_account_id, amount, reference, status, error, transfer_id, updated_at FROM transfer_batch_items
+WHERE batch_id = $1 AND status = 'pending'
+ORDER BY row_number
+`
+
+func (q *Queries) ListPendingTransferBatchItems(ctx context.Context, batchID int64) ([]TransferBatchItem, error) {
+	rows, err := q.db.QueryContext(ctx, listPendingTransferBatchItems, batchID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []TransferBatchItem{}
+	for rows.Next() {
+		var i TransferBatchItem
+		if err := rows.Scan(
+			&i.ID,
+			&i.BatchID,
+			&i.RowNumber,
+			&i.ToAccountID,
+			&i.Amount,
+			&i.Reference,
+			&i.Status,
+			&i.Error,
+			&i.TransferID,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listTransferBatchItems = `-- name: ListTransferBatchItems :many
+SELECT id, batch_id, row_number, to_account_id, amount, reference, status, error, transfer_id, updated_at FROM transfer_batch_items
+WHERE batch_id = $1
+ORDER BY row_number
+`
+
+func (q *Queries) ListTransferBatchItems(ctx context.Context, batchID int64) ([]TransferBatchItem, error) {
+	rows, err := q.db.QueryContext(ctx, listTransferBatchItems, batchID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []TransferBatchItem{}
+	for rows.Next() {
+		var i TransferBatchItem
+		if err := rows.Scan(
+			&i.ID,
+			&i.BatchID,
+			&i.RowNumber,
+			&i.ToAccountID,
+			&i.Amount,
+			&i.Reference,
+			&i.Status,
+			&i.Error,
+			&i.TransferID,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listTransferBatchesBefore = `-- name: ListTransferBatchesBefore :many
+SELECT id, owner, from_account_id, currency, mode, status, total_rows, total_amount, processed_rows, succeeded_rows, failed_rows, error, completed_at, updated_at, created_at, client_ip, held_rows FROM transfer_batches
+WHERE owner = $1
+    AND ($2::bigint = 0 OR id < $2::bigint)
+ORDER BY id DESC
+LIMIT $3
+`
+
+type ListTransferBatchesBeforeParams struct {
+	Owner     string `json:"owner"`
+	BeforeID  int64  `json:"before_id"`
+	PageLimit int32  `json:"page_limit"`
+}
+
+func (q *Queries) ListTransferBatchesBefore(ctx context.Context, arg ListTransferBatchesBeforeParams) ([]TransferBatch, error) {
+	rows, err := q.db.QueryContext(ctx, listTransferBatchesBefore, arg.Owner, arg.BeforeID, arg.PageLimit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []TransferBatch{}
+	for rows.Next() {
+		var i TransferBatch
+		if err := rows.Scan(
+			&i.ID,
+			&i.Owner,
+			&i.FromAccountID,
+			&i.Currency,
+			&i.Mode,
+			&i.Status,
+			&i.TotalRows,
+			&i.TotalAmount,
+			&i.ProcessedRows,
+			&i.SucceededRows,
+			&i.FailedRows,
+			&i.Error,
+			&i.CompletedAt,
+			&i.UpdatedAt,
+			&i.CreatedAt,
+			&i.ClientIp,
+			&i.HeldRows,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const updateTransferBatchItem = `-- name: UpdateTransferBatchItem :one
+UPDATE transfer_batch_items
+SET status = $1,
+    error = $2,
+    transfer_id = $3,
+    updated_at = now()
+WHERE id = $4 AND status IN ('pending', 'held')
+RETURNING id, batch_id, row_number, to_account_id, amount, reference, status, error, transfer_id, updated_at
+`
+
+type UpdateTransferBatchItemParams struct {
+	Status     string        `json:"status"`
+	Error      string        `json:"error"`
+	TransferID sql.NullInt64 `json:"transfer_id"`
+	ID         int64         `json:"id"`
+}
+
+// Settles a pending row, or a row held for fraud review.
+func (q *Queries) UpdateTransferBatchItem(ctx context.Context, arg UpdateTransferBatchItemParams) (TransferBatchItem, error) {
+	row := q.db.QueryRowContext(ctx, updateTransferBatchItem,
+		arg.Status,
+		arg.Error,
+		arg.TransferID,
+		arg.ID,
+	)
+	var i TransferBatchItem
+	err := row.Scan(
+		&i.ID,
+		&i.BatchID,
+		&i.RowNumber,
+		&i.ToAccountID,
+		&i.Amount,
+		&i.Reference,
+		&i.Status,
+		&i.Error,
+		&i.TransferID,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const updateTransferBatchProgress = `-- name: UpdateTransferBatchProgress :one
+UPDATE transfer_batches
+SET processed_rows = (
+        SELECT count(*) FROM transfer_batch_items i
+        WHERE i.batch_id = $1 AND i.status <> 'pending'),
+    succeeded_rows = (
+        SELECT count(*) FROM transfer_batch_items i
+        WHERE i.batch_id = $1 AND i.status = 'succeeded'),
+    failed_rows = (
+        SELECT count(*) FROM transfer_batch_items i
+        WHERE i.batch_id = $1 AND i.status = 'failed'),
+    held_rows = (
+        SELECT count(*) FROM transfer_batch_items i
+        WHERE i.batch_id = $1 AND i.status = 'held'),
+    updated_at = now()
+WHERE id = $1
+RETURNING id, owner, from_account_id, currency, mode, status, total_rows, total_amount, processed_rows, succeeded_rows, failed_rows, error, completed_at, updated_at, created_at, client_ip, held_rows
+`
+
+func (q *Queries) UpdateTransferBatchProgress(ctx context.Context, id int64) (TransferBatch, error) {
+	row := q.db.QueryRowContext(ctx, updateTransferBatchProgress, id)
+	var i TransferBatch
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.FromAccountID,
+		&i.Currency,
+		&i.Mode,
+		&i.Status,
+		&i.TotalRows,
+		&i.TotalAmount,
+		&i.ProcessedRows,
+		&i.SucceededRows,
+		&i.FailedRows,
+		&i.Error,
+		&i.CompletedAt,
+		&i.UpdatedAt,
+		&i.CreatedAt,
+		&i.ClientIp,
+		&i.HeldRows,
+	)
+	return i, err
+}
@@ -18,7 +18,7 @@ INSERT INTO users (
     email
 ) VALUES (
     $1, $2, $3, $4
-) RETURNING username, hashed_password, full_name, email, password_changed_at, created_at
+) RETURNING username, hashed_password, full_name, email, password_changed_at, created_at, is_email_verified, tier, role, kyc_level
 `
 
 type CreateUserParams struct {
@@ -43,12 +43,16 @@ func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (User, e
 		&i.Email,
 		&i.PasswordChangedAt,
 		&i.CreatedAt,
+		&i.IsEmailVerified,
+		&i.Tier,
+		&i.Role,
+		&i.KycLevel,
 	)
 	return i, err
 }
 
 const getUser = `-- name: GetUser :one
-SELECT username, hashed_password, full_name, email, password_changed_at, created_at FROM users 
+SELECT username, hashed_password, full_name, email, password_changed_at, created_at, is_email_verified, tier, role, kyc_level FROM users 
 WHERE username = $1 LIMIT 1
 `
 
@@ -62,6 +66,58 @@ func (q *Queries) GetUser(ctx context.Context, username string) (User, error) {
 		&i.Email,
 		&i.PasswordChangedAt,
 		&i.CreatedAt,
+		&i.IsEmailVerified,
+		&i.Tier,
+		&i.Role,
+		&i.KycLevel,
+	)
+	return i, err
+}
+
+const getUserByVerifiedEmail = `-- name: GetUserByVerifiedEmail :one
+SELECT username, hashed_password, full_name, email, password_changed_at, created_at, is_email_verified, tier, role, kyc_level FROM users
+WHERE lower(email) = lower($1) AND is_email_verified
+LIMIT 1
+`
+
+func (q *Queries) GetUserByVerifiedEmail(ctx context.Context, email string) (User, error) {
+	row := q.db.QueryRowContext(ctx, getUserByVerifiedEmail, email)
+	var i User
+	err := row.Scan(
+		&i.Username,
+		&i.HashedPassword,
+		&i.FullName,
+		&i.Email,
+		&i.PasswordChangedAt,
+		&i.CreatedAt,
+		&i.IsEmailVerified,
+		&i.Tier,
+		&i.Role,
+		&i.KycLevel,
+	)
+	return i, err
+}
+
+const getUserForUpdate = `-- name: GetUserForUpdate :one
+SELECT username, hashed_password, full_name, email, password_changed_at, created_at, is_email_verified, tier, role, kyc_level FROM users
+WHERE username = $1 LIMIT 1
+FOR NO KEY UPDATE
+`
+
+func (q *Queries) GetUserForUpdate(ctx context.Context, username string) (User, error) {
+	row := q.db.QueryRowContext(ctx, getUserForUpdate, username)
+	var i User
+	err := row.Scan(
+		&i.Username,
+		&i.HashedPassword,
+		&i.FullName,
+		&i.Email,
+		&i.PasswordChangedAt,
+		&i.CreatedAt,
+		&i.IsEmailVerified,
+		&i.Tier,
+		&i.Role,
+		&i.KycLevel,
 	)
 	return i, err
 }
@@ -72,10 +128,14 @@ SET
     hashed_password = COALESCE($1, hashed_password),
     password_changed_at = COALESCE($2, password_changed_at),
     full_name = COALESCE($3, full_name),
-    email = COALESCE($4, email)
+    email = COALESCE($4, email),
+    is_email_verified = CASE
+        WHEN $4::varchar IS NOT NULL AND $4::varchar <> email THEN false
+        ELSE is_email_verified
+    END
 WHERE
     username = $5
-RETURNING username, hashed_password, full_name, email, password_changed_at, created_at
+RETURNING username, hashed_password, full_name, email, password_changed_at, created_at, is_email_verified, tier, role, kyc_level
 `
 
 type UpdateUserParams struct {
@@ -102,6 +162,10 @@ func (q *Queries) UpdateUser(ctx context.Context, arg UpdateUserParams) (User, e
 		&i.Email,
 		&i.PasswordChangedAt,
 		&i.CreatedAt,
+		&i.IsEmailVerified,
+		&i.Tier,
+		&i.Role,
+		&i.KycLevel,
 	)
 	return i, err
 }
@@ -0,0 +1,241 @@
+package interest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/NhutHuyDev/sgbank/internal/accountno"
+	"github.com/NhutHuyDev/sgbank/internal/bank"
+	"github.com/NhutHuyDev/sgbank/internal/domain"
+	"github.com/NhutHuyDev/sgbank/internal/infra/db"
+	"github.com/NhutHuyDev/sgbank/pkg/utils"
+)
+
+const PageSize = 100
+
+// RatePrecision is the denominator of annual rates: 20000 is 2%.
+const RatePrecision = 1_000_000
+
+var ErrOverflow = errors.New("interest does not fit in 64 bits")
+
+// DayCount returns the days per year of the money market convention of
+// currency: actual/360 for USD and EUR, actual/365 otherwise.
+func DayCount(currency string) int {
+	switch currency {
+	case utils.USD, utils.EUR:
+		return 360
+	}
+
+	return 365
+}
+
+// DailyAccrual returns the interest earned by balance in one day at
+// annualRate (in parts per million) with dayCount days per year, in
+// accrual units of 1/db.InterestScale minor unit, rounded half to even.
+// It only uses integer arithmetic, so the same inputs always accrue the
+// same amount. Balances that are not positive earn nothing.
+func DailyAccrual(balance int64, annualRate int64, dayCount int) (int64, error) {
+	if balance <= 0 || annualRate <= 0 {
+		return 0, nil
+	}
+
+	numerator := new(big.Int).Mul(big.NewInt(balance), big.NewInt(annualRate))
+	numerator.Mul(numerator, big.NewInt(db.InterestScale))
+	denominator := big.NewInt(int64(RatePrecision) * int64(dayCount))
+
+	quotient, remainder := new(big.Int).QuoRem(numerator, denominator, new(big.Int))
+	switch remainder.Lsh(remainder, 1).Cmp(denominator) {
+	case 1:
+		quotient.Add(quotient, big.NewInt(1))
+	case 0:
+		if quotient.Bit(0) == 1 {
+			quotient.Add(quotient, big.NewInt(1))
+		}
+	}
+
+	if !quotient.IsInt64() {
+		return 0, ErrOverflow
+	}
+
+	return quotient.Int64(), nil
+}
+
+// Date returns the business date of t: its calendar day in UTC.
+func Date(t time.Time) time.Time {
+	year, month, day := t.UTC().Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// Service accrues interest daily on the accounts whose product pays it and
+// capitalizes it monthly. It runs as an end-of-day step once the daily
+// balances of the date are recorded. Every step is idempotent per business
+// date, so a date may be run again after a failure or by several instances.
+type Service struct {
+	store db.Store
+	bank  *bank.Accounts
+}
+
+func NewService(store db.Store, bankAccounts *bank.Accounts) *Service {
+	return &Service{store: store, bank: bankAccounts}
+}
+
+func FromConfig(store db.Store, config utils.Config) (*Service, error) {
+	accountNumbers, err := accountno.FromConfig(config)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewService(store, bank.NewAccounts(store, accountNumbers)), nil
+}
+
+// Run accrues interest for businessDate and, on the last day of a month,
+// capitalizes the month.
+func (service *Service) Run(ctx context.Context, businessDate time.Time) error {
+	businessDate = Date(businessDate)
+
+	if _, err := service.Accrue(ctx, businessDate); err != nil {
+		return err
+	}
+
+	if businessDate.AddDate(0, 0, 1).Day() == 1 {
+		period := businessDate.AddDate(0, 0, 1-businessDate.Day())
+		if _, err := service.Capitalize(ctx, period); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Accrue records one day of interest on every interest-bearing account in
+// the daily balances of businessDate, computed on its balance at the end of
+// that day. Accounts that already accrued for the date are skipped; it
+// returns the number of accruals recorded.
+func (service *Service) Accrue(ctx context.Context, businessDate time.Time) (int, error) {
+	businessDate = Date(businessDate)
+	arg := db.ListInterestBearingAccountsParams{
+		BusinessDate: businessDate,
+		PageLimit:    PageSize,
+	}
+	recorded := 0
+
+	for {
+		accounts, err := service.store.ListInterestBearingAccounts(ctx, arg)
+		if err != nil {
+			return recorded, err
+		}
+
+		for _, account := range accounts {
+			dayCount := DayCount(account.Currency)
+			rate, amount, err := accrual(account, dayCount)
+			if err != nil {
+				return recorded, fmt.Errorf("account [%d]: %w", account.ID, err)
+			}
+
+			rows, err := service.store.CreateInterestAccrual(ctx, db.CreateInterestAccrualParams{
+				AccountID:      account.ID,
+				BusinessDate:   businessDate,
+				ClosingBalance: account.ClosingBalance,
+				AnnualRatePpm:  rate,
+				DayCount:       int32(dayCount),
+				AmountMicros:   amount,
+			})
+			if err != nil {
+				return recorded, err
+			}
+			recorded += int(rows)
+		}
+
+		if len(accounts) < PageSize {
+			return recorded, nil
+		}
+		arg.AfterID = accounts[len(accounts)-1].ID
+	}
+}
+
+// accrual returns the rate that applies to the closing balance of account
+// and one day of interest at that rate: earned on a positive balance at the
+// product's rate, or charged, as a negative amount, on an overdrawn balance
+// at its overdraft rate.
+func accrual(account db.ListInterestBearingAccountsRow, dayCount int) (int64, int64, error) {
+	if account.ClosingBalance >= 0 {
+		amount, err := DailyAccrual(account.ClosingBalance, account.AnnualRatePpm, dayCount)
+		return account.AnnualRatePpm, amount, err
+	}
+
+	amount, err := DailyAccrual(-account.ClosingBalance, account.OverdraftRatePpm, dayCount)
+	return account.OverdraftRatePpm, -amount, err
+}
+
+// Capitalize posts the interest accrued during the month starting on
+// period to every account: interest earned is paid from the bank's
+// interest expense account in its currency, and overdraft interest is paid
+// to its interest income account. It returns the number of accounts
+// capitalized.
+func (service *Service) Capitalize(ctx context.Context, period time.Time) (int, error) {
+	period = Date(period)
+	accountIDs, err := service.store.ListAccountsWithUncapitalizedInterest(ctx, period.AddDate(0, 1, 0))
+	if err != nil {
+		return 0, err
+	}
+
+	bankAccounts := make(map[string]interestAccounts)
+	capitalized := 0
+
+	for _, accountID := range accountIDs {
+		account, err := service.store.GetAccount(ctx, accountID)
+		if err != nil {
+			return capitalized, err
+		}
+
+		accounts, ok := bankAccounts[account.Currency]
+		if !ok {
+			accounts, err = service.interestAccounts(ctx, account.Currency)
+			if err != nil {
+				return capitalized, err
+			}
+			bankAccounts[account.Currency] = accounts
+		}
+
+		_, err = service.store.CapitalizeInterestTx(ctx, db.CapitalizeInterestTxParams{
+			AccountID:        account.ID,
+			ExpenseAccountID: accounts.expense.ID,
+			IncomeAccountID:  accounts.income.ID,
+			Period:           period,
+			Description:      "Interest " + period.Format("January 2006"),
+		})
+		// another instance capitalized the account first
+		if errors.Is(err, domain.ErrDuplicate) {
+			continue
+		}
+		if err != nil {
+			return capitalized, fmt.Errorf("account [%d]: %w", account.ID, err)
+		}
+		capitalized++
+	}
+
+	return capitalized, nil
+}
+
+// interestAccounts are the bank's accounts interest is paid from and
+// charged to in one currency.
+type interestAccounts struct {
+	expense db.Account
+	income  db.Account
+}
+
+func (service *Service) interestAccounts(ctx context.Context, currency string) (interestAccounts, error) {
+	var accounts interestAccounts
+	var err error
+
+	accounts.expense, err = service.bank.Get(ctx, currency, bank.ProductInterestExpense)
+	if err != nil {
+		return accounts, err
+	}
+
+	accounts.income, err = service.bank.Get(ctx, currency, bank.ProductInterestIncome)
+	return accounts, err
+}
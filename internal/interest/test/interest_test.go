@@ -0,0 +1,179 @@
+package test
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/NhutHuyDev/sgbank/internal/accountno"
+	"github.com/NhutHuyDev/sgbank/internal/bank"
+	"github.com/NhutHuyDev/sgbank/internal/domain"
+	"github.com/NhutHuyDev/sgbank/internal/infra/db"
+	mockdb "github.com/NhutHuyDev/sgbank/internal/infra/db/mock"
+	"github.com/NhutHuyDev/sgbank/internal/interest"
+	"github.com/NhutHuyDev/sgbank/pkg/utils"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDailyAccrual(t *testing.T) {
+	testCases := []struct {
+		name     string
+		balance  int64
+		rate     int64
+		dayCount int
+		want     int64
+	}{
+		// 100000 * 2% / 365 = 5.479452054... minor units
+		{name: "Exact", balance: 100000, rate: 20000, dayCount: 365, want: 5479452},
+		// 1 * 1% / 360 = 0.0000277...: rounds up from 27.77 micros
+		{name: "RoundUp", balance: 1, rate: 10000, dayCount: 360, want: 28},
+		// 1 * 0.0036% / 360 = 0.0000001 minor units = 0.1 micros
+		{name: "RoundDown", balance: 1, rate: 36, dayCount: 360, want: 0},
+		// 0.5 micros rounds to the even neighbour
+		{name: "HalfToEvenDown", balance: 1, rate: 180, dayCount: 360, want: 0},
+		{name: "HalfToEvenUp", balance: 3, rate: 180, dayCount: 360, want: 2},
+		{name: "Negative", balance: -5000, rate: 20000, dayCount: 365, want: 0},
+		{name: "NoRate", balance: 5000, rate: 0, dayCount: 365, want: 0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := interest.DailyAccrual(tc.balance, tc.rate, tc.dayCount)
+			require.NoError(t, err)
+			require.Equal(t, tc.want, got)
+		})
+	}
+
+	_, err := interest.DailyAccrual(math.MaxInt64, interest.RatePrecision, 1)
+	require.ErrorIs(t, err, interest.ErrOverflow)
+}
+
+func TestDayCount(t *testing.T) {
+	require.Equal(t, 360, interest.DayCount(utils.USD))
+	require.Equal(t, 360, interest.DayCount(utils.EUR))
+	require.Equal(t, 365, interest.DayCount(utils.CAD))
+}
+
+func newService(t *testing.T, store db.Store) *interest.Service {
+	generator, err := accountno.NewGenerator(accountno.DefaultBankCode, accountno.DefaultBranchCode)
+	require.NoError(t, err)
+
+	return interest.NewService(store, bank.NewAccounts(store, generator))
+}
+
+func TestRunMonthEnd(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	savings := db.Account{ID: 7, Currency: utils.CAD, Product: bank.ProductSavings}
+	expense := db.Account{ID: 2, Owner: bank.Username, Currency: utils.CAD, Product: bank.ProductInterestExpense}
+	income := db.Account{ID: 3, Owner: bank.Username, Currency: utils.CAD, Product: bank.ProductInterestIncome}
+	businessDate := time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC)
+
+	store.EXPECT().
+		ListInterestBearingAccounts(gomock.Any(), gomock.Eq(db.ListInterestBearingAccountsParams{
+			BusinessDate: businessDate,
+			PageLimit:    interest.PageSize,
+		})).
+		Times(1).
+		Return([]db.ListInterestBearingAccountsRow{{ID: savings.ID, Currency: utils.CAD, AnnualRatePpm: 20000, ClosingBalance: 100000}}, nil)
+	store.EXPECT().
+		CreateInterestAccrual(gomock.Any(), gomock.Eq(db.CreateInterestAccrualParams{
+			AccountID:      savings.ID,
+			BusinessDate:   businessDate,
+			ClosingBalance: 100000,
+			AnnualRatePpm:  20000,
+			DayCount:       365,
+			AmountMicros:   5479452,
+		})).
+		Times(1).
+		Return(int64(1), nil)
+
+	period := time.Date(2024, time.February, 1, 0, 0, 0, 0, time.UTC)
+	store.EXPECT().
+		ListAccountsWithUncapitalizedInterest(gomock.Any(), gomock.Eq(time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC))).
+		Times(1).
+		Return([]int64{savings.ID}, nil)
+	store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(savings.ID)).Times(1).Return(savings, nil)
+	store.EXPECT().
+		GetAccountByOwnerProduct(gomock.Any(), gomock.Eq(db.GetAccountByOwnerProductParams{
+			Owner:    bank.Username,
+			Currency: utils.CAD,
+			Product:  bank.ProductInterestExpense,
+		})).
+		Times(1).
+		Return(db.Account{}, domain.ErrAccountNotFound)
+	store.EXPECT().
+		GetAccountByOwnerProduct(gomock.Any(), gomock.Eq(db.GetAccountByOwnerProductParams{
+			Owner:    bank.Username,
+			Currency: utils.CAD,
+			Product:  bank.ProductInterestIncome,
+		})).
+		Times(1).
+		Return(income, nil)
+	store.EXPECT().
+		CreateAccount(gomock.Any(), gomock.Any()).
+		Times(1).
+		DoAndReturn(func(_ context.Context, arg db.CreateAccountParams) (db.Account, error) {
+			require.Equal(t, bank.Username, arg.Owner)
+			require.Equal(t, bank.ProductInterestExpense, arg.Product)
+			return expense, nil
+		})
+	store.EXPECT().
+		CapitalizeInterestTx(gomock.Any(), gomock.Eq(db.CapitalizeInterestTxParams{
+			AccountID:        savings.ID,
+			ExpenseAccountID: expense.ID,
+			IncomeAccountID:  income.ID,
+			Period:           period,
+			Description:      "Interest February 2024",
+		})).
+		Times(1)
+
+	// any time of the day runs the same business date
+	require.NoError(t, newService(t, store).Run(context.Background(), businessDate.Add(15*time.Hour)))
+}
+
+func TestAccrueOverdraft(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	businessDate := time.Date(2024, time.March, 4, 0, 0, 0, 0, time.UTC)
+
+	store.EXPECT().
+		ListInterestBearingAccounts(gomock.Any(), gomock.Any()).
+		Times(1).
+		Return([]db.ListInterestBearingAccountsRow{
+			{ID: 7, Currency: utils.CAD, OverdraftRatePpm: 180000, ClosingBalance: -36500},
+			{ID: 8, Currency: utils.CAD, OverdraftRatePpm: 180000, ClosingBalance: 36500},
+		}, nil)
+	// 36500 * 18% / 365 = 18 minor units charged
+	store.EXPECT().
+		CreateInterestAccrual(gomock.Any(), gomock.Eq(db.CreateInterestAccrualParams{
+			AccountID:      7,
+			BusinessDate:   businessDate,
+			ClosingBalance: -36500,
+			AnnualRatePpm:  180000,
+			DayCount:       365,
+			AmountMicros:   -18 * db.InterestScale,
+		})).
+		Times(1).
+		Return(int64(1), nil)
+	// a checking account in credit earns nothing
+	store.EXPECT().
+		CreateInterestAccrual(gomock.Any(), gomock.Eq(db.CreateInterestAccrualParams{
+			AccountID:      8,
+			BusinessDate:   businessDate,
+			ClosingBalance: 36500,
+			DayCount:       365,
+		})).
+		Times(1).
+		Return(int64(1), nil)
+
+	recorded, err := newService(t, store).Accrue(context.Background(), businessDate)
+	require.NoError(t, err)
+	require.Equal(t, 2, recorded)
+}
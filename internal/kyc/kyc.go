@@ -0,0 +1,444 @@
+// Package kyc verifies the identity of users. Every user has a KYC level
+// with limits on the accounts they may open and the amounts they may send in
+// each currency; new users start unverified. A user asks for a higher level by submitting
+// the documents it requires, which are kept in a document store, and staff
+// approve or reject the submission with a reason.
+package kyc
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/NhutHuyDev/sgbank/internal/blob"
+	"github.com/NhutHuyDev/sgbank/internal/domain"
+	"github.com/NhutHuyDev/sgbank/internal/infra/db"
+	"github.com/NhutHuyDev/sgbank/internal/logging"
+	"github.com/NhutHuyDev/sgbank/internal/notify"
+	"github.com/NhutHuyDev/sgbank/pkg/utils"
+	"github.com/google/uuid"
+)
+
+// Levels.
+const (
+	LevelUnverified int32 = 0
+	LevelIdentity   int32 = 1
+	LevelFull       int32 = 2
+)
+
+// Kinds of documents.
+const (
+	DocumentID      = "id_document"
+	DocumentAddress = "proof_of_address"
+)
+
+// Requirements are the documents a submission for a level must include.
+var Requirements = map[int32][]string{
+	LevelIdentity: {DocumentID},
+	LevelFull:     {DocumentID, DocumentAddress},
+}
+
+// ContentTypes are the types a document may have, detected from its
+// content.
+var ContentTypes = []string{"application/pdf", "image/jpeg", "image/png"}
+
+// Statuses of submissions.
+const (
+	StatusPending  = db.KycSubmissionPending
+	StatusApproved = db.KycSubmissionApproved
+	StatusRejected = db.KycSubmissionRejected
+)
+
+const (
+	KindApproved = "kyc.approved"
+	KindRejected = "kyc.rejected"
+)
+
+const (
+	DefaultStorageDir      = "data/kyc"
+	DefaultMaxDocumentSize = 5 << 20
+	MaxFileNameLength      = 200
+	MaxReasonLength        = 200
+	PageSize               = 100
+)
+
+// Level is a KYC level and its transfer limits in every currency it has
+// some in.
+type Level struct {
+	db.KycLevel
+	Limits []db.KycLevelLimit `json:"limits"`
+}
+
+// Status is the level of a user, what they used of its limits today and
+// their submissions, newest first.
+type Status struct {
+	Level    Level `json:"level"`
+	Accounts int64 `json:"accounts"`
+	// SentToday is what they sent since midnight UTC in each currency.
+	SentToday   map[string]int64   `json:"sent_today"`
+	Submissions []db.KycSubmission `json:"submissions"`
+}
+
+type Service struct {
+	store           db.Store
+	files           blob.Store
+	notifier        notify.Notifier
+	maxDocumentSize int64
+}
+
+func NewService(store db.Store, files blob.Store, notifier notify.Notifier, maxDocumentSize int64) *Service {
+	return &Service{store: store, files: files, notifier: notifier, maxDocumentSize: maxDocumentSize}
+}
+
+// FromConfig builds the service with its documents on disk in
+// KYC_STORAGE_DIR, using the defaults for the KYC_* settings that are not
+// set.
+func FromConfig(store db.Store, notifier notify.Notifier, config utils.Config) *Service {
+	dir := config.KYCStorageDir
+	if dir == "" {
+		dir = DefaultStorageDir
+	}
+
+	maxDocumentSize := config.KYCMaxDocumentSize
+	if maxDocumentSize <= 0 {
+		maxDocumentSize = DefaultMaxDocumentSize
+	}
+
+	return NewService(store, blob.NewDisk(dir), notifier, maxDocumentSize)
+}
+
+// MaxDocumentSize is the largest file a document may be.
+func (service *Service) MaxDocumentSize() int64 {
+	return service.maxDocumentSize
+}
+
+func (service *Service) Levels(ctx context.Context) ([]Level, error) {
+	levels, err := service.store.ListKycLevels(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	limits, err := service.limits(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]Level, 0, len(levels))
+	for _, level := range levels {
+		result = append(result, Level{KycLevel: level, Limits: limits[level.Level]})
+	}
+
+	return result, nil
+}
+
+// limits returns the transfer limits of every level, by level.
+func (service *Service) limits(ctx context.Context) (map[int32][]db.KycLevelLimit, error) {
+	limits, err := service.store.ListKycLevelLimits(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	byLevel := map[int32][]db.KycLevelLimit{}
+	for _, limit := range limits {
+		byLevel[limit.Level] = append(byLevel[limit.Level], limit)
+	}
+
+	return byLevel, nil
+}
+
+func (service *Service) Status(ctx context.Context, username string) (Status, error) {
+	var status Status
+	var err error
+
+	status.Level.KycLevel, err = service.store.GetUserKycLimits(ctx, username)
+	if err != nil {
+		return status, err
+	}
+
+	limits, err := service.limits(ctx)
+	if err != nil {
+		return status, err
+	}
+	status.Level.Limits = limits[status.Level.Level]
+
+	status.Accounts, err = service.store.CountAccountsByOwner(ctx, username)
+	if err != nil {
+		return status, err
+	}
+
+	status.SentToday, err = service.sentToday(ctx, username)
+	if err != nil {
+		return status, err
+	}
+
+	status.Submissions, err = service.store.ListUserKycSubmissions(ctx, username)
+	return status, err
+}
+
+// CheckAccountCreation fails with ErrKYCLimitExceeded when the user already
+// has as many accounts as their level allows. The store checks again when it
+// opens the account, with the user locked.
+func (service *Service) CheckAccountCreation(ctx context.Context, username string) error {
+	return db.CheckAccountLimit(ctx, service.store, username)
+}
+
+// CheckTransfer fails with ErrKYCLimitExceeded when amount is above the
+// limit per transfer in currency of the sender's level, or would take what
+// they sent in currency since midnight UTC above its daily limit. The store
+// checks again when it posts the transfer, with the sender locked, since
+// payments made meanwhile count towards the daily limit.
+func (service *Service) CheckTransfer(ctx context.Context, username string, currency string, amount int64) error {
+	return db.CheckKYCLimits(ctx, service.store, username, currency, amount)
+}
+
+func (service *Service) sentToday(ctx context.Context, username string) (map[string]int64, error) {
+	totals, err := service.store.SumTransfersFromOwnerSinceByCurrency(ctx, db.SumTransfersFromOwnerSinceByCurrencyParams{
+		Owner: username,
+		Since: time.Now().UTC().Truncate(24 * time.Hour),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sent := make(map[string]int64, len(totals))
+	for _, total := range totals {
+		sent[total.Currency] = total.Total
+	}
+
+	return sent, nil
+}
+
+// Document is a file submitted for verification.
+type Document struct {
+	Kind     string
+	FileName string
+	Content  io.Reader
+}
+
+type SubmitParams struct {
+	Username  string
+	Level     int32
+	Documents []Document
+}
+
+// Submit stores the documents of a request for a higher level and queues it
+// for review. A user has at most one submission pending.
+func (service *Service) Submit(ctx context.Context, arg SubmitParams) (db.CreateKycSubmissionTxResult, error) {
+	var result db.CreateKycSubmissionTxResult
+
+	current, err := service.store.GetUserKycLimits(ctx, arg.Username)
+	if err != nil {
+		return result, err
+	}
+
+	if err := validateSubmission(arg, current.Level); err != nil {
+		return result, err
+	}
+
+	documents := make([]db.CreateKycDocumentParams, 0, len(arg.Documents))
+	defer func() {
+		if err != nil {
+			service.deleteFiles(ctx, documents)
+		}
+	}()
+
+	for i, document := range arg.Documents {
+		var stored db.CreateKycDocumentParams
+		stored, err = service.storeFile(ctx, arg.Username, document, fmt.Sprintf("documents[%d]", i))
+		if err != nil {
+			return result, err
+		}
+		documents = append(documents, stored)
+	}
+
+	result, err = service.store.CreateKycSubmissionTx(ctx, db.CreateKycSubmissionTxParams{
+		CreateKycSubmissionParams: db.CreateKycSubmissionParams{
+			Username: arg.Username,
+			Level:    arg.Level,
+		},
+		Documents: documents,
+	})
+	return result, err
+}
+
+func validateSubmission(arg SubmitParams, current int32) error {
+	required, ok := Requirements[arg.Level]
+	if !ok {
+		return domain.NewValidationError(domain.FieldViolation{Field: "level", Description: fmt.Sprintf("must be %d or %d", LevelIdentity, LevelFull)})
+	}
+
+	if arg.Level <= current {
+		return domain.NewValidationError(domain.FieldViolation{Field: "level", Description: fmt.Sprintf("must be above the current level %d", current)})
+	}
+
+	var violations []domain.FieldViolation
+	kinds := make(map[string]bool, len(arg.Documents))
+	for i, document := range arg.Documents {
+		field := fmt.Sprintf("documents[%d]", i)
+
+		switch {
+		case !slices.Contains(Requirements[LevelFull], document.Kind):
+			violations = append(violations, domain.FieldViolation{Field: field, Description: fmt.Sprintf("kind must be one of %s", strings.Join(Requirements[LevelFull], ", "))})
+		case kinds[document.Kind]:
+			violations = append(violations, domain.FieldViolation{Field: field, Description: fmt.Sprintf("%s is submitted more than once", document.Kind)})
+		}
+		kinds[document.Kind] = true
+
+		if name := filepath.Base(document.FileName); name == "." || len(name) > MaxFileNameLength {
+			violations = append(violations, domain.FieldViolation{Field: field, Description: fmt.Sprintf("file name must be 1 to %d characters", MaxFileNameLength)})
+		}
+	}
+
+	for _, kind := range required {
+		if !kinds[kind] {
+			violations = append(violations, domain.FieldViolation{Field: kind, Description: fmt.Sprintf("is required for level %d", arg.Level)})
+		}
+	}
+
+	if violations != nil {
+		return domain.NewValidationError(violations...)
+	}
+
+	return nil
+}
+
+// storeFile checks the size and type of a document and puts it in the
+// document store.
+func (service *Service) storeFile(ctx context.Context, username string, document Document, field string) (db.CreateKycDocumentParams, error) {
+	content, err := io.ReadAll(io.LimitReader(document.Content, service.maxDocumentSize+1))
+	if err != nil {
+		return db.CreateKycDocumentParams{}, err
+	}
+
+	if len(content) == 0 || int64(len(content)) > service.maxDocumentSize {
+		return db.CreateKycDocumentParams{}, domain.NewValidationError(domain.FieldViolation{
+			Field:       field,
+			Description: fmt.Sprintf("must be 1 to %d bytes", service.maxDocumentSize),
+		})
+	}
+
+	contentType := http.DetectContentType(content)
+	if !slices.Contains(ContentTypes, contentType) {
+		return db.CreateKycDocumentParams{}, domain.NewValidationError(domain.FieldViolation{
+			Field:       field,
+			Description: fmt.Sprintf("must be one of %s, not %s", strings.Join(ContentTypes, ", "), contentType),
+		})
+	}
+
+	sum := sha256.Sum256(content)
+	key := fmt.Sprintf("%s/%s-%s", username, uuid.NewString(), document.Kind)
+	if err := service.files.Put(ctx, key, bytes.NewReader(content)); err != nil {
+		return db.CreateKycDocumentParams{}, err
+	}
+
+	return db.CreateKycDocumentParams{
+		Kind:        document.Kind,
+		FileName:    filepath.Base(document.FileName),
+		ContentType: contentType,
+		Size:        int64(len(content)),
+		Sha256:      hex.EncodeToString(sum[:]),
+		StorageKey:  key,
+	}, nil
+}
+
+func (service *Service) deleteFiles(ctx context.Context, documents []db.CreateKycDocumentParams) {
+	for _, document := range documents {
+		if err := service.files.Delete(ctx, document.StorageKey); err != nil {
+			logging.FromContext(ctx).Warn().Err(err).Str("storage_key", document.StorageKey).Msg("cannot delete document of failed submission")
+		}
+	}
+}
+
+// Submission returns a submission with its documents.
+func (service *Service) Submission(ctx context.Context, id int64) (db.KycSubmission, []db.KycDocument, error) {
+	submission, err := service.store.GetKycSubmission(ctx, id)
+	if err != nil {
+		return submission, nil, err
+	}
+
+	documents, err := service.store.ListKycDocuments(ctx, id)
+	return submission, documents, err
+}
+
+// OpenDocument returns a document of a submission and its content, which
+// the caller closes.
+func (service *Service) OpenDocument(ctx context.Context, submissionID, documentID int64) (db.KycDocument, io.ReadCloser, error) {
+	document, err := service.store.GetKycDocument(ctx, db.GetKycDocumentParams{ID: documentID, SubmissionID: submissionID})
+	if err != nil {
+		return document, nil, err
+	}
+
+	content, err := service.files.Open(ctx, document.StorageKey)
+	return document, content, err
+}
+
+func (service *Service) Pending(ctx context.Context, afterID int64, pageSize int32) ([]db.KycSubmission, error) {
+	return service.store.ListPendingKycSubmissions(ctx, db.ListPendingKycSubmissionsParams{
+		AfterID:   afterID,
+		PageLimit: pageSize,
+	})
+}
+
+type ReviewParams struct {
+	ID       int64
+	Reviewer string
+	Reason   string
+}
+
+// Approve raises the level of the user to the level of a pending
+// submission. It fails with ErrInvalidTransition when the submission is not
+// pending.
+func (service *Service) Approve(ctx context.Context, arg ReviewParams) (db.ReviewKycSubmissionTxResult, error) {
+	return service.review(ctx, arg, StatusApproved)
+}
+
+// Reject refuses a pending submission; the reason is required and shown to
+// the user.
+func (service *Service) Reject(ctx context.Context, arg ReviewParams) (db.ReviewKycSubmissionTxResult, error) {
+	if strings.TrimSpace(arg.Reason) == "" {
+		return db.ReviewKycSubmissionTxResult{}, domain.NewValidationError(domain.FieldViolation{Field: "reason", Description: "is required to reject a submission"})
+	}
+
+	return service.review(ctx, arg, StatusRejected)
+}
+
+func (service *Service) review(ctx context.Context, arg ReviewParams, status string) (db.ReviewKycSubmissionTxResult, error) {
+	arg.Reason = strings.TrimSpace(arg.Reason)
+	if len(arg.Reason) > MaxReasonLength {
+		return db.ReviewKycSubmissionTxResult{}, domain.NewValidationError(domain.FieldViolation{
+			Field:       "reason",
+			Description: fmt.Sprintf("must be at most %d characters", MaxReasonLength),
+		})
+	}
+
+	result, err := service.store.ReviewKycSubmissionTx(ctx, db.ReviewKycSubmissionTxParams{
+		ID:         arg.ID,
+		Status:     status,
+		Reason:     arg.Reason,
+		ReviewedBy: arg.Reviewer,
+	})
+	if err != nil {
+		return result, err
+	}
+
+	notification := notify.Notification{
+		Username: result.Submission.Username,
+		Kind:     KindApproved,
+		Message:  fmt.Sprintf("your verification for level %d was approved", result.Submission.Level),
+	}
+	if status == StatusRejected {
+		notification.Kind = KindRejected
+		notification.Message = fmt.Sprintf("your verification for level %d was rejected: %s", result.Submission.Level, arg.Reason)
+	}
+	service.notifier.Notify(ctx, notification)
+
+	return result, nil
+}
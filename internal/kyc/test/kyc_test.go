@@ -0,0 +1,298 @@
+package test
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/NhutHuyDev/sgbank/internal/blob"
+	"github.com/NhutHuyDev/sgbank/internal/domain"
+	"github.com/NhutHuyDev/sgbank/internal/infra/db"
+	mockdb "github.com/NhutHuyDev/sgbank/internal/infra/db/mock"
+	"github.com/NhutHuyDev/sgbank/internal/kyc"
+	"github.com/NhutHuyDev/sgbank/internal/notify"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+// pngHeader is enough of a PNG file for its type to be detected.
+var pngHeader = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+
+type recordingNotifier struct {
+	notifications []notify.Notification
+}
+
+func (notifier *recordingNotifier) Notify(_ context.Context, notification notify.Notification) {
+	notifier.notifications = append(notifier.notifications, notification)
+}
+
+func unverified() db.KycLevel {
+	return db.KycLevel{
+		Level:       kyc.LevelUnverified,
+		Name:        "Unverified",
+		MaxAccounts: sql.NullInt32{Int32: 1, Valid: true},
+	}
+}
+
+func unverifiedLimits(currency string) db.GetUserKycTransferLimitsRow {
+	limits := db.GetUserKycTransferLimitsRow{Level: kyc.LevelUnverified, Name: "Unverified"}
+	if currency == "USD" {
+		limits.MaxTransferAmount = sql.NullInt64{Int64: 10000, Valid: true}
+		limits.DailyTransferLimit = sql.NullInt64{Int64: 20000, Valid: true}
+	}
+	return limits
+}
+
+func newService(t *testing.T, store db.Store) (*kyc.Service, *blob.Disk, *recordingNotifier) {
+	files := blob.NewDisk(t.TempDir())
+	notifier := &recordingNotifier{}
+	return kyc.NewService(store, files, notifier, 1<<10), files, notifier
+}
+
+func TestCheckAccountCreation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	service, _, _ := newService(t, store)
+
+	store.EXPECT().GetUserKycLimits(gomock.Any(), gomock.Eq("alice")).Times(2).Return(unverified(), nil)
+	store.EXPECT().CountAccountsByOwner(gomock.Any(), gomock.Eq("alice")).Times(1).Return(int64(0), nil)
+	store.EXPECT().CountAccountsByOwner(gomock.Any(), gomock.Eq("alice")).Times(1).Return(int64(1), nil)
+
+	require.NoError(t, service.CheckAccountCreation(context.Background(), "alice"))
+	require.ErrorIs(t, service.CheckAccountCreation(context.Background(), "alice"), domain.ErrKYCLimitExceeded)
+
+	// a level without a limit does not count the accounts
+	store.EXPECT().GetUserKycLimits(gomock.Any(), gomock.Eq("bob")).Times(1).Return(db.KycLevel{Level: kyc.LevelFull}, nil)
+	require.NoError(t, service.CheckAccountCreation(context.Background(), "bob"))
+}
+
+func TestCheckTransfer(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	service, _, _ := newService(t, store)
+
+	store.EXPECT().GetUserKycTransferLimits(gomock.Any(), gomock.Any()).AnyTimes().
+		DoAndReturn(func(_ any, arg db.GetUserKycTransferLimitsParams) (db.GetUserKycTransferLimitsRow, error) {
+			require.Equal(t, "alice", arg.Username)
+			return unverifiedLimits(arg.Currency), nil
+		})
+	store.EXPECT().SumTransfersFromOwnerSince(gomock.Any(), gomock.Any()).AnyTimes().
+		DoAndReturn(func(_ any, arg db.SumTransfersFromOwnerSinceParams) (int64, error) {
+			require.Equal(t, "alice", arg.Owner)
+			require.Equal(t, "USD", arg.Currency)
+			require.Zero(t, arg.Since.Hour())
+			return 15000, nil
+		})
+
+	ctx := context.Background()
+	require.NoError(t, service.CheckTransfer(ctx, "alice", "USD", 5000))
+	// above the limit per transfer
+	require.ErrorIs(t, service.CheckTransfer(ctx, "alice", "USD", 10001), domain.ErrKYCLimitExceeded)
+	// above what is left of the daily limit
+	require.ErrorIs(t, service.CheckTransfer(ctx, "alice", "USD", 5001), domain.ErrKYCLimitExceeded)
+	// a currency the level has no limits in
+	require.NoError(t, service.CheckTransfer(ctx, "alice", "JPY", 1000000))
+}
+
+func TestStatus(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	service, _, _ := newService(t, store)
+
+	limits := []db.KycLevelLimit{
+		{Level: kyc.LevelUnverified, Currency: "JPY", MaxTransferAmount: sql.NullInt64{Int64: 15000, Valid: true}},
+		{Level: kyc.LevelUnverified, Currency: "USD", MaxTransferAmount: sql.NullInt64{Int64: 10000, Valid: true}},
+		{Level: kyc.LevelIdentity, Currency: "USD", MaxTransferAmount: sql.NullInt64{Int64: 100000, Valid: true}},
+	}
+
+	store.EXPECT().GetUserKycLimits(gomock.Any(), gomock.Eq("alice")).Times(1).Return(unverified(), nil)
+	store.EXPECT().ListKycLevelLimits(gomock.Any()).Times(1).Return(limits, nil)
+	store.EXPECT().CountAccountsByOwner(gomock.Any(), gomock.Eq("alice")).Times(1).Return(int64(2), nil)
+	store.EXPECT().SumTransfersFromOwnerSinceByCurrency(gomock.Any(), gomock.Any()).Times(1).
+		Return([]db.SumTransfersFromOwnerSinceByCurrencyRow{{Currency: "JPY", Total: 900}, {Currency: "USD", Total: 1500}}, nil)
+	store.EXPECT().ListUserKycSubmissions(gomock.Any(), gomock.Eq("alice")).Times(1).Return([]db.KycSubmission{}, nil)
+
+	status, err := service.Status(context.Background(), "alice")
+	require.NoError(t, err)
+	require.Equal(t, limits[:2], status.Level.Limits)
+	require.Equal(t, map[string]int64{"JPY": 900, "USD": 1500}, status.SentToday)
+	require.EqualValues(t, 2, status.Accounts)
+}
+
+func TestSubmitValidation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	service, _, _ := newService(t, store)
+
+	store.EXPECT().GetUserKycLimits(gomock.Any(), gomock.Any()).AnyTimes().Return(unverified(), nil)
+	store.EXPECT().CreateKycSubmissionTx(gomock.Any(), gomock.Any()).Times(0)
+
+	ctx := context.Background()
+	testCases := []struct {
+		name string
+		arg  kyc.SubmitParams
+	}{
+		{
+			name: "UnknownLevel",
+			arg:  kyc.SubmitParams{Username: "alice", Level: 3},
+		},
+		{
+			name: "CurrentLevel",
+			arg:  kyc.SubmitParams{Username: "alice", Level: kyc.LevelUnverified},
+		},
+		{
+			name: "MissingDocument",
+			arg: kyc.SubmitParams{Username: "alice", Level: kyc.LevelFull, Documents: []kyc.Document{
+				{Kind: kyc.DocumentID, FileName: "id.png", Content: bytes.NewReader(pngHeader)},
+			}},
+		},
+		{
+			name: "UnknownKind",
+			arg: kyc.SubmitParams{Username: "alice", Level: kyc.LevelIdentity, Documents: []kyc.Document{
+				{Kind: kyc.DocumentID, FileName: "id.png", Content: bytes.NewReader(pngHeader)},
+				{Kind: "selfie", FileName: "me.png", Content: bytes.NewReader(pngHeader)},
+			}},
+		},
+		{
+			name: "ContentType",
+			arg: kyc.SubmitParams{Username: "alice", Level: kyc.LevelIdentity, Documents: []kyc.Document{
+				{Kind: kyc.DocumentID, FileName: "id.txt", Content: strings.NewReader("hello")},
+			}},
+		},
+		{
+			name: "TooLarge",
+			arg: kyc.SubmitParams{Username: "alice", Level: kyc.LevelIdentity, Documents: []kyc.Document{
+				{Kind: kyc.DocumentID, FileName: "id.png", Content: io.MultiReader(bytes.NewReader(pngHeader), bytes.NewReader(make([]byte, 1<<10)))},
+			}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := service.Submit(ctx, tc.arg)
+			var validationErr *domain.ValidationError
+			require.ErrorAs(t, err, &validationErr)
+		})
+	}
+}
+
+func TestSubmit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	service, files, _ := newService(t, store)
+	ctx := context.Background()
+
+	store.EXPECT().GetUserKycLimits(gomock.Any(), gomock.Eq("alice")).Times(1).Return(unverified(), nil)
+	store.EXPECT().
+		CreateKycSubmissionTx(gomock.Any(), gomock.Any()).
+		Times(1).
+		DoAndReturn(func(_ any, arg db.CreateKycSubmissionTxParams) (db.CreateKycSubmissionTxResult, error) {
+			require.Equal(t, "alice", arg.Username)
+			require.Equal(t, kyc.LevelIdentity, arg.Level)
+			require.Len(t, arg.Documents, 1)
+
+			document := arg.Documents[0]
+			require.Equal(t, kyc.DocumentID, document.Kind)
+			require.Equal(t, "id.png", document.FileName)
+			require.Equal(t, "image/png", document.ContentType)
+			require.EqualValues(t, len(pngHeader), document.Size)
+			require.Len(t, document.Sha256, 64)
+			require.True(t, strings.HasPrefix(document.StorageKey, "alice/"))
+
+			return db.CreateKycSubmissionTxResult{
+				Submission: db.KycSubmission{ID: 1, Username: arg.Username, Level: arg.Level, Status: kyc.StatusPending},
+				Documents:  []db.KycDocument{{ID: 1, SubmissionID: 1, StorageKey: document.StorageKey}},
+			}, nil
+		})
+
+	result, err := service.Submit(ctx, kyc.SubmitParams{Username: "alice", Level: kyc.LevelIdentity, Documents: []kyc.Document{
+		{Kind: kyc.DocumentID, FileName: "/tmp/id.png", Content: bytes.NewReader(pngHeader)},
+	}})
+	require.NoError(t, err)
+
+	content, err := files.Open(ctx, result.Documents[0].StorageKey)
+	require.NoError(t, err)
+	defer content.Close()
+
+	stored, err := io.ReadAll(content)
+	require.NoError(t, err)
+	require.Equal(t, pngHeader, stored)
+}
+
+func TestSubmitDeletesFilesOnError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	service, files, _ := newService(t, store)
+	ctx := context.Background()
+
+	var key string
+	store.EXPECT().GetUserKycLimits(gomock.Any(), gomock.Any()).Times(1).Return(unverified(), nil)
+	store.EXPECT().
+		CreateKycSubmissionTx(gomock.Any(), gomock.Any()).
+		Times(1).
+		DoAndReturn(func(_ any, arg db.CreateKycSubmissionTxParams) (db.CreateKycSubmissionTxResult, error) {
+			key = arg.Documents[0].StorageKey
+			return db.CreateKycSubmissionTxResult{}, domain.ErrDuplicate
+		})
+
+	_, err := service.Submit(ctx, kyc.SubmitParams{Username: "alice", Level: kyc.LevelIdentity, Documents: []kyc.Document{
+		{Kind: kyc.DocumentID, FileName: "id.png", Content: bytes.NewReader(pngHeader)},
+	}})
+	require.ErrorIs(t, err, domain.ErrDuplicate)
+
+	_, err = files.Open(ctx, key)
+	require.ErrorIs(t, err, domain.ErrNotFound)
+}
+
+func TestReview(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	service, _, notifier := newService(t, store)
+	ctx := context.Background()
+
+	_, err := service.Reject(ctx, kyc.ReviewParams{ID: 1, Reviewer: "admin", Reason: "  "})
+	var validationErr *domain.ValidationError
+	require.ErrorAs(t, err, &validationErr)
+
+	store.EXPECT().
+		ReviewKycSubmissionTx(gomock.Any(), gomock.Any()).
+		Times(2).
+		DoAndReturn(func(_ any, arg db.ReviewKycSubmissionTxParams) (db.ReviewKycSubmissionTxResult, error) {
+			require.Equal(t, "admin", arg.ReviewedBy)
+			return db.ReviewKycSubmissionTxResult{
+				Submission: db.KycSubmission{ID: arg.ID, Username: "alice", Level: kyc.LevelIdentity, Status: arg.Status, Reason: arg.Reason},
+				User:       db.User{Username: "alice", KycLevel: kyc.LevelIdentity},
+			}, nil
+		})
+
+	result, err := service.Approve(ctx, kyc.ReviewParams{ID: 1, Reviewer: "admin"})
+	require.NoError(t, err)
+	require.Equal(t, kyc.StatusApproved, result.Submission.Status)
+
+	result, err = service.Reject(ctx, kyc.ReviewParams{ID: 2, Reviewer: "admin", Reason: " blurry photo "})
+	require.NoError(t, err)
+	require.Equal(t, kyc.StatusRejected, result.Submission.Status)
+	require.Equal(t, "blurry photo", result.Submission.Reason)
+
+	require.Len(t, notifier.notifications, 2)
+	require.Equal(t, kyc.KindApproved, notifier.notifications[0].Kind)
+	require.Equal(t, kyc.KindRejected, notifier.notifications[1].Kind)
+	require.Contains(t, notifier.notifications[1].Message, "blurry photo")
+}
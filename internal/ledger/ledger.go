@@ -0,0 +1,327 @@
+// Package ledger reports the general ledger. Every entry is posted to the
+// general ledger account of its account's product: a credit to a customer
+// account credits customer deposits, a fee credits fee revenue and interest
+// paid debits interest expense. Both sides of a transfer are entries, so
+// the postings of each transfer balance.
+package ledger
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/NhutHuyDev/sgbank/internal/domain"
+	"github.com/NhutHuyDev/sgbank/internal/infra/db"
+	"github.com/NhutHuyDev/sgbank/internal/money"
+)
+
+// Types of general ledger accounts. Assets and expenses have debit
+// balances, the others credit balances.
+const (
+	TypeAsset     = "asset"
+	TypeLiability = "liability"
+	TypeEquity    = "equity"
+	TypeRevenue   = "revenue"
+	TypeExpense   = "expense"
+)
+
+// Accounts of the chart the reports post to themselves.
+const (
+	// CodeSuspense offsets entries that are not part of a transfer.
+	CodeSuspense = "1900"
+	// CodeRetainedEarnings carries revenue less expenses on the balance sheet.
+	CodeRetainedEarnings = "3000"
+)
+
+// DateLayout is the layout of report dates. A date covers the whole UTC
+// calendar day.
+const DateLayout = time.DateOnly
+
+// Line is the balance of a general ledger account on a trial balance, in
+// the debit or credit column.
+type Line struct {
+	Code   string `json:"code"`
+	Name   string `json:"name"`
+	Type   string `json:"type"`
+	Debit  int64  `json:"debit"`
+	Credit int64  `json:"credit"`
+}
+
+type TrialBalance struct {
+	Currency    string `json:"currency"`
+	Lines       []Line `json:"lines"`
+	TotalDebit  int64  `json:"total_debit"`
+	TotalCredit int64  `json:"total_credit"`
+}
+
+// Item is the balance of a general ledger account on a balance sheet or
+// profit and loss statement, positive when it is on the account's normal
+// side.
+type Item struct {
+	Code   string `json:"code"`
+	Name   string `json:"name"`
+	Amount int64  `json:"amount"`
+}
+
+type Section struct {
+	Items []Item `json:"items"`
+	Total int64  `json:"total"`
+}
+
+// BalanceSheet reports assets against liabilities and equity. Revenue less
+// expenses to date is reported as retained earnings, so Assets.Total equals
+// Liabilities.Total plus Equity.Total.
+type BalanceSheet struct {
+	Currency    string  `json:"currency"`
+	Assets      Section `json:"assets"`
+	Liabilities Section `json:"liabilities"`
+	Equity      Section `json:"equity"`
+}
+
+type ProfitAndLoss struct {
+	Currency  string  `json:"currency"`
+	Revenue   Section `json:"revenue"`
+	Expenses  Section `json:"expenses"`
+	NetIncome int64   `json:"net_income"`
+}
+
+// Service builds the reports from the entries, one per currency.
+type Service struct {
+	store db.Store
+}
+
+func NewService(store db.Store) *Service {
+	return &Service{store: store}
+}
+
+// Chart returns the chart of accounts.
+func (service *Service) Chart(ctx context.Context) ([]db.GlAccount, error) {
+	return service.store.ListGLAccounts(ctx)
+}
+
+// TrialBalances returns the balance of every general ledger account at the
+// end of date, of currency or of every currency when it is empty.
+func (service *Service) TrialBalances(ctx context.Context, date time.Time, currency string) ([]TrialBalance, error) {
+	chart, balances, err := service.balances(ctx, endOf(date), currency)
+	if err != nil {
+		return nil, err
+	}
+
+	reports := make([]TrialBalance, 0, len(balances))
+	for _, code := range sortedKeys(balances) {
+		report := TrialBalance{Currency: code, Lines: []Line{}}
+
+		for _, glCode := range sortedKeys(balances[code]) {
+			credit := balances[code][glCode]
+			if credit == 0 {
+				continue
+			}
+
+			account := chart[glCode]
+			line := Line{Code: account.Code, Name: account.Name, Type: account.Type}
+
+			// balances are positive for credits
+			if credit > 0 {
+				line.Credit = credit
+			} else {
+				line.Debit = -credit
+			}
+
+			if report.TotalDebit, err = money.Add(report.TotalDebit, line.Debit); err != nil {
+				return nil, err
+			}
+			if report.TotalCredit, err = money.Add(report.TotalCredit, line.Credit); err != nil {
+				return nil, err
+			}
+			report.Lines = append(report.Lines, line)
+		}
+
+		reports = append(reports, report)
+	}
+
+	return reports, nil
+}
+
+// BalanceSheets returns the balance sheet at the end of date, of currency
+// or of every currency when it is empty.
+func (service *Service) BalanceSheets(ctx context.Context, date time.Time, currency string) ([]BalanceSheet, error) {
+	chart, balances, err := service.balances(ctx, endOf(date), currency)
+	if err != nil {
+		return nil, err
+	}
+
+	reports := make([]BalanceSheet, 0, len(balances))
+	for _, code := range sortedKeys(balances) {
+		report := BalanceSheet{
+			Currency:    code,
+			Assets:      Section{Items: []Item{}},
+			Liabilities: Section{Items: []Item{}},
+			Equity:      Section{Items: []Item{}},
+		}
+
+		var earnings int64
+		for _, glCode := range sortedKeys(balances[code]) {
+			account := chart[glCode]
+			credit := balances[code][glCode]
+
+			switch account.Type {
+			case TypeAsset:
+				err = report.Assets.add(account, -credit)
+			case TypeLiability:
+				err = report.Liabilities.add(account, credit)
+			case TypeEquity:
+				if glCode == CodeRetainedEarnings {
+					earnings, err = money.Add(earnings, credit)
+				} else {
+					err = report.Equity.add(account, credit)
+				}
+			case TypeRevenue, TypeExpense:
+				earnings, err = money.Add(earnings, credit)
+			}
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if earnings != 0 {
+			if err := report.Equity.add(chart[CodeRetainedEarnings], earnings); err != nil {
+				return nil, err
+			}
+		}
+
+		reports = append(reports, report)
+	}
+
+	return reports, nil
+}
+
+// ProfitAndLoss returns the revenue and expenses posted from the start of
+// from to the end of to, of currency or of every currency when it is empty.
+func (service *Service) ProfitAndLoss(ctx context.Context, from time.Time, to time.Time, currency string) ([]ProfitAndLoss, error) {
+	if to.Before(from) {
+		return nil, domain.NewValidationError(domain.FieldViolation{Field: "to", Description: "must not be before from"})
+	}
+
+	chart, closing, err := service.balances(ctx, endOf(to), currency)
+	if err != nil {
+		return nil, err
+	}
+
+	_, opening, err := service.balances(ctx, startOf(from), currency)
+	if err != nil {
+		return nil, err
+	}
+
+	reports := make([]ProfitAndLoss, 0, len(closing))
+	for _, code := range sortedKeys(closing) {
+		report := ProfitAndLoss{
+			Currency: code,
+			Revenue:  Section{Items: []Item{}},
+			Expenses: Section{Items: []Item{}},
+		}
+
+		for _, glCode := range sortedKeys(closing[code]) {
+			account := chart[glCode]
+			if account.Type != TypeRevenue && account.Type != TypeExpense {
+				continue
+			}
+
+			credit, err := money.Sub(closing[code][glCode], opening[code][glCode])
+			if err != nil {
+				return nil, err
+			}
+
+			if account.Type == TypeRevenue {
+				err = report.Revenue.add(account, credit)
+			} else {
+				err = report.Expenses.add(account, -credit)
+			}
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if report.NetIncome, err = money.Sub(report.Revenue.Total, report.Expenses.Total); err != nil {
+			return nil, err
+		}
+
+		reports = append(reports, report)
+	}
+
+	return reports, nil
+}
+
+// balances returns the chart of accounts by code and the balances of the
+// entries created before before by currency and general ledger account,
+// positive for credits.
+func (service *Service) balances(ctx context.Context, before time.Time, currency string) (map[string]db.GlAccount, map[string]map[string]int64, error) {
+	accounts, err := service.store.ListGLAccounts(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	chart := make(map[string]db.GlAccount, len(accounts))
+	for _, account := range accounts {
+		chart[account.Code] = account
+	}
+
+	rows, err := service.store.SumGLBalances(ctx, db.SumGLBalancesParams{
+		Before:          before,
+		Currency:        sql.NullString{String: currency, Valid: currency != ""},
+		SuspenseAccount: CodeSuspense,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	balances := make(map[string]map[string]int64)
+	for _, row := range rows {
+		if _, ok := chart[row.GlAccount]; !ok {
+			return nil, nil, fmt.Errorf("%w: general ledger account %s is not in the chart of accounts", domain.ErrInternal, row.GlAccount)
+		}
+		if balances[row.Currency] == nil {
+			balances[row.Currency] = make(map[string]int64)
+		}
+		if balances[row.Currency][row.GlAccount], err = money.Add(balances[row.Currency][row.GlAccount], row.Balance); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return chart, balances, nil
+}
+
+func (section *Section) add(account db.GlAccount, amount int64) error {
+	if amount == 0 {
+		return nil
+	}
+
+	total, err := money.Add(section.Total, amount)
+	if err != nil {
+		return err
+	}
+
+	section.Total = total
+	section.Items = append(section.Items, Item{Code: account.Code, Name: account.Name, Amount: amount})
+	return nil
+}
+
+func startOf(date time.Time) time.Time {
+	year, month, day := date.UTC().Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func endOf(date time.Time) time.Time {
+	return startOf(date).AddDate(0, 0, 1)
+}
+
+func sortedKeys[V any](values map[string]V) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,129 @@
+package test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/NhutHuyDev/sgbank/internal/domain"
+	"github.com/NhutHuyDev/sgbank/internal/infra/db"
+	mockdb "github.com/NhutHuyDev/sgbank/internal/infra/db/mock"
+	"github.com/NhutHuyDev/sgbank/internal/ledger"
+	"github.com/NhutHuyDev/sgbank/pkg/utils"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+var chart = []db.GlAccount{
+	{Code: "1000", Name: "Cash", Type: ledger.TypeAsset},
+	{Code: "1100", Name: "Customer overdrafts", Type: ledger.TypeAsset},
+	{Code: ledger.CodeSuspense, Name: "Suspense", Type: ledger.TypeAsset},
+	{Code: "2000", Name: "Customer deposits", Type: ledger.TypeLiability},
+	{Code: ledger.CodeRetainedEarnings, Name: "Retained earnings", Type: ledger.TypeEquity},
+	{Code: "4000", Name: "Fee revenue", Type: ledger.TypeRevenue},
+	{Code: "5000", Name: "Interest expense", Type: ledger.TypeExpense},
+}
+
+// balances is a ledger where customers deposited 10000 in cash, paid 30 of
+// fees, were paid 12 of interest and one customer is 500 overdrawn.
+var balances = []db.SumGLBalancesRow{
+	{Currency: utils.USD, GlAccount: "1000", Balance: -10000},
+	{Currency: utils.USD, GlAccount: "1100", Balance: -500},
+	{Currency: utils.USD, GlAccount: "2000", Balance: 10482},
+	{Currency: utils.USD, GlAccount: "4000", Balance: 30},
+	{Currency: utils.USD, GlAccount: "5000", Balance: -12},
+	{Currency: utils.EUR, GlAccount: "2000", Balance: 7},
+	{Currency: utils.EUR, GlAccount: ledger.CodeSuspense, Balance: -7},
+}
+
+func date(value string) time.Time {
+	parsed, err := time.Parse(ledger.DateLayout, value)
+	if err != nil {
+		panic(err)
+	}
+	return parsed
+}
+
+func expectBalances(store *mockdb.MockStore, before time.Time, currency string, rows []db.SumGLBalancesRow) {
+	store.EXPECT().ListGLAccounts(gomock.Any()).Times(1).Return(chart, nil)
+	store.EXPECT().SumGLBalances(gomock.Any(), gomock.Eq(db.SumGLBalancesParams{
+		Before:          before,
+		Currency:        sql.NullString{String: currency, Valid: currency != ""},
+		SuspenseAccount: ledger.CodeSuspense,
+	})).Times(1).Return(rows, nil)
+}
+
+func TestTrialBalances(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	expectBalances(store, date("2024-11-01"), "", balances)
+
+	reports, err := ledger.NewService(store).TrialBalances(context.Background(), date("2024-10-31"), "")
+	require.NoError(t, err)
+	require.Len(t, reports, 2)
+
+	require.Equal(t, utils.EUR, reports[0].Currency)
+	require.Equal(t, []ledger.Line{
+		{Code: ledger.CodeSuspense, Name: "Suspense", Type: ledger.TypeAsset, Debit: 7},
+		{Code: "2000", Name: "Customer deposits", Type: ledger.TypeLiability, Credit: 7},
+	}, reports[0].Lines)
+
+	usd := reports[1]
+	require.Equal(t, utils.USD, usd.Currency)
+	require.Len(t, usd.Lines, 5)
+	require.Equal(t, int64(10512), usd.TotalDebit)
+	require.Equal(t, usd.TotalDebit, usd.TotalCredit)
+}
+
+func TestBalanceSheets(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	expectBalances(store, date("2024-11-01"), utils.USD, balances[:5])
+
+	reports, err := ledger.NewService(store).BalanceSheets(context.Background(), date("2024-10-31"), utils.USD)
+	require.NoError(t, err)
+	require.Len(t, reports, 1)
+
+	sheet := reports[0]
+	require.Equal(t, ledger.Section{
+		Items: []ledger.Item{{Code: "1000", Name: "Cash", Amount: 10000}, {Code: "1100", Name: "Customer overdrafts", Amount: 500}},
+		Total: 10500,
+	}, sheet.Assets)
+	require.Equal(t, int64(10482), sheet.Liabilities.Total)
+	require.Equal(t, ledger.Section{
+		Items: []ledger.Item{{Code: ledger.CodeRetainedEarnings, Name: "Retained earnings", Amount: 18}},
+		Total: 18,
+	}, sheet.Equity)
+	require.Equal(t, sheet.Assets.Total, sheet.Liabilities.Total+sheet.Equity.Total)
+}
+
+func TestProfitAndLoss(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	expectBalances(store, date("2024-11-01"), utils.USD, balances[:5])
+	expectBalances(store, date("2024-10-01"), utils.USD, []db.SumGLBalancesRow{
+		{Currency: utils.USD, GlAccount: "1000", Balance: -10000},
+		{Currency: utils.USD, GlAccount: "2000", Balance: 9990},
+		{Currency: utils.USD, GlAccount: "4000", Balance: 10},
+	})
+
+	reports, err := ledger.NewService(store).ProfitAndLoss(context.Background(), date("2024-10-01"), date("2024-10-31"), utils.USD)
+	require.NoError(t, err)
+	require.Len(t, reports, 1)
+
+	statement := reports[0]
+	require.Equal(t, ledger.Section{Items: []ledger.Item{{Code: "4000", Name: "Fee revenue", Amount: 20}}, Total: 20}, statement.Revenue)
+	require.Equal(t, ledger.Section{Items: []ledger.Item{{Code: "5000", Name: "Interest expense", Amount: 12}}, Total: 12}, statement.Expenses)
+	require.Equal(t, int64(8), statement.NetIncome)
+
+	_, err = ledger.NewService(store).ProfitAndLoss(context.Background(), date("2024-10-31"), date("2024-10-01"), "")
+	var validationErr *domain.ValidationError
+	require.ErrorAs(t, err, &validationErr)
+}
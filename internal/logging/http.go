@@ -0,0 +1,156 @@
+package logging
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+)
+
+// maxLoggedBodySize caps how much of a request or response body ends up in the logs.
+const maxLoggedBodySize = 4 << 10
+
+// GinMiddleware assigns a request ID, stores a request-scoped logger in the
+// request context and writes one access log line per request. The router must
+// have ContextWithFallback enabled so handlers see the logger through *gin.Context.
+func GinMiddleware() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		startTime := time.Now()
+
+		requestID := RequestIDOrNew(ctx.GetHeader(RequestIDHeader))
+		ctx.Header(RequestIDHeader, requestID)
+
+		route := ctx.FullPath()
+		if route == "" {
+			route = ctx.Request.URL.Path
+		}
+
+		reqCtx := NewContext(ctx.Request.Context(), requestID, map[string]interface{}{
+			"protocol":  "HTTP",
+			"method":    ctx.Request.Method,
+			"route":     route,
+			"client_ip": ctx.ClientIP(),
+		})
+		ctx.Request = ctx.Request.WithContext(reqCtx)
+
+		reqBody := peekBody(ctx.Request)
+		rec := &ginBodyRecorder{ResponseWriter: ctx.Writer}
+		ctx.Writer = rec
+
+		ctx.Next()
+
+		statusCode := ctx.Writer.Status()
+		logAccess(FromContext(reqCtx), statusCode, time.Since(startTime), reqBody, rec.body.Bytes())
+	}
+}
+
+// HTTPMiddleware is the net/http counterpart of GinMiddleware, used in front
+// of the grpc-gateway mux.
+func HTTPMiddleware(handler http.Handler, protocol string) http.Handler {
+	return http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		startTime := time.Now()
+
+		requestID := RequestIDOrNew(req.Header.Get(RequestIDHeader))
+		res.Header().Set(RequestIDHeader, requestID)
+
+		reqCtx := NewContext(req.Context(), requestID, map[string]interface{}{
+			"protocol":  protocol,
+			"method":    req.Method,
+			"route":     req.URL.Path,
+			"client_ip": clientIP(req),
+		})
+		req = req.WithContext(reqCtx)
+
+		reqBody := peekBody(req)
+		rec := &ResponseRecorder{
+			ResponseWriter: res,
+			StatusCode:     http.StatusOK,
+		}
+
+		handler.ServeHTTP(rec, req)
+
+		logAccess(FromContext(reqCtx), rec.StatusCode, time.Since(startTime), reqBody, rec.Body)
+	})
+}
+
+func logAccess(logger *zerolog.Logger, statusCode int, duration time.Duration, reqBody []byte, resBody []byte) {
+	event := logger.Info()
+	if statusCode >= http.StatusBadRequest {
+		event = logger.Error().
+			Str("request_body", Redact(reqBody)).
+			Str("body", Redact(resBody))
+	}
+
+	event.Int("status_code", statusCode).
+		Str("status_text", http.StatusText(statusCode)).
+		Dur("duration", duration).
+		Msg("received a HTTP request")
+}
+
+// peekBody reads up to maxLoggedBodySize of the request body and puts it back
+// so handlers still see the full body.
+func peekBody(req *http.Request) []byte {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil
+	}
+
+	head, err := io.ReadAll(io.LimitReader(req.Body, maxLoggedBodySize))
+	if err != nil {
+		return nil
+	}
+
+	req.Body = struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(bytes.NewReader(head), req.Body), req.Body}
+
+	return head
+}
+
+func clientIP(req *http.Request) string {
+	if forwarded := req.Header.Get("X-Forwarded-For"); forwarded != "" {
+		return forwarded
+	}
+
+	return req.RemoteAddr
+}
+
+type ResponseRecorder struct {
+	http.ResponseWriter
+	StatusCode int
+	Body       []byte
+}
+
+func (rec *ResponseRecorder) WriteHeader(statusCode int) {
+	rec.StatusCode = statusCode
+	rec.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (rec *ResponseRecorder) Write(body []byte) (int, error) {
+	if len(rec.Body) < maxLoggedBodySize {
+		rec.Body = append(rec.Body, body...)
+	}
+	return rec.ResponseWriter.Write(body)
+}
+
+type ginBodyRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (rec *ginBodyRecorder) Write(body []byte) (int, error) {
+	if rec.body.Len() < maxLoggedBodySize {
+		rec.body.Write(body)
+	}
+	return rec.ResponseWriter.Write(body)
+}
+
+func (rec *ginBodyRecorder) WriteString(body string) (int, error) {
+	if rec.body.Len() < maxLoggedBodySize {
+		rec.body.WriteString(body)
+	}
+	return rec.ResponseWriter.WriteString(body)
+}
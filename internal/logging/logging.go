@@ -0,0 +1,78 @@
+package logging
+
+import (
+	"context"
+	"unicode"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// RequestIDHeader is honoured on incoming requests and echoed on responses.
+	RequestIDHeader = "X-Request-ID"
+	// RequestIDMetadataKey is the gRPC metadata equivalent of RequestIDHeader.
+	RequestIDMetadataKey = "x-request-id"
+
+	maxRequestIDLength = 128
+)
+
+type requestIDKey struct{}
+
+func init() {
+	// Code running outside a request still logs through the global logger.
+	zerolog.DefaultContextLogger = &log.Logger
+}
+
+// RequestIDOrNew returns the incoming request ID if it is safe to log and
+// echo back, or a fresh one otherwise.
+func RequestIDOrNew(requestID string) string {
+	if requestID == "" || len(requestID) > maxRequestIDLength {
+		return uuid.NewString()
+	}
+
+	for _, r := range requestID {
+		if r > unicode.MaxASCII || !unicode.IsPrint(r) || unicode.IsSpace(r) {
+			return uuid.NewString()
+		}
+	}
+
+	return requestID
+}
+
+// NewContext returns a copy of ctx carrying requestID and a request-scoped
+// logger built from fields.
+func NewContext(ctx context.Context, requestID string, fields map[string]interface{}) context.Context {
+	logger := log.With().
+		Str("request_id", requestID).
+		Fields(fields).
+		Logger()
+
+	ctx = context.WithValue(ctx, requestIDKey{}, requestID)
+	return logger.WithContext(ctx)
+}
+
+// RequestID returns the request ID stored in ctx, if any.
+func RequestID(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDKey{}).(string)
+	return requestID
+}
+
+// FromContext returns the request-scoped logger, falling back to the global one.
+func FromContext(ctx context.Context) *zerolog.Logger {
+	return zerolog.Ctx(ctx)
+}
+
+// SetUsername adds the authenticated user to the request-scoped logger so the
+// access log line and every later log of the request carry it.
+func SetUsername(ctx context.Context, username string) {
+	logger := zerolog.Ctx(ctx)
+	if logger == zerolog.DefaultContextLogger {
+		return
+	}
+
+	logger.UpdateContext(func(c zerolog.Context) zerolog.Context {
+		return c.Str("username", username)
+	})
+}
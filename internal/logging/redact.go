@@ -0,0 +1,61 @@
+package logging
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+const redacted = "[REDACTED]"
+
+var sensitiveKeyParts = []string{"password", "token", "secret", "authorization"}
+
+// Redact masks passwords, tokens and other secrets in a JSON body before it
+// is logged. Bodies that are not JSON are dropped entirely since we cannot
+// tell what they contain.
+func Redact(body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+
+	var value interface{}
+	if err := json.Unmarshal(body, &value); err != nil {
+		return redacted
+	}
+
+	redactValue(value)
+
+	out, err := json.Marshal(value)
+	if err != nil {
+		return redacted
+	}
+
+	return string(out)
+}
+
+func redactValue(value interface{}) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, field := range v {
+			if isSensitiveKey(key) {
+				v[key] = redacted
+				continue
+			}
+			redactValue(field)
+		}
+	case []interface{}:
+		for _, item := range v {
+			redactValue(item)
+		}
+	}
+}
+
+func isSensitiveKey(key string) bool {
+	key = strings.ToLower(key)
+	for _, part := range sensitiveKeyParts {
+		if strings.Contains(key, part) {
+			return true
+		}
+	}
+
+	return false
+}
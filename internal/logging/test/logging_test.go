@@ -0,0 +1,140 @@
+package test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/NhutHuyDev/sgbank/internal/logging"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"github.com/stretchr/testify/require"
+)
+
+func captureLogs(t *testing.T) *bytes.Buffer {
+	var buf bytes.Buffer
+	previous := log.Logger
+	log.Logger = zerolog.New(&buf)
+	t.Cleanup(func() { log.Logger = previous })
+
+	return &buf
+}
+
+func lastLogLine(t *testing.T, buf *bytes.Buffer) map[string]interface{} {
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.NotEmpty(t, lines)
+
+	var entry map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(lines[len(lines)-1]), &entry))
+	return entry
+}
+
+func TestRedact(t *testing.T) {
+	body := `{"username":"alice","password":"secret1","user":{"refresh_token":"v2.local.x","full_name":"Alice"},"items":[{"access_token":"t"}]}`
+
+	redacted := logging.Redact([]byte(body))
+	require.NotContains(t, redacted, "secret1")
+	require.NotContains(t, redacted, "v2.local.x")
+	require.Contains(t, redacted, `"username":"alice"`)
+	require.Contains(t, redacted, `"full_name":"Alice"`)
+	require.Contains(t, redacted, `"access_token":"[REDACTED]"`)
+
+	require.Equal(t, "[REDACTED]", logging.Redact([]byte("password=secret1")))
+	require.Equal(t, "", logging.Redact(nil))
+}
+
+func TestRequestIDOrNew(t *testing.T) {
+	require.Equal(t, "abc-123", logging.RequestIDOrNew("abc-123"))
+	require.NotEmpty(t, logging.RequestIDOrNew(""))
+	require.NotEqual(t, "bad id", logging.RequestIDOrNew("bad id"))
+	require.NotEqual(t, "x\ny", logging.RequestIDOrNew("x\ny"))
+	require.Len(t, logging.RequestIDOrNew(strings.Repeat("a", 200)), 36)
+}
+
+func TestGinMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	testCases := []struct {
+		name          string
+		requestID     string
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder, entry map[string]interface{})
+	}{
+		{
+			name:      "HonorsIncomingRequestID",
+			requestID: "req-42",
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder, entry map[string]interface{}) {
+				require.Equal(t, "req-42", recorder.Header().Get(logging.RequestIDHeader))
+				require.Equal(t, "req-42", entry["request_id"])
+			},
+		},
+		{
+			name: "GeneratesRequestID",
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder, entry map[string]interface{}) {
+				requestID := recorder.Header().Get(logging.RequestIDHeader)
+				require.NotEmpty(t, requestID)
+				require.Equal(t, requestID, entry["request_id"])
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			buf := captureLogs(t)
+
+			router := gin.New()
+			router.ContextWithFallback = true
+			router.Use(logging.GinMiddleware())
+			router.POST("/v1/users/:username", func(ctx *gin.Context) {
+				logging.SetUsername(ctx, ctx.Param("username"))
+				logging.FromContext(ctx).Info().Msg("inside handler")
+				ctx.JSON(http.StatusUnauthorized, gin.H{"error": "nope", "access_token": "leaked"})
+			})
+
+			recorder := httptest.NewRecorder()
+			request := httptest.NewRequest(http.MethodPost, "/v1/users/alice", strings.NewReader(`{"password":"secret1"}`))
+			if tc.requestID != "" {
+				request.Header.Set(logging.RequestIDHeader, tc.requestID)
+			}
+
+			router.ServeHTTP(recorder, request)
+			require.Equal(t, http.StatusUnauthorized, recorder.Code)
+
+			require.Contains(t, buf.String(), `"message":"inside handler"`)
+			require.NotContains(t, buf.String(), "secret1")
+			require.NotContains(t, buf.String(), "leaked")
+
+			entry := lastLogLine(t, buf)
+			require.Equal(t, "error", entry["level"])
+			require.Equal(t, "alice", entry["username"])
+			require.Equal(t, "/v1/users/:username", entry["route"])
+			require.Equal(t, http.MethodPost, entry["method"])
+			require.NotEmpty(t, entry["client_ip"])
+			tc.checkResponse(t, recorder, entry)
+		})
+	}
+}
+
+func TestHTTPMiddleware(t *testing.T) {
+	buf := captureLogs(t)
+
+	handler := logging.HTTPMiddleware(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		require.Equal(t, "req-7", logging.RequestID(req.Context()))
+		res.WriteHeader(http.StatusOK)
+	}), "HTTP gateway")
+
+	recorder := httptest.NewRecorder()
+	request := httptest.NewRequest(http.MethodPost, "/v1/login_user", strings.NewReader(`{}`))
+	request.Header.Set(logging.RequestIDHeader, "req-7")
+
+	handler.ServeHTTP(recorder, request)
+	require.Equal(t, "req-7", recorder.Header().Get(logging.RequestIDHeader))
+
+	entry := lastLogLine(t, buf)
+	require.Equal(t, "info", entry["level"])
+	require.Equal(t, "req-7", entry["request_id"])
+	require.Equal(t, "/v1/login_user", entry["route"])
+}
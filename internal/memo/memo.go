@@ -0,0 +1,103 @@
+package memo
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/NhutHuyDev/sgbank/internal/domain"
+)
+
+const (
+	MaxDescriptionLength       = 140
+	MaxExternalReferenceLength = 35
+	MerchantCategoryLength     = 4
+)
+
+// referenceSymbols are the punctuation characters allowed in an external
+// reference besides letters, digits and spaces: the SWIFT character set,
+// so references survive a round trip through other payment networks.
+const referenceSymbols = "/-?:().,'+"
+
+// Fields are the free-text fields a transfer and its entries carry: the
+// description shown on statements, the payer's external reference and the
+// merchant category code.
+type Fields struct {
+	Description       string `json:"description"`
+	ExternalReference string `json:"external_reference"`
+	MerchantCategory  string `json:"merchant_category"`
+}
+
+// Normalize trims surrounding spaces from every field.
+func (fields Fields) Normalize() Fields {
+	return Fields{
+		Description:       strings.TrimSpace(fields.Description),
+		ExternalReference: strings.TrimSpace(fields.ExternalReference),
+		MerchantCategory:  strings.TrimSpace(fields.MerchantCategory),
+	}
+}
+
+// Validate reports every invalid field. prefix is prepended to the field
+// names, e.g. "rows[3]." for a row of a batch.
+func (fields Fields) Validate(prefix string) []domain.FieldViolation {
+	var violations []domain.FieldViolation
+
+	if description := ValidateDescription(fields.Description); description != "" {
+		violations = append(violations, domain.FieldViolation{Field: prefix + "description", Description: description})
+	}
+
+	if description := validateExternalReference(fields.ExternalReference); description != "" {
+		violations = append(violations, domain.FieldViolation{Field: prefix + "external_reference", Description: description})
+	}
+
+	if description := ValidateMerchantCategory(fields.MerchantCategory); description != "" {
+		violations = append(violations, domain.FieldViolation{Field: prefix + "merchant_category", Description: description})
+	}
+
+	return violations
+}
+
+// ValidateDescription checks a free-text memo and returns why it is
+// invalid, or "" when it is valid. Any printable character is allowed;
+// control characters such as newlines are not.
+func ValidateDescription(description string) string {
+	if len([]rune(description)) > MaxDescriptionLength {
+		return fmt.Sprintf("must be at most %d characters", MaxDescriptionLength)
+	}
+
+	for _, r := range description {
+		if !unicode.IsPrint(r) {
+			return "must only contain printable characters"
+		}
+	}
+
+	return ""
+}
+
+func validateExternalReference(reference string) string {
+	if len(reference) > MaxExternalReferenceLength {
+		return fmt.Sprintf("must be at most %d characters", MaxExternalReferenceLength)
+	}
+
+	for _, r := range reference {
+		if r > unicode.MaxASCII || !(unicode.IsLetter(r) || unicode.IsDigit(r) || r == ' ' || strings.ContainsRune(referenceSymbols, r)) {
+			return fmt.Sprintf("must only contain letters, digits, spaces and %s", referenceSymbols)
+		}
+	}
+
+	return ""
+}
+
+// ValidateMerchantCategory checks a merchant category code the way
+// ValidateDescription checks a description. The empty code is valid.
+func ValidateMerchantCategory(code string) string {
+	if code == "" {
+		return ""
+	}
+
+	if len(code) != MerchantCategoryLength || strings.Trim(code, "0123456789") != "" {
+		return fmt.Sprintf("must be a %d-digit ISO 18245 merchant category code", MerchantCategoryLength)
+	}
+
+	return ""
+}
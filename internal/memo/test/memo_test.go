@@ -0,0 +1,63 @@
+package test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/NhutHuyDev/sgbank/internal/memo"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidate(t *testing.T) {
+	testCases := []struct {
+		name    string
+		fields  memo.Fields
+		prefix  string
+		invalid []string
+	}{
+		{
+			name:   "Empty",
+			fields: memo.Fields{},
+		},
+		{
+			name:   "Valid",
+			fields: memo.Fields{Description: "Déjeuner chez Marie 🥐", ExternalReference: "INV-2024/0042 (Q1)", MerchantCategory: "5812"},
+		},
+		{
+			name:    "DescriptionTooLong",
+			fields:  memo.Fields{Description: strings.Repeat("é", memo.MaxDescriptionLength+1)},
+			invalid: []string{"description"},
+		},
+		{
+			name:    "ControlCharacters",
+			fields:  memo.Fields{Description: "line\nbreak"},
+			invalid: []string{"description"},
+		},
+		{
+			name:    "InvalidReferenceAndCode",
+			fields:  memo.Fields{ExternalReference: "INV#42", MerchantCategory: "58a2"},
+			prefix:  "p.",
+			invalid: []string{"p.external_reference", "p.merchant_category"},
+		},
+		{
+			name:    "NonASCIIReference",
+			fields:  memo.Fields{ExternalReference: "FACTURE-É"},
+			invalid: []string{"external_reference"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var invalid []string
+			for _, violation := range tc.fields.Validate(tc.prefix) {
+				invalid = append(invalid, violation.Field)
+			}
+			require.Equal(t, tc.invalid, invalid)
+		})
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	fields := memo.Fields{Description: "  rent ", ExternalReference: " REF1", MerchantCategory: "6513 "}.Normalize()
+	require.Equal(t, memo.Fields{Description: "rent", ExternalReference: "REF1", MerchantCategory: "6513"}, fields)
+}
@@ -0,0 +1,84 @@
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+)
+
+const (
+	ServerGin     = "gin"
+	ServerGateway = "gateway"
+
+	unmatchedRoute = "unmatched"
+)
+
+// GinMiddleware records request count and latency per route template.
+func GinMiddleware() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		startTime := time.Now()
+		ctx.Next()
+
+		route := ctx.FullPath()
+		if route == "" {
+			route = unmatchedRoute
+		}
+
+		observeHTTPRequest(ServerGin, ctx.Request.Method, route, ctx.Writer.Status(), time.Since(startTime))
+	}
+}
+
+// GatewayMiddleware records request count and latency for grpc-gateway routes.
+// It only sees requests that matched a route, so the label set stays bounded.
+func GatewayMiddleware() runtime.Middleware {
+	return func(next runtime.HandlerFunc) runtime.HandlerFunc {
+		return func(res http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+			startTime := time.Now()
+			rec := &statusRecorder{ResponseWriter: res, statusCode: http.StatusOK}
+			next(rec, req, pathParams)
+
+			route := routeTemplate(req.URL.Path, pathParams)
+			observeHTTPRequest(ServerGateway, req.Method, route, rec.statusCode, time.Since(startTime))
+		}
+	}
+}
+
+func observeHTTPRequest(server string, method string, route string, statusCode int, duration time.Duration) {
+	status := strconv.Itoa(statusCode)
+	httpRequestsTotal.WithLabelValues(server, method, route, status).Inc()
+	httpRequestDuration.WithLabelValues(server, method, route, status).Observe(duration.Seconds())
+}
+
+// routeTemplate turns /v1/accounts/42 back into /v1/accounts/{id} so path
+// parameters don't blow up the label cardinality.
+func routeTemplate(path string, pathParams map[string]string) string {
+	if len(pathParams) == 0 {
+		return path
+	}
+
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		for name, value := range pathParams {
+			if segment != "" && segment == value {
+				segments[i] = "{" + name + "}"
+				break
+			}
+		}
+	}
+
+	return strings.Join(segments, "/")
+}
+
+type statusRecorder struct {
+	http.ResponseWriter
+	statusCode int
+}
+
+func (rec *statusRecorder) WriteHeader(statusCode int) {
+	rec.statusCode = statusCode
+	rec.ResponseWriter.WriteHeader(statusCode)
+}
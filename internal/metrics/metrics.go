@@ -0,0 +1,128 @@
+package metrics
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "sgbank"
+
+// Registry holds every sgbank collector and is what /metrics exposes.
+var Registry = prometheus.NewRegistry()
+
+var factory = promauto.With(Registry)
+
+var (
+	httpRequestsTotal = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "requests_total",
+		Help:      "Number of HTTP requests by server, method, route and status.",
+	}, []string{"server", "method", "route", "status"})
+
+	httpRequestDuration = factory.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "request_duration_seconds",
+		Help:      "Latency of HTTP requests by server, method, route and status.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"server", "method", "route", "status"})
+
+	grpcRequestsTotal = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "grpc",
+		Name:      "requests_total",
+		Help:      "Number of gRPC requests by method and status code.",
+	}, []string{"method", "code"})
+
+	grpcRequestDuration = factory.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "grpc",
+		Name:      "request_duration_seconds",
+		Help:      "Latency of gRPC requests by method and status code.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "code"})
+
+	transferTxDuration = factory.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "transfer_tx",
+		Name:      "duration_seconds",
+		Help:      "Duration of the transactions that post transfers, such as TransferTx, by transaction and outcome.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"tx", "outcome"})
+
+	transferTxRetries = factory.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "transfer_tx",
+		Name:      "retries_total",
+		Help:      "Number of attempts of the transactions that post transfers retried after a retryable database error.",
+	})
+
+	transferTxFailures = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "transfer_tx",
+		Name:      "failures_total",
+		Help:      "Number of failed transactions that post transfers by transaction and reason.",
+	}, []string{"tx", "reason"})
+
+	transfersTotal = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "business",
+		Name:      "transfers_total",
+		Help:      "Number of completed transfers by currency.",
+	}, []string{"currency"})
+
+	transferVolume = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "business",
+		Name:      "transfer_volume_total",
+		Help:      "Amount moved by completed transfers, in minor units, by currency.",
+	}, []string{"currency"})
+
+	transfersInFlight = factory.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "business",
+		Name:      "transfers_in_flight",
+		Help:      "Number of transfers currently being executed.",
+	})
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+}
+
+// Handler serves the metrics in Registry in the Prometheus text format.
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Registry, promhttp.HandlerOpts{Registry: Registry})
+}
+
+// RegisterDBStats exposes the connection pool statistics of conn.
+func RegisterDBStats(conn *sql.DB) error {
+	err := Registry.Register(collectors.NewDBStatsCollector(conn, namespace))
+	if err != nil {
+		var alreadyRegistered prometheus.AlreadyRegisteredError
+		if errors.As(err, &alreadyRegistered) {
+			return nil
+		}
+		return err
+	}
+
+	return nil
+}
+
+// AddTransferTxRetries records retried attempts of a transaction that posts
+// transfers.
+func AddTransferTxRetries(retries int) {
+	if retries > 0 {
+		transferTxRetries.Add(float64(retries))
+	}
+}
@@ -0,0 +1,148 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/NhutHuyDev/sgbank/internal/domain"
+	"github.com/NhutHuyDev/sgbank/internal/infra/db"
+)
+
+// Store decorates a db.Store with metrics of every transaction that posts
+// transfers, and business metrics of the transfers they post.
+type Store struct {
+	db.Store
+}
+
+func NewStore(store db.Store) db.Store {
+	return &Store{Store: store}
+}
+
+func (store *Store) TransferTx(ctx context.Context, arg db.TransferTxParams) (db.TransferTxResult, error) {
+	return observeTx("TransferTx", func() (db.TransferTxResult, error) {
+		return store.Store.TransferTx(ctx, arg)
+	}, func(result db.TransferTxResult) ([]*db.TransferTxResult, int) {
+		return []*db.TransferTxResult{&result}, result.Retries
+	})
+}
+
+func (store *Store) PayPaymentRequestTx(ctx context.Context, arg db.PayPaymentRequestTxParams) (db.PayPaymentRequestTxResult, error) {
+	return observeTx("PayPaymentRequestTx", func() (db.PayPaymentRequestTxResult, error) {
+		return store.Store.PayPaymentRequestTx(ctx, arg)
+	}, func(result db.PayPaymentRequestTxResult) ([]*db.TransferTxResult, int) {
+		return []*db.TransferTxResult{&result.TransferTxResult}, result.Retries
+	})
+}
+
+func (store *Store) PostTransferBatchItemTx(ctx context.Context, arg db.PostTransferBatchItemTxParams) (db.PostTransferBatchItemTxResult, error) {
+	return observeTx("PostTransferBatchItemTx", func() (db.PostTransferBatchItemTxResult, error) {
+		return store.Store.PostTransferBatchItemTx(ctx, arg)
+	}, func(result db.PostTransferBatchItemTxResult) ([]*db.TransferTxResult, int) {
+		return []*db.TransferTxResult{&result.TransferTxResult}, result.Retries
+	})
+}
+
+func (store *Store) PostTransferBatchTx(ctx context.Context, arg db.PostTransferBatchTxParams) (db.PostTransferBatchTxResult, error) {
+	return observeTx("PostTransferBatchTx", func() (db.PostTransferBatchTxResult, error) {
+		return store.Store.PostTransferBatchTx(ctx, arg)
+	}, func(result db.PostTransferBatchTxResult) ([]*db.TransferTxResult, int) {
+		transfers := make([]*db.TransferTxResult, len(result.Transfers))
+		for i := range result.Transfers {
+			transfers[i] = &result.Transfers[i]
+		}
+		return transfers, result.Retries
+	})
+}
+
+func (store *Store) ApproveHeldTransferTx(ctx context.Context, arg db.ApproveHeldTransferTxParams) (db.ApproveHeldTransferTxResult, error) {
+	return observeTx("ApproveHeldTransferTx", func() (db.ApproveHeldTransferTxResult, error) {
+		return store.Store.ApproveHeldTransferTx(ctx, arg)
+	}, func(result db.ApproveHeldTransferTxResult) ([]*db.TransferTxResult, int) {
+		return []*db.TransferTxResult{&result.TransferTxResult}, result.Retries
+	})
+}
+
+func (store *Store) CreateEscrowTx(ctx context.Context, arg db.CreateEscrowTxParams) (db.CreateEscrowTxResult, error) {
+	return observeTx("CreateEscrowTx", func() (db.CreateEscrowTxResult, error) {
+		return store.Store.CreateEscrowTx(ctx, arg)
+	}, func(result db.CreateEscrowTxResult) ([]*db.TransferTxResult, int) {
+		return []*db.TransferTxResult{&result.Funding}, result.Funding.Retries
+	})
+}
+
+func (store *Store) SettleEscrowTx(ctx context.Context, arg db.SettleEscrowTxParams) (db.SettleEscrowTxResult, error) {
+	return observeTx("SettleEscrowTx", func() (db.SettleEscrowTxResult, error) {
+		return store.Store.SettleEscrowTx(ctx, arg)
+	}, func(result db.SettleEscrowTxResult) ([]*db.TransferTxResult, int) {
+		return []*db.TransferTxResult{result.Transfer}, 0
+	})
+}
+
+func (store *Store) OpenDisputeTx(ctx context.Context, arg db.OpenDisputeTxParams) (db.OpenDisputeTxResult, error) {
+	return observeTx("OpenDisputeTx", func() (db.OpenDisputeTxResult, error) {
+		return store.Store.OpenDisputeTx(ctx, arg)
+	}, func(result db.OpenDisputeTxResult) ([]*db.TransferTxResult, int) {
+		return []*db.TransferTxResult{result.ProvisionalCredit}, 0
+	})
+}
+
+func (store *Store) TransitionDisputeTx(ctx context.Context, arg db.TransitionDisputeTxParams) (db.TransitionDisputeTxResult, error) {
+	return observeTx("TransitionDisputeTx", func() (db.TransitionDisputeTxResult, error) {
+		return store.Store.TransitionDisputeTx(ctx, arg)
+	}, func(result db.TransitionDisputeTxResult) ([]*db.TransferTxResult, int) {
+		return []*db.TransferTxResult{result.Transfer}, 0
+	})
+}
+
+func (store *Store) CapitalizeInterestTx(ctx context.Context, arg db.CapitalizeInterestTxParams) (db.CapitalizeInterestTxResult, error) {
+	return observeTx("CapitalizeInterestTx", func() (db.CapitalizeInterestTxResult, error) {
+		return store.Store.CapitalizeInterestTx(ctx, arg)
+	}, func(result db.CapitalizeInterestTxResult) ([]*db.TransferTxResult, int) {
+		return []*db.TransferTxResult{result.Transfer}, 0
+	})
+}
+
+// observeTx runs tx, a transaction that posts transfers, recording its
+// duration, failures and retries. posted returns the transfers a result
+// posted, nil where it posted none, and its retries.
+func observeTx[R any](tx string, run func() (R, error), posted func(R) ([]*db.TransferTxResult, int)) (R, error) {
+	transfersInFlight.Inc()
+	defer transfersInFlight.Dec()
+
+	startTime := time.Now()
+	result, err := run()
+	duration := time.Since(startTime)
+
+	transfers, retries := posted(result)
+	AddTransferTxRetries(retries)
+
+	if err != nil {
+		transferTxDuration.WithLabelValues(tx, "failure").Observe(duration.Seconds())
+		transferTxFailures.WithLabelValues(tx, transferFailureReason(err)).Inc()
+		return result, err
+	}
+
+	transferTxDuration.WithLabelValues(tx, "success").Observe(duration.Seconds())
+
+	for _, transfer := range transfers {
+		if transfer == nil {
+			continue
+		}
+
+		currency := transfer.FromAccount.Currency
+		transfersTotal.WithLabelValues(currency).Inc()
+		transferVolume.WithLabelValues(currency).Add(float64(transfer.Transfer.Amount))
+	}
+
+	return result, nil
+}
+
+func transferFailureReason(err error) string {
+	switch {
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		return "canceled"
+	default:
+		return domain.Lookup(err).Code
+	}
+}
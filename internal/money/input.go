@@ -0,0 +1,93 @@
+package money
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+// Input is an amount in a request, before its currency is known. A JSON
+// number is a whole number of minor units, as the API has always accepted,
+// so 1250 is 12.50 USD; a JSON string is a decimal in major units such as
+// "12.50", with at most as many decimal places as the currency has. Request
+// DTOs take amounts as an Input and Resolve it in the requested currency.
+type Input struct {
+	minor     int64
+	decimal   string
+	isDecimal bool
+	isSet     bool
+}
+
+// MinorInput returns an Input of minor units.
+func MinorInput(minor int64) Input {
+	return Input{minor: minor, isSet: true}
+}
+
+// DecimalInput returns an Input of a decimal in major units.
+func DecimalInput(decimal string) Input {
+	return Input{decimal: decimal, isDecimal: true, isSet: true}
+}
+
+// QueryInput reads an amount from a query string, which has no JSON types:
+// a whole number is minor units, as the API has always accepted, and
+// anything else a decimal in major units such as "12.50". An empty value is
+// not set.
+func QueryInput(value string) Input {
+	if value == "" {
+		return Input{}
+	}
+
+	if minor, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return MinorInput(minor)
+	}
+
+	return DecimalInput(value)
+}
+
+// IsSet reports whether the request contained the amount.
+func (input Input) IsSet() bool {
+	return input.isSet
+}
+
+func (input *Input) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		*input = Input{}
+		return nil
+	}
+
+	if len(data) > 0 && data[0] == '"' {
+		var decimal string
+		if err := json.Unmarshal(data, &decimal); err != nil {
+			return err
+		}
+
+		*input = DecimalInput(decimal)
+		return nil
+	}
+
+	minor, err := strconv.ParseInt(string(data), 10, 64)
+	if err != nil {
+		return fmt.Errorf("%w: a number must be a whole number of minor units, use a string for a decimal", ErrInvalidAmount)
+	}
+
+	*input = MinorInput(minor)
+	return nil
+}
+
+func (input Input) MarshalJSON() ([]byte, error) {
+	if input.isDecimal {
+		return json.Marshal(input.decimal)
+	}
+
+	return json.Marshal(input.minor)
+}
+
+// Resolve returns the amount input denotes in currency.
+func (input Input) Resolve(currency Currency) (Amount, error) {
+	if input.isDecimal {
+		return Parse(input.decimal, currency)
+	}
+
+	return New(input.minor, currency), nil
+}
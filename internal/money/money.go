@@ -0,0 +1,198 @@
+// Package money represents amounts as an integer count of a currency's
+// minor unit, the way balances and transfers are stored, and converts them
+// to and from the decimal strings users read and write.
+package money
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// MaxExponent is the largest minor-unit exponent ISO 4217 assigns.
+const MaxExponent = 4
+
+var (
+	ErrOverflow         = errors.New("amount does not fit in 64 bits")
+	ErrCurrencyMismatch = errors.New("amounts are in different currencies")
+	ErrInvalidAmount    = errors.New("invalid amount")
+)
+
+// Currency is an ISO 4217 currency. Exponent is the number of decimal
+// places of its minor unit: 2 for USD cents, 0 for JPY, 3 for KWD fils.
+type Currency struct {
+	Code     string `json:"code"`
+	Exponent int    `json:"exponent"`
+}
+
+// Amount is Minor units of Currency.
+type Amount struct {
+	Minor    int64
+	Currency Currency
+}
+
+func New(minor int64, currency Currency) Amount {
+	return Amount{Minor: minor, Currency: currency}
+}
+
+// Add returns amount plus other, which must be in the same currency.
+func (amount Amount) Add(other Amount) (Amount, error) {
+	if amount.Currency.Code != other.Currency.Code {
+		return Amount{}, ErrCurrencyMismatch
+	}
+
+	sum, err := Add(amount.Minor, other.Minor)
+	if err != nil {
+		return Amount{}, err
+	}
+
+	return New(sum, amount.Currency), nil
+}
+
+// Sub returns amount minus other, which must be in the same currency.
+func (amount Amount) Sub(other Amount) (Amount, error) {
+	if amount.Currency.Code != other.Currency.Code {
+		return Amount{}, ErrCurrencyMismatch
+	}
+
+	difference, err := Sub(amount.Minor, other.Minor)
+	if err != nil {
+		return Amount{}, err
+	}
+
+	return New(difference, amount.Currency), nil
+}
+
+// Mul returns amount times factor.
+func (amount Amount) Mul(factor int64) (Amount, error) {
+	product, err := Mul(amount.Minor, factor)
+	if err != nil {
+		return Amount{}, err
+	}
+
+	return New(product, amount.Currency), nil
+}
+
+// Neg returns -amount. The most negative int64 has no negation.
+func (amount Amount) Neg() (Amount, error) {
+	if amount.Minor == math.MinInt64 {
+		return Amount{}, ErrOverflow
+	}
+
+	return New(-amount.Minor, amount.Currency), nil
+}
+
+// Add returns a+b, or ErrOverflow when the sum does not fit in an int64.
+func Add(a, b int64) (int64, error) {
+	if (b > 0 && a > math.MaxInt64-b) || (b < 0 && a < math.MinInt64-b) {
+		return 0, ErrOverflow
+	}
+
+	return a + b, nil
+}
+
+// Sub returns a-b, or ErrOverflow when the difference does not fit in an int64.
+func Sub(a, b int64) (int64, error) {
+	if (b < 0 && a > math.MaxInt64+b) || (b > 0 && a < math.MinInt64+b) {
+		return 0, ErrOverflow
+	}
+
+	return a - b, nil
+}
+
+// Mul returns a*b, or ErrOverflow when the product does not fit in an int64.
+func Mul(a, b int64) (int64, error) {
+	if a == 0 || b == 0 {
+		return 0, nil
+	}
+
+	product := a * b
+	if product/b != a || (a == -1 && b == math.MinInt64) || (b == -1 && a == math.MinInt64) {
+		return 0, ErrOverflow
+	}
+
+	return product, nil
+}
+
+// Parse reads a decimal string such as "12.50" or "-3" in major units of
+// currency. It rejects more fraction digits than the currency's minor unit
+// has, exponents, thousands separators and amounts that overflow.
+func Parse(value string, currency Currency) (Amount, error) {
+	text := value
+	negative := false
+	switch {
+	case strings.HasPrefix(text, "-"):
+		negative = true
+		text = text[1:]
+	case strings.HasPrefix(text, "+"):
+		text = text[1:]
+	}
+
+	whole, fraction, hasPoint := strings.Cut(text, ".")
+	if whole == "" || (hasPoint && fraction == "") || !isDigits(whole) || !isDigits(fraction) {
+		return Amount{}, fmt.Errorf("%w: %q is not a decimal number", ErrInvalidAmount, value)
+	}
+
+	if len(fraction) > currency.Exponent {
+		return Amount{}, fmt.Errorf("%w: %s allows at most %d decimal places", ErrInvalidAmount, currency.Code, currency.Exponent)
+	}
+
+	digits := strings.TrimLeft(whole+fraction+strings.Repeat("0", currency.Exponent-len(fraction)), "0")
+	if digits == "" {
+		return New(0, currency), nil
+	}
+
+	if negative {
+		digits = "-" + digits
+	}
+
+	minor, err := strconv.ParseInt(digits, 10, 64)
+	if err != nil {
+		return Amount{}, ErrOverflow
+	}
+
+	return New(minor, currency), nil
+}
+
+func isDigits(text string) bool {
+	for _, r := range text {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// String formats amount as a decimal in major units with exactly as many
+// fraction digits as the currency's minor unit, e.g. "-12.50" or "1000".
+func (amount Amount) String() string {
+	return Format(amount.Minor, amount.Currency.Exponent)
+}
+
+// Format formats minor units with exponent decimal places.
+func Format(minor int64, exponent int) string {
+	digits := strconv.FormatUint(absUint(minor), 10)
+	if exponent > 0 {
+		if len(digits) <= exponent {
+			digits = strings.Repeat("0", exponent-len(digits)+1) + digits
+		}
+		digits = digits[:len(digits)-exponent] + "." + digits[len(digits)-exponent:]
+	}
+
+	if minor < 0 {
+		return "-" + digits
+	}
+
+	return digits
+}
+
+func absUint(minor int64) uint64 {
+	if minor < 0 {
+		return uint64(-(minor + 1)) + 1
+	}
+
+	return uint64(minor)
+}
@@ -0,0 +1,144 @@
+package test
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/NhutHuyDev/sgbank/internal/money"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	usd = money.Currency{Code: "USD", Exponent: 2}
+	jpy = money.Currency{Code: "JPY", Exponent: 0}
+	kwd = money.Currency{Code: "KWD", Exponent: 3}
+)
+
+func TestParse(t *testing.T) {
+	testCases := []struct {
+		value    string
+		currency money.Currency
+		minor    int64
+	}{
+		{value: "12.50", currency: usd, minor: 1250},
+		{value: "12.5", currency: usd, minor: 1250},
+		{value: "12", currency: usd, minor: 1200},
+		{value: "-0.07", currency: usd, minor: -7},
+		{value: "+3", currency: usd, minor: 300},
+		{value: "0.00", currency: usd, minor: 0},
+		{value: "1500", currency: jpy, minor: 1500},
+		{value: "1.234", currency: kwd, minor: 1234},
+		{value: "92233720368547758.07", currency: usd, minor: math.MaxInt64},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.value, func(t *testing.T) {
+			amount, err := money.Parse(tc.value, tc.currency)
+			require.NoError(t, err)
+			require.Equal(t, money.New(tc.minor, tc.currency), amount)
+		})
+	}
+
+	for _, value := range []string{"", "-", ".5", "5.", "1,000", "1e3", "12.345", "0x10", " 1"} {
+		_, err := money.Parse(value, usd)
+		require.ErrorIs(t, err, money.ErrInvalidAmount, value)
+	}
+
+	_, err := money.Parse("1.5", jpy)
+	require.ErrorIs(t, err, money.ErrInvalidAmount)
+
+	_, err = money.Parse("92233720368547758.08", usd)
+	require.ErrorIs(t, err, money.ErrOverflow)
+}
+
+func TestFormat(t *testing.T) {
+	require.Equal(t, "12.50", money.New(1250, usd).String())
+	require.Equal(t, "0.07", money.New(7, usd).String())
+	require.Equal(t, "-0.07", money.New(-7, usd).String())
+	require.Equal(t, "0.000", money.New(0, kwd).String())
+	require.Equal(t, "1.234", money.New(1234, kwd).String())
+	require.Equal(t, "1500", money.New(1500, jpy).String())
+	require.Equal(t, "-92233720368547758.08", money.New(math.MinInt64, usd).String())
+
+	for _, minor := range []int64{0, 1, -1, 99, 100, 123456789, math.MaxInt64, math.MinInt64 + 1} {
+		for _, currency := range []money.Currency{usd, jpy, kwd} {
+			amount := money.New(minor, currency)
+			parsed, err := money.Parse(amount.String(), currency)
+			require.NoError(t, err)
+			require.Equal(t, amount, parsed)
+		}
+	}
+}
+
+func TestArithmetic(t *testing.T) {
+	sum, err := money.New(150, usd).Add(money.New(-200, usd))
+	require.NoError(t, err)
+	require.Equal(t, money.New(-50, usd), sum)
+
+	difference, err := money.New(150, usd).Sub(money.New(200, usd))
+	require.NoError(t, err)
+	require.Equal(t, money.New(-50, usd), difference)
+
+	product, err := money.New(-25, usd).Mul(4)
+	require.NoError(t, err)
+	require.Equal(t, money.New(-100, usd), product)
+
+	_, err = money.New(1, usd).Add(money.New(1, jpy))
+	require.ErrorIs(t, err, money.ErrCurrencyMismatch)
+
+	_, err = money.New(math.MaxInt64, usd).Add(money.New(1, usd))
+	require.ErrorIs(t, err, money.ErrOverflow)
+
+	_, err = money.New(math.MinInt64, usd).Sub(money.New(1, usd))
+	require.ErrorIs(t, err, money.ErrOverflow)
+
+	_, err = money.New(math.MaxInt64/2+1, usd).Mul(2)
+	require.ErrorIs(t, err, money.ErrOverflow)
+
+	_, err = money.New(math.MinInt64, usd).Mul(-1)
+	require.ErrorIs(t, err, money.ErrOverflow)
+
+	_, err = money.New(math.MinInt64, usd).Neg()
+	require.ErrorIs(t, err, money.ErrOverflow)
+}
+
+func TestInput(t *testing.T) {
+	var req struct {
+		Amount money.Input `json:"amount"`
+	}
+
+	require.NoError(t, json.Unmarshal([]byte(`{"amount": 1250}`), &req))
+	amount, err := req.Amount.Resolve(usd)
+	require.NoError(t, err)
+	require.Equal(t, money.New(1250, usd), amount)
+
+	require.NoError(t, json.Unmarshal([]byte(`{"amount": "12.50"}`), &req))
+	amount, err = req.Amount.Resolve(usd)
+	require.NoError(t, err)
+	require.Equal(t, money.New(1250, usd), amount)
+
+	_, err = req.Amount.Resolve(jpy)
+	require.ErrorIs(t, err, money.ErrInvalidAmount)
+
+	require.Error(t, json.Unmarshal([]byte(`{"amount": 12.5}`), &req))
+
+	req.Amount = money.Input{}
+	require.NoError(t, json.Unmarshal([]byte(`{}`), &req))
+	require.False(t, req.Amount.IsSet())
+}
+
+func TestQueryInput(t *testing.T) {
+	amount, err := money.QueryInput("1250").Resolve(usd)
+	require.NoError(t, err)
+	require.Equal(t, money.New(1250, usd), amount)
+
+	amount, err = money.QueryInput("12.50").Resolve(usd)
+	require.NoError(t, err)
+	require.Equal(t, money.New(1250, usd), amount)
+
+	_, err = money.QueryInput("12.5x").Resolve(usd)
+	require.ErrorIs(t, err, money.ErrInvalidAmount)
+
+	require.False(t, money.QueryInput("").IsSet())
+}
@@ -0,0 +1,46 @@
+package notify
+
+import (
+	"context"
+
+	"github.com/NhutHuyDev/sgbank/internal/infra/db"
+	"github.com/NhutHuyDev/sgbank/internal/logging"
+)
+
+// Notification is a message for one user, e.g. "alice asked you for 10.00 USD".
+// Kind is a stable identifier clients can switch on.
+type Notification struct {
+	Username string
+	Kind     string
+	Message  string
+}
+
+// Notifier delivers notifications. Delivery is best effort: a failed
+// notification never fails the operation that emitted it.
+type Notifier interface {
+	Notify(ctx context.Context, notification Notification)
+}
+
+// StoreNotifier writes notifications to the user's inbox in the notifications
+// table, which clients read through GET /v1/notifications.
+type StoreNotifier struct {
+	store db.Store
+}
+
+func NewStoreNotifier(store db.Store) *StoreNotifier {
+	return &StoreNotifier{store: store}
+}
+
+func (notifier *StoreNotifier) Notify(ctx context.Context, notification Notification) {
+	_, err := notifier.store.CreateNotification(ctx, db.CreateNotificationParams{
+		Username: notification.Username,
+		Kind:     notification.Kind,
+		Message:  notification.Message,
+	})
+	if err != nil {
+		logging.FromContext(ctx).Error().Err(err).
+			Str("username", notification.Username).
+			Str("kind", notification.Kind).
+			Msg("cannot deliver notification")
+	}
+}
@@ -0,0 +1,150 @@
+package overdraft
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/NhutHuyDev/sgbank/internal/domain"
+	"github.com/NhutHuyDev/sgbank/internal/infra/db"
+	"github.com/NhutHuyDev/sgbank/internal/notify"
+)
+
+const (
+	MaxReasonLength = 200
+	PageSize        = 100
+)
+
+const (
+	KindEntered = "overdraft.entered"
+	KindLeft    = "overdraft.left"
+)
+
+// Service manages overdraft limits. An admin approves a limit per account;
+// TransferTx then lets the balance go down to minus the limit, records an
+// event whenever an account enters or leaves its overdraft, and the
+// interest job charges the product's overdraft rate on overdrawn balances.
+type Service struct {
+	store    db.Store
+	notifier notify.Notifier
+}
+
+func NewService(store db.Store, notifier notify.Notifier) *Service {
+	return &Service{store: store, notifier: notifier}
+}
+
+type ApproveParams struct {
+	Admin          string
+	AccountID      int64
+	OverdraftLimit int64
+	Reason         string
+}
+
+// Approve sets the overdraft limit of a customer account; zero withdraws
+// the overdraft. Lowering a limit below the current overdraft does not
+// move money, but blocks further debits until the balance is back within
+// the limit.
+func (service *Service) Approve(ctx context.Context, arg ApproveParams) (db.ApproveOverdraftTxResult, error) {
+	arg.Reason = strings.TrimSpace(arg.Reason)
+
+	var violations []domain.FieldViolation
+	if arg.OverdraftLimit < 0 {
+		violations = append(violations, domain.FieldViolation{Field: "overdraft_limit", Description: "must not be negative"})
+	}
+	if len(arg.Reason) > MaxReasonLength {
+		violations = append(violations, domain.FieldViolation{
+			Field:       "reason",
+			Description: fmt.Sprintf("must be at most %d characters", MaxReasonLength),
+		})
+	}
+	if violations != nil {
+		return db.ApproveOverdraftTxResult{}, domain.NewValidationError(violations...)
+	}
+
+	account, err := service.store.GetAccount(ctx, arg.AccountID)
+	if err != nil {
+		return db.ApproveOverdraftTxResult{}, err
+	}
+
+	product, err := service.store.GetAccountProduct(ctx, account.Product)
+	if err != nil {
+		return db.ApproveOverdraftTxResult{}, err
+	}
+	if product.IsInternal {
+		return db.ApproveOverdraftTxResult{}, fmt.Errorf("%w: account [%d] belongs to the bank", domain.ErrInvalidArgument, account.ID)
+	}
+
+	return service.store.ApproveOverdraftTx(ctx, db.ApproveOverdraftTxParams{
+		AccountID:      account.ID,
+		OverdraftLimit: arg.OverdraftLimit,
+		ApprovedBy:     arg.Admin,
+		Reason:         arg.Reason,
+	})
+}
+
+// Report is a page of the customer accounts in overdraft and the totals of
+// every currency.
+type Report struct {
+	Accounts []db.Account                `json:"accounts"`
+	Totals   []db.SummarizeOverdraftsRow `json:"totals"`
+}
+
+// Report lists the accounts in overdraft, optionally in one currency, after
+// the account afterID.
+func (service *Service) Report(ctx context.Context, currency string, afterID int64, pageSize int32) (Report, error) {
+	var report Report
+	var err error
+
+	report.Accounts, err = service.store.ListOverdrawnAccounts(ctx, db.ListOverdrawnAccountsParams{
+		Currency:  sql.NullString{String: currency, Valid: currency != ""},
+		AfterID:   afterID,
+		PageLimit: pageSize,
+	})
+	if err != nil {
+		return report, err
+	}
+
+	report.Totals, err = service.store.SummarizeOverdrafts(ctx)
+	return report, err
+}
+
+// NotifyPending notifies the owners of the accounts that entered or left
+// their overdraft since the last call. It returns the number of events
+// delivered.
+func (service *Service) NotifyPending(ctx context.Context) (int, error) {
+	delivered := 0
+
+	for {
+		events, err := service.store.ClaimOverdraftEvents(ctx, PageSize)
+		if err != nil {
+			return delivered, err
+		}
+
+		for _, event := range events {
+			service.notifier.Notify(ctx, notification(event))
+			delivered++
+		}
+
+		if len(events) < PageSize {
+			return delivered, nil
+		}
+	}
+}
+
+func notification(event db.ClaimOverdraftEventsRow) notify.Notification {
+	if event.Kind == db.OverdraftLeft {
+		return notify.Notification{
+			Username: event.Owner,
+			Kind:     KindLeft,
+			Message:  fmt.Sprintf("account %s is no longer overdrawn: balance %d %s", event.AccountNumber, event.Balance, event.Currency),
+		}
+	}
+
+	return notify.Notification{
+		Username: event.Owner,
+		Kind:     KindEntered,
+		Message: fmt.Sprintf("account %s is overdrawn: balance %d %s, overdraft limit %d %s",
+			event.AccountNumber, event.Balance, event.Currency, event.OverdraftLimit, event.Currency),
+	}
+}
@@ -0,0 +1,48 @@
+package test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/NhutHuyDev/sgbank/internal/infra/db"
+	mockdb "github.com/NhutHuyDev/sgbank/internal/infra/db/mock"
+	"github.com/NhutHuyDev/sgbank/internal/notify"
+	"github.com/NhutHuyDev/sgbank/internal/overdraft"
+	"github.com/NhutHuyDev/sgbank/pkg/utils"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+type recordingNotifier struct {
+	notifications []notify.Notification
+}
+
+func (notifier *recordingNotifier) Notify(_ context.Context, notification notify.Notification) {
+	notifier.notifications = append(notifier.notifications, notification)
+}
+
+func TestNotifyPending(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	notifier := &recordingNotifier{}
+
+	store.EXPECT().
+		ClaimOverdraftEvents(gomock.Any(), gomock.Eq(int32(overdraft.PageSize))).
+		Times(1).
+		Return([]db.ClaimOverdraftEventsRow{
+			{ID: 1, Kind: db.OverdraftEntered, Balance: -300, Owner: "alice", Currency: utils.USD, AccountNumber: "SG05", OverdraftLimit: 1000},
+			{ID: 2, Kind: db.OverdraftLeft, Balance: 20, Owner: "alice", Currency: utils.USD, AccountNumber: "SG05", OverdraftLimit: 1000},
+		}, nil)
+
+	delivered, err := overdraft.NewService(store, notifier).NotifyPending(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 2, delivered)
+
+	require.Len(t, notifier.notifications, 2)
+	require.Equal(t, "alice", notifier.notifications[0].Username)
+	require.Equal(t, overdraft.KindEntered, notifier.notifications[0].Kind)
+	require.Equal(t, "account SG05 is overdrawn: balance -300 USD, overdraft limit 1000 USD", notifier.notifications[0].Message)
+	require.Equal(t, overdraft.KindLeft, notifier.notifications[1].Kind)
+}
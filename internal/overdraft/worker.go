@@ -0,0 +1,42 @@
+package overdraft
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+const DefaultNotifyInterval = time.Minute
+
+// Worker delivers the overdraft notifications recorded by TransferTx. Each
+// event is claimed by one worker, so several instances may run at once.
+type Worker struct {
+	service  *Service
+	interval time.Duration
+}
+
+func NewWorker(service *Service, interval time.Duration) *Worker {
+	if interval <= 0 {
+		interval = DefaultNotifyInterval
+	}
+
+	return &Worker{service: service, interval: interval}
+}
+
+func (worker *Worker) Run(ctx context.Context) error {
+	ticker := time.NewTicker(worker.interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := worker.service.NotifyPending(ctx); err != nil {
+			log.Error().Err(err).Msg("cannot deliver overdraft notifications")
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
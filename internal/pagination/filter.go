@@ -0,0 +1,187 @@
+package pagination
+
+import (
+	"database/sql"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/NhutHuyDev/sgbank/internal/domain"
+	"github.com/NhutHuyDev/sgbank/internal/infra/db"
+)
+
+const (
+	DirectionCredit   = "credit"
+	DirectionDebit    = "debit"
+	DirectionIncoming = "incoming"
+	DirectionOutgoing = "outgoing"
+)
+
+// Filter holds the list filters shared by the entry and transfer endpoints
+// of both APIs. Zero values mean "no filter". From is inclusive, To exclusive.
+type Filter struct {
+	From                  *time.Time
+	To                    *time.Time
+	MinAmount             *int64
+	MaxAmount             *int64
+	Direction             string
+	CounterpartyAccountID *int64
+	Currency              string
+	// Query is a full-text search over descriptions and external
+	// references, in web search syntax: words, "quoted phrases", or, -word.
+	Query string
+	// Category only applies to entries.
+	Category string
+}
+
+const MaxQueryLength = 200
+
+// Validate checks the filter; directions lists the accepted Direction values.
+func (filter Filter) Validate(directions ...string) error {
+	var violations []domain.FieldViolation
+
+	if filter.From != nil && filter.To != nil && !filter.From.Before(*filter.To) {
+		violations = append(violations, domain.FieldViolation{Field: "to", Description: "must be after from"})
+	}
+
+	if filter.MinAmount != nil && *filter.MinAmount < 0 {
+		violations = append(violations, domain.FieldViolation{Field: "min_amount", Description: "must not be negative"})
+	}
+
+	if filter.MaxAmount != nil && *filter.MaxAmount < 0 {
+		violations = append(violations, domain.FieldViolation{Field: "max_amount", Description: "must not be negative"})
+	}
+
+	if filter.MinAmount != nil && filter.MaxAmount != nil && *filter.MinAmount > *filter.MaxAmount {
+		violations = append(violations, domain.FieldViolation{Field: "max_amount", Description: "must not be less than min_amount"})
+	}
+
+	if filter.Direction != "" && !slices.Contains(directions, filter.Direction) {
+		violations = append(violations, domain.FieldViolation{
+			Field:       "direction",
+			Description: fmt.Sprintf("must be one of %s", strings.Join(directions, ", ")),
+		})
+	}
+
+	if filter.CounterpartyAccountID != nil && *filter.CounterpartyAccountID < 1 {
+		violations = append(violations, domain.FieldViolation{Field: "counterparty_account_id", Description: "must be a valid account ID"})
+	}
+
+	if len([]rune(filter.Query)) > MaxQueryLength {
+		violations = append(violations, domain.FieldViolation{Field: "q", Description: fmt.Sprintf("must be at most %d characters", MaxQueryLength)})
+	}
+
+	if len(violations) > 0 {
+		return domain.NewValidationError(violations...)
+	}
+
+	return nil
+}
+
+// Scope identifies a query by its resource, owner and every filter value.
+// Cursors are only accepted for the scope they were issued for.
+func (filter Filter) Scope(resource string, owner string, accountID *int64) string {
+	return strings.Join([]string{
+		resource,
+		owner,
+		formatInt(accountID),
+		formatTime(filter.From),
+		formatTime(filter.To),
+		formatInt(filter.MinAmount),
+		formatInt(filter.MaxAmount),
+		filter.Direction,
+		formatInt(filter.CounterpartyAccountID),
+		filter.Currency,
+		filter.Query,
+		filter.Category,
+	}, "|")
+}
+
+// EntryPageFilter builds the entries query for one page of an account.
+func EntryPageFilter(accountID int64, filter Filter, page Page) db.EntryPageFilter {
+	arg := db.EntryPageFilter{
+		AccountID:             accountID,
+		CreatedFrom:           nullTime(filter.From),
+		CreatedTo:             nullTime(filter.To),
+		MinAmount:             nullInt64(filter.MinAmount),
+		MaxAmount:             nullInt64(filter.MaxAmount),
+		Direction:             nullString(filter.Direction),
+		CounterpartyAccountID: nullInt64(filter.CounterpartyAccountID),
+		Category:              nullString(filter.Category),
+		Query:                 nullString(filter.Query),
+		PageLimit:             page.Limit(),
+	}
+
+	if page.After != nil {
+		arg.AfterCreatedAt = sql.NullTime{Time: page.After.CreatedAt, Valid: true}
+		arg.AfterID = sql.NullInt64{Int64: page.After.ID, Valid: true}
+	}
+
+	return arg
+}
+
+// TransferPageFilter builds the transfers query for one page of the
+// transfers touching owner's accounts, optionally narrowed to one account.
+func TransferPageFilter(owner string, accountID *int64, filter Filter, page Page) db.TransferPageFilter {
+	arg := db.TransferPageFilter{
+		Owner:                 owner,
+		AccountID:             nullInt64(accountID),
+		CreatedFrom:           nullTime(filter.From),
+		CreatedTo:             nullTime(filter.To),
+		MinAmount:             nullInt64(filter.MinAmount),
+		MaxAmount:             nullInt64(filter.MaxAmount),
+		Direction:             nullString(filter.Direction),
+		CounterpartyAccountID: nullInt64(filter.CounterpartyAccountID),
+		Currency:              nullString(filter.Currency),
+		Query:                 nullString(filter.Query),
+		PageLimit:             page.Limit(),
+	}
+
+	if page.After != nil {
+		arg.AfterCreatedAt = sql.NullTime{Time: page.After.CreatedAt, Valid: true}
+		arg.AfterID = sql.NullInt64{Int64: page.After.ID, Valid: true}
+	}
+
+	return arg
+}
+
+func EntryKey(row db.EntryPageRow) Key {
+	return Key{CreatedAt: row.Entry.CreatedAt, ID: row.Entry.ID}
+}
+
+func TransferKey(row db.TransferPageRow) Key {
+	return Key{CreatedAt: row.Transfer.CreatedAt, ID: row.Transfer.ID}
+}
+
+func nullTime(value *time.Time) sql.NullTime {
+	if value == nil {
+		return sql.NullTime{}
+	}
+	return sql.NullTime{Time: *value, Valid: true}
+}
+
+func nullInt64(value *int64) sql.NullInt64 {
+	if value == nil {
+		return sql.NullInt64{}
+	}
+	return sql.NullInt64{Int64: *value, Valid: true}
+}
+
+func nullString(value string) sql.NullString {
+	return sql.NullString{String: value, Valid: value != ""}
+}
+
+func formatTime(value *time.Time) string {
+	if value == nil {
+		return ""
+	}
+	return value.UTC().Format(time.RFC3339Nano)
+}
+
+func formatInt(value *int64) string {
+	if value == nil {
+		return ""
+	}
+	return fmt.Sprint(*value)
+}
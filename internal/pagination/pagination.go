@@ -0,0 +1,178 @@
+package pagination
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/NhutHuyDev/sgbank/internal/domain"
+	"github.com/NhutHuyDev/sgbank/internal/infra/db"
+	"github.com/NhutHuyDev/sgbank/pkg/utils"
+)
+
+const (
+	DefaultPageSize = 20
+	MaxPageSize     = 100
+
+	minSecretKeySize = 32
+)
+
+// Key is the keyset position of a row: the (created_at, id) pair the list
+// queries order by.
+type Key struct {
+	CreatedAt time.Time `json:"t"`
+	ID        int64     `json:"i"`
+}
+
+// Page is a validated page request.
+type Page struct {
+	Size  int32
+	Order db.SortOrder
+	After *Key
+
+	scope string
+}
+
+// Paginator issues and verifies opaque cursors. A cursor is the base64url
+// encoded keyset position followed by an HMAC-SHA256 over it and the query
+// scope, so clients can neither forge positions nor reuse a cursor with
+// different filters or sort order.
+type Paginator struct {
+	secretKey []byte
+}
+
+func NewPaginator(secretKey string) (*Paginator, error) {
+	if len(secretKey) < minSecretKeySize {
+		return nil, fmt.Errorf("invalid cursor key size: must be at least %d characters", minSecretKeySize)
+	}
+
+	return &Paginator{secretKey: []byte(secretKey)}, nil
+}
+
+// FromConfig builds the paginator from CURSOR_SECRET_KEY, deriving a separate
+// key from TOKEN_SYMMETRIC_KEY when it is not set.
+func FromConfig(config utils.Config) (*Paginator, error) {
+	if config.CursorSecretKey != "" {
+		return NewPaginator(config.CursorSecretKey)
+	}
+
+	mac := hmac.New(sha256.New, []byte(config.TokenSymmetricKey))
+	mac.Write([]byte("sgbank pagination cursor"))
+	return NewPaginator(hex.EncodeToString(mac.Sum(nil)))
+}
+
+// Page validates the page size, sort order and cursor of a list request.
+// scope identifies the query the cursor belongs to, see Filter.Scope.
+func (paginator *Paginator) Page(size int32, order string, cursor string, scope string) (Page, error) {
+	var violations []domain.FieldViolation
+
+	switch {
+	case size == 0:
+		size = DefaultPageSize
+	case size < 0 || size > MaxPageSize:
+		violations = append(violations, domain.FieldViolation{
+			Field:       "page_size",
+			Description: fmt.Sprintf("must be between 1 and %d", MaxPageSize),
+		})
+	}
+
+	sortOrder := db.SortOrder(strings.ToLower(order))
+	switch sortOrder {
+	case "":
+		sortOrder = db.SortDesc
+	case db.SortAsc, db.SortDesc:
+	default:
+		violations = append(violations, domain.FieldViolation{
+			Field:       "sort",
+			Description: "must be one of asc, desc",
+		})
+	}
+
+	page := Page{
+		Size:  size,
+		Order: sortOrder,
+		scope: scope + "|" + string(sortOrder),
+	}
+
+	if cursor != "" && len(violations) == 0 {
+		key, err := paginator.decode(cursor, page.scope)
+		if err != nil {
+			violations = append(violations, domain.FieldViolation{
+				Field:       "cursor",
+				Description: err.Error(),
+			})
+		}
+		page.After = key
+	}
+
+	if len(violations) > 0 {
+		return Page{}, domain.NewValidationError(violations...)
+	}
+
+	return page, nil
+}
+
+// Limit is the number of rows to fetch: one more than the page size, so
+// the caller can tell whether another page follows.
+func (page Page) Limit() int32 {
+	return page.Size + 1
+}
+
+// Trim cuts rows fetched with page.Limit down to the page size and returns
+// the cursor of the next page, or "" when this is the last page. keyOf
+// extracts the keyset position of a row.
+func Trim[T any](paginator *Paginator, page Page, rows []T, keyOf func(T) Key) ([]T, string) {
+	if len(rows) <= int(page.Size) {
+		return rows, ""
+	}
+
+	rows = rows[:page.Size]
+	return rows, paginator.encode(keyOf(rows[len(rows)-1]), page.scope)
+}
+
+func (paginator *Paginator) encode(key Key, scope string) string {
+	payload, _ := json.Marshal(key)
+	return base64.RawURLEncoding.EncodeToString(payload) + "." +
+		base64.RawURLEncoding.EncodeToString(paginator.sign(payload, scope))
+}
+
+func (paginator *Paginator) decode(cursor string, scope string) (*Key, error) {
+	encodedPayload, encodedSignature, ok := strings.Cut(cursor, ".")
+	if !ok {
+		return nil, fmt.Errorf("malformed cursor")
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(encodedPayload)
+	if err != nil {
+		return nil, fmt.Errorf("malformed cursor")
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(encodedSignature)
+	if err != nil {
+		return nil, fmt.Errorf("malformed cursor")
+	}
+
+	if !hmac.Equal(signature, paginator.sign(payload, scope)) {
+		return nil, fmt.Errorf("cursor does not match this query")
+	}
+
+	var key Key
+	if err := json.Unmarshal(payload, &key); err != nil {
+		return nil, fmt.Errorf("malformed cursor")
+	}
+
+	return &key, nil
+}
+
+func (paginator *Paginator) sign(payload []byte, scope string) []byte {
+	mac := hmac.New(sha256.New, paginator.secretKey)
+	mac.Write(payload)
+	mac.Write([]byte{0})
+	mac.Write([]byte(scope))
+	return mac.Sum(nil)
+}
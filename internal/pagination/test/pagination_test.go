@@ -0,0 +1,127 @@
+package test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/NhutHuyDev/sgbank/internal/domain"
+	"github.com/NhutHuyDev/sgbank/internal/infra/db"
+	"github.com/NhutHuyDev/sgbank/internal/pagination"
+	"github.com/NhutHuyDev/sgbank/pkg/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func newPaginator(t *testing.T) *pagination.Paginator {
+	paginator, err := pagination.NewPaginator(utils.RandomString(32))
+	require.NoError(t, err)
+	return paginator
+}
+
+func nextCursor(t *testing.T, paginator *pagination.Paginator, page pagination.Page, key pagination.Key) string {
+	rows := make([]pagination.Key, page.Limit())
+	rows[len(rows)-2] = key
+
+	trimmed, cursor := pagination.Trim(paginator, page, rows, func(key pagination.Key) pagination.Key { return key })
+	require.Len(t, trimmed, int(page.Size))
+	require.NotEmpty(t, cursor)
+	return cursor
+}
+
+func TestCursorRoundTrip(t *testing.T) {
+	paginator := newPaginator(t)
+	scope := pagination.Filter{Direction: pagination.DirectionCredit}.Scope("entries", "alice", nil)
+
+	page, err := paginator.Page(0, "", "", scope)
+	require.NoError(t, err)
+	require.EqualValues(t, pagination.DefaultPageSize, page.Size)
+	require.Equal(t, db.SortDesc, page.Order)
+	require.Nil(t, page.After)
+
+	key := pagination.Key{CreatedAt: time.Now().UTC().Truncate(time.Microsecond), ID: 42}
+	cursor := nextCursor(t, paginator, page, key)
+
+	next, err := paginator.Page(0, "", cursor, scope)
+	require.NoError(t, err)
+	require.NotNil(t, next.After)
+	require.Equal(t, key.ID, next.After.ID)
+	require.True(t, key.CreatedAt.Equal(next.After.CreatedAt))
+}
+
+func TestCursorRejected(t *testing.T) {
+	paginator := newPaginator(t)
+	scope := pagination.Filter{}.Scope("entries", "alice", nil)
+
+	page, err := paginator.Page(5, "asc", "", scope)
+	require.NoError(t, err)
+	cursor := nextCursor(t, paginator, page, pagination.Key{ID: 7})
+
+	testCases := []struct {
+		name   string
+		cursor string
+		sort   string
+		scope  string
+	}{
+		{name: "OtherFilters", cursor: cursor, sort: "asc", scope: pagination.Filter{Currency: utils.USD}.Scope("entries", "alice", nil)},
+		{name: "OtherUser", cursor: cursor, sort: "asc", scope: pagination.Filter{}.Scope("entries", "bob", nil)},
+		{name: "OtherSort", cursor: cursor, sort: "desc", scope: scope},
+		{name: "Tampered", cursor: "eyJ0IjoiMDAwMS0wMS0wMVQwMDowMDowMFoiLCJpIjo4fQ." + cursor[len(cursor)-43:], sort: "asc", scope: scope},
+		{name: "Malformed", cursor: "not-a-cursor", sort: "asc", scope: scope},
+		{name: "OtherKey", cursor: cursor, sort: "asc", scope: scope},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			verifier := paginator
+			if tc.name == "OtherKey" {
+				verifier = newPaginator(t)
+			}
+
+			_, err := verifier.Page(5, tc.sort, tc.cursor, tc.scope)
+			require.ErrorIs(t, err, domain.ErrInvalidArgument)
+		})
+	}
+}
+
+func TestPageValidation(t *testing.T) {
+	paginator := newPaginator(t)
+
+	_, err := paginator.Page(pagination.MaxPageSize+1, "", "", "")
+	require.ErrorIs(t, err, domain.ErrInvalidArgument)
+
+	_, err = paginator.Page(10, "sideways", "", "")
+	require.ErrorIs(t, err, domain.ErrInvalidArgument)
+
+	page, err := paginator.Page(3, "ASC", "", "")
+	require.NoError(t, err)
+	require.Equal(t, db.SortAsc, page.Order)
+
+	rows, cursor := pagination.Trim(paginator, page, []int{1, 2, 3}, func(int) pagination.Key { return pagination.Key{} })
+	require.Len(t, rows, 3)
+	require.Empty(t, cursor)
+}
+
+func TestFilterValidate(t *testing.T) {
+	from := time.Now()
+	to := from.Add(-time.Hour)
+	min, max := int64(10), int64(5)
+
+	err := pagination.Filter{From: &from, To: &to}.Validate()
+	require.ErrorIs(t, err, domain.ErrInvalidArgument)
+
+	err = pagination.Filter{MinAmount: &min, MaxAmount: &max}.Validate()
+	require.ErrorIs(t, err, domain.ErrInvalidArgument)
+
+	err = pagination.Filter{Direction: "sideways"}.Validate(pagination.DirectionCredit, pagination.DirectionDebit)
+	require.ErrorIs(t, err, domain.ErrInvalidArgument)
+
+	err = pagination.Filter{Direction: pagination.DirectionDebit, MinAmount: &max, MaxAmount: &min}.Validate(pagination.DirectionCredit, pagination.DirectionDebit)
+	require.NoError(t, err)
+}
+
+func TestFromConfig(t *testing.T) {
+	_, err := pagination.FromConfig(utils.Config{TokenSymmetricKey: utils.RandomString(32)})
+	require.NoError(t, err)
+
+	_, err = pagination.FromConfig(utils.Config{CursorSecretKey: "short"})
+	require.Error(t, err)
+}
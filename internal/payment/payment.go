@@ -0,0 +1,136 @@
+// Package payment runs the checks every payment out of a customer account
+// goes through, whatever sends it: a transfer, a paid payment request, a
+// row of a transfer batch or a payment into escrow. A payment is cleared before it is posted: the
+// owner of the account it is sent to is screened against the sanctions
+// lists, the sender's KYC limits are checked, the fee is added, then the
+// fraud rules allow it, hold it for review or block it. The caller posts an
+// allowed payment through its own transaction, where the store checks the
+// KYC limits again, and reports the transfer back with Posted.
+package payment
+
+import (
+	"context"
+
+	"github.com/NhutHuyDev/sgbank/internal/fee"
+	"github.com/NhutHuyDev/sgbank/internal/fraud"
+	"github.com/NhutHuyDev/sgbank/internal/infra/db"
+	"github.com/NhutHuyDev/sgbank/internal/kyc"
+	"github.com/NhutHuyDev/sgbank/internal/screening"
+)
+
+// Payment is a payment about to be posted.
+type Payment struct {
+	Username string
+	ClientIP string
+	Currency string
+	Params   db.TransferTxParams
+	// ToAccount is the account of the counterparty, the one
+	// Params.ToAccountID names unless the payment goes through one of the
+	// bank's accounts, such as an escrow.
+	ToAccount db.Account
+	// Kind is what the payment pays, db.HeldTransfer when empty, and
+	// Payload the parameters of the transaction that posts it once a held
+	// payment is approved; see db.ApproveHeldTransferTx.
+	Kind    string
+	Payload any
+	// Immediate marks a payment that cannot wait for a review, which the
+	// fraud rules block instead of holding.
+	Immediate bool
+}
+
+// Clearance is a payment that passed the checks.
+type Clearance struct {
+	// Params is the transfer to post, with its fee.
+	Params   db.TransferTxParams
+	Fee      fee.Quote
+	Decision db.FraudDecision
+}
+
+// Held reports whether the payment waits for a fraud review. It is posted
+// by db.ApproveHeldTransferTx once it is approved, and never otherwise.
+func (clearance Clearance) Held() bool {
+	return clearance.Decision.Status == fraud.StatusPending
+}
+
+// Result is a payment that was cleared, and posted unless it is held.
+type Result struct {
+	Clearance
+	// Transfer is the posted transfer, nil when the payment is held.
+	Transfer *db.TransferTxResult
+}
+
+type Service struct {
+	store     db.Store
+	fraud     *fraud.Service
+	screening *screening.Service
+	kyc       *kyc.Service
+	fees      *fee.Service
+}
+
+func NewService(
+	store db.Store,
+	fraudService *fraud.Service,
+	screeningService *screening.Service,
+	kycService *kyc.Service,
+	feeService *fee.Service,
+) *Service {
+	return &Service{store: store, fraud: fraudService, screening: screeningService, kyc: kycService, fees: feeService}
+}
+
+// Clear runs the checks on payment. It fails when the payment may not be
+// made, e.g. with ErrSanctionsMatch, ErrKYCLimitExceeded or
+// ErrTransferBlocked.
+func (service *Service) Clear(ctx context.Context, payment Payment) (Clearance, error) {
+	clearance := Clearance{Params: payment.Params}
+
+	_, err := service.screening.ScreenCounterparty(ctx, payment.Username, payment.ToAccount)
+	if err != nil {
+		return clearance, err
+	}
+
+	err = service.kyc.CheckTransfer(ctx, payment.Username, payment.Currency, clearance.Params.Amount)
+	if err != nil {
+		return clearance, err
+	}
+
+	clearance.Params, clearance.Fee, err = service.fees.Apply(ctx, payment.Username, payment.Currency, clearance.Params)
+	if err != nil {
+		return clearance, err
+	}
+
+	clearance.Decision, err = service.fraud.Decide(ctx, fraud.Transfer{
+		Username:      payment.Username,
+		ClientIP:      payment.ClientIP,
+		Currency:      payment.Currency,
+		Params:        clearance.Params,
+		BeneficiaryID: payment.ToAccount.ID,
+		Kind:          payment.Kind,
+		Payload:       payment.Payload,
+		Immediate:     payment.Immediate,
+	})
+
+	return clearance, err
+}
+
+// Posted links a cleared payment to the transfer that posted it.
+func (service *Service) Posted(ctx context.Context, clearance Clearance, transferID int64) Clearance {
+	clearance.Decision = service.fraud.Link(ctx, clearance.Decision, transferID)
+	return clearance
+}
+
+// Transfer clears a plain transfer and posts it unless it is held.
+func (service *Service) Transfer(ctx context.Context, payment Payment) (Result, error) {
+	payment.Kind, payment.Payload = db.HeldTransfer, nil
+
+	clearance, err := service.Clear(ctx, payment)
+	if err != nil || clearance.Held() {
+		return Result{Clearance: clearance}, err
+	}
+
+	posted, err := service.store.TransferTx(ctx, clearance.Params)
+	if err != nil {
+		return Result{Clearance: clearance}, err
+	}
+
+	return Result{Clearance: service.Posted(ctx, clearance, posted.Transfer.ID), Transfer: &posted}, nil
+}
@@ -0,0 +1,85 @@
+// Package paymenttest builds the payment checks over a mock store for the
+// tests of the services that take payments.
+package paymenttest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/NhutHuyDev/sgbank/internal/accountno"
+	"github.com/NhutHuyDev/sgbank/internal/bank"
+	"github.com/NhutHuyDev/sgbank/internal/blob"
+	"github.com/NhutHuyDev/sgbank/internal/domain"
+	"github.com/NhutHuyDev/sgbank/internal/fee"
+	"github.com/NhutHuyDev/sgbank/internal/fraud"
+	"github.com/NhutHuyDev/sgbank/internal/infra/db"
+	mockdb "github.com/NhutHuyDev/sgbank/internal/infra/db/mock"
+	"github.com/NhutHuyDev/sgbank/internal/kyc"
+	"github.com/NhutHuyDev/sgbank/internal/notify"
+	"github.com/NhutHuyDev/sgbank/internal/payment"
+	"github.com/NhutHuyDev/sgbank/internal/screening"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+// Notifier keeps the notifications it is sent.
+type Notifier struct {
+	Notifications []notify.Notification
+}
+
+func (notifier *Notifier) Notify(_ context.Context, notification notify.Notification) {
+	notifier.Notifications = append(notifier.Notifications, notification)
+}
+
+// FraudConfig scores only the new beneficiary rule, from any amount, so
+// every payment to an account the sender never paid is held.
+func FraudConfig() fraud.Config {
+	config := fraud.DefaultConfig
+	config.Scores = map[string]int32{fraud.RuleNewBeneficiary: 60}
+	config.LargeAmount = 1
+	return config
+}
+
+// NewAccounts finds the bank's own accounts in store.
+func NewAccounts(t *testing.T, store db.Store) *bank.Accounts {
+	generator, err := accountno.NewGenerator(accountno.DefaultBankCode, accountno.DefaultBranchCode)
+	require.NoError(t, err)
+
+	return bank.NewAccounts(store, generator)
+}
+
+// NewService runs the payment checks with FraudConfig, screening against
+// lists and notifying notifier.
+func NewService(t *testing.T, store db.Store, notifier notify.Notifier, lists *screening.Lists) *payment.Service {
+	sanctions := screening.NewService(store, lists, nil, screening.DefaultFlagThreshold, screening.DefaultBlockThreshold)
+	kycService := kyc.NewService(store, blob.NewDisk(t.TempDir()), notifier, kyc.DefaultMaxDocumentSize)
+	fees := fee.NewService(store, NewAccounts(t, store))
+
+	return payment.NewService(store, fraud.NewService(store, notifier, FraudConfig()), sanctions, kycService, fees)
+}
+
+// StubNoKYCLimits puts every sender at a verification level without limits.
+func StubNoKYCLimits(store *mockdb.MockStore) {
+	store.EXPECT().GetUserKycTransferLimits(gomock.Any(), gomock.Any()).AnyTimes().Return(db.GetUserKycTransferLimitsRow{Level: kyc.LevelFull, Name: "Fully verified"}, nil)
+}
+
+// StubNoFees puts every sender on a tier in a currency without a fee
+// schedule.
+func StubNoFees(store *mockdb.MockStore) {
+	store.EXPECT().GetUserTier(gomock.Any(), gomock.Any()).AnyTimes().Return(db.UserTier{Code: "standard", Name: "Standard"}, nil)
+	store.EXPECT().GetFeeSchedule(gomock.Any(), gomock.Any()).AnyTimes().Return(db.FeeSchedule{}, domain.ErrNotFound)
+}
+
+// EchoFraudDecision stands in for CreateFraudDecision, returning decision 9
+// as it was asked to be recorded.
+func EchoFraudDecision(_ context.Context, arg db.CreateFraudDecisionParams) (db.FraudDecision, error) {
+	return db.FraudDecision{
+		ID:            9,
+		Username:      arg.Username,
+		FromAccountID: arg.FromAccountID,
+		ToAccountID:   arg.ToAccountID,
+		Kind:          arg.Kind,
+		Payload:       arg.Payload,
+		Status:        arg.Status,
+	}, nil
+}
@@ -0,0 +1,156 @@
+package test
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/NhutHuyDev/sgbank/internal/domain"
+	"github.com/NhutHuyDev/sgbank/internal/fraud"
+	"github.com/NhutHuyDev/sgbank/internal/infra/db"
+	mockdb "github.com/NhutHuyDev/sgbank/internal/infra/db/mock"
+	"github.com/NhutHuyDev/sgbank/internal/kyc"
+	"github.com/NhutHuyDev/sgbank/internal/payment"
+	"github.com/NhutHuyDev/sgbank/internal/payment/paymenttest"
+	"github.com/NhutHuyDev/sgbank/internal/screening"
+	"github.com/NhutHuyDev/sgbank/pkg/utils"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+// sanctionsLists holds a single entry, 2674 for Usama BIN LADIN.
+func sanctionsLists(t *testing.T) *screening.Lists {
+	path := filepath.Join(t.TempDir(), "sdn.csv")
+	row := `2674,"BIN LADIN, Usama","individual","SDGT",-0- ,-0- ,-0- ,-0- ,-0- ,-0- ,-0- ,-0-` + "\n"
+	require.NoError(t, os.WriteFile(path, []byte(row), 0o600))
+
+	lists := screening.NewLists()
+	require.NoError(t, lists.Load([]string{path}))
+
+	return lists
+}
+
+func newPayment() payment.Payment {
+	return payment.Payment{
+		Username:  "alice",
+		ClientIP:  "203.0.113.7",
+		Currency:  utils.USD,
+		Params:    db.TransferTxParams{FromAccountID: 1, ToAccountID: 2, Amount: 150000},
+		ToAccount: db.Account{ID: 2, Owner: "bob", Currency: utils.USD},
+	}
+}
+
+func TestTransferAllowed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	paymenttest.StubNoKYCLimits(store)
+	paymenttest.StubNoFees(store)
+	store.EXPECT().HasTransferredTo(gomock.Any(), gomock.Any()).Times(1).Return(true, nil)
+	store.EXPECT().CreateFraudDecision(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(paymenttest.EchoFraudDecision)
+	store.EXPECT().
+		TransferTx(gomock.Any(), gomock.Eq(newPayment().Params)).
+		Times(1).
+		Return(db.TransferTxResult{Transfer: db.Transfer{ID: 30}}, nil)
+	store.EXPECT().
+		SetFraudDecisionTransfer(gomock.Any(), gomock.Eq(db.SetFraudDecisionTransferParams{ID: 9, TransferID: sql.NullInt64{Int64: 30, Valid: true}})).
+		Times(1).
+		Return(nil)
+
+	result, err := paymenttest.NewService(t, store, &paymenttest.Notifier{}, screening.NewLists()).Transfer(context.Background(), newPayment())
+	require.NoError(t, err)
+	require.False(t, result.Held())
+	require.NotNil(t, result.Transfer)
+	require.Equal(t, int64(30), result.Decision.TransferID.Int64)
+}
+
+func TestTransferHeld(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	notifier := &paymenttest.Notifier{}
+	paymenttest.StubNoKYCLimits(store)
+	paymenttest.StubNoFees(store)
+
+	store.EXPECT().HasTransferredTo(gomock.Any(), gomock.Any()).Times(1).Return(false, nil)
+	store.EXPECT().CreateFraudDecision(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(paymenttest.EchoFraudDecision)
+	store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+
+	result, err := paymenttest.NewService(t, store, notifier, screening.NewLists()).Transfer(context.Background(), newPayment())
+	require.NoError(t, err)
+	require.True(t, result.Held())
+	require.Nil(t, result.Transfer)
+	require.Equal(t, db.HeldTransfer, result.Decision.Kind)
+
+	require.Len(t, notifier.Notifications, 1)
+	require.Equal(t, fraud.KindHeld, notifier.Notifications[0].Kind)
+}
+
+func TestClearRecordsPayload(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+
+	arg := newPayment()
+	arg.Kind = db.HeldPaymentRequest
+	arg.Payload = db.PayPaymentRequestTxParams{ID: 4, Payer: "alice", FromAccountID: 1, Status: "paid"}
+
+	paymenttest.StubNoKYCLimits(store)
+	paymenttest.StubNoFees(store)
+	store.EXPECT().HasTransferredTo(gomock.Any(), gomock.Any()).Times(1).Return(false, nil)
+	store.EXPECT().CreateFraudDecision(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(paymenttest.EchoFraudDecision)
+
+	clearance, err := paymenttest.NewService(t, store, &paymenttest.Notifier{}, screening.NewLists()).Clear(context.Background(), arg)
+	require.NoError(t, err)
+	require.True(t, clearance.Held())
+	require.Equal(t, db.HeldPaymentRequest, clearance.Decision.Kind)
+	require.JSONEq(t, `{"id":4,"payer":"alice","from_account_id":1,"status":"paid"}`, string(clearance.Decision.Payload))
+}
+
+func TestClearSanctionsMatch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().GetUser(gomock.Any(), gomock.Eq("bob")).Times(1).Return(db.User{Username: "bob", FullName: "Usama BIN LADIN"}, nil)
+	store.EXPECT().ListWhitelistedEntryIDs(gomock.Any(), gomock.Any()).Times(1).Return(nil, nil)
+	store.EXPECT().
+		CreateScreeningMatch(gomock.Any(), gomock.Any()).
+		Times(1).
+		DoAndReturn(func(_ context.Context, arg db.CreateScreeningMatchParams) (db.ScreeningMatch, error) {
+			require.Equal(t, screening.SubjectCounterparty, arg.Subject)
+			require.Equal(t, "alice", arg.Username)
+			require.Equal(t, int64(2), arg.AccountID.Int64)
+			return db.ScreeningMatch{ID: 1}, nil
+		})
+	store.EXPECT().CreateFraudDecision(gomock.Any(), gomock.Any()).Times(0)
+	store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+
+	_, err := paymenttest.NewService(t, store, &paymenttest.Notifier{}, sanctionsLists(t)).Transfer(context.Background(), newPayment())
+	require.ErrorIs(t, err, domain.ErrSanctionsMatch)
+}
+
+func TestClearKYCLimitExceeded(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().
+		GetUserKycTransferLimits(gomock.Any(), gomock.Eq(db.GetUserKycTransferLimitsParams{Username: "alice", Currency: utils.USD})).
+		Times(1).
+		Return(db.GetUserKycTransferLimitsRow{
+			Level:             kyc.LevelIdentity,
+			Name:              "Identity verified",
+			MaxTransferAmount: sql.NullInt64{Int64: 100000, Valid: true},
+		}, nil)
+	store.EXPECT().CreateFraudDecision(gomock.Any(), gomock.Any()).Times(0)
+	store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+
+	_, err := paymenttest.NewService(t, store, &paymenttest.Notifier{}, screening.NewLists()).Transfer(context.Background(), newPayment())
+	require.ErrorIs(t, err, domain.ErrKYCLimitExceeded)
+}
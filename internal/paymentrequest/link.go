@@ -0,0 +1,62 @@
+package paymentrequest
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/NhutHuyDev/sgbank/internal/domain"
+	"github.com/NhutHuyDev/sgbank/pkg/utils"
+)
+
+// Links signs payment request IDs into shareable link tokens of the form
+// "<id>.<base64url HMAC-SHA256>", so holders of a link can pay that one
+// request but cannot guess links to other requests.
+type Links struct {
+	secretKey []byte
+}
+
+func NewLinks(secretKey string) *Links {
+	return &Links{secretKey: []byte(secretKey)}
+}
+
+// LinksFromConfig derives the signing key from TOKEN_SYMMETRIC_KEY.
+func LinksFromConfig(config utils.Config) *Links {
+	mac := hmac.New(sha256.New, []byte(config.TokenSymmetricKey))
+	mac.Write([]byte("sgbank payment link"))
+	return &Links{secretKey: mac.Sum(nil)}
+}
+
+func (links *Links) Token(id int64) string {
+	payload := strconv.FormatInt(id, 10)
+	return payload + "." + base64.RawURLEncoding.EncodeToString(links.sign(payload))
+}
+
+// Verify returns the payment request ID of a link token.
+func (links *Links) Verify(token string) (int64, error) {
+	payload, encodedSignature, ok := strings.Cut(token, ".")
+	if !ok {
+		return 0, fmt.Errorf("%w: malformed payment link", domain.ErrPaymentRequestNotFound)
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(encodedSignature)
+	if err != nil || !hmac.Equal(signature, links.sign(payload)) {
+		return 0, fmt.Errorf("%w: invalid payment link", domain.ErrPaymentRequestNotFound)
+	}
+
+	id, err := strconv.ParseInt(payload, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w: malformed payment link", domain.ErrPaymentRequestNotFound)
+	}
+
+	return id, nil
+}
+
+func (links *Links) sign(payload string) []byte {
+	mac := hmac.New(sha256.New, links.secretKey)
+	mac.Write([]byte(payload))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,402 @@
+package paymentrequest
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/NhutHuyDev/sgbank/internal/domain"
+	"github.com/NhutHuyDev/sgbank/internal/infra/db"
+	"github.com/NhutHuyDev/sgbank/internal/memo"
+	"github.com/NhutHuyDev/sgbank/internal/notify"
+	"github.com/NhutHuyDev/sgbank/internal/payment"
+)
+
+type Status string
+
+const (
+	StatusPending   Status = "pending"
+	StatusPaid      Status = "paid"
+	StatusDeclined  Status = "declined"
+	StatusCancelled Status = "cancelled"
+	StatusExpired   Status = "expired"
+)
+
+// transitions lists the statuses a request may move to from each status.
+// Every status other than pending is final.
+var transitions = map[Status][]Status{
+	StatusPending: {StatusPaid, StatusDeclined, StatusCancelled, StatusExpired},
+}
+
+func CanTransition(from Status, to Status) bool {
+	for _, status := range transitions[from] {
+		if status == to {
+			return true
+		}
+	}
+
+	return false
+}
+
+const (
+	DefaultExpiry = 7 * 24 * time.Hour
+	MaxExpiry     = 30 * 24 * time.Hour
+)
+
+// Notification kinds emitted on status changes.
+const (
+	KindReceived  = "payment_request.received"
+	KindPaid      = "payment_request.paid"
+	KindDeclined  = "payment_request.declined"
+	KindCancelled = "payment_request.cancelled"
+)
+
+// Service runs the payment request workflow: a requester asks a payer, or
+// anyone holding a signed link, for money into one of the requester's
+// accounts; the payer accepts or declines, the requester may cancel, and
+// pending requests expire.
+type Service struct {
+	store    db.Store
+	notifier notify.Notifier
+	payments *payment.Service
+	links    *Links
+}
+
+func NewService(store db.Store, notifier notify.Notifier, payments *payment.Service, links *Links) *Service {
+	return &Service{store: store, notifier: notifier, payments: payments, links: links}
+}
+
+func (service *Service) Links() *Links {
+	return service.links
+}
+
+type CreateParams struct {
+	Requester   string
+	Payer       string // empty for a shareable link request
+	ToAccountID int64
+	Amount      int64
+	Currency    string
+	Memo        string
+	ExpiresAt   time.Time // zero for DefaultExpiry
+}
+
+func (service *Service) Create(ctx context.Context, arg CreateParams) (db.PaymentRequest, error) {
+	now := time.Now()
+	if arg.ExpiresAt.IsZero() {
+		arg.ExpiresAt = now.Add(DefaultExpiry)
+	}
+
+	var violations []domain.FieldViolation
+	if arg.Payer == arg.Requester {
+		violations = append(violations, domain.FieldViolation{Field: "payer", Description: "cannot request money from yourself"})
+	}
+	if description := memo.ValidateDescription(arg.Memo); description != "" {
+		violations = append(violations, domain.FieldViolation{Field: "memo", Description: description})
+	}
+	if !arg.ExpiresAt.After(now) || arg.ExpiresAt.After(now.Add(MaxExpiry)) {
+		violations = append(violations, domain.FieldViolation{Field: "expires_at", Description: fmt.Sprintf("must be in the future and within %s", MaxExpiry)})
+	}
+	if violations != nil {
+		return db.PaymentRequest{}, domain.NewValidationError(violations...)
+	}
+
+	account, err := service.store.GetAccount(ctx, arg.ToAccountID)
+	if err != nil {
+		return db.PaymentRequest{}, err
+	}
+
+	if account.Owner != arg.Requester {
+		return db.PaymentRequest{}, fmt.Errorf("%w: to account doesn't belong to the authenticated user", domain.ErrForbidden)
+	}
+
+	if account.Currency != arg.Currency {
+		return db.PaymentRequest{}, fmt.Errorf("%w: to account [%d]: %s vs %s", domain.ErrCurrencyMismatch, account.ID, account.Currency, arg.Currency)
+	}
+
+	request, err := service.store.CreatePaymentRequest(ctx, db.CreatePaymentRequestParams{
+		Requester:   arg.Requester,
+		Payer:       sql.NullString{String: arg.Payer, Valid: arg.Payer != ""},
+		ToAccountID: arg.ToAccountID,
+		Amount:      arg.Amount,
+		Currency:    arg.Currency,
+		Memo:        arg.Memo,
+		ExpiresAt:   arg.ExpiresAt,
+	})
+	if err != nil {
+		return request, err
+	}
+
+	if request.Payer.Valid {
+		service.notifier.Notify(ctx, notify.Notification{
+			Username: request.Payer.String,
+			Kind:     KindReceived,
+			Message:  fmt.Sprintf("%s requested %d %s: %s", request.Requester, request.Amount, request.Currency, request.Memo),
+		})
+	}
+
+	return request, nil
+}
+
+// Get returns the request if username is its requester or payer.
+func (service *Service) Get(ctx context.Context, username string, id int64) (db.PaymentRequest, error) {
+	request, err := service.store.GetPaymentRequest(ctx, id)
+	if err != nil {
+		return request, err
+	}
+
+	if request.Requester != username && request.Payer.String != username {
+		return db.PaymentRequest{}, fmt.Errorf("%w: payment request doesn't involve the authenticated user", domain.ErrForbidden)
+	}
+
+	return service.expire(ctx, request), nil
+}
+
+// GetByLink returns the request a link token was issued for.
+func (service *Service) GetByLink(ctx context.Context, token string) (db.PaymentRequest, error) {
+	id, err := service.links.Verify(token)
+	if err != nil {
+		return db.PaymentRequest{}, err
+	}
+
+	request, err := service.store.GetPaymentRequest(ctx, id)
+	if err != nil {
+		return request, err
+	}
+
+	return service.expire(ctx, request), nil
+}
+
+type AcceptParams struct {
+	ID            int64
+	Payer         string
+	FromAccountID int64
+	// ViaLink is set when the payer opened a signed link, which lets any
+	// user other than the requester pay a link request.
+	ViaLink  bool
+	ClientIP string
+}
+
+// AcceptResult is an accepted request, paid unless its payment is held for
+// a fraud review.
+type AcceptResult struct {
+	db.PayPaymentRequestTxResult
+	Payment payment.Clearance
+}
+
+// Held reports whether the payment waits for a fraud review; the request
+// stays pending until the review approves the payment.
+func (result AcceptResult) Held() bool {
+	return result.Payment.Held()
+}
+
+// Accept pays a request from one of the payer's accounts. The payment goes
+// through the same checks as a transfer, and the request is paid only once
+// a payment held by them is approved.
+func (service *Service) Accept(ctx context.Context, arg AcceptParams) (AcceptResult, error) {
+	var result AcceptResult
+
+	request, err := service.store.GetPaymentRequest(ctx, arg.ID)
+	if err != nil {
+		return result, err
+	}
+
+	account, err := service.store.GetAccount(ctx, arg.FromAccountID)
+	if err != nil {
+		return result, err
+	}
+
+	if account.Owner != arg.Payer {
+		return result, fmt.Errorf("%w: from account doesn't belong to the authenticated user", domain.ErrForbidden)
+	}
+
+	if account.Currency != request.Currency {
+		return result, fmt.Errorf("%w: from account [%d]: %s vs %s", domain.ErrCurrencyMismatch, account.ID, account.Currency, request.Currency)
+	}
+
+	authorize := func(request db.PaymentRequest) error {
+		if err := checkPayer(request, arg.Payer, arg.ViaLink); err != nil {
+			return err
+		}
+		return checkTransition(request, StatusPaid)
+	}
+	// checked again below while the request is locked; a payment the payer
+	// may not make is not worth clearing
+	if err := authorize(request); err != nil {
+		return result, err
+	}
+
+	toAccount, err := service.store.GetAccount(ctx, request.ToAccountID)
+	if err != nil {
+		return result, err
+	}
+
+	params := db.PayPaymentRequestTxParams{
+		ID:            arg.ID,
+		Payer:         arg.Payer,
+		FromAccountID: arg.FromAccountID,
+		Status:        string(StatusPaid),
+	}
+	result.Payment, err = service.payments.Clear(ctx, payment.Payment{
+		Username: arg.Payer,
+		ClientIP: arg.ClientIP,
+		Currency: request.Currency,
+		Params: db.TransferTxParams{
+			FromAccountID: arg.FromAccountID,
+			ToAccountID:   request.ToAccountID,
+			Amount:        request.Amount,
+			Description:   request.Memo,
+		},
+		ToAccount: toAccount,
+		Kind:      db.HeldPaymentRequest,
+		Payload:   params,
+	})
+	if err != nil {
+		return result, err
+	}
+
+	if result.Held() {
+		result.PaymentRequest = request
+		return result, nil
+	}
+
+	params.Authorize = authorize
+	params.Fee, params.FeeAccountID = result.Payment.Params.Fee, result.Payment.Params.FeeAccountID
+	result.PayPaymentRequestTxResult, err = service.store.PayPaymentRequestTx(ctx, params)
+	if err != nil {
+		return result, err
+	}
+	result.Payment = service.payments.Posted(ctx, result.Payment, result.Transfer.ID)
+
+	service.NotifyPaid(ctx, result.PaymentRequest)
+
+	return result, nil
+}
+
+// NotifyPaid tells the requester their request was paid, e.g. once a held
+// payment is approved.
+func (service *Service) NotifyPaid(ctx context.Context, request db.PaymentRequest) {
+	service.notifier.Notify(ctx, notify.Notification{
+		Username: request.Requester,
+		Kind:     KindPaid,
+		Message:  fmt.Sprintf("%s paid your request for %d %s", request.Payer.String, request.Amount, request.Currency),
+	})
+}
+
+// Decline lets the payer of a request addressed to them turn it down.
+func (service *Service) Decline(ctx context.Context, username string, id int64) (db.PaymentRequest, error) {
+	request, err := service.Get(ctx, username, id)
+	if err != nil {
+		return request, err
+	}
+
+	if request.Payer.String != username || request.Requester == username {
+		return db.PaymentRequest{}, fmt.Errorf("%w: only the payer can decline a payment request", domain.ErrForbidden)
+	}
+
+	request, err = service.transition(ctx, request, StatusDeclined)
+	if err != nil {
+		return request, err
+	}
+
+	service.notifier.Notify(ctx, notify.Notification{
+		Username: request.Requester,
+		Kind:     KindDeclined,
+		Message:  fmt.Sprintf("%s declined your request for %d %s", username, request.Amount, request.Currency),
+	})
+
+	return request, nil
+}
+
+// Cancel lets the requester withdraw a pending request.
+func (service *Service) Cancel(ctx context.Context, username string, id int64) (db.PaymentRequest, error) {
+	request, err := service.Get(ctx, username, id)
+	if err != nil {
+		return request, err
+	}
+
+	if request.Requester != username {
+		return db.PaymentRequest{}, fmt.Errorf("%w: only the requester can cancel a payment request", domain.ErrForbidden)
+	}
+
+	request, err = service.transition(ctx, request, StatusCancelled)
+	if err != nil {
+		return request, err
+	}
+
+	if request.Payer.Valid {
+		service.notifier.Notify(ctx, notify.Notification{
+			Username: request.Payer.String,
+			Kind:     KindCancelled,
+			Message:  fmt.Sprintf("%s cancelled their request for %d %s", request.Requester, request.Amount, request.Currency),
+		})
+	}
+
+	return request, nil
+}
+
+func (service *Service) transition(ctx context.Context, request db.PaymentRequest, to Status) (db.PaymentRequest, error) {
+	if err := checkTransition(request, to); err != nil {
+		return request, err
+	}
+
+	updated, err := service.store.UpdatePaymentRequestStatus(ctx, db.UpdatePaymentRequestStatusParams{
+		ID:         request.ID,
+		FromStatus: request.Status,
+		ToStatus:   string(to),
+	})
+	if errors.Is(err, domain.ErrPaymentRequestNotFound) {
+		// the status changed since the request was read
+		return request, fmt.Errorf("%w: payment request is no longer %s", domain.ErrInvalidTransition, request.Status)
+	}
+
+	return updated, err
+}
+
+// expire moves a pending request past its expiry to expired. A failure is
+// not fatal: the request is still reported as expired and the next read
+// retries.
+func (service *Service) expire(ctx context.Context, request db.PaymentRequest) db.PaymentRequest {
+	if Status(request.Status) != StatusPending || time.Now().Before(request.ExpiresAt) {
+		return request
+	}
+
+	updated, err := service.store.UpdatePaymentRequestStatus(ctx, db.UpdatePaymentRequestStatusParams{
+		ID:         request.ID,
+		FromStatus: request.Status,
+		ToStatus:   string(StatusExpired),
+	})
+	if err != nil {
+		request.Status = string(StatusExpired)
+		return request
+	}
+
+	return updated
+}
+
+func checkPayer(request db.PaymentRequest, payer string, viaLink bool) error {
+	switch {
+	case request.Requester == payer:
+		return fmt.Errorf("%w: cannot pay your own payment request", domain.ErrForbidden)
+	case request.Payer.Valid && request.Payer.String != payer:
+		return fmt.Errorf("%w: payment request is addressed to another user", domain.ErrForbidden)
+	case !request.Payer.Valid && !viaLink:
+		return fmt.Errorf("%w: link payment requests can only be paid through the link", domain.ErrForbidden)
+	}
+
+	return nil
+}
+
+func checkTransition(request db.PaymentRequest, to Status) error {
+	from := Status(request.Status)
+	if from == StatusPending && !time.Now().Before(request.ExpiresAt) {
+		from = StatusExpired
+	}
+
+	if !CanTransition(from, to) {
+		return fmt.Errorf("%w: payment request is %s", domain.ErrInvalidTransition, from)
+	}
+
+	return nil
+}
@@ -0,0 +1,417 @@
+package test
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/NhutHuyDev/sgbank/internal/bank"
+	"github.com/NhutHuyDev/sgbank/internal/domain"
+	"github.com/NhutHuyDev/sgbank/internal/fraud"
+	"github.com/NhutHuyDev/sgbank/internal/infra/db"
+	mockdb "github.com/NhutHuyDev/sgbank/internal/infra/db/mock"
+	"github.com/NhutHuyDev/sgbank/internal/kyc"
+	"github.com/NhutHuyDev/sgbank/internal/notify"
+	"github.com/NhutHuyDev/sgbank/internal/payment/paymenttest"
+	"github.com/NhutHuyDev/sgbank/internal/paymentrequest"
+	"github.com/NhutHuyDev/sgbank/internal/screening"
+	"github.com/NhutHuyDev/sgbank/pkg/utils"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+// newService holds any payment to an account the payer never paid, and
+// screens against lists.
+func newService(t *testing.T, store db.Store, notifier notify.Notifier, lists *screening.Lists) *paymentrequest.Service {
+	payments := paymenttest.NewService(t, store, notifier, lists)
+	return paymentrequest.NewService(store, notifier, payments, paymentrequest.NewLinks("secret"))
+}
+
+func TestCanTransition(t *testing.T) {
+	require.True(t, paymentrequest.CanTransition(paymentrequest.StatusPending, paymentrequest.StatusPaid))
+	require.True(t, paymentrequest.CanTransition(paymentrequest.StatusPending, paymentrequest.StatusCancelled))
+	require.False(t, paymentrequest.CanTransition(paymentrequest.StatusPaid, paymentrequest.StatusCancelled))
+	require.False(t, paymentrequest.CanTransition(paymentrequest.StatusDeclined, paymentrequest.StatusPaid))
+	require.False(t, paymentrequest.CanTransition(paymentrequest.StatusExpired, paymentrequest.StatusPaid))
+}
+
+func TestLinks(t *testing.T) {
+	links := paymentrequest.NewLinks("secret")
+
+	id, err := links.Verify(links.Token(42))
+	require.NoError(t, err)
+	require.EqualValues(t, 42, id)
+
+	_, err = links.Verify("43." + links.Token(42)[3:])
+	require.ErrorIs(t, err, domain.ErrPaymentRequestNotFound)
+
+	_, err = paymentrequest.NewLinks("other").Verify(links.Token(42))
+	require.ErrorIs(t, err, domain.ErrPaymentRequestNotFound)
+}
+
+func pendingRequest(payer string) db.PaymentRequest {
+	return db.PaymentRequest{
+		ID:          1,
+		Requester:   "alice",
+		Payer:       sql.NullString{String: payer, Valid: payer != ""},
+		ToAccountID: 10,
+		Amount:      50,
+		Currency:    utils.USD,
+		Status:      string(paymentrequest.StatusPending),
+		ExpiresAt:   time.Now().Add(time.Hour),
+	}
+}
+
+func TestCreate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	notifier := &paymenttest.Notifier{}
+	service := newService(t, store, notifier, screening.NewLists())
+
+	_, err := service.Create(context.Background(), paymentrequest.CreateParams{
+		Requester: "alice", Payer: "alice", ToAccountID: 10, Amount: 50, Currency: utils.USD,
+		ExpiresAt: time.Now().Add(-time.Minute),
+	})
+	var validationErr *domain.ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	require.Len(t, validationErr.Violations, 2)
+
+	store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(int64(10))).Times(1).
+		Return(db.Account{ID: 10, Owner: "alice", Currency: utils.USD}, nil)
+	store.EXPECT().CreatePaymentRequest(gomock.Any(), gomock.Any()).Times(1).
+		DoAndReturn(func(_ context.Context, arg db.CreatePaymentRequestParams) (db.PaymentRequest, error) {
+			require.Equal(t, "bob", arg.Payer.String)
+			require.WithinDuration(t, time.Now().Add(paymentrequest.DefaultExpiry), arg.ExpiresAt, time.Minute)
+			return pendingRequest("bob"), nil
+		})
+
+	_, err = service.Create(context.Background(), paymentrequest.CreateParams{
+		Requester: "alice", Payer: "bob", ToAccountID: 10, Amount: 50, Currency: utils.USD, Memo: "dinner",
+	})
+	require.NoError(t, err)
+	require.Len(t, notifier.Notifications, 1)
+	require.Equal(t, "bob", notifier.Notifications[0].Username)
+	require.Equal(t, paymentrequest.KindReceived, notifier.Notifications[0].Kind)
+}
+
+func TestAccept(t *testing.T) {
+	expired := pendingRequest("bob")
+	expired.ExpiresAt = time.Now().Add(-time.Minute)
+
+	paid := pendingRequest("bob")
+	paid.Status = string(paymentrequest.StatusPaid)
+
+	testCases := []struct {
+		name    string
+		locked  db.PaymentRequest
+		payer   string
+		viaLink bool
+		posted  bool
+		check   func(t *testing.T, err error, notifier *paymenttest.Notifier)
+	}{
+		{
+			name:   "OK",
+			locked: pendingRequest("bob"),
+			payer:  "bob",
+			posted: true,
+			check: func(t *testing.T, err error, notifier *paymenttest.Notifier) {
+				require.NoError(t, err)
+				require.Len(t, notifier.Notifications, 1)
+				require.Equal(t, "alice", notifier.Notifications[0].Username)
+				require.Equal(t, paymentrequest.KindPaid, notifier.Notifications[0].Kind)
+			},
+		},
+		{
+			name:    "LinkRequest",
+			locked:  pendingRequest(""),
+			payer:   "bob",
+			viaLink: true,
+			posted:  true,
+			check: func(t *testing.T, err error, notifier *paymenttest.Notifier) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name:   "LinkRequestWithoutLink",
+			locked: pendingRequest(""),
+			payer:  "bob",
+			check: func(t *testing.T, err error, notifier *paymenttest.Notifier) {
+				require.ErrorIs(t, err, domain.ErrForbidden)
+			},
+		},
+		{
+			name:   "OtherPayer",
+			locked: pendingRequest("carol"),
+			payer:  "bob",
+			check: func(t *testing.T, err error, notifier *paymenttest.Notifier) {
+				require.ErrorIs(t, err, domain.ErrForbidden)
+			},
+		},
+		{
+			name:   "AlreadyPaid",
+			locked: paid,
+			payer:  "bob",
+			check: func(t *testing.T, err error, notifier *paymenttest.Notifier) {
+				require.ErrorIs(t, err, domain.ErrInvalidTransition)
+				require.Empty(t, notifier.Notifications)
+			},
+		},
+		{
+			name:   "Expired",
+			locked: expired,
+			payer:  "bob",
+			check: func(t *testing.T, err error, notifier *paymenttest.Notifier) {
+				require.ErrorIs(t, err, domain.ErrInvalidTransition)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			paymenttest.StubNoKYCLimits(store)
+			paymenttest.StubNoFees(store)
+			store.EXPECT().GetPaymentRequest(gomock.Any(), gomock.Eq(int64(1))).Times(1).Return(tc.locked, nil)
+			store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(int64(20))).Times(1).
+				Return(db.Account{ID: 20, Owner: tc.payer, Currency: utils.USD}, nil)
+
+			// a payment the payer may not make is refused before it is cleared
+			times := 0
+			if tc.posted {
+				times = 1
+			}
+			store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(tc.locked.ToAccountID)).Times(times).
+				Return(db.Account{ID: tc.locked.ToAccountID, Owner: "alice", Currency: utils.USD}, nil)
+			store.EXPECT().HasTransferredTo(gomock.Any(), gomock.Any()).Times(times).Return(true, nil)
+			store.EXPECT().CreateFraudDecision(gomock.Any(), gomock.Any()).Times(times).DoAndReturn(paymenttest.EchoFraudDecision)
+			store.EXPECT().PayPaymentRequestTx(gomock.Any(), gomock.Any()).Times(times).
+				DoAndReturn(func(_ context.Context, arg db.PayPaymentRequestTxParams) (db.PayPaymentRequestTxResult, error) {
+					require.Equal(t, string(paymentrequest.StatusPaid), arg.Status)
+					if err := arg.Authorize(tc.locked); err != nil {
+						return db.PayPaymentRequestTxResult{}, err
+					}
+					return db.PayPaymentRequestTxResult{PaymentRequest: tc.locked, TransferTxResult: db.TransferTxResult{Transfer: db.Transfer{ID: 30}}}, nil
+				})
+			store.EXPECT().SetFraudDecisionTransfer(gomock.Any(), gomock.Any()).Times(times).Return(nil)
+
+			notifier := &paymenttest.Notifier{}
+			service := newService(t, store, notifier, screening.NewLists())
+
+			_, err := service.Accept(context.Background(), paymentrequest.AcceptParams{
+				ID:            1,
+				Payer:         tc.payer,
+				FromAccountID: 20,
+				ViaLink:       tc.viaLink,
+			})
+			tc.check(t, err, notifier)
+		})
+	}
+}
+
+func TestAcceptChargesFee(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	request := pendingRequest("bob")
+	revenue := db.Account{ID: 90, Owner: bank.Username, Currency: utils.USD, Product: bank.ProductFeeRevenue}
+
+	store := mockdb.NewMockStore(ctrl)
+	paymenttest.StubNoKYCLimits(store)
+	store.EXPECT().GetPaymentRequest(gomock.Any(), gomock.Eq(int64(1))).Times(1).Return(request, nil)
+	store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(int64(20))).Times(1).
+		Return(db.Account{ID: 20, Owner: "bob", Currency: utils.USD}, nil)
+	store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(request.ToAccountID)).Times(1).
+		Return(db.Account{ID: request.ToAccountID, Owner: "alice", Currency: utils.USD}, nil)
+	store.EXPECT().GetUserTier(gomock.Any(), gomock.Eq("bob")).Times(1).Return(db.UserTier{Code: "standard"}, nil)
+	store.EXPECT().GetFeeSchedule(gomock.Any(), gomock.Eq(utils.USD)).Times(1).Return(db.FeeSchedule{ID: 3, Currency: utils.USD}, nil)
+	store.EXPECT().ListFeeScheduleBands(gomock.Any(), gomock.Eq(int64(3))).Times(1).Return([]db.FeeScheduleBand{{FlatFee: 5}}, nil)
+	store.EXPECT().GetAccountByOwnerProduct(gomock.Any(), gomock.Any()).Times(1).Return(revenue, nil)
+	store.EXPECT().HasTransferredTo(gomock.Any(), gomock.Any()).Times(1).Return(true, nil)
+	store.EXPECT().CreateFraudDecision(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(paymenttest.EchoFraudDecision)
+	store.EXPECT().PayPaymentRequestTx(gomock.Any(), gomock.Any()).Times(1).
+		DoAndReturn(func(_ context.Context, arg db.PayPaymentRequestTxParams) (db.PayPaymentRequestTxResult, error) {
+			require.Equal(t, int64(5), arg.Fee)
+			require.Equal(t, revenue.ID, arg.FeeAccountID)
+			return db.PayPaymentRequestTxResult{PaymentRequest: request, TransferTxResult: db.TransferTxResult{Transfer: db.Transfer{ID: 30, Fee: arg.Fee}}}, nil
+		})
+	store.EXPECT().SetFraudDecisionTransfer(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+
+	result, err := newService(t, store, &paymenttest.Notifier{}, screening.NewLists()).Accept(context.Background(), paymentrequest.AcceptParams{
+		ID:            1,
+		Payer:         "bob",
+		FromAccountID: 20,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(5), result.Payment.Fee.Fee)
+	require.Equal(t, request.Amount+5, result.Payment.Fee.Total)
+}
+
+func TestAcceptHeld(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	request := pendingRequest("bob")
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().GetPaymentRequest(gomock.Any(), gomock.Eq(int64(1))).Times(1).Return(request, nil)
+	store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(int64(20))).Times(1).
+		Return(db.Account{ID: 20, Owner: "bob", Currency: utils.USD}, nil)
+	store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(request.ToAccountID)).Times(1).
+		Return(db.Account{ID: request.ToAccountID, Owner: "alice", Currency: utils.USD}, nil)
+	paymenttest.StubNoKYCLimits(store)
+	paymenttest.StubNoFees(store)
+	store.EXPECT().HasTransferredTo(gomock.Any(), gomock.Eq(db.HasTransferredToParams{Owner: "bob", ToAccountID: request.ToAccountID})).
+		Times(1).
+		Return(false, nil)
+	store.EXPECT().
+		CreateFraudDecision(gomock.Any(), gomock.Any()).
+		Times(1).
+		DoAndReturn(func(ctx context.Context, arg db.CreateFraudDecisionParams) (db.FraudDecision, error) {
+			require.Equal(t, fraud.StatusPending, arg.Status)
+			require.Equal(t, db.HeldPaymentRequest, arg.Kind)
+			require.JSONEq(t, `{"id":1,"payer":"bob","from_account_id":20,"status":"paid"}`, string(arg.Payload))
+			return paymenttest.EchoFraudDecision(ctx, arg)
+		})
+	store.EXPECT().PayPaymentRequestTx(gomock.Any(), gomock.Any()).Times(0)
+
+	notifier := &paymenttest.Notifier{}
+	result, err := newService(t, store, notifier, screening.NewLists()).Accept(context.Background(), paymentrequest.AcceptParams{
+		ID:            1,
+		Payer:         "bob",
+		FromAccountID: 20,
+	})
+	require.NoError(t, err)
+	require.True(t, result.Held())
+	require.Equal(t, string(paymentrequest.StatusPending), result.PaymentRequest.Status)
+
+	// bob is told the payment waits for review; alice is not told it was paid
+	require.Len(t, notifier.Notifications, 1)
+	require.Equal(t, fraud.KindHeld, notifier.Notifications[0].Kind)
+	require.Equal(t, "bob", notifier.Notifications[0].Username)
+}
+
+func TestAcceptSanctionsMatch(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sdn.csv")
+	row := `2674,"BIN LADIN, Usama","individual","SDGT",-0- ,-0- ,-0- ,-0- ,-0- ,-0- ,-0- ,-0-` + "\n"
+	require.NoError(t, os.WriteFile(path, []byte(row), 0o600))
+
+	lists := screening.NewLists()
+	require.NoError(t, lists.Load([]string{path}))
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	request := pendingRequest("bob")
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().GetPaymentRequest(gomock.Any(), gomock.Eq(int64(1))).Times(1).Return(request, nil)
+	store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(int64(20))).Times(1).
+		Return(db.Account{ID: 20, Owner: "bob", Currency: utils.USD}, nil)
+	store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(request.ToAccountID)).Times(1).
+		Return(db.Account{ID: request.ToAccountID, Owner: "alice", Currency: utils.USD}, nil)
+	store.EXPECT().GetUser(gomock.Any(), gomock.Eq("alice")).Times(1).Return(db.User{Username: "alice", FullName: "Usama BIN LADIN"}, nil)
+	store.EXPECT().ListWhitelistedEntryIDs(gomock.Any(), gomock.Any()).Times(1).Return(nil, nil)
+	store.EXPECT().
+		CreateScreeningMatch(gomock.Any(), gomock.Any()).
+		Times(1).
+		DoAndReturn(func(_ context.Context, arg db.CreateScreeningMatchParams) (db.ScreeningMatch, error) {
+			require.Equal(t, screening.SubjectCounterparty, arg.Subject)
+			require.Equal(t, "bob", arg.Username)
+			require.Equal(t, request.ToAccountID, arg.AccountID.Int64)
+			return db.ScreeningMatch{ID: 1}, nil
+		})
+	store.EXPECT().CreateFraudDecision(gomock.Any(), gomock.Any()).Times(0)
+	store.EXPECT().PayPaymentRequestTx(gomock.Any(), gomock.Any()).Times(0)
+
+	notifier := &paymenttest.Notifier{}
+	_, err := newService(t, store, notifier, lists).Accept(context.Background(), paymentrequest.AcceptParams{
+		ID:            1,
+		Payer:         "bob",
+		FromAccountID: 20,
+	})
+	require.ErrorIs(t, err, domain.ErrSanctionsMatch)
+	require.Empty(t, notifier.Notifications)
+}
+
+func TestAcceptKYCLimitExceeded(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	request := pendingRequest("bob")
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().GetPaymentRequest(gomock.Any(), gomock.Eq(int64(1))).Times(1).Return(request, nil)
+	store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(int64(20))).Times(1).
+		Return(db.Account{ID: 20, Owner: "bob", Currency: utils.USD}, nil)
+	store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(request.ToAccountID)).Times(1).
+		Return(db.Account{ID: request.ToAccountID, Owner: "alice", Currency: utils.USD}, nil)
+	// bob already sent all but 10 of his daily limit in USD
+	store.EXPECT().
+		GetUserKycTransferLimits(gomock.Any(), gomock.Eq(db.GetUserKycTransferLimitsParams{Username: "bob", Currency: utils.USD})).
+		Times(1).
+		Return(db.GetUserKycTransferLimitsRow{
+			Level:              kyc.LevelUnverified,
+			Name:               "Unverified",
+			DailyTransferLimit: sql.NullInt64{Int64: 20000, Valid: true},
+		}, nil)
+	store.EXPECT().SumTransfersFromOwnerSince(gomock.Any(), gomock.Any()).Times(1).
+		DoAndReturn(func(_ context.Context, arg db.SumTransfersFromOwnerSinceParams) (int64, error) {
+			require.Equal(t, "bob", arg.Owner)
+			require.Equal(t, utils.USD, arg.Currency)
+			return 19990, nil
+		})
+	store.EXPECT().CreateFraudDecision(gomock.Any(), gomock.Any()).Times(0)
+	store.EXPECT().PayPaymentRequestTx(gomock.Any(), gomock.Any()).Times(0)
+
+	notifier := &paymenttest.Notifier{}
+	_, err := newService(t, store, notifier, screening.NewLists()).Accept(context.Background(), paymentrequest.AcceptParams{
+		ID:            1,
+		Payer:         "bob",
+		FromAccountID: 20,
+	})
+	require.ErrorIs(t, err, domain.ErrKYCLimitExceeded)
+	require.Empty(t, notifier.Notifications)
+}
+
+func TestDeclineAndCancel(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	notifier := &paymenttest.Notifier{}
+	service := newService(t, store, notifier, screening.NewLists())
+	ctx := context.Background()
+
+	store.EXPECT().GetPaymentRequest(gomock.Any(), gomock.Eq(int64(1))).AnyTimes().Return(pendingRequest("bob"), nil)
+
+	// the requester cannot decline and the payer cannot cancel
+	_, err := service.Decline(ctx, "alice", 1)
+	require.ErrorIs(t, err, domain.ErrForbidden)
+	_, err = service.Cancel(ctx, "bob", 1)
+	require.ErrorIs(t, err, domain.ErrForbidden)
+
+	store.EXPECT().UpdatePaymentRequestStatus(gomock.Any(), gomock.Eq(db.UpdatePaymentRequestStatusParams{
+		ID:         1,
+		FromStatus: string(paymentrequest.StatusPending),
+		ToStatus:   string(paymentrequest.StatusDeclined),
+	})).Times(1).Return(pendingRequest("bob"), nil)
+
+	_, err = service.Decline(ctx, "bob", 1)
+	require.NoError(t, err)
+	require.Equal(t, paymentrequest.KindDeclined, notifier.Notifications[0].Kind)
+
+	// a concurrent status change makes the guarded update miss
+	store.EXPECT().UpdatePaymentRequestStatus(gomock.Any(), gomock.Any()).Times(1).
+		Return(db.PaymentRequest{}, domain.ErrPaymentRequestNotFound)
+
+	_, err = service.Cancel(ctx, "alice", 1)
+	require.ErrorIs(t, err, domain.ErrInvalidTransition)
+}
@@ -0,0 +1,176 @@
+package recipient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/NhutHuyDev/sgbank/internal/domain"
+	"github.com/NhutHuyDev/sgbank/internal/infra/db"
+	"github.com/NhutHuyDev/sgbank/internal/logging"
+	"github.com/NhutHuyDev/sgbank/pkg/utils"
+	"github.com/NhutHuyDev/sgbank/pkg/val"
+)
+
+const (
+	// DefaultLimit is the number of resolutions a user may make per Window
+	// when RECIPIENT_LOOKUP_LIMIT is not set.
+	DefaultLimit = 30
+	Window       = time.Hour
+)
+
+// Purpose records why a recipient was resolved in the audit trail.
+type Purpose string
+
+const (
+	PurposeLookup   Purpose = "lookup"
+	PurposeTransfer Purpose = "transfer"
+)
+
+// Request identifies the recipient to resolve and who is asking for it.
+type Request struct {
+	Requester  string
+	Identifier string
+	Currency   string
+	Purpose    Purpose
+	ClientIP   string
+}
+
+// Recipient is a resolved recipient. FullName must never be returned to the
+// requester; use MaskedName instead.
+type Recipient struct {
+	Account  db.Account
+	FullName string
+}
+
+func (r Recipient) MaskedName() string {
+	return MaskName(r.FullName)
+}
+
+// Resolver maps a username or verified email to the owner's account in a
+// currency. Every resolution is written to recipient_lookups, and requesters
+// exceeding the limit are rejected so the endpoint cannot be used to
+// enumerate customers.
+type Resolver struct {
+	store db.Store
+	limit int
+}
+
+func NewResolver(store db.Store, limit int) *Resolver {
+	if limit <= 0 {
+		limit = DefaultLimit
+	}
+
+	return &Resolver{store: store, limit: limit}
+}
+
+func FromConfig(store db.Store, config utils.Config) *Resolver {
+	return NewResolver(store, config.RecipientLookupLimit)
+}
+
+// Resolve returns the recipient matching req. Unknown users, unverified
+// emails and users without an account in the currency are all reported as
+// domain.ErrRecipientNotFound so the response does not reveal which one.
+func (resolver *Resolver) Resolve(ctx context.Context, req Request) (Recipient, error) {
+	if violations := ValidateIdentifier(req.Identifier); violations != nil {
+		return Recipient{}, domain.NewValidationError(violations...)
+	}
+
+	count, err := resolver.store.CountRecipientLookupsSince(ctx, db.CountRecipientLookupsSinceParams{
+		Username: req.Requester,
+		Since:    time.Now().Add(-Window),
+	})
+	if err != nil {
+		return Recipient{}, err
+	}
+
+	if count >= int64(resolver.limit) {
+		return Recipient{}, fmt.Errorf("%w: recipient lookup limit of %d per %s reached", domain.ErrTooManyRequests, resolver.limit, Window)
+	}
+
+	recipient, err := resolver.find(ctx, req.Identifier, req.Currency)
+	found := err == nil
+	if err != nil && !errors.Is(err, domain.ErrRecipientNotFound) {
+		return Recipient{}, err
+	}
+
+	_, auditErr := resolver.store.CreateRecipientLookup(ctx, db.CreateRecipientLookupParams{
+		Username:   req.Requester,
+		Identifier: strings.ToLower(req.Identifier),
+		Purpose:    string(req.Purpose),
+		Found:      found,
+		ClientIp:   req.ClientIP,
+	})
+	if auditErr != nil {
+		logging.FromContext(ctx).Error().Err(auditErr).Msg("cannot audit recipient lookup")
+		return Recipient{}, auditErr
+	}
+
+	return recipient, err
+}
+
+func (resolver *Resolver) find(ctx context.Context, identifier string, currency string) (Recipient, error) {
+	var user db.User
+	var err error
+	if IsEmail(identifier) {
+		user, err = resolver.store.GetUserByVerifiedEmail(ctx, identifier)
+	} else {
+		user, err = resolver.store.GetUser(ctx, identifier)
+	}
+	if err != nil {
+		return Recipient{}, notFound(err)
+	}
+
+	account, err := resolver.store.GetAccountByOwnerCurrency(ctx, db.GetAccountByOwnerCurrencyParams{
+		Owner:    user.Username,
+		Currency: currency,
+	})
+	if err != nil {
+		return Recipient{}, notFound(err)
+	}
+
+	return Recipient{Account: account, FullName: user.FullName}, nil
+}
+
+func notFound(err error) error {
+	if domain.Lookup(err).Kind == domain.KindNotFound {
+		return domain.ErrRecipientNotFound
+	}
+
+	return err
+}
+
+// IsEmail reports whether identifier should be resolved as an email rather
+// than a username. Usernames cannot contain '@'.
+func IsEmail(identifier string) bool {
+	return strings.Contains(identifier, "@")
+}
+
+func ValidateIdentifier(identifier string) []domain.FieldViolation {
+	validate := val.ValidateUsername
+	if IsEmail(identifier) {
+		validate = val.ValidateEmail
+	}
+
+	if err := validate(identifier); err != nil {
+		return []domain.FieldViolation{{Field: "recipient", Description: err.Error()}}
+	}
+
+	return nil
+}
+
+// MaskName keeps the first letter of each word of the name, e.g.
+// "Nguyen Van An" becomes "N*** V*** A***". The mask has a fixed width so it
+// does not leak the length of the name.
+func MaskName(fullName string) string {
+	words := strings.Fields(fullName)
+	for i, word := range words {
+		first, _ := utf8.DecodeRuneInString(word)
+		words[i] = string(first) + "***"
+	}
+
+	return strings.Join(words, " ")
+}
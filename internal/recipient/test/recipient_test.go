@@ -0,0 +1,134 @@
+package test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/NhutHuyDev/sgbank/internal/domain"
+	"github.com/NhutHuyDev/sgbank/internal/infra/db"
+	mockdb "github.com/NhutHuyDev/sgbank/internal/infra/db/mock"
+	"github.com/NhutHuyDev/sgbank/internal/recipient"
+	"github.com/NhutHuyDev/sgbank/pkg/utils"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMaskName(t *testing.T) {
+	require.Equal(t, "N*** V*** A***", recipient.MaskName("Nguyen Van An"))
+	require.Equal(t, "J***", recipient.MaskName("  Jo "))
+	require.Equal(t, "", recipient.MaskName(""))
+}
+
+func TestResolve(t *testing.T) {
+	user := db.User{Username: "alice", FullName: "Alice Nguyen", Email: "alice@example.com"}
+	account := db.Account{ID: 7, Owner: user.Username, Currency: utils.USD}
+
+	request := func(identifier string) recipient.Request {
+		return recipient.Request{
+			Requester:  "bob",
+			Identifier: identifier,
+			Currency:   utils.USD,
+			Purpose:    recipient.PurposeLookup,
+			ClientIP:   "127.0.0.1",
+		}
+	}
+
+	testCases := []struct {
+		name       string
+		identifier string
+		buildStubs func(store *mockdb.MockStore)
+		check      func(t *testing.T, found recipient.Recipient, err error)
+	}{
+		{
+			name:       "ByUsername",
+			identifier: user.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CountRecipientLookupsSince(gomock.Any(), gomock.Any()).Times(1).Return(int64(0), nil)
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(user, nil)
+				store.EXPECT().GetAccountByOwnerCurrency(gomock.Any(), gomock.Eq(db.GetAccountByOwnerCurrencyParams{
+					Owner:    user.Username,
+					Currency: utils.USD,
+				})).Times(1).Return(account, nil)
+				store.EXPECT().CreateRecipientLookup(gomock.Any(), gomock.Eq(db.CreateRecipientLookupParams{
+					Username:   "bob",
+					Identifier: user.Username,
+					Purpose:    string(recipient.PurposeLookup),
+					Found:      true,
+					ClientIp:   "127.0.0.1",
+				})).Times(1)
+			},
+			check: func(t *testing.T, found recipient.Recipient, err error) {
+				require.NoError(t, err)
+				require.Equal(t, account, found.Account)
+				require.Equal(t, "A*** N***", found.MaskedName())
+			},
+		},
+		{
+			name:       "UnverifiedEmail",
+			identifier: "Alice@Example.com",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CountRecipientLookupsSince(gomock.Any(), gomock.Any()).Times(1).Return(int64(0), nil)
+				store.EXPECT().GetUserByVerifiedEmail(gomock.Any(), gomock.Eq("Alice@Example.com")).Times(1).Return(db.User{}, domain.ErrUserNotFound)
+				store.EXPECT().GetAccountByOwnerCurrency(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().CreateRecipientLookup(gomock.Any(), gomock.Any()).Times(1).
+					DoAndReturn(func(_ context.Context, arg db.CreateRecipientLookupParams) (db.RecipientLookup, error) {
+						require.False(t, arg.Found)
+						require.Equal(t, "alice@example.com", arg.Identifier)
+						return db.RecipientLookup{}, nil
+					})
+			},
+			check: func(t *testing.T, found recipient.Recipient, err error) {
+				require.ErrorIs(t, err, domain.ErrRecipientNotFound)
+			},
+		},
+		{
+			name:       "NoAccountInCurrency",
+			identifier: user.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CountRecipientLookupsSince(gomock.Any(), gomock.Any()).Times(1).Return(int64(0), nil)
+				store.EXPECT().GetUser(gomock.Any(), gomock.Any()).Times(1).Return(user, nil)
+				store.EXPECT().GetAccountByOwnerCurrency(gomock.Any(), gomock.Any()).Times(1).Return(db.Account{}, domain.ErrAccountNotFound)
+				store.EXPECT().CreateRecipientLookup(gomock.Any(), gomock.Any()).Times(1)
+			},
+			check: func(t *testing.T, found recipient.Recipient, err error) {
+				require.ErrorIs(t, err, domain.ErrRecipientNotFound)
+			},
+		},
+		{
+			name:       "LimitReached",
+			identifier: user.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CountRecipientLookupsSince(gomock.Any(), gomock.Any()).Times(1).Return(int64(3), nil)
+				store.EXPECT().GetUser(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().CreateRecipientLookup(gomock.Any(), gomock.Any()).Times(0)
+			},
+			check: func(t *testing.T, found recipient.Recipient, err error) {
+				require.ErrorIs(t, err, domain.ErrTooManyRequests)
+			},
+		},
+		{
+			name:       "InvalidIdentifier",
+			identifier: "Not A Username",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CountRecipientLookupsSince(gomock.Any(), gomock.Any()).Times(0)
+			},
+			check: func(t *testing.T, found recipient.Recipient, err error) {
+				require.ErrorIs(t, err, domain.ErrInvalidArgument)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			resolver := recipient.NewResolver(store, 3)
+			found, err := resolver.Resolve(context.Background(), request(tc.identifier))
+			tc.check(t, found, err)
+		})
+	}
+}
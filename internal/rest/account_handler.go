@@ -1,46 +1,62 @@
 package rest
 
 import (
-	"database/sql"
 	"errors"
+	"fmt"
 	"net/http"
 
+	"github.com/NhutHuyDev/sgbank/internal/bank"
+	"github.com/NhutHuyDev/sgbank/internal/domain"
 	"github.com/NhutHuyDev/sgbank/internal/infra/db"
+	"github.com/NhutHuyDev/sgbank/internal/pagination"
 	"github.com/NhutHuyDev/sgbank/internal/token"
 	"github.com/gin-gonic/gin"
-	"github.com/lib/pq"
 )
 
 type CreateAccountDTO struct {
-	Currency string `json:"currency" binding:"required,oneof=USD EUR CAD"`
+	Currency string `json:"currency" binding:"required,currency"`
+	// Product defaults to a checking account.
+	Product string `json:"product"`
 }
 
 func (server *Server) createAccountHandler(ctx *gin.Context) {
 	var req CreateAccountDTO
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		writeError(ctx, bindingError(err))
+		return
+	}
+
+	if req.Product == "" {
+		req.Product = bank.ProductChecking
+	}
+
+	product, err := server.Store.GetAccountProduct(ctx, req.Product)
+	if errors.Is(err, domain.ErrNotFound) || (err == nil && product.IsInternal) {
+		err = domain.NewValidationError(domain.FieldViolation{Field: "product", Description: "unknown account product"})
+	}
+	if err != nil {
+		writeError(ctx, err)
 		return
 	}
 
 	authPayload := ctx.MustGet(AuthorizationPayloadKey).(*token.Payload)
+	accountNumber, err := server.AccountNumbers.Generate()
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
 
 	arg := db.CreateAccountParams{
-		Owner:    authPayload.Username,
-		Currency: req.Currency,
-		Balance:  0,
+		Owner:         authPayload.Username,
+		Currency:      req.Currency,
+		Balance:       0,
+		AccountNumber: accountNumber,
+		Product:       product.Code,
 	}
 
-	account, err := server.Store.CreateAccount(ctx, arg)
+	account, err := server.Store.CreateAccountTx(ctx, arg)
 	if err != nil {
-		if pqErr, ok := err.(*pq.Error); ok {
-			switch pqErr.Code.Name() {
-			case "foreign_key_violation", "unique_violation":
-				ctx.JSON(http.StatusForbidden, errorResponse(err))
-				return
-			}
-		}
-
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		writeError(ctx, err)
 		return
 	}
 
@@ -50,7 +66,7 @@ func (server *Server) createAccountHandler(ctx *gin.Context) {
 }
 
 type GetAccountDTO struct {
-	ID int64 `uri:"id" binding:"required,min=1"`
+	ID string `uri:"id" binding:"required"`
 }
 
 type GetAccountRes struct {
@@ -58,40 +74,36 @@ type GetAccountRes struct {
 }
 
 // GetAccount godoc
-// @Summary      Get account by ID
-// @Description  Get account detail by account ID. Only the account owner can access this resource.
+// @Summary      Get account by ID or account number
+// @Description  Get account detail by internal account ID or external account number. Only the account owner can access this resource.
 // @Tags         accounts
 // @Produce      json
-// @Param        id   path      int64  true  "Account ID (must be >= 1)"
+// @Param        id   path      string  true  "Account ID (must be >= 1) or account number"
 // @Success      200  {object}  GetAccountRes
-// @Failure      400  {object}  map[string]string "Invalid account ID"
-// @Failure      401  {object}  map[string]string "Unauthorized or account does not belong to user"
-// @Failure      404  {object}  map[string]string "Account not found"
-// @Failure      500  {object}  map[string]string "Internal server error"
+// @Failure      400  {object}  domain.Problem "Invalid account ID"
+// @Failure      401  {object}  domain.Problem "Unauthenticated"
+// @Failure      403  {object}  domain.Problem "Account does not belong to user"
+// @Failure      404  {object}  domain.Problem "Account not found"
+// @Failure      500  {object}  domain.Problem "Internal server error"
 // @Security     BearerAuth
 // @Router       /accounts/{id} [get]
 func (server *Server) getAccountHandler(ctx *gin.Context) {
 	var req GetAccountDTO
 	if err := ctx.ShouldBindUri(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		writeError(ctx, bindingError(err))
 		return
 	}
 
-	account, err := server.Store.GetAccount(ctx, req.ID)
+	account, err := server.getAccountByRef(ctx, "id", req.ID)
 	if err != nil {
-		if err == sql.ErrNoRows {
-			ctx.JSON(http.StatusNotFound, errorResponse(err))
-			return
-		}
-
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		writeError(ctx, err)
 		return
 	}
 
 	authPayload := ctx.MustGet(AuthorizationPayloadKey).(*token.Payload)
 	if authPayload.Username != account.Owner {
-		err := errors.New("account doestn't belong to the authenticated user")
-		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+		err := fmt.Errorf("%w: account doesn't belong to the authenticated user", domain.ErrForbidden)
+		writeError(ctx, err)
 		return
 	}
 
@@ -101,36 +113,49 @@ func (server *Server) getAccountHandler(ctx *gin.Context) {
 }
 
 type listAccountsDTO struct {
-	PageID   int32 `form:"page_id" binding:"required,min=1"`
-	PageSize int32 `form:"page_size" binding:"required,min=5,max=10"`
+	PageSize int32  `form:"page_size" binding:"omitempty,min=1,max=100"`
+	Cursor   string `form:"cursor"`
+}
+
+type ListAccountsRes struct {
+	Accounts   []db.Account `json:"accounts"`
+	NextCursor string       `json:"next_cursor,omitempty"`
 }
 
 func (server *Server) listAccountsHandler(ctx *gin.Context) {
 	var req listAccountsDTO
 	if err := ctx.ShouldBindQuery(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		writeError(ctx, bindingError(err))
 		return
 	}
 
 	authPayload := ctx.MustGet(AuthorizationPayloadKey).(*token.Payload)
-	arg := db.ListAccountsParams{
-		Owner:  authPayload.Username,
-		Limit:  req.PageSize,
-		Offset: (req.PageID - 1) * req.PageSize,
+	page, err := server.Paginator.Page(req.PageSize, string(db.SortAsc), req.Cursor, "accounts|"+authPayload.Username)
+	if err != nil {
+		writeError(ctx, err)
+		return
 	}
 
-	accounts, err := server.Store.ListAccounts(ctx, arg)
-	if err != nil {
-		if err == sql.ErrNoRows {
-			ctx.JSON(http.StatusNotFound, errorResponse(err))
-			return
-		}
+	arg := db.ListAccountsAfterParams{
+		Owner:     authPayload.Username,
+		PageLimit: page.Limit(),
+	}
+	if page.After != nil {
+		arg.AfterID = page.After.ID
+	}
 
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+	accounts, err := server.Store.ListAccountsAfter(ctx, arg)
+	if err != nil {
+		writeError(ctx, err)
 		return
 	}
 
-	ctx.JSON(http.StatusOK, gin.H{
-		"accounts": accounts,
+	accounts, nextCursor := pagination.Trim(server.Paginator, page, accounts, func(account db.Account) pagination.Key {
+		return pagination.Key{ID: account.ID}
+	})
+
+	ctx.JSON(http.StatusOK, ListAccountsRes{
+		Accounts:   accounts,
+		NextCursor: nextCursor,
 	})
 }
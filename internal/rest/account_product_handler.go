@@ -0,0 +1,49 @@
+package rest
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AccountProductRes struct {
+	Code string `json:"code"`
+	Name string `json:"name"`
+	// AnnualRate is the nominal annual interest rate in parts per million.
+	AnnualRate int64 `json:"annual_rate_ppm"`
+	// OverdraftRate is the annual rate charged on overdrawn balances in
+	// parts per million.
+	OverdraftRate int64 `json:"overdraft_rate_ppm"`
+}
+
+type ListAccountProductsRes struct {
+	Products []AccountProductRes `json:"products"`
+}
+
+// ListAccountProducts godoc
+// @Summary      List account products
+// @Description  List the products an account can be opened with and their interest rates. Interest accrues daily on the balance at the end of each day (UTC) and is credited monthly.
+// @Tags         accounts
+// @Produce      json
+// @Success      200  {object}  ListAccountProductsRes
+// @Security     BearerAuth
+// @Router       /v1/account-products [get]
+func (server *Server) listAccountProductsHandler(ctx *gin.Context) {
+	products, err := server.Store.ListAccountProducts(ctx)
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	res := ListAccountProductsRes{Products: make([]AccountProductRes, 0, len(products))}
+	for _, product := range products {
+		res.Products = append(res.Products, AccountProductRes{
+			Code:          product.Code,
+			Name:          product.Name,
+			AnnualRate:    product.AnnualRatePpm,
+			OverdraftRate: product.OverdraftRatePpm,
+		})
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
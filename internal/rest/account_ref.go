@@ -0,0 +1,34 @@
+package rest
+
+import (
+	"strconv"
+
+	"github.com/NhutHuyDev/sgbank/internal/accountno"
+	"github.com/NhutHuyDev/sgbank/internal/domain"
+	"github.com/NhutHuyDev/sgbank/internal/infra/db"
+	"github.com/gin-gonic/gin"
+)
+
+// getAccountByRef loads the account identified by ref, which is either the
+// internal account ID or the external account number. field names the
+// request parameter ref came from in validation errors.
+func (server *Server) getAccountByRef(ctx *gin.Context, field string, ref string) (db.Account, error) {
+	if accountno.IsAccountNumber(ref) {
+		number := accountno.Normalize(ref)
+		if err := accountno.Validate(number); err != nil {
+			return db.Account{}, domain.NewValidationError(domain.FieldViolation{Field: field, Description: err.Error()})
+		}
+
+		return server.Store.GetAccountByNumber(ctx, number)
+	}
+
+	id, err := strconv.ParseInt(ref, 10, 64)
+	if err != nil || id < 1 {
+		return db.Account{}, domain.NewValidationError(domain.FieldViolation{
+			Field:       field,
+			Description: "must be an account ID >= 1 or an account number",
+		})
+	}
+
+	return server.Store.GetAccount(ctx, id)
+}
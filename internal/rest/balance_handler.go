@@ -0,0 +1,174 @@
+package rest
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/NhutHuyDev/sgbank/internal/balance"
+	"github.com/NhutHuyDev/sgbank/internal/domain"
+	"github.com/NhutHuyDev/sgbank/internal/token"
+	"github.com/gin-gonic/gin"
+)
+
+type getBalanceDTO struct {
+	At *time.Time `form:"at"`
+}
+
+type AccountBalanceRes struct {
+	AccountID      int64     `json:"account_id"`
+	Currency       string    `json:"currency"`
+	At             time.Time `json:"at"`
+	Balance        int64     `json:"balance"`
+	BalanceDecimal string    `json:"balance_decimal"`
+}
+
+type balanceHistoryDTO struct {
+	Interval string     `form:"interval" binding:"omitempty,oneof=day week month"`
+	From     *time.Time `form:"from"`
+	To       *time.Time `form:"to"`
+}
+
+type BalanceHistoryRes struct {
+	AccountID int64             `json:"account_id"`
+	Currency  string            `json:"currency"`
+	Interval  string            `json:"interval"`
+	Points    []BalancePointRes `json:"points"`
+}
+
+type BalancePointRes struct {
+	balance.Point
+	ClosingDecimal string `json:"closing_decimal"`
+}
+
+// GetAccountBalance godoc
+// @Summary      Get the balance of an account at a time
+// @Description  Get the balance of an account after every entry created before a time, now by default.
+// @Tags         accounts
+// @Produce      json
+// @Param        id  path      string  true  "Account ID or account number"
+// @Param        at  query     string  false  "RFC 3339 time"
+// @Success      200  {object}  AccountBalanceRes
+// @Failure      400  {object}  domain.Problem "Invalid parameters"
+// @Failure      403  {object}  domain.Problem "Not the owner of the account"
+// @Failure      404  {object}  domain.Problem "Not found"
+// @Security     BearerAuth
+// @Router       /v1/accounts/{id}/balance [get]
+func (server *Server) getAccountBalanceHandler(ctx *gin.Context) {
+	var uri GetAccountDTO
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		writeError(ctx, bindingError(err))
+		return
+	}
+
+	var req getBalanceDTO
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		writeError(ctx, bindingError(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(AuthorizationPayloadKey).(*token.Payload)
+	account, err := server.getAccountByRef(ctx, "id", uri.ID)
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	if account.Owner != authPayload.Username {
+		writeError(ctx, domain.ErrForbidden)
+		return
+	}
+
+	at := time.Now()
+	if req.At != nil {
+		at = *req.At
+	}
+
+	amount, err := server.Balances.At(ctx, account, at)
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, AccountBalanceRes{
+		AccountID:      account.ID,
+		Currency:       account.Currency,
+		At:             at,
+		Balance:        amount,
+		BalanceDecimal: server.Currencies.Registry().Format(amount, account.Currency),
+	})
+}
+
+// GetBalanceHistory godoc
+// @Summary      Get the balance history of an account
+// @Description  Get the opening and closing balance, credits and debits of an account by UTC day, week (from Monday) or month, from the bucket "from" is in up to "to". Defaults to the last 30 days, 12 weeks or 12 months; at most 400 buckets.
+// @Tags         accounts
+// @Produce      json
+// @Param        id        path      string  true   "Account ID or account number"
+// @Param        interval  query     string  false  "day, week or month"
+// @Param        from      query     string  false  "RFC 3339 time"
+// @Param        to        query     string  false  "RFC 3339 time"
+// @Success      200  {object}  BalanceHistoryRes
+// @Failure      400  {object}  domain.Problem "Invalid parameters"
+// @Failure      403  {object}  domain.Problem "Not the owner of the account"
+// @Failure      404  {object}  domain.Problem "Not found"
+// @Security     BearerAuth
+// @Router       /v1/accounts/{id}/balance-history [get]
+func (server *Server) getBalanceHistoryHandler(ctx *gin.Context) {
+	var uri GetAccountDTO
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		writeError(ctx, bindingError(err))
+		return
+	}
+
+	var req balanceHistoryDTO
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		writeError(ctx, bindingError(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(AuthorizationPayloadKey).(*token.Payload)
+	account, err := server.getAccountByRef(ctx, "id", uri.ID)
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	if account.Owner != authPayload.Username {
+		writeError(ctx, domain.ErrForbidden)
+		return
+	}
+
+	arg := balance.HistoryParams{Interval: req.Interval}
+	if req.From != nil {
+		arg.From = *req.From
+	}
+	if req.To != nil {
+		arg.To = *req.To
+	}
+
+	points, err := server.Balances.History(ctx, account, arg)
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	if arg.Interval == "" {
+		arg.Interval = balance.IntervalDay
+	}
+
+	registry := server.Currencies.Registry()
+	res := BalanceHistoryRes{
+		AccountID: account.ID,
+		Currency:  account.Currency,
+		Interval:  arg.Interval,
+		Points:    make([]BalancePointRes, 0, len(points)),
+	}
+	for _, point := range points {
+		res.Points = append(res.Points, BalancePointRes{
+			Point:          point,
+			ClosingDecimal: registry.Format(point.Closing, account.Currency),
+		})
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
@@ -0,0 +1,207 @@
+package rest
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/NhutHuyDev/sgbank/internal/infra/db"
+	"github.com/NhutHuyDev/sgbank/internal/pagination"
+	"github.com/NhutHuyDev/sgbank/internal/token"
+	"github.com/gin-gonic/gin"
+)
+
+type createBeneficiaryDTO struct {
+	AccountNumber string `json:"account_number" binding:"required,max=64"`
+	Nickname      string `json:"nickname" binding:"required,min=1,max=100"`
+	Currency      string `json:"currency" binding:"required,currency"`
+}
+
+type updateBeneficiaryDTO struct {
+	Nickname string `json:"nickname" binding:"required,min=1,max=100"`
+}
+
+type getBeneficiaryDTO struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+type listBeneficiariesDTO struct {
+	PageSize int32  `form:"page_size" binding:"omitempty,min=1,max=100"`
+	Cursor   string `form:"cursor"`
+}
+
+type BeneficiaryRes struct {
+	ID              int64     `json:"id"`
+	AccountNumber   string    `json:"account_number"`
+	Nickname        string    `json:"nickname"`
+	Currency        string    `json:"currency"`
+	IsVerified      bool      `json:"is_verified"`
+	CoolingOffUntil time.Time `json:"cooling_off_until"`
+	CreatedAt       time.Time `json:"created_at"`
+}
+
+type ListBeneficiariesRes struct {
+	Beneficiaries []BeneficiaryRes `json:"beneficiaries"`
+	NextCursor    string           `json:"next_cursor,omitempty"`
+}
+
+func (server *Server) beneficiaryRes(beneficiary db.Beneficiary) BeneficiaryRes {
+	return BeneficiaryRes{
+		ID:              beneficiary.ID,
+		AccountNumber:   beneficiary.AccountNumber,
+		Nickname:        beneficiary.Nickname,
+		Currency:        beneficiary.Currency,
+		IsVerified:      beneficiary.IsVerified,
+		CoolingOffUntil: server.Beneficiaries.CoolingOffUntil(beneficiary),
+		CreatedAt:       beneficiary.CreatedAt,
+	}
+}
+
+// CreateBeneficiary godoc
+// @Summary      Save a beneficiary
+// @Description  Save a payee by account number. Transfers to a new beneficiary are limited during its cooling-off period.
+// @Tags         beneficiaries
+// @Accept       json
+// @Produce      json
+// @Param        request  body      createBeneficiaryDTO  true  "Beneficiary"
+// @Success      200  {object}  BeneficiaryRes
+// @Failure      400  {object}  domain.Problem "Invalid parameters or account number"
+// @Failure      409  {object}  domain.Problem "Beneficiary already saved"
+// @Security     BearerAuth
+// @Router       /v1/beneficiaries [post]
+func (server *Server) createBeneficiaryHandler(ctx *gin.Context) {
+	var req createBeneficiaryDTO
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		writeError(ctx, bindingError(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(AuthorizationPayloadKey).(*token.Payload)
+
+	beneficiary, err := server.Beneficiaries.Create(ctx, db.CreateBeneficiaryParams{
+		Owner:         authPayload.Username,
+		AccountNumber: req.AccountNumber,
+		Nickname:      req.Nickname,
+		Currency:      req.Currency,
+	})
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, server.beneficiaryRes(beneficiary))
+}
+
+func (server *Server) getBeneficiaryHandler(ctx *gin.Context) {
+	var req getBeneficiaryDTO
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		writeError(ctx, bindingError(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(AuthorizationPayloadKey).(*token.Payload)
+
+	beneficiary, err := server.Beneficiaries.Get(ctx, authPayload.Username, req.ID)
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, server.beneficiaryRes(beneficiary))
+}
+
+func (server *Server) listBeneficiariesHandler(ctx *gin.Context) {
+	var req listBeneficiariesDTO
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		writeError(ctx, bindingError(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(AuthorizationPayloadKey).(*token.Payload)
+	page, err := server.Paginator.Page(req.PageSize, string(db.SortAsc), req.Cursor, "beneficiaries|"+authPayload.Username)
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	arg := db.ListBeneficiariesAfterParams{
+		Owner:     authPayload.Username,
+		PageLimit: page.Limit(),
+	}
+	if page.After != nil {
+		arg.AfterID = page.After.ID
+	}
+
+	beneficiaries, err := server.Store.ListBeneficiariesAfter(ctx, arg)
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	beneficiaries, nextCursor := pagination.Trim(server.Paginator, page, beneficiaries, func(beneficiary db.Beneficiary) pagination.Key {
+		return pagination.Key{ID: beneficiary.ID}
+	})
+
+	res := ListBeneficiariesRes{
+		Beneficiaries: make([]BeneficiaryRes, 0, len(beneficiaries)),
+		NextCursor:    nextCursor,
+	}
+	for _, beneficiary := range beneficiaries {
+		res.Beneficiaries = append(res.Beneficiaries, server.beneficiaryRes(beneficiary))
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (server *Server) updateBeneficiaryHandler(ctx *gin.Context) {
+	var uri getBeneficiaryDTO
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		writeError(ctx, bindingError(err))
+		return
+	}
+
+	var req updateBeneficiaryDTO
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		writeError(ctx, bindingError(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(AuthorizationPayloadKey).(*token.Payload)
+
+	if _, err := server.Beneficiaries.Get(ctx, authPayload.Username, uri.ID); err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	beneficiary, err := server.Store.UpdateBeneficiaryNickname(ctx, db.UpdateBeneficiaryNicknameParams{
+		ID:       uri.ID,
+		Nickname: req.Nickname,
+	})
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, server.beneficiaryRes(beneficiary))
+}
+
+func (server *Server) deleteBeneficiaryHandler(ctx *gin.Context) {
+	var req getBeneficiaryDTO
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		writeError(ctx, bindingError(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(AuthorizationPayloadKey).(*token.Payload)
+
+	if _, err := server.Beneficiaries.Get(ctx, authPayload.Username, req.ID); err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	if err := server.Store.DeleteBeneficiary(ctx, req.ID); err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
@@ -0,0 +1,138 @@
+package rest
+
+import (
+	"database/sql"
+	"net/http"
+	"time"
+
+	"github.com/NhutHuyDev/sgbank/internal/infra/db"
+	"github.com/NhutHuyDev/sgbank/internal/token"
+	"github.com/gin-gonic/gin"
+)
+
+type createCategorizationRuleDTO struct {
+	Category              string `json:"category" binding:"required"`
+	DescriptionContains   string `json:"description_contains"`
+	MerchantCategory      string `json:"merchant_category"`
+	CounterpartyAccountID int64  `json:"counterparty_account_id" binding:"omitempty,min=1"`
+	Priority              int32  `json:"priority"`
+}
+
+type getCategorizationRuleDTO struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+type CategorizationRuleRes struct {
+	ID                    int64     `json:"id"`
+	Category              string    `json:"category"`
+	DescriptionContains   string    `json:"description_contains,omitempty"`
+	MerchantCategory      string    `json:"merchant_category,omitempty"`
+	CounterpartyAccountID int64     `json:"counterparty_account_id,omitempty"`
+	Priority              int32     `json:"priority"`
+	CreatedAt             time.Time `json:"created_at"`
+}
+
+type ListCategorizationRulesRes struct {
+	Rules []CategorizationRuleRes `json:"rules"`
+}
+
+func categorizationRuleRes(rule db.CategorizationRule) CategorizationRuleRes {
+	return CategorizationRuleRes{
+		ID:                    rule.ID,
+		Category:              rule.Category,
+		DescriptionContains:   rule.DescriptionContains,
+		MerchantCategory:      rule.MerchantCategory,
+		CounterpartyAccountID: rule.CounterpartyAccountID.Int64,
+		Priority:              rule.Priority,
+		CreatedAt:             rule.CreatedAt,
+	}
+}
+
+// CreateCategorizationRule godoc
+// @Summary      Create a categorization rule
+// @Description  Entries of transfers posted afterwards take the category of the highest-priority rule matching their description (case-insensitive substring), merchant category and counterparty account.
+// @Tags         categorization-rules
+// @Accept       json
+// @Produce      json
+// @Param        request  body      createCategorizationRuleDTO  true  "Rule"
+// @Success      200  {object}  CategorizationRuleRes
+// @Failure      400  {object}  domain.Problem "Invalid parameters"
+// @Failure      404  {object}  domain.Problem "Counterparty account not found"
+// @Security     BearerAuth
+// @Router       /v1/categorization-rules [post]
+func (server *Server) createCategorizationRuleHandler(ctx *gin.Context) {
+	var req createCategorizationRuleDTO
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		writeError(ctx, bindingError(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(AuthorizationPayloadKey).(*token.Payload)
+
+	rule, err := server.Categories.Create(ctx, db.CreateCategorizationRuleParams{
+		Owner:                 authPayload.Username,
+		Category:              req.Category,
+		DescriptionContains:   req.DescriptionContains,
+		MerchantCategory:      req.MerchantCategory,
+		CounterpartyAccountID: sql.NullInt64{Int64: req.CounterpartyAccountID, Valid: req.CounterpartyAccountID != 0},
+		Priority:              req.Priority,
+	})
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, categorizationRuleRes(rule))
+}
+
+// ListCategorizationRules godoc
+// @Summary      List categorization rules
+// @Description  List the authenticated user's rules in the order they are tried.
+// @Tags         categorization-rules
+// @Produce      json
+// @Success      200  {object}  ListCategorizationRulesRes
+// @Security     BearerAuth
+// @Router       /v1/categorization-rules [get]
+func (server *Server) listCategorizationRulesHandler(ctx *gin.Context) {
+	authPayload := ctx.MustGet(AuthorizationPayloadKey).(*token.Payload)
+
+	rules, err := server.Categories.List(ctx, authPayload.Username)
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	res := ListCategorizationRulesRes{Rules: make([]CategorizationRuleRes, 0, len(rules))}
+	for _, rule := range rules {
+		res.Rules = append(res.Rules, categorizationRuleRes(rule))
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+// DeleteCategorizationRule godoc
+// @Summary      Delete a categorization rule
+// @Description  Entries already categorized by the rule keep their category.
+// @Tags         categorization-rules
+// @Param        id   path      int  true  "Rule ID"
+// @Success      204
+// @Failure      403  {object}  domain.Problem "Rule belongs to another user"
+// @Failure      404  {object}  domain.Problem "Rule not found"
+// @Security     BearerAuth
+// @Router       /v1/categorization-rules/{id} [delete]
+func (server *Server) deleteCategorizationRuleHandler(ctx *gin.Context) {
+	var req getCategorizationRuleDTO
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		writeError(ctx, bindingError(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(AuthorizationPayloadKey).(*token.Payload)
+
+	if err := server.Categories.Delete(ctx, authPayload.Username, req.ID); err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
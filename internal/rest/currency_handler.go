@@ -0,0 +1,119 @@
+package rest
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/NhutHuyDev/sgbank/internal/currency"
+	"github.com/NhutHuyDev/sgbank/internal/domain"
+	"github.com/NhutHuyDev/sgbank/internal/money"
+	"github.com/gin-gonic/gin"
+)
+
+type upsertCurrencyDTO struct {
+	Name     string `json:"name" binding:"required"`
+	Exponent *int32 `json:"exponent" binding:"required"`
+	Enabled  bool   `json:"enabled"`
+}
+
+type currencyCodeDTO struct {
+	Code string `uri:"code" binding:"required"`
+}
+
+type ListCurrenciesRes struct {
+	Currencies []CurrencyRes `json:"currencies"`
+}
+
+type CurrencyRes struct {
+	Code     string `json:"code"`
+	Name     string `json:"name"`
+	Exponent int32  `json:"exponent"`
+}
+
+// ListCurrencies godoc
+// @Summary      List currencies
+// @Description  List the currencies accounts and transfers may use, with the number of decimal places of their minor unit.
+// @Tags         currencies
+// @Produce      json
+// @Success      200  {object}  ListCurrenciesRes
+// @Failure      401  {object}  domain.Problem "Unauthenticated"
+// @Security     BearerAuth
+// @Router       /v1/currencies [get]
+func (server *Server) listCurrenciesHandler(ctx *gin.Context) {
+	enabled := server.Currencies.Registry().Enabled()
+
+	res := ListCurrenciesRes{Currencies: make([]CurrencyRes, 0, len(enabled))}
+	for _, c := range enabled {
+		res.Currencies = append(res.Currencies, CurrencyRes{Code: c.Code, Name: c.Name, Exponent: c.Exponent})
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+// UpsertCurrency godoc
+// @Summary      Add, enable or disable a currency
+// @Description  Add an ISO 4217 currency or rename, enable or disable an existing one. The exponent of an existing currency cannot change. Other instances pick the change up at their next refresh. Admins only.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        code  path      string             true  "ISO 4217 code"
+// @Param        body  body      upsertCurrencyDTO  true  "Currency"
+// @Success      200  {object}  db.Currency
+// @Failure      400  {object}  domain.Problem "Invalid parameters"
+// @Failure      403  {object}  domain.Problem "Not an admin"
+// @Security     BearerAuth
+// @Router       /v1/admin/currencies/{code} [put]
+func (server *Server) upsertCurrencyHandler(ctx *gin.Context) {
+	var uri currencyCodeDTO
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		writeError(ctx, bindingError(err))
+		return
+	}
+
+	var req upsertCurrencyDTO
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		writeError(ctx, bindingError(err))
+		return
+	}
+
+	result, err := server.Currencies.Upsert(ctx, currency.UpsertParams{
+		Code:     uri.Code,
+		Name:     req.Name,
+		Exponent: *req.Exponent,
+		Enabled:  req.Enabled,
+	})
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+// positiveAmount resolves the amount field of a request in code and
+// requires it to be greater than zero.
+func (server *Server) positiveAmount(input money.Input, code string) (int64, error) {
+	invalid := func(description string) error {
+		return domain.NewValidationError(domain.FieldViolation{Field: "amount", Description: description})
+	}
+
+	if !input.IsSet() {
+		return 0, invalid("failed on the 'required' rule")
+	}
+
+	c, ok := server.Currencies.Registry().Lookup(code)
+	if !ok {
+		return 0, domain.NewValidationError(domain.FieldViolation{Field: "currency", Description: "unsupported currency"})
+	}
+
+	amount, err := input.Resolve(c)
+	if err != nil {
+		return 0, invalid(err.Error())
+	}
+
+	if amount.Minor <= 0 {
+		return 0, invalid(fmt.Sprintf("must be greater than 0 %s", c.Code))
+	}
+
+	return amount.Minor, nil
+}
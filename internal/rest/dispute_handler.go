@@ -0,0 +1,598 @@
+package rest
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/NhutHuyDev/sgbank/internal/dispute"
+	"github.com/NhutHuyDev/sgbank/internal/domain"
+	"github.com/NhutHuyDev/sgbank/internal/infra/db"
+	"github.com/NhutHuyDev/sgbank/internal/token"
+	"github.com/gin-gonic/gin"
+)
+
+// maxDisputeFormOverhead is the room left in a dispute form for the fields
+// other than its evidence files.
+const maxDisputeFormOverhead = 1 << 20
+
+type openDisputeDTO struct {
+	TransferID        int64  `json:"transfer_id" form:"transfer_id" binding:"required,min=1"`
+	Reason            string `json:"reason" form:"reason" binding:"required"`
+	Description       string `json:"description" form:"description"`
+	Amount            int64  `json:"amount" form:"amount" binding:"omitempty,min=1"`
+	ProvisionalCredit bool   `json:"provisional_credit" form:"provisional_credit"`
+}
+
+type disputeDTO struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+type disputeEvidenceDTO struct {
+	ID         int64 `uri:"id" binding:"required,min=1"`
+	EvidenceID int64 `uri:"evidence_id" binding:"required,min=1"`
+}
+
+type disputeNoteDTO struct {
+	Note string `json:"note" form:"note"`
+}
+
+type resolveDisputeDTO struct {
+	Outcome    string `json:"outcome" binding:"required,oneof=won lost"`
+	Resolution string `json:"resolution" binding:"required"`
+}
+
+type listDisputesDTO struct {
+	Status     string `form:"status" binding:"omitempty,oneof=open under_review won lost withdrawn"`
+	AssignedTo string `form:"assigned_to"`
+	AfterID    int64  `form:"after_id" binding:"omitempty,min=1"`
+	PageSize   int32  `form:"page_size" binding:"omitempty,min=1,max=100"`
+}
+
+// DisputeRes is a dispute; the IDs of its postings, assignee and
+// resolution are omitted until they are set.
+type DisputeRes struct {
+	ID                          int64      `json:"id"`
+	TransferID                  int64      `json:"transfer_id"`
+	Username                    string     `json:"username"`
+	Reason                      string     `json:"reason"`
+	Description                 string     `json:"description"`
+	Amount                      int64      `json:"amount"`
+	Currency                    string     `json:"currency"`
+	Status                      string     `json:"status"`
+	ProvisionalCreditTransferID *int64     `json:"provisional_credit_transfer_id,omitempty"`
+	ResolutionTransferID        *int64     `json:"resolution_transfer_id,omitempty"`
+	AssignedTo                  string     `json:"assigned_to,omitempty"`
+	Resolution                  string     `json:"resolution,omitempty"`
+	ResolvedBy                  string     `json:"resolved_by,omitempty"`
+	DueAt                       time.Time  `json:"due_at"`
+	Overdue                     bool       `json:"overdue"`
+	ResolvedAt                  *time.Time `json:"resolved_at,omitempty"`
+	CreatedAt                   time.Time  `json:"created_at"`
+}
+
+// DisputeEvidenceRes is a note or file given as evidence; the file fields
+// are omitted for a note alone.
+type DisputeEvidenceRes struct {
+	ID          int64     `json:"id"`
+	SubmittedBy string    `json:"submitted_by"`
+	Note        string    `json:"note,omitempty"`
+	FileName    string    `json:"file_name,omitempty"`
+	ContentType string    `json:"content_type,omitempty"`
+	Size        int64     `json:"size,omitempty"`
+	Sha256      string    `json:"sha256,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type DisputeEventRes struct {
+	Actor      string    `json:"actor"`
+	FromStatus string    `json:"from_status,omitempty"`
+	ToStatus   string    `json:"to_status"`
+	Note       string    `json:"note,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// DisputeCaseRes is a dispute with its evidence and history, oldest first.
+type DisputeCaseRes struct {
+	DisputeRes
+	Evidence []DisputeEvidenceRes `json:"evidence"`
+	Events   []DisputeEventRes    `json:"events"`
+}
+
+type OpenDisputeRes struct {
+	DisputeCaseRes
+	// ProvisionalCredit is omitted when no provisional credit was given.
+	ProvisionalCredit *db.TransferTxResult `json:"provisional_credit,omitempty"`
+}
+
+type TransitionDisputeRes struct {
+	Dispute DisputeRes `json:"dispute"`
+	// Transfer is the reversal of a won dispute or the recovery of a
+	// provisional credit, omitted when no money moved.
+	Transfer *db.TransferTxResult `json:"transfer,omitempty"`
+}
+
+type ListDisputesRes struct {
+	Disputes []DisputeRes `json:"disputes"`
+	// NextAfterID is the after_id of the next page, 0 on the last page.
+	NextAfterID int64 `json:"next_after_id,omitempty"`
+}
+
+func disputeRes(d db.Dispute) DisputeRes {
+	res := DisputeRes{
+		ID:          d.ID,
+		TransferID:  d.TransferID,
+		Username:    d.Username,
+		Reason:      d.Reason,
+		Description: d.Description,
+		Amount:      d.Amount,
+		Currency:    d.Currency,
+		Status:      d.Status,
+		AssignedTo:  d.AssignedTo.String,
+		Resolution:  d.Resolution,
+		ResolvedBy:  d.ResolvedBy.String,
+		DueAt:       d.DueAt,
+		Overdue:     dispute.Overdue(d, time.Now()),
+		ResolvedAt:  timeOrNil(d.ResolvedAt),
+		CreatedAt:   d.CreatedAt,
+	}
+
+	if d.ProvisionalCreditTransferID.Valid {
+		res.ProvisionalCreditTransferID = &d.ProvisionalCreditTransferID.Int64
+	}
+	if d.ResolutionTransferID.Valid {
+		res.ResolutionTransferID = &d.ResolutionTransferID.Int64
+	}
+
+	return res
+}
+
+func disputeEvidenceRes(evidence db.DisputeEvidence) DisputeEvidenceRes {
+	return DisputeEvidenceRes{
+		ID:          evidence.ID,
+		SubmittedBy: evidence.SubmittedBy,
+		Note:        evidence.Note,
+		FileName:    evidence.FileName,
+		ContentType: evidence.ContentType,
+		Size:        evidence.Size,
+		Sha256:      evidence.Sha256,
+		CreatedAt:   evidence.CreatedAt,
+	}
+}
+
+func disputeCaseRes(d db.Dispute, evidence []db.DisputeEvidence, events []db.DisputeEvent) DisputeCaseRes {
+	res := DisputeCaseRes{
+		DisputeRes: disputeRes(d),
+		Evidence:   make([]DisputeEvidenceRes, 0, len(evidence)),
+		Events:     make([]DisputeEventRes, 0, len(events)),
+	}
+
+	for _, item := range evidence {
+		res.Evidence = append(res.Evidence, disputeEvidenceRes(item))
+	}
+	for _, event := range events {
+		res.Events = append(res.Events, DisputeEventRes{
+			Actor:      event.Actor,
+			FromStatus: event.FromStatus,
+			ToStatus:   event.ToStatus,
+			Note:       event.Note,
+			CreatedAt:  event.CreatedAt,
+		})
+	}
+
+	return res
+}
+
+// maxDisputeFormSize bounds the body of a request with evidence files.
+func maxDisputeFormSize(files int) int64 {
+	return int64(files)*dispute.MaxEvidenceSize + maxDisputeFormOverhead
+}
+
+// OpenDispute godoc
+// @Summary      Dispute a transfer
+// @Description  Dispute a transfer sent from an account of the authenticated user, within the dispute window. Send JSON, or a multipart form with up to 5 evidence files (PDF, JPEG or PNG). The amount defaults to the whole transfer. A provisional credit of the amount is given at once when asked for and the amount is within the limit; it is taken back if the dispute is lost or withdrawn. A transfer has at most one dispute that was not withdrawn.
+// @Tags         disputes
+// @Accept       json,multipart/form-data
+// @Produce      json
+// @Param        body      body      openDisputeDTO  false  "Dispute"
+// @Param        evidence  formData  file            false  "Evidence file"
+// @Success      201  {object}  OpenDisputeRes
+// @Failure      400  {object}  domain.Problem "Invalid parameters"
+// @Failure      403  {object}  domain.Problem "Not a transfer of the user"
+// @Failure      404  {object}  domain.Problem "Transfer not found"
+// @Failure      409  {object}  domain.Problem "The transfer is already disputed"
+// @Failure      422  {object}  domain.Problem "The transfer is too old to be disputed"
+// @Security     BearerAuth
+// @Router       /v1/disputes [post]
+func (server *Server) openDisputeHandler(ctx *gin.Context) {
+	ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, maxDisputeFormSize(dispute.MaxFiles))
+
+	var req openDisputeDTO
+	if err := ctx.ShouldBind(&req); err != nil {
+		writeError(ctx, bindingError(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(AuthorizationPayloadKey).(*token.Payload)
+	arg := dispute.OpenParams{
+		Username:          authPayload.Username,
+		TransferID:        req.TransferID,
+		Reason:            req.Reason,
+		Description:       req.Description,
+		Amount:            req.Amount,
+		ProvisionalCredit: req.ProvisionalCredit,
+	}
+
+	if ctx.ContentType() == gin.MIMEMultipartPOSTForm {
+		form, err := ctx.MultipartForm()
+		if err != nil {
+			writeError(ctx, fmt.Errorf("%w: %v", domain.ErrInvalidArgument, err))
+			return
+		}
+		defer form.RemoveAll()
+
+		for _, header := range form.File["evidence"] {
+			file, err := header.Open()
+			if err != nil {
+				writeError(ctx, err)
+				return
+			}
+			defer file.Close()
+
+			arg.Files = append(arg.Files, dispute.File{FileName: header.Filename, Content: file})
+		}
+	}
+
+	result, err := server.Disputes.Open(ctx, arg)
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, OpenDisputeRes{
+		DisputeCaseRes:    disputeCaseRes(result.Dispute, result.Evidence, nil),
+		ProvisionalCredit: result.ProvisionalCredit,
+	})
+}
+
+// ListDisputes godoc
+// @Summary      List disputes
+// @Description  List the disputes of the authenticated user, newest first.
+// @Tags         disputes
+// @Produce      json
+// @Success      200  {object}  ListDisputesRes
+// @Security     BearerAuth
+// @Router       /v1/disputes [get]
+func (server *Server) listDisputesHandler(ctx *gin.Context) {
+	authPayload := ctx.MustGet(AuthorizationPayloadKey).(*token.Payload)
+
+	disputes, err := server.Disputes.List(ctx, authPayload.Username)
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	res := ListDisputesRes{Disputes: make([]DisputeRes, 0, len(disputes))}
+	for _, d := range disputes {
+		res.Disputes = append(res.Disputes, disputeRes(d))
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+// GetDispute godoc
+// @Summary      Get a dispute
+// @Description  Get a dispute of the authenticated user with its evidence and history.
+// @Tags         disputes
+// @Produce      json
+// @Param        id   path      int  true  "Dispute ID"
+// @Success      200  {object}  DisputeCaseRes
+// @Failure      403  {object}  domain.Problem "Not a dispute of the user"
+// @Failure      404  {object}  domain.Problem "Not found"
+// @Security     BearerAuth
+// @Router       /v1/disputes/{id} [get]
+func (server *Server) getDisputeHandler(ctx *gin.Context) {
+	var uri disputeDTO
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		writeError(ctx, bindingError(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(AuthorizationPayloadKey).(*token.Payload)
+
+	c, err := server.Disputes.Get(ctx, authPayload.Username, uri.ID)
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, disputeCaseRes(c.Dispute, c.Evidence, c.Events))
+}
+
+// AddDisputeEvidence godoc
+// @Summary      Add evidence to a dispute
+// @Description  Add a note, a file (PDF, JPEG or PNG) or both to an open or under review dispute of the authenticated user. Send JSON for a note alone, or a multipart form.
+// @Tags         disputes
+// @Accept       json,multipart/form-data
+// @Produce      json
+// @Param        id    path      int             true   "Dispute ID"
+// @Param        body  body      disputeNoteDTO  false  "Note"
+// @Param        file  formData  file            false  "Evidence file"
+// @Success      201  {object}  DisputeEvidenceRes
+// @Failure      400  {object}  domain.Problem "Invalid parameters"
+// @Failure      403  {object}  domain.Problem "Not a dispute of the user"
+// @Failure      404  {object}  domain.Problem "Not found"
+// @Failure      422  {object}  domain.Problem "The dispute is decided"
+// @Security     BearerAuth
+// @Router       /v1/disputes/{id}/evidence [post]
+func (server *Server) addDisputeEvidenceHandler(ctx *gin.Context) {
+	var uri disputeDTO
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		writeError(ctx, bindingError(err))
+		return
+	}
+
+	ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, maxDisputeFormSize(1))
+
+	var req disputeNoteDTO
+	if err := ctx.ShouldBind(&req); err != nil {
+		writeError(ctx, bindingError(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(AuthorizationPayloadKey).(*token.Payload)
+	arg := dispute.EvidenceParams{DisputeID: uri.ID, Username: authPayload.Username, Note: req.Note}
+
+	if ctx.ContentType() == gin.MIMEMultipartPOSTForm {
+		header, err := ctx.FormFile("file")
+		if err != nil && !errors.Is(err, http.ErrMissingFile) {
+			writeError(ctx, fmt.Errorf("%w: %v", domain.ErrInvalidArgument, err))
+			return
+		}
+
+		if header != nil {
+			file, err := header.Open()
+			if err != nil {
+				writeError(ctx, err)
+				return
+			}
+			defer file.Close()
+
+			arg.File = &dispute.File{FileName: header.Filename, Content: file}
+		}
+	}
+
+	evidence, err := server.Disputes.AddEvidence(ctx, arg)
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, disputeEvidenceRes(evidence))
+}
+
+// WithdrawDispute godoc
+// @Summary      Withdraw a dispute
+// @Description  Drop an open or under review dispute of the authenticated user. A provisional credit is taken back.
+// @Tags         disputes
+// @Accept       json
+// @Produce      json
+// @Param        id    path      int             true   "Dispute ID"
+// @Param        body  body      disputeNoteDTO  false  "Note"
+// @Success      200  {object}  TransitionDisputeRes
+// @Failure      403  {object}  domain.Problem "Not a dispute of the user"
+// @Failure      404  {object}  domain.Problem "Not found"
+// @Failure      422  {object}  domain.Problem "The dispute is decided"
+// @Security     BearerAuth
+// @Router       /v1/disputes/{id}/withdraw [post]
+func (server *Server) withdrawDisputeHandler(ctx *gin.Context) {
+	var uri disputeDTO
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		writeError(ctx, bindingError(err))
+		return
+	}
+
+	var req disputeNoteDTO
+	if err := ctx.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		writeError(ctx, bindingError(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(AuthorizationPayloadKey).(*token.Payload)
+
+	result, err := server.Disputes.Withdraw(ctx, authPayload.Username, uri.ID, req.Note)
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, TransitionDisputeRes{Dispute: disputeRes(result.Dispute), Transfer: result.Transfer})
+}
+
+// ListDisputeQueue godoc
+// @Summary      List disputes to handle
+// @Description  List disputes, oldest first, optionally by status and assignee. Admins only.
+// @Tags         admin
+// @Produce      json
+// @Param        status       query     string  false  "open, under_review, won, lost or withdrawn"
+// @Param        assigned_to  query     string  false  "Username of the assignee"
+// @Param        after_id     query     int     false  "Return disputes after this ID"
+// @Param        page_size    query     int     false  "Disputes per page, 1 to 100 (default 100)"
+// @Success      200  {object}  ListDisputesRes
+// @Failure      400  {object}  domain.Problem "Invalid parameters"
+// @Failure      403  {object}  domain.Problem "Not an admin"
+// @Security     BearerAuth
+// @Router       /v1/admin/disputes [get]
+func (server *Server) listDisputeQueueHandler(ctx *gin.Context) {
+	var req listDisputesDTO
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		writeError(ctx, bindingError(err))
+		return
+	}
+
+	if req.PageSize == 0 {
+		req.PageSize = dispute.PageSize
+	}
+
+	disputes, err := server.Disputes.Queue(ctx, dispute.QueueParams{
+		Status:     req.Status,
+		AssignedTo: req.AssignedTo,
+		AfterID:    req.AfterID,
+		PageSize:   req.PageSize,
+	})
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	res := ListDisputesRes{Disputes: make([]DisputeRes, 0, len(disputes))}
+	for _, d := range disputes {
+		res.Disputes = append(res.Disputes, disputeRes(d))
+	}
+	if len(disputes) == int(req.PageSize) {
+		res.NextAfterID = disputes[len(disputes)-1].ID
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+// GetDisputeCase godoc
+// @Summary      Get a dispute to handle
+// @Description  Get any dispute with its evidence and history. Admins only.
+// @Tags         admin
+// @Produce      json
+// @Param        id   path      int  true  "Dispute ID"
+// @Success      200  {object}  DisputeCaseRes
+// @Failure      403  {object}  domain.Problem "Not an admin"
+// @Failure      404  {object}  domain.Problem "Not found"
+// @Security     BearerAuth
+// @Router       /v1/admin/disputes/{id} [get]
+func (server *Server) getDisputeCaseHandler(ctx *gin.Context) {
+	var uri disputeDTO
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		writeError(ctx, bindingError(err))
+		return
+	}
+
+	c, err := server.Disputes.Case(ctx, uri.ID)
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, disputeCaseRes(c.Dispute, c.Evidence, c.Events))
+}
+
+// DownloadDisputeEvidence godoc
+// @Summary      Download a dispute evidence file
+// @Description  Download an evidence file of a dispute. Admins only.
+// @Tags         admin
+// @Produce      application/pdf,image/jpeg,image/png
+// @Param        id           path      int  true  "Dispute ID"
+// @Param        evidence_id  path      int  true  "Evidence ID"
+// @Success      200  {file}    file
+// @Failure      403  {object}  domain.Problem "Not an admin"
+// @Failure      404  {object}  domain.Problem "Not found"
+// @Security     BearerAuth
+// @Router       /v1/admin/disputes/{id}/evidence/{evidence_id} [get]
+func (server *Server) downloadDisputeEvidenceHandler(ctx *gin.Context) {
+	var uri disputeEvidenceDTO
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		writeError(ctx, bindingError(err))
+		return
+	}
+
+	evidence, content, err := server.Disputes.OpenEvidence(ctx, uri.ID, uri.EvidenceID)
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+	defer content.Close()
+
+	ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", evidence.FileName))
+	ctx.DataFromReader(http.StatusOK, evidence.Size, evidence.ContentType, content, nil)
+}
+
+// ReviewDispute godoc
+// @Summary      Take a dispute under review
+// @Description  Take an open dispute under review and assign it to the authenticated admin. The customer is notified. Admins only.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        id    path      int             true   "Dispute ID"
+// @Param        body  body      disputeNoteDTO  false  "Note"
+// @Success      200  {object}  TransitionDisputeRes
+// @Failure      403  {object}  domain.Problem "Not an admin"
+// @Failure      404  {object}  domain.Problem "Not found"
+// @Failure      422  {object}  domain.Problem "Not open"
+// @Security     BearerAuth
+// @Router       /v1/admin/disputes/{id}/review [post]
+func (server *Server) reviewDisputeHandler(ctx *gin.Context) {
+	var uri disputeDTO
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		writeError(ctx, bindingError(err))
+		return
+	}
+
+	var req disputeNoteDTO
+	if err := ctx.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		writeError(ctx, bindingError(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(AuthorizationPayloadKey).(*token.Payload)
+
+	result, err := server.Disputes.Review(ctx, uri.ID, authPayload.Username, req.Note)
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, TransitionDisputeRes{Dispute: disputeRes(result.Dispute)})
+}
+
+// ResolveDispute godoc
+// @Summary      Decide a dispute
+// @Description  Decide an open or under review dispute. A won dispute reverses the amount from the payee to the customer, or recovers the provisional credit from the payee; a lost one takes a provisional credit back from the customer. The customer is notified with the resolution. Admins only.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        id    path      int                true  "Dispute ID"
+// @Param        body  body      resolveDisputeDTO  true  "Outcome and resolution"
+// @Success      200  {object}  TransitionDisputeRes
+// @Failure      400  {object}  domain.Problem "Invalid parameters"
+// @Failure      403  {object}  domain.Problem "Not an admin"
+// @Failure      404  {object}  domain.Problem "Not found"
+// @Failure      422  {object}  domain.Problem "Already decided"
+// @Security     BearerAuth
+// @Router       /v1/admin/disputes/{id}/resolve [post]
+func (server *Server) resolveDisputeHandler(ctx *gin.Context) {
+	var uri disputeDTO
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		writeError(ctx, bindingError(err))
+		return
+	}
+
+	var req resolveDisputeDTO
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		writeError(ctx, bindingError(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(AuthorizationPayloadKey).(*token.Payload)
+
+	result, err := server.Disputes.Resolve(ctx, dispute.ResolveParams{
+		ID:         uri.ID,
+		Agent:      authPayload.Username,
+		Outcome:    dispute.Status(req.Outcome),
+		Resolution: req.Resolution,
+	})
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, TransitionDisputeRes{Dispute: disputeRes(result.Dispute), Transfer: result.Transfer})
+}
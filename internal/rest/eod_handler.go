@@ -0,0 +1,179 @@
+package rest
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/NhutHuyDev/sgbank/internal/domain"
+	"github.com/NhutHuyDev/sgbank/internal/eod"
+	"github.com/gin-gonic/gin"
+)
+
+type businessDateDTO struct {
+	Date string `uri:"date" binding:"required"`
+}
+
+type EODStatusRes struct {
+	// BusinessDate is the open business day postings go to.
+	BusinessDate string `json:"business_date"`
+	// Closing is the day whose end-of-day steps have not all succeeded.
+	Closing *BusinessDayRes `json:"closing,omitempty"`
+}
+
+type BusinessDayRes struct {
+	BusinessDate string       `json:"business_date"`
+	Status       string       `json:"status"`
+	OpenedAt     time.Time    `json:"opened_at"`
+	ClosingAt    *time.Time   `json:"closing_at,omitempty"`
+	ClosedAt     *time.Time   `json:"closed_at,omitempty"`
+	Steps        []EODStepRes `json:"steps"`
+}
+
+type EODStepRes struct {
+	Name       string     `json:"name"`
+	Position   int32      `json:"position"`
+	Status     string     `json:"status"`
+	Attempts   int32      `json:"attempts"`
+	Error      string     `json:"error,omitempty"`
+	StartedAt  *time.Time `json:"started_at,omitempty"`
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+}
+
+func businessDayRes(day eod.Day) BusinessDayRes {
+	res := BusinessDayRes{
+		BusinessDate: day.BusinessDate.Format(time.DateOnly),
+		Status:       day.Status,
+		OpenedAt:     day.OpenedAt,
+		ClosingAt:    timeOrNil(day.ClosingAt),
+		ClosedAt:     timeOrNil(day.ClosedAt),
+		Steps:        make([]EODStepRes, 0, len(day.Steps)),
+	}
+
+	for _, step := range day.Steps {
+		res.Steps = append(res.Steps, EODStepRes{
+			Name:       step.Name,
+			Position:   step.Position,
+			Status:     step.Status,
+			Attempts:   step.Attempts,
+			Error:      step.Error,
+			StartedAt:  timeOrNil(step.StartedAt),
+			FinishedAt: timeOrNil(step.FinishedAt),
+		})
+	}
+
+	return res
+}
+
+func timeOrNil(t sql.NullTime) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+	return &t.Time
+}
+
+// EODStatus godoc
+// @Summary      Get the business date
+// @Description  Get the open business day postings go to and the day that is closing, if any, with its end-of-day steps. Admins only.
+// @Tags         admin
+// @Produce      json
+// @Success      200  {object}  EODStatusRes
+// @Failure      403  {object}  domain.Problem "Not an admin"
+// @Security     BearerAuth
+// @Router       /v1/admin/eod [get]
+func (server *Server) eodStatusHandler(ctx *gin.Context) {
+	open, err := server.EndOfDay.BusinessDate(ctx)
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	res := EODStatusRes{BusinessDate: open.BusinessDate.Format(time.DateOnly)}
+
+	closing, err := server.EndOfDay.Closing(ctx)
+	switch {
+	case err == nil:
+		day := businessDayRes(closing)
+		res.Closing = &day
+	case !errors.Is(err, domain.ErrNotFound):
+		writeError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+// GetBusinessDay godoc
+// @Summary      Get a business day
+// @Description  Get a business day with the status of its end-of-day steps. Admins only.
+// @Tags         admin
+// @Produce      json
+// @Param        date  path      string  true  "Date as YYYY-MM-DD"
+// @Success      200  {object}  BusinessDayRes
+// @Failure      400  {object}  domain.Problem "Invalid parameters"
+// @Failure      403  {object}  domain.Problem "Not an admin"
+// @Failure      404  {object}  domain.Problem "Not found"
+// @Security     BearerAuth
+// @Router       /v1/admin/eod/days/{date} [get]
+func (server *Server) getBusinessDayHandler(ctx *gin.Context) {
+	var uri businessDateDTO
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		writeError(ctx, bindingError(err))
+		return
+	}
+
+	date, err := time.Parse(time.DateOnly, uri.Date)
+	if err != nil {
+		writeError(ctx, domain.NewValidationError(domain.FieldViolation{Field: "date", Description: "must be a date as YYYY-MM-DD"}))
+		return
+	}
+
+	day, err := server.EndOfDay.Day(ctx, date)
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, businessDayRes(day))
+}
+
+// CloseBusinessDay godoc
+// @Summary      Close the business day
+// @Description  Close the open business day now, open the next one and run the end-of-day steps of the closed day. A failed step leaves the day closing with the error on the step; resume it once the cause is fixed. Admins only.
+// @Tags         admin
+// @Produce      json
+// @Success      200  {object}  BusinessDayRes
+// @Failure      403  {object}  domain.Problem "Not an admin"
+// @Failure      422  {object}  domain.Problem "An earlier day is still closing"
+// @Security     BearerAuth
+// @Router       /v1/admin/eod/close [post]
+func (server *Server) closeBusinessDayHandler(ctx *gin.Context) {
+	day, err := server.EndOfDay.Close(ctx)
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, businessDayRes(day))
+}
+
+// ResumeBusinessDay godoc
+// @Summary      Resume the end of day
+// @Description  Run the end-of-day steps of the closing business day again from the first one that has not succeeded. Admins only.
+// @Tags         admin
+// @Produce      json
+// @Success      200  {object}  BusinessDayRes
+// @Failure      403  {object}  domain.Problem "Not an admin"
+// @Failure      404  {object}  domain.Problem "No day is closing"
+// @Security     BearerAuth
+// @Router       /v1/admin/eod/resume [post]
+func (server *Server) resumeBusinessDayHandler(ctx *gin.Context) {
+	day, err := server.EndOfDay.Resume(ctx)
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, businessDayRes(day))
+}
@@ -0,0 +1,440 @@
+package rest
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/NhutHuyDev/sgbank/internal/escrow"
+	"github.com/NhutHuyDev/sgbank/internal/infra/db"
+	"github.com/NhutHuyDev/sgbank/internal/money"
+	"github.com/NhutHuyDev/sgbank/internal/token"
+	"github.com/gin-gonic/gin"
+)
+
+type createEscrowDTO struct {
+	FromAccountID int64       `json:"from_account_id" binding:"required,min=1"`
+	ToAccountID   int64       `json:"to_account_id" binding:"required,min=1"`
+	Amount        money.Input `json:"amount" swaggertype:"string" example:"12.50"` // minor units, or a decimal string in major units
+	Currency      string      `json:"currency" binding:"required,currency"`
+	Description   string      `json:"description"`
+	// ReleaseAt defaults to ESCROW_DEFAULT_RELEASE_AFTER from now.
+	ReleaseAt time.Time `json:"release_at"`
+}
+
+type escrowDTO struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+type escrowNoteDTO struct {
+	Note string `json:"note"`
+}
+
+type disputeEscrowDTO struct {
+	Reason string `json:"reason" binding:"required"`
+}
+
+type resolveEscrowDTO struct {
+	Outcome string `json:"outcome" binding:"required,oneof=released refunded"`
+	Note    string `json:"note"`
+}
+
+type listEscrowsDTO struct {
+	Status   string `form:"status" binding:"omitempty,oneof=held disputed released refunded"`
+	AfterID  int64  `form:"after_id" binding:"omitempty,min=1"`
+	PageSize int32  `form:"page_size" binding:"omitempty,min=1,max=100"`
+}
+
+// EscrowRes is an escrow; its settlement and dispute fields are omitted
+// until they are set.
+type EscrowRes struct {
+	ID                   int64      `json:"id"`
+	Payer                string     `json:"payer"`
+	Payee                string     `json:"payee"`
+	FromAccountID        int64      `json:"from_account_id"`
+	ToAccountID          int64      `json:"to_account_id"`
+	Amount               int64      `json:"amount"`
+	Currency             string     `json:"currency"`
+	Description          string     `json:"description"`
+	Status               string     `json:"status"`
+	ReleaseAt            time.Time  `json:"release_at"`
+	Overdue              bool       `json:"overdue"`
+	FundingTransferID    int64      `json:"funding_transfer_id"`
+	SettlementTransferID *int64     `json:"settlement_transfer_id,omitempty"`
+	DisputeReason        string     `json:"dispute_reason,omitempty"`
+	DisputedAt           *time.Time `json:"disputed_at,omitempty"`
+	Note                 string     `json:"note,omitempty"`
+	SettledBy            string     `json:"settled_by,omitempty"`
+	SettledAt            *time.Time `json:"settled_at,omitempty"`
+	CreatedAt            time.Time  `json:"created_at"`
+}
+
+type CreateEscrowRes struct {
+	Escrow EscrowRes `json:"escrow"`
+	// Funding moves the amount from the payer into the escrow account.
+	Funding         db.TransferTxResult `json:"funding"`
+	FeeDetails      QuoteRes            `json:"fee_details"`
+	FraudDecisionID int64               `json:"fraud_decision_id"`
+}
+
+// HeldEscrowRes is a payment into escrow the fraud rules held for review.
+// The escrow is created once an admin approves the payment.
+type HeldEscrowRes struct {
+	FraudDecision FraudDecisionRes `json:"fraud_decision"`
+	FeeDetails    QuoteRes         `json:"fee_details"`
+}
+
+type SettleEscrowRes struct {
+	Escrow EscrowRes `json:"escrow"`
+	// Transfer pays the amount out of escrow, omitted for a dispute.
+	Transfer *db.TransferTxResult `json:"transfer,omitempty"`
+}
+
+type ListEscrowsRes struct {
+	Escrows []EscrowRes `json:"escrows"`
+	// NextAfterID is the after_id of the next page, 0 on the last page.
+	NextAfterID int64 `json:"next_after_id,omitempty"`
+}
+
+func escrowRes(e db.Escrow) EscrowRes {
+	res := EscrowRes{
+		ID:                e.ID,
+		Payer:             e.Payer,
+		Payee:             e.Payee,
+		FromAccountID:     e.FromAccountID,
+		ToAccountID:       e.ToAccountID,
+		Amount:            e.Amount,
+		Currency:          e.Currency,
+		Description:       e.Description,
+		Status:            e.Status,
+		ReleaseAt:         e.ReleaseAt,
+		Overdue:           escrow.Overdue(e, time.Now()),
+		FundingTransferID: e.FundingTransferID,
+		DisputeReason:     e.DisputeReason,
+		DisputedAt:        timeOrNil(e.DisputedAt),
+		Note:              e.Note,
+		SettledBy:         e.SettledBy.String,
+		SettledAt:         timeOrNil(e.SettledAt),
+		CreatedAt:         e.CreatedAt,
+	}
+
+	if e.SettlementTransferID.Valid {
+		res.SettlementTransferID = &e.SettlementTransferID.Int64
+	}
+
+	return res
+}
+
+func listEscrowsRes(escrows []db.Escrow) ListEscrowsRes {
+	res := ListEscrowsRes{Escrows: make([]EscrowRes, 0, len(escrows))}
+	for _, e := range escrows {
+		res.Escrows = append(res.Escrows, escrowRes(e))
+	}
+
+	return res
+}
+
+// CreateEscrow godoc
+// @Summary      Pay into escrow
+// @Description  Move an amount from an account of the authenticated user into the bank's escrow account for the owner of another customer's account. The payment goes through the same sanctions screening, KYC limits, fee and fraud rules as a transfer; a payment held for fraud review creates the escrow once an admin approves it. The payee is paid when the payer confirms, or at release_at unless the payer disputes first; the payer is refunded when the payee cancels. release_at defaults to ESCROW_DEFAULT_RELEASE_AFTER from now and is at most ESCROW_MAX_RELEASE_AFTER away.
+// @Tags         escrows
+// @Accept       json
+// @Produce      json
+// @Param        body  body      createEscrowDTO  true  "Escrow"
+// @Success      201  {object}  CreateEscrowRes
+// @Success      202  {object}  HeldEscrowRes
+// @Failure      400  {object}  domain.Problem "Invalid parameters"
+// @Failure      403  {object}  domain.Problem "Not an account of the user, or the payee is sanctioned"
+// @Failure      404  {object}  domain.Problem "Account not found"
+// @Failure      422  {object}  domain.Problem "Insufficient funds, currency mismatch, over the KYC limits or blocked by the fraud rules"
+// @Security     BearerAuth
+// @Router       /v1/escrows [post]
+func (server *Server) createEscrowHandler(ctx *gin.Context) {
+	var req createEscrowDTO
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		writeError(ctx, bindingError(err))
+		return
+	}
+
+	amount, err := server.positiveAmount(req.Amount, req.Currency)
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	toAccount, valid := server.isValidAccount(ctx, req.ToAccountID, req.Currency)
+	if !valid {
+		return
+	}
+
+	authPayload := ctx.MustGet(AuthorizationPayloadKey).(*token.Payload)
+
+	result, err := server.Escrows.Create(ctx, escrow.CreateParams{
+		Payer:         authPayload.Username,
+		FromAccountID: req.FromAccountID,
+		ToAccountID:   toAccount.ID,
+		Amount:        amount,
+		Currency:      req.Currency,
+		Description:   req.Description,
+		ReleaseAt:     req.ReleaseAt,
+		ClientIP:      ctx.ClientIP(),
+	})
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	if result.Held() {
+		ctx.JSON(http.StatusAccepted, HeldEscrowRes{
+			FraudDecision: server.fraudDecisionRes(result.Payment.Decision),
+			FeeDetails:    server.quoteRes(result.Payment.Fee),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, CreateEscrowRes{
+		Escrow:          escrowRes(result.Escrow),
+		Funding:         result.Funding,
+		FeeDetails:      server.quoteRes(result.Payment.Fee),
+		FraudDecisionID: result.Payment.Decision.ID,
+	})
+}
+
+// ListEscrows godoc
+// @Summary      List escrows
+// @Description  List the escrows the authenticated user pays or is paid by, newest first.
+// @Tags         escrows
+// @Produce      json
+// @Success      200  {object}  ListEscrowsRes
+// @Security     BearerAuth
+// @Router       /v1/escrows [get]
+func (server *Server) listEscrowsHandler(ctx *gin.Context) {
+	authPayload := ctx.MustGet(AuthorizationPayloadKey).(*token.Payload)
+
+	escrows, err := server.Escrows.List(ctx, authPayload.Username)
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, listEscrowsRes(escrows))
+}
+
+// GetEscrow godoc
+// @Summary      Get an escrow
+// @Description  Get an escrow the authenticated user pays or is paid by.
+// @Tags         escrows
+// @Produce      json
+// @Param        id   path      int  true  "Escrow ID"
+// @Success      200  {object}  EscrowRes
+// @Failure      403  {object}  domain.Problem "Not an escrow of the user"
+// @Failure      404  {object}  domain.Problem "Not found"
+// @Security     BearerAuth
+// @Router       /v1/escrows/{id} [get]
+func (server *Server) getEscrowHandler(ctx *gin.Context) {
+	var uri escrowDTO
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		writeError(ctx, bindingError(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(AuthorizationPayloadKey).(*token.Payload)
+
+	e, err := server.Escrows.Get(ctx, authPayload.Username, uri.ID)
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, escrowRes(e))
+}
+
+// ConfirmEscrow godoc
+// @Summary      Confirm an escrow
+// @Description  Release a held or disputed escrow the authenticated user pays to its payee. The payee is notified.
+// @Tags         escrows
+// @Produce      json
+// @Param        id   path      int  true  "Escrow ID"
+// @Success      200  {object}  SettleEscrowRes
+// @Failure      403  {object}  domain.Problem "Not the payer"
+// @Failure      404  {object}  domain.Problem "Not found"
+// @Failure      422  {object}  domain.Problem "Already released or refunded"
+// @Security     BearerAuth
+// @Router       /v1/escrows/{id}/confirm [post]
+func (server *Server) confirmEscrowHandler(ctx *gin.Context) {
+	var uri escrowDTO
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		writeError(ctx, bindingError(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(AuthorizationPayloadKey).(*token.Payload)
+
+	result, err := server.Escrows.Confirm(ctx, authPayload.Username, uri.ID)
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, SettleEscrowRes{Escrow: escrowRes(result.Escrow), Transfer: result.Transfer})
+}
+
+// CancelEscrow godoc
+// @Summary      Cancel an escrow
+// @Description  Refund a held or disputed escrow the authenticated user is paid by to its payer. The payer is notified.
+// @Tags         escrows
+// @Accept       json
+// @Produce      json
+// @Param        id    path      int            true   "Escrow ID"
+// @Param        body  body      escrowNoteDTO  false  "Note"
+// @Success      200  {object}  SettleEscrowRes
+// @Failure      403  {object}  domain.Problem "Not the payee"
+// @Failure      404  {object}  domain.Problem "Not found"
+// @Failure      422  {object}  domain.Problem "Already released or refunded"
+// @Security     BearerAuth
+// @Router       /v1/escrows/{id}/cancel [post]
+func (server *Server) cancelEscrowHandler(ctx *gin.Context) {
+	var uri escrowDTO
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		writeError(ctx, bindingError(err))
+		return
+	}
+
+	var req escrowNoteDTO
+	if err := ctx.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		writeError(ctx, bindingError(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(AuthorizationPayloadKey).(*token.Payload)
+
+	result, err := server.Escrows.Cancel(ctx, authPayload.Username, uri.ID, req.Note)
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, SettleEscrowRes{Escrow: escrowRes(result.Escrow), Transfer: result.Transfer})
+}
+
+// DisputeEscrow godoc
+// @Summary      Dispute an escrow
+// @Description  Stop a held escrow the authenticated user pays from being released at release_at, until an admin releases or refunds it. The payee and admins are notified.
+// @Tags         escrows
+// @Accept       json
+// @Produce      json
+// @Param        id    path      int               true  "Escrow ID"
+// @Param        body  body      disputeEscrowDTO  true  "Reason"
+// @Success      200  {object}  SettleEscrowRes
+// @Failure      400  {object}  domain.Problem "Invalid parameters"
+// @Failure      403  {object}  domain.Problem "Not the payer"
+// @Failure      404  {object}  domain.Problem "Not found"
+// @Failure      422  {object}  domain.Problem "Not held"
+// @Security     BearerAuth
+// @Router       /v1/escrows/{id}/dispute [post]
+func (server *Server) disputeEscrowHandler(ctx *gin.Context) {
+	var uri escrowDTO
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		writeError(ctx, bindingError(err))
+		return
+	}
+
+	var req disputeEscrowDTO
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		writeError(ctx, bindingError(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(AuthorizationPayloadKey).(*token.Payload)
+
+	result, err := server.Escrows.Dispute(ctx, authPayload.Username, uri.ID, req.Reason)
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, SettleEscrowRes{Escrow: escrowRes(result.Escrow)})
+}
+
+// ListEscrowQueue godoc
+// @Summary      List escrows to handle
+// @Description  List escrows, oldest first, optionally by status. Admins only.
+// @Tags         admin
+// @Produce      json
+// @Param        status     query     string  false  "held, disputed, released or refunded"
+// @Param        after_id   query     int     false  "Return escrows after this ID"
+// @Param        page_size  query     int     false  "Escrows per page, 1 to 100 (default 100)"
+// @Success      200  {object}  ListEscrowsRes
+// @Failure      400  {object}  domain.Problem "Invalid parameters"
+// @Failure      403  {object}  domain.Problem "Not an admin"
+// @Security     BearerAuth
+// @Router       /v1/admin/escrows [get]
+func (server *Server) listEscrowQueueHandler(ctx *gin.Context) {
+	var req listEscrowsDTO
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		writeError(ctx, bindingError(err))
+		return
+	}
+
+	if req.PageSize == 0 {
+		req.PageSize = escrow.PageSize
+	}
+
+	escrows, err := server.Escrows.Queue(ctx, req.Status, req.AfterID, req.PageSize)
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	res := listEscrowsRes(escrows)
+	if len(escrows) == int(req.PageSize) {
+		res.NextAfterID = escrows[len(escrows)-1].ID
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+// ResolveEscrow godoc
+// @Summary      Decide a disputed escrow
+// @Description  Release a disputed escrow to its payee or refund it to its payer. The party paid is notified. Admins only.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        id    path      int               true  "Escrow ID"
+// @Param        body  body      resolveEscrowDTO  true  "Outcome"
+// @Success      200  {object}  SettleEscrowRes
+// @Failure      400  {object}  domain.Problem "Invalid parameters"
+// @Failure      403  {object}  domain.Problem "Not an admin"
+// @Failure      404  {object}  domain.Problem "Not found"
+// @Failure      422  {object}  domain.Problem "Not disputed"
+// @Security     BearerAuth
+// @Router       /v1/admin/escrows/{id}/resolve [post]
+func (server *Server) resolveEscrowHandler(ctx *gin.Context) {
+	var uri escrowDTO
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		writeError(ctx, bindingError(err))
+		return
+	}
+
+	var req resolveEscrowDTO
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		writeError(ctx, bindingError(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(AuthorizationPayloadKey).(*token.Payload)
+
+	result, err := server.Escrows.Resolve(ctx, escrow.ResolveParams{
+		ID:      uri.ID,
+		Admin:   authPayload.Username,
+		Outcome: escrow.Status(req.Outcome),
+		Note:    req.Note,
+	})
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, SettleEscrowRes{Escrow: escrowRes(result.Escrow), Transfer: result.Transfer})
+}
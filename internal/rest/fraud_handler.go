@@ -0,0 +1,258 @@
+package rest
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/NhutHuyDev/sgbank/internal/fraud"
+	"github.com/NhutHuyDev/sgbank/internal/infra/db"
+	"github.com/NhutHuyDev/sgbank/internal/token"
+	"github.com/gin-gonic/gin"
+)
+
+type fraudDecisionDTO struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+type reviewFraudDecisionDTO struct {
+	Note string `json:"note"`
+}
+
+type listFraudReviewsDTO struct {
+	AfterID  int64 `form:"after_id" binding:"omitempty,min=1"`
+	PageSize int32 `form:"page_size" binding:"omitempty,min=1,max=100"`
+}
+
+// FraudDecisionRes is the risk assessment of a transfer. Hits are the rules
+// that matched, each with its score and details.
+type FraudDecisionRes struct {
+	ID            int64           `json:"id"`
+	Username      string          `json:"username"`
+	FromAccountID int64           `json:"from_account_id"`
+	ToAccountID   int64           `json:"to_account_id"`
+	Amount        int64           `json:"amount"`
+	AmountDecimal string          `json:"amount_decimal"`
+	Currency      string          `json:"currency"`
+	ClientIP      string          `json:"client_ip"`
+	Kind          string          `json:"kind"`
+	Score         int32           `json:"score"`
+	Outcome       string          `json:"outcome"`
+	Hits          json.RawMessage `json:"hits" swaggertype:"array,object"`
+	Status        string          `json:"status"`
+	TransferID    *int64          `json:"transfer_id,omitempty"`
+	ReviewedBy    string          `json:"reviewed_by,omitempty"`
+	ReviewNote    string          `json:"review_note,omitempty"`
+	ReviewedAt    *time.Time      `json:"reviewed_at,omitempty"`
+	CreatedAt     time.Time       `json:"created_at"`
+}
+
+type ListFraudReviewsRes struct {
+	Decisions []FraudDecisionRes `json:"decisions"`
+	// NextAfterID is the after_id of the next page, 0 on the last page.
+	NextAfterID int64 `json:"next_after_id,omitempty"`
+}
+
+type ApproveFraudDecisionRes struct {
+	FraudDecision FraudDecisionRes `json:"fraud_decision"`
+	db.TransferTxResult
+	// PaymentRequest is the request the transfer paid, if any.
+	PaymentRequest *PaymentRequestRes `json:"payment_request,omitempty"`
+	// TransferBatchItem is the batch row the transfer posted, if any.
+	TransferBatchItem *TransferBatchItemRes `json:"transfer_batch_item,omitempty"`
+	// Escrow is the escrow the transfer funded, if any.
+	Escrow *EscrowRes `json:"escrow,omitempty"`
+}
+
+func (server *Server) fraudDecisionRes(decision db.FraudDecision) FraudDecisionRes {
+	res := FraudDecisionRes{
+		ID:            decision.ID,
+		Username:      decision.Username,
+		FromAccountID: decision.FromAccountID,
+		ToAccountID:   decision.ToAccountID,
+		Amount:        decision.Amount,
+		AmountDecimal: server.Currencies.Registry().Format(decision.Amount, decision.Currency),
+		Currency:      decision.Currency,
+		ClientIP:      decision.ClientIp,
+		Kind:          decision.Kind,
+		Score:         decision.Score,
+		Outcome:       decision.Outcome,
+		Hits:          decision.Hits,
+		Status:        decision.Status,
+		ReviewedBy:    decision.ReviewedBy.String,
+		ReviewNote:    decision.ReviewNote,
+		ReviewedAt:    timeOrNil(decision.ReviewedAt),
+		CreatedAt:     decision.CreatedAt,
+	}
+
+	if decision.TransferID.Valid {
+		res.TransferID = &decision.TransferID.Int64
+	}
+
+	return res
+}
+
+// ListFraudReviews godoc
+// @Summary      List transfers held for review
+// @Description  List the transfers the fraud rules held, oldest first, with the rules that matched. Admins only.
+// @Tags         admin
+// @Produce      json
+// @Param        after_id   query     int     false  "Return decisions after this ID"
+// @Param        page_size  query     int     false  "Decisions per page, 1 to 100 (default 100)"
+// @Success      200  {object}  ListFraudReviewsRes
+// @Failure      400  {object}  domain.Problem "Invalid parameters"
+// @Failure      403  {object}  domain.Problem "Not an admin"
+// @Security     BearerAuth
+// @Router       /v1/admin/fraud/reviews [get]
+func (server *Server) listFraudReviewsHandler(ctx *gin.Context) {
+	var req listFraudReviewsDTO
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		writeError(ctx, bindingError(err))
+		return
+	}
+
+	if req.PageSize == 0 {
+		req.PageSize = fraud.PageSize
+	}
+
+	decisions, err := server.Fraud.Pending(ctx, req.AfterID, req.PageSize)
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	res := ListFraudReviewsRes{Decisions: make([]FraudDecisionRes, 0, len(decisions))}
+	for _, decision := range decisions {
+		res.Decisions = append(res.Decisions, server.fraudDecisionRes(decision))
+	}
+	if len(decisions) == int(req.PageSize) {
+		res.NextAfterID = decisions[len(decisions)-1].ID
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+// GetFraudDecision godoc
+// @Summary      Get a fraud decision
+// @Description  Get the risk assessment of a transfer and its review. Admins only.
+// @Tags         admin
+// @Produce      json
+// @Param        id   path      int  true  "Fraud decision ID"
+// @Success      200  {object}  FraudDecisionRes
+// @Failure      403  {object}  domain.Problem "Not an admin"
+// @Failure      404  {object}  domain.Problem "Not found"
+// @Security     BearerAuth
+// @Router       /v1/admin/fraud/decisions/{id} [get]
+func (server *Server) getFraudDecisionHandler(ctx *gin.Context) {
+	var uri fraudDecisionDTO
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		writeError(ctx, bindingError(err))
+		return
+	}
+
+	decision, err := server.Store.GetFraudDecision(ctx, uri.ID)
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, server.fraudDecisionRes(decision))
+}
+
+// ApproveFraudDecision godoc
+// @Summary      Approve a held transfer
+// @Description  Post a transfer the fraud rules held for review, paying the payment request, settling the batch row or funding the escrow it was held from. A transfer that fails, e.g. for insufficient funds or a payment request that is no longer pending, stays held. Admins only.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        id    path      int                     true   "Fraud decision ID"
+// @Param        body  body      reviewFraudDecisionDTO  false  "Review note"
+// @Success      200  {object}  ApproveFraudDecisionRes
+// @Failure      403  {object}  domain.Problem "Not an admin"
+// @Failure      404  {object}  domain.Problem "Not found"
+// @Failure      422  {object}  domain.Problem "Not held, or the transfer failed"
+// @Security     BearerAuth
+// @Router       /v1/admin/fraud/decisions/{id}/approve [post]
+func (server *Server) approveFraudDecisionHandler(ctx *gin.Context) {
+	arg, ok := bindReview(ctx)
+	if !ok {
+		return
+	}
+
+	result, err := server.Fraud.Approve(ctx, arg)
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	res := ApproveFraudDecisionRes{
+		FraudDecision:    server.fraudDecisionRes(result.Decision),
+		TransferTxResult: result.TransferTxResult,
+	}
+	if result.TransferBatchItem != nil {
+		item := transferBatchItemRes(*result.TransferBatchItem)
+		res.TransferBatchItem = &item
+	}
+	if result.PaymentRequest != nil {
+		server.PaymentRequests.NotifyPaid(ctx, *result.PaymentRequest)
+		request := server.paymentRequestRes(*result.PaymentRequest, arg.Reviewer)
+		res.PaymentRequest = &request
+	}
+	if result.Escrow != nil {
+		server.Escrows.NotifyCreated(ctx, *result.Escrow)
+		escrow := escrowRes(*result.Escrow)
+		res.Escrow = &escrow
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+// RejectFraudDecision godoc
+// @Summary      Reject a held transfer
+// @Description  Refuse a transfer the fraud rules held for review; it is never posted, and the batch row it was held from fails. A held payment request stays pending. Admins only.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        id    path      int                     true   "Fraud decision ID"
+// @Param        body  body      reviewFraudDecisionDTO  false  "Review note"
+// @Success      200  {object}  FraudDecisionRes
+// @Failure      403  {object}  domain.Problem "Not an admin"
+// @Failure      404  {object}  domain.Problem "Not found"
+// @Failure      422  {object}  domain.Problem "Not held"
+// @Security     BearerAuth
+// @Router       /v1/admin/fraud/decisions/{id}/reject [post]
+func (server *Server) rejectFraudDecisionHandler(ctx *gin.Context) {
+	arg, ok := bindReview(ctx)
+	if !ok {
+		return
+	}
+
+	decision, err := server.Fraud.Reject(ctx, arg)
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, server.fraudDecisionRes(decision))
+}
+
+// bindReview binds the decision ID and the optional review note.
+func bindReview(ctx *gin.Context) (fraud.ReviewParams, bool) {
+	var uri fraudDecisionDTO
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		writeError(ctx, bindingError(err))
+		return fraud.ReviewParams{}, false
+	}
+
+	var req reviewFraudDecisionDTO
+	if ctx.Request.ContentLength != 0 {
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			writeError(ctx, bindingError(err))
+			return fraud.ReviewParams{}, false
+		}
+	}
+
+	authPayload := ctx.MustGet(AuthorizationPayloadKey).(*token.Payload)
+
+	return fraud.ReviewParams{ID: uri.ID, Reviewer: authPayload.Username, Note: req.Note}, true
+}
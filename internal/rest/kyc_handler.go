@@ -0,0 +1,440 @@
+package rest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/NhutHuyDev/sgbank/internal/domain"
+	"github.com/NhutHuyDev/sgbank/internal/infra/db"
+	"github.com/NhutHuyDev/sgbank/internal/kyc"
+	"github.com/NhutHuyDev/sgbank/internal/token"
+	"github.com/gin-gonic/gin"
+)
+
+// maxKYCFormOverhead is the room left in a submission for the fields other
+// than its documents.
+const maxKYCFormOverhead = 1 << 20
+
+type kycSubmissionDTO struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+type kycDocumentDTO struct {
+	ID         int64 `uri:"id" binding:"required,min=1"`
+	DocumentID int64 `uri:"document_id" binding:"required,min=1"`
+}
+
+type reviewKYCSubmissionDTO struct {
+	Reason string `json:"reason"`
+}
+
+type listKYCSubmissionsDTO struct {
+	AfterID  int64 `form:"after_id" binding:"omitempty,min=1"`
+	PageSize int32 `form:"page_size" binding:"omitempty,min=1,max=100"`
+}
+
+// KYCLevelRes is a verification level and its limits; a limit is omitted
+// when there is none, and so is a currency the level has no limits in.
+type KYCLevelRes struct {
+	Level       int32              `json:"level"`
+	Name        string             `json:"name"`
+	MaxAccounts *int32             `json:"max_accounts,omitempty"`
+	Limits      []KYCLevelLimitRes `json:"limits"`
+}
+
+// KYCLevelLimitRes is what a level may send in a currency, in its minor
+// units.
+type KYCLevelLimitRes struct {
+	Currency           string `json:"currency"`
+	MaxTransferAmount  *int64 `json:"max_transfer_amount,omitempty"`
+	DailyTransferLimit *int64 `json:"daily_transfer_limit,omitempty"`
+}
+
+type KYCDocumentRes struct {
+	ID          int64     `json:"id"`
+	Kind        string    `json:"kind"`
+	FileName    string    `json:"file_name"`
+	ContentType string    `json:"content_type"`
+	Size        int64     `json:"size"`
+	Sha256      string    `json:"sha256"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type KYCSubmissionRes struct {
+	ID         int64            `json:"id"`
+	Username   string           `json:"username"`
+	Level      int32            `json:"level"`
+	Status     string           `json:"status"`
+	Reason     string           `json:"reason,omitempty"`
+	ReviewedBy string           `json:"reviewed_by,omitempty"`
+	ReviewedAt *time.Time       `json:"reviewed_at,omitempty"`
+	CreatedAt  time.Time        `json:"created_at"`
+	Documents  []KYCDocumentRes `json:"documents,omitempty"`
+}
+
+// KYCStatusRes is the level of the user, what they used of its limits
+// today, their submissions and every level.
+type KYCStatusRes struct {
+	Level    KYCLevelRes `json:"level"`
+	Accounts int64       `json:"accounts"`
+	// SentToday is what the user sent since midnight UTC in each currency.
+	SentToday   map[string]int64   `json:"sent_today"`
+	Submissions []KYCSubmissionRes `json:"submissions"`
+	Levels      []KYCLevelRes      `json:"levels"`
+}
+
+type ListKYCSubmissionsRes struct {
+	Submissions []KYCSubmissionRes `json:"submissions"`
+	// NextAfterID is the after_id of the next page, 0 on the last page.
+	NextAfterID int64 `json:"next_after_id,omitempty"`
+}
+
+type ReviewKYCSubmissionRes struct {
+	Submission KYCSubmissionRes `json:"submission"`
+	User       UserRes          `json:"user"`
+}
+
+func kycLevelRes(level kyc.Level) KYCLevelRes {
+	res := KYCLevelRes{Level: level.Level, Name: level.Name, Limits: make([]KYCLevelLimitRes, 0, len(level.Limits))}
+
+	if level.MaxAccounts.Valid {
+		res.MaxAccounts = &level.MaxAccounts.Int32
+	}
+
+	for _, limit := range level.Limits {
+		limitRes := KYCLevelLimitRes{Currency: limit.Currency}
+		if limit.MaxTransferAmount.Valid {
+			limitRes.MaxTransferAmount = &limit.MaxTransferAmount.Int64
+		}
+		if limit.DailyTransferLimit.Valid {
+			limitRes.DailyTransferLimit = &limit.DailyTransferLimit.Int64
+		}
+		res.Limits = append(res.Limits, limitRes)
+	}
+
+	return res
+}
+
+func kycSubmissionRes(submission db.KycSubmission, documents []db.KycDocument) KYCSubmissionRes {
+	res := KYCSubmissionRes{
+		ID:         submission.ID,
+		Username:   submission.Username,
+		Level:      submission.Level,
+		Status:     submission.Status,
+		Reason:     submission.Reason,
+		ReviewedBy: submission.ReviewedBy.String,
+		ReviewedAt: timeOrNil(submission.ReviewedAt),
+		CreatedAt:  submission.CreatedAt,
+	}
+
+	for _, document := range documents {
+		res.Documents = append(res.Documents, KYCDocumentRes{
+			ID:          document.ID,
+			Kind:        document.Kind,
+			FileName:    document.FileName,
+			ContentType: document.ContentType,
+			Size:        document.Size,
+			Sha256:      document.Sha256,
+			CreatedAt:   document.CreatedAt,
+		})
+	}
+
+	return res
+}
+
+// GetKYCStatus godoc
+// @Summary      Get the verification level of the user
+// @Description  Get the verification level of the authenticated user and its limits, the accounts they have and what they sent since midnight UTC, their submissions, newest first, and every level.
+// @Tags         kyc
+// @Produce      json
+// @Success      200  {object}  KYCStatusRes
+// @Security     BearerAuth
+// @Router       /v1/kyc [get]
+func (server *Server) getKYCStatusHandler(ctx *gin.Context) {
+	authPayload := ctx.MustGet(AuthorizationPayloadKey).(*token.Payload)
+
+	status, err := server.KYC.Status(ctx, authPayload.Username)
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	levels, err := server.KYC.Levels(ctx)
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	res := KYCStatusRes{
+		Level:       kycLevelRes(status.Level),
+		Accounts:    status.Accounts,
+		SentToday:   status.SentToday,
+		Submissions: make([]KYCSubmissionRes, 0, len(status.Submissions)),
+		Levels:      make([]KYCLevelRes, 0, len(levels)),
+	}
+	for _, submission := range status.Submissions {
+		res.Submissions = append(res.Submissions, kycSubmissionRes(submission, nil))
+	}
+	for _, level := range levels {
+		res.Levels = append(res.Levels, kycLevelRes(level))
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+// SubmitKYC godoc
+// @Summary      Submit documents for a higher verification level
+// @Description  Send a multipart form with the level and a file for each document it requires: id_document for level 1, id_document and proof_of_address for level 2. Files are PDF, JPEG or PNG. A user has at most one submission pending.
+// @Tags         kyc
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        level             formData  int   true   "Level requested"
+// @Param        id_document       formData  file  false  "Identity document"
+// @Param        proof_of_address  formData  file  false  "Proof of address"
+// @Success      201  {object}  KYCSubmissionRes
+// @Failure      400  {object}  domain.Problem "Invalid parameters"
+// @Failure      409  {object}  domain.Problem "A submission is already pending"
+// @Security     BearerAuth
+// @Router       /v1/kyc/submissions [post]
+func (server *Server) submitKYCHandler(ctx *gin.Context) {
+	maxFormSize := int64(len(kyc.Requirements[kyc.LevelFull]))*server.KYC.MaxDocumentSize() + maxKYCFormOverhead
+	ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, maxFormSize)
+
+	form, err := ctx.MultipartForm()
+	if err != nil {
+		writeError(ctx, fmt.Errorf("%w: %v", domain.ErrInvalidArgument, err))
+		return
+	}
+	defer form.RemoveAll()
+
+	level, err := strconv.ParseInt(ctx.PostForm("level"), 10, 32)
+	if err != nil {
+		writeError(ctx, domain.NewValidationError(domain.FieldViolation{Field: "level", Description: "must be a number"}))
+		return
+	}
+
+	authPayload := ctx.MustGet(AuthorizationPayloadKey).(*token.Payload)
+	arg := kyc.SubmitParams{Username: authPayload.Username, Level: int32(level)}
+
+	for _, kind := range slices.Sorted(maps.Keys(form.File)) {
+		for _, header := range form.File[kind] {
+			file, err := header.Open()
+			if err != nil {
+				writeError(ctx, err)
+				return
+			}
+			defer file.Close()
+
+			arg.Documents = append(arg.Documents, kyc.Document{Kind: kind, FileName: header.Filename, Content: file})
+		}
+	}
+
+	result, err := server.KYC.Submit(ctx, arg)
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, kycSubmissionRes(result.Submission, result.Documents))
+}
+
+// GetKYCSubmission godoc
+// @Summary      Get a verification submission
+// @Description  Get a submission of the authenticated user with its documents.
+// @Tags         kyc
+// @Produce      json
+// @Param        id   path      int  true  "Submission ID"
+// @Success      200  {object}  KYCSubmissionRes
+// @Failure      403  {object}  domain.Problem "Not a submission of the user"
+// @Failure      404  {object}  domain.Problem "Not found"
+// @Security     BearerAuth
+// @Router       /v1/kyc/submissions/{id} [get]
+func (server *Server) getKYCSubmissionHandler(ctx *gin.Context) {
+	var uri kycSubmissionDTO
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		writeError(ctx, bindingError(err))
+		return
+	}
+
+	submission, documents, err := server.KYC.Submission(ctx, uri.ID)
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	authPayload := ctx.MustGet(AuthorizationPayloadKey).(*token.Payload)
+	if submission.Username != authPayload.Username {
+		writeError(ctx, domain.ErrForbidden)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, kycSubmissionRes(submission, documents))
+}
+
+// ListKYCSubmissions godoc
+// @Summary      List verification submissions to review
+// @Description  List the pending verification submissions, oldest first. Admins only.
+// @Tags         admin
+// @Produce      json
+// @Param        after_id   query     int     false  "Return submissions after this ID"
+// @Param        page_size  query     int     false  "Submissions per page, 1 to 100 (default 100)"
+// @Success      200  {object}  ListKYCSubmissionsRes
+// @Failure      400  {object}  domain.Problem "Invalid parameters"
+// @Failure      403  {object}  domain.Problem "Not an admin"
+// @Security     BearerAuth
+// @Router       /v1/admin/kyc/submissions [get]
+func (server *Server) listKYCSubmissionsHandler(ctx *gin.Context) {
+	var req listKYCSubmissionsDTO
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		writeError(ctx, bindingError(err))
+		return
+	}
+
+	if req.PageSize == 0 {
+		req.PageSize = kyc.PageSize
+	}
+
+	submissions, err := server.KYC.Pending(ctx, req.AfterID, req.PageSize)
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	res := ListKYCSubmissionsRes{Submissions: make([]KYCSubmissionRes, 0, len(submissions))}
+	for _, submission := range submissions {
+		res.Submissions = append(res.Submissions, kycSubmissionRes(submission, nil))
+	}
+	if len(submissions) == int(req.PageSize) {
+		res.NextAfterID = submissions[len(submissions)-1].ID
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+// ReviewKYCSubmission godoc
+// @Summary      Get a verification submission to review
+// @Description  Get a verification submission with its documents. Admins only.
+// @Tags         admin
+// @Produce      json
+// @Param        id   path      int  true  "Submission ID"
+// @Success      200  {object}  KYCSubmissionRes
+// @Failure      403  {object}  domain.Problem "Not an admin"
+// @Failure      404  {object}  domain.Problem "Not found"
+// @Security     BearerAuth
+// @Router       /v1/admin/kyc/submissions/{id} [get]
+func (server *Server) reviewKYCSubmissionHandler(ctx *gin.Context) {
+	var uri kycSubmissionDTO
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		writeError(ctx, bindingError(err))
+		return
+	}
+
+	submission, documents, err := server.KYC.Submission(ctx, uri.ID)
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, kycSubmissionRes(submission, documents))
+}
+
+// DownloadKYCDocument godoc
+// @Summary      Download a verification document
+// @Description  Download a document of a verification submission. Admins only.
+// @Tags         admin
+// @Produce      application/pdf,image/jpeg,image/png
+// @Param        id           path      int  true  "Submission ID"
+// @Param        document_id  path      int  true  "Document ID"
+// @Success      200  {file}    file
+// @Failure      403  {object}  domain.Problem "Not an admin"
+// @Failure      404  {object}  domain.Problem "Not found"
+// @Security     BearerAuth
+// @Router       /v1/admin/kyc/submissions/{id}/documents/{document_id} [get]
+func (server *Server) downloadKYCDocumentHandler(ctx *gin.Context) {
+	var uri kycDocumentDTO
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		writeError(ctx, bindingError(err))
+		return
+	}
+
+	document, content, err := server.KYC.OpenDocument(ctx, uri.ID, uri.DocumentID)
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+	defer content.Close()
+
+	ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", document.FileName))
+	ctx.DataFromReader(http.StatusOK, document.Size, document.ContentType, content, nil)
+}
+
+// ApproveKYCSubmission godoc
+// @Summary      Approve a verification submission
+// @Description  Raise the user to the level of a pending submission. Admins only.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        id    path      int                     true   "Submission ID"
+// @Param        body  body      reviewKYCSubmissionDTO  false  "Reason"
+// @Success      200  {object}  ReviewKYCSubmissionRes
+// @Failure      403  {object}  domain.Problem "Not an admin"
+// @Failure      404  {object}  domain.Problem "Not found"
+// @Failure      422  {object}  domain.Problem "Not pending"
+// @Security     BearerAuth
+// @Router       /v1/admin/kyc/submissions/{id}/approve [post]
+func (server *Server) approveKYCSubmissionHandler(ctx *gin.Context) {
+	server.reviewKYCSubmission(ctx, server.KYC.Approve)
+}
+
+// RejectKYCSubmission godoc
+// @Summary      Reject a verification submission
+// @Description  Refuse a pending submission with a reason, which the user is notified of. Admins only.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        id    path      int                     true  "Submission ID"
+// @Param        body  body      reviewKYCSubmissionDTO  true  "Reason"
+// @Success      200  {object}  ReviewKYCSubmissionRes
+// @Failure      400  {object}  domain.Problem "No reason"
+// @Failure      403  {object}  domain.Problem "Not an admin"
+// @Failure      404  {object}  domain.Problem "Not found"
+// @Failure      422  {object}  domain.Problem "Not pending"
+// @Security     BearerAuth
+// @Router       /v1/admin/kyc/submissions/{id}/reject [post]
+func (server *Server) rejectKYCSubmissionHandler(ctx *gin.Context) {
+	server.reviewKYCSubmission(ctx, server.KYC.Reject)
+}
+
+func (server *Server) reviewKYCSubmission(ctx *gin.Context, review func(ctx context.Context, arg kyc.ReviewParams) (db.ReviewKycSubmissionTxResult, error)) {
+	var uri kycSubmissionDTO
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		writeError(ctx, bindingError(err))
+		return
+	}
+
+	var req reviewKYCSubmissionDTO
+	if err := ctx.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		writeError(ctx, bindingError(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(AuthorizationPayloadKey).(*token.Payload)
+
+	result, err := review(ctx, kyc.ReviewParams{ID: uri.ID, Reviewer: authPayload.Username, Reason: req.Reason})
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, ReviewKYCSubmissionRes{
+		Submission: kycSubmissionRes(result.Submission, nil),
+		User:       castToUserRes(result.User),
+	})
+}
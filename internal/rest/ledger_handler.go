@@ -0,0 +1,164 @@
+package rest
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/NhutHuyDev/sgbank/internal/infra/db"
+	"github.com/NhutHuyDev/sgbank/internal/ledger"
+	"github.com/gin-gonic/gin"
+)
+
+type ledgerReportDTO struct {
+	// Date defaults to today (UTC).
+	Date     time.Time `form:"date" time_format:"2006-01-02" time_utc:"1"`
+	Currency string    `form:"currency" binding:"omitempty,len=3,uppercase"`
+}
+
+func (req ledgerReportDTO) date() time.Time {
+	if req.Date.IsZero() {
+		return time.Now().UTC()
+	}
+	return req.Date
+}
+
+type profitAndLossDTO struct {
+	From time.Time `form:"from" binding:"required" time_format:"2006-01-02" time_utc:"1"`
+	// To defaults to today (UTC).
+	To       time.Time `form:"to" time_format:"2006-01-02" time_utc:"1"`
+	Currency string    `form:"currency" binding:"omitempty,len=3,uppercase"`
+}
+
+type ListGLAccountsRes struct {
+	Accounts []db.GlAccount `json:"accounts"`
+}
+
+type TrialBalanceRes struct {
+	Date          string                `json:"date"`
+	TrialBalances []ledger.TrialBalance `json:"trial_balances"`
+}
+
+type BalanceSheetRes struct {
+	Date          string                `json:"date"`
+	BalanceSheets []ledger.BalanceSheet `json:"balance_sheets"`
+}
+
+type ProfitAndLossRes struct {
+	From       string                 `json:"from"`
+	To         string                 `json:"to"`
+	Statements []ledger.ProfitAndLoss `json:"statements"`
+}
+
+// ListGLAccounts godoc
+// @Summary      Get the chart of accounts
+// @Description  List the general ledger accounts the entries are posted to. Admins only.
+// @Tags         admin
+// @Produce      json
+// @Success      200  {object}  ListGLAccountsRes
+// @Failure      403  {object}  domain.Problem "Not an admin"
+// @Security     BearerAuth
+// @Router       /v1/admin/ledger/accounts [get]
+func (server *Server) listGLAccountsHandler(ctx *gin.Context) {
+	accounts, err := server.Ledger.Chart(ctx)
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, ListGLAccountsRes{Accounts: accounts})
+}
+
+// TrialBalance godoc
+// @Summary      Get the trial balance
+// @Description  Get the debit or credit balance of every general ledger account at the end of a date (UTC), per currency. Admins only.
+// @Tags         admin
+// @Produce      json
+// @Param        date      query     string  false  "Date as YYYY-MM-DD, today by default"
+// @Param        currency  query     string  false  "Only this currency"
+// @Success      200  {object}  TrialBalanceRes
+// @Failure      400  {object}  domain.Problem "Invalid parameters"
+// @Failure      403  {object}  domain.Problem "Not an admin"
+// @Security     BearerAuth
+// @Router       /v1/admin/ledger/trial-balance [get]
+func (server *Server) trialBalanceHandler(ctx *gin.Context) {
+	var req ledgerReportDTO
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		writeError(ctx, bindingError(err))
+		return
+	}
+
+	date := req.date()
+	reports, err := server.Ledger.TrialBalances(ctx, date, req.Currency)
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, TrialBalanceRes{Date: date.Format(ledger.DateLayout), TrialBalances: reports})
+}
+
+// BalanceSheet godoc
+// @Summary      Get the balance sheet
+// @Description  Get assets, liabilities and equity at the end of a date (UTC), per currency. Revenue less expenses to date is reported as retained earnings. Admins only.
+// @Tags         admin
+// @Produce      json
+// @Param        date      query     string  false  "Date as YYYY-MM-DD, today by default"
+// @Param        currency  query     string  false  "Only this currency"
+// @Success      200  {object}  BalanceSheetRes
+// @Failure      400  {object}  domain.Problem "Invalid parameters"
+// @Failure      403  {object}  domain.Problem "Not an admin"
+// @Security     BearerAuth
+// @Router       /v1/admin/ledger/balance-sheet [get]
+func (server *Server) balanceSheetHandler(ctx *gin.Context) {
+	var req ledgerReportDTO
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		writeError(ctx, bindingError(err))
+		return
+	}
+
+	date := req.date()
+	reports, err := server.Ledger.BalanceSheets(ctx, date, req.Currency)
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, BalanceSheetRes{Date: date.Format(ledger.DateLayout), BalanceSheets: reports})
+}
+
+// ProfitAndLoss godoc
+// @Summary      Get the profit and loss statement
+// @Description  Get the revenue and expenses posted from the start of from to the end of to (UTC), per currency. Admins only.
+// @Tags         admin
+// @Produce      json
+// @Param        from      query     string  true   "First date as YYYY-MM-DD"
+// @Param        to        query     string  false  "Last date as YYYY-MM-DD, today by default"
+// @Param        currency  query     string  false  "Only this currency"
+// @Success      200  {object}  ProfitAndLossRes
+// @Failure      400  {object}  domain.Problem "Invalid parameters"
+// @Failure      403  {object}  domain.Problem "Not an admin"
+// @Security     BearerAuth
+// @Router       /v1/admin/ledger/profit-and-loss [get]
+func (server *Server) profitAndLossHandler(ctx *gin.Context) {
+	var req profitAndLossDTO
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		writeError(ctx, bindingError(err))
+		return
+	}
+
+	if req.To.IsZero() {
+		req.To = time.Now().UTC()
+	}
+
+	reports, err := server.Ledger.ProfitAndLoss(ctx, req.From, req.To, req.Currency)
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, ProfitAndLossRes{
+		From:       req.From.Format(ledger.DateLayout),
+		To:         req.To.Format(ledger.DateLayout),
+		Statements: reports,
+	})
+}
@@ -0,0 +1,234 @@
+package rest
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/NhutHuyDev/sgbank/internal/domain"
+	"github.com/NhutHuyDev/sgbank/internal/infra/db"
+	"github.com/NhutHuyDev/sgbank/internal/pagination"
+	"github.com/NhutHuyDev/sgbank/internal/token"
+	"github.com/gin-gonic/gin"
+)
+
+type listPageDTO struct {
+	PageSize              int32      `form:"page_size" binding:"omitempty,min=1,max=100"`
+	Cursor                string     `form:"cursor"`
+	Sort                  string     `form:"sort" binding:"omitempty,oneof=asc desc"`
+	From                  *time.Time `form:"from"`
+	To                    *time.Time `form:"to"`
+	MinAmount             *int64     `form:"min_amount" binding:"omitempty,min=0"`
+	MaxAmount             *int64     `form:"max_amount" binding:"omitempty,min=0"`
+	Direction             string     `form:"direction"`
+	CounterpartyAccountID *int64     `form:"counterparty_account_id" binding:"omitempty,min=1"`
+	Query                 string     `form:"q"`
+}
+
+func (req listPageDTO) filter() pagination.Filter {
+	return pagination.Filter{
+		From:                  req.From,
+		To:                    req.To,
+		MinAmount:             req.MinAmount,
+		MaxAmount:             req.MaxAmount,
+		Direction:             req.Direction,
+		CounterpartyAccountID: req.CounterpartyAccountID,
+		Query:                 req.Query,
+	}
+}
+
+type listEntriesDTO struct {
+	listPageDTO
+	Category string `form:"category" binding:"omitempty,max=50"`
+}
+
+type EntryRes struct {
+	ID                    int64     `json:"id"`
+	AccountID             int64     `json:"account_id"`
+	Amount                int64     `json:"amount"`
+	AmountDecimal         string    `json:"amount_decimal"`
+	TransferID            *int64    `json:"transfer_id"`
+	CounterpartyAccountID int64     `json:"counterparty_account_id,omitempty"`
+	Description           string    `json:"description"`
+	ExternalReference     string    `json:"external_reference"`
+	MerchantCategory      string    `json:"merchant_category"`
+	Category              string    `json:"category"`
+	CreatedAt             time.Time `json:"created_at"`
+}
+
+type ListEntriesRes struct {
+	Entries    []EntryRes `json:"entries"`
+	NextCursor string     `json:"next_cursor,omitempty"`
+}
+
+func (server *Server) listEntriesHandler(ctx *gin.Context) {
+	var uri GetAccountDTO
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		writeError(ctx, bindingError(err))
+		return
+	}
+
+	var req listEntriesDTO
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		writeError(ctx, bindingError(err))
+		return
+	}
+
+	filter := req.filter()
+	filter.Category = req.Category
+	if err := filter.Validate(pagination.DirectionCredit, pagination.DirectionDebit); err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	authPayload := ctx.MustGet(AuthorizationPayloadKey).(*token.Payload)
+	account, err := server.getAccountByRef(ctx, "id", uri.ID)
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	if account.Owner != authPayload.Username {
+		writeError(ctx, domain.ErrForbidden)
+		return
+	}
+
+	page, err := server.Paginator.Page(req.PageSize, req.Sort, req.Cursor, filter.Scope("entries", authPayload.Username, &account.ID))
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	rows, err := server.Store.ListEntriesPage(ctx, pagination.EntryPageFilter(account.ID, filter, page), page.Order)
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	rows, nextCursor := pagination.Trim(server.Paginator, page, rows, pagination.EntryKey)
+
+	registry := server.Currencies.Registry()
+	res := ListEntriesRes{
+		Entries:    make([]EntryRes, 0, len(rows)),
+		NextCursor: nextCursor,
+	}
+	for _, row := range rows {
+		res.Entries = append(res.Entries, EntryRes{
+			ID:                    row.Entry.ID,
+			AccountID:             row.Entry.AccountID,
+			Amount:                row.Entry.Amount,
+			AmountDecimal:         registry.Format(row.Entry.Amount, account.Currency),
+			TransferID:            row.Entry.TransferID,
+			CounterpartyAccountID: row.CounterpartyAccountID,
+			Description:           row.Entry.Description,
+			ExternalReference:     row.Entry.ExternalReference,
+			MerchantCategory:      row.Entry.MerchantCategory,
+			Category:              row.Entry.Category,
+			CreatedAt:             row.Entry.CreatedAt,
+		})
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+type listTransfersDTO struct {
+	listPageDTO
+	AccountID *int64 `form:"account_id" binding:"omitempty,min=1"`
+	Currency  string `form:"currency" binding:"omitempty,currency"`
+}
+
+type TransferRes struct {
+	ID                int64     `json:"id"`
+	FromAccountID     int64     `json:"from_account_id"`
+	ToAccountID       int64     `json:"to_account_id"`
+	Amount            int64     `json:"amount"`
+	AmountDecimal     string    `json:"amount_decimal"`
+	Fee               int64     `json:"fee"`
+	FeeDecimal        string    `json:"fee_decimal"`
+	Currency          string    `json:"currency"`
+	Description       string    `json:"description"`
+	ExternalReference string    `json:"external_reference"`
+	MerchantCategory  string    `json:"merchant_category"`
+	CreatedAt         time.Time `json:"created_at"`
+}
+
+type ListTransfersRes struct {
+	Transfers  []TransferRes `json:"transfers"`
+	NextCursor string        `json:"next_cursor,omitempty"`
+}
+
+func (server *Server) listTransfersHandler(ctx *gin.Context) {
+	var req listTransfersDTO
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		writeError(ctx, bindingError(err))
+		return
+	}
+
+	filter := req.filter()
+	filter.Currency = req.Currency
+	if err := filter.Validate(pagination.DirectionIncoming, pagination.DirectionOutgoing); err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	authPayload := ctx.MustGet(AuthorizationPayloadKey).(*token.Payload)
+	page, err := server.Paginator.Page(req.PageSize, req.Sort, req.Cursor, filter.Scope("transfers", authPayload.Username, req.AccountID))
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	if req.AccountID != nil {
+		if _, ok := server.isAccountOwner(ctx, *req.AccountID, authPayload.Username); !ok {
+			return
+		}
+	}
+
+	rows, err := server.Store.ListTransfersPage(ctx, pagination.TransferPageFilter(authPayload.Username, req.AccountID, filter, page), page.Order)
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	rows, nextCursor := pagination.Trim(server.Paginator, page, rows, pagination.TransferKey)
+
+	registry := server.Currencies.Registry()
+	res := ListTransfersRes{
+		Transfers:  make([]TransferRes, 0, len(rows)),
+		NextCursor: nextCursor,
+	}
+	for _, row := range rows {
+		res.Transfers = append(res.Transfers, TransferRes{
+			ID:                row.Transfer.ID,
+			FromAccountID:     row.Transfer.FromAccountID,
+			ToAccountID:       row.Transfer.ToAccountID,
+			Amount:            row.Transfer.Amount,
+			AmountDecimal:     registry.Format(row.Transfer.Amount, row.Currency),
+			Fee:               row.Transfer.Fee,
+			FeeDecimal:        registry.Format(row.Transfer.Fee, row.Currency),
+			Currency:          row.Currency,
+			Description:       row.Transfer.Description,
+			ExternalReference: row.Transfer.ExternalReference,
+			MerchantCategory:  row.Transfer.MerchantCategory,
+			CreatedAt:         row.Transfer.CreatedAt,
+		})
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+// isAccountOwner loads the account and writes an error response unless it
+// belongs to username.
+func (server *Server) isAccountOwner(ctx *gin.Context, accountID int64, username string) (db.Account, bool) {
+	account, err := server.Store.GetAccount(ctx, accountID)
+	if err != nil {
+		writeError(ctx, err)
+		return account, false
+	}
+
+	if account.Owner != username {
+		writeError(ctx, domain.ErrForbidden)
+		return account, false
+	}
+
+	return account, true
+}
@@ -1,11 +1,13 @@
 package rest
 
 import (
-	"errors"
 	"fmt"
-	"net/http"
 	"strings"
 
+	"github.com/NhutHuyDev/sgbank/internal/bank"
+	"github.com/NhutHuyDev/sgbank/internal/domain"
+	"github.com/NhutHuyDev/sgbank/internal/infra/db"
+	"github.com/NhutHuyDev/sgbank/internal/logging"
 	"github.com/NhutHuyDev/sgbank/internal/token"
 	"github.com/gin-gonic/gin"
 )
@@ -20,33 +22,56 @@ func AuthMiddleware(tokeMaker token.Maker) gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		authorizationHeader := ctx.GetHeader(AuthorizationHeaderKey)
 		if len(authorizationHeader) == 0 {
-			err := errors.New("authorization header is not provide")
-			ctx.AbortWithStatusJSON(http.StatusUnauthorized, errorResponse(err))
+			err := fmt.Errorf("%w: authorization header is not provided", domain.ErrUnauthenticated)
+			writeError(ctx, err)
 			return
 		}
 
 		fields := strings.Fields(authorizationHeader)
 		if len(fields) < 2 {
-			err := errors.New("invalid authorization header format")
-			ctx.AbortWithStatusJSON(http.StatusUnauthorized, errorResponse(err))
+			err := fmt.Errorf("%w: invalid authorization header format", domain.ErrUnauthenticated)
+			writeError(ctx, err)
 			return
 		}
 
 		authorizationType := strings.ToLower(fields[0])
 		if authorizationType != AuthorizationTypeBearer {
-			err := fmt.Errorf("unsupported authorization type %s", authorizationType)
-			ctx.AbortWithStatusJSON(http.StatusUnauthorized, errorResponse(err))
+			err := fmt.Errorf("%w: unsupported authorization type %s", domain.ErrUnauthenticated, authorizationType)
+			writeError(ctx, err)
 			return
 		}
 
 		accessToken := fields[1]
 		payload, err := tokeMaker.VerifyToken(accessToken)
 		if err != nil {
-			ctx.AbortWithStatusJSON(http.StatusUnauthorized, errorResponse(err))
+			writeError(ctx, fmt.Errorf("%w: %w", domain.ErrUnauthenticated, err))
 			return
 		}
 
+		logging.SetUsername(ctx.Request.Context(), payload.Username)
 		ctx.Set(AuthorizationPayloadKey, payload)
 		ctx.Next()
 	}
 }
+
+// AdminMiddleware only lets admins through. It runs after AuthMiddleware and
+// reads the role from the store on every request, so demoting a user takes
+// effect without waiting for their tokens to expire.
+func AdminMiddleware(store db.Store) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		authPayload := ctx.MustGet(AuthorizationPayloadKey).(*token.Payload)
+
+		user, err := store.GetUser(ctx, authPayload.Username)
+		if err != nil {
+			writeError(ctx, err)
+			return
+		}
+
+		if user.Role != bank.RoleAdmin {
+			writeError(ctx, fmt.Errorf("%w: admin role required", domain.ErrForbidden))
+			return
+		}
+
+		ctx.Next()
+	}
+}
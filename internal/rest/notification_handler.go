@@ -0,0 +1,59 @@
+package rest
+
+import (
+	"net/http"
+
+	"github.com/NhutHuyDev/sgbank/internal/infra/db"
+	"github.com/NhutHuyDev/sgbank/internal/pagination"
+	"github.com/NhutHuyDev/sgbank/internal/token"
+	"github.com/gin-gonic/gin"
+)
+
+type listNotificationsDTO struct {
+	PageSize int32  `form:"page_size" binding:"omitempty,min=1,max=100"`
+	Cursor   string `form:"cursor"`
+}
+
+type ListNotificationsRes struct {
+	Notifications []db.Notification `json:"notifications"`
+	NextCursor    string            `json:"next_cursor,omitempty"`
+}
+
+// listNotificationsHandler returns the user's notifications, newest first.
+func (server *Server) listNotificationsHandler(ctx *gin.Context) {
+	var req listNotificationsDTO
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		writeError(ctx, bindingError(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(AuthorizationPayloadKey).(*token.Payload)
+	page, err := server.Paginator.Page(req.PageSize, string(db.SortDesc), req.Cursor, "notifications|"+authPayload.Username)
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	arg := db.ListNotificationsBeforeParams{
+		Username:  authPayload.Username,
+		PageLimit: page.Limit(),
+	}
+	if page.After != nil {
+		arg.BeforeID = page.After.ID
+	}
+
+	notifications, err := server.Store.ListNotificationsBefore(ctx, arg)
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	notifications, nextCursor := pagination.Trim(server.Paginator, page, notifications, func(notification db.Notification) pagination.Key {
+		return pagination.Key{ID: notification.ID}
+	})
+
+	ctx.JSON(http.StatusOK, ListNotificationsRes{
+		Notifications: notifications,
+		NextCursor:    nextCursor,
+	})
+}
@@ -0,0 +1,152 @@
+package rest
+
+import (
+	"net/http"
+
+	"github.com/NhutHuyDev/sgbank/internal/infra/db"
+	"github.com/NhutHuyDev/sgbank/internal/overdraft"
+	"github.com/NhutHuyDev/sgbank/internal/token"
+	"github.com/gin-gonic/gin"
+)
+
+type approveOverdraftDTO struct {
+	OverdraftLimit *int64 `json:"overdraft_limit" binding:"required,min=0"`
+	Reason         string `json:"reason"`
+}
+
+type GetOverdraftRes struct {
+	Account   db.Account             `json:"account"`
+	Approvals []db.OverdraftApproval `json:"approvals"`
+}
+
+// ApproveOverdraft godoc
+// @Summary      Approve an overdraft limit
+// @Description  Set how far below zero the balance of a customer account may go; 0 withdraws the overdraft. The approval is recorded with the admin and reason. Admins only.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        id    path      string               true  "Account ID or account number"
+// @Param        body  body      approveOverdraftDTO  true  "Overdraft limit in minor units"
+// @Success      200  {object}  db.ApproveOverdraftTxResult
+// @Failure      400  {object}  domain.Problem "Invalid parameters"
+// @Failure      403  {object}  domain.Problem "Not an admin"
+// @Failure      404  {object}  domain.Problem "Account not found"
+// @Security     BearerAuth
+// @Router       /v1/admin/accounts/{id}/overdraft [put]
+func (server *Server) approveOverdraftHandler(ctx *gin.Context) {
+	var uri GetAccountDTO
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		writeError(ctx, bindingError(err))
+		return
+	}
+
+	var req approveOverdraftDTO
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		writeError(ctx, bindingError(err))
+		return
+	}
+
+	account, err := server.getAccountByRef(ctx, "id", uri.ID)
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	authPayload := ctx.MustGet(AuthorizationPayloadKey).(*token.Payload)
+
+	result, err := server.Overdrafts.Approve(ctx, overdraft.ApproveParams{
+		Admin:          authPayload.Username,
+		AccountID:      account.ID,
+		OverdraftLimit: *req.OverdraftLimit,
+		Reason:         req.Reason,
+	})
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+// GetOverdraft godoc
+// @Summary      Get the overdraft of an account
+// @Description  Get an account with the history of its overdraft approvals, newest first. Admins only.
+// @Tags         admin
+// @Produce      json
+// @Param        id   path      string  true  "Account ID or account number"
+// @Success      200  {object}  GetOverdraftRes
+// @Failure      403  {object}  domain.Problem "Not an admin"
+// @Failure      404  {object}  domain.Problem "Account not found"
+// @Security     BearerAuth
+// @Router       /v1/admin/accounts/{id}/overdraft [get]
+func (server *Server) getOverdraftHandler(ctx *gin.Context) {
+	var uri GetAccountDTO
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		writeError(ctx, bindingError(err))
+		return
+	}
+
+	account, err := server.getAccountByRef(ctx, "id", uri.ID)
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	approvals, err := server.Store.ListOverdraftApprovals(ctx, account.ID)
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, GetOverdraftRes{Account: account, Approvals: approvals})
+}
+
+type listOverdraftsDTO struct {
+	Currency string `form:"currency" binding:"omitempty,currency"`
+	AfterID  int64  `form:"after_id" binding:"omitempty,min=1"`
+	PageSize int32  `form:"page_size" binding:"omitempty,min=1,max=100"`
+}
+
+type ListOverdraftsRes struct {
+	overdraft.Report
+	// NextAfterID is the after_id of the next page, 0 on the last page.
+	NextAfterID int64 `json:"next_after_id,omitempty"`
+}
+
+// ListOverdrafts godoc
+// @Summary      Report the accounts in overdraft
+// @Description  List the customer accounts with a negative balance by ID, with the number of accounts, amount overdrawn, limits and accounts over their limit per currency. Admins only.
+// @Tags         admin
+// @Produce      json
+// @Param        currency   query     string  false  "Only accounts in this currency"
+// @Param        after_id   query     int     false  "Return accounts after this ID"
+// @Param        page_size  query     int     false  "Accounts per page, 1 to 100 (default 100)"
+// @Success      200  {object}  ListOverdraftsRes
+// @Failure      400  {object}  domain.Problem "Invalid parameters"
+// @Failure      403  {object}  domain.Problem "Not an admin"
+// @Security     BearerAuth
+// @Router       /v1/admin/overdrafts [get]
+func (server *Server) listOverdraftsHandler(ctx *gin.Context) {
+	var req listOverdraftsDTO
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		writeError(ctx, bindingError(err))
+		return
+	}
+
+	if req.PageSize == 0 {
+		req.PageSize = overdraft.PageSize
+	}
+
+	report, err := server.Overdrafts.Report(ctx, req.Currency, req.AfterID, req.PageSize)
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	res := ListOverdraftsRes{Report: report}
+	if len(report.Accounts) == int(req.PageSize) {
+		res.NextAfterID = report.Accounts[len(report.Accounts)-1].ID
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
@@ -0,0 +1,335 @@
+package rest
+
+import (
+	"database/sql"
+	"net/http"
+	"time"
+
+	"github.com/NhutHuyDev/sgbank/internal/infra/db"
+	"github.com/NhutHuyDev/sgbank/internal/money"
+	"github.com/NhutHuyDev/sgbank/internal/pagination"
+	"github.com/NhutHuyDev/sgbank/internal/paymentrequest"
+	"github.com/NhutHuyDev/sgbank/internal/token"
+	"github.com/gin-gonic/gin"
+)
+
+type createPaymentRequestDTO struct {
+	Payer       string      `json:"payer" binding:"omitempty,alphanum,min=3,max=100"`
+	ToAccountID int64       `json:"to_account_id" binding:"required,min=1"`
+	Amount      money.Input `json:"amount" swaggertype:"string" example:"12.50"` // minor units, or a decimal string in major units
+	Currency    string      `json:"currency" binding:"required,currency"`
+	Memo        string      `json:"memo"`
+	ExpiresAt   time.Time   `json:"expires_at"`
+}
+
+type getPaymentRequestDTO struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+type paymentLinkDTO struct {
+	Token string `uri:"token" binding:"required"`
+}
+
+type acceptPaymentRequestDTO struct {
+	FromAccountID int64 `json:"from_account_id" binding:"required,min=1"`
+}
+
+type listPaymentRequestsDTO struct {
+	Direction string `form:"direction" binding:"required,oneof=incoming outgoing"`
+	Status    string `form:"status" binding:"omitempty,oneof=pending paid declined cancelled expired"`
+	PageSize  int32  `form:"page_size" binding:"omitempty,min=1,max=100"`
+	Cursor    string `form:"cursor"`
+}
+
+type PaymentRequestRes struct {
+	ID            int64     `json:"id"`
+	Requester     string    `json:"requester"`
+	Payer         string    `json:"payer,omitempty"`
+	ToAccountID   int64     `json:"to_account_id"`
+	Amount        int64     `json:"amount"`
+	AmountDecimal string    `json:"amount_decimal"`
+	Currency      string    `json:"currency"`
+	Memo          string    `json:"memo"`
+	Status        string    `json:"status"`
+	TransferID    *int64    `json:"transfer_id,omitempty"`
+	Link          string    `json:"link,omitempty"`
+	ExpiresAt     time.Time `json:"expires_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+type ListPaymentRequestsRes struct {
+	PaymentRequests []PaymentRequestRes `json:"payment_requests"`
+	NextCursor      string              `json:"next_cursor,omitempty"`
+}
+
+type AcceptPaymentRequestRes struct {
+	PaymentRequest  PaymentRequestRes   `json:"payment_request"`
+	Transfer        db.TransferTxResult `json:"transfer"`
+	FeeDetails      QuoteRes            `json:"fee_details"`
+	FraudDecisionID int64               `json:"fraud_decision_id"`
+}
+
+// HeldPaymentRequestRes is an accepted request whose payment the fraud
+// rules held for review. The request stays pending and is paid once an
+// admin approves the payment.
+type HeldPaymentRequestRes struct {
+	PaymentRequest PaymentRequestRes `json:"payment_request"`
+	FraudDecision  FraudDecisionRes  `json:"fraud_decision"`
+	FeeDetails     QuoteRes          `json:"fee_details"`
+}
+
+// paymentRequestRes converts request for username. The shareable link is
+// only shown to the requester of a link request that nobody has paid yet.
+func (server *Server) paymentRequestRes(request db.PaymentRequest, username string) PaymentRequestRes {
+	res := PaymentRequestRes{
+		ID:            request.ID,
+		Requester:     request.Requester,
+		Payer:         request.Payer.String,
+		ToAccountID:   request.ToAccountID,
+		Amount:        request.Amount,
+		AmountDecimal: server.Currencies.Registry().Format(request.Amount, request.Currency),
+		Currency:      request.Currency,
+		Memo:          request.Memo,
+		Status:        request.Status,
+		ExpiresAt:     request.ExpiresAt,
+		UpdatedAt:     request.UpdatedAt,
+		CreatedAt:     request.CreatedAt,
+	}
+
+	if request.TransferID.Valid {
+		res.TransferID = &request.TransferID.Int64
+	}
+
+	if request.Requester == username && !request.Payer.Valid {
+		res.Link = "/v1/payment-links/" + server.PaymentRequests.Links().Token(request.ID)
+	}
+
+	return res
+}
+
+// CreatePaymentRequest godoc
+// @Summary      Request money
+// @Description  Ask a user for money, or leave payer empty to get a shareable signed link anyone can pay.
+// @Tags         payment-requests
+// @Accept       json
+// @Produce      json
+// @Param        request  body      createPaymentRequestDTO  true  "Payment request"
+// @Success      200  {object}  PaymentRequestRes
+// @Failure      400  {object}  domain.Problem "Invalid parameters"
+// @Failure      403  {object}  domain.Problem "Account does not belong to user"
+// @Security     BearerAuth
+// @Router       /v1/payment-requests [post]
+func (server *Server) createPaymentRequestHandler(ctx *gin.Context) {
+	var req createPaymentRequestDTO
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		writeError(ctx, bindingError(err))
+		return
+	}
+
+	amount, err := server.positiveAmount(req.Amount, req.Currency)
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	authPayload := ctx.MustGet(AuthorizationPayloadKey).(*token.Payload)
+
+	request, err := server.PaymentRequests.Create(ctx, paymentrequest.CreateParams{
+		Requester:   authPayload.Username,
+		Payer:       req.Payer,
+		ToAccountID: req.ToAccountID,
+		Amount:      amount,
+		Currency:    req.Currency,
+		Memo:        req.Memo,
+		ExpiresAt:   req.ExpiresAt,
+	})
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, server.paymentRequestRes(request, authPayload.Username))
+}
+
+func (server *Server) getPaymentRequestHandler(ctx *gin.Context) {
+	var req getPaymentRequestDTO
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		writeError(ctx, bindingError(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(AuthorizationPayloadKey).(*token.Payload)
+
+	request, err := server.PaymentRequests.Get(ctx, authPayload.Username, req.ID)
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, server.paymentRequestRes(request, authPayload.Username))
+}
+
+func (server *Server) listPaymentRequestsHandler(ctx *gin.Context) {
+	var req listPaymentRequestsDTO
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		writeError(ctx, bindingError(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(AuthorizationPayloadKey).(*token.Payload)
+	scope := "payment_requests|" + authPayload.Username + "|" + req.Direction + "|" + req.Status
+	page, err := server.Paginator.Page(req.PageSize, string(db.SortDesc), req.Cursor, scope)
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	arg := db.ListPaymentRequestsBeforeParams{
+		Username:  authPayload.Username,
+		Direction: req.Direction,
+		Status:    sql.NullString{String: req.Status, Valid: req.Status != ""},
+		PageLimit: page.Limit(),
+	}
+	if page.After != nil {
+		arg.BeforeID = page.After.ID
+	}
+
+	requests, err := server.Store.ListPaymentRequestsBefore(ctx, arg)
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	requests, nextCursor := pagination.Trim(server.Paginator, page, requests, func(request db.PaymentRequest) pagination.Key {
+		return pagination.Key{ID: request.ID}
+	})
+
+	res := ListPaymentRequestsRes{
+		PaymentRequests: make([]PaymentRequestRes, 0, len(requests)),
+		NextCursor:      nextCursor,
+	}
+	for _, request := range requests {
+		res.PaymentRequests = append(res.PaymentRequests, server.paymentRequestRes(request, authPayload.Username))
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (server *Server) acceptPaymentRequestHandler(ctx *gin.Context) {
+	var uri getPaymentRequestDTO
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		writeError(ctx, bindingError(err))
+		return
+	}
+
+	server.acceptPaymentRequest(ctx, uri.ID, false)
+}
+
+func (server *Server) declinePaymentRequestHandler(ctx *gin.Context) {
+	var uri getPaymentRequestDTO
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		writeError(ctx, bindingError(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(AuthorizationPayloadKey).(*token.Payload)
+
+	request, err := server.PaymentRequests.Decline(ctx, authPayload.Username, uri.ID)
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, server.paymentRequestRes(request, authPayload.Username))
+}
+
+func (server *Server) cancelPaymentRequestHandler(ctx *gin.Context) {
+	var uri getPaymentRequestDTO
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		writeError(ctx, bindingError(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(AuthorizationPayloadKey).(*token.Payload)
+
+	request, err := server.PaymentRequests.Cancel(ctx, authPayload.Username, uri.ID)
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, server.paymentRequestRes(request, authPayload.Username))
+}
+
+func (server *Server) getPaymentLinkHandler(ctx *gin.Context) {
+	var uri paymentLinkDTO
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		writeError(ctx, bindingError(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(AuthorizationPayloadKey).(*token.Payload)
+
+	request, err := server.PaymentRequests.GetByLink(ctx, uri.Token)
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, server.paymentRequestRes(request, authPayload.Username))
+}
+
+func (server *Server) acceptPaymentLinkHandler(ctx *gin.Context) {
+	var uri paymentLinkDTO
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		writeError(ctx, bindingError(err))
+		return
+	}
+
+	id, err := server.PaymentRequests.Links().Verify(uri.Token)
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	server.acceptPaymentRequest(ctx, id, true)
+}
+
+func (server *Server) acceptPaymentRequest(ctx *gin.Context, id int64, viaLink bool) {
+	var req acceptPaymentRequestDTO
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		writeError(ctx, bindingError(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(AuthorizationPayloadKey).(*token.Payload)
+
+	result, err := server.PaymentRequests.Accept(ctx, paymentrequest.AcceptParams{
+		ID:            id,
+		Payer:         authPayload.Username,
+		FromAccountID: req.FromAccountID,
+		ViaLink:       viaLink,
+		ClientIP:      ctx.ClientIP(),
+	})
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	if result.Held() {
+		ctx.JSON(http.StatusAccepted, HeldPaymentRequestRes{
+			PaymentRequest: server.paymentRequestRes(result.PaymentRequest, authPayload.Username),
+			FraudDecision:  server.fraudDecisionRes(result.Payment.Decision),
+			FeeDetails:     server.quoteRes(result.Payment.Fee),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, AcceptPaymentRequestRes{
+		PaymentRequest:  server.paymentRequestRes(result.PaymentRequest, authPayload.Username),
+		Transfer:        result.TransferTxResult,
+		FeeDetails:      server.quoteRes(result.Payment.Fee),
+		FraudDecisionID: result.Payment.Decision.ID,
+	})
+}
@@ -0,0 +1,69 @@
+package rest
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/NhutHuyDev/sgbank/internal/domain"
+	"github.com/NhutHuyDev/sgbank/internal/logging"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+// writeError aborts the request with the problem details for err.
+func writeError(ctx *gin.Context, err error) {
+	problem := domain.NewProblem(err)
+	problem.Instance = ctx.Request.URL.Path
+	problem.RequestID = logging.RequestID(ctx)
+
+	if problem.Code == domain.ErrInternal.Code {
+		logging.FromContext(ctx).Error().Err(err).Msg("internal error")
+	}
+
+	ctx.Header("Content-Type", domain.ProblemContentType)
+	ctx.AbortWithStatusJSON(problem.Status, problem)
+}
+
+// bindingError turns a gin binding failure into a domain validation error.
+func bindingError(err error) error {
+	var validationErrs validator.ValidationErrors
+	if !errors.As(err, &validationErrs) {
+		return domain.NewValidationError(domain.FieldViolation{
+			Field:       "body",
+			Description: err.Error(),
+		})
+	}
+
+	violations := make([]domain.FieldViolation, 0, len(validationErrs))
+	for _, fieldErr := range validationErrs {
+		description := fmt.Sprintf("failed on the '%s' rule", fieldErr.Tag())
+		if fieldErr.Param() != "" {
+			description = fmt.Sprintf("failed on the '%s=%s' rule", fieldErr.Tag(), fieldErr.Param())
+		}
+
+		violations = append(violations, domain.FieldViolation{
+			Field:       fieldErr.Field(),
+			Description: description,
+		})
+	}
+
+	return domain.NewValidationError(violations...)
+}
+
+// fieldName reports request fields by the name clients send rather than the
+// Go struct field name.
+func fieldName(field reflect.StructField) string {
+	for _, tag := range []string{"json", "uri", "form"} {
+		name := strings.SplitN(field.Tag.Get(tag), ",", 2)[0]
+		if name == "-" {
+			return ""
+		}
+		if name != "" {
+			return name
+		}
+	}
+
+	return field.Name
+}
@@ -0,0 +1,60 @@
+package rest
+
+import (
+	"net/http"
+
+	"github.com/NhutHuyDev/sgbank/internal/recipient"
+	"github.com/NhutHuyDev/sgbank/internal/token"
+	"github.com/gin-gonic/gin"
+)
+
+type lookupRecipientDTO struct {
+	Recipient string `form:"recipient" binding:"required"`
+	Currency  string `form:"currency" binding:"required,currency"`
+}
+
+type LookupRecipientRes struct {
+	MaskedName string `json:"masked_name"`
+	Currency   string `json:"currency"`
+}
+
+// LookupRecipient godoc
+// @Summary      Look up a transfer recipient
+// @Description  Check that a username or verified email can receive transfers in a currency. Only the masked name of the recipient is returned, and lookups are audited and rate limited.
+// @Tags         transfers
+// @Produce      json
+// @Param        recipient  query     string  true  "Username or verified email"
+// @Param        currency   query     string  true  "Currency of the recipient account"
+// @Success      200  {object}  LookupRecipientRes
+// @Failure      400  {object}  domain.Problem "Invalid parameters"
+// @Failure      401  {object}  domain.Problem "Unauthenticated"
+// @Failure      404  {object}  domain.Problem "Recipient not found"
+// @Failure      429  {object}  domain.Problem "Lookup limit reached"
+// @Security     BearerAuth
+// @Router       /v1/recipients/lookup [get]
+func (server *Server) lookupRecipientHandler(ctx *gin.Context) {
+	var req lookupRecipientDTO
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		writeError(ctx, bindingError(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(AuthorizationPayloadKey).(*token.Payload)
+
+	found, err := server.Recipients.Resolve(ctx, recipient.Request{
+		Requester:  authPayload.Username,
+		Identifier: req.Recipient,
+		Currency:   req.Currency,
+		Purpose:    recipient.PurposeLookup,
+		ClientIP:   ctx.ClientIP(),
+	})
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, LookupRecipientRes{
+		MaskedName: found.MaskedName(),
+		Currency:   found.Account.Currency,
+	})
+}
@@ -0,0 +1,268 @@
+package rest
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/NhutHuyDev/sgbank/internal/infra/db"
+	"github.com/NhutHuyDev/sgbank/internal/screening"
+	"github.com/NhutHuyDev/sgbank/internal/token"
+	"github.com/gin-gonic/gin"
+)
+
+type searchScreeningListsDTO struct {
+	Name string `form:"name" binding:"required,max=200"`
+}
+
+type listScreeningMatchesDTO struct {
+	Action   string `form:"action" binding:"omitempty,oneof=flag block"`
+	AfterID  int64  `form:"after_id" binding:"omitempty,min=1"`
+	PageSize int32  `form:"page_size" binding:"omitempty,min=1,max=100"`
+}
+
+type whitelistScreeningMatchDTO struct {
+	Name    string `json:"name" binding:"required,max=200"`
+	EntryID string `json:"entry_id" binding:"required"`
+	Reason  string `json:"reason"`
+}
+
+type screeningWhitelistEntryDTO struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+type SearchScreeningListsRes struct {
+	Matches []screening.Match `json:"matches"`
+}
+
+// ScreeningMatchRes is a name that matched a sanctions list entry.
+// AccountID is the account a transfer was sent to.
+type ScreeningMatchRes struct {
+	ID        int64     `json:"id"`
+	Subject   string    `json:"subject"`
+	Name      string    `json:"name"`
+	Username  string    `json:"username"`
+	AccountID *int64    `json:"account_id,omitempty"`
+	List      string    `json:"list"`
+	EntryID   string    `json:"entry_id"`
+	EntryName string    `json:"entry_name"`
+	Score     float64   `json:"score"`
+	Action    string    `json:"action"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type ListScreeningMatchesRes struct {
+	Matches []ScreeningMatchRes `json:"matches"`
+	// NextAfterID is the after_id of the next page, 0 on the last page.
+	NextAfterID int64 `json:"next_after_id,omitempty"`
+}
+
+func screeningMatchRes(match db.ScreeningMatch) ScreeningMatchRes {
+	res := ScreeningMatchRes{
+		ID:        match.ID,
+		Subject:   match.Subject,
+		Name:      match.Name,
+		Username:  match.Username,
+		List:      match.List,
+		EntryID:   match.EntryID,
+		EntryName: match.EntryName,
+		Score:     match.Score,
+		Action:    match.Action,
+		CreatedAt: match.CreatedAt,
+	}
+
+	if match.AccountID.Valid {
+		res.AccountID = &match.AccountID.Int64
+	}
+
+	return res
+}
+
+type ListScreeningWhitelistRes struct {
+	Entries []db.ScreeningWhitelist `json:"entries"`
+}
+
+// ScreeningStatus godoc
+// @Summary      Get the sanctions lists loaded
+// @Description  Get the sanctions list files loaded, their entries and when they were loaded. Admins only.
+// @Tags         admin
+// @Produce      json
+// @Success      200  {object}  screening.Status
+// @Failure      403  {object}  domain.Problem "Not an admin"
+// @Security     BearerAuth
+// @Router       /v1/admin/screening [get]
+func (server *Server) screeningStatusHandler(ctx *gin.Context) {
+	ctx.JSON(http.StatusOK, server.Screening.Lists().Status())
+}
+
+// ReloadScreeningLists godoc
+// @Summary      Reload the sanctions lists
+// @Description  Read the sanctions list files again without waiting for the worker to notice they changed. When a file cannot be read the lists loaded before stay in use. Admins only.
+// @Tags         admin
+// @Produce      json
+// @Success      200  {object}  screening.Status
+// @Failure      403  {object}  domain.Problem "Not an admin"
+// @Failure      422  {object}  domain.Problem "A list file cannot be read"
+// @Security     BearerAuth
+// @Router       /v1/admin/screening/reload [post]
+func (server *Server) reloadScreeningListsHandler(ctx *gin.Context) {
+	status, err := server.Screening.Reload()
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, status)
+}
+
+// SearchScreeningLists godoc
+// @Summary      Search the sanctions lists
+// @Description  Get the entries a name would be flagged or blocked for, whitelisted or not, best first. Nothing is stored. Admins only.
+// @Tags         admin
+// @Produce      json
+// @Param        name  query     string  true  "Name"
+// @Success      200  {object}  SearchScreeningListsRes
+// @Failure      400  {object}  domain.Problem "Invalid parameters"
+// @Failure      403  {object}  domain.Problem "Not an admin"
+// @Security     BearerAuth
+// @Router       /v1/admin/screening/search [get]
+func (server *Server) searchScreeningListsHandler(ctx *gin.Context) {
+	var req searchScreeningListsDTO
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		writeError(ctx, bindingError(err))
+		return
+	}
+
+	res := SearchScreeningListsRes{Matches: server.Screening.Search(req.Name)}
+	if res.Matches == nil {
+		res.Matches = []screening.Match{}
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+// ListScreeningMatches godoc
+// @Summary      List sanctions list matches
+// @Description  List the signups and transfer counterparties that matched a sanctions list entry, oldest first. Admins only.
+// @Tags         admin
+// @Produce      json
+// @Param        action     query     string  false  "flag or block"
+// @Param        after_id   query     int     false  "Return matches after this ID"
+// @Param        page_size  query     int     false  "Matches per page, 1 to 100 (default 100)"
+// @Success      200  {object}  ListScreeningMatchesRes
+// @Failure      400  {object}  domain.Problem "Invalid parameters"
+// @Failure      403  {object}  domain.Problem "Not an admin"
+// @Security     BearerAuth
+// @Router       /v1/admin/screening/matches [get]
+func (server *Server) listScreeningMatchesHandler(ctx *gin.Context) {
+	var req listScreeningMatchesDTO
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		writeError(ctx, bindingError(err))
+		return
+	}
+
+	if req.PageSize == 0 {
+		req.PageSize = screening.PageSize
+	}
+
+	matches, err := server.Screening.Matches(ctx, req.Action, req.AfterID, req.PageSize)
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	res := ListScreeningMatchesRes{Matches: make([]ScreeningMatchRes, 0, len(matches))}
+	for _, match := range matches {
+		res.Matches = append(res.Matches, screeningMatchRes(match))
+	}
+	if len(matches) == int(req.PageSize) {
+		res.NextAfterID = matches[len(matches)-1].ID
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+// ListScreeningWhitelist godoc
+// @Summary      List whitelisted sanctions matches
+// @Description  List the names that are no longer matched against a sanctions list entry. Admins only.
+// @Tags         admin
+// @Produce      json
+// @Success      200  {object}  ListScreeningWhitelistRes
+// @Failure      403  {object}  domain.Problem "Not an admin"
+// @Security     BearerAuth
+// @Router       /v1/admin/screening/whitelist [get]
+func (server *Server) listScreeningWhitelistHandler(ctx *gin.Context) {
+	entries, err := server.Screening.Whitelisted(ctx)
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	res := ListScreeningWhitelistRes{Entries: entries}
+	if res.Entries == nil {
+		res.Entries = []db.ScreeningWhitelist{}
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+// WhitelistScreeningMatch godoc
+// @Summary      Whitelist a sanctions match
+// @Description  Stop matching a name against a sanctions list entry, once the match was found to be a false positive. The name is stored normalized, so it also covers other accents, punctuation and word order. Admins only.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        body  body      whitelistScreeningMatchDTO  true  "Name and entry"
+// @Success      201  {object}  db.ScreeningWhitelist
+// @Failure      400  {object}  domain.Problem "Invalid parameters"
+// @Failure      403  {object}  domain.Problem "Not an admin"
+// @Failure      409  {object}  domain.Problem "Already whitelisted"
+// @Security     BearerAuth
+// @Router       /v1/admin/screening/whitelist [post]
+func (server *Server) whitelistScreeningMatchHandler(ctx *gin.Context) {
+	var req whitelistScreeningMatchDTO
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		writeError(ctx, bindingError(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(AuthorizationPayloadKey).(*token.Payload)
+	entry, err := server.Screening.Whitelist(ctx, screening.WhitelistParams{
+		Name:      req.Name,
+		EntryID:   req.EntryID,
+		Reason:    req.Reason,
+		CreatedBy: authPayload.Username,
+	})
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, entry)
+}
+
+// DeleteScreeningWhitelistEntry godoc
+// @Summary      Remove a whitelisted sanctions match
+// @Description  Match the name against the sanctions list entry again. Admins only.
+// @Tags         admin
+// @Produce      json
+// @Param        id   path      int  true  "Whitelist entry ID"
+// @Success      200  {object}  db.ScreeningWhitelist
+// @Failure      403  {object}  domain.Problem "Not an admin"
+// @Failure      404  {object}  domain.Problem "Not found"
+// @Security     BearerAuth
+// @Router       /v1/admin/screening/whitelist/{id} [delete]
+func (server *Server) deleteScreeningWhitelistEntryHandler(ctx *gin.Context) {
+	var uri screeningWhitelistEntryDTO
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		writeError(ctx, bindingError(err))
+		return
+	}
+
+	entry, err := server.Screening.RemoveFromWhitelist(ctx, uri.ID)
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, entry)
+}
@@ -4,13 +4,39 @@ import (
 	"fmt"
 	"net/http"
 
+	"github.com/NhutHuyDev/sgbank/internal/accountno"
+	"github.com/NhutHuyDev/sgbank/internal/balance"
+	"github.com/NhutHuyDev/sgbank/internal/beneficiary"
+	"github.com/NhutHuyDev/sgbank/internal/category"
+	"github.com/NhutHuyDev/sgbank/internal/currency"
+	"github.com/NhutHuyDev/sgbank/internal/dispute"
+	"github.com/NhutHuyDev/sgbank/internal/domain"
+	"github.com/NhutHuyDev/sgbank/internal/eod"
+	"github.com/NhutHuyDev/sgbank/internal/escrow"
+	"github.com/NhutHuyDev/sgbank/internal/fee"
+	"github.com/NhutHuyDev/sgbank/internal/fraud"
 	"github.com/NhutHuyDev/sgbank/internal/infra/db"
+	"github.com/NhutHuyDev/sgbank/internal/kyc"
+	"github.com/NhutHuyDev/sgbank/internal/ledger"
+	"github.com/NhutHuyDev/sgbank/internal/logging"
+	"github.com/NhutHuyDev/sgbank/internal/metrics"
+	"github.com/NhutHuyDev/sgbank/internal/notify"
+	"github.com/NhutHuyDev/sgbank/internal/overdraft"
+	"github.com/NhutHuyDev/sgbank/internal/pagination"
+	"github.com/NhutHuyDev/sgbank/internal/payment"
+	"github.com/NhutHuyDev/sgbank/internal/paymentrequest"
+	"github.com/NhutHuyDev/sgbank/internal/recipient"
+	"github.com/NhutHuyDev/sgbank/internal/screening"
+	"github.com/NhutHuyDev/sgbank/internal/services"
 	"github.com/NhutHuyDev/sgbank/internal/token"
+	"github.com/NhutHuyDev/sgbank/internal/tracing"
+	"github.com/NhutHuyDev/sgbank/internal/transferbatch"
 	"github.com/NhutHuyDev/sgbank/pkg/utils"
 
 	"github.com/gin-gonic/gin"
 	"github.com/gin-gonic/gin/binding"
 	"github.com/go-playground/validator/v10"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
 
 	_ "github.com/NhutHuyDev/sgbank/doc/swagger"
 	swaggerFiles "github.com/swaggo/files"
@@ -18,30 +44,84 @@ import (
 )
 
 type Server struct {
-	Config     utils.Config
-	Store      db.Store
-	TokenMaker token.Maker
-	Router     *gin.Engine
+	Config          utils.Config
+	Store           db.Store
+	TokenMaker      token.Maker
+	Paginator       *pagination.Paginator
+	Recipients      *recipient.Resolver
+	AccountNumbers  *accountno.Generator
+	Balances        *balance.Service
+	Beneficiaries   *beneficiary.Service
+	Categories      *category.Service
+	Currencies      *currency.Service
+	Disputes        *dispute.Service
+	EndOfDay        *eod.Service
+	Escrows         *escrow.Service
+	Fees            *fee.Service
+	Fraud           *fraud.Service
+	KYC             *kyc.Service
+	Ledger          *ledger.Service
+	Notifier        notify.Notifier
+	Overdrafts      *overdraft.Service
+	PaymentRequests *paymentrequest.Service
+	Payments        *payment.Service
+	Screening       *screening.Service
+	TransferBatches *transferbatch.Service
+	Router          *gin.Engine
 }
 
-func NewServer(config utils.Config, store db.Store) (*Server, error) {
+// NewServer routes the REST API to the services of set.
+func NewServer(config utils.Config, store db.Store, set *services.Set) (*Server, error) {
 	tokenMaker, err := token.NewPasetoMaker(config.TokenSymmetricKey)
 	if err != nil {
 		return nil, fmt.Errorf("cannot create token maker: %w", err)
 	}
 
+	paginator, err := pagination.FromConfig(config)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create paginator: %w", err)
+	}
+
 	server := &Server{
-		Config:     config,
-		Store:      store,
-		TokenMaker: tokenMaker,
+		Config:          config,
+		Store:           store,
+		TokenMaker:      tokenMaker,
+		Paginator:       paginator,
+		Recipients:      set.Recipients,
+		AccountNumbers:  set.AccountNumbers,
+		Balances:        set.Balances,
+		Beneficiaries:   set.Beneficiaries,
+		Categories:      set.Categories,
+		Currencies:      set.Currencies,
+		Disputes:        set.Disputes,
+		EndOfDay:        set.EndOfDay,
+		Escrows:         set.Escrows,
+		Fees:            set.Fees,
+		Fraud:           set.Fraud,
+		KYC:             set.KYC,
+		Ledger:          set.Ledger,
+		Notifier:        set.Notifier,
+		Overdrafts:      set.Overdrafts,
+		PaymentRequests: set.PaymentRequests,
+		Payments:        set.Payments,
+		Screening:       set.Screening,
+		TransferBatches: set.TransferBatches,
 	}
 
-	router := gin.Default()
+	router := gin.New()
+	router.ContextWithFallback = true
+	router.Use(
+		gin.Recovery(),
+		logging.GinMiddleware(),
+		otelgin.Middleware(tracing.ServiceName),
+		metrics.GinMiddleware(),
+	)
 
 	_ = router.SetTrustedProxies([]string{"192.168.1.1"})
 
 	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
 		v.RegisterValidation("currency", currencyValidator)
+		v.RegisterTagNameFunc(fieldName)
 	}
 
 	router.GET("/v1/healthz", func(ctx *gin.Context) {
@@ -65,13 +145,99 @@ func NewServer(config utils.Config, store db.Store) (*Server, error) {
 	authRoutes.GET("/v1/accounts", server.listAccountsHandler)
 	authRoutes.GET("/v1/accounts/:id", server.getAccountHandler)
 	authRoutes.POST("/v1/accounts", server.createAccountHandler)
+	authRoutes.GET("/v1/accounts/:id/entries", server.listEntriesHandler)
+	authRoutes.GET("/v1/accounts/:id/balance", server.getAccountBalanceHandler)
+	authRoutes.GET("/v1/accounts/:id/balance-history", server.getBalanceHistoryHandler)
+	authRoutes.GET("/v1/account-products", server.listAccountProductsHandler)
+	authRoutes.GET("/v1/currencies", server.listCurrenciesHandler)
 
+	authRoutes.GET("/v1/transfers", server.listTransfersHandler)
 	authRoutes.POST("/v1/transfers", server.transferHandler)
+	authRoutes.GET("/v1/transfers/quote", server.quoteTransferHandler)
+	authRoutes.GET("/v1/transfer-batches", server.listTransferBatchesHandler)
+	authRoutes.POST("/v1/transfer-batches", server.createTransferBatchHandler)
+	authRoutes.GET("/v1/transfer-batches/:id", server.getTransferBatchHandler)
+	authRoutes.GET("/v1/transfer-batches/:id/results", server.getTransferBatchResultsHandler)
+	authRoutes.GET("/v1/recipients/lookup", server.lookupRecipientHandler)
+
+	authRoutes.GET("/v1/beneficiaries", server.listBeneficiariesHandler)
+	authRoutes.POST("/v1/beneficiaries", server.createBeneficiaryHandler)
+	authRoutes.GET("/v1/beneficiaries/:id", server.getBeneficiaryHandler)
+	authRoutes.PATCH("/v1/beneficiaries/:id", server.updateBeneficiaryHandler)
+	authRoutes.DELETE("/v1/beneficiaries/:id", server.deleteBeneficiaryHandler)
+
+	authRoutes.GET("/v1/categorization-rules", server.listCategorizationRulesHandler)
+	authRoutes.POST("/v1/categorization-rules", server.createCategorizationRuleHandler)
+	authRoutes.DELETE("/v1/categorization-rules/:id", server.deleteCategorizationRuleHandler)
+
+	authRoutes.GET("/v1/payment-requests", server.listPaymentRequestsHandler)
+	authRoutes.POST("/v1/payment-requests", server.createPaymentRequestHandler)
+	authRoutes.GET("/v1/payment-requests/:id", server.getPaymentRequestHandler)
+	authRoutes.POST("/v1/payment-requests/:id/accept", server.acceptPaymentRequestHandler)
+	authRoutes.POST("/v1/payment-requests/:id/decline", server.declinePaymentRequestHandler)
+	authRoutes.POST("/v1/payment-requests/:id/cancel", server.cancelPaymentRequestHandler)
+	authRoutes.GET("/v1/payment-links/:token", server.getPaymentLinkHandler)
+	authRoutes.POST("/v1/payment-links/:token/accept", server.acceptPaymentLinkHandler)
+
+	authRoutes.GET("/v1/notifications", server.listNotificationsHandler)
+
+	authRoutes.GET("/v1/kyc", server.getKYCStatusHandler)
+	authRoutes.POST("/v1/kyc/submissions", server.submitKYCHandler)
+	authRoutes.GET("/v1/kyc/submissions/:id", server.getKYCSubmissionHandler)
+
+	authRoutes.POST("/v1/disputes", server.openDisputeHandler)
+	authRoutes.GET("/v1/disputes", server.listDisputesHandler)
+	authRoutes.GET("/v1/disputes/:id", server.getDisputeHandler)
+	authRoutes.POST("/v1/disputes/:id/evidence", server.addDisputeEvidenceHandler)
+	authRoutes.POST("/v1/disputes/:id/withdraw", server.withdrawDisputeHandler)
+
+	authRoutes.POST("/v1/escrows", server.createEscrowHandler)
+	authRoutes.GET("/v1/escrows", server.listEscrowsHandler)
+	authRoutes.GET("/v1/escrows/:id", server.getEscrowHandler)
+	authRoutes.POST("/v1/escrows/:id/confirm", server.confirmEscrowHandler)
+	authRoutes.POST("/v1/escrows/:id/cancel", server.cancelEscrowHandler)
+	authRoutes.POST("/v1/escrows/:id/dispute", server.disputeEscrowHandler)
+
+	adminRoutes := router.Group("/v1/admin").Use(AuthMiddleware(server.TokenMaker), AdminMiddleware(server.Store))
+
+	adminRoutes.GET("/accounts/:id/overdraft", server.getOverdraftHandler)
+	adminRoutes.PUT("/accounts/:id/overdraft", server.approveOverdraftHandler)
+	adminRoutes.GET("/overdrafts", server.listOverdraftsHandler)
+	adminRoutes.PUT("/currencies/:code", server.upsertCurrencyHandler)
+	adminRoutes.GET("/ledger/accounts", server.listGLAccountsHandler)
+	adminRoutes.GET("/ledger/trial-balance", server.trialBalanceHandler)
+	adminRoutes.GET("/ledger/balance-sheet", server.balanceSheetHandler)
+	adminRoutes.GET("/ledger/profit-and-loss", server.profitAndLossHandler)
+	adminRoutes.GET("/eod", server.eodStatusHandler)
+	adminRoutes.GET("/eod/days/:date", server.getBusinessDayHandler)
+	adminRoutes.POST("/eod/close", server.closeBusinessDayHandler)
+	adminRoutes.POST("/eod/resume", server.resumeBusinessDayHandler)
+	adminRoutes.GET("/fraud/reviews", server.listFraudReviewsHandler)
+	adminRoutes.GET("/fraud/decisions/:id", server.getFraudDecisionHandler)
+	adminRoutes.POST("/fraud/decisions/:id/approve", server.approveFraudDecisionHandler)
+	adminRoutes.POST("/fraud/decisions/:id/reject", server.rejectFraudDecisionHandler)
+	adminRoutes.GET("/screening", server.screeningStatusHandler)
+	adminRoutes.POST("/screening/reload", server.reloadScreeningListsHandler)
+	adminRoutes.GET("/screening/search", server.searchScreeningListsHandler)
+	adminRoutes.GET("/screening/matches", server.listScreeningMatchesHandler)
+	adminRoutes.GET("/screening/whitelist", server.listScreeningWhitelistHandler)
+	adminRoutes.POST("/screening/whitelist", server.whitelistScreeningMatchHandler)
+	adminRoutes.DELETE("/screening/whitelist/:id", server.deleteScreeningWhitelistEntryHandler)
+	adminRoutes.GET("/kyc/submissions", server.listKYCSubmissionsHandler)
+	adminRoutes.GET("/kyc/submissions/:id", server.reviewKYCSubmissionHandler)
+	adminRoutes.GET("/kyc/submissions/:id/documents/:document_id", server.downloadKYCDocumentHandler)
+	adminRoutes.POST("/kyc/submissions/:id/approve", server.approveKYCSubmissionHandler)
+	adminRoutes.POST("/kyc/submissions/:id/reject", server.rejectKYCSubmissionHandler)
+	adminRoutes.GET("/disputes", server.listDisputeQueueHandler)
+	adminRoutes.GET("/disputes/:id", server.getDisputeCaseHandler)
+	adminRoutes.GET("/disputes/:id/evidence/:evidence_id", server.downloadDisputeEvidenceHandler)
+	adminRoutes.POST("/disputes/:id/review", server.reviewDisputeHandler)
+	adminRoutes.POST("/disputes/:id/resolve", server.resolveDisputeHandler)
+	adminRoutes.GET("/escrows", server.listEscrowQueueHandler)
+	adminRoutes.POST("/escrows/:id/resolve", server.resolveEscrowHandler)
 
 	router.NoRoute(func(c *gin.Context) {
-		c.JSON(http.StatusNotFound, gin.H{
-			"error": "not found",
-		})
+		writeError(c, domain.ErrNotFound)
 	})
 
 	server.Router = router
@@ -82,9 +248,3 @@ func NewServer(config utils.Config, store db.Store) (*Server, error) {
 func (server *Server) Start(address string) error {
 	return server.Router.Run(address)
 }
-
-func errorResponse(err error) gin.H {
-	return gin.H{
-		"error": err.Error(),
-	}
-}
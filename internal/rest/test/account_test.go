@@ -8,9 +8,12 @@ import (
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/NhutHuyDev/sgbank/internal/accountno"
+	"github.com/NhutHuyDev/sgbank/internal/domain"
 	"github.com/NhutHuyDev/sgbank/internal/infra/db"
 	mockdb "github.com/NhutHuyDev/sgbank/internal/infra/db/mock"
 	"github.com/NhutHuyDev/sgbank/internal/rest"
@@ -61,7 +64,8 @@ func TestGetAccountAPI(t *testing.T) {
 					Return(account, nil)
 			},
 			checkResponse: func(t *testing.T, recoder *httptest.ResponseRecorder) {
-				require.Equal(t, http.StatusUnauthorized, recoder.Code)
+				require.Equal(t, http.StatusForbidden, recoder.Code)
+				requireBodyMatchProblem(t, recoder, domain.ErrForbidden)
 			},
 		},
 		{
@@ -88,10 +92,11 @@ func TestGetAccountAPI(t *testing.T) {
 				store.EXPECT().
 					GetAccount(gomock.Any(), gomock.Eq(account.ID)).
 					Times(1).
-					Return(db.Account{}, sql.ErrNoRows)
+					Return(db.Account{}, domain.ErrAccountNotFound)
 			},
 			checkResponse: func(t *testing.T, recoder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusNotFound, recoder.Code)
+				requireBodyMatchProblem(t, recoder, domain.ErrAccountNotFound)
 			},
 		},
 		{
@@ -108,6 +113,8 @@ func TestGetAccountAPI(t *testing.T) {
 			},
 			checkResponse: func(t *testing.T, recoder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusInternalServerError, recoder.Code)
+				problem := requireBodyMatchProblem(t, recoder, domain.ErrInternal)
+				require.Empty(t, problem.Detail)
 			},
 		},
 		{
@@ -123,6 +130,7 @@ func TestGetAccountAPI(t *testing.T) {
 			},
 			checkResponse: func(t *testing.T, recoder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusBadRequest, recoder.Code)
+				requireBodyMatchProblem(t, recoder, domain.ErrInvalidArgument)
 			},
 		},
 	}
@@ -153,15 +161,200 @@ func TestGetAccountAPI(t *testing.T) {
 
 }
 
+func TestGetAccountByNumberAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	account := randomAccount(user.Username)
+
+	typo := typoAccountNumber(account.AccountNumber)
+
+	testCases := []struct {
+		name          string
+		ref           string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recoder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			ref:  strings.ToLower(account.AccountNumber[:4]) + "%20" + account.AccountNumber[4:],
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetAccountByNumber(gomock.Any(), gomock.Eq(account.AccountNumber)).
+					Times(1).
+					Return(account, nil)
+			},
+			checkResponse: func(t *testing.T, recoder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recoder.Code)
+				requireBodyMatchAccount(t, recoder.Body, account)
+			},
+		},
+		{
+			name: "Typo",
+			ref:  typo,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccountByNumber(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recoder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recoder.Code)
+				problem := requireBodyMatchProblem(t, recoder, domain.ErrInvalidArgument)
+				require.Equal(t, "id", problem.InvalidParams[0].Field)
+				require.Equal(t, accountno.ErrInvalidCheckDigits.Error(), problem.InvalidParams[0].Description)
+			},
+		},
+		{
+			name: "NotFound",
+			ref:  account.AccountNumber,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetAccountByNumber(gomock.Any(), gomock.Eq(account.AccountNumber)).
+					Times(1).
+					Return(db.Account{}, domain.ErrAccountNotFound)
+			},
+			checkResponse: func(t *testing.T, recoder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recoder.Code)
+				requireBodyMatchProblem(t, recoder, domain.ErrAccountNotFound)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recoder := httptest.NewRecorder()
+
+			request, err := http.NewRequest(http.MethodGet, "/v1/accounts/"+tc.ref, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.TokenMaker, rest.AuthorizationTypeBearer, user.Username, time.Minute)
+
+			server.Router.ServeHTTP(recoder, request)
+			tc.checkResponse(t, recoder)
+		})
+	}
+}
+
+func TestCreateAccountAPI(t *testing.T) {
+	user, _ := randomUser(t)
+
+	testCases := []struct {
+		name          string
+		body          string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recoder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "Savings",
+			body: `{"currency": "USD", "product": "savings"}`,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccountProduct(gomock.Any(), gomock.Eq("savings")).Times(1).
+					Return(db.AccountProduct{Code: "savings", AnnualRatePpm: 20000}, nil)
+				store.EXPECT().CreateAccountTx(gomock.Any(), gomock.Any()).Times(1).
+					DoAndReturn(func(_ any, arg db.CreateAccountParams) (db.Account, error) {
+						require.Equal(t, user.Username, arg.Owner)
+						require.Equal(t, "savings", arg.Product)
+						return db.Account{ID: 1, Owner: arg.Owner, Currency: arg.Currency, Product: arg.Product}, nil
+					})
+			},
+			checkResponse: func(t *testing.T, recoder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recoder.Code)
+			},
+		},
+		{
+			name: "DefaultsToChecking",
+			body: `{"currency": "USD"}`,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccountProduct(gomock.Any(), gomock.Eq("checking")).Times(1).
+					Return(db.AccountProduct{Code: "checking"}, nil)
+				store.EXPECT().CreateAccountTx(gomock.Any(), gomock.Any()).Times(1)
+			},
+			checkResponse: func(t *testing.T, recoder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recoder.Code)
+			},
+		},
+		{
+			name: "KYCLimitExceeded",
+			body: `{"currency": "USD"}`,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccountProduct(gomock.Any(), gomock.Eq("checking")).Times(1).
+					Return(db.AccountProduct{Code: "checking"}, nil)
+				store.EXPECT().CreateAccountTx(gomock.Any(), gomock.Any()).Times(1).
+					Return(db.Account{}, fmt.Errorf("%w: level 0 (Unverified) allows 1 accounts", domain.ErrKYCLimitExceeded))
+			},
+			checkResponse: func(t *testing.T, recoder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnprocessableEntity, recoder.Code)
+				requireBodyMatchProblem(t, recoder, domain.ErrKYCLimitExceeded)
+			},
+		},
+		{
+			name: "InternalProduct",
+			body: `{"currency": "USD", "product": "interest_expense"}`,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccountProduct(gomock.Any(), gomock.Any()).Times(1).
+					Return(db.AccountProduct{Code: "interest_expense", IsInternal: true}, nil)
+				store.EXPECT().CreateAccountTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recoder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recoder.Code)
+				problem := requireBodyMatchProblem(t, recoder, domain.ErrInvalidArgument)
+				require.Equal(t, "product", problem.InvalidParams[0].Field)
+			},
+		},
+		{
+			name: "UnknownProduct",
+			body: `{"currency": "USD", "product": "gold"}`,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccountProduct(gomock.Any(), gomock.Any()).Times(1).Return(db.AccountProduct{}, domain.ErrNotFound)
+				store.EXPECT().CreateAccountTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recoder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recoder.Code)
+				requireBodyMatchProblem(t, recoder, domain.ErrInvalidArgument)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recoder := httptest.NewRecorder()
+
+			request, err := http.NewRequest(http.MethodPost, "/v1/accounts", strings.NewReader(tc.body))
+			require.NoError(t, err)
+			addAuthorization(t, request, server.TokenMaker, rest.AuthorizationTypeBearer, user.Username, time.Minute)
+
+			server.Router.ServeHTTP(recoder, request)
+			tc.checkResponse(t, recoder)
+		})
+	}
+}
+
 func randomAccount(owner string) db.Account {
 	return db.Account{
-		ID:       int64(utils.RandomInt(1, 1000)),
-		Owner:    owner,
-		Balance:  int64(utils.RandomMoney()),
-		Currency: utils.RandomCurrency(),
+		ID:            int64(utils.RandomInt(1, 1000)),
+		Owner:         owner,
+		Balance:       int64(utils.RandomMoney()),
+		Currency:      utils.RandomCurrency(),
+		AccountNumber: randomAccountNumber(),
 	}
 }
 
+func randomAccountNumber() string {
+	generator, _ := accountno.NewGenerator(accountno.DefaultBankCode, accountno.DefaultBranchCode)
+	number, _ := generator.Generate()
+	return number
+}
+
 func requireBodyMatchAccount(t *testing.T, body *bytes.Buffer, account db.Account) {
 	data, err := io.ReadAll(body)
 	require.NoError(t, err)
@@ -172,3 +365,16 @@ func requireBodyMatchAccount(t *testing.T, body *bytes.Buffer, account db.Accoun
 	require.NoError(t, err)
 	require.Equal(t, account, gotAccount.Account)
 }
+
+func requireBodyMatchProblem(t *testing.T, recoder *httptest.ResponseRecorder, want *domain.Error) domain.Problem {
+	require.Equal(t, domain.ProblemContentType, recoder.Header().Get("Content-Type"))
+
+	var problem domain.Problem
+	err := json.Unmarshal(recoder.Body.Bytes(), &problem)
+	require.NoError(t, err)
+
+	require.Equal(t, want.Code, problem.Code)
+	require.Equal(t, recoder.Code, problem.Status)
+	require.Equal(t, "urn:sgbank:problem:"+want.Code, problem.Type)
+	return problem
+}
@@ -0,0 +1,136 @@
+package test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/NhutHuyDev/sgbank/internal/domain"
+	"github.com/NhutHuyDev/sgbank/internal/infra/db"
+	mockdb "github.com/NhutHuyDev/sgbank/internal/infra/db/mock"
+	"github.com/NhutHuyDev/sgbank/internal/rest"
+	"github.com/NhutHuyDev/sgbank/pkg/utils"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetAccountBalanceAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	account := db.Account{ID: 7, Owner: user.Username, Currency: utils.USD, CreatedAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	at := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name          string
+		username      string
+		query         string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recoder *httptest.ResponseRecorder)
+	}{
+		{
+			name:     "OK",
+			username: user.Username,
+			query:    "?at=2024-03-01T12:00:00Z",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().
+					GetAccountBalanceBefore(gomock.Any(), gomock.Eq(db.GetAccountBalanceBeforeParams{AccountID: account.ID, Before: at})).
+					Times(1).
+					Return(int64(1234), nil)
+			},
+			checkResponse: func(t *testing.T, recoder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recoder.Code)
+
+				var res rest.AccountBalanceRes
+				require.NoError(t, json.Unmarshal(recoder.Body.Bytes(), &res))
+				require.Equal(t, int64(1234), res.Balance)
+				require.Equal(t, "12.34", res.BalanceDecimal)
+				require.True(t, at.Equal(res.At))
+			},
+		},
+		{
+			name:     "Forbidden",
+			username: "someone_else",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().GetAccountBalanceBefore(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recoder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recoder.Code)
+				requireBodyMatchProblem(t, recoder, domain.ErrForbidden)
+			},
+		},
+		{
+			name:     "InvalidAt",
+			username: user.Username,
+			query:    "?at=yesterday",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recoder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recoder.Code)
+				requireBodyMatchProblem(t, recoder, domain.ErrInvalidArgument)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recoder := httptest.NewRecorder()
+
+			request, err := http.NewRequest(http.MethodGet, fmt.Sprintf("/v1/accounts/%d/balance%s", account.ID, tc.query), nil)
+			require.NoError(t, err)
+			addAuthorization(t, request, server.TokenMaker, rest.AuthorizationTypeBearer, tc.username, time.Minute)
+
+			server.Router.ServeHTTP(recoder, request)
+			tc.checkResponse(t, recoder)
+		})
+	}
+}
+
+func TestGetBalanceHistoryAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	account := db.Account{ID: 7, Owner: user.Username, Currency: utils.USD, CreatedAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	march := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+	store.EXPECT().
+		GetAccountBalanceBefore(gomock.Any(), gomock.Eq(db.GetAccountBalanceBeforeParams{AccountID: account.ID, Before: march})).
+		Times(1).
+		Return(int64(500), nil)
+	store.EXPECT().
+		SumEntriesByPeriod(gomock.Any(), gomock.Any()).
+		Times(1).
+		Return([]db.SumEntriesByPeriodRow{{PeriodStart: march.AddDate(0, 1, 0), Credits: 100}}, nil)
+
+	server := newTestServer(t, store)
+	recoder := httptest.NewRecorder()
+
+	request, err := http.NewRequest(http.MethodGet, fmt.Sprintf("/v1/accounts/%d/balance-history?interval=month&from=2024-03-15T00:00:00Z&to=2024-05-01T00:00:00Z", account.ID), nil)
+	require.NoError(t, err)
+	addAuthorization(t, request, server.TokenMaker, rest.AuthorizationTypeBearer, user.Username, time.Minute)
+
+	server.Router.ServeHTTP(recoder, request)
+	require.Equal(t, http.StatusOK, recoder.Code)
+
+	var res rest.BalanceHistoryRes
+	require.NoError(t, json.Unmarshal(recoder.Body.Bytes(), &res))
+	require.Equal(t, "month", res.Interval)
+	require.Len(t, res.Points, 2)
+	require.Equal(t, int64(500), res.Points[0].Closing)
+	require.Equal(t, int64(600), res.Points[1].Closing)
+	require.Equal(t, "6.00", res.Points[1].ClosingDecimal)
+}
@@ -0,0 +1,158 @@
+package test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/NhutHuyDev/sgbank/internal/domain"
+	"github.com/NhutHuyDev/sgbank/internal/infra/db"
+	mockdb "github.com/NhutHuyDev/sgbank/internal/infra/db/mock"
+	"github.com/NhutHuyDev/sgbank/internal/rest"
+	"github.com/NhutHuyDev/sgbank/pkg/utils"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBeneficiaryAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	payee, _ := randomUser(t)
+
+	account := randomAccount(payee.Username)
+	account.Currency = utils.USD
+
+	beneficiary := db.Beneficiary{
+		ID:            int64(utils.RandomInt(1, 1000)),
+		Owner:         user.Username,
+		AccountNumber: account.AccountNumber,
+		Nickname:      "landlord",
+		Currency:      utils.USD,
+		IsVerified:    true,
+		CreatedAt:     time.Now(),
+	}
+
+	testCases := []struct {
+		name          string
+		method        string
+		url           string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recoder *httptest.ResponseRecorder)
+	}{
+		{
+			name:   "Create",
+			method: http.MethodPost,
+			url:    "/v1/beneficiaries",
+			body:   gin.H{"account_number": account.AccountNumber, "nickname": "landlord", "currency": utils.USD},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccountByNumber(gomock.Any(), gomock.Eq(account.AccountNumber)).Times(1).Return(account, nil)
+				store.EXPECT().CreateBeneficiary(gomock.Any(), gomock.Eq(db.CreateBeneficiaryParams{
+					Owner:         user.Username,
+					AccountNumber: account.AccountNumber,
+					Nickname:      "landlord",
+					Currency:      utils.USD,
+					IsVerified:    true,
+				})).Times(1).Return(beneficiary, nil)
+			},
+			checkResponse: func(t *testing.T, recoder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recoder.Code)
+
+				var res rest.BeneficiaryRes
+				require.NoError(t, json.Unmarshal(recoder.Body.Bytes(), &res))
+				require.Equal(t, beneficiary.ID, res.ID)
+				require.True(t, res.IsVerified)
+				require.True(t, res.CoolingOffUntil.After(beneficiary.CreatedAt))
+			},
+		},
+		{
+			name:   "CreateDuplicate",
+			method: http.MethodPost,
+			url:    "/v1/beneficiaries",
+			body:   gin.H{"account_number": account.AccountNumber, "nickname": "landlord", "currency": utils.USD},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccountByNumber(gomock.Any(), gomock.Any()).Times(1).Return(account, nil)
+				store.EXPECT().CreateBeneficiary(gomock.Any(), gomock.Any()).Times(1).Return(db.Beneficiary{}, domain.ErrDuplicate)
+			},
+			checkResponse: func(t *testing.T, recoder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recoder.Code)
+				requireBodyMatchProblem(t, recoder, domain.ErrDuplicate)
+			},
+		},
+		{
+			name:   "GetOtherUsersBeneficiary",
+			method: http.MethodGet,
+			url:    fmt.Sprintf("/v1/beneficiaries/%d", beneficiary.ID),
+			buildStubs: func(store *mockdb.MockStore) {
+				other := beneficiary
+				other.Owner = payee.Username
+				store.EXPECT().GetBeneficiary(gomock.Any(), gomock.Eq(beneficiary.ID)).Times(1).Return(other, nil)
+			},
+			checkResponse: func(t *testing.T, recoder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recoder.Code)
+				requireBodyMatchProblem(t, recoder, domain.ErrForbidden)
+			},
+		},
+		{
+			name:   "Rename",
+			method: http.MethodPatch,
+			url:    fmt.Sprintf("/v1/beneficiaries/%d", beneficiary.ID),
+			body:   gin.H{"nickname": "old landlord"},
+			buildStubs: func(store *mockdb.MockStore) {
+				renamed := beneficiary
+				renamed.Nickname = "old landlord"
+				store.EXPECT().GetBeneficiary(gomock.Any(), gomock.Eq(beneficiary.ID)).Times(1).Return(beneficiary, nil)
+				store.EXPECT().UpdateBeneficiaryNickname(gomock.Any(), gomock.Eq(db.UpdateBeneficiaryNicknameParams{
+					ID:       beneficiary.ID,
+					Nickname: "old landlord",
+				})).Times(1).Return(renamed, nil)
+			},
+			checkResponse: func(t *testing.T, recoder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recoder.Code)
+				require.Contains(t, recoder.Body.String(), "old landlord")
+			},
+		},
+		{
+			name:   "Delete",
+			method: http.MethodDelete,
+			url:    fmt.Sprintf("/v1/beneficiaries/%d", beneficiary.ID),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetBeneficiary(gomock.Any(), gomock.Eq(beneficiary.ID)).Times(1).Return(beneficiary, nil)
+				store.EXPECT().DeleteBeneficiary(gomock.Any(), gomock.Eq(beneficiary.ID)).Times(1).Return(nil)
+			},
+			checkResponse: func(t *testing.T, recoder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNoContent, recoder.Code)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recoder := httptest.NewRecorder()
+
+			var body bytes.Buffer
+			if tc.body != nil {
+				require.NoError(t, json.NewEncoder(&body).Encode(tc.body))
+			}
+
+			request, err := http.NewRequest(tc.method, tc.url, &body)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.TokenMaker, rest.AuthorizationTypeBearer, user.Username, time.Minute)
+
+			server.Router.ServeHTTP(recoder, request)
+			tc.checkResponse(t, recoder)
+		})
+	}
+}
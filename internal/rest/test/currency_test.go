@@ -0,0 +1,130 @@
+package test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/NhutHuyDev/sgbank/internal/bank"
+	"github.com/NhutHuyDev/sgbank/internal/currency"
+	"github.com/NhutHuyDev/sgbank/internal/domain"
+	"github.com/NhutHuyDev/sgbank/internal/infra/db"
+	mockdb "github.com/NhutHuyDev/sgbank/internal/infra/db/mock"
+	"github.com/NhutHuyDev/sgbank/internal/rest"
+	"github.com/NhutHuyDev/sgbank/pkg/utils"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListCurrenciesAPI(t *testing.T) {
+	user, _ := randomUser(t)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	server := newTestServer(t, mockdb.NewMockStore(ctrl))
+	recoder := httptest.NewRecorder()
+
+	request, err := http.NewRequest(http.MethodGet, "/v1/currencies", nil)
+	require.NoError(t, err)
+	addAuthorization(t, request, server.TokenMaker, rest.AuthorizationTypeBearer, user.Username, time.Minute)
+
+	server.Router.ServeHTTP(recoder, request)
+	require.Equal(t, http.StatusOK, recoder.Code)
+
+	var res rest.ListCurrenciesRes
+	require.NoError(t, json.Unmarshal(recoder.Body.Bytes(), &res))
+	require.Equal(t, []rest.CurrencyRes{
+		{Code: utils.CAD, Name: "Canadian Dollar", Exponent: 2},
+		{Code: utils.EUR, Name: "Euro", Exponent: 2},
+		{Code: utils.USD, Name: "US Dollar", Exponent: 2},
+	}, res.Currencies)
+}
+
+func TestUpsertCurrencyAPI(t *testing.T) {
+	admin, _ := randomUser(t)
+	admin.Role = bank.RoleAdmin
+
+	jpy := db.Currency{Code: "JPY", Name: "Yen", Exponent: 0, Enabled: true}
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recoder *httptest.ResponseRecorder, registry *currency.Registry)
+	}{
+		{
+			name: "OK",
+			body: gin.H{"name": "Yen", "exponent": 0, "enabled": true},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetCurrency(gomock.Any(), gomock.Eq("JPY")).Times(1).Return(db.Currency{}, domain.ErrNotFound)
+				store.EXPECT().UpsertCurrency(gomock.Any(), gomock.Eq(db.UpsertCurrencyParams{
+					Code:    "JPY",
+					Name:    "Yen",
+					Enabled: true,
+				})).Times(1).Return(jpy, nil)
+				store.EXPECT().ListCurrencies(gomock.Any()).Times(1).Return([]db.Currency{jpy}, nil)
+			},
+			checkResponse: func(t *testing.T, recoder *httptest.ResponseRecorder, registry *currency.Registry) {
+				require.Equal(t, http.StatusOK, recoder.Code)
+				require.True(t, registry.IsSupported("JPY"))
+			},
+		},
+		{
+			name: "ExponentChanged",
+			body: gin.H{"name": "Yen", "exponent": 2, "enabled": true},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetCurrency(gomock.Any(), gomock.Eq("JPY")).Times(1).Return(jpy, nil)
+				store.EXPECT().UpsertCurrency(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recoder *httptest.ResponseRecorder, registry *currency.Registry) {
+				require.Equal(t, http.StatusBadRequest, recoder.Code)
+				requireBodyMatchProblem(t, recoder, domain.ErrInvalidArgument)
+				require.False(t, registry.IsSupported("JPY"))
+			},
+		},
+		{
+			name: "MissingExponent",
+			body: gin.H{"name": "Yen"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetCurrency(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recoder *httptest.ResponseRecorder, registry *currency.Registry) {
+				require.Equal(t, http.StatusBadRequest, recoder.Code)
+				problem := requireBodyMatchProblem(t, recoder, domain.ErrInvalidArgument)
+				require.Equal(t, "exponent", problem.InvalidParams[0].Field)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().GetUser(gomock.Any(), gomock.Eq(admin.Username)).Times(1).Return(admin, nil)
+			tc.buildStubs(store)
+
+			// a registry of its own keeps the shared one unchanged for other tests
+			registry := currency.NewRegistry()
+			server := newTestServer(t, store)
+			server.Currencies = currency.NewService(store, registry)
+			recoder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			request, err := http.NewRequest(http.MethodPut, "/v1/admin/currencies/jpy", bytes.NewReader(data))
+			require.NoError(t, err)
+			addAuthorization(t, request, server.TokenMaker, rest.AuthorizationTypeBearer, admin.Username, time.Minute)
+
+			server.Router.ServeHTTP(recoder, request)
+			tc.checkResponse(t, recoder, registry)
+		})
+	}
+}
@@ -0,0 +1,384 @@
+package test
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/NhutHuyDev/sgbank/internal/accountno"
+	"github.com/NhutHuyDev/sgbank/internal/bank"
+	"github.com/NhutHuyDev/sgbank/internal/blob"
+	"github.com/NhutHuyDev/sgbank/internal/dispute"
+	"github.com/NhutHuyDev/sgbank/internal/domain"
+	"github.com/NhutHuyDev/sgbank/internal/infra/db"
+	mockdb "github.com/NhutHuyDev/sgbank/internal/infra/db/mock"
+	"github.com/NhutHuyDev/sgbank/internal/notify"
+	"github.com/NhutHuyDev/sgbank/internal/rest"
+	"github.com/NhutHuyDev/sgbank/pkg/utils"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+// newDisputes keeps the evidence in a temporary directory and offers
+// provisional credits of up to 1000.
+func newDisputes(t *testing.T, store db.Store) *dispute.Service {
+	generator, err := accountno.NewGenerator(accountno.DefaultBankCode, accountno.DefaultBranchCode)
+	require.NoError(t, err)
+
+	return dispute.NewService(store, bank.NewAccounts(store, generator), blob.NewDisk(t.TempDir()), notify.NewStoreNotifier(store), dispute.Policy{
+		Window:                 dispute.DefaultWindow,
+		SLA:                    dispute.DefaultSLA,
+		SLAWarning:             dispute.DefaultSLAWarning,
+		ProvisionalCreditLimit: 1000,
+	})
+}
+
+func TestOpenDisputeAPI(t *testing.T) {
+	user, _ := randomUser(t)
+
+	account := randomAccount(user.Username)
+	account.Currency = utils.USD
+	transfer := db.Transfer{ID: 7, FromAccountID: account.ID, ToAccountID: account.ID + 1, Amount: 500, CreatedAt: time.Now(), Kind: db.TransferPayment}
+	payee := db.Account{ID: transfer.ToAccountID, Owner: "payee", Currency: utils.USD}
+	credits := db.Account{ID: account.ID + 2, Owner: bank.Username, Currency: utils.USD, Product: bank.ProductDisputeCredits}
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		evidence      []byte
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recoder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "ProvisionalCredit",
+			body: gin.H{"transfer_id": transfer.ID, "reason": "not_received", "provisional_credit": true},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetTransfer(gomock.Any(), gomock.Eq(transfer.ID)).Times(1).Return(transfer, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(payee.ID)).Times(1).Return(payee, nil)
+				store.EXPECT().GetAccountByOwnerProduct(gomock.Any(), gomock.Any()).Times(1).Return(credits, nil)
+				store.EXPECT().
+					OpenDisputeTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ any, arg db.OpenDisputeTxParams) (db.OpenDisputeTxResult, error) {
+						require.Equal(t, credits.ID, arg.CreditAccountID)
+						require.Equal(t, transfer.Amount, arg.Amount)
+						return db.OpenDisputeTxResult{
+							Dispute: db.Dispute{
+								ID:                          1,
+								TransferID:                  transfer.ID,
+								Username:                    user.Username,
+								Amount:                      arg.Amount,
+								Status:                      db.DisputeOpen,
+								ProvisionalCreditTransferID: sql.NullInt64{Int64: 11, Valid: true},
+								DueAt:                       arg.DueAt,
+							},
+							ProvisionalCredit: &db.TransferTxResult{Transfer: db.Transfer{ID: 11}},
+						}, nil
+					})
+			},
+			checkResponse: func(t *testing.T, recoder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusCreated, recoder.Code)
+
+				var res rest.OpenDisputeRes
+				require.NoError(t, json.Unmarshal(recoder.Body.Bytes(), &res))
+				require.Equal(t, db.DisputeOpen, res.Status)
+				require.EqualValues(t, 11, *res.ProvisionalCreditTransferID)
+				require.NotNil(t, res.ProvisionalCredit)
+				require.False(t, res.Overdue)
+			},
+		},
+		{
+			name:     "Evidence",
+			body:     gin.H{"transfer_id": transfer.ID, "reason": "not_as_described", "description": "broken on arrival"},
+			evidence: pngDocument,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetTransfer(gomock.Any(), gomock.Eq(transfer.ID)).Times(1).Return(transfer, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(payee.ID)).Times(1).Return(payee, nil)
+				store.EXPECT().
+					OpenDisputeTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ any, arg db.OpenDisputeTxParams) (db.OpenDisputeTxResult, error) {
+						require.Equal(t, "broken on arrival", arg.Description)
+						require.Len(t, arg.Evidence, 1)
+						require.Equal(t, "receipt.png", arg.Evidence[0].FileName)
+						return db.OpenDisputeTxResult{
+							Dispute:  db.Dispute{ID: 1, Status: db.DisputeOpen},
+							Evidence: []db.DisputeEvidence{{ID: 1, FileName: "receipt.png", StorageKey: arg.Evidence[0].StorageKey}},
+						}, nil
+					})
+			},
+			checkResponse: func(t *testing.T, recoder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusCreated, recoder.Code)
+
+				var res rest.OpenDisputeRes
+				require.NoError(t, json.Unmarshal(recoder.Body.Bytes(), &res))
+				require.Len(t, res.Evidence, 1)
+				require.Nil(t, res.ProvisionalCredit)
+				require.NotContains(t, recoder.Body.String(), "storage_key")
+			},
+		},
+		{
+			name: "AlreadyDisputed",
+			body: gin.H{"transfer_id": transfer.ID, "reason": "duplicate"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetTransfer(gomock.Any(), gomock.Eq(transfer.ID)).Times(1).Return(transfer, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(payee.ID)).Times(1).Return(payee, nil)
+				store.EXPECT().OpenDisputeTx(gomock.Any(), gomock.Any()).Times(1).Return(db.OpenDisputeTxResult{}, domain.ErrDuplicate)
+			},
+			checkResponse: func(t *testing.T, recoder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recoder.Code)
+			},
+		},
+		{
+			name: "Chargeback",
+			body: gin.H{"transfer_id": transfer.ID, "reason": "unauthorized"},
+			buildStubs: func(store *mockdb.MockStore) {
+				chargeback := transfer
+				chargeback.Kind = db.TransferForced
+				store.EXPECT().GetTransfer(gomock.Any(), gomock.Eq(transfer.ID)).Times(1).Return(chargeback, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().OpenDisputeTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recoder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnprocessableEntity, recoder.Code)
+				require.Contains(t, recoder.Body.String(), "transfer_not_disputable")
+			},
+		},
+		{
+			name: "OtherUsersTransfer",
+			body: gin.H{"transfer_id": transfer.ID, "reason": "unauthorized"},
+			buildStubs: func(store *mockdb.MockStore) {
+				other := account
+				other.Owner = "someone_else"
+				store.EXPECT().GetTransfer(gomock.Any(), gomock.Eq(transfer.ID)).Times(1).Return(transfer, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(other, nil)
+				store.EXPECT().OpenDisputeTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recoder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recoder.Code)
+			},
+		},
+		{
+			name: "MissingReason",
+			body: gin.H{"transfer_id": transfer.ID},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetTransfer(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recoder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recoder.Code)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			server.Disputes = newDisputes(t, store)
+			recoder := httptest.NewRecorder()
+
+			var body bytes.Buffer
+			contentType := gin.MIMEJSON
+			if tc.evidence != nil {
+				form := multipart.NewWriter(&body)
+				for key, value := range tc.body {
+					require.NoError(t, form.WriteField(key, fmt.Sprint(value)))
+				}
+				part, err := form.CreateFormFile("evidence", "receipt.png")
+				require.NoError(t, err)
+				_, err = part.Write(tc.evidence)
+				require.NoError(t, err)
+				require.NoError(t, form.Close())
+				contentType = form.FormDataContentType()
+			} else {
+				require.NoError(t, json.NewEncoder(&body).Encode(tc.body))
+			}
+
+			request, err := http.NewRequest(http.MethodPost, "/v1/disputes", &body)
+			require.NoError(t, err)
+			request.Header.Set("Content-Type", contentType)
+			addAuthorization(t, request, server.TokenMaker, rest.AuthorizationTypeBearer, user.Username, time.Minute)
+
+			server.Router.ServeHTTP(recoder, request)
+			tc.checkResponse(t, recoder)
+		})
+	}
+}
+
+func TestDisputeAdminAPI(t *testing.T) {
+	admin, _ := randomUser(t)
+	admin.Role = bank.RoleAdmin
+
+	open := db.Dispute{ID: 1, TransferID: 7, Username: "alice", Amount: 500, Currency: utils.USD, Status: db.DisputeOpen, DueAt: time.Now().Add(-time.Hour)}
+	credits := db.Account{ID: 99, Owner: bank.Username, Currency: utils.USD, Product: bank.ProductDisputeCredits}
+
+	testCases := []struct {
+		name          string
+		method        string
+		url           string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recoder *httptest.ResponseRecorder)
+	}{
+		{
+			name:   "Queue",
+			method: http.MethodGet,
+			url:    "/v1/admin/disputes?status=open&page_size=1",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ListDisputes(gomock.Any(), gomock.Eq(db.ListDisputesParams{
+						Status:    sql.NullString{String: db.DisputeOpen, Valid: true},
+						PageLimit: 1,
+					})).
+					Times(1).
+					Return([]db.Dispute{open}, nil)
+			},
+			checkResponse: func(t *testing.T, recoder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recoder.Code)
+
+				var res rest.ListDisputesRes
+				require.NoError(t, json.Unmarshal(recoder.Body.Bytes(), &res))
+				require.Len(t, res.Disputes, 1)
+				require.True(t, res.Disputes[0].Overdue)
+				require.EqualValues(t, open.ID, res.NextAfterID)
+			},
+		},
+		{
+			name:   "QueueInvalidStatus",
+			method: http.MethodGet,
+			url:    "/v1/admin/disputes?status=closed",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListDisputes(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recoder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recoder.Code)
+			},
+		},
+		{
+			name:   "Review",
+			method: http.MethodPost,
+			url:    "/v1/admin/disputes/1/review",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetDispute(gomock.Any(), gomock.Eq(open.ID)).Times(1).Return(open, nil)
+				store.EXPECT().
+					TransitionDisputeTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ any, arg db.TransitionDisputeTxParams) (db.TransitionDisputeTxResult, error) {
+						require.Equal(t, db.DisputeUnderReview, arg.Status)
+						require.Equal(t, admin.Username, arg.Actor)
+						reviewed := open
+						reviewed.Status = arg.Status
+						reviewed.AssignedTo = sql.NullString{String: arg.Actor, Valid: true}
+						return db.TransitionDisputeTxResult{Dispute: reviewed}, nil
+					})
+				store.EXPECT().CreateNotification(gomock.Any(), gomock.Any()).Times(1)
+			},
+			checkResponse: func(t *testing.T, recoder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recoder.Code)
+
+				var res rest.TransitionDisputeRes
+				require.NoError(t, json.Unmarshal(recoder.Body.Bytes(), &res))
+				require.Equal(t, admin.Username, res.Dispute.AssignedTo)
+			},
+		},
+		{
+			name:   "ResolveWon",
+			method: http.MethodPost,
+			url:    "/v1/admin/disputes/1/resolve",
+			body:   gin.H{"outcome": "won", "resolution": "merchant did not reply"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetDispute(gomock.Any(), gomock.Eq(open.ID)).Times(1).Return(open, nil)
+				store.EXPECT().GetAccountByOwnerProduct(gomock.Any(), gomock.Any()).Times(1).Return(credits, nil)
+				store.EXPECT().
+					TransitionDisputeTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ any, arg db.TransitionDisputeTxParams) (db.TransitionDisputeTxResult, error) {
+						require.Equal(t, db.DisputeWon, arg.Status)
+						require.Equal(t, "merchant did not reply", arg.Note)
+						won := open
+						won.Status = arg.Status
+						won.ResolutionTransferID = sql.NullInt64{Int64: 12, Valid: true}
+						return db.TransitionDisputeTxResult{Dispute: won, Transfer: &db.TransferTxResult{Transfer: db.Transfer{ID: 12}}}, nil
+					})
+				store.EXPECT().CreateNotification(gomock.Any(), gomock.Any()).Times(1)
+			},
+			checkResponse: func(t *testing.T, recoder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recoder.Code)
+
+				var res rest.TransitionDisputeRes
+				require.NoError(t, json.Unmarshal(recoder.Body.Bytes(), &res))
+				require.Equal(t, db.DisputeWon, res.Dispute.Status)
+				require.False(t, res.Dispute.Overdue)
+				require.EqualValues(t, 12, res.Transfer.Transfer.ID)
+			},
+		},
+		{
+			name:   "ResolveDecided",
+			method: http.MethodPost,
+			url:    "/v1/admin/disputes/1/resolve",
+			body:   gin.H{"outcome": "lost", "resolution": "no proof"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetDispute(gomock.Any(), gomock.Eq(open.ID)).Times(1).Return(open, nil)
+				store.EXPECT().TransitionDisputeTx(gomock.Any(), gomock.Any()).Times(1).Return(db.TransitionDisputeTxResult{}, domain.ErrInvalidTransition)
+				store.EXPECT().CreateNotification(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recoder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnprocessableEntity, recoder.Code)
+			},
+		},
+		{
+			name:   "ResolveWithdrawn",
+			method: http.MethodPost,
+			url:    "/v1/admin/disputes/1/resolve",
+			body:   gin.H{"outcome": "withdrawn", "resolution": "no"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().TransitionDisputeTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recoder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recoder.Code)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().GetUser(gomock.Any(), gomock.Eq(admin.Username)).Times(1).Return(admin, nil)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			server.Disputes = newDisputes(t, store)
+			recoder := httptest.NewRecorder()
+
+			var body bytes.Buffer
+			if tc.body != nil {
+				require.NoError(t, json.NewEncoder(&body).Encode(tc.body))
+			}
+
+			request, err := http.NewRequest(tc.method, tc.url, &body)
+			require.NoError(t, err)
+			addAuthorization(t, request, server.TokenMaker, rest.AuthorizationTypeBearer, admin.Username, time.Minute)
+
+			server.Router.ServeHTTP(recoder, request)
+			tc.checkResponse(t, recoder)
+		})
+	}
+}
@@ -0,0 +1,132 @@
+package test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/NhutHuyDev/sgbank/internal/bank"
+	"github.com/NhutHuyDev/sgbank/internal/domain"
+	"github.com/NhutHuyDev/sgbank/internal/eod"
+	"github.com/NhutHuyDev/sgbank/internal/infra/db"
+	mockdb "github.com/NhutHuyDev/sgbank/internal/infra/db/mock"
+	"github.com/NhutHuyDev/sgbank/internal/rest"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCloseBusinessDayAPI(t *testing.T) {
+	admin, _ := randomUser(t)
+	admin.Role = bank.RoleAdmin
+
+	businessDate := time.Date(2024, time.October, 31, 0, 0, 0, 0, time.UTC)
+	open := db.BusinessDay{BusinessDate: businessDate, Status: eod.DayOpen}
+
+	testCases := []struct {
+		name          string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recoder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			buildStubs: func(store *mockdb.MockStore) {
+				steps := []db.EodStep{
+					{BusinessDate: businessDate, Name: eod.StepDailyBalances, Position: 1, Status: eod.StepSucceeded},
+					{BusinessDate: businessDate, Name: eod.StepInterest, Position: 2, Status: eod.StepSucceeded},
+				}
+
+				store.EXPECT().GetOpenBusinessDay(gomock.Any()).Times(1).Return(open, nil)
+				store.EXPECT().
+					CloseBusinessDayTx(gomock.Any(), gomock.Eq(db.CloseBusinessDayTxParams{
+						BusinessDate: businessDate,
+						Steps:        []string{eod.StepDailyBalances, eod.StepInterest},
+					})).
+					Times(1).
+					Return(db.CloseBusinessDayTxResult{Closing: db.BusinessDay{BusinessDate: businessDate, Status: eod.DayClosing}, Steps: steps}, nil)
+				store.EXPECT().
+					FinishClosingBusinessDay(gomock.Any(), gomock.Eq(businessDate)).
+					Times(1).
+					Return(db.BusinessDay{BusinessDate: businessDate, Status: eod.DayClosed}, nil)
+				store.EXPECT().ListEODSteps(gomock.Any(), gomock.Eq(businessDate)).Times(1).Return(steps, nil)
+			},
+			checkResponse: func(t *testing.T, recoder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recoder.Code)
+
+				var res rest.BusinessDayRes
+				require.NoError(t, json.Unmarshal(recoder.Body.Bytes(), &res))
+				require.Equal(t, "2024-10-31", res.BusinessDate)
+				require.Equal(t, eod.DayClosed, res.Status)
+				require.Len(t, res.Steps, 2)
+			},
+		},
+		{
+			name: "InProgress",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetOpenBusinessDay(gomock.Any()).Times(1).Return(open, nil)
+				store.EXPECT().
+					CloseBusinessDayTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.CloseBusinessDayTxResult{}, domain.ErrEODInProgress)
+				store.EXPECT().ClaimEODStep(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recoder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnprocessableEntity, recoder.Code)
+				requireBodyMatchProblem(t, recoder, domain.ErrEODInProgress)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().GetUser(gomock.Any(), gomock.Eq(admin.Username)).Times(1).Return(admin, nil)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recoder := httptest.NewRecorder()
+
+			request, err := http.NewRequest(http.MethodPost, "/v1/admin/eod/close", nil)
+			require.NoError(t, err)
+			addAuthorization(t, request, server.TokenMaker, rest.AuthorizationTypeBearer, admin.Username, time.Minute)
+
+			server.Router.ServeHTTP(recoder, request)
+			tc.checkResponse(t, recoder)
+		})
+	}
+}
+
+func TestEODStatusAPI(t *testing.T) {
+	admin, _ := randomUser(t)
+	admin.Role = bank.RoleAdmin
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().GetUser(gomock.Any(), gomock.Eq(admin.Username)).Times(1).Return(admin, nil)
+	store.EXPECT().
+		GetOpenBusinessDay(gomock.Any()).
+		Times(1).
+		Return(db.BusinessDay{BusinessDate: time.Date(2024, time.November, 1, 0, 0, 0, 0, time.UTC), Status: eod.DayOpen}, nil)
+	store.EXPECT().GetClosingBusinessDay(gomock.Any()).Times(1).Return(db.BusinessDay{}, domain.ErrNotFound)
+
+	server := newTestServer(t, store)
+	recoder := httptest.NewRecorder()
+
+	request, err := http.NewRequest(http.MethodGet, "/v1/admin/eod", nil)
+	require.NoError(t, err)
+	addAuthorization(t, request, server.TokenMaker, rest.AuthorizationTypeBearer, admin.Username, time.Minute)
+
+	server.Router.ServeHTTP(recoder, request)
+	require.Equal(t, http.StatusOK, recoder.Code)
+
+	var res rest.EODStatusRes
+	require.NoError(t, json.Unmarshal(recoder.Body.Bytes(), &res))
+	require.Equal(t, "2024-11-01", res.BusinessDate)
+	require.Nil(t, res.Closing)
+}
@@ -0,0 +1,453 @@
+package test
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/NhutHuyDev/sgbank/internal/accountno"
+	"github.com/NhutHuyDev/sgbank/internal/bank"
+	"github.com/NhutHuyDev/sgbank/internal/domain"
+	"github.com/NhutHuyDev/sgbank/internal/escrow"
+	"github.com/NhutHuyDev/sgbank/internal/infra/db"
+	mockdb "github.com/NhutHuyDev/sgbank/internal/infra/db/mock"
+	"github.com/NhutHuyDev/sgbank/internal/notify"
+	"github.com/NhutHuyDev/sgbank/internal/payment"
+	"github.com/NhutHuyDev/sgbank/internal/payment/paymenttest"
+	"github.com/NhutHuyDev/sgbank/internal/rest"
+	"github.com/NhutHuyDev/sgbank/pkg/utils"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func newEscrows(t *testing.T, store db.Store, payments *payment.Service) *escrow.Service {
+	generator, err := accountno.NewGenerator(accountno.DefaultBankCode, accountno.DefaultBranchCode)
+	require.NoError(t, err)
+
+	return escrow.NewService(store, bank.NewAccounts(store, generator), notify.NewStoreNotifier(store), payments, escrow.Policy{
+		DefaultReleaseAfter: escrow.DefaultReleaseAfter,
+		MaxReleaseAfter:     escrow.DefaultMaxReleaseAfter,
+	})
+}
+
+// settleEscrow runs the Authorize of SettleEscrowTx against current and
+// returns current moved to the asked status.
+func settleEscrow(current db.Escrow) func(any, db.SettleEscrowTxParams) (db.SettleEscrowTxResult, error) {
+	return func(_ any, arg db.SettleEscrowTxParams) (db.SettleEscrowTxResult, error) {
+		if err := arg.Authorize(current); err != nil {
+			return db.SettleEscrowTxResult{}, err
+		}
+
+		current.Status = arg.Status
+		if arg.Status == db.EscrowReleased || arg.Status == db.EscrowRefunded {
+			current.SettlementTransferID = sql.NullInt64{Int64: 12, Valid: true}
+			return db.SettleEscrowTxResult{Escrow: current, Transfer: &db.TransferTxResult{Transfer: db.Transfer{ID: 12}}}, nil
+		}
+
+		return db.SettleEscrowTxResult{Escrow: current}, nil
+	}
+}
+
+func TestCreateEscrowAPI(t *testing.T) {
+	payer, _ := randomUser(t)
+	payee, _ := randomUser(t)
+
+	from := randomAccount(payer.Username)
+	from.Currency = utils.USD
+	to := randomAccount(payee.Username)
+	to.Currency = utils.USD
+	escrowAccount := db.Account{ID: to.ID + 1, Owner: bank.Username, Currency: utils.USD, Product: bank.ProductEscrow}
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recoder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{"from_account_id": from.ID, "to_account_id": to.ID, "amount": "12.50", "currency": utils.USD, "description": "camera"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(to.ID)).Times(2).Return(to, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(from.ID)).Times(1).Return(from, nil)
+				store.EXPECT().GetAccountByOwnerProduct(gomock.Any(), gomock.Any()).Times(1).Return(escrowAccount, nil)
+				store.EXPECT().
+					CreateEscrowTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ any, arg db.CreateEscrowTxParams) (db.CreateEscrowTxResult, error) {
+						require.EqualValues(t, 1250, arg.Amount)
+						require.Equal(t, payee.Username, arg.Payee)
+						require.Equal(t, escrowAccount.ID, arg.EscrowAccountID)
+						return db.CreateEscrowTxResult{
+							Escrow: db.Escrow{
+								ID:                1,
+								Payer:             arg.Payer,
+								Payee:             arg.Payee,
+								Amount:            arg.Amount,
+								Currency:          arg.Currency,
+								Status:            db.EscrowHeld,
+								ReleaseAt:         arg.ReleaseAt,
+								FundingTransferID: 11,
+							},
+							Funding: db.TransferTxResult{Transfer: db.Transfer{ID: 11}},
+						}, nil
+					})
+				store.EXPECT().CreateNotification(gomock.Any(), gomock.Any()).Times(1)
+			},
+			checkResponse: func(t *testing.T, recoder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusCreated, recoder.Code)
+
+				var res rest.CreateEscrowRes
+				require.NoError(t, json.Unmarshal(recoder.Body.Bytes(), &res))
+				require.Equal(t, db.EscrowHeld, res.Escrow.Status)
+				require.EqualValues(t, 11, res.Funding.Transfer.ID)
+				require.Nil(t, res.Escrow.SettlementTransferID)
+				require.False(t, res.Escrow.Overdue)
+				require.EqualValues(t, 1250, res.FeeDetails.Total)
+			},
+		},
+		{
+			// a first large payment into escrow scores 60
+			name: "Held",
+			body: gin.H{"from_account_id": from.ID, "to_account_id": to.ID, "amount": 150000, "currency": utils.USD},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(to.ID)).Times(2).Return(to, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(from.ID)).Times(1).Return(from, nil)
+				store.EXPECT().GetAccountByOwnerProduct(gomock.Any(), gomock.Any()).Times(1).Return(escrowAccount, nil)
+				store.EXPECT().HasTransferredTo(gomock.Any(), gomock.Eq(db.HasTransferredToParams{
+					Owner:       payer.Username,
+					ToAccountID: to.ID,
+				})).Times(1).Return(false, nil)
+				store.EXPECT().CreateEscrowTx(gomock.Any(), gomock.Any()).Times(0)
+				// only the payer is told that the payment waits for review
+				store.EXPECT().CreateNotification(gomock.Any(), gomock.Any()).Times(1).
+					DoAndReturn(func(_ any, arg db.CreateNotificationParams) (db.Notification, error) {
+						require.Equal(t, payer.Username, arg.Username)
+						return db.Notification{}, nil
+					})
+			},
+			checkResponse: func(t *testing.T, recoder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusAccepted, recoder.Code)
+
+				var res rest.HeldEscrowRes
+				require.NoError(t, json.Unmarshal(recoder.Body.Bytes(), &res))
+				require.Equal(t, "pending", res.FraudDecision.Status)
+				require.Equal(t, db.HeldEscrow, res.FraudDecision.Kind)
+				require.Equal(t, to.ID, res.FraudDecision.ToAccountID)
+			},
+		},
+		{
+			name: "NotPayerAccount",
+			body: gin.H{"from_account_id": to.ID, "to_account_id": to.ID + 5, "amount": 100, "currency": utils.USD},
+			buildStubs: func(store *mockdb.MockStore) {
+				other := randomAccount(payee.Username)
+				other.Currency = utils.USD
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(to.ID+5)).Times(1).Return(other, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(to.ID)).Times(1).Return(to, nil)
+				store.EXPECT().CreateEscrowTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recoder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recoder.Code)
+			},
+		},
+		{
+			name: "CurrencyMismatch",
+			body: gin.H{"from_account_id": from.ID, "to_account_id": to.ID, "amount": 100, "currency": utils.EUR},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(to.ID)).Times(1).Return(to, nil)
+				store.EXPECT().CreateEscrowTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recoder *httptest.ResponseRecorder) {
+				requireBodyMatchProblem(t, recoder, domain.ErrCurrencyMismatch)
+			},
+		},
+		{
+			name: "MissingAmount",
+			body: gin.H{"from_account_id": from.ID, "to_account_id": to.ID, "currency": utils.USD},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recoder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recoder.Code)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+			paymenttest.StubNoFees(store)
+			stubNoFraud(store)
+			stubNoKYCLimits(store)
+
+			server := newTestServer(t, store)
+			server.Escrows = newEscrows(t, store, server.Payments)
+			recoder := httptest.NewRecorder()
+
+			var body bytes.Buffer
+			require.NoError(t, json.NewEncoder(&body).Encode(tc.body))
+
+			request, err := http.NewRequest(http.MethodPost, "/v1/escrows", &body)
+			require.NoError(t, err)
+			addAuthorization(t, request, server.TokenMaker, rest.AuthorizationTypeBearer, payer.Username, time.Minute)
+
+			server.Router.ServeHTTP(recoder, request)
+			tc.checkResponse(t, recoder)
+		})
+	}
+}
+
+func TestSettleEscrowAPI(t *testing.T) {
+	held := db.Escrow{ID: 1, Payer: "alice", Payee: "bob", Amount: 500, Currency: utils.USD, Status: db.EscrowHeld, ReleaseAt: time.Now().Add(time.Hour)}
+
+	testCases := []struct {
+		name          string
+		username      string
+		url           string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recoder *httptest.ResponseRecorder)
+	}{
+		{
+			name:     "Confirm",
+			username: held.Payer,
+			url:      "/v1/escrows/1/confirm",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().SettleEscrowTx(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(settleEscrow(held))
+				store.EXPECT().CreateNotification(gomock.Any(), gomock.Any()).Times(1)
+			},
+			checkResponse: func(t *testing.T, recoder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recoder.Code)
+
+				var res rest.SettleEscrowRes
+				require.NoError(t, json.Unmarshal(recoder.Body.Bytes(), &res))
+				require.Equal(t, db.EscrowReleased, res.Escrow.Status)
+				require.EqualValues(t, 12, *res.Escrow.SettlementTransferID)
+				require.EqualValues(t, 12, res.Transfer.Transfer.ID)
+			},
+		},
+		{
+			name:     "ConfirmByPayee",
+			username: held.Payee,
+			url:      "/v1/escrows/1/confirm",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().SettleEscrowTx(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(settleEscrow(held))
+				store.EXPECT().CreateNotification(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recoder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recoder.Code)
+			},
+		},
+		{
+			name:     "CancelWithoutBody",
+			username: held.Payee,
+			url:      "/v1/escrows/1/cancel",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().SettleEscrowTx(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(settleEscrow(held))
+				store.EXPECT().CreateNotification(gomock.Any(), gomock.Any()).Times(1)
+			},
+			checkResponse: func(t *testing.T, recoder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recoder.Code)
+
+				var res rest.SettleEscrowRes
+				require.NoError(t, json.Unmarshal(recoder.Body.Bytes(), &res))
+				require.Equal(t, db.EscrowRefunded, res.Escrow.Status)
+			},
+		},
+		{
+			name:     "Dispute",
+			username: held.Payer,
+			url:      "/v1/escrows/1/dispute",
+			body:     gin.H{"reason": "never arrived"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().SettleEscrowTx(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(settleEscrow(held))
+				store.EXPECT().ListUsernamesByRole(gomock.Any(), gomock.Eq(bank.RoleAdmin)).Times(1).Return([]string{"admin"}, nil)
+				store.EXPECT().CreateNotification(gomock.Any(), gomock.Any()).Times(2)
+			},
+			checkResponse: func(t *testing.T, recoder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recoder.Code)
+
+				var res rest.SettleEscrowRes
+				require.NoError(t, json.Unmarshal(recoder.Body.Bytes(), &res))
+				require.Equal(t, db.EscrowDisputed, res.Escrow.Status)
+				require.Nil(t, res.Transfer)
+			},
+		},
+		{
+			name:     "DisputeWithoutReason",
+			username: held.Payer,
+			url:      "/v1/escrows/1/dispute",
+			body:     gin.H{},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().SettleEscrowTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recoder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recoder.Code)
+			},
+		},
+		{
+			name:     "NotFound",
+			username: held.Payer,
+			url:      "/v1/escrows/1/confirm",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().SettleEscrowTx(gomock.Any(), gomock.Any()).Times(1).Return(db.SettleEscrowTxResult{}, domain.ErrEscrowNotFound)
+			},
+			checkResponse: func(t *testing.T, recoder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recoder.Code)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			server.Escrows = newEscrows(t, store, server.Payments)
+			recoder := httptest.NewRecorder()
+
+			var body bytes.Buffer
+			if tc.body != nil {
+				require.NoError(t, json.NewEncoder(&body).Encode(tc.body))
+			}
+
+			request, err := http.NewRequest(http.MethodPost, tc.url, &body)
+			require.NoError(t, err)
+			addAuthorization(t, request, server.TokenMaker, rest.AuthorizationTypeBearer, tc.username, time.Minute)
+
+			server.Router.ServeHTTP(recoder, request)
+			tc.checkResponse(t, recoder)
+		})
+	}
+}
+
+func TestEscrowAdminAPI(t *testing.T) {
+	admin, _ := randomUser(t)
+	admin.Role = bank.RoleAdmin
+
+	disputed := db.Escrow{ID: 1, Payer: "alice", Payee: "bob", Amount: 500, Currency: utils.USD, Status: db.EscrowDisputed, ReleaseAt: time.Now().Add(-time.Hour)}
+
+	testCases := []struct {
+		name          string
+		method        string
+		url           string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recoder *httptest.ResponseRecorder)
+	}{
+		{
+			name:   "Queue",
+			method: http.MethodGet,
+			url:    "/v1/admin/escrows?status=disputed&page_size=1",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ListEscrows(gomock.Any(), gomock.Eq(db.ListEscrowsParams{
+						Status:    sql.NullString{String: db.EscrowDisputed, Valid: true},
+						PageLimit: 1,
+					})).
+					Times(1).
+					Return([]db.Escrow{disputed}, nil)
+			},
+			checkResponse: func(t *testing.T, recoder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recoder.Code)
+
+				var res rest.ListEscrowsRes
+				require.NoError(t, json.Unmarshal(recoder.Body.Bytes(), &res))
+				require.Len(t, res.Escrows, 1)
+				require.False(t, res.Escrows[0].Overdue)
+				require.EqualValues(t, disputed.ID, res.NextAfterID)
+			},
+		},
+		{
+			name:   "ResolveRefunded",
+			method: http.MethodPost,
+			url:    "/v1/admin/escrows/1/resolve",
+			body:   gin.H{"outcome": "refunded", "note": "seller agreed"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					SettleEscrowTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(ctx any, arg db.SettleEscrowTxParams) (db.SettleEscrowTxResult, error) {
+						require.Equal(t, admin.Username, arg.Actor)
+						require.Equal(t, "seller agreed", arg.Note)
+						return settleEscrow(disputed)(ctx, arg)
+					})
+				store.EXPECT().CreateNotification(gomock.Any(), gomock.Any()).Times(1)
+			},
+			checkResponse: func(t *testing.T, recoder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recoder.Code)
+
+				var res rest.SettleEscrowRes
+				require.NoError(t, json.Unmarshal(recoder.Body.Bytes(), &res))
+				require.Equal(t, db.EscrowRefunded, res.Escrow.Status)
+			},
+		},
+		{
+			name:   "ResolveHeld",
+			method: http.MethodPost,
+			url:    "/v1/admin/escrows/1/resolve",
+			body:   gin.H{"outcome": "released"},
+			buildStubs: func(store *mockdb.MockStore) {
+				held := disputed
+				held.Status = db.EscrowHeld
+				store.EXPECT().SettleEscrowTx(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(settleEscrow(held))
+				store.EXPECT().CreateNotification(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recoder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnprocessableEntity, recoder.Code)
+			},
+		},
+		{
+			name:   "ResolveInvalidOutcome",
+			method: http.MethodPost,
+			url:    "/v1/admin/escrows/1/resolve",
+			body:   gin.H{"outcome": "disputed"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().SettleEscrowTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recoder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recoder.Code)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().GetUser(gomock.Any(), gomock.Eq(admin.Username)).Times(1).Return(admin, nil)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			server.Escrows = newEscrows(t, store, server.Payments)
+			recoder := httptest.NewRecorder()
+
+			var body bytes.Buffer
+			if tc.body != nil {
+				require.NoError(t, json.NewEncoder(&body).Encode(tc.body))
+			}
+
+			request, err := http.NewRequest(tc.method, tc.url, &body)
+			require.NoError(t, err)
+			addAuthorization(t, request, server.TokenMaker, rest.AuthorizationTypeBearer, admin.Username, time.Minute)
+
+			server.Router.ServeHTTP(recoder, request)
+			tc.checkResponse(t, recoder)
+		})
+	}
+}
@@ -0,0 +1,291 @@
+package test
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/NhutHuyDev/sgbank/internal/bank"
+	"github.com/NhutHuyDev/sgbank/internal/domain"
+	"github.com/NhutHuyDev/sgbank/internal/fraud"
+	"github.com/NhutHuyDev/sgbank/internal/infra/db"
+	mockdb "github.com/NhutHuyDev/sgbank/internal/infra/db/mock"
+	"github.com/NhutHuyDev/sgbank/internal/payment/paymenttest"
+	"github.com/NhutHuyDev/sgbank/internal/rest"
+	"github.com/NhutHuyDev/sgbank/pkg/utils"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransferAPIFraudRules(t *testing.T) {
+	user1, _ := randomUser(t)
+	user2, _ := randomUser(t)
+
+	account1 := randomAccount(user1.Username)
+	account2 := randomAccount(user2.Username)
+	account1.Currency = utils.USD
+	account2.Currency = utils.USD
+	account2.ID = account1.ID + 1
+
+	// a first transfer of a large amount to account2 scores 60
+	amount := int64(150000)
+
+	testCases := []struct {
+		name          string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recoder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "Held",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CountRecentTransfers(gomock.Any(), gomock.Any()).Times(1).Return(db.CountRecentTransfersRow{}, nil)
+				store.EXPECT().CreateNotification(gomock.Any(), gomock.Any()).Times(1)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recoder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusAccepted, recoder.Code)
+
+				var res rest.HeldTransferRes
+				require.NoError(t, json.Unmarshal(recoder.Body.Bytes(), &res))
+				require.Equal(t, fraud.OutcomeHold, res.FraudDecision.Outcome)
+				require.Equal(t, fraud.StatusPending, res.FraudDecision.Status)
+				require.Equal(t, int32(60), res.FraudDecision.Score)
+				require.Equal(t, "1500.00", res.FraudDecision.AmountDecimal)
+				require.Contains(t, string(res.FraudDecision.Hits), fraud.RuleNewBeneficiary)
+			},
+		},
+		{
+			name: "Blocked",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CountRecentTransfers(gomock.Any(), gomock.Any()).Times(1).Return(db.CountRecentTransfersRow{Transfers: 9}, nil)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recoder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recoder.Code)
+				requireBodyMatchProblem(t, recoder, domain.ErrTransferBlocked)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).MinTimes(1).Return(account1, nil)
+			store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account2.ID)).Times(1).Return(account2, nil)
+			store.EXPECT().HasTransferredTo(gomock.Any(), gomock.Eq(db.HasTransferredToParams{
+				Owner:       user1.Username,
+				ToAccountID: account2.ID,
+			})).Times(1).Return(false, nil)
+			tc.buildStubs(store)
+			paymenttest.StubNoFees(store)
+			stubNoFraud(store)
+			stubNoKYCLimits(store)
+
+			server := newTestServer(t, store)
+			recoder := httptest.NewRecorder()
+
+			data, err := json.Marshal(gin.H{
+				"from_account_id": account1.ID,
+				"to_account_id":   account2.ID,
+				"amount":          amount,
+				"currency":        utils.USD,
+			})
+			require.NoError(t, err)
+
+			request, err := http.NewRequest(http.MethodPost, "/v1/transfers", bytes.NewReader(data))
+			require.NoError(t, err)
+			addAuthorization(t, request, server.TokenMaker, rest.AuthorizationTypeBearer, user1.Username, time.Minute)
+
+			server.Router.ServeHTTP(recoder, request)
+			tc.checkResponse(t, recoder)
+		})
+	}
+}
+
+func TestReviewFraudDecisionAPI(t *testing.T) {
+	admin, _ := randomUser(t)
+	admin.Role = bank.RoleAdmin
+
+	decision := db.FraudDecision{ID: 9, Username: "alice", Amount: 150000, Currency: utils.USD, Status: fraud.StatusPending}
+
+	testCases := []struct {
+		name          string
+		action        string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recoder *httptest.ResponseRecorder)
+	}{
+		{
+			name:   "Approve",
+			action: "approve",
+			body:   gin.H{"note": "confirmed by phone"},
+			buildStubs: func(store *mockdb.MockStore) {
+				approved := decision
+				approved.Status = fraud.StatusApproved
+				store.EXPECT().
+					ApproveHeldTransferTx(gomock.Any(), gomock.Eq(db.ApproveHeldTransferTxParams{
+						ID:         decision.ID,
+						ReviewedBy: admin.Username,
+						ReviewNote: "confirmed by phone",
+					})).
+					Times(1).
+					Return(db.ApproveHeldTransferTxResult{Decision: approved, TransferTxResult: db.TransferTxResult{Transfer: db.Transfer{ID: 30}}}, nil)
+				store.EXPECT().CreateNotification(gomock.Any(), gomock.Any()).Times(1)
+			},
+			checkResponse: func(t *testing.T, recoder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recoder.Code)
+
+				var res rest.ApproveFraudDecisionRes
+				require.NoError(t, json.Unmarshal(recoder.Body.Bytes(), &res))
+				require.Equal(t, fraud.StatusApproved, res.FraudDecision.Status)
+				require.Equal(t, int64(30), res.Transfer.ID)
+			},
+		},
+		{
+			name:   "ApprovePaymentRequest",
+			action: "approve",
+			buildStubs: func(store *mockdb.MockStore) {
+				approved := decision
+				approved.Status = fraud.StatusApproved
+				approved.Kind = db.HeldPaymentRequest
+				paid := db.PaymentRequest{
+					ID:         4,
+					Requester:  "bob",
+					Payer:      sql.NullString{String: decision.Username, Valid: true},
+					Amount:     decision.Amount,
+					Currency:   decision.Currency,
+					Status:     "paid",
+					TransferID: sql.NullInt64{Int64: 30, Valid: true},
+				}
+				store.EXPECT().ApproveHeldTransferTx(gomock.Any(), gomock.Any()).Times(1).
+					Return(db.ApproveHeldTransferTxResult{
+						Decision:         approved,
+						TransferTxResult: db.TransferTxResult{Transfer: db.Transfer{ID: 30}},
+						PaymentRequest:   &paid,
+					}, nil)
+				// the payer is told the payment was approved, the requester that it was paid
+				store.EXPECT().CreateNotification(gomock.Any(), gomock.Any()).Times(2)
+			},
+			checkResponse: func(t *testing.T, recoder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recoder.Code)
+
+				var res rest.ApproveFraudDecisionRes
+				require.NoError(t, json.Unmarshal(recoder.Body.Bytes(), &res))
+				require.Equal(t, db.HeldPaymentRequest, res.FraudDecision.Kind)
+				require.NotNil(t, res.PaymentRequest)
+				require.Equal(t, "paid", res.PaymentRequest.Status)
+				require.Nil(t, res.TransferBatchItem)
+			},
+		},
+		{
+			name:   "ApproveEscrow",
+			action: "approve",
+			buildStubs: func(store *mockdb.MockStore) {
+				approved := decision
+				approved.Status = fraud.StatusApproved
+				approved.Kind = db.HeldEscrow
+				funded := db.Escrow{
+					ID:                6,
+					Payer:             decision.Username,
+					Payee:             "bob",
+					Amount:            decision.Amount,
+					Currency:          decision.Currency,
+					Status:            db.EscrowHeld,
+					ReleaseAt:         time.Now().Add(time.Hour),
+					FundingTransferID: 30,
+				}
+				store.EXPECT().ApproveHeldTransferTx(gomock.Any(), gomock.Any()).Times(1).
+					Return(db.ApproveHeldTransferTxResult{
+						Decision:         approved,
+						TransferTxResult: db.TransferTxResult{Transfer: db.Transfer{ID: 30}},
+						Escrow:           &funded,
+					}, nil)
+				// the payer is told the payment was approved, the payee that the escrow was funded
+				store.EXPECT().CreateNotification(gomock.Any(), gomock.Any()).Times(2)
+			},
+			checkResponse: func(t *testing.T, recoder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recoder.Code)
+
+				var res rest.ApproveFraudDecisionRes
+				require.NoError(t, json.Unmarshal(recoder.Body.Bytes(), &res))
+				require.Equal(t, db.HeldEscrow, res.FraudDecision.Kind)
+				require.NotNil(t, res.Escrow)
+				require.EqualValues(t, 30, res.Escrow.FundingTransferID)
+				require.Nil(t, res.PaymentRequest)
+			},
+		},
+		{
+			name:   "ApproveInsufficientFunds",
+			action: "approve",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ApproveHeldTransferTx(gomock.Any(), gomock.Any()).Times(1).
+					Return(db.ApproveHeldTransferTxResult{}, domain.ErrInsufficientFunds)
+				store.EXPECT().CreateNotification(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recoder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnprocessableEntity, recoder.Code)
+				requireBodyMatchProblem(t, recoder, domain.ErrInsufficientFunds)
+			},
+		},
+		{
+			name:   "Reject",
+			action: "reject",
+			buildStubs: func(store *mockdb.MockStore) {
+				rejected := decision
+				rejected.Status = fraud.StatusRejected
+				store.EXPECT().GetFraudDecision(gomock.Any(), gomock.Eq(decision.ID)).Times(1).Return(decision, nil)
+				store.EXPECT().
+					RejectHeldTransferTx(gomock.Any(), gomock.Eq(db.RejectHeldTransferTxParams{
+						ID:         decision.ID,
+						ReviewedBy: admin.Username,
+					})).
+					Times(1).
+					Return(db.RejectHeldTransferTxResult{Decision: rejected}, nil)
+				store.EXPECT().CreateNotification(gomock.Any(), gomock.Any()).Times(1)
+			},
+			checkResponse: func(t *testing.T, recoder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recoder.Code)
+
+				var res rest.FraudDecisionRes
+				require.NoError(t, json.Unmarshal(recoder.Body.Bytes(), &res))
+				require.Equal(t, fraud.StatusRejected, res.Status)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().GetUser(gomock.Any(), gomock.Eq(admin.Username)).Times(1).Return(admin, nil)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recoder := httptest.NewRecorder()
+
+			var body bytes.Buffer
+			if tc.body != nil {
+				require.NoError(t, json.NewEncoder(&body).Encode(tc.body))
+			}
+
+			url := fmt.Sprintf("/v1/admin/fraud/decisions/%d/%s", decision.ID, tc.action)
+			request, err := http.NewRequest(http.MethodPost, url, &body)
+			require.NoError(t, err)
+			addAuthorization(t, request, server.TokenMaker, rest.AuthorizationTypeBearer, admin.Username, time.Minute)
+
+			server.Router.ServeHTTP(recoder, request)
+			tc.checkResponse(t, recoder)
+		})
+	}
+}
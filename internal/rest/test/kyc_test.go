@@ -0,0 +1,348 @@
+package test
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/NhutHuyDev/sgbank/internal/bank"
+	"github.com/NhutHuyDev/sgbank/internal/blob"
+	"github.com/NhutHuyDev/sgbank/internal/domain"
+	"github.com/NhutHuyDev/sgbank/internal/infra/db"
+	mockdb "github.com/NhutHuyDev/sgbank/internal/infra/db/mock"
+	"github.com/NhutHuyDev/sgbank/internal/kyc"
+	"github.com/NhutHuyDev/sgbank/internal/notify"
+	"github.com/NhutHuyDev/sgbank/internal/payment/paymenttest"
+	"github.com/NhutHuyDev/sgbank/internal/rest"
+	"github.com/NhutHuyDev/sgbank/pkg/utils"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+// pngDocument is enough of a PNG file for its type to be detected.
+var pngDocument = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+
+// newKYC keeps the documents in a temporary directory.
+func newKYC(t *testing.T, store db.Store) (*kyc.Service, *blob.Disk) {
+	files := blob.NewDisk(t.TempDir())
+	return kyc.NewService(store, files, notify.NewStoreNotifier(store), kyc.DefaultMaxDocumentSize), files
+}
+
+func TestTransferAPIKYCLimitExceeded(t *testing.T) {
+	user1, _ := randomUser(t)
+	user2, _ := randomUser(t)
+
+	account1 := randomAccount(user1.Username)
+	account2 := randomAccount(user2.Username)
+	account1.Currency = utils.USD
+	account2.Currency = utils.USD
+	account2.ID = account1.ID + 1
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).MinTimes(1).Return(account1, nil)
+	store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account2.ID)).Times(1).Return(account2, nil)
+	store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user2.Username)).AnyTimes().Return(user2, nil)
+	store.EXPECT().ListWhitelistedEntryIDs(gomock.Any(), gomock.Any()).AnyTimes().Return(nil, nil)
+	store.EXPECT().
+		GetUserKycTransferLimits(gomock.Any(), gomock.Eq(db.GetUserKycTransferLimitsParams{Username: user1.Username, Currency: utils.USD})).
+		Times(1).
+		Return(db.GetUserKycTransferLimitsRow{
+			Level:              kyc.LevelUnverified,
+			Name:               "Unverified",
+			MaxTransferAmount:  sql.NullInt64{Int64: 10000, Valid: true},
+			DailyTransferLimit: sql.NullInt64{Int64: 20000, Valid: true},
+		}, nil)
+	store.EXPECT().SumTransfersFromOwnerSince(gomock.Any(), gomock.Any()).Times(1).Return(int64(19950), nil)
+	store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+	paymenttest.StubNoFees(store)
+	stubNoFraud(store)
+
+	server := newTestServer(t, store)
+	recoder := httptest.NewRecorder()
+
+	data, err := json.Marshal(gin.H{
+		"from_account_id": account1.ID,
+		"to_account_id":   account2.ID,
+		"amount":          100,
+		"currency":        utils.USD,
+	})
+	require.NoError(t, err)
+
+	request, err := http.NewRequest(http.MethodPost, "/v1/transfers", bytes.NewReader(data))
+	require.NoError(t, err)
+	addAuthorization(t, request, server.TokenMaker, rest.AuthorizationTypeBearer, user1.Username, time.Minute)
+
+	server.Router.ServeHTTP(recoder, request)
+	require.Equal(t, http.StatusUnprocessableEntity, recoder.Code)
+	requireBodyMatchProblem(t, recoder, domain.ErrKYCLimitExceeded)
+}
+
+func TestSubmitKYCAPI(t *testing.T) {
+	user, _ := randomUser(t)
+
+	testCases := []struct {
+		name          string
+		level         string
+		files         map[string][]byte
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recoder *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "OK",
+			level: "1",
+			files: map[string][]byte{kyc.DocumentID: pngDocument},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUserKycLimits(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(db.KycLevel{Level: kyc.LevelUnverified}, nil)
+				store.EXPECT().
+					CreateKycSubmissionTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ any, arg db.CreateKycSubmissionTxParams) (db.CreateKycSubmissionTxResult, error) {
+						require.Equal(t, user.Username, arg.Username)
+						require.Len(t, arg.Documents, 1)
+						require.Equal(t, "id.png", arg.Documents[0].FileName)
+						return db.CreateKycSubmissionTxResult{
+							Submission: db.KycSubmission{ID: 1, Username: arg.Username, Level: arg.Level, Status: kyc.StatusPending},
+							Documents:  []db.KycDocument{{ID: 1, SubmissionID: 1, Kind: kyc.DocumentID, StorageKey: arg.Documents[0].StorageKey}},
+						}, nil
+					})
+			},
+			checkResponse: func(t *testing.T, recoder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusCreated, recoder.Code)
+
+				var res rest.KYCSubmissionRes
+				require.NoError(t, json.Unmarshal(recoder.Body.Bytes(), &res))
+				require.Equal(t, kyc.StatusPending, res.Status)
+				require.Len(t, res.Documents, 1)
+				require.NotContains(t, recoder.Body.String(), "storage_key")
+			},
+		},
+		{
+			name:  "MissingDocument",
+			level: "2",
+			files: map[string][]byte{kyc.DocumentID: pngDocument},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUserKycLimits(gomock.Any(), gomock.Any()).Times(1).Return(db.KycLevel{Level: kyc.LevelUnverified}, nil)
+				store.EXPECT().CreateKycSubmissionTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recoder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recoder.Code)
+				problem := requireBodyMatchProblem(t, recoder, domain.ErrInvalidArgument)
+				require.Equal(t, kyc.DocumentAddress, problem.InvalidParams[0].Field)
+			},
+		},
+		{
+			name:  "InvalidLevel",
+			level: "one",
+			files: map[string][]byte{kyc.DocumentID: pngDocument},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateKycSubmissionTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recoder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recoder.Code)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			server.KYC, _ = newKYC(t, store)
+			recoder := httptest.NewRecorder()
+
+			var body bytes.Buffer
+			form := multipart.NewWriter(&body)
+			require.NoError(t, form.WriteField("level", tc.level))
+			for kind, content := range tc.files {
+				part, err := form.CreateFormFile(kind, "id.png")
+				require.NoError(t, err)
+				_, err = part.Write(content)
+				require.NoError(t, err)
+			}
+			require.NoError(t, form.Close())
+
+			request, err := http.NewRequest(http.MethodPost, "/v1/kyc/submissions", &body)
+			require.NoError(t, err)
+			request.Header.Set("Content-Type", form.FormDataContentType())
+			addAuthorization(t, request, server.TokenMaker, rest.AuthorizationTypeBearer, user.Username, time.Minute)
+
+			server.Router.ServeHTTP(recoder, request)
+			tc.checkResponse(t, recoder)
+		})
+	}
+}
+
+func TestGetKYCSubmissionAPIOtherUser(t *testing.T) {
+	user, _ := randomUser(t)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().GetKycSubmission(gomock.Any(), gomock.Eq(int64(1))).Times(1).Return(db.KycSubmission{ID: 1, Username: "someone_else"}, nil)
+	store.EXPECT().ListKycDocuments(gomock.Any(), gomock.Eq(int64(1))).Times(1).Return(nil, nil)
+
+	server := newTestServer(t, store)
+	recoder := httptest.NewRecorder()
+
+	request, err := http.NewRequest(http.MethodGet, "/v1/kyc/submissions/1", nil)
+	require.NoError(t, err)
+	addAuthorization(t, request, server.TokenMaker, rest.AuthorizationTypeBearer, user.Username, time.Minute)
+
+	server.Router.ServeHTTP(recoder, request)
+	require.Equal(t, http.StatusForbidden, recoder.Code)
+}
+
+func TestKYCAdminAPI(t *testing.T) {
+	admin, _ := randomUser(t)
+	admin.Role = bank.RoleAdmin
+
+	const storageKey = "alice/document-id_document"
+
+	testCases := []struct {
+		name          string
+		method        string
+		url           string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recoder *httptest.ResponseRecorder)
+	}{
+		{
+			name:   "ListPending",
+			method: http.MethodGet,
+			url:    "/v1/admin/kyc/submissions?page_size=1",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ListPendingKycSubmissions(gomock.Any(), gomock.Eq(db.ListPendingKycSubmissionsParams{PageLimit: 1})).
+					Times(1).
+					Return([]db.KycSubmission{{ID: 5, Status: kyc.StatusPending}}, nil)
+			},
+			checkResponse: func(t *testing.T, recoder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recoder.Code)
+
+				var res rest.ListKYCSubmissionsRes
+				require.NoError(t, json.Unmarshal(recoder.Body.Bytes(), &res))
+				require.Len(t, res.Submissions, 1)
+				require.EqualValues(t, 5, res.NextAfterID)
+			},
+		},
+		{
+			name:   "Download",
+			method: http.MethodGet,
+			url:    "/v1/admin/kyc/submissions/1/documents/2",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetKycDocument(gomock.Any(), gomock.Eq(db.GetKycDocumentParams{ID: 2, SubmissionID: 1})).
+					Times(1).
+					Return(db.KycDocument{
+						ID:           2,
+						SubmissionID: 1,
+						FileName:     "id.png",
+						ContentType:  "image/png",
+						Size:         int64(len(pngDocument)),
+						StorageKey:   storageKey,
+					}, nil)
+			},
+			checkResponse: func(t *testing.T, recoder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recoder.Code)
+				require.Equal(t, "image/png", recoder.Header().Get("Content-Type"))
+				require.Contains(t, recoder.Header().Get("Content-Disposition"), `filename="id.png"`)
+				require.Equal(t, pngDocument, recoder.Body.Bytes())
+			},
+		},
+		{
+			name:   "Approve",
+			method: http.MethodPost,
+			url:    "/v1/admin/kyc/submissions/1/approve",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ReviewKycSubmissionTx(gomock.Any(), gomock.Eq(db.ReviewKycSubmissionTxParams{
+						ID:         1,
+						Status:     kyc.StatusApproved,
+						ReviewedBy: admin.Username,
+					})).
+					Times(1).
+					Return(db.ReviewKycSubmissionTxResult{
+						Submission: db.KycSubmission{ID: 1, Username: "alice", Level: kyc.LevelIdentity, Status: kyc.StatusApproved},
+						User:       db.User{Username: "alice", KycLevel: kyc.LevelIdentity},
+					}, nil)
+				store.EXPECT().CreateNotification(gomock.Any(), gomock.Any()).Times(1)
+			},
+			checkResponse: func(t *testing.T, recoder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recoder.Code)
+
+				var res rest.ReviewKYCSubmissionRes
+				require.NoError(t, json.Unmarshal(recoder.Body.Bytes(), &res))
+				require.Equal(t, kyc.LevelIdentity, res.User.KYCLevel)
+			},
+		},
+		{
+			name:   "ApproveNotPending",
+			method: http.MethodPost,
+			url:    "/v1/admin/kyc/submissions/1/approve",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ReviewKycSubmissionTx(gomock.Any(), gomock.Any()).Times(1).Return(db.ReviewKycSubmissionTxResult{}, domain.ErrInvalidTransition)
+				store.EXPECT().CreateNotification(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recoder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnprocessableEntity, recoder.Code)
+			},
+		},
+		{
+			name:   "RejectWithoutReason",
+			method: http.MethodPost,
+			url:    "/v1/admin/kyc/submissions/1/reject",
+			body:   gin.H{"reason": ""},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ReviewKycSubmissionTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recoder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recoder.Code)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().GetUser(gomock.Any(), gomock.Eq(admin.Username)).Times(1).Return(admin, nil)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			var files *blob.Disk
+			server.KYC, files = newKYC(t, store)
+			require.NoError(t, files.Put(context.Background(), storageKey, bytes.NewReader(pngDocument)))
+			recoder := httptest.NewRecorder()
+
+			var body bytes.Buffer
+			if tc.body != nil {
+				require.NoError(t, json.NewEncoder(&body).Encode(tc.body))
+			}
+
+			request, err := http.NewRequest(tc.method, tc.url, &body)
+			require.NoError(t, err)
+			addAuthorization(t, request, server.TokenMaker, rest.AuthorizationTypeBearer, admin.Username, time.Minute)
+
+			server.Router.ServeHTTP(recoder, request)
+			tc.checkResponse(t, recoder)
+		})
+	}
+}
@@ -0,0 +1,138 @@
+package test
+
+import (
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/NhutHuyDev/sgbank/internal/bank"
+	"github.com/NhutHuyDev/sgbank/internal/domain"
+	"github.com/NhutHuyDev/sgbank/internal/infra/db"
+	mockdb "github.com/NhutHuyDev/sgbank/internal/infra/db/mock"
+	"github.com/NhutHuyDev/sgbank/internal/ledger"
+	"github.com/NhutHuyDev/sgbank/internal/rest"
+	"github.com/NhutHuyDev/sgbank/pkg/utils"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTrialBalanceAPI(t *testing.T) {
+	admin, _ := randomUser(t)
+	admin.Role = bank.RoleAdmin
+	depositor, _ := randomUser(t)
+	depositor.Role = bank.RoleDepositor
+
+	chart := []db.GlAccount{
+		{Code: "2000", Name: "Customer deposits", Type: ledger.TypeLiability},
+		{Code: "4000", Name: "Fee revenue", Type: ledger.TypeRevenue},
+		{Code: "5000", Name: "Interest expense", Type: ledger.TypeExpense},
+	}
+
+	testCases := []struct {
+		name          string
+		user          db.User
+		query         string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recoder *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "OK",
+			user:  admin,
+			query: "?date=2024-10-31&currency=USD",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListGLAccounts(gomock.Any()).Times(1).Return(chart, nil)
+				store.EXPECT().SumGLBalances(gomock.Any(), gomock.Eq(db.SumGLBalancesParams{
+					Before:          time.Date(2024, 11, 1, 0, 0, 0, 0, time.UTC),
+					Currency:        sql.NullString{String: utils.USD, Valid: true},
+					SuspenseAccount: ledger.CodeSuspense,
+				})).Times(1).Return([]db.SumGLBalancesRow{
+					{Currency: utils.USD, GlAccount: "2000", Balance: 5},
+					{Currency: utils.USD, GlAccount: "4000", Balance: 25},
+					{Currency: utils.USD, GlAccount: "5000", Balance: -30},
+				}, nil)
+			},
+			checkResponse: func(t *testing.T, recoder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recoder.Code)
+
+				var res rest.TrialBalanceRes
+				require.NoError(t, json.Unmarshal(recoder.Body.Bytes(), &res))
+				require.Equal(t, "2024-10-31", res.Date)
+				require.Len(t, res.TrialBalances, 1)
+				require.Equal(t, int64(30), res.TrialBalances[0].TotalDebit)
+				require.Equal(t, int64(30), res.TrialBalances[0].TotalCredit)
+			},
+		},
+		{
+			name:  "InvalidDate",
+			user:  admin,
+			query: "?date=31/10/2024",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().SumGLBalances(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recoder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recoder.Code)
+				requireBodyMatchProblem(t, recoder, domain.ErrInvalidArgument)
+			},
+		},
+		{
+			name:  "NotAdmin",
+			user:  depositor,
+			query: "",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().SumGLBalances(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recoder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recoder.Code)
+				requireBodyMatchProblem(t, recoder, domain.ErrForbidden)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().GetUser(gomock.Any(), gomock.Eq(tc.user.Username)).Times(1).Return(tc.user, nil)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recoder := httptest.NewRecorder()
+
+			request, err := http.NewRequest(http.MethodGet, "/v1/admin/ledger/trial-balance"+tc.query, nil)
+			require.NoError(t, err)
+			addAuthorization(t, request, server.TokenMaker, rest.AuthorizationTypeBearer, tc.user.Username, time.Minute)
+
+			server.Router.ServeHTTP(recoder, request)
+			tc.checkResponse(t, recoder)
+		})
+	}
+}
+
+func TestProfitAndLossAPIRequiresFrom(t *testing.T) {
+	admin, _ := randomUser(t)
+	admin.Role = bank.RoleAdmin
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().GetUser(gomock.Any(), gomock.Eq(admin.Username)).Times(1).Return(admin, nil)
+	store.EXPECT().SumGLBalances(gomock.Any(), gomock.Any()).Times(0)
+
+	server := newTestServer(t, store)
+	recoder := httptest.NewRecorder()
+
+	request, err := http.NewRequest(http.MethodGet, "/v1/admin/ledger/profit-and-loss?to=2024-10-31", nil)
+	require.NoError(t, err)
+	addAuthorization(t, request, server.TokenMaker, rest.AuthorizationTypeBearer, admin.Username, time.Minute)
+
+	server.Router.ServeHTTP(recoder, request)
+	require.Equal(t, http.StatusBadRequest, recoder.Code)
+	problem := requireBodyMatchProblem(t, recoder, domain.ErrInvalidArgument)
+	require.Equal(t, "from", problem.InvalidParams[0].Field)
+}
@@ -0,0 +1,224 @@
+package test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/NhutHuyDev/sgbank/internal/domain"
+	"github.com/NhutHuyDev/sgbank/internal/infra/db"
+	mockdb "github.com/NhutHuyDev/sgbank/internal/infra/db/mock"
+	"github.com/NhutHuyDev/sgbank/internal/rest"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func randomEntryRows(accountID int64, n int) []db.EntryPageRow {
+	rows := make([]db.EntryPageRow, n)
+	createdAt := time.Now().UTC().Truncate(time.Microsecond)
+	for i := range rows {
+		transferID := int64(100 + i)
+		rows[i] = db.EntryPageRow{
+			Entry: db.Entry{
+				ID:         int64(n - i),
+				AccountID:  accountID,
+				Amount:     -10,
+				TransferID: &transferID,
+				CreatedAt:  createdAt.Add(-time.Duration(i) * time.Second),
+			},
+			CounterpartyAccountID: accountID + 1,
+		}
+	}
+	return rows
+}
+
+func TestListEntriesAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	account := randomAccount(user.Username)
+	rows := randomEntryRows(account.ID, 3)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	server := newTestServer(t, store)
+
+	store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(3).Return(account, nil)
+
+	gomock.InOrder(
+		store.EXPECT().
+			ListEntriesPage(gomock.Any(), gomock.Any(), gomock.Eq(db.SortDesc)).
+			Times(1).
+			DoAndReturn(func(_ any, filter db.EntryPageFilter, _ db.SortOrder) ([]db.EntryPageRow, error) {
+				require.Equal(t, account.ID, filter.AccountID)
+				require.EqualValues(t, 3, filter.PageLimit)
+				require.Equal(t, "debit", filter.Direction.String)
+				require.False(t, filter.AfterID.Valid)
+				return rows, nil
+			}),
+		store.EXPECT().
+			ListEntriesPage(gomock.Any(), gomock.Any(), gomock.Eq(db.SortDesc)).
+			Times(1).
+			DoAndReturn(func(_ any, filter db.EntryPageFilter, _ db.SortOrder) ([]db.EntryPageRow, error) {
+				require.True(t, filter.AfterID.Valid)
+				require.Equal(t, rows[1].Entry.ID, filter.AfterID.Int64)
+				require.True(t, rows[1].Entry.CreatedAt.Equal(filter.AfterCreatedAt.Time))
+				return rows[2:], nil
+			}),
+	)
+
+	get := func(query url.Values) *httptest.ResponseRecorder {
+		recoder := httptest.NewRecorder()
+		request, err := http.NewRequest(http.MethodGet, fmt.Sprintf("/v1/accounts/%d/entries?%s", account.ID, query.Encode()), nil)
+		require.NoError(t, err)
+		addAuthorization(t, request, server.TokenMaker, rest.AuthorizationTypeBearer, user.Username, time.Minute)
+		server.Router.ServeHTTP(recoder, request)
+		return recoder
+	}
+
+	query := url.Values{"page_size": {"2"}, "direction": {"debit"}}
+	recoder := get(query)
+	require.Equal(t, http.StatusOK, recoder.Code)
+
+	var firstPage rest.ListEntriesRes
+	require.NoError(t, json.Unmarshal(recoder.Body.Bytes(), &firstPage))
+	require.Len(t, firstPage.Entries, 2)
+	require.NotEmpty(t, firstPage.NextCursor)
+	require.Equal(t, account.ID+1, firstPage.Entries[0].CounterpartyAccountID)
+
+	query.Set("cursor", firstPage.NextCursor)
+	recoder = get(query)
+	require.Equal(t, http.StatusOK, recoder.Code)
+
+	var secondPage rest.ListEntriesRes
+	require.NoError(t, json.Unmarshal(recoder.Body.Bytes(), &secondPage))
+	require.Len(t, secondPage.Entries, 1)
+	require.Empty(t, secondPage.NextCursor)
+
+	// the cursor is bound to the filters it was issued for
+	query.Set("direction", "credit")
+	recoder = get(query)
+	require.Equal(t, http.StatusBadRequest, recoder.Code)
+	problem := requireBodyMatchProblem(t, recoder, domain.ErrInvalidArgument)
+	require.Equal(t, "cursor", problem.InvalidParams[0].Field)
+}
+
+func TestListEntriesAPIForbidden(t *testing.T) {
+	user, _ := randomUser(t)
+	account := randomAccount("someone_else")
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+	store.EXPECT().ListEntriesPage(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+	server := newTestServer(t, store)
+	recoder := httptest.NewRecorder()
+	request, err := http.NewRequest(http.MethodGet, fmt.Sprintf("/v1/accounts/%d/entries", account.ID), nil)
+	require.NoError(t, err)
+	addAuthorization(t, request, server.TokenMaker, rest.AuthorizationTypeBearer, user.Username, time.Minute)
+
+	server.Router.ServeHTTP(recoder, request)
+	require.Equal(t, http.StatusForbidden, recoder.Code)
+	requireBodyMatchProblem(t, recoder, domain.ErrForbidden)
+}
+
+func TestListTransfersAPI(t *testing.T) {
+	user, _ := randomUser(t)
+
+	testCases := []struct {
+		name          string
+		query         url.Values
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recoder *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "OK",
+			query: url.Values{"sort": {"asc"}, "currency": {"USD"}, "min_amount": {"5"}, "direction": {"incoming"}, "q": {"rent -deposit"}},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ListTransfersPage(gomock.Any(), gomock.Any(), gomock.Eq(db.SortAsc)).
+					Times(1).
+					DoAndReturn(func(_ any, filter db.TransferPageFilter, _ db.SortOrder) ([]db.TransferPageRow, error) {
+						require.Equal(t, user.Username, filter.Owner)
+						require.Equal(t, "USD", filter.Currency.String)
+						require.EqualValues(t, 5, filter.MinAmount.Int64)
+						require.Equal(t, "incoming", filter.Direction.String)
+						require.Equal(t, "rent -deposit", filter.Query.String)
+						require.EqualValues(t, 21, filter.PageLimit)
+						return []db.TransferPageRow{{Transfer: db.Transfer{ID: 1, Amount: 10, Description: "March rent"}, Currency: "USD"}}, nil
+					})
+			},
+			checkResponse: func(t *testing.T, recoder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recoder.Code)
+
+				var res rest.ListTransfersRes
+				require.NoError(t, json.Unmarshal(recoder.Body.Bytes(), &res))
+				require.Len(t, res.Transfers, 1)
+				require.Equal(t, "USD", res.Transfers[0].Currency)
+				require.Equal(t, "March rent", res.Transfers[0].Description)
+				require.Empty(t, res.NextCursor)
+			},
+		},
+		{
+			name:  "InvalidDirection",
+			query: url.Values{"direction": {"credit"}},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListTransfersPage(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recoder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recoder.Code)
+				requireBodyMatchProblem(t, recoder, domain.ErrInvalidArgument)
+			},
+		},
+		{
+			name:  "InvalidAmountRange",
+			query: url.Values{"min_amount": {"10"}, "max_amount": {"5"}},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListTransfersPage(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recoder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recoder.Code)
+				requireBodyMatchProblem(t, recoder, domain.ErrInvalidArgument)
+			},
+		},
+		{
+			name:  "PageSizeTooLarge",
+			query: url.Values{"page_size": {"101"}},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListTransfersPage(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recoder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recoder.Code)
+				problem := requireBodyMatchProblem(t, recoder, domain.ErrInvalidArgument)
+				require.Equal(t, "page_size", problem.InvalidParams[0].Field)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recoder := httptest.NewRecorder()
+
+			request, err := http.NewRequest(http.MethodGet, "/v1/transfers?"+tc.query.Encode(), nil)
+			require.NoError(t, err)
+			addAuthorization(t, request, server.TokenMaker, rest.AuthorizationTypeBearer, user.Username, time.Minute)
+
+			server.Router.ServeHTTP(recoder, request)
+			tc.checkResponse(t, recoder)
+		})
+	}
+}
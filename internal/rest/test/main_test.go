@@ -7,6 +7,7 @@ import (
 
 	"github.com/NhutHuyDev/sgbank/internal/infra/db"
 	"github.com/NhutHuyDev/sgbank/internal/rest"
+	"github.com/NhutHuyDev/sgbank/internal/services"
 	"github.com/NhutHuyDev/sgbank/pkg/utils"
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/require"
@@ -18,7 +19,10 @@ func newTestServer(t *testing.T, store db.Store) *rest.Server {
 		AccessTokenDuration: time.Minute,
 	}
 
-	server, err := rest.NewServer(config, store)
+	set, err := services.FromConfig(store, config)
+	require.NoError(t, err)
+
+	server, err := rest.NewServer(config, store, set)
 	require.NoError(t, err)
 
 	return server
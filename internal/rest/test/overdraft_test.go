@@ -0,0 +1,176 @@
+package test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/NhutHuyDev/sgbank/internal/bank"
+	"github.com/NhutHuyDev/sgbank/internal/domain"
+	"github.com/NhutHuyDev/sgbank/internal/infra/db"
+	mockdb "github.com/NhutHuyDev/sgbank/internal/infra/db/mock"
+	"github.com/NhutHuyDev/sgbank/internal/rest"
+	"github.com/NhutHuyDev/sgbank/pkg/utils"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApproveOverdraftAPI(t *testing.T) {
+	admin, _ := randomUser(t)
+	admin.Role = bank.RoleAdmin
+	depositor, _ := randomUser(t)
+	depositor.Role = bank.RoleDepositor
+
+	account := randomAccount(depositor.Username)
+	account.Product = bank.ProductChecking
+
+	testCases := []struct {
+		name          string
+		user          db.User
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recoder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			user: admin,
+			body: gin.H{"overdraft_limit": 50000, "reason": " salary history "},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(admin.Username)).Times(1).Return(admin, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(2).Return(account, nil)
+				store.EXPECT().GetAccountProduct(gomock.Any(), gomock.Eq(bank.ProductChecking)).Times(1).
+					Return(db.AccountProduct{Code: bank.ProductChecking}, nil)
+				store.EXPECT().
+					ApproveOverdraftTx(gomock.Any(), gomock.Eq(db.ApproveOverdraftTxParams{
+						AccountID:      account.ID,
+						OverdraftLimit: 50000,
+						ApprovedBy:     admin.Username,
+						Reason:         "salary history",
+					})).
+					Times(1).
+					Return(db.ApproveOverdraftTxResult{}, nil)
+			},
+			checkResponse: func(t *testing.T, recoder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recoder.Code)
+			},
+		},
+		{
+			name: "NotAdmin",
+			user: depositor,
+			body: gin.H{"overdraft_limit": 50000},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(depositor.Username)).Times(1).Return(depositor, nil)
+				store.EXPECT().ApproveOverdraftTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recoder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recoder.Code)
+				requireBodyMatchProblem(t, recoder, domain.ErrForbidden)
+			},
+		},
+		{
+			name: "BankAccount",
+			user: admin,
+			body: gin.H{"overdraft_limit": 50000},
+			buildStubs: func(store *mockdb.MockStore) {
+				internal := account
+				internal.Product = bank.ProductInterestExpense
+
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(admin.Username)).Times(1).Return(admin, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(2).Return(internal, nil)
+				store.EXPECT().GetAccountProduct(gomock.Any(), gomock.Eq(bank.ProductInterestExpense)).Times(1).
+					Return(db.AccountProduct{Code: bank.ProductInterestExpense, IsInternal: true}, nil)
+				store.EXPECT().ApproveOverdraftTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recoder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recoder.Code)
+				requireBodyMatchProblem(t, recoder, domain.ErrInvalidArgument)
+			},
+		},
+		{
+			name: "NegativeLimit",
+			user: admin,
+			body: gin.H{"overdraft_limit": -1},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(admin.Username)).Times(1).Return(admin, nil)
+				store.EXPECT().ApproveOverdraftTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recoder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recoder.Code)
+				problem := requireBodyMatchProblem(t, recoder, domain.ErrInvalidArgument)
+				require.Equal(t, "overdraft_limit", problem.InvalidParams[0].Field)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recoder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/v1/admin/accounts/%d/overdraft", account.ID)
+			request, err := http.NewRequest(http.MethodPut, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.TokenMaker, rest.AuthorizationTypeBearer, tc.user.Username, time.Minute)
+
+			server.Router.ServeHTTP(recoder, request)
+			tc.checkResponse(t, recoder)
+		})
+	}
+}
+
+func TestListOverdraftsAPI(t *testing.T) {
+	admin, _ := randomUser(t)
+	admin.Role = bank.RoleAdmin
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	accounts := []db.Account{randomAccount(utils.RandomOwner()), randomAccount(utils.RandomOwner())}
+	totals := []db.SummarizeOverdraftsRow{{Currency: utils.USD, Accounts: 2, Overdrawn: 700, OverdraftLimits: 1000}}
+
+	store.EXPECT().GetUser(gomock.Any(), gomock.Eq(admin.Username)).Times(1).Return(admin, nil)
+	store.EXPECT().
+		ListOverdrawnAccounts(gomock.Any(), gomock.Any()).
+		Times(1).
+		DoAndReturn(func(_ context.Context, arg db.ListOverdrawnAccountsParams) ([]db.Account, error) {
+			require.Equal(t, utils.USD, arg.Currency.String)
+			require.Equal(t, int64(5), arg.AfterID)
+			require.Equal(t, int32(2), arg.PageLimit)
+			return accounts, nil
+		})
+	store.EXPECT().SummarizeOverdrafts(gomock.Any()).Times(1).Return(totals, nil)
+
+	server := newTestServer(t, store)
+	recoder := httptest.NewRecorder()
+
+	request, err := http.NewRequest(http.MethodGet, "/v1/admin/overdrafts?currency=USD&after_id=5&page_size=2", nil)
+	require.NoError(t, err)
+
+	addAuthorization(t, request, server.TokenMaker, rest.AuthorizationTypeBearer, admin.Username, time.Minute)
+
+	server.Router.ServeHTTP(recoder, request)
+	require.Equal(t, http.StatusOK, recoder.Code)
+
+	var res rest.ListOverdraftsRes
+	require.NoError(t, json.Unmarshal(recoder.Body.Bytes(), &res))
+	require.Len(t, res.Accounts, 2)
+	require.Equal(t, totals, res.Totals)
+	require.Equal(t, accounts[1].ID, res.NextAfterID)
+}
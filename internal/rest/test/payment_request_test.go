@@ -0,0 +1,191 @@
+package test
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/NhutHuyDev/sgbank/internal/domain"
+	"github.com/NhutHuyDev/sgbank/internal/infra/db"
+	mockdb "github.com/NhutHuyDev/sgbank/internal/infra/db/mock"
+	"github.com/NhutHuyDev/sgbank/internal/payment/paymenttest"
+	"github.com/NhutHuyDev/sgbank/internal/rest"
+	"github.com/NhutHuyDev/sgbank/pkg/utils"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPaymentLinkAPI(t *testing.T) {
+	requester, _ := randomUser(t)
+	payer, _ := randomUser(t)
+
+	toAccount := randomAccount(requester.Username)
+	toAccount.Currency = utils.USD
+	fromAccount := randomAccount(payer.Username)
+	fromAccount.Currency = utils.USD
+	fromAccount.ID = toAccount.ID + 1
+
+	request := db.PaymentRequest{
+		ID:          int64(utils.RandomInt(1, 1000)),
+		Requester:   requester.Username,
+		ToAccountID: toAccount.ID,
+		Amount:      25,
+		Currency:    utils.USD,
+		Memo:        "tickets",
+		Status:      "pending",
+		ExpiresAt:   time.Now().Add(time.Hour),
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	paymenttest.StubNoFees(store)
+	stubNoFraud(store)
+	stubNoKYCLimits(store)
+	server := newTestServer(t, store)
+
+	send := func(method, url, username string, body gin.H) *httptest.ResponseRecorder {
+		data, err := json.Marshal(body)
+		require.NoError(t, err)
+
+		req, err := http.NewRequest(method, url, bytes.NewReader(data))
+		require.NoError(t, err)
+		addAuthorization(t, req, server.TokenMaker, rest.AuthorizationTypeBearer, username, time.Minute)
+
+		recoder := httptest.NewRecorder()
+		server.Router.ServeHTTP(recoder, req)
+		return recoder
+	}
+
+	// the requester creates a link request
+	store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(toAccount.ID)).Times(2).Return(toAccount, nil)
+	store.EXPECT().CreatePaymentRequest(gomock.Any(), gomock.Any()).Times(1).Return(request, nil)
+
+	recoder := send(http.MethodPost, "/v1/payment-requests", requester.Username, gin.H{
+		"to_account_id": toAccount.ID,
+		"amount":        request.Amount,
+		"currency":      utils.USD,
+		"memo":          request.Memo,
+	})
+	require.Equal(t, http.StatusOK, recoder.Code)
+
+	var created rest.PaymentRequestRes
+	require.NoError(t, json.Unmarshal(recoder.Body.Bytes(), &created))
+	require.NotEmpty(t, created.Link)
+
+	// the payer cannot pay it by ID, only through the link
+	store.EXPECT().GetPaymentRequest(gomock.Any(), gomock.Eq(request.ID)).Times(2).Return(request, nil)
+	store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(fromAccount.ID)).Times(2).Return(fromAccount, nil)
+
+	paid := request
+	paid.Status = "paid"
+	paid.Payer = sql.NullString{String: payer.Username, Valid: true}
+	paid.TransferID = sql.NullInt64{Int64: 99, Valid: true}
+
+	store.EXPECT().PayPaymentRequestTx(gomock.Any(), gomock.Any()).Times(1).
+		DoAndReturn(func(_ context.Context, arg db.PayPaymentRequestTxParams) (db.PayPaymentRequestTxResult, error) {
+			require.Equal(t, payer.Username, arg.Payer)
+			require.Equal(t, fromAccount.ID, arg.FromAccountID)
+			if err := arg.Authorize(request); err != nil {
+				return db.PayPaymentRequestTxResult{}, err
+			}
+			return db.PayPaymentRequestTxResult{PaymentRequest: paid, TransferTxResult: db.TransferTxResult{Transfer: db.Transfer{ID: 99}}}, nil
+		})
+	store.EXPECT().CreateNotification(gomock.Any(), gomock.Any()).Times(1).
+		DoAndReturn(func(_ context.Context, arg db.CreateNotificationParams) (db.Notification, error) {
+			require.Equal(t, requester.Username, arg.Username)
+			return db.Notification{}, nil
+		})
+
+	recoder = send(http.MethodPost, fmt.Sprintf("/v1/payment-requests/%d/accept", request.ID), payer.Username, gin.H{"from_account_id": fromAccount.ID})
+	require.Equal(t, http.StatusForbidden, recoder.Code)
+	requireBodyMatchProblem(t, recoder, domain.ErrForbidden)
+
+	recoder = send(http.MethodPost, created.Link+"/accept", payer.Username, gin.H{"from_account_id": fromAccount.ID})
+	require.Equal(t, http.StatusOK, recoder.Code)
+
+	var accepted rest.AcceptPaymentRequestRes
+	require.NoError(t, json.Unmarshal(recoder.Body.Bytes(), &accepted))
+	require.Equal(t, "paid", accepted.PaymentRequest.Status)
+	require.EqualValues(t, 99, *accepted.PaymentRequest.TransferID)
+	require.Empty(t, accepted.PaymentRequest.Link)
+	require.EqualValues(t, 1, accepted.FraudDecisionID)
+
+	// a tampered link is rejected before touching the store
+	recoder = send(http.MethodGet, created.Link+"x", payer.Username, nil)
+	require.Equal(t, http.StatusNotFound, recoder.Code)
+	requireBodyMatchProblem(t, recoder, domain.ErrPaymentRequestNotFound)
+}
+
+func TestAcceptPaymentRequestHeldAPI(t *testing.T) {
+	requester, _ := randomUser(t)
+	payer, _ := randomUser(t)
+
+	toAccount := randomAccount(requester.Username)
+	toAccount.Currency = utils.USD
+	fromAccount := randomAccount(payer.Username)
+	fromAccount.Currency = utils.USD
+	fromAccount.ID = toAccount.ID + 1
+
+	// a first payment of a large amount to toAccount scores 60
+	request := db.PaymentRequest{
+		ID:          int64(utils.RandomInt(1, 1000)),
+		Requester:   requester.Username,
+		Payer:       sql.NullString{String: payer.Username, Valid: true},
+		ToAccountID: toAccount.ID,
+		Amount:      150000,
+		Currency:    utils.USD,
+		Memo:        "rent",
+		Status:      "pending",
+		ExpiresAt:   time.Now().Add(time.Hour),
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().GetPaymentRequest(gomock.Any(), gomock.Eq(request.ID)).Times(1).Return(request, nil)
+	store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(fromAccount.ID)).Times(1).Return(fromAccount, nil)
+	store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(toAccount.ID)).Times(1).Return(toAccount, nil)
+	store.EXPECT().HasTransferredTo(gomock.Any(), gomock.Eq(db.HasTransferredToParams{
+		Owner:       payer.Username,
+		ToAccountID: toAccount.ID,
+	})).Times(1).Return(false, nil)
+	paymenttest.StubNoFees(store)
+	stubNoFraud(store)
+	stubNoKYCLimits(store)
+	store.EXPECT().PayPaymentRequestTx(gomock.Any(), gomock.Any()).Times(0)
+	// only the payer is told that the payment waits for review
+	store.EXPECT().CreateNotification(gomock.Any(), gomock.Any()).Times(1).
+		DoAndReturn(func(_ context.Context, arg db.CreateNotificationParams) (db.Notification, error) {
+			require.Equal(t, payer.Username, arg.Username)
+			return db.Notification{}, nil
+		})
+
+	server := newTestServer(t, store)
+	recoder := httptest.NewRecorder()
+
+	data, err := json.Marshal(gin.H{"from_account_id": fromAccount.ID})
+	require.NoError(t, err)
+
+	req, err := http.NewRequest(http.MethodPost, fmt.Sprintf("/v1/payment-requests/%d/accept", request.ID), bytes.NewReader(data))
+	require.NoError(t, err)
+	addAuthorization(t, req, server.TokenMaker, rest.AuthorizationTypeBearer, payer.Username, time.Minute)
+
+	server.Router.ServeHTTP(recoder, req)
+	require.Equal(t, http.StatusAccepted, recoder.Code)
+
+	var res rest.HeldPaymentRequestRes
+	require.NoError(t, json.Unmarshal(recoder.Body.Bytes(), &res))
+	require.Equal(t, "pending", res.PaymentRequest.Status)
+	require.Equal(t, "pending", res.FraudDecision.Status)
+	require.Equal(t, db.HeldPaymentRequest, res.FraudDecision.Kind)
+}
@@ -0,0 +1,111 @@
+package test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/NhutHuyDev/sgbank/internal/domain"
+	"github.com/NhutHuyDev/sgbank/internal/infra/db"
+	mockdb "github.com/NhutHuyDev/sgbank/internal/infra/db/mock"
+	"github.com/NhutHuyDev/sgbank/internal/rest"
+	"github.com/NhutHuyDev/sgbank/pkg/utils"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLookupRecipientAPI(t *testing.T) {
+	user1, _ := randomUser(t)
+	user2, _ := randomUser(t)
+	user2.FullName = "Tran Thi Binh"
+
+	account2 := randomAccount(user2.Username)
+	account2.Currency = utils.USD
+
+	testCases := []struct {
+		name          string
+		query         url.Values
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recoder *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "OK",
+			query: url.Values{"recipient": {user2.Email}, "currency": {utils.USD}},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CountRecipientLookupsSince(gomock.Any(), gomock.Any()).Times(1).Return(int64(0), nil)
+				store.EXPECT().GetUserByVerifiedEmail(gomock.Any(), gomock.Eq(user2.Email)).Times(1).Return(user2, nil)
+				store.EXPECT().GetAccountByOwnerCurrency(gomock.Any(), gomock.Any()).Times(1).Return(account2, nil)
+				store.EXPECT().CreateRecipientLookup(gomock.Any(), gomock.Any()).Times(1)
+			},
+			checkResponse: func(t *testing.T, recoder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recoder.Code)
+
+				var res rest.LookupRecipientRes
+				require.NoError(t, json.Unmarshal(recoder.Body.Bytes(), &res))
+				require.Equal(t, "T*** T*** B***", res.MaskedName)
+				require.Equal(t, utils.USD, res.Currency)
+				require.NotContains(t, recoder.Body.String(), user2.Username)
+			},
+		},
+		{
+			name:  "NotFound",
+			query: url.Values{"recipient": {user2.Username}, "currency": {utils.USD}},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CountRecipientLookupsSince(gomock.Any(), gomock.Any()).Times(1).Return(int64(0), nil)
+				store.EXPECT().GetUser(gomock.Any(), gomock.Any()).Times(1).Return(db.User{}, domain.ErrUserNotFound)
+				store.EXPECT().CreateRecipientLookup(gomock.Any(), gomock.Any()).Times(1)
+			},
+			checkResponse: func(t *testing.T, recoder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recoder.Code)
+				requireBodyMatchProblem(t, recoder, domain.ErrRecipientNotFound)
+			},
+		},
+		{
+			name:  "TooManyRequests",
+			query: url.Values{"recipient": {user2.Username}, "currency": {utils.USD}},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CountRecipientLookupsSince(gomock.Any(), gomock.Any()).Times(1).Return(int64(1000), nil)
+				store.EXPECT().GetUser(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recoder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusTooManyRequests, recoder.Code)
+				requireBodyMatchProblem(t, recoder, domain.ErrTooManyRequests)
+			},
+		},
+		{
+			name:  "MissingCurrency",
+			query: url.Values{"recipient": {user2.Username}},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CountRecipientLookupsSince(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recoder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recoder.Code)
+				requireBodyMatchProblem(t, recoder, domain.ErrInvalidArgument)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recoder := httptest.NewRecorder()
+
+			request, err := http.NewRequest(http.MethodGet, "/v1/recipients/lookup?"+tc.query.Encode(), nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.TokenMaker, rest.AuthorizationTypeBearer, user1.Username, time.Minute)
+
+			server.Router.ServeHTTP(recoder, request)
+			tc.checkResponse(t, recoder)
+		})
+	}
+}
@@ -0,0 +1,271 @@
+package test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/NhutHuyDev/sgbank/internal/bank"
+	"github.com/NhutHuyDev/sgbank/internal/domain"
+	"github.com/NhutHuyDev/sgbank/internal/infra/db"
+	mockdb "github.com/NhutHuyDev/sgbank/internal/infra/db/mock"
+	"github.com/NhutHuyDev/sgbank/internal/payment"
+	"github.com/NhutHuyDev/sgbank/internal/payment/paymenttest"
+	"github.com/NhutHuyDev/sgbank/internal/rest"
+	"github.com/NhutHuyDev/sgbank/internal/screening"
+	"github.com/NhutHuyDev/sgbank/pkg/utils"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+const sanctionedName = "Usama BIN LADIN"
+
+// newScreening screens against a list with a single entry, 2674 for
+// sanctionedName, instead of the shared default lists.
+func newScreening(t *testing.T, store db.Store) *screening.Service {
+	path := filepath.Join(t.TempDir(), "sdn.csv")
+	row := `2674,"BIN LADIN, Usama","individual","SDGT",-0- ,-0- ,-0- ,-0- ,-0- ,-0- ,-0- ,-0-` + "\n"
+	require.NoError(t, os.WriteFile(path, []byte(row), 0o600))
+
+	service := screening.NewService(store, screening.NewLists(), []string{path}, screening.DefaultFlagThreshold, screening.DefaultBlockThreshold)
+	_, err := service.Reload()
+	require.NoError(t, err)
+
+	return service
+}
+
+func TestCreateUserAPISanctionsMatch(t *testing.T) {
+	user, password := randomUser(t)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().ListWhitelistedEntryIDs(gomock.Any(), gomock.Eq("bin ladin usama")).Times(1).Return(nil, nil)
+	store.EXPECT().
+		CreateScreeningMatch(gomock.Any(), gomock.Any()).
+		Times(1).
+		DoAndReturn(func(_ any, arg db.CreateScreeningMatchParams) (db.ScreeningMatch, error) {
+			require.Equal(t, screening.SubjectSignup, arg.Subject)
+			require.Equal(t, user.Username, arg.Username)
+			require.Equal(t, screening.ActionBlock, arg.Action)
+			return db.ScreeningMatch{ID: 1}, nil
+		})
+	store.EXPECT().CreateUser(gomock.Any(), gomock.Any()).Times(0)
+
+	server := newTestServer(t, store)
+	server.Screening = newScreening(t, store)
+	recoder := httptest.NewRecorder()
+
+	data, err := json.Marshal(gin.H{
+		"username":  user.Username,
+		"password":  password,
+		"full_name": sanctionedName,
+		"email":     user.Email,
+	})
+	require.NoError(t, err)
+
+	request, err := http.NewRequest(http.MethodPost, "/v1/users", bytes.NewReader(data))
+	require.NoError(t, err)
+
+	server.Router.ServeHTTP(recoder, request)
+	require.Equal(t, http.StatusForbidden, recoder.Code)
+	requireBodyMatchProblem(t, recoder, domain.ErrSanctionsMatch)
+}
+
+func TestTransferAPISanctionsMatch(t *testing.T) {
+	user1, _ := randomUser(t)
+	user2, _ := randomUser(t)
+	user2.FullName = sanctionedName
+
+	account1 := randomAccount(user1.Username)
+	account2 := randomAccount(user2.Username)
+	account1.Currency = utils.USD
+	account2.Currency = utils.USD
+	account2.ID = account1.ID + 1
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).MinTimes(1).Return(account1, nil)
+	store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account2.ID)).Times(1).Return(account2, nil)
+	store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user2.Username)).Times(1).Return(user2, nil)
+	store.EXPECT().ListWhitelistedEntryIDs(gomock.Any(), gomock.Any()).Times(1).Return(nil, nil)
+	store.EXPECT().
+		CreateScreeningMatch(gomock.Any(), gomock.Any()).
+		Times(1).
+		DoAndReturn(func(_ any, arg db.CreateScreeningMatchParams) (db.ScreeningMatch, error) {
+			require.Equal(t, screening.SubjectCounterparty, arg.Subject)
+			require.Equal(t, user1.Username, arg.Username)
+			require.Equal(t, account2.ID, arg.AccountID.Int64)
+			return db.ScreeningMatch{ID: 1}, nil
+		})
+	store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+	paymenttest.StubNoFees(store)
+	stubNoFraud(store)
+	stubNoKYCLimits(store)
+
+	server := newTestServer(t, store)
+	server.Screening = newScreening(t, store)
+	server.Payments = payment.NewService(store, server.Fraud, server.Screening, server.KYC, server.Fees)
+	recoder := httptest.NewRecorder()
+
+	data, err := json.Marshal(gin.H{
+		"from_account_id": account1.ID,
+		"to_account_id":   account2.ID,
+		"amount":          100,
+		"currency":        utils.USD,
+	})
+	require.NoError(t, err)
+
+	request, err := http.NewRequest(http.MethodPost, "/v1/transfers", bytes.NewReader(data))
+	require.NoError(t, err)
+	addAuthorization(t, request, server.TokenMaker, rest.AuthorizationTypeBearer, user1.Username, time.Minute)
+
+	server.Router.ServeHTTP(recoder, request)
+	require.Equal(t, http.StatusForbidden, recoder.Code)
+	requireBodyMatchProblem(t, recoder, domain.ErrSanctionsMatch)
+}
+
+func TestScreeningAdminAPI(t *testing.T) {
+	admin, _ := randomUser(t)
+	admin.Role = bank.RoleAdmin
+
+	testCases := []struct {
+		name          string
+		method        string
+		url           string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recoder *httptest.ResponseRecorder)
+	}{
+		{
+			name:       "Status",
+			method:     http.MethodGet,
+			url:        "/v1/admin/screening",
+			buildStubs: func(store *mockdb.MockStore) {},
+			checkResponse: func(t *testing.T, recoder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recoder.Code)
+
+				var res screening.Status
+				require.NoError(t, json.Unmarshal(recoder.Body.Bytes(), &res))
+				require.Equal(t, 1, res.Entries)
+				require.Len(t, res.Files, 1)
+			},
+		},
+		{
+			name:       "Search",
+			method:     http.MethodGet,
+			url:        "/v1/admin/screening/search?name=Osama+bin+Laden",
+			buildStubs: func(store *mockdb.MockStore) {},
+			checkResponse: func(t *testing.T, recoder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recoder.Code)
+
+				var res rest.SearchScreeningListsRes
+				require.NoError(t, json.Unmarshal(recoder.Body.Bytes(), &res))
+				require.Len(t, res.Matches, 1)
+				require.Equal(t, "2674", res.Matches[0].Entry.ID)
+			},
+		},
+		{
+			name:   "ListMatches",
+			method: http.MethodGet,
+			url:    "/v1/admin/screening/matches?action=block",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ListScreeningMatches(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ any, arg db.ListScreeningMatchesParams) ([]db.ScreeningMatch, error) {
+						require.Equal(t, screening.ActionBlock, arg.Action.String)
+						require.Equal(t, int32(screening.PageSize), arg.PageLimit)
+						return []db.ScreeningMatch{{ID: 3, Action: screening.ActionBlock}}, nil
+					})
+			},
+			checkResponse: func(t *testing.T, recoder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recoder.Code)
+
+				var res rest.ListScreeningMatchesRes
+				require.NoError(t, json.Unmarshal(recoder.Body.Bytes(), &res))
+				require.Len(t, res.Matches, 1)
+				require.Zero(t, res.NextAfterID)
+			},
+		},
+		{
+			name:   "Whitelist",
+			method: http.MethodPost,
+			url:    "/v1/admin/screening/whitelist",
+			body:   gin.H{"name": "Usama Bin-Ladin", "entry_id": "2674", "reason": "different date of birth"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateScreeningWhitelistEntry(gomock.Any(), gomock.Eq(db.CreateScreeningWhitelistEntryParams{
+						Name:      "bin ladin usama",
+						EntryID:   "2674",
+						Reason:    "different date of birth",
+						CreatedBy: admin.Username,
+					})).
+					Times(1).
+					Return(db.ScreeningWhitelist{ID: 4, Name: "bin ladin usama", EntryID: "2674"}, nil)
+			},
+			checkResponse: func(t *testing.T, recoder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusCreated, recoder.Code)
+			},
+		},
+		{
+			name:   "WhitelistInvalidEntry",
+			method: http.MethodPost,
+			url:    "/v1/admin/screening/whitelist",
+			body:   gin.H{"name": "Usama Bin-Ladin", "entry_id": "SDN-2674"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateScreeningWhitelistEntry(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recoder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recoder.Code)
+			},
+		},
+		{
+			name:   "DeleteNotFound",
+			method: http.MethodDelete,
+			url:    "/v1/admin/screening/whitelist/4",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().DeleteScreeningWhitelistEntry(gomock.Any(), gomock.Eq(int64(4))).Times(1).Return(db.ScreeningWhitelist{}, domain.ErrNotFound)
+			},
+			checkResponse: func(t *testing.T, recoder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recoder.Code)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().GetUser(gomock.Any(), gomock.Eq(admin.Username)).Times(1).Return(admin, nil)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			server.Screening = newScreening(t, store)
+			recoder := httptest.NewRecorder()
+
+			var body bytes.Buffer
+			if tc.body != nil {
+				require.NoError(t, json.NewEncoder(&body).Encode(tc.body))
+			}
+
+			request, err := http.NewRequest(tc.method, tc.url, &body)
+			require.NoError(t, err)
+			addAuthorization(t, request, server.TokenMaker, rest.AuthorizationTypeBearer, admin.Username, time.Minute)
+
+			server.Router.ServeHTTP(recoder, request)
+			tc.checkResponse(t, recoder)
+		})
+	}
+}
@@ -0,0 +1,153 @@
+package test
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/NhutHuyDev/sgbank/internal/domain"
+	"github.com/NhutHuyDev/sgbank/internal/infra/db"
+	mockdb "github.com/NhutHuyDev/sgbank/internal/infra/db/mock"
+	"github.com/NhutHuyDev/sgbank/internal/rest"
+	"github.com/NhutHuyDev/sgbank/pkg/utils"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateTransferBatchCSVAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	fromAccount := randomAccount(user.Username)
+	fromAccount.Currency = utils.USD
+	toAccount := randomAccount("employee")
+	toAccount.ID = fromAccount.ID + 1
+	toAccount.Currency = utils.USD
+
+	query := url.Values{
+		"from_account_id": {fmt.Sprint(fromAccount.ID)},
+		"currency":        {utils.USD},
+		"mode":            {"best_effort"},
+	}
+
+	testCases := []struct {
+		name          string
+		body          string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recoder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "Accepted",
+			body: fmt.Sprintf("to_account_number,amount,reference\n%s,10,bonus\n%s,20,salary\n", toAccount.AccountNumber, toAccount.AccountNumber),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(fromAccount.ID)).Times(1).Return(fromAccount, nil)
+				store.EXPECT().GetAccountByNumber(gomock.Any(), gomock.Eq(toAccount.AccountNumber)).Times(1).Return(toAccount, nil)
+				store.EXPECT().CreateTransferBatchTx(gomock.Any(), gomock.Any()).Times(1).
+					DoAndReturn(func(_ context.Context, arg db.CreateTransferBatchTxParams) (db.CreateTransferBatchTxResult, error) {
+						require.Equal(t, user.Username, arg.Owner)
+						require.EqualValues(t, 30, arg.TotalAmount)
+						require.Equal(t, "salary", arg.Items[1].Reference)
+						return db.CreateTransferBatchTxResult{Batch: db.TransferBatch{
+							ID:            3,
+							Owner:         arg.Owner,
+							FromAccountID: arg.FromAccountID,
+							Currency:      arg.Currency,
+							Mode:          arg.Mode,
+							Status:        "pending",
+							TotalRows:     arg.TotalRows,
+							TotalAmount:   arg.TotalAmount,
+						}}, nil
+					})
+			},
+			checkResponse: func(t *testing.T, recoder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusAccepted, recoder.Code)
+
+				var res rest.TransferBatchRes
+				require.NoError(t, json.Unmarshal(recoder.Body.Bytes(), &res))
+				require.Equal(t, "pending", res.Status)
+				require.EqualValues(t, 2, res.TotalRows)
+				require.Empty(t, res.Results)
+			},
+		},
+		{
+			name: "InvalidRows",
+			body: "to_account_id,amount\n1,ten\n2,-5\n",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateTransferBatchTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recoder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recoder.Code)
+				problem := requireBodyMatchProblem(t, recoder, domain.ErrInvalidArgument)
+				require.Len(t, problem.InvalidParams, 1)
+				require.Equal(t, "rows[1].amount", problem.InvalidParams[0].Field)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recoder := httptest.NewRecorder()
+
+			request, err := http.NewRequest(http.MethodPost, "/v1/transfer-batches?"+query.Encode(), strings.NewReader(tc.body))
+			require.NoError(t, err)
+			request.Header.Set("Content-Type", "text/csv")
+			addAuthorization(t, request, server.TokenMaker, rest.AuthorizationTypeBearer, user.Username, time.Minute)
+
+			server.Router.ServeHTTP(recoder, request)
+			tc.checkResponse(t, recoder)
+		})
+	}
+}
+
+func TestTransferBatchResultsAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	batch := db.TransferBatch{ID: 5, Owner: user.Username, Status: "processing", TotalRows: 2, ProcessedRows: 1}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	server := newTestServer(t, store)
+
+	get := func() *httptest.ResponseRecorder {
+		recoder := httptest.NewRecorder()
+		request, err := http.NewRequest(http.MethodGet, fmt.Sprintf("/v1/transfer-batches/%d/results", batch.ID), nil)
+		require.NoError(t, err)
+		addAuthorization(t, request, server.TokenMaker, rest.AuthorizationTypeBearer, user.Username, time.Minute)
+		server.Router.ServeHTTP(recoder, request)
+		return recoder
+	}
+
+	store.EXPECT().GetTransferBatch(gomock.Any(), gomock.Eq(batch.ID)).Times(1).Return(batch, nil)
+	recoder := get()
+	require.Equal(t, http.StatusUnprocessableEntity, recoder.Code)
+	requireBodyMatchProblem(t, recoder, domain.ErrBatchInProgress)
+
+	batch.Status = "completed"
+	store.EXPECT().GetTransferBatch(gomock.Any(), gomock.Eq(batch.ID)).Times(1).Return(batch, nil)
+	store.EXPECT().ListTransferBatchItems(gomock.Any(), gomock.Eq(batch.ID)).Times(1).Return([]db.TransferBatchItem{
+		{RowNumber: 1, ToAccountID: 9, Amount: 10, Status: "succeeded"},
+		{RowNumber: 2, ToAccountID: 9, Amount: 20, Status: "failed", Error: "insufficient funds"},
+	}, nil)
+
+	recoder = get()
+	require.Equal(t, http.StatusOK, recoder.Code)
+	require.Equal(t, "text/csv", recoder.Header().Get("Content-Type"))
+
+	records, err := csv.NewReader(recoder.Body).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 3)
+	require.Equal(t, "failed", records[2][4])
+}
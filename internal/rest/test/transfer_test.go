@@ -0,0 +1,595 @@
+package test
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/NhutHuyDev/sgbank/internal/bank"
+	"github.com/NhutHuyDev/sgbank/internal/domain"
+	"github.com/NhutHuyDev/sgbank/internal/fee"
+	"github.com/NhutHuyDev/sgbank/internal/infra/db"
+	mockdb "github.com/NhutHuyDev/sgbank/internal/infra/db/mock"
+	"github.com/NhutHuyDev/sgbank/internal/payment/paymenttest"
+	"github.com/NhutHuyDev/sgbank/internal/rest"
+	"github.com/NhutHuyDev/sgbank/pkg/utils"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransferAPI(t *testing.T) {
+	user1, _ := randomUser(t)
+	user2, _ := randomUser(t)
+
+	account1 := randomAccount(user1.Username)
+	account2 := randomAccount(user2.Username)
+	account1.Currency = utils.USD
+	account2.Currency = utils.USD
+	account2.ID = account1.ID + 1
+
+	amount := int64(10)
+	feeRevenue := randomAccount(bank.Username)
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recoder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{
+				"from_account_id": account1.ID,
+				"to_account_id":   account2.ID,
+				"amount":          amount,
+				"currency":        utils.USD,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).MinTimes(1).Return(account1, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account2.ID)).Times(1).Return(account2, nil)
+				store.EXPECT().
+					TransferTx(gomock.Any(), gomock.Eq(db.TransferTxParams{
+						FromAccountID: account1.ID,
+						ToAccountID:   account2.ID,
+						Amount:        amount,
+					})).
+					Times(1).
+					Return(db.TransferTxResult{}, nil)
+			},
+			checkResponse: func(t *testing.T, recoder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recoder.Code)
+			},
+		},
+		{
+			name: "OKByRecipient",
+			body: gin.H{
+				"from_account_id": account1.ID,
+				"to_recipient":    user2.Username,
+				"amount":          amount,
+				"currency":        utils.USD,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).MinTimes(1).Return(account1, nil)
+				store.EXPECT().CountRecipientLookupsSince(gomock.Any(), gomock.Any()).Times(1).Return(int64(0), nil)
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user2.Username)).Times(1).Return(user2, nil)
+				store.EXPECT().GetAccountByOwnerCurrency(gomock.Any(), gomock.Eq(db.GetAccountByOwnerCurrencyParams{
+					Owner:    user2.Username,
+					Currency: utils.USD,
+				})).Times(1).Return(account2, nil)
+				store.EXPECT().CreateRecipientLookup(gomock.Any(), gomock.Any()).Times(1)
+				store.EXPECT().
+					TransferTx(gomock.Any(), gomock.Eq(db.TransferTxParams{
+						FromAccountID: account1.ID,
+						ToAccountID:   account2.ID,
+						Amount:        amount,
+					})).
+					Times(1).
+					Return(db.TransferTxResult{}, nil)
+			},
+			checkResponse: func(t *testing.T, recoder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recoder.Code)
+			},
+		},
+		{
+			name: "OKByAccountNumber",
+			body: gin.H{
+				"from_account_id":   account1.ID,
+				"to_account_number": account2.AccountNumber,
+				"amount":            amount,
+				"currency":          utils.USD,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).MinTimes(1).Return(account1, nil)
+				store.EXPECT().GetAccountByNumber(gomock.Any(), gomock.Eq(account2.AccountNumber)).Times(1).Return(account2, nil)
+				store.EXPECT().
+					TransferTx(gomock.Any(), gomock.Eq(db.TransferTxParams{
+						FromAccountID: account1.ID,
+						ToAccountID:   account2.ID,
+						Amount:        amount,
+					})).
+					Times(1).
+					Return(db.TransferTxResult{}, nil)
+			},
+			checkResponse: func(t *testing.T, recoder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recoder.Code)
+			},
+		},
+		{
+			name: "OKWithFee",
+			body: gin.H{
+				"from_account_id": account1.ID,
+				"to_account_id":   account2.ID,
+				"amount":          amount,
+				"currency":        utils.USD,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).MinTimes(1).Return(account1, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account2.ID)).Times(1).Return(account2, nil)
+				stubFeeSchedule(store, user1.Username, false)
+				store.EXPECT().GetAccountByOwnerProduct(gomock.Any(), gomock.Eq(db.GetAccountByOwnerProductParams{
+					Owner:    bank.Username,
+					Currency: utils.USD,
+					Product:  bank.ProductFeeRevenue,
+				})).Times(1).Return(feeRevenue, nil)
+				store.EXPECT().
+					TransferTx(gomock.Any(), gomock.Eq(db.TransferTxParams{
+						FromAccountID: account1.ID,
+						ToAccountID:   account2.ID,
+						Amount:        amount,
+						Fee:           25,
+						FeeAccountID:  feeRevenue.ID,
+					})).
+					Times(1).
+					Return(db.TransferTxResult{}, nil)
+			},
+			checkResponse: func(t *testing.T, recoder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recoder.Code)
+
+				var res rest.TransferTxRes
+				require.NoError(t, json.Unmarshal(recoder.Body.Bytes(), &res))
+				require.Equal(t, int64(25), res.FeeDetails.Fee)
+				require.Equal(t, amount+25, res.FeeDetails.Total)
+			},
+		},
+		{
+			name: "FeeWaived",
+			body: gin.H{
+				"from_account_id": account1.ID,
+				"to_account_id":   account2.ID,
+				"amount":          amount,
+				"currency":        utils.USD,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).MinTimes(1).Return(account1, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account2.ID)).Times(1).Return(account2, nil)
+				stubFeeSchedule(store, user1.Username, true)
+				store.EXPECT().GetAccountByOwnerProduct(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().
+					TransferTx(gomock.Any(), gomock.Eq(db.TransferTxParams{
+						FromAccountID: account1.ID,
+						ToAccountID:   account2.ID,
+						Amount:        amount,
+					})).
+					Times(1).
+					Return(db.TransferTxResult{}, nil)
+			},
+			checkResponse: func(t *testing.T, recoder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recoder.Code)
+
+				var res rest.TransferTxRes
+				require.NoError(t, json.Unmarshal(recoder.Body.Bytes(), &res))
+				require.True(t, res.FeeDetails.Waived)
+				require.Zero(t, res.FeeDetails.Fee)
+				require.Equal(t, int64(25), res.FeeDetails.FlatFee)
+			},
+		},
+		{
+			name: "AccountNumberTypo",
+			body: gin.H{
+				"from_account_id":   account1.ID,
+				"to_account_number": typoAccountNumber(account2.AccountNumber),
+				"amount":            amount,
+				"currency":          utils.USD,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).MinTimes(1).Return(account1, nil)
+				store.EXPECT().GetAccountByNumber(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recoder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recoder.Code)
+				problem := requireBodyMatchProblem(t, recoder, domain.ErrInvalidArgument)
+				require.Equal(t, "to_account_number", problem.InvalidParams[0].Field)
+			},
+		},
+		{
+			name: "CoolingOffLimit",
+			body: gin.H{
+				"from_account_id": account1.ID,
+				"beneficiary_id":  7,
+				"amount":          amount,
+				"currency":        utils.USD,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).MinTimes(1).Return(account1, nil)
+				store.EXPECT().GetBeneficiary(gomock.Any(), gomock.Eq(int64(7))).Times(1).Return(db.Beneficiary{
+					ID:            7,
+					Owner:         user1.Username,
+					AccountNumber: account2.AccountNumber,
+					Currency:      utils.USD,
+					IsVerified:    true,
+					CreatedAt:     time.Now(),
+				}, nil)
+				store.EXPECT().GetAccountByNumber(gomock.Any(), gomock.Eq(account2.AccountNumber)).Times(1).Return(account2, nil)
+				store.EXPECT().SumTransfersToAccountSince(gomock.Any(), gomock.Any()).Times(1).Return(int64(100000), nil)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recoder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnprocessableEntity, recoder.Code)
+				requireBodyMatchProblem(t, recoder, domain.ErrCoolingOffLimit)
+			},
+		},
+		{
+			name: "BothDestinations",
+			body: gin.H{
+				"from_account_id": account1.ID,
+				"to_account_id":   account2.ID,
+				"to_recipient":    user2.Username,
+				"amount":          amount,
+				"currency":        utils.USD,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recoder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recoder.Code)
+				problem := requireBodyMatchProblem(t, recoder, domain.ErrInvalidArgument)
+				require.Equal(t, "to_account_id", problem.InvalidParams[0].Field)
+			},
+		},
+		{
+			name: "InsufficientFunds",
+			body: gin.H{
+				"from_account_id": account1.ID,
+				"to_account_id":   account2.ID,
+				"amount":          amount,
+				"currency":        utils.USD,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).MinTimes(1).Return(account1, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account2.ID)).Times(1).Return(account2, nil)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(1).Return(db.TransferTxResult{}, domain.ErrInsufficientFunds)
+			},
+			checkResponse: func(t *testing.T, recoder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnprocessableEntity, recoder.Code)
+				requireBodyMatchProblem(t, recoder, domain.ErrInsufficientFunds)
+			},
+		},
+		{
+			name: "CurrencyMismatch",
+			body: gin.H{
+				"from_account_id": account1.ID,
+				"to_account_id":   account2.ID,
+				"amount":          amount,
+				"currency":        utils.EUR,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recoder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recoder.Code)
+				requireBodyMatchProblem(t, recoder, domain.ErrCurrencyMismatch)
+			},
+		},
+		{
+			name: "OKWithMemo",
+			body: gin.H{
+				"from_account_id":    account1.ID,
+				"to_account_id":      account2.ID,
+				"amount":             amount,
+				"currency":           utils.USD,
+				"description":        " March rent ",
+				"external_reference": "INV-2024/03",
+				"merchant_category":  "6513",
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).MinTimes(1).Return(account1, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account2.ID)).Times(1).Return(account2, nil)
+				store.EXPECT().
+					TransferTx(gomock.Any(), gomock.Eq(db.TransferTxParams{
+						FromAccountID:     account1.ID,
+						ToAccountID:       account2.ID,
+						Amount:            amount,
+						Description:       "March rent",
+						ExternalReference: "INV-2024/03",
+						MerchantCategory:  "6513",
+					})).
+					Times(1).
+					Return(db.TransferTxResult{}, nil)
+			},
+			checkResponse: func(t *testing.T, recoder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recoder.Code)
+			},
+		},
+		{
+			name: "InvalidMemo",
+			body: gin.H{
+				"from_account_id":    account1.ID,
+				"to_account_id":      account2.ID,
+				"amount":             amount,
+				"currency":           utils.USD,
+				"external_reference": "INV#3",
+				"merchant_category":  "65",
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recoder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recoder.Code)
+				problem := requireBodyMatchProblem(t, recoder, domain.ErrInvalidArgument)
+				require.Len(t, problem.InvalidParams, 2)
+				require.Equal(t, "external_reference", problem.InvalidParams[0].Field)
+				require.Equal(t, "merchant_category", problem.InvalidParams[1].Field)
+			},
+		},
+		{
+			name: "InvalidAmount",
+			body: gin.H{
+				"from_account_id": account1.ID,
+				"to_account_id":   account2.ID,
+				"amount":          -amount,
+				"currency":        utils.USD,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recoder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recoder.Code)
+				problem := requireBodyMatchProblem(t, recoder, domain.ErrInvalidArgument)
+				require.Len(t, problem.InvalidParams, 1)
+				require.Equal(t, "amount", problem.InvalidParams[0].Field)
+			},
+		},
+		{
+			name: "OKDecimalAmount",
+			body: gin.H{
+				"from_account_id": account1.ID,
+				"to_account_id":   account2.ID,
+				"amount":          "0.10",
+				"currency":        utils.USD,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).MinTimes(1).Return(account1, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account2.ID)).Times(1).Return(account2, nil)
+				store.EXPECT().
+					TransferTx(gomock.Any(), gomock.Eq(db.TransferTxParams{
+						FromAccountID: account1.ID,
+						ToAccountID:   account2.ID,
+						Amount:        amount,
+					})).
+					Times(1).
+					Return(db.TransferTxResult{}, nil)
+			},
+			checkResponse: func(t *testing.T, recoder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recoder.Code)
+
+				var res rest.TransferTxRes
+				require.NoError(t, json.Unmarshal(recoder.Body.Bytes(), &res))
+				require.Equal(t, "0.10", res.FeeDetails.AmountDecimal)
+				require.Equal(t, "0.10", res.FeeDetails.TotalDecimal)
+			},
+		},
+		{
+			name: "TooManyDecimals",
+			body: gin.H{
+				"from_account_id": account1.ID,
+				"to_account_id":   account2.ID,
+				"amount":          "0.105",
+				"currency":        utils.USD,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recoder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recoder.Code)
+				problem := requireBodyMatchProblem(t, recoder, domain.ErrInvalidArgument)
+				require.Len(t, problem.InvalidParams, 1)
+				require.Equal(t, "amount", problem.InvalidParams[0].Field)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+			paymenttest.StubNoFees(store)
+			stubNoFraud(store)
+			stubNoKYCLimits(store)
+
+			server := newTestServer(t, store)
+			recoder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			request, err := http.NewRequest(http.MethodPost, "/v1/transfers", bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.TokenMaker, rest.AuthorizationTypeBearer, user1.Username, time.Minute)
+
+			server.Router.ServeHTTP(recoder, request)
+			tc.checkResponse(t, recoder)
+		})
+	}
+}
+
+func TestQuoteTransferAPI(t *testing.T) {
+	user, _ := randomUser(t)
+
+	testCases := []struct {
+		name          string
+		query         string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recoder *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "OK",
+			query: "amount=20000&currency=USD",
+			buildStubs: func(store *mockdb.MockStore) {
+				stubFeeSchedule(store, user.Username, false)
+			},
+			checkResponse: func(t *testing.T, recoder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recoder.Code)
+
+				var quote fee.Quote
+				require.NoError(t, json.Unmarshal(recoder.Body.Bytes(), &quote))
+				require.Equal(t, int64(100), quote.PercentageFee)
+				require.Equal(t, int64(100), quote.Fee)
+				require.Equal(t, int64(20100), quote.Total)
+				require.Equal(t, "standard", quote.Tier)
+			},
+		},
+		{
+			name:  "Decimal",
+			query: "amount=200.00&currency=USD",
+			buildStubs: func(store *mockdb.MockStore) {
+				stubFeeSchedule(store, user.Username, false)
+			},
+			checkResponse: func(t *testing.T, recoder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recoder.Code)
+
+				var quote fee.Quote
+				require.NoError(t, json.Unmarshal(recoder.Body.Bytes(), &quote))
+				require.Equal(t, int64(20000), quote.Amount)
+				require.Equal(t, int64(20100), quote.Total)
+			},
+		},
+		{
+			name:  "TooManyDecimals",
+			query: "amount=200.001&currency=USD",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUserTier(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recoder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recoder.Code)
+				problem := requireBodyMatchProblem(t, recoder, domain.ErrInvalidArgument)
+				require.Equal(t, "amount", problem.InvalidParams[0].Field)
+			},
+		},
+		{
+			name:  "NoSchedule",
+			query: "amount=20000&currency=EUR",
+			buildStubs: func(store *mockdb.MockStore) {
+				paymenttest.StubNoFees(store)
+			},
+			checkResponse: func(t *testing.T, recoder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recoder.Code)
+
+				var quote fee.Quote
+				require.NoError(t, json.Unmarshal(recoder.Body.Bytes(), &quote))
+				require.Zero(t, quote.Fee)
+				require.Equal(t, int64(20000), quote.Total)
+			},
+		},
+		{
+			name:  "InvalidAmount",
+			query: "amount=0&currency=USD",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUserTier(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recoder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recoder.Code)
+				problem := requireBodyMatchProblem(t, recoder, domain.ErrInvalidArgument)
+				require.Equal(t, "amount", problem.InvalidParams[0].Field)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recoder := httptest.NewRecorder()
+
+			request, err := http.NewRequest(http.MethodGet, "/v1/transfers/quote?"+tc.query, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.TokenMaker, rest.AuthorizationTypeBearer, user.Username, time.Minute)
+
+			server.Router.ServeHTTP(recoder, request)
+			tc.checkResponse(t, recoder)
+		})
+	}
+}
+
+// stubFeeSchedule charges username 25 plus 0.5% on USD transfers up to 10000
+// and 0.5% above, unless waived is set.
+func stubFeeSchedule(store *mockdb.MockStore, username string, waived bool) {
+	tier := db.UserTier{Code: "standard", Name: "Standard"}
+	if waived {
+		tier = db.UserTier{Code: "premium", Name: "Premium", FeesWaived: true}
+	}
+
+	schedule := db.FeeSchedule{ID: 3, Currency: utils.USD}
+	store.EXPECT().GetUserTier(gomock.Any(), gomock.Eq(username)).Times(1).Return(tier, nil)
+	store.EXPECT().GetFeeSchedule(gomock.Any(), gomock.Eq(utils.USD)).Times(1).Return(schedule, nil)
+	store.EXPECT().ListFeeScheduleBands(gomock.Any(), gomock.Eq(schedule.ID)).Times(1).Return([]db.FeeScheduleBand{
+		{ScheduleID: schedule.ID, UpTo: sql.NullInt64{Int64: 10000, Valid: true}, FlatFee: 25},
+		{ScheduleID: schedule.ID, RatePpm: 5000},
+	}, nil)
+}
+
+// stubNoFraud lets every transfer through the fraud rules: the sender made
+// no recent transfers, has paid every account before and has no sessions.
+func stubNoFraud(store *mockdb.MockStore) {
+	store.EXPECT().CountRecentTransfers(gomock.Any(), gomock.Any()).AnyTimes().Return(db.CountRecentTransfersRow{}, nil)
+	store.EXPECT().HasTransferredTo(gomock.Any(), gomock.Any()).AnyTimes().Return(true, nil)
+	store.EXPECT().CountSessionsFromClientIP(gomock.Any(), gomock.Any()).AnyTimes().Return(db.CountSessionsFromClientIPRow{}, nil)
+	store.EXPECT().
+		CreateFraudDecision(gomock.Any(), gomock.Any()).
+		AnyTimes().
+		DoAndReturn(func(_ any, arg db.CreateFraudDecisionParams) (db.FraudDecision, error) {
+			return db.FraudDecision{
+				ID:            1,
+				Username:      arg.Username,
+				FromAccountID: arg.FromAccountID,
+				ToAccountID:   arg.ToAccountID,
+				Amount:        arg.Amount,
+				Currency:      arg.Currency,
+				Score:         arg.Score,
+				Outcome:       arg.Outcome,
+				Hits:          arg.Hits,
+				Transfer:      arg.Transfer,
+				Status:        arg.Status,
+				Kind:          arg.Kind,
+				Payload:       arg.Payload,
+			}, nil
+		})
+	store.EXPECT().SetFraudDecisionTransfer(gomock.Any(), gomock.Any()).AnyTimes().Return(nil)
+}
+
+// stubNoKYCLimits puts every user at a verification level without limits.
+func stubNoKYCLimits(store *mockdb.MockStore) {
+	store.EXPECT().GetUserKycTransferLimits(gomock.Any(), gomock.Any()).AnyTimes().Return(db.GetUserKycTransferLimitsRow{Level: 2, Name: "Fully verified"}, nil)
+}
+
+// typoAccountNumber changes the last digit, which the check digits always catch.
+func typoAccountNumber(number string) string {
+	last := number[len(number)-1]
+	return number[:len(number)-1] + string('0'+(last-'0'+1)%10)
+}
@@ -1,11 +1,11 @@
 package rest
 
 import (
-	"database/sql"
 	"fmt"
 	"net/http"
 	"time"
 
+	"github.com/NhutHuyDev/sgbank/internal/domain"
 	"github.com/gin-gonic/gin"
 )
 
@@ -21,45 +21,41 @@ type RenewAccessTokenRes struct {
 func (server *Server) renewTokenHandler(ctx *gin.Context) {
 	var req RenewAccessTokenDTO
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		writeError(ctx, bindingError(err))
 		return
 	}
 
 	refreshPayload, err := server.TokenMaker.VerifyToken(req.RefreshToken)
 	if err != nil {
-		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+		writeError(ctx, fmt.Errorf("%w: %w", domain.ErrUnauthenticated, err))
 		return
 	}
 
 	session, err := server.Store.GetSession(ctx, refreshPayload.ID)
 	if err != nil {
-		if err == sql.ErrNoRows {
-			ctx.JSON(http.StatusNotFound, errorResponse(err))
-			return
-		}
-
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		writeError(ctx, err)
 		return
 	}
 
 	if session.IsBlocked {
-		err = fmt.Errorf("blocked session")
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		writeError(ctx, fmt.Errorf("%w: blocked session", domain.ErrUnauthenticated))
+		return
 	}
 
 	if session.Username != refreshPayload.Username {
-		err = fmt.Errorf("incorrect session user")
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		writeError(ctx, fmt.Errorf("%w: incorrect session user", domain.ErrUnauthenticated))
+		return
 	}
 
 	if session.RefreshToken != req.RefreshToken {
-		err = fmt.Errorf("mismatched session token")
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		writeError(ctx, fmt.Errorf("%w: mismatched session token", domain.ErrUnauthenticated))
+		return
 	}
 
 	refreshToken, refreshPayload, err := server.TokenMaker.CreateToken(session.Username, server.Config.RefreshTokenDuration)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		writeError(ctx, err)
+		return
 	}
 
 	ctx.JSON(http.StatusOK, RenewAccessTokenRes{
@@ -0,0 +1,266 @@
+package rest
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/NhutHuyDev/sgbank/internal/infra/db"
+	"github.com/NhutHuyDev/sgbank/internal/pagination"
+	"github.com/NhutHuyDev/sgbank/internal/token"
+	"github.com/NhutHuyDev/sgbank/internal/transferbatch"
+	"github.com/gin-gonic/gin"
+)
+
+// maxTransferBatchFileSize bounds CSV uploads; a full batch of short rows
+// is far smaller.
+const maxTransferBatchFileSize = 4 << 20
+
+type transferBatchParamsDTO struct {
+	FromAccountID int64  `json:"from_account_id" form:"from_account_id" binding:"required,min=1"`
+	Currency      string `json:"currency" form:"currency" binding:"required,currency"`
+	Mode          string `json:"mode" form:"mode" binding:"required,oneof=all_or_nothing best_effort"`
+}
+
+type createTransferBatchDTO struct {
+	transferBatchParamsDTO
+	Rows []transferbatch.Row `json:"rows" binding:"required"`
+}
+
+type getTransferBatchDTO struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+type listTransferBatchesDTO struct {
+	PageSize int32  `form:"page_size" binding:"omitempty,min=1,max=100"`
+	Cursor   string `form:"cursor"`
+}
+
+type TransferBatchRes struct {
+	ID            int64      `json:"id"`
+	FromAccountID int64      `json:"from_account_id"`
+	Currency      string     `json:"currency"`
+	Mode          string     `json:"mode"`
+	Status        string     `json:"status"`
+	TotalRows     int32      `json:"total_rows"`
+	TotalAmount   int64      `json:"total_amount"`
+	ProcessedRows int32      `json:"processed_rows"`
+	SucceededRows int32      `json:"succeeded_rows"`
+	FailedRows    int32      `json:"failed_rows"`
+	HeldRows      int32      `json:"held_rows"`
+	Error         string     `json:"error,omitempty"`
+	Results       string     `json:"results,omitempty"`
+	CompletedAt   *time.Time `json:"completed_at,omitempty"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+	CreatedAt     time.Time  `json:"created_at"`
+}
+
+// TransferBatchItemRes is one row of a batch.
+type TransferBatchItemRes struct {
+	ID          int64     `json:"id"`
+	BatchID     int64     `json:"batch_id"`
+	RowNumber   int32     `json:"row_number"`
+	ToAccountID int64     `json:"to_account_id"`
+	Amount      int64     `json:"amount"`
+	Reference   string    `json:"reference"`
+	Status      string    `json:"status"`
+	TransferID  *int64    `json:"transfer_id,omitempty"`
+	Error       string    `json:"error,omitempty"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+func transferBatchItemRes(item db.TransferBatchItem) TransferBatchItemRes {
+	res := TransferBatchItemRes{
+		ID:          item.ID,
+		BatchID:     item.BatchID,
+		RowNumber:   item.RowNumber,
+		ToAccountID: item.ToAccountID,
+		Amount:      item.Amount,
+		Reference:   item.Reference,
+		Status:      item.Status,
+		Error:       item.Error,
+		UpdatedAt:   item.UpdatedAt,
+	}
+
+	if item.TransferID.Valid {
+		res.TransferID = &item.TransferID.Int64
+	}
+
+	return res
+}
+
+type ListTransferBatchesRes struct {
+	TransferBatches []TransferBatchRes `json:"transfer_batches"`
+	NextCursor      string             `json:"next_cursor,omitempty"`
+}
+
+// transferBatchRes converts batch, linking the results file once the batch
+// has finished.
+func transferBatchRes(batch db.TransferBatch) TransferBatchRes {
+	res := TransferBatchRes{
+		ID:            batch.ID,
+		FromAccountID: batch.FromAccountID,
+		Currency:      batch.Currency,
+		Mode:          batch.Mode,
+		Status:        batch.Status,
+		TotalRows:     batch.TotalRows,
+		TotalAmount:   batch.TotalAmount,
+		ProcessedRows: batch.ProcessedRows,
+		SucceededRows: batch.SucceededRows,
+		FailedRows:    batch.FailedRows,
+		HeldRows:      batch.HeldRows,
+		Error:         batch.Error,
+		UpdatedAt:     batch.UpdatedAt,
+		CreatedAt:     batch.CreatedAt,
+	}
+
+	if batch.CompletedAt.Valid {
+		res.CompletedAt = &batch.CompletedAt.Time
+	}
+
+	if transferbatch.IsFinished(batch) {
+		res.Results = fmt.Sprintf("/v1/transfer-batches/%d/results", batch.ID)
+	}
+
+	return res
+}
+
+// CreateTransferBatch godoc
+// @Summary      Queue a batch of transfers
+// @Description  Send a JSON body, or a CSV file with Content-Type text/csv and from_account_id, currency and mode as query parameters. Every row is validated before the batch is queued; invalid rows are reported as rows[n] with n counted from 1.
+// @Tags         transfers
+// @Accept       json,text/csv
+// @Produce      json
+// @Param        request  body      createTransferBatchDTO  true  "Transfer batch"
+// @Success      202  {object}  TransferBatchRes
+// @Failure      400  {object}  domain.Problem "Invalid rows"
+// @Failure      403  {object}  domain.Problem "Account does not belong to user"
+// @Failure      422  {object}  domain.Problem "Insufficient funds for an all-or-nothing batch"
+// @Security     BearerAuth
+// @Router       /v1/transfer-batches [post]
+func (server *Server) createTransferBatchHandler(ctx *gin.Context) {
+	var req createTransferBatchDTO
+
+	if ctx.ContentType() == "text/csv" {
+		if err := ctx.ShouldBindQuery(&req.transferBatchParamsDTO); err != nil {
+			writeError(ctx, bindingError(err))
+			return
+		}
+
+		rows, err := transferbatch.ParseCSV(http.MaxBytesReader(ctx.Writer, ctx.Request.Body, maxTransferBatchFileSize))
+		if err != nil {
+			writeError(ctx, err)
+			return
+		}
+		req.Rows = rows
+	} else if err := ctx.ShouldBindJSON(&req); err != nil {
+		writeError(ctx, bindingError(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(AuthorizationPayloadKey).(*token.Payload)
+
+	batch, err := server.TransferBatches.Create(ctx, transferbatch.CreateParams{
+		Owner:         authPayload.Username,
+		FromAccountID: req.FromAccountID,
+		Currency:      req.Currency,
+		Mode:          transferbatch.Mode(req.Mode),
+		Rows:          req.Rows,
+		ClientIP:      ctx.ClientIP(),
+	})
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusAccepted, transferBatchRes(batch))
+}
+
+// getTransferBatchHandler reports the progress of a batch.
+func (server *Server) getTransferBatchHandler(ctx *gin.Context) {
+	var req getTransferBatchDTO
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		writeError(ctx, bindingError(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(AuthorizationPayloadKey).(*token.Payload)
+
+	batch, err := server.TransferBatches.Get(ctx, authPayload.Username, req.ID)
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, transferBatchRes(batch))
+}
+
+// getTransferBatchResultsHandler downloads the results file of a finished
+// batch as CSV.
+func (server *Server) getTransferBatchResultsHandler(ctx *gin.Context) {
+	var req getTransferBatchDTO
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		writeError(ctx, bindingError(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(AuthorizationPayloadKey).(*token.Payload)
+
+	batch, items, err := server.TransferBatches.Results(ctx, authPayload.Username, req.ID)
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	ctx.Header("Content-Type", "text/csv")
+	ctx.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="transfer-batch-%d-results.csv"`, batch.ID))
+	ctx.Status(http.StatusOK)
+
+	if err := transferbatch.WriteResults(ctx.Writer, items); err != nil {
+		_ = ctx.Error(err)
+	}
+}
+
+// listTransferBatchesHandler returns the user's batches, newest first.
+func (server *Server) listTransferBatchesHandler(ctx *gin.Context) {
+	var req listTransferBatchesDTO
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		writeError(ctx, bindingError(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(AuthorizationPayloadKey).(*token.Payload)
+	page, err := server.Paginator.Page(req.PageSize, string(db.SortDesc), req.Cursor, "transfer_batches|"+authPayload.Username)
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	arg := db.ListTransferBatchesBeforeParams{
+		Owner:     authPayload.Username,
+		PageLimit: page.Limit(),
+	}
+	if page.After != nil {
+		arg.BeforeID = page.After.ID
+	}
+
+	batches, err := server.Store.ListTransferBatchesBefore(ctx, arg)
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	batches, nextCursor := pagination.Trim(server.Paginator, page, batches, func(batch db.TransferBatch) pagination.Key {
+		return pagination.Key{ID: batch.ID}
+	})
+
+	res := ListTransferBatchesRes{
+		TransferBatches: make([]TransferBatchRes, 0, len(batches)),
+		NextCursor:      nextCursor,
+	}
+	for _, batch := range batches {
+		res.TransferBatches = append(res.TransferBatches, transferBatchRes(batch))
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
@@ -1,29 +1,83 @@
 package rest
 
 import (
-	"database/sql"
-	"errors"
 	"fmt"
 	"net/http"
 
+	"github.com/NhutHuyDev/sgbank/internal/domain"
+	"github.com/NhutHuyDev/sgbank/internal/fee"
 	"github.com/NhutHuyDev/sgbank/internal/infra/db"
+	"github.com/NhutHuyDev/sgbank/internal/logging"
+	"github.com/NhutHuyDev/sgbank/internal/memo"
+	"github.com/NhutHuyDev/sgbank/internal/money"
+	"github.com/NhutHuyDev/sgbank/internal/payment"
+	"github.com/NhutHuyDev/sgbank/internal/recipient"
 	"github.com/NhutHuyDev/sgbank/internal/token"
 	"github.com/gin-gonic/gin"
 )
 
 type transferDTO struct {
-	FromAccountID int64  `json:"from_account_id" binding:"required,min=1"`
-	ToAccountID   int64  `json:"to_account_id" binding:"required,min=1"`
-	Amount        int64  `json:"amount" binding:"required,gt=0"`
-	Currency      string `json:"currency" binding:"required,currency"`
+	FromAccountID   int64       `json:"from_account_id" binding:"required,min=1"`
+	ToAccountID     int64       `json:"to_account_id" binding:"required_without_all=ToAccountNumber ToRecipient BeneficiaryID,excluded_with=ToAccountNumber ToRecipient BeneficiaryID,omitempty,min=1"`
+	ToAccountNumber string      `json:"to_account_number" binding:"excluded_with=ToRecipient BeneficiaryID,omitempty,max=64"`
+	ToRecipient     string      `json:"to_recipient" binding:"excluded_with=BeneficiaryID,omitempty,max=200"`
+	BeneficiaryID   int64       `json:"beneficiary_id" binding:"omitempty,min=1"`
+	Amount          money.Input `json:"amount" swaggertype:"string" example:"12.50"` // minor units, or a decimal string in major units
+	Currency        string      `json:"currency" binding:"required,currency"`
+	memo.Fields
+}
+
+type TransferTxRes struct {
+	db.TransferTxResult
+	FeeDetails      QuoteRes `json:"fee_details"`
+	FraudDecisionID int64    `json:"fraud_decision_id"`
+}
+
+// HeldTransferRes is a transfer the fraud rules held for review. It is
+// posted once an admin approves it.
+type HeldTransferRes struct {
+	FraudDecision FraudDecisionRes `json:"fraud_decision"`
+	FeeDetails    QuoteRes         `json:"fee_details"`
+}
+
+// QuoteRes is a fee quote with its amounts also formatted as decimals in
+// major units of the currency.
+type QuoteRes struct {
+	fee.Quote
+	AmountDecimal string `json:"amount_decimal"`
+	FeeDecimal    string `json:"fee_decimal"`
+	TotalDecimal  string `json:"total_decimal"`
+}
+
+func (server *Server) quoteRes(quote fee.Quote) QuoteRes {
+	registry := server.Currencies.Registry()
+	return QuoteRes{
+		Quote:         quote,
+		AmountDecimal: registry.Format(quote.Amount, quote.Currency),
+		FeeDecimal:    registry.Format(quote.Fee, quote.Currency),
+		TotalDecimal:  registry.Format(quote.Total, quote.Currency),
+	}
 }
 
 func (server *Server) transferHandler(ctx *gin.Context) {
 	var req transferDTO
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		writeError(ctx, bindingError(err))
 		return
 	}
+
+	amount, err := server.positiveAmount(req.Amount, req.Currency)
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	req.Fields = req.Fields.Normalize()
+	if violations := req.Fields.Validate(""); violations != nil {
+		writeError(ctx, domain.NewValidationError(violations...))
+		return
+	}
+
 	fromAccount, valid := server.isValidAccount(ctx, req.FromAccountID, req.Currency)
 	if !valid {
 		return
@@ -31,8 +85,8 @@ func (server *Server) transferHandler(ctx *gin.Context) {
 
 	authPayload := ctx.MustGet(AuthorizationPayloadKey).(*token.Payload)
 	if authPayload.Username != fromAccount.Owner {
-		err := errors.New("from account doestn't belong to the authenticated user")
-		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+		err := fmt.Errorf("%w: from account doesn't belong to the authenticated user", domain.ErrForbidden)
+		writeError(ctx, err)
 		return
 	}
 
@@ -41,53 +95,135 @@ func (server *Server) transferHandler(ctx *gin.Context) {
 		return
 	}
 
-	toAccount, err := server.Store.GetAccount(ctx, req.ToAccountID)
+	toAccount, err := server.toAccount(ctx, req, amount, authPayload.Username)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		writeError(ctx, err)
 		return
 	}
 
 	if toAccount.Currency != req.Currency {
-		err := fmt.Errorf("currency of from_account and to_account mismatch: %s vs %s", req.Currency, toAccount.Currency)
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		err := fmt.Errorf("%w: from_account and to_account: %s vs %s", domain.ErrCurrencyMismatch, req.Currency, toAccount.Currency)
+		writeError(ctx, err)
 		return
 	}
 
 	arg := db.TransferTxParams{
-		FromAccountID: req.FromAccountID,
-		ToAccountID:   req.ToAccountID,
-		Amount:        req.Amount,
+		FromAccountID:     req.FromAccountID,
+		ToAccountID:       toAccount.ID,
+		Amount:            amount,
+		Description:       req.Description,
+		ExternalReference: req.ExternalReference,
+		MerchantCategory:  req.MerchantCategory,
+	}
+
+	result, err := server.Payments.Transfer(ctx, payment.Payment{
+		Username:  authPayload.Username,
+		ClientIP:  ctx.ClientIP(),
+		Currency:  req.Currency,
+		Params:    arg,
+		ToAccount: toAccount,
+	})
+	if err != nil {
+		logging.FromContext(ctx).Warn().Err(err).
+			Int64("from_account_id", req.FromAccountID).
+			Int64("to_account_id", toAccount.ID).
+			Msg("transfer failed")
+		writeError(ctx, err)
+		return
+	}
+
+	if result.Held() {
+		ctx.JSON(http.StatusAccepted, HeldTransferRes{
+			FraudDecision: server.fraudDecisionRes(result.Decision),
+			FeeDetails:    server.quoteRes(result.Fee),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, TransferTxRes{
+		TransferTxResult: *result.Transfer,
+		FeeDetails:       server.quoteRes(result.Fee),
+		FraudDecisionID:  result.Decision.ID,
+	})
+}
+
+type quoteTransferDTO struct {
+	Amount   string `form:"amount"` // minor units, or a decimal in major units
+	Currency string `form:"currency" binding:"required,currency"`
+}
+
+// QuoteTransfer godoc
+// @Summary      Quote a transfer fee
+// @Description  Return the fee the authenticated user pays on top of a transfer of amount in currency, and its breakdown. The band of the currency's fee schedule containing the amount charges its flat fee plus its percentage of the whole amount, within the schedule's minimum and maximum. Fees are waived for premium users.
+// @Tags         transfers
+// @Produce      json
+// @Param        amount    query     string  true  "Amount to transfer: a whole number of minor units, or a decimal in major units such as 12.50"
+// @Param        currency  query     string  true  "Currency of the transfer"
+// @Success      200  {object}  QuoteRes
+// @Failure      400  {object}  domain.Problem "Invalid parameters"
+// @Failure      401  {object}  domain.Problem "Unauthenticated"
+// @Security     BearerAuth
+// @Router       /v1/transfers/quote [get]
+func (server *Server) quoteTransferHandler(ctx *gin.Context) {
+	var req quoteTransferDTO
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		writeError(ctx, bindingError(err))
+		return
 	}
 
-	result, err := server.Store.TransferTx(ctx, arg)
+	amount, err := server.positiveAmount(money.QueryInput(req.Amount), req.Currency)
 	if err != nil {
-		fmt.Println(err)
-		if err.Error() == "the balance of the from account is insufficient" {
-			ctx.JSON(http.StatusBadRequest, errorResponse(err))
-			return
-		}
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		writeError(ctx, err)
 		return
 	}
 
-	ctx.JSON(http.StatusOK, result)
+	authPayload := ctx.MustGet(AuthorizationPayloadKey).(*token.Payload)
+
+	quote, err := server.Fees.Quote(ctx, authPayload.Username, req.Currency, amount)
+	if err != nil {
+		writeError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, server.quoteRes(quote))
+}
+
+// toAccount resolves the destination of req: by account ID, by account
+// number, by saved beneficiary, or through the recipient resolver when paying
+// a username or email.
+func (server *Server) toAccount(ctx *gin.Context, req transferDTO, amount int64, requester string) (db.Account, error) {
+	if req.BeneficiaryID != 0 {
+		return server.Beneficiaries.ResolveTransfer(ctx, requester, req.BeneficiaryID, req.Currency, amount)
+	}
+
+	if req.ToAccountNumber != "" {
+		return server.getAccountByRef(ctx, "to_account_number", req.ToAccountNumber)
+	}
+
+	if req.ToRecipient == "" {
+		return server.Store.GetAccount(ctx, req.ToAccountID)
+	}
+
+	found, err := server.Recipients.Resolve(ctx, recipient.Request{
+		Requester:  requester,
+		Identifier: req.ToRecipient,
+		Currency:   req.Currency,
+		Purpose:    recipient.PurposeTransfer,
+		ClientIP:   ctx.ClientIP(),
+	})
+	return found.Account, err
 }
 
 func (server *Server) isValidAccount(ctx *gin.Context, accountID int64, currency string) (db.Account, bool) {
 	account, err := server.Store.GetAccount(ctx, accountID)
 	if err != nil {
-		if err == sql.ErrNoRows {
-			ctx.JSON(http.StatusNotFound, errorResponse(err))
-			return account, false
-		}
-
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		writeError(ctx, err)
 		return account, false
 	}
 
 	if account.Currency != currency {
-		err := fmt.Errorf("account [%d] currency mismatch: %s vs %s", account.ID, account.Currency, currency)
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		err := fmt.Errorf("%w: account [%d]: %s vs %s", domain.ErrCurrencyMismatch, account.ID, account.Currency, currency)
+		writeError(ctx, err)
 		return account, false
 	}
 
@@ -1,15 +1,15 @@
 package rest
 
 import (
-	"database/sql"
 	"net/http"
 	"time"
 
+	"github.com/NhutHuyDev/sgbank/internal/domain"
 	"github.com/NhutHuyDev/sgbank/internal/infra/db"
+	"github.com/NhutHuyDev/sgbank/internal/screening"
 	"github.com/NhutHuyDev/sgbank/pkg/secure"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
-	"github.com/lib/pq"
 )
 
 type CreateUserDTO struct {
@@ -25,6 +25,7 @@ type UserRes struct {
 	Email             string    `json:"email"`
 	PasswordChangedAt time.Time `json:"password_changed_at"`
 	CreatedAt         time.Time `json:"created_at"`
+	KYCLevel          int32     `json:"kyc_level"`
 }
 
 func castToUserRes(user db.User) UserRes {
@@ -34,19 +35,30 @@ func castToUserRes(user db.User) UserRes {
 		Email:             user.Email,
 		PasswordChangedAt: user.PasswordChangedAt,
 		CreatedAt:         user.CreatedAt,
+		KYCLevel:          user.KycLevel,
 	}
 }
 
 func (server *Server) createUserHandler(ctx *gin.Context) {
 	var req CreateUserDTO
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		writeError(ctx, bindingError(err))
+		return
+	}
+
+	_, err := server.Screening.Screen(ctx, screening.Subject{
+		Kind:     screening.SubjectSignup,
+		Name:     req.Fullname,
+		Username: req.Username,
+	})
+	if err != nil {
+		writeError(ctx, err)
 		return
 	}
 
 	hashedPassword, err := secure.HashPassword(req.Password)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		writeError(ctx, err)
 		return
 	}
 
@@ -59,15 +71,7 @@ func (server *Server) createUserHandler(ctx *gin.Context) {
 
 	user, err := server.Store.CreateUser(ctx, arg)
 	if err != nil {
-		if pqErr, ok := err.(*pq.Error); ok {
-			switch pqErr.Code.Name() {
-			case "unique_violation":
-				ctx.JSON(http.StatusForbidden, errorResponse(err))
-				return
-			}
-		}
-
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		writeError(ctx, err)
 		return
 	}
 
@@ -91,30 +95,26 @@ type SignInRes struct {
 func (server *Server) signInHandler(ctx *gin.Context) {
 	var req SignInDTO
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		writeError(ctx, bindingError(err))
 		return
 	}
 
 	user, err := server.Store.GetUser(ctx, req.Username)
 	if err != nil {
-		if err == sql.ErrNoRows {
-			ctx.JSON(http.StatusNotFound, errorResponse(err))
-			return
-		}
-
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		writeError(ctx, err)
 		return
 	}
 
 	err = secure.CheckPassword(req.Password, user.HashedPassword)
 	if err != nil {
-		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+		writeError(ctx, domain.ErrInvalidCredential)
 		return
 	}
 
 	refreshToken, refreshPayload, err := server.TokenMaker.CreateToken(user.Username, server.Config.AccessTokenDuration)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		writeError(ctx, err)
+		return
 	}
 
 	session, err := server.Store.CreateSession(ctx, db.CreateSessionParams{
@@ -127,12 +127,14 @@ func (server *Server) signInHandler(ctx *gin.Context) {
 		CreatedAt:    refreshPayload.ExpiredAt,
 	})
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		writeError(ctx, err)
+		return
 	}
 
 	accessToken, accessPayload, err := server.TokenMaker.CreateToken(user.Username, server.Config.AccessTokenDuration)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		writeError(ctx, err)
+		return
 	}
 
 	ctx.JSON(http.StatusOK, SignInRes{
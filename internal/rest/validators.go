@@ -1,13 +1,15 @@
 package rest
 
 import (
-	"github.com/NhutHuyDev/sgbank/pkg/utils"
+	"github.com/NhutHuyDev/sgbank/internal/currency"
 	"github.com/go-playground/validator/v10"
 )
 
+// currencyValidator accepts the currencies enabled in the shared registry.
+// Gin's validator is process-wide, so it cannot depend on a Server.
 var currencyValidator validator.Func = func(fieldLevel validator.FieldLevel) bool {
-	if currency, ok := fieldLevel.Field().Interface().(string); ok {
-		return utils.IsSupportedCurrency(currency)
+	if code, ok := fieldLevel.Field().Interface().(string); ok {
+		return currency.Default.IsSupported(code)
 	}
 
 	return false
@@ -0,0 +1,282 @@
+package screening
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Field counts of the OFAC list files: SDN files (sdn.csv, cons_prim.csv)
+// have a row per entry, alternate name files (alt.csv, cons_alt.csv) a row
+// per alias of an entry.
+const (
+	sdnFields = 12
+	altFields = 5
+)
+
+// null is how OFAC files mark an empty field.
+const null = "-0-"
+
+// Entry is a name on a sanctions list. Each alias of a listed party is a
+// separate entry with the ID of the party.
+type Entry struct {
+	ID       string `json:"id"`
+	List     string `json:"list"`
+	Name     string `json:"name"`
+	Type     string `json:"type,omitempty"`
+	Programs string `json:"programs,omitempty"`
+
+	tokens []string
+}
+
+// Match is an entry similar to a screened name.
+type Match struct {
+	Entry Entry   `json:"entry"`
+	Score float64 `json:"score"`
+}
+
+// File is a loaded list file.
+type File struct {
+	Path       string    `json:"path"`
+	Entries    int       `json:"entries"`
+	ModifiedAt time.Time `json:"modified_at"`
+}
+
+// Status describes the lists loaded.
+type Status struct {
+	Files    []File     `json:"files"`
+	Entries  int        `json:"entries"`
+	LoadedAt *time.Time `json:"loaded_at,omitempty"`
+}
+
+// Default is the lists shared by the HTTP and gRPC servers and reloaded by
+// the worker.
+var Default = NewLists()
+
+// Lists is the entries of the sanctions list files. It is safe for
+// concurrent use.
+type Lists struct {
+	mu       sync.RWMutex
+	entries  []Entry
+	files    []File
+	loadedAt time.Time
+}
+
+func NewLists() *Lists {
+	return &Lists{}
+}
+
+// Load replaces the entries with those of the files at paths. When a file
+// cannot be read the lists are left as they were.
+func (lists *Lists) Load(paths []string) error {
+	var entries []Entry
+	files := make([]File, 0, len(paths))
+
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return err
+		}
+
+		fileEntries, err := readFile(path)
+		if err != nil {
+			return err
+		}
+
+		entries = append(entries, fileEntries...)
+		files = append(files, File{Path: path, Entries: len(fileEntries), ModifiedAt: info.ModTime()})
+	}
+
+	inheritDetails(entries)
+
+	lists.mu.Lock()
+	lists.entries = entries
+	lists.files = files
+	lists.loadedAt = time.Now()
+	lists.mu.Unlock()
+
+	return nil
+}
+
+// Changed reports whether the files at paths are not the files loaded or
+// were modified since.
+func (lists *Lists) Changed(paths []string) bool {
+	lists.mu.RLock()
+	defer lists.mu.RUnlock()
+
+	if len(paths) != len(lists.files) {
+		return true
+	}
+
+	for i, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil || path != lists.files[i].Path || !info.ModTime().Equal(lists.files[i].ModifiedAt) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Len returns the number of entries loaded.
+func (lists *Lists) Len() int {
+	lists.mu.RLock()
+	defer lists.mu.RUnlock()
+
+	return len(lists.entries)
+}
+
+func (lists *Lists) Status() Status {
+	lists.mu.RLock()
+	defer lists.mu.RUnlock()
+
+	status := Status{Files: append([]File{}, lists.files...), Entries: len(lists.entries)}
+	if !lists.loadedAt.IsZero() {
+		loadedAt := lists.loadedAt
+		status.LoadedAt = &loadedAt
+	}
+
+	return status
+}
+
+// Search returns the entries whose similarity to name is at least
+// threshold, best first, with only the best scoring alias of each listed
+// party.
+func (lists *Lists) Search(name string, threshold float64) []Match {
+	tokens := Tokens(name)
+	if len(tokens) == 0 {
+		return nil
+	}
+
+	lists.mu.RLock()
+	best := make(map[string]Match)
+	for _, entry := range lists.entries {
+		score := Similarity(tokens, entry.tokens)
+		if score < threshold {
+			continue
+		}
+
+		key := entry.List + "/" + entry.ID
+		if match, ok := best[key]; !ok || score > match.Score {
+			best[key] = Match{Entry: entry, Score: score}
+		}
+	}
+	lists.mu.RUnlock()
+
+	matches := make([]Match, 0, len(best))
+	for _, match := range best {
+		matches = append(matches, match)
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].Score != matches[j].Score {
+			return matches[i].Score > matches[j].Score
+		}
+		return matches[i].Entry.ID < matches[j].Entry.ID
+	})
+
+	return matches
+}
+
+// readFile reads the entries of an OFAC list file. Rows that do not start
+// with an entry number, such as a header or the end of file marker, are
+// skipped.
+func readFile(path string) ([]Entry, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	list := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+	reader.TrimLeadingSpace = true
+
+	var entries []Entry
+	for line := 1; ; line++ {
+		row, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+
+		if !isEntryNumber(row[0]) {
+			continue
+		}
+
+		entry := Entry{ID: strings.TrimSpace(row[0]), List: list}
+		switch len(row) {
+		case sdnFields:
+			entry.Name = field(row[1])
+			entry.Type = field(row[2])
+			entry.Programs = field(row[3])
+		case altFields:
+			entry.Name = field(row[3])
+		default:
+			return nil, fmt.Errorf("%s: line %d: expected %d fields (SDN) or %d (alternate names), got %d", path, line, sdnFields, altFields, len(row))
+		}
+
+		entry.tokens = Tokens(entry.Name)
+		if len(entry.tokens) == 0 {
+			continue
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+// inheritDetails gives aliases the list, type and programs of their party
+// when its SDN file is loaded.
+func inheritDetails(entries []Entry) {
+	parties := make(map[string]Entry)
+	for _, entry := range entries {
+		if entry.Type != "" || entry.Programs != "" {
+			parties[entry.ID] = entry
+		}
+	}
+
+	for i, entry := range entries {
+		if party, ok := parties[entry.ID]; ok && entry.Type == "" && entry.Programs == "" {
+			entries[i].List = party.List
+			entries[i].Type = party.Type
+			entries[i].Programs = party.Programs
+		}
+	}
+}
+
+func isEntryNumber(value string) bool {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return false
+	}
+
+	for _, r := range value {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+
+	return true
+}
+
+func field(value string) string {
+	value = strings.TrimSpace(value)
+	if value == null {
+		return ""
+	}
+
+	return value
+}
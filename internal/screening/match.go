@@ -0,0 +1,150 @@
+package screening
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// stopWords carry no identity and are dropped from names before matching.
+var stopWords = map[string]bool{
+	"the": true, "of": true, "and": true, "mr": true, "mrs": true, "ms": true, "dr": true,
+}
+
+// Tokens normalizes a name into the words it is matched on: accents are
+// removed, letters lowercased, and punctuation and stop words dropped, so
+// "AL-ZAWAHIRI, Ayman" and "Ayman al Zawahiri" have the same tokens.
+func Tokens(name string) []string {
+	var builder strings.Builder
+	for _, r := range norm.NFD.String(name) {
+		switch {
+		case unicode.Is(unicode.Mn, r):
+			// combining accent of the letter before it
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			builder.WriteRune(unicode.ToLower(r))
+		default:
+			builder.WriteRune(' ')
+		}
+	}
+
+	var tokens []string
+	for _, token := range strings.Fields(builder.String()) {
+		if !stopWords[token] {
+			tokens = append(tokens, token)
+		}
+	}
+
+	return tokens
+}
+
+// Key is the normalized form of a name with its words sorted, which
+// identifies it in the whitelist whatever the order of its words.
+func Key(name string) string {
+	tokens := Tokens(name)
+	sort.Strings(tokens)
+	return strings.Join(tokens, " ")
+}
+
+// Similarity scores two tokenized names from 0 to 1. It is the better of
+// the Jaro-Winkler similarity of the names with their words sorted, and of
+// the average similarity of each word to the closest word of the other
+// name, both ways, which tolerates extra middle names and spelling
+// variants such as Usama and Osama.
+func Similarity(a, b []string) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+
+	sortedA := append([]string(nil), a...)
+	sortedB := append([]string(nil), b...)
+	sort.Strings(sortedA)
+	sort.Strings(sortedB)
+
+	score := jaroWinkler(strings.Join(sortedA, " "), strings.Join(sortedB, " "))
+
+	set := (closest(a, b) + closest(b, a)) / 2
+	if set > score {
+		score = set
+	}
+
+	return score
+}
+
+// closest averages, over the words of a, the similarity of the closest word
+// of b.
+func closest(a, b []string) float64 {
+	total := 0.0
+	for _, x := range a {
+		best := 0.0
+		for _, y := range b {
+			if s := jaroWinkler(x, y); s > best {
+				best = s
+			}
+		}
+		total += best
+	}
+
+	return total / float64(len(a))
+}
+
+func jaroWinkler(a, b string) float64 {
+	if a == b {
+		return 1
+	}
+
+	s, t := []rune(a), []rune(b)
+	if len(s) == 0 || len(t) == 0 {
+		return 0
+	}
+
+	window := max(len(s), len(t))/2 - 1
+	if window < 0 {
+		window = 0
+	}
+
+	sMatched := make([]bool, len(s))
+	tMatched := make([]bool, len(t))
+	matches := 0
+
+	for i := range s {
+		for j := max(0, i-window); j < min(len(t), i+window+1); j++ {
+			if tMatched[j] || s[i] != t[j] {
+				continue
+			}
+			sMatched[i], tMatched[j] = true, true
+			matches++
+			break
+		}
+	}
+
+	if matches == 0 {
+		return 0
+	}
+
+	transpositions := 0
+	j := 0
+	for i := range s {
+		if !sMatched[i] {
+			continue
+		}
+		for !tMatched[j] {
+			j++
+		}
+		if s[i] != t[j] {
+			transpositions++
+		}
+		j++
+	}
+
+	m := float64(matches)
+	jaro := (m/float64(len(s)) + m/float64(len(t)) + (m-float64(transpositions)/2)/m) / 3
+
+	prefix := 0
+	for prefix < min(4, len(s), len(t)) && s[prefix] == t[prefix] {
+		prefix++
+	}
+
+	return jaro + float64(prefix)*0.1*(1-jaro)
+}
@@ -0,0 +1,277 @@
+// Package screening checks names against sanctions lists: the full name of
+// a user signing up and of the owner of an account money is sent to. Lists
+// are OFAC CSV files read from disk and reloaded when they change. A name
+// similar enough to an entry is flagged for compliance, and one that is
+// closer still is refused. Every match is stored; a match staff confirmed
+// to be a false positive is whitelisted for that name and entry.
+package screening
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/NhutHuyDev/sgbank/internal/domain"
+	"github.com/NhutHuyDev/sgbank/internal/infra/db"
+	"github.com/NhutHuyDev/sgbank/internal/logging"
+	"github.com/NhutHuyDev/sgbank/pkg/utils"
+)
+
+// Subjects of a screening.
+const (
+	SubjectSignup       = "signup"
+	SubjectCounterparty = "counterparty"
+)
+
+// Actions taken on a match.
+const (
+	ActionFlag  = "flag"
+	ActionBlock = "block"
+)
+
+const (
+	DefaultFlagThreshold  = 0.85
+	DefaultBlockThreshold = 0.95
+)
+
+const (
+	MaxReasonLength = 200
+	PageSize        = 100
+)
+
+// Subject is a name to screen and who it is screened for.
+type Subject struct {
+	Kind string
+	Name string
+	// Username is the user signing up or sending the transfer.
+	Username string
+	// AccountID is the account a transfer is sent to.
+	AccountID int64
+}
+
+type Service struct {
+	store          db.Store
+	lists          *Lists
+	paths          []string
+	flagThreshold  float64
+	blockThreshold float64
+}
+
+func NewService(store db.Store, lists *Lists, paths []string, flagThreshold, blockThreshold float64) *Service {
+	return &Service{
+		store:          store,
+		lists:          lists,
+		paths:          paths,
+		flagThreshold:  flagThreshold,
+		blockThreshold: blockThreshold,
+	}
+}
+
+// FromConfig builds the service on the Default lists from the
+// SANCTIONS_LIST_PATHS, a comma-separated list of files, and the
+// SCREENING_*_THRESHOLD settings, using the default thresholds for those
+// that are not set.
+func FromConfig(store db.Store, config utils.Config) *Service {
+	var paths []string
+	for _, path := range strings.Split(config.SanctionsListPaths, ",") {
+		if path = strings.TrimSpace(path); path != "" {
+			paths = append(paths, path)
+		}
+	}
+
+	flagThreshold := DefaultFlagThreshold
+	if config.ScreeningFlagThreshold > 0 {
+		flagThreshold = config.ScreeningFlagThreshold
+	}
+	blockThreshold := DefaultBlockThreshold
+	if config.ScreeningBlockThreshold > 0 {
+		blockThreshold = config.ScreeningBlockThreshold
+	}
+
+	return NewService(store, Default, paths, flagThreshold, blockThreshold)
+}
+
+// Lists returns the lists the service screens against.
+func (service *Service) Lists() *Lists {
+	return service.lists
+}
+
+// Reload reads the list files again. It fails with ErrSanctionsListInvalid
+// when a file cannot be read, and the lists loaded before stay in use.
+func (service *Service) Reload() (Status, error) {
+	if err := service.lists.Load(service.paths); err != nil {
+		return service.lists.Status(), fmt.Errorf("%w: %v", domain.ErrSanctionsListInvalid, err)
+	}
+
+	return service.lists.Status(), nil
+}
+
+// Refresh reloads the list files when they changed since they were loaded.
+func (service *Service) Refresh() error {
+	if !service.lists.Changed(service.paths) {
+		return nil
+	}
+
+	_, err := service.Reload()
+	return err
+}
+
+// Search returns the entries that would be flagged or blocked for name,
+// whitelisted or not, without storing anything.
+func (service *Service) Search(name string) []Match {
+	return service.lists.Search(name, service.flagThreshold)
+}
+
+// Screen checks the name of a subject against the lists and stores the
+// matches that are not whitelisted. It fails with ErrSanctionsMatch when a
+// match reaches the block threshold.
+func (service *Service) Screen(ctx context.Context, subject Subject) ([]db.ScreeningMatch, error) {
+	matches := service.lists.Search(subject.Name, service.flagThreshold)
+	if len(matches) == 0 {
+		return nil, nil
+	}
+
+	whitelisted, err := service.store.ListWhitelistedEntryIDs(ctx, Key(subject.Name))
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		recorded []db.ScreeningMatch
+		blocked  *Match
+	)
+	for i, match := range matches {
+		if contains(whitelisted, match.Entry.ID) {
+			continue
+		}
+
+		action := ActionFlag
+		if match.Score >= service.blockThreshold {
+			action = ActionBlock
+			if blocked == nil {
+				blocked = &matches[i]
+			}
+		}
+
+		arg := db.CreateScreeningMatchParams{
+			Subject:   subject.Kind,
+			Name:      subject.Name,
+			Username:  subject.Username,
+			List:      match.Entry.List,
+			EntryID:   match.Entry.ID,
+			EntryName: match.Entry.Name,
+			Score:     match.Score,
+			Action:    action,
+		}
+		if subject.AccountID != 0 {
+			arg.AccountID = sql.NullInt64{Int64: subject.AccountID, Valid: true}
+		}
+
+		record, err := service.store.CreateScreeningMatch(ctx, arg)
+		if err != nil {
+			return recorded, err
+		}
+		recorded = append(recorded, record)
+
+		logging.FromContext(ctx).Warn().
+			Str("subject", subject.Kind).
+			Str("username", subject.Username).
+			Str("list", match.Entry.List).
+			Str("entry_id", match.Entry.ID).
+			Float64("score", match.Score).
+			Str("action", action).
+			Msg("sanctions list match")
+	}
+
+	if blocked != nil {
+		return recorded, fmt.Errorf("%w: %s entry %s", domain.ErrSanctionsMatch, blocked.Entry.List, blocked.Entry.ID)
+	}
+
+	return recorded, nil
+}
+
+// ScreenCounterparty screens the owner of the account a user sends money
+// to. The owner is only looked up when lists are loaded.
+func (service *Service) ScreenCounterparty(ctx context.Context, username string, account db.Account) ([]db.ScreeningMatch, error) {
+	if service.lists.Len() == 0 {
+		return nil, nil
+	}
+
+	owner, err := service.store.GetUser(ctx, account.Owner)
+	if err != nil {
+		return nil, err
+	}
+
+	return service.Screen(ctx, Subject{
+		Kind:      SubjectCounterparty,
+		Name:      owner.FullName,
+		Username:  username,
+		AccountID: account.ID,
+	})
+}
+
+// Matches lists stored matches, oldest first, with the action or all of
+// them when action is empty.
+func (service *Service) Matches(ctx context.Context, action string, afterID int64, pageSize int32) ([]db.ScreeningMatch, error) {
+	return service.store.ListScreeningMatches(ctx, db.ListScreeningMatchesParams{
+		AfterID:   afterID,
+		Action:    sql.NullString{String: action, Valid: action != ""},
+		PageLimit: pageSize,
+	})
+}
+
+type WhitelistParams struct {
+	Name      string
+	EntryID   string
+	Reason    string
+	CreatedBy string
+}
+
+// Whitelist stops matching a name against an entry. The name is stored
+// normalized, so it covers the same name written with other accents,
+// punctuation or word order.
+func (service *Service) Whitelist(ctx context.Context, arg WhitelistParams) (db.ScreeningWhitelist, error) {
+	key := Key(arg.Name)
+	arg.EntryID = strings.TrimSpace(arg.EntryID)
+	arg.Reason = strings.TrimSpace(arg.Reason)
+
+	var violations []domain.FieldViolation
+	if key == "" {
+		violations = append(violations, domain.FieldViolation{Field: "name", Description: "must contain letters or digits"})
+	}
+	if !isEntryNumber(arg.EntryID) {
+		violations = append(violations, domain.FieldViolation{Field: "entry_id", Description: "must be an entry number"})
+	}
+	if len(arg.Reason) > MaxReasonLength {
+		violations = append(violations, domain.FieldViolation{Field: "reason", Description: fmt.Sprintf("must not be longer than %d characters", MaxReasonLength)})
+	}
+	if violations != nil {
+		return db.ScreeningWhitelist{}, domain.NewValidationError(violations...)
+	}
+
+	return service.store.CreateScreeningWhitelistEntry(ctx, db.CreateScreeningWhitelistEntryParams{
+		Name:      key,
+		EntryID:   arg.EntryID,
+		Reason:    arg.Reason,
+		CreatedBy: arg.CreatedBy,
+	})
+}
+
+func (service *Service) Whitelisted(ctx context.Context) ([]db.ScreeningWhitelist, error) {
+	return service.store.ListScreeningWhitelist(ctx)
+}
+
+func (service *Service) RemoveFromWhitelist(ctx context.Context, id int64) (db.ScreeningWhitelist, error) {
+	return service.store.DeleteScreeningWhitelistEntry(ctx, id)
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
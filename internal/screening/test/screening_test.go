@@ -0,0 +1,233 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/NhutHuyDev/sgbank/internal/domain"
+	"github.com/NhutHuyDev/sgbank/internal/infra/db"
+	mockdb "github.com/NhutHuyDev/sgbank/internal/infra/db/mock"
+	"github.com/NhutHuyDev/sgbank/internal/screening"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+var testPaths = []string{"testdata/sdn.csv", "testdata/alt.csv"}
+
+func loadLists(t *testing.T) *screening.Lists {
+	lists := screening.NewLists()
+	require.NoError(t, lists.Load(testPaths))
+	return lists
+}
+
+func TestTokens(t *testing.T) {
+	require.Equal(t, []string{"al", "zawahiri", "ayman"}, screening.Tokens("AL-ZAWAHIRI, Ayman"))
+	require.Equal(t, []string{"munoz", "garcia", "jose"}, screening.Tokens("MUÑOZ GARCÍA, José"))
+	require.Equal(t, []string{"smith"}, screening.Tokens("Mr. Smith"))
+	require.Empty(t, screening.Tokens(" -, "))
+
+	require.Equal(t, screening.Key("José Muñoz"), screening.Key("MUNOZ, Jose"))
+}
+
+func TestSimilarity(t *testing.T) {
+	score := func(a, b string) float64 {
+		return screening.Similarity(screening.Tokens(a), screening.Tokens(b))
+	}
+
+	require.Equal(t, 1.0, score("Ayman al-Zawahiri", "AL-ZAWAHIRI, Ayman"))
+	require.GreaterOrEqual(t, score("Aiman Zawahiri", "ZAWAHRI, Aiman"), screening.DefaultFlagThreshold)
+	require.Less(t, score("John Smith", "AL-ZAWAHIRI, Ayman"), screening.DefaultFlagThreshold)
+	require.Zero(t, score("", "Smith"))
+}
+
+func TestListsLoad(t *testing.T) {
+	lists := loadLists(t)
+
+	status := lists.Status()
+	require.Equal(t, 6, status.Entries)
+	require.Len(t, status.Files, 2)
+	require.Equal(t, 4, status.Files[0].Entries)
+	require.NotNil(t, status.LoadedAt)
+	require.False(t, lists.Changed(testPaths))
+	require.True(t, lists.Changed(testPaths[:1]))
+
+	// an alias is matched as its listed party
+	matches := lists.Search("Osama bin Laden", screening.DefaultFlagThreshold)
+	require.Len(t, matches, 1)
+	require.Equal(t, "2674", matches[0].Entry.ID)
+	require.Equal(t, "sdn", matches[0].Entry.List)
+	require.Equal(t, "SDGT", matches[0].Entry.Programs)
+	require.Equal(t, 1.0, matches[0].Score)
+
+	require.Empty(t, lists.Search("John Smith", screening.DefaultFlagThreshold))
+
+	// a file that cannot be read keeps the lists loaded before
+	require.Error(t, lists.Load([]string{"testdata/missing.csv"}))
+	require.Equal(t, 6, lists.Len())
+}
+
+func TestListsLoadInvalidRow(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "add.csv")
+	require.NoError(t, os.WriteFile(path, []byte(`25,"Street","City","Country",-0- ,-0-`+"\n"), 0o600))
+
+	err := screening.NewLists().Load([]string{path})
+	require.ErrorContains(t, err, "line 1")
+}
+
+func TestListsChanged(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sdn.csv")
+	require.NoError(t, os.WriteFile(path, []byte(`36,"AEROCARIBBEAN AIRLINES",-0- ,"CUBA",-0- ,-0- ,-0- ,-0- ,-0- ,-0- ,-0- ,-0-`+"\n"), 0o600))
+
+	lists := screening.NewLists()
+	service := screening.NewService(nil, lists, []string{path}, screening.DefaultFlagThreshold, screening.DefaultBlockThreshold)
+	require.NoError(t, service.Refresh())
+	require.Equal(t, 1, lists.Len())
+
+	require.NoError(t, os.WriteFile(path, []byte(""), 0o600))
+	require.NoError(t, os.Chtimes(path, time.Now(), time.Now().Add(time.Minute)))
+	require.True(t, lists.Changed([]string{path}))
+
+	require.NoError(t, service.Refresh())
+	require.Zero(t, lists.Len())
+}
+
+func TestScreen(t *testing.T) {
+	testCases := []struct {
+		name       string
+		fullName   string
+		buildStubs func(store *mockdb.MockStore)
+		checkError func(t *testing.T, matches []db.ScreeningMatch, err error)
+	}{
+		{
+			name:     "NoMatch",
+			fullName: "John Smith",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListWhitelistedEntryIDs(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().CreateScreeningMatch(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkError: func(t *testing.T, matches []db.ScreeningMatch, err error) {
+				require.NoError(t, err)
+				require.Empty(t, matches)
+			},
+		},
+		{
+			name:     "Flag",
+			fullName: "Jose Garcia",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListWhitelistedEntryIDs(gomock.Any(), gomock.Eq("garcia jose")).Times(1).Return(nil, nil)
+				store.EXPECT().
+					CreateScreeningMatch(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ any, arg db.CreateScreeningMatchParams) (db.ScreeningMatch, error) {
+						require.Equal(t, screening.SubjectSignup, arg.Subject)
+						require.Equal(t, "9647", arg.EntryID)
+						require.Equal(t, screening.ActionFlag, arg.Action)
+						require.False(t, arg.AccountID.Valid)
+						return db.ScreeningMatch{ID: 1, EntryID: arg.EntryID, Action: arg.Action}, nil
+					})
+			},
+			checkError: func(t *testing.T, matches []db.ScreeningMatch, err error) {
+				require.NoError(t, err)
+				require.Len(t, matches, 1)
+			},
+		},
+		{
+			name:     "Block",
+			fullName: "Osama Bin Laden",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListWhitelistedEntryIDs(gomock.Any(), gomock.Any()).Times(1).Return(nil, nil)
+				store.EXPECT().
+					CreateScreeningMatch(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ any, arg db.CreateScreeningMatchParams) (db.ScreeningMatch, error) {
+						require.Equal(t, screening.ActionBlock, arg.Action)
+						return db.ScreeningMatch{ID: 2, EntryID: arg.EntryID, Action: arg.Action}, nil
+					})
+			},
+			checkError: func(t *testing.T, matches []db.ScreeningMatch, err error) {
+				require.True(t, errors.Is(err, domain.ErrSanctionsMatch))
+				require.Len(t, matches, 1)
+			},
+		},
+		{
+			name:     "Whitelisted",
+			fullName: "Osama Bin Laden",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListWhitelistedEntryIDs(gomock.Any(), gomock.Eq("bin laden osama")).Times(1).Return([]string{"2674"}, nil)
+				store.EXPECT().CreateScreeningMatch(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkError: func(t *testing.T, matches []db.ScreeningMatch, err error) {
+				require.NoError(t, err)
+				require.Empty(t, matches)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			service := screening.NewService(store, loadLists(t), testPaths, screening.DefaultFlagThreshold, screening.DefaultBlockThreshold)
+			matches, err := service.Screen(context.Background(), screening.Subject{
+				Kind:     screening.SubjectSignup,
+				Name:     tc.fullName,
+				Username: "newuser",
+			})
+			tc.checkError(t, matches, err)
+		})
+	}
+}
+
+func TestScreenCounterpartyWithoutLists(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().GetUser(gomock.Any(), gomock.Any()).Times(0)
+
+	service := screening.NewService(store, screening.NewLists(), nil, screening.DefaultFlagThreshold, screening.DefaultBlockThreshold)
+	matches, err := service.ScreenCounterparty(context.Background(), "alice", db.Account{ID: 1, Owner: "bob"})
+	require.NoError(t, err)
+	require.Empty(t, matches)
+}
+
+func TestWhitelist(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().
+		CreateScreeningWhitelistEntry(gomock.Any(), gomock.Eq(db.CreateScreeningWhitelistEntryParams{
+			Name:      "garcia jose",
+			EntryID:   "9647",
+			Reason:    "different date of birth",
+			CreatedBy: "admin",
+		})).
+		Times(1).
+		Return(db.ScreeningWhitelist{ID: 1}, nil)
+
+	service := screening.NewService(store, screening.NewLists(), nil, screening.DefaultFlagThreshold, screening.DefaultBlockThreshold)
+
+	_, err := service.Whitelist(context.Background(), screening.WhitelistParams{
+		Name:      "García, José",
+		EntryID:   " 9647 ",
+		Reason:    " different date of birth ",
+		CreatedBy: "admin",
+	})
+	require.NoError(t, err)
+
+	_, err = service.Whitelist(context.Background(), screening.WhitelistParams{Name: "--", EntryID: "SDN-1", CreatedBy: "admin"})
+	var validationErr *domain.ValidationError
+	require.True(t, errors.As(err, &validationErr))
+	require.Len(t, validationErr.Violations, 2)
+}
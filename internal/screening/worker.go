@@ -0,0 +1,42 @@
+package screening
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+const DefaultReloadInterval = 5 * time.Minute
+
+// Worker reloads the list files when they change, so a new download of a
+// list takes effect without a restart.
+type Worker struct {
+	service  *Service
+	interval time.Duration
+}
+
+func NewWorker(service *Service, interval time.Duration) *Worker {
+	if interval <= 0 {
+		interval = DefaultReloadInterval
+	}
+
+	return &Worker{service: service, interval: interval}
+}
+
+func (worker *Worker) Run(ctx context.Context) error {
+	ticker := time.NewTicker(worker.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+
+		if err := worker.service.Refresh(); err != nil {
+			log.Error().Err(err).Msg("cannot reload sanctions lists")
+		}
+	}
+}
@@ -0,0 +1,104 @@
+// Package services builds the services of sgbank once, so the REST and gRPC
+// servers and the background workers share them: the sanctions lists the
+// screening worker reloads and the currencies the currency worker refreshes
+// are the ones every request is checked against.
+package services
+
+import (
+	"fmt"
+
+	"github.com/NhutHuyDev/sgbank/internal/accountno"
+	"github.com/NhutHuyDev/sgbank/internal/balance"
+	"github.com/NhutHuyDev/sgbank/internal/bank"
+	"github.com/NhutHuyDev/sgbank/internal/beneficiary"
+	"github.com/NhutHuyDev/sgbank/internal/category"
+	"github.com/NhutHuyDev/sgbank/internal/currency"
+	"github.com/NhutHuyDev/sgbank/internal/dispute"
+	"github.com/NhutHuyDev/sgbank/internal/eod"
+	"github.com/NhutHuyDev/sgbank/internal/escrow"
+	"github.com/NhutHuyDev/sgbank/internal/fee"
+	"github.com/NhutHuyDev/sgbank/internal/fraud"
+	"github.com/NhutHuyDev/sgbank/internal/infra/db"
+	"github.com/NhutHuyDev/sgbank/internal/kyc"
+	"github.com/NhutHuyDev/sgbank/internal/ledger"
+	"github.com/NhutHuyDev/sgbank/internal/notify"
+	"github.com/NhutHuyDev/sgbank/internal/overdraft"
+	"github.com/NhutHuyDev/sgbank/internal/payment"
+	"github.com/NhutHuyDev/sgbank/internal/paymentrequest"
+	"github.com/NhutHuyDev/sgbank/internal/recipient"
+	"github.com/NhutHuyDev/sgbank/internal/screening"
+	"github.com/NhutHuyDev/sgbank/internal/transferbatch"
+	"github.com/NhutHuyDev/sgbank/pkg/utils"
+)
+
+// Set holds one of each service.
+type Set struct {
+	AccountNumbers  *accountno.Generator
+	BankAccounts    *bank.Accounts
+	Balances        *balance.Service
+	Beneficiaries   *beneficiary.Service
+	Categories      *category.Service
+	Currencies      *currency.Service
+	Disputes        *dispute.Service
+	EndOfDay        *eod.Service
+	Escrows         *escrow.Service
+	Fees            *fee.Service
+	Fraud           *fraud.Service
+	KYC             *kyc.Service
+	Ledger          *ledger.Service
+	Notifier        notify.Notifier
+	Overdrafts      *overdraft.Service
+	PaymentRequests *paymentrequest.Service
+	Payments        *payment.Service
+	Recipients      *recipient.Resolver
+	Screening       *screening.Service
+	TransferBatches *transferbatch.Service
+}
+
+func FromConfig(store db.Store, config utils.Config) (*Set, error) {
+	accountNumbers, err := accountno.FromConfig(config)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create account number generator: %w", err)
+	}
+
+	endOfDay, err := eod.FromConfig(store, config)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create end-of-day service: %w", err)
+	}
+
+	notifier := notify.NewStoreNotifier(store)
+
+	fraudService, err := fraud.FromConfig(store, notifier, config)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create fraud service: %w", err)
+	}
+
+	bankAccounts := bank.NewAccounts(store, accountNumbers)
+	sanctions := screening.FromConfig(store, config)
+	kycService := kyc.FromConfig(store, notifier, config)
+	fees := fee.NewService(store, bankAccounts)
+	payments := payment.NewService(store, fraudService, sanctions, kycService, fees)
+
+	return &Set{
+		AccountNumbers:  accountNumbers,
+		BankAccounts:    bankAccounts,
+		Balances:        balance.NewService(store),
+		Beneficiaries:   beneficiary.FromConfig(store, config),
+		Categories:      category.NewService(store),
+		Currencies:      currency.NewService(store, currency.Default),
+		Disputes:        dispute.FromConfig(store, bankAccounts, notifier, config),
+		EndOfDay:        endOfDay,
+		Escrows:         escrow.FromConfig(store, bankAccounts, notifier, payments, config),
+		Fees:            fees,
+		Fraud:           fraudService,
+		KYC:             kycService,
+		Ledger:          ledger.NewService(store),
+		Notifier:        notifier,
+		Overdrafts:      overdraft.NewService(store, notifier),
+		PaymentRequests: paymentrequest.NewService(store, notifier, payments, paymentrequest.LinksFromConfig(config)),
+		Payments:        payments,
+		Recipients:      recipient.FromConfig(store, config),
+		Screening:       sanctions,
+		TransferBatches: transferbatch.FromConfig(store, notifier, payments, config),
+	}, nil
+}
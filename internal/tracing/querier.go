@@ -0,0 +1,1234 @@
+package tracing
+
+import (
+	"context"
+	"time"
+
+	"github.com/NhutHuyDev/sgbank/internal/infra/db"
+	"github.com/google/uuid"
+)
+
+func (store *Store) AddAccountBalance(ctx context.Context, arg db.AddAccountBalanceParams) (db.Account, error) {
+	ctx, span := startSpan(ctx, "AddAccountBalance")
+	result, err := store.next.AddAccountBalance(ctx, arg)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) ApproveHeldTransferTx(ctx context.Context, arg db.ApproveHeldTransferTxParams) (db.ApproveHeldTransferTxResult, error) {
+	ctx, span := startSpan(ctx, "ApproveHeldTransferTx")
+	result, err := store.next.ApproveHeldTransferTx(ctx, arg)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) ApproveOverdraftTx(ctx context.Context, arg db.ApproveOverdraftTxParams) (db.ApproveOverdraftTxResult, error) {
+	ctx, span := startSpan(ctx, "ApproveOverdraftTx")
+	result, err := store.next.ApproveOverdraftTx(ctx, arg)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) CapitalizeInterestTx(ctx context.Context, arg db.CapitalizeInterestTxParams) (db.CapitalizeInterestTxResult, error) {
+	ctx, span := startSpan(ctx, "CapitalizeInterestTx")
+	result, err := store.next.CapitalizeInterestTx(ctx, arg)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) ClaimDisputeSLABreaches(ctx context.Context, pageLimit int32) ([]db.Dispute, error) {
+	ctx, span := startSpan(ctx, "ClaimDisputeSLABreaches")
+	result, err := store.next.ClaimDisputeSLABreaches(ctx, pageLimit)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) ClaimDisputeSLAWarnings(ctx context.Context, arg db.ClaimDisputeSLAWarningsParams) ([]db.Dispute, error) {
+	ctx, span := startSpan(ctx, "ClaimDisputeSLAWarnings")
+	result, err := store.next.ClaimDisputeSLAWarnings(ctx, arg)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) ClaimEODStep(ctx context.Context, arg db.ClaimEODStepParams) (db.EodStep, error) {
+	ctx, span := startSpan(ctx, "ClaimEODStep")
+	result, err := store.next.ClaimEODStep(ctx, arg)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) ClaimOverdraftEvents(ctx context.Context, pageLimit int32) ([]db.ClaimOverdraftEventsRow, error) {
+	ctx, span := startSpan(ctx, "ClaimOverdraftEvents")
+	result, err := store.next.ClaimOverdraftEvents(ctx, pageLimit)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) ClaimTransferBatch(ctx context.Context, staleBefore time.Time) (db.TransferBatch, error) {
+	ctx, span := startSpan(ctx, "ClaimTransferBatch")
+	result, err := store.next.ClaimTransferBatch(ctx, staleBefore)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) CloseBusinessDayTx(ctx context.Context, arg db.CloseBusinessDayTxParams) (db.CloseBusinessDayTxResult, error) {
+	ctx, span := startSpan(ctx, "CloseBusinessDayTx")
+	result, err := store.next.CloseBusinessDayTx(ctx, arg)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) CountAccountsByOwner(ctx context.Context, owner string) (int64, error) {
+	ctx, span := startSpan(ctx, "CountAccountsByOwner")
+	result, err := store.next.CountAccountsByOwner(ctx, owner)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) CountRecentTransfers(ctx context.Context, arg db.CountRecentTransfersParams) (db.CountRecentTransfersRow, error) {
+	ctx, span := startSpan(ctx, "CountRecentTransfers")
+	result, err := store.next.CountRecentTransfers(ctx, arg)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) CountRecipientLookupsSince(ctx context.Context, arg db.CountRecipientLookupsSinceParams) (int64, error) {
+	ctx, span := startSpan(ctx, "CountRecipientLookupsSince")
+	result, err := store.next.CountRecipientLookupsSince(ctx, arg)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) CountSessionsFromClientIP(ctx context.Context, arg db.CountSessionsFromClientIPParams) (db.CountSessionsFromClientIPRow, error) {
+	ctx, span := startSpan(ctx, "CountSessionsFromClientIP")
+	result, err := store.next.CountSessionsFromClientIP(ctx, arg)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) CreateAccount(ctx context.Context, arg db.CreateAccountParams) (db.Account, error) {
+	ctx, span := startSpan(ctx, "CreateAccount")
+	result, err := store.next.CreateAccount(ctx, arg)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) CreateAccountTx(ctx context.Context, arg db.CreateAccountParams) (db.Account, error) {
+	ctx, span := startSpan(ctx, "CreateAccountTx")
+	result, err := store.next.CreateAccountTx(ctx, arg)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) CreateBeneficiary(ctx context.Context, arg db.CreateBeneficiaryParams) (db.Beneficiary, error) {
+	ctx, span := startSpan(ctx, "CreateBeneficiary")
+	result, err := store.next.CreateBeneficiary(ctx, arg)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) CreateBusinessDay(ctx context.Context, businessDate time.Time) (db.BusinessDay, error) {
+	ctx, span := startSpan(ctx, "CreateBusinessDay")
+	result, err := store.next.CreateBusinessDay(ctx, businessDate)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) CreateCategorizationRule(ctx context.Context, arg db.CreateCategorizationRuleParams) (db.CategorizationRule, error) {
+	ctx, span := startSpan(ctx, "CreateCategorizationRule")
+	result, err := store.next.CreateCategorizationRule(ctx, arg)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) CreateDispute(ctx context.Context, arg db.CreateDisputeParams) (db.Dispute, error) {
+	ctx, span := startSpan(ctx, "CreateDispute")
+	result, err := store.next.CreateDispute(ctx, arg)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) CreateDisputeEvent(ctx context.Context, arg db.CreateDisputeEventParams) (db.DisputeEvent, error) {
+	ctx, span := startSpan(ctx, "CreateDisputeEvent")
+	result, err := store.next.CreateDisputeEvent(ctx, arg)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) CreateDisputeEvidence(ctx context.Context, arg db.CreateDisputeEvidenceParams) (db.DisputeEvidence, error) {
+	ctx, span := startSpan(ctx, "CreateDisputeEvidence")
+	result, err := store.next.CreateDisputeEvidence(ctx, arg)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) CreateEODStep(ctx context.Context, arg db.CreateEODStepParams) (db.EodStep, error) {
+	ctx, span := startSpan(ctx, "CreateEODStep")
+	result, err := store.next.CreateEODStep(ctx, arg)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) CreateEntry(ctx context.Context, arg db.CreateEntryParams) (db.Entry, error) {
+	ctx, span := startSpan(ctx, "CreateEntry")
+	result, err := store.next.CreateEntry(ctx, arg)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) CreateEscrow(ctx context.Context, arg db.CreateEscrowParams) (db.Escrow, error) {
+	ctx, span := startSpan(ctx, "CreateEscrow")
+	result, err := store.next.CreateEscrow(ctx, arg)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) CreateEscrowTx(ctx context.Context, arg db.CreateEscrowTxParams) (db.CreateEscrowTxResult, error) {
+	ctx, span := startSpan(ctx, "CreateEscrowTx")
+	result, err := store.next.CreateEscrowTx(ctx, arg)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) CreateFeeSchedule(ctx context.Context, arg db.CreateFeeScheduleParams) (db.FeeSchedule, error) {
+	ctx, span := startSpan(ctx, "CreateFeeSchedule")
+	result, err := store.next.CreateFeeSchedule(ctx, arg)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) CreateFeeScheduleBand(ctx context.Context, arg db.CreateFeeScheduleBandParams) (db.FeeScheduleBand, error) {
+	ctx, span := startSpan(ctx, "CreateFeeScheduleBand")
+	result, err := store.next.CreateFeeScheduleBand(ctx, arg)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) CreateFraudDecision(ctx context.Context, arg db.CreateFraudDecisionParams) (db.FraudDecision, error) {
+	ctx, span := startSpan(ctx, "CreateFraudDecision")
+	result, err := store.next.CreateFraudDecision(ctx, arg)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) CreateInterestAccrual(ctx context.Context, arg db.CreateInterestAccrualParams) (int64, error) {
+	ctx, span := startSpan(ctx, "CreateInterestAccrual")
+	result, err := store.next.CreateInterestAccrual(ctx, arg)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) CreateInterestCapitalization(ctx context.Context, arg db.CreateInterestCapitalizationParams) (db.InterestCapitalization, error) {
+	ctx, span := startSpan(ctx, "CreateInterestCapitalization")
+	result, err := store.next.CreateInterestCapitalization(ctx, arg)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) CreateKycDocument(ctx context.Context, arg db.CreateKycDocumentParams) (db.KycDocument, error) {
+	ctx, span := startSpan(ctx, "CreateKycDocument")
+	result, err := store.next.CreateKycDocument(ctx, arg)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) CreateKycSubmission(ctx context.Context, arg db.CreateKycSubmissionParams) (db.KycSubmission, error) {
+	ctx, span := startSpan(ctx, "CreateKycSubmission")
+	result, err := store.next.CreateKycSubmission(ctx, arg)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) CreateKycSubmissionTx(ctx context.Context, arg db.CreateKycSubmissionTxParams) (db.CreateKycSubmissionTxResult, error) {
+	ctx, span := startSpan(ctx, "CreateKycSubmissionTx")
+	result, err := store.next.CreateKycSubmissionTx(ctx, arg)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) CreateNotification(ctx context.Context, arg db.CreateNotificationParams) (db.Notification, error) {
+	ctx, span := startSpan(ctx, "CreateNotification")
+	result, err := store.next.CreateNotification(ctx, arg)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) CreateOverdraftApproval(ctx context.Context, arg db.CreateOverdraftApprovalParams) (db.OverdraftApproval, error) {
+	ctx, span := startSpan(ctx, "CreateOverdraftApproval")
+	result, err := store.next.CreateOverdraftApproval(ctx, arg)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) CreateOverdraftEvent(ctx context.Context, arg db.CreateOverdraftEventParams) (db.OverdraftEvent, error) {
+	ctx, span := startSpan(ctx, "CreateOverdraftEvent")
+	result, err := store.next.CreateOverdraftEvent(ctx, arg)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) CreatePaymentRequest(ctx context.Context, arg db.CreatePaymentRequestParams) (db.PaymentRequest, error) {
+	ctx, span := startSpan(ctx, "CreatePaymentRequest")
+	result, err := store.next.CreatePaymentRequest(ctx, arg)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) CreateRecipientLookup(ctx context.Context, arg db.CreateRecipientLookupParams) (db.RecipientLookup, error) {
+	ctx, span := startSpan(ctx, "CreateRecipientLookup")
+	result, err := store.next.CreateRecipientLookup(ctx, arg)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) CreateScreeningMatch(ctx context.Context, arg db.CreateScreeningMatchParams) (db.ScreeningMatch, error) {
+	ctx, span := startSpan(ctx, "CreateScreeningMatch")
+	result, err := store.next.CreateScreeningMatch(ctx, arg)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) CreateScreeningWhitelistEntry(ctx context.Context, arg db.CreateScreeningWhitelistEntryParams) (db.ScreeningWhitelist, error) {
+	ctx, span := startSpan(ctx, "CreateScreeningWhitelistEntry")
+	result, err := store.next.CreateScreeningWhitelistEntry(ctx, arg)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) CreateSession(ctx context.Context, arg db.CreateSessionParams) (db.Session, error) {
+	ctx, span := startSpan(ctx, "CreateSession")
+	result, err := store.next.CreateSession(ctx, arg)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) CreateTransfer(ctx context.Context, arg db.CreateTransferParams) (db.Transfer, error) {
+	ctx, span := startSpan(ctx, "CreateTransfer")
+	result, err := store.next.CreateTransfer(ctx, arg)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) CreateTransferBatch(ctx context.Context, arg db.CreateTransferBatchParams) (db.TransferBatch, error) {
+	ctx, span := startSpan(ctx, "CreateTransferBatch")
+	result, err := store.next.CreateTransferBatch(ctx, arg)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) CreateTransferBatchItem(ctx context.Context, arg db.CreateTransferBatchItemParams) (db.TransferBatchItem, error) {
+	ctx, span := startSpan(ctx, "CreateTransferBatchItem")
+	result, err := store.next.CreateTransferBatchItem(ctx, arg)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) CreateTransferBatchTx(ctx context.Context, arg db.CreateTransferBatchTxParams) (db.CreateTransferBatchTxResult, error) {
+	ctx, span := startSpan(ctx, "CreateTransferBatchTx")
+	result, err := store.next.CreateTransferBatchTx(ctx, arg)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) CreateUser(ctx context.Context, arg db.CreateUserParams) (db.User, error) {
+	ctx, span := startSpan(ctx, "CreateUser")
+	result, err := store.next.CreateUser(ctx, arg)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) DeleteAccount(ctx context.Context, id int64) error {
+	ctx, span := startSpan(ctx, "DeleteAccount")
+	err := store.next.DeleteAccount(ctx, id)
+	endSpan(span, err)
+	return err
+}
+
+func (store *Store) DeleteBeneficiary(ctx context.Context, id int64) error {
+	ctx, span := startSpan(ctx, "DeleteBeneficiary")
+	err := store.next.DeleteBeneficiary(ctx, id)
+	endSpan(span, err)
+	return err
+}
+
+func (store *Store) DeleteCategorizationRule(ctx context.Context, id int64) error {
+	ctx, span := startSpan(ctx, "DeleteCategorizationRule")
+	err := store.next.DeleteCategorizationRule(ctx, id)
+	endSpan(span, err)
+	return err
+}
+
+func (store *Store) DeleteScreeningWhitelistEntry(ctx context.Context, id int64) (db.ScreeningWhitelist, error) {
+	ctx, span := startSpan(ctx, "DeleteScreeningWhitelistEntry")
+	result, err := store.next.DeleteScreeningWhitelistEntry(ctx, id)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) FailPendingTransferBatchItems(ctx context.Context, arg db.FailPendingTransferBatchItemsParams) error {
+	ctx, span := startSpan(ctx, "FailPendingTransferBatchItems")
+	err := store.next.FailPendingTransferBatchItems(ctx, arg)
+	endSpan(span, err)
+	return err
+}
+
+func (store *Store) FinishClosingBusinessDay(ctx context.Context, businessDate time.Time) (db.BusinessDay, error) {
+	ctx, span := startSpan(ctx, "FinishClosingBusinessDay")
+	result, err := store.next.FinishClosingBusinessDay(ctx, businessDate)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) FinishEODStep(ctx context.Context, arg db.FinishEODStepParams) (db.EodStep, error) {
+	ctx, span := startSpan(ctx, "FinishEODStep")
+	result, err := store.next.FinishEODStep(ctx, arg)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) FinishTransferBatch(ctx context.Context, arg db.FinishTransferBatchParams) (db.TransferBatch, error) {
+	ctx, span := startSpan(ctx, "FinishTransferBatch")
+	result, err := store.next.FinishTransferBatch(ctx, arg)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) GetAccount(ctx context.Context, id int64) (db.Account, error) {
+	ctx, span := startSpan(ctx, "GetAccount")
+	result, err := store.next.GetAccount(ctx, id)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) GetAccountBalanceBefore(ctx context.Context, arg db.GetAccountBalanceBeforeParams) (int64, error) {
+	ctx, span := startSpan(ctx, "GetAccountBalanceBefore")
+	result, err := store.next.GetAccountBalanceBefore(ctx, arg)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) GetAccountByNumber(ctx context.Context, accountNumber string) (db.Account, error) {
+	ctx, span := startSpan(ctx, "GetAccountByNumber")
+	result, err := store.next.GetAccountByNumber(ctx, accountNumber)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) GetAccountByOwnerCurrency(ctx context.Context, arg db.GetAccountByOwnerCurrencyParams) (db.Account, error) {
+	ctx, span := startSpan(ctx, "GetAccountByOwnerCurrency")
+	result, err := store.next.GetAccountByOwnerCurrency(ctx, arg)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) GetAccountByOwnerProduct(ctx context.Context, arg db.GetAccountByOwnerProductParams) (db.Account, error) {
+	ctx, span := startSpan(ctx, "GetAccountByOwnerProduct")
+	result, err := store.next.GetAccountByOwnerProduct(ctx, arg)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) GetAccountForUpdate(ctx context.Context, id int64) (db.Account, error) {
+	ctx, span := startSpan(ctx, "GetAccountForUpdate")
+	result, err := store.next.GetAccountForUpdate(ctx, id)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) GetAccountProduct(ctx context.Context, code string) (db.AccountProduct, error) {
+	ctx, span := startSpan(ctx, "GetAccountProduct")
+	result, err := store.next.GetAccountProduct(ctx, code)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) GetBeneficiary(ctx context.Context, id int64) (db.Beneficiary, error) {
+	ctx, span := startSpan(ctx, "GetBeneficiary")
+	result, err := store.next.GetBeneficiary(ctx, id)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) GetBusinessDay(ctx context.Context, businessDate time.Time) (db.BusinessDay, error) {
+	ctx, span := startSpan(ctx, "GetBusinessDay")
+	result, err := store.next.GetBusinessDay(ctx, businessDate)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) GetCategorizationRule(ctx context.Context, id int64) (db.CategorizationRule, error) {
+	ctx, span := startSpan(ctx, "GetCategorizationRule")
+	result, err := store.next.GetCategorizationRule(ctx, id)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) GetClosingBusinessDay(ctx context.Context) (db.BusinessDay, error) {
+	ctx, span := startSpan(ctx, "GetClosingBusinessDay")
+	result, err := store.next.GetClosingBusinessDay(ctx)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) GetCurrency(ctx context.Context, code string) (db.Currency, error) {
+	ctx, span := startSpan(ctx, "GetCurrency")
+	result, err := store.next.GetCurrency(ctx, code)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) GetDailyBalance(ctx context.Context, arg db.GetDailyBalanceParams) (db.DailyBalance, error) {
+	ctx, span := startSpan(ctx, "GetDailyBalance")
+	result, err := store.next.GetDailyBalance(ctx, arg)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) GetDispute(ctx context.Context, id int64) (db.Dispute, error) {
+	ctx, span := startSpan(ctx, "GetDispute")
+	result, err := store.next.GetDispute(ctx, id)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) GetDisputeEvidence(ctx context.Context, arg db.GetDisputeEvidenceParams) (db.DisputeEvidence, error) {
+	ctx, span := startSpan(ctx, "GetDisputeEvidence")
+	result, err := store.next.GetDisputeEvidence(ctx, arg)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) GetDisputeForUpdate(ctx context.Context, id int64) (db.Dispute, error) {
+	ctx, span := startSpan(ctx, "GetDisputeForUpdate")
+	result, err := store.next.GetDisputeForUpdate(ctx, id)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) GetEntry(ctx context.Context, id int64) (db.Entry, error) {
+	ctx, span := startSpan(ctx, "GetEntry")
+	result, err := store.next.GetEntry(ctx, id)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) GetEscrow(ctx context.Context, id int64) (db.Escrow, error) {
+	ctx, span := startSpan(ctx, "GetEscrow")
+	result, err := store.next.GetEscrow(ctx, id)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) GetEscrowForUpdate(ctx context.Context, id int64) (db.Escrow, error) {
+	ctx, span := startSpan(ctx, "GetEscrowForUpdate")
+	result, err := store.next.GetEscrowForUpdate(ctx, id)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) GetFeeSchedule(ctx context.Context, currency string) (db.FeeSchedule, error) {
+	ctx, span := startSpan(ctx, "GetFeeSchedule")
+	result, err := store.next.GetFeeSchedule(ctx, currency)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) GetFraudDecision(ctx context.Context, id int64) (db.FraudDecision, error) {
+	ctx, span := startSpan(ctx, "GetFraudDecision")
+	result, err := store.next.GetFraudDecision(ctx, id)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) GetFraudDecisionForUpdate(ctx context.Context, id int64) (db.FraudDecision, error) {
+	ctx, span := startSpan(ctx, "GetFraudDecisionForUpdate")
+	result, err := store.next.GetFraudDecisionForUpdate(ctx, id)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) GetInterestCapitalization(ctx context.Context, arg db.GetInterestCapitalizationParams) (db.InterestCapitalization, error) {
+	ctx, span := startSpan(ctx, "GetInterestCapitalization")
+	result, err := store.next.GetInterestCapitalization(ctx, arg)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) GetKycDocument(ctx context.Context, arg db.GetKycDocumentParams) (db.KycDocument, error) {
+	ctx, span := startSpan(ctx, "GetKycDocument")
+	result, err := store.next.GetKycDocument(ctx, arg)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) GetKycLevel(ctx context.Context, level int32) (db.KycLevel, error) {
+	ctx, span := startSpan(ctx, "GetKycLevel")
+	result, err := store.next.GetKycLevel(ctx, level)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) GetKycSubmission(ctx context.Context, id int64) (db.KycSubmission, error) {
+	ctx, span := startSpan(ctx, "GetKycSubmission")
+	result, err := store.next.GetKycSubmission(ctx, id)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) GetKycSubmissionForUpdate(ctx context.Context, id int64) (db.KycSubmission, error) {
+	ctx, span := startSpan(ctx, "GetKycSubmissionForUpdate")
+	result, err := store.next.GetKycSubmissionForUpdate(ctx, id)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) GetLastInterestCapitalization(ctx context.Context, accountID int64) (db.InterestCapitalization, error) {
+	ctx, span := startSpan(ctx, "GetLastInterestCapitalization")
+	result, err := store.next.GetLastInterestCapitalization(ctx, accountID)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) GetOpenBusinessDay(ctx context.Context) (db.BusinessDay, error) {
+	ctx, span := startSpan(ctx, "GetOpenBusinessDay")
+	result, err := store.next.GetOpenBusinessDay(ctx)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) GetOpenBusinessDayForShare(ctx context.Context) (db.BusinessDay, error) {
+	ctx, span := startSpan(ctx, "GetOpenBusinessDayForShare")
+	result, err := store.next.GetOpenBusinessDayForShare(ctx)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) GetPaymentRequest(ctx context.Context, id int64) (db.PaymentRequest, error) {
+	ctx, span := startSpan(ctx, "GetPaymentRequest")
+	result, err := store.next.GetPaymentRequest(ctx, id)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) GetPaymentRequestForUpdate(ctx context.Context, id int64) (db.PaymentRequest, error) {
+	ctx, span := startSpan(ctx, "GetPaymentRequestForUpdate")
+	result, err := store.next.GetPaymentRequestForUpdate(ctx, id)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) GetSession(ctx context.Context, id uuid.UUID) (db.Session, error) {
+	ctx, span := startSpan(ctx, "GetSession")
+	result, err := store.next.GetSession(ctx, id)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) GetTransfer(ctx context.Context, id int64) (db.Transfer, error) {
+	ctx, span := startSpan(ctx, "GetTransfer")
+	result, err := store.next.GetTransfer(ctx, id)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) GetTransferBatch(ctx context.Context, id int64) (db.TransferBatch, error) {
+	ctx, span := startSpan(ctx, "GetTransferBatch")
+	result, err := store.next.GetTransferBatch(ctx, id)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) GetUser(ctx context.Context, username string) (db.User, error) {
+	ctx, span := startSpan(ctx, "GetUser")
+	result, err := store.next.GetUser(ctx, username)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) GetUserByVerifiedEmail(ctx context.Context, email string) (db.User, error) {
+	ctx, span := startSpan(ctx, "GetUserByVerifiedEmail")
+	result, err := store.next.GetUserByVerifiedEmail(ctx, email)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) GetUserForUpdate(ctx context.Context, username string) (db.User, error) {
+	ctx, span := startSpan(ctx, "GetUserForUpdate")
+	result, err := store.next.GetUserForUpdate(ctx, username)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) GetUserKycLimits(ctx context.Context, username string) (db.KycLevel, error) {
+	ctx, span := startSpan(ctx, "GetUserKycLimits")
+	result, err := store.next.GetUserKycLimits(ctx, username)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) GetUserKycTransferLimits(ctx context.Context, arg db.GetUserKycTransferLimitsParams) (db.GetUserKycTransferLimitsRow, error) {
+	ctx, span := startSpan(ctx, "GetUserKycTransferLimits")
+	result, err := store.next.GetUserKycTransferLimits(ctx, arg)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) GetUserTier(ctx context.Context, username string) (db.UserTier, error) {
+	ctx, span := startSpan(ctx, "GetUserTier")
+	result, err := store.next.GetUserTier(ctx, username)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) HasTransferredTo(ctx context.Context, arg db.HasTransferredToParams) (bool, error) {
+	ctx, span := startSpan(ctx, "HasTransferredTo")
+	result, err := store.next.HasTransferredTo(ctx, arg)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) ListAccountProducts(ctx context.Context) ([]db.AccountProduct, error) {
+	ctx, span := startSpan(ctx, "ListAccountProducts")
+	result, err := store.next.ListAccountProducts(ctx)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) ListAccounts(ctx context.Context, arg db.ListAccountsParams) ([]db.Account, error) {
+	ctx, span := startSpan(ctx, "ListAccounts")
+	result, err := store.next.ListAccounts(ctx, arg)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) ListAccountsAfter(ctx context.Context, arg db.ListAccountsAfterParams) ([]db.Account, error) {
+	ctx, span := startSpan(ctx, "ListAccountsAfter")
+	result, err := store.next.ListAccountsAfter(ctx, arg)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) ListAccountsWithUncapitalizedInterest(ctx context.Context, before time.Time) ([]int64, error) {
+	ctx, span := startSpan(ctx, "ListAccountsWithUncapitalizedInterest")
+	result, err := store.next.ListAccountsWithUncapitalizedInterest(ctx, before)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) ListBeneficiariesAfter(ctx context.Context, arg db.ListBeneficiariesAfterParams) ([]db.Beneficiary, error) {
+	ctx, span := startSpan(ctx, "ListBeneficiariesAfter")
+	result, err := store.next.ListBeneficiariesAfter(ctx, arg)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) ListCategorizationRules(ctx context.Context, owner string) ([]db.CategorizationRule, error) {
+	ctx, span := startSpan(ctx, "ListCategorizationRules")
+	result, err := store.next.ListCategorizationRules(ctx, owner)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) ListCurrencies(ctx context.Context) ([]db.Currency, error) {
+	ctx, span := startSpan(ctx, "ListCurrencies")
+	result, err := store.next.ListCurrencies(ctx)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) ListDisputeEvents(ctx context.Context, disputeID int64) ([]db.DisputeEvent, error) {
+	ctx, span := startSpan(ctx, "ListDisputeEvents")
+	result, err := store.next.ListDisputeEvents(ctx, disputeID)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) ListDisputeEvidence(ctx context.Context, disputeID int64) ([]db.DisputeEvidence, error) {
+	ctx, span := startSpan(ctx, "ListDisputeEvidence")
+	result, err := store.next.ListDisputeEvidence(ctx, disputeID)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) ListDisputes(ctx context.Context, arg db.ListDisputesParams) ([]db.Dispute, error) {
+	ctx, span := startSpan(ctx, "ListDisputes")
+	result, err := store.next.ListDisputes(ctx, arg)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) ListDueEscrowIDs(ctx context.Context, pageLimit int32) ([]int64, error) {
+	ctx, span := startSpan(ctx, "ListDueEscrowIDs")
+	result, err := store.next.ListDueEscrowIDs(ctx, pageLimit)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) ListEODSteps(ctx context.Context, businessDate time.Time) ([]db.EodStep, error) {
+	ctx, span := startSpan(ctx, "ListEODSteps")
+	result, err := store.next.ListEODSteps(ctx, businessDate)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) ListEntriesAsc(ctx context.Context, arg db.ListEntriesAscParams) ([]db.ListEntriesAscRow, error) {
+	ctx, span := startSpan(ctx, "ListEntriesAsc")
+	result, err := store.next.ListEntriesAsc(ctx, arg)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) ListEntriesDesc(ctx context.Context, arg db.ListEntriesDescParams) ([]db.ListEntriesDescRow, error) {
+	ctx, span := startSpan(ctx, "ListEntriesDesc")
+	result, err := store.next.ListEntriesDesc(ctx, arg)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) ListEntriesPage(ctx context.Context, filter db.EntryPageFilter, order db.SortOrder) ([]db.EntryPageRow, error) {
+	ctx, span := startSpan(ctx, "ListEntriesPage")
+	result, err := store.next.ListEntriesPage(ctx, filter, order)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) ListEntry(ctx context.Context, arg db.ListEntryParams) ([]db.Entry, error) {
+	ctx, span := startSpan(ctx, "ListEntry")
+	result, err := store.next.ListEntry(ctx, arg)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) ListEscrows(ctx context.Context, arg db.ListEscrowsParams) ([]db.Escrow, error) {
+	ctx, span := startSpan(ctx, "ListEscrows")
+	result, err := store.next.ListEscrows(ctx, arg)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) ListFeeScheduleBands(ctx context.Context, scheduleID int64) ([]db.FeeScheduleBand, error) {
+	ctx, span := startSpan(ctx, "ListFeeScheduleBands")
+	result, err := store.next.ListFeeScheduleBands(ctx, scheduleID)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) ListGLAccounts(ctx context.Context) ([]db.GlAccount, error) {
+	ctx, span := startSpan(ctx, "ListGLAccounts")
+	result, err := store.next.ListGLAccounts(ctx)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) ListInterestBearingAccounts(ctx context.Context, arg db.ListInterestBearingAccountsParams) ([]db.ListInterestBearingAccountsRow, error) {
+	ctx, span := startSpan(ctx, "ListInterestBearingAccounts")
+	result, err := store.next.ListInterestBearingAccounts(ctx, arg)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) ListKycDocuments(ctx context.Context, submissionID int64) ([]db.KycDocument, error) {
+	ctx, span := startSpan(ctx, "ListKycDocuments")
+	result, err := store.next.ListKycDocuments(ctx, submissionID)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) ListKycLevelLimits(ctx context.Context) ([]db.KycLevelLimit, error) {
+	ctx, span := startSpan(ctx, "ListKycLevelLimits")
+	result, err := store.next.ListKycLevelLimits(ctx)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) ListKycLevels(ctx context.Context) ([]db.KycLevel, error) {
+	ctx, span := startSpan(ctx, "ListKycLevels")
+	result, err := store.next.ListKycLevels(ctx)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) ListNotificationsBefore(ctx context.Context, arg db.ListNotificationsBeforeParams) ([]db.Notification, error) {
+	ctx, span := startSpan(ctx, "ListNotificationsBefore")
+	result, err := store.next.ListNotificationsBefore(ctx, arg)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) ListOverdraftApprovals(ctx context.Context, accountID int64) ([]db.OverdraftApproval, error) {
+	ctx, span := startSpan(ctx, "ListOverdraftApprovals")
+	result, err := store.next.ListOverdraftApprovals(ctx, accountID)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) ListOverdrawnAccounts(ctx context.Context, arg db.ListOverdrawnAccountsParams) ([]db.Account, error) {
+	ctx, span := startSpan(ctx, "ListOverdrawnAccounts")
+	result, err := store.next.ListOverdrawnAccounts(ctx, arg)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) ListPaymentRequestsBefore(ctx context.Context, arg db.ListPaymentRequestsBeforeParams) ([]db.PaymentRequest, error) {
+	ctx, span := startSpan(ctx, "ListPaymentRequestsBefore")
+	result, err := store.next.ListPaymentRequestsBefore(ctx, arg)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) ListPendingFraudDecisions(ctx context.Context, arg db.ListPendingFraudDecisionsParams) ([]db.FraudDecision, error) {
+	ctx, span := startSpan(ctx, "ListPendingFraudDecisions")
+	result, err := store.next.ListPendingFraudDecisions(ctx, arg)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) ListPendingKycSubmissions(ctx context.Context, arg db.ListPendingKycSubmissionsParams) ([]db.KycSubmission, error) {
+	ctx, span := startSpan(ctx, "ListPendingKycSubmissions")
+	result, err := store.next.ListPendingKycSubmissions(ctx, arg)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) ListPendingTransferBatchItems(ctx context.Context, batchID int64) ([]db.TransferBatchItem, error) {
+	ctx, span := startSpan(ctx, "ListPendingTransferBatchItems")
+	result, err := store.next.ListPendingTransferBatchItems(ctx, batchID)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) ListScreeningMatches(ctx context.Context, arg db.ListScreeningMatchesParams) ([]db.ScreeningMatch, error) {
+	ctx, span := startSpan(ctx, "ListScreeningMatches")
+	result, err := store.next.ListScreeningMatches(ctx, arg)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) ListScreeningWhitelist(ctx context.Context) ([]db.ScreeningWhitelist, error) {
+	ctx, span := startSpan(ctx, "ListScreeningWhitelist")
+	result, err := store.next.ListScreeningWhitelist(ctx)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) ListTransferBatchItems(ctx context.Context, batchID int64) ([]db.TransferBatchItem, error) {
+	ctx, span := startSpan(ctx, "ListTransferBatchItems")
+	result, err := store.next.ListTransferBatchItems(ctx, batchID)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) ListTransferBatchesBefore(ctx context.Context, arg db.ListTransferBatchesBeforeParams) ([]db.TransferBatch, error) {
+	ctx, span := startSpan(ctx, "ListTransferBatchesBefore")
+	result, err := store.next.ListTransferBatchesBefore(ctx, arg)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) ListTransfers(ctx context.Context, arg db.ListTransfersParams) ([]db.Transfer, error) {
+	ctx, span := startSpan(ctx, "ListTransfers")
+	result, err := store.next.ListTransfers(ctx, arg)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) ListTransfersAsc(ctx context.Context, arg db.ListTransfersAscParams) ([]db.ListTransfersAscRow, error) {
+	ctx, span := startSpan(ctx, "ListTransfersAsc")
+	result, err := store.next.ListTransfersAsc(ctx, arg)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) ListTransfersDesc(ctx context.Context, arg db.ListTransfersDescParams) ([]db.ListTransfersDescRow, error) {
+	ctx, span := startSpan(ctx, "ListTransfersDesc")
+	result, err := store.next.ListTransfersDesc(ctx, arg)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) ListTransfersPage(ctx context.Context, filter db.TransferPageFilter, order db.SortOrder) ([]db.TransferPageRow, error) {
+	ctx, span := startSpan(ctx, "ListTransfersPage")
+	result, err := store.next.ListTransfersPage(ctx, filter, order)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) ListUserDisputes(ctx context.Context, username string) ([]db.Dispute, error) {
+	ctx, span := startSpan(ctx, "ListUserDisputes")
+	result, err := store.next.ListUserDisputes(ctx, username)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) ListUserEscrows(ctx context.Context, username string) ([]db.Escrow, error) {
+	ctx, span := startSpan(ctx, "ListUserEscrows")
+	result, err := store.next.ListUserEscrows(ctx, username)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) ListUserKycSubmissions(ctx context.Context, username string) ([]db.KycSubmission, error) {
+	ctx, span := startSpan(ctx, "ListUserKycSubmissions")
+	result, err := store.next.ListUserKycSubmissions(ctx, username)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) ListUsernamesByRole(ctx context.Context, role string) ([]string, error) {
+	ctx, span := startSpan(ctx, "ListUsernamesByRole")
+	result, err := store.next.ListUsernamesByRole(ctx, role)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) ListWhitelistedEntryIDs(ctx context.Context, name string) ([]string, error) {
+	ctx, span := startSpan(ctx, "ListWhitelistedEntryIDs")
+	result, err := store.next.ListWhitelistedEntryIDs(ctx, name)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) MarkBeneficiaryVerified(ctx context.Context, id int64) (db.Beneficiary, error) {
+	ctx, span := startSpan(ctx, "MarkBeneficiaryVerified")
+	result, err := store.next.MarkBeneficiaryVerified(ctx, id)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) MarkInterestCapitalized(ctx context.Context, arg db.MarkInterestCapitalizedParams) error {
+	ctx, span := startSpan(ctx, "MarkInterestCapitalized")
+	err := store.next.MarkInterestCapitalized(ctx, arg)
+	endSpan(span, err)
+	return err
+}
+
+func (store *Store) MatchCategorizationRule(ctx context.Context, arg db.MatchCategorizationRuleParams) (string, error) {
+	ctx, span := startSpan(ctx, "MatchCategorizationRule")
+	result, err := store.next.MatchCategorizationRule(ctx, arg)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) OpenDisputeTx(ctx context.Context, arg db.OpenDisputeTxParams) (db.OpenDisputeTxResult, error) {
+	ctx, span := startSpan(ctx, "OpenDisputeTx")
+	result, err := store.next.OpenDisputeTx(ctx, arg)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) PayPaymentRequestTx(ctx context.Context, arg db.PayPaymentRequestTxParams) (db.PayPaymentRequestTxResult, error) {
+	ctx, span := startSpan(ctx, "PayPaymentRequestTx")
+	result, err := store.next.PayPaymentRequestTx(ctx, arg)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) PostTransferBatchItemTx(ctx context.Context, arg db.PostTransferBatchItemTxParams) (db.PostTransferBatchItemTxResult, error) {
+	ctx, span := startSpan(ctx, "PostTransferBatchItemTx")
+	result, err := store.next.PostTransferBatchItemTx(ctx, arg)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) PostTransferBatchTx(ctx context.Context, arg db.PostTransferBatchTxParams) (db.PostTransferBatchTxResult, error) {
+	ctx, span := startSpan(ctx, "PostTransferBatchTx")
+	result, err := store.next.PostTransferBatchTx(ctx, arg)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) RaiseUserKycLevel(ctx context.Context, arg db.RaiseUserKycLevelParams) (db.User, error) {
+	ctx, span := startSpan(ctx, "RaiseUserKycLevel")
+	result, err := store.next.RaiseUserKycLevel(ctx, arg)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) RejectHeldTransferTx(ctx context.Context, arg db.RejectHeldTransferTxParams) (db.RejectHeldTransferTxResult, error) {
+	ctx, span := startSpan(ctx, "RejectHeldTransferTx")
+	result, err := store.next.RejectHeldTransferTx(ctx, arg)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) ReviewFraudDecision(ctx context.Context, arg db.ReviewFraudDecisionParams) (db.FraudDecision, error) {
+	ctx, span := startSpan(ctx, "ReviewFraudDecision")
+	result, err := store.next.ReviewFraudDecision(ctx, arg)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) ReviewKycSubmission(ctx context.Context, arg db.ReviewKycSubmissionParams) (db.KycSubmission, error) {
+	ctx, span := startSpan(ctx, "ReviewKycSubmission")
+	result, err := store.next.ReviewKycSubmission(ctx, arg)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) ReviewKycSubmissionTx(ctx context.Context, arg db.ReviewKycSubmissionTxParams) (db.ReviewKycSubmissionTxResult, error) {
+	ctx, span := startSpan(ctx, "ReviewKycSubmissionTx")
+	result, err := store.next.ReviewKycSubmissionTx(ctx, arg)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) SetAccountOverdraftLimit(ctx context.Context, arg db.SetAccountOverdraftLimitParams) (db.Account, error) {
+	ctx, span := startSpan(ctx, "SetAccountOverdraftLimit")
+	result, err := store.next.SetAccountOverdraftLimit(ctx, arg)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) SetAccountOverdrawnSince(ctx context.Context, arg db.SetAccountOverdrawnSinceParams) error {
+	ctx, span := startSpan(ctx, "SetAccountOverdrawnSince")
+	err := store.next.SetAccountOverdrawnSince(ctx, arg)
+	endSpan(span, err)
+	return err
+}
+
+func (store *Store) SetFraudDecisionTransfer(ctx context.Context, arg db.SetFraudDecisionTransferParams) error {
+	ctx, span := startSpan(ctx, "SetFraudDecisionTransfer")
+	err := store.next.SetFraudDecisionTransfer(ctx, arg)
+	endSpan(span, err)
+	return err
+}
+
+func (store *Store) SettleEscrowTx(ctx context.Context, arg db.SettleEscrowTxParams) (db.SettleEscrowTxResult, error) {
+	ctx, span := startSpan(ctx, "SettleEscrowTx")
+	result, err := store.next.SettleEscrowTx(ctx, arg)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) SnapshotDailyBalances(ctx context.Context, businessDate time.Time) (int64, error) {
+	ctx, span := startSpan(ctx, "SnapshotDailyBalances")
+	result, err := store.next.SnapshotDailyBalances(ctx, businessDate)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) StartClosingBusinessDay(ctx context.Context, businessDate time.Time) (db.BusinessDay, error) {
+	ctx, span := startSpan(ctx, "StartClosingBusinessDay")
+	result, err := store.next.StartClosingBusinessDay(ctx, businessDate)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) SumEntriesByPeriod(ctx context.Context, arg db.SumEntriesByPeriodParams) ([]db.SumEntriesByPeriodRow, error) {
+	ctx, span := startSpan(ctx, "SumEntriesByPeriod")
+	result, err := store.next.SumEntriesByPeriod(ctx, arg)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) SumGLBalances(ctx context.Context, arg db.SumGLBalancesParams) ([]db.SumGLBalancesRow, error) {
+	ctx, span := startSpan(ctx, "SumGLBalances")
+	result, err := store.next.SumGLBalances(ctx, arg)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) SumTransfersFromOwnerSince(ctx context.Context, arg db.SumTransfersFromOwnerSinceParams) (int64, error) {
+	ctx, span := startSpan(ctx, "SumTransfersFromOwnerSince")
+	result, err := store.next.SumTransfersFromOwnerSince(ctx, arg)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) SumTransfersFromOwnerSinceByCurrency(ctx context.Context, arg db.SumTransfersFromOwnerSinceByCurrencyParams) ([]db.SumTransfersFromOwnerSinceByCurrencyRow, error) {
+	ctx, span := startSpan(ctx, "SumTransfersFromOwnerSinceByCurrency")
+	result, err := store.next.SumTransfersFromOwnerSinceByCurrency(ctx, arg)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) SumTransfersToAccountSince(ctx context.Context, arg db.SumTransfersToAccountSinceParams) (int64, error) {
+	ctx, span := startSpan(ctx, "SumTransfersToAccountSince")
+	result, err := store.next.SumTransfersToAccountSince(ctx, arg)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) SumUncapitalizedInterest(ctx context.Context, arg db.SumUncapitalizedInterestParams) (int64, error) {
+	ctx, span := startSpan(ctx, "SumUncapitalizedInterest")
+	result, err := store.next.SumUncapitalizedInterest(ctx, arg)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) SummarizeOverdrafts(ctx context.Context) ([]db.SummarizeOverdraftsRow, error) {
+	ctx, span := startSpan(ctx, "SummarizeOverdrafts")
+	result, err := store.next.SummarizeOverdrafts(ctx)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) TransitionDisputeTx(ctx context.Context, arg db.TransitionDisputeTxParams) (db.TransitionDisputeTxResult, error) {
+	ctx, span := startSpan(ctx, "TransitionDisputeTx")
+	result, err := store.next.TransitionDisputeTx(ctx, arg)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) UpdateBeneficiaryNickname(ctx context.Context, arg db.UpdateBeneficiaryNicknameParams) (db.Beneficiary, error) {
+	ctx, span := startSpan(ctx, "UpdateBeneficiaryNickname")
+	result, err := store.next.UpdateBeneficiaryNickname(ctx, arg)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) UpdateDispute(ctx context.Context, arg db.UpdateDisputeParams) (db.Dispute, error) {
+	ctx, span := startSpan(ctx, "UpdateDispute")
+	result, err := store.next.UpdateDispute(ctx, arg)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) UpdateEscrow(ctx context.Context, arg db.UpdateEscrowParams) (db.Escrow, error) {
+	ctx, span := startSpan(ctx, "UpdateEscrow")
+	result, err := store.next.UpdateEscrow(ctx, arg)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) UpdatePaymentRequestStatus(ctx context.Context, arg db.UpdatePaymentRequestStatusParams) (db.PaymentRequest, error) {
+	ctx, span := startSpan(ctx, "UpdatePaymentRequestStatus")
+	result, err := store.next.UpdatePaymentRequestStatus(ctx, arg)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) UpdateTransferBatchItem(ctx context.Context, arg db.UpdateTransferBatchItemParams) (db.TransferBatchItem, error) {
+	ctx, span := startSpan(ctx, "UpdateTransferBatchItem")
+	result, err := store.next.UpdateTransferBatchItem(ctx, arg)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) UpdateTransferBatchProgress(ctx context.Context, id int64) (db.TransferBatch, error) {
+	ctx, span := startSpan(ctx, "UpdateTransferBatchProgress")
+	result, err := store.next.UpdateTransferBatchProgress(ctx, id)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) UpdateUser(ctx context.Context, arg db.UpdateUserParams) (db.User, error) {
+	ctx, span := startSpan(ctx, "UpdateUser")
+	result, err := store.next.UpdateUser(ctx, arg)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) UpdatedAccount(ctx context.Context, arg db.UpdatedAccountParams) (db.Account, error) {
+	ctx, span := startSpan(ctx, "UpdatedAccount")
+	result, err := store.next.UpdatedAccount(ctx, arg)
+	endSpan(span, err)
+	return result, err
+}
+
+func (store *Store) UpsertCurrency(ctx context.Context, arg db.UpsertCurrencyParams) (db.Currency, error) {
+	ctx, span := startSpan(ctx, "UpsertCurrency")
+	result, err := store.next.UpsertCurrency(ctx, arg)
+	endSpan(span, err)
+	return result, err
+}
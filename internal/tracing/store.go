@@ -0,0 +1,56 @@
+package tracing
+
+import (
+	"context"
+
+	"github.com/NhutHuyDev/sgbank/internal/infra/db"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Store decorates a db.Store with one client span per Querier method and
+// transaction, so slow queries show up in the request trace.
+type Store struct {
+	next db.Store
+}
+
+var _ db.Store = (*Store)(nil)
+
+func NewStore(store db.Store) db.Store {
+	return &Store{next: store}
+}
+
+func startSpan(ctx context.Context, operation string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	attrs = append(attrs,
+		semconv.DBSystemNamePostgreSQL,
+		semconv.DBOperationName(operation),
+	)
+
+	return otel.Tracer(instrumentationName).Start(ctx, "db."+operation,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attrs...),
+	)
+}
+
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+func (store *Store) TransferTx(ctx context.Context, arg db.TransferTxParams) (db.TransferTxResult, error) {
+	ctx, span := startSpan(ctx, "TransferTx",
+		attribute.Int64("sgbank.transfer.from_account_id", arg.FromAccountID),
+		attribute.Int64("sgbank.transfer.to_account_id", arg.ToAccountID),
+		attribute.Int64("sgbank.transfer.amount", arg.Amount),
+	)
+	result, err := store.next.TransferTx(ctx, arg)
+	span.SetAttributes(attribute.Int("sgbank.transfer.retries", result.Retries))
+	endSpan(span, err)
+	return result, err
+}
@@ -0,0 +1,150 @@
+package test
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/NhutHuyDev/sgbank/internal/domain"
+	"github.com/NhutHuyDev/sgbank/internal/gapi"
+	"github.com/NhutHuyDev/sgbank/internal/infra/db"
+	mockdb "github.com/NhutHuyDev/sgbank/internal/infra/db/mock"
+	"github.com/NhutHuyDev/sgbank/internal/rest"
+	"github.com/NhutHuyDev/sgbank/internal/services"
+	"github.com/NhutHuyDev/sgbank/internal/tracing"
+	"github.com/NhutHuyDev/sgbank/pkg/utils"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	parentTraceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+	traceParent   = "00-" + parentTraceID + "-00f067aa0ba902b7-01"
+)
+
+func setupTracing(t *testing.T) *tracetest.SpanRecorder {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	otel.SetTracerProvider(provider)
+
+	_, err := tracing.Init(context.Background(), utils.Config{TracingExporter: tracing.ExporterNone})
+	require.NoError(t, err)
+
+	return recorder
+}
+
+func findSpan(t *testing.T, recorder *tracetest.SpanRecorder, name string) sdktrace.ReadOnlySpan {
+	for _, span := range recorder.Ended() {
+		if span.Name() == name {
+			return span
+		}
+	}
+
+	t.Fatalf("span %q not recorded", name)
+	return nil
+}
+
+func TestStoreSpans(t *testing.T) {
+	recorder := setupTracing(t)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mockdb.NewMockStore(ctrl)
+	store := tracing.NewStore(mockStore)
+
+	arg := db.TransferTxParams{FromAccountID: 1, ToAccountID: 2, Amount: 10}
+	mockStore.EXPECT().TransferTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.TransferTxResult{}, nil)
+	mockStore.EXPECT().GetAccount(gomock.Any(), gomock.Eq(int64(1))).Times(1).Return(db.Account{}, sql.ErrNoRows)
+
+	ctx, parent := otel.Tracer("test").Start(context.Background(), "parent")
+	_, err := store.TransferTx(ctx, arg)
+	require.NoError(t, err)
+	_, err = store.GetAccount(ctx, 1)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+	parent.End()
+
+	transferSpan := findSpan(t, recorder, "db.TransferTx")
+	require.Equal(t, trace.SpanKindClient, transferSpan.SpanKind())
+	require.Equal(t, parent.SpanContext().SpanID(), transferSpan.Parent().SpanID())
+	require.Equal(t, codes.Unset, transferSpan.Status().Code)
+
+	getSpan := findSpan(t, recorder, "db.GetAccount")
+	require.Equal(t, codes.Error, getSpan.Status().Code)
+}
+
+func TestGinPropagation(t *testing.T) {
+	recorder := setupTracing(t)
+	gin.SetMode(gin.TestMode)
+
+	config := utils.Config{TokenSymmetricKey: utils.RandomString(32)}
+	set, err := services.FromConfig(nil, config)
+	require.NoError(t, err)
+
+	server, err := rest.NewServer(config, nil, set)
+	require.NoError(t, err)
+
+	request, err := http.NewRequest(http.MethodGet, "/v1/healthz", nil)
+	require.NoError(t, err)
+	request.Header.Set("traceparent", traceParent)
+
+	server.Router.ServeHTTP(httptest.NewRecorder(), request)
+
+	span := findSpan(t, recorder, "GET /v1/healthz")
+	require.Equal(t, parentTraceID, span.SpanContext().TraceID().String())
+	require.True(t, span.Parent().IsRemote())
+}
+
+func TestGatewayPropagation(t *testing.T) {
+	recorder := setupTracing(t)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mockdb.NewMockStore(ctrl)
+	store := tracing.NewStore(mockStore)
+	mockStore.EXPECT().GetUser(gomock.Any(), gomock.Eq("alice")).Times(1).Return(db.User{}, domain.ErrUserNotFound)
+
+	config := utils.Config{TokenSymmetricKey: utils.RandomString(32)}
+	set, err := services.FromConfig(store, config)
+	require.NoError(t, err)
+
+	server, err := gapi.NewServer(config, store, set)
+	require.NoError(t, err)
+
+	handler, err := server.NewGatewayHandler(context.Background())
+	require.NoError(t, err)
+
+	body := `{"username": "alice", "password": "secret"}`
+	request := httptest.NewRequest(http.MethodPost, "/v1/login_user", strings.NewReader(body))
+	request.Header.Set("traceparent", traceParent)
+
+	res := httptest.NewRecorder()
+	handler.ServeHTTP(res, request)
+	require.Equal(t, http.StatusNotFound, res.Code)
+
+	gatewaySpan := findSpan(t, recorder, "POST /v1/login_user")
+	require.Equal(t, parentTraceID, gatewaySpan.SpanContext().TraceID().String())
+
+	querySpan := findSpan(t, recorder, "db.GetUser")
+	require.Equal(t, parentTraceID, querySpan.SpanContext().TraceID().String())
+	require.Equal(t, gatewaySpan.SpanContext().SpanID(), querySpan.Parent().SpanID())
+}
+
+func TestInitExporters(t *testing.T) {
+	shutdown, err := tracing.Init(context.Background(), utils.Config{TracingExporter: tracing.ExporterStdout})
+	require.NoError(t, err)
+	require.NoError(t, shutdown(context.Background()))
+
+	_, err = tracing.Init(context.Background(), utils.Config{TracingExporter: "zipkin"})
+	require.Error(t, err)
+}
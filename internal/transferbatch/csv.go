@@ -0,0 +1,162 @@
+package transferbatch
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/NhutHuyDev/sgbank/internal/domain"
+	"github.com/NhutHuyDev/sgbank/internal/infra/db"
+)
+
+// CSV columns. The header row is required and names the columns in any
+// order; to_account_id or to_account_number and amount must be present.
+const (
+	ColumnToAccountID     = "to_account_id"
+	ColumnToAccountNumber = "to_account_number"
+	ColumnAmount          = "amount"
+	ColumnReference       = "reference"
+)
+
+// ParseCSV reads batch rows from a CSV file. Every malformed row is
+// reported, with rows numbered from 1 after the header like Create does.
+func ParseCSV(r io.Reader) ([]Row, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, domain.NewValidationError(domain.FieldViolation{Field: "rows", Description: "the file is empty"})
+	}
+	if err != nil {
+		return nil, domain.NewValidationError(domain.FieldViolation{Field: "header", Description: csvErrorDescription(err)})
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(name))
+		switch name {
+		case ColumnToAccountID, ColumnToAccountNumber, ColumnAmount, ColumnReference:
+			columns[name] = i
+		default:
+			return nil, domain.NewValidationError(domain.FieldViolation{
+				Field:       "header",
+				Description: fmt.Sprintf("unknown column %q", name),
+			})
+		}
+	}
+
+	_, hasID := columns[ColumnToAccountID]
+	_, hasNumber := columns[ColumnToAccountNumber]
+	_, hasAmount := columns[ColumnAmount]
+	if !hasAmount || (!hasID && !hasNumber) {
+		return nil, domain.NewValidationError(domain.FieldViolation{
+			Field:       "header",
+			Description: "must name the amount column and the to_account_id or to_account_number column",
+		})
+	}
+
+	var rows []Row
+	var violations []domain.FieldViolation
+
+	for rowNumber := 1; ; rowNumber++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			violations = append(violations, domain.FieldViolation{
+				Field:       fmt.Sprintf("rows[%d]", rowNumber),
+				Description: csvErrorDescription(err),
+			})
+			var parseErr *csv.ParseError
+			if errors.As(err, &parseErr) && errors.Is(parseErr.Err, csv.ErrFieldCount) {
+				continue
+			}
+			break
+		}
+
+		cell := func(column string) string {
+			if i, ok := columns[column]; ok {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+
+		row := Row{
+			ToAccountNumber: cell(ColumnToAccountNumber),
+			Reference:       cell(ColumnReference),
+		}
+
+		if value := cell(ColumnToAccountID); value != "" {
+			row.ToAccountID, err = strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				violations = append(violations, domain.FieldViolation{
+					Field:       fmt.Sprintf("rows[%d].%s", rowNumber, ColumnToAccountID),
+					Description: "must be an integer",
+				})
+			}
+		}
+
+		row.Amount, err = strconv.ParseInt(cell(ColumnAmount), 10, 64)
+		if err != nil {
+			violations = append(violations, domain.FieldViolation{
+				Field:       fmt.Sprintf("rows[%d].%s", rowNumber, ColumnAmount),
+				Description: "must be an integer in the currency's minor units",
+			})
+		}
+
+		rows = append(rows, row)
+	}
+
+	if violations != nil {
+		return nil, domain.NewValidationError(violations...)
+	}
+
+	return rows, nil
+}
+
+func csvErrorDescription(err error) string {
+	var parseErr *csv.ParseError
+	if errors.As(err, &parseErr) {
+		return parseErr.Err.Error()
+	}
+	return err.Error()
+}
+
+// WriteResults writes the results file of a batch: one line per row with
+// its status and, for posted rows, the transfer ID.
+func WriteResults(w io.Writer, items []db.TransferBatchItem) error {
+	writer := csv.NewWriter(w)
+
+	err := writer.Write([]string{"row_number", ColumnToAccountID, ColumnAmount, ColumnReference, "status", "transfer_id", "error"})
+	if err != nil {
+		return err
+	}
+
+	for _, item := range items {
+		transferID := ""
+		if item.TransferID.Valid {
+			transferID = strconv.FormatInt(item.TransferID.Int64, 10)
+		}
+
+		err := writer.Write([]string{
+			strconv.Itoa(int(item.RowNumber)),
+			strconv.FormatInt(item.ToAccountID, 10),
+			strconv.FormatInt(item.Amount, 10),
+			item.Reference,
+			item.Status,
+			transferID,
+			item.Error,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
@@ -0,0 +1,564 @@
+package test
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/NhutHuyDev/sgbank/internal/bank"
+	"github.com/NhutHuyDev/sgbank/internal/domain"
+	"github.com/NhutHuyDev/sgbank/internal/fraud"
+	"github.com/NhutHuyDev/sgbank/internal/infra/db"
+	mockdb "github.com/NhutHuyDev/sgbank/internal/infra/db/mock"
+	"github.com/NhutHuyDev/sgbank/internal/kyc"
+	"github.com/NhutHuyDev/sgbank/internal/notify"
+	"github.com/NhutHuyDev/sgbank/internal/payment/paymenttest"
+	"github.com/NhutHuyDev/sgbank/internal/screening"
+	"github.com/NhutHuyDev/sgbank/internal/transferbatch"
+	"github.com/NhutHuyDev/sgbank/pkg/utils"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+// newService holds any payment to an account the owner never paid, and
+// screens against lists.
+func newService(t *testing.T, store db.Store, notifier notify.Notifier, maxRows int, lists *screening.Lists) *transferbatch.Service {
+	payments := paymenttest.NewService(t, store, notifier, lists)
+	return transferbatch.NewService(store, notifier, payments, maxRows)
+}
+
+// expectRowAccounts returns the accounts rows are sent to, all owned by
+// employee.
+func expectRowAccounts(store *mockdb.MockStore) {
+	store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).AnyTimes().
+		DoAndReturn(func(_ context.Context, id int64) (db.Account, error) {
+			return db.Account{ID: id, Owner: "employee", Currency: utils.USD}, nil
+		})
+}
+
+// expectFraudChecks lets every row through the fraud rules except those
+// paying one of the held accounts.
+func expectFraudChecks(store *mockdb.MockStore, held ...int64) {
+	expectRowAccounts(store)
+	store.EXPECT().HasTransferredTo(gomock.Any(), gomock.Any()).AnyTimes().
+		DoAndReturn(func(_ context.Context, arg db.HasTransferredToParams) (bool, error) {
+			for _, id := range held {
+				if arg.ToAccountID == id {
+					return false, nil
+				}
+			}
+			return true, nil
+		})
+	store.EXPECT().CreateFraudDecision(gomock.Any(), gomock.Any()).AnyTimes().
+		DoAndReturn(paymenttest.EchoFraudDecision)
+	store.EXPECT().SetFraudDecisionTransfer(gomock.Any(), gomock.Any()).AnyTimes().Return(nil)
+}
+
+func requireViolations(t *testing.T, err error, fields ...string) {
+	var validationErr *domain.ValidationError
+	require.ErrorAs(t, err, &validationErr)
+
+	got := make([]string, 0, len(validationErr.Violations))
+	for _, violation := range validationErr.Violations {
+		got = append(got, violation.Field)
+	}
+	require.Equal(t, fields, got)
+}
+
+func TestParseCSV(t *testing.T) {
+	rows, err := transferbatch.ParseCSV(strings.NewReader(
+		"amount,to_account_number,reference\n" +
+			"100, SG05 0001 0001 0000 0000 0042 ,June salary\n" +
+			"250,SG0500010001000000000042,\n",
+	))
+	require.NoError(t, err)
+	require.Equal(t, []transferbatch.Row{
+		{ToAccountNumber: "SG05 0001 0001 0000 0000 0042", Amount: 100, Reference: "June salary"},
+		{ToAccountNumber: "SG0500010001000000000042", Amount: 250},
+	}, rows)
+
+	_, err = transferbatch.ParseCSV(strings.NewReader("to_account_id,amount,iban\n"))
+	requireViolations(t, err, "header")
+
+	_, err = transferbatch.ParseCSV(strings.NewReader("reference,amount\n"))
+	requireViolations(t, err, "header")
+
+	_, err = transferbatch.ParseCSV(strings.NewReader(
+		"to_account_id,amount\n" +
+			"1,ten\n" +
+			"2\n" +
+			"x,10\n" +
+			"4,10\n",
+	))
+	requireViolations(t, err, "rows[1].amount", "rows[2]", "rows[3].to_account_id")
+}
+
+func TestCreate(t *testing.T) {
+	fromAccount := db.Account{ID: 1, Owner: "payroll", Currency: utils.USD, Balance: 1000}
+	employee := db.Account{ID: 2, Owner: "bob", Currency: utils.USD, AccountNumber: "SG0500010001000000000042"}
+	euroAccount := db.Account{ID: 3, Owner: "carol", Currency: utils.EUR}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	service := newService(t, store, &paymenttest.Notifier{}, 3, screening.NewLists())
+
+	store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(fromAccount.ID)).AnyTimes().Return(fromAccount, nil)
+	store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(employee.ID)).AnyTimes().Return(employee, nil)
+	store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(euroAccount.ID)).AnyTimes().Return(euroAccount, nil)
+	store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(int64(404))).AnyTimes().Return(db.Account{}, domain.ErrAccountNotFound)
+	store.EXPECT().GetAccountByNumber(gomock.Any(), gomock.Eq(employee.AccountNumber)).Times(2).Return(employee, nil)
+
+	arg := transferbatch.CreateParams{
+		Owner:         fromAccount.Owner,
+		FromAccountID: fromAccount.ID,
+		Currency:      utils.USD,
+		Mode:          transferbatch.ModeAllOrNothing,
+	}
+
+	// every invalid row is reported in one response
+	arg.Rows = []transferbatch.Row{
+		{ToAccountID: 404, Amount: 10},
+		{ToAccountID: euroAccount.ID, Amount: 0},
+		{ToAccountID: fromAccount.ID, ToAccountNumber: employee.AccountNumber, Amount: 10},
+	}
+	_, err := service.Create(context.Background(), arg)
+	requireViolations(t, err, "rows[1].to_account_id", "rows[2].amount", "rows[2].to_account_id", "rows[3].to_account_id")
+
+	arg.Rows = make([]transferbatch.Row, 4)
+	_, err = service.Create(context.Background(), arg)
+	requireViolations(t, err, "rows")
+
+	arg.Rows = []transferbatch.Row{
+		{ToAccountID: employee.ID, Amount: 600},
+		{ToAccountNumber: strings.ToLower(employee.AccountNumber), Amount: 600},
+	}
+	_, err = service.Create(context.Background(), arg)
+	require.ErrorIs(t, err, domain.ErrInsufficientFunds)
+
+	// a best-effort batch may exceed the balance: the rows that do not fit fail
+	arg.Mode = transferbatch.ModeBestEffort
+	store.EXPECT().CreateTransferBatchTx(gomock.Any(), gomock.Any()).Times(1).
+		DoAndReturn(func(_ context.Context, arg db.CreateTransferBatchTxParams) (db.CreateTransferBatchTxResult, error) {
+			require.EqualValues(t, 1200, arg.TotalAmount)
+			require.EqualValues(t, 2, arg.TotalRows)
+			require.Len(t, arg.Items, 2)
+			require.EqualValues(t, 2, arg.Items[1].RowNumber)
+			require.Equal(t, employee.ID, arg.Items[1].ToAccountID)
+			return db.CreateTransferBatchTxResult{Batch: db.TransferBatch{ID: 7, Status: string(transferbatch.StatusPending)}}, nil
+		})
+
+	batch, err := service.Create(context.Background(), arg)
+	require.NoError(t, err)
+	require.EqualValues(t, 7, batch.ID)
+
+	arg.Owner = "mallory"
+	_, err = service.Create(context.Background(), arg)
+	require.ErrorIs(t, err, domain.ErrForbidden)
+}
+
+func pendingItems(batchID int64, n int) []db.TransferBatchItem {
+	items := make([]db.TransferBatchItem, n)
+	for i := range items {
+		items[i] = db.TransferBatchItem{
+			ID:          int64(100 + i),
+			BatchID:     batchID,
+			RowNumber:   int32(i + 1),
+			ToAccountID: int64(10 + i),
+			Amount:      50,
+			Status:      transferbatch.ItemPending,
+		}
+	}
+	return items
+}
+
+func TestProcessAllOrNothing(t *testing.T) {
+	batch := db.TransferBatch{ID: 7, Owner: "payroll", FromAccountID: 1, Mode: string(transferbatch.ModeAllOrNothing), Status: string(transferbatch.StatusProcessing), TotalRows: 3}
+	items := pendingItems(batch.ID, 3)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	notifier := &paymenttest.Notifier{}
+	service := newService(t, store, notifier, 0, screening.NewLists())
+	paymenttest.StubNoKYCLimits(store)
+	paymenttest.StubNoFees(store)
+	expectFraudChecks(store)
+
+	gomock.InOrder(
+		store.EXPECT().ListPendingTransferBatchItems(gomock.Any(), gomock.Eq(batch.ID)).Times(1).Return(items, nil),
+		store.EXPECT().PostTransferBatchTx(gomock.Any(), gomock.Any()).Times(1).
+			Return(db.PostTransferBatchTxResult{FailedRow: 2}, domain.ErrInsufficientFunds),
+		store.EXPECT().UpdateTransferBatchItem(gomock.Any(), gomock.Any()).Times(1).
+			DoAndReturn(func(_ context.Context, arg db.UpdateTransferBatchItemParams) (db.TransferBatchItem, error) {
+				require.Equal(t, items[1].ID, arg.ID)
+				require.Equal(t, transferbatch.ItemFailed, arg.Status)
+				return db.TransferBatchItem{}, nil
+			}),
+		store.EXPECT().FailPendingTransferBatchItems(gomock.Any(), gomock.Any()).Times(1).Return(nil),
+		store.EXPECT().UpdateTransferBatchProgress(gomock.Any(), gomock.Eq(batch.ID)).Times(1).Return(batch, nil),
+		store.EXPECT().FinishTransferBatch(gomock.Any(), gomock.Any()).Times(1).
+			DoAndReturn(func(_ context.Context, arg db.FinishTransferBatchParams) (db.TransferBatch, error) {
+				require.Equal(t, string(transferbatch.StatusFailed), arg.Status)
+				require.Contains(t, arg.Error, "row 2")
+				batch.Status = arg.Status
+				return batch, nil
+			}),
+	)
+
+	require.NoError(t, service.Process(context.Background(), batch))
+	require.Len(t, notifier.Notifications, 1)
+	require.Equal(t, transferbatch.KindFailed, notifier.Notifications[0].Kind)
+}
+
+// expectFlatFee charges the batch owner fee on every row, credited to
+// revenue.
+func expectFlatFee(store *mockdb.MockStore, fee int64, revenue db.Account) {
+	store.EXPECT().GetUserTier(gomock.Any(), gomock.Eq("payroll")).AnyTimes().Return(db.UserTier{Code: "standard"}, nil)
+	store.EXPECT().GetFeeSchedule(gomock.Any(), gomock.Eq(utils.USD)).AnyTimes().Return(db.FeeSchedule{ID: 3, Currency: utils.USD}, nil)
+	store.EXPECT().ListFeeScheduleBands(gomock.Any(), gomock.Eq(int64(3))).AnyTimes().Return([]db.FeeScheduleBand{{FlatFee: fee}}, nil)
+	store.EXPECT().GetAccountByOwnerProduct(gomock.Any(), gomock.Any()).AnyTimes().Return(revenue, nil)
+}
+
+func TestProcessAllOrNothingChargesFees(t *testing.T) {
+	batch := db.TransferBatch{ID: 7, Owner: "payroll", FromAccountID: 1, Currency: utils.USD, Mode: string(transferbatch.ModeAllOrNothing), Status: string(transferbatch.StatusProcessing), TotalRows: 2}
+	items := pendingItems(batch.ID, 2)
+	revenue := db.Account{ID: 90, Owner: bank.Username, Currency: utils.USD, Product: bank.ProductFeeRevenue}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	service := newService(t, store, &paymenttest.Notifier{}, 0, screening.NewLists())
+	paymenttest.StubNoKYCLimits(store)
+	expectFlatFee(store, 5, revenue)
+	expectFraudChecks(store)
+
+	store.EXPECT().ListPendingTransferBatchItems(gomock.Any(), gomock.Eq(batch.ID)).Times(1).Return(items, nil)
+	store.EXPECT().PostTransferBatchTx(gomock.Any(), gomock.Any()).Times(1).
+		DoAndReturn(func(_ context.Context, arg db.PostTransferBatchTxParams) (db.PostTransferBatchTxResult, error) {
+			require.Equal(t, map[int64]int64{items[0].ID: 5, items[1].ID: 5}, arg.Fees)
+			require.Equal(t, revenue.ID, arg.FeeAccountID)
+			return db.PostTransferBatchTxResult{Items: arg.Items}, nil
+		})
+	store.EXPECT().UpdateTransferBatchProgress(gomock.Any(), gomock.Eq(batch.ID)).Times(1).Return(batch, nil)
+	store.EXPECT().FinishTransferBatch(gomock.Any(), gomock.Any()).Times(1).
+		DoAndReturn(func(_ context.Context, arg db.FinishTransferBatchParams) (db.TransferBatch, error) {
+			batch.Status = arg.Status
+			return batch, nil
+		})
+
+	require.NoError(t, service.Process(context.Background(), batch))
+}
+
+func TestProcessBestEffortChargesFees(t *testing.T) {
+	batch := db.TransferBatch{ID: 8, Owner: "payroll", FromAccountID: 1, Currency: utils.USD, Mode: string(transferbatch.ModeBestEffort), Status: string(transferbatch.StatusProcessing), TotalRows: 2}
+	items := pendingItems(batch.ID, 2)
+	revenue := db.Account{ID: 90, Owner: bank.Username, Currency: utils.USD, Product: bank.ProductFeeRevenue}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	service := newService(t, store, &paymenttest.Notifier{}, 0, screening.NewLists())
+	paymenttest.StubNoKYCLimits(store)
+	expectFlatFee(store, 5, revenue)
+	expectFraudChecks(store)
+
+	store.EXPECT().ListPendingTransferBatchItems(gomock.Any(), gomock.Eq(batch.ID)).Times(1).Return(items, nil)
+	store.EXPECT().PostTransferBatchItemTx(gomock.Any(), gomock.Any()).Times(2).
+		DoAndReturn(func(_ context.Context, arg db.PostTransferBatchItemTxParams) (db.PostTransferBatchItemTxResult, error) {
+			require.Equal(t, int64(5), arg.Fee)
+			require.Equal(t, revenue.ID, arg.FeeAccountID)
+			return db.PostTransferBatchItemTxResult{Item: arg.Item}, nil
+		})
+	store.EXPECT().UpdateTransferBatchProgress(gomock.Any(), gomock.Eq(batch.ID)).Times(3).Return(batch, nil)
+	store.EXPECT().FinishTransferBatch(gomock.Any(), gomock.Any()).Times(1).
+		DoAndReturn(func(_ context.Context, arg db.FinishTransferBatchParams) (db.TransferBatch, error) {
+			batch.Status = arg.Status
+			return batch, nil
+		})
+
+	require.NoError(t, service.Process(context.Background(), batch))
+}
+
+func TestProcessBestEffort(t *testing.T) {
+	batch := db.TransferBatch{ID: 8, Owner: "payroll", FromAccountID: 1, Mode: string(transferbatch.ModeBestEffort), Status: string(transferbatch.StatusProcessing), TotalRows: 3}
+	items := pendingItems(batch.ID, 3)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	notifier := &paymenttest.Notifier{}
+	service := newService(t, store, notifier, 0, screening.NewLists())
+	paymenttest.StubNoKYCLimits(store)
+	paymenttest.StubNoFees(store)
+	expectFraudChecks(store)
+
+	store.EXPECT().ListPendingTransferBatchItems(gomock.Any(), gomock.Eq(batch.ID)).Times(1).Return(items, nil)
+	store.EXPECT().PostTransferBatchItemTx(gomock.Any(), gomock.Any()).Times(3).
+		DoAndReturn(func(_ context.Context, arg db.PostTransferBatchItemTxParams) (db.PostTransferBatchItemTxResult, error) {
+			if arg.Item.RowNumber == 2 {
+				return db.PostTransferBatchItemTxResult{}, domain.ErrInsufficientFunds
+			}
+			return db.PostTransferBatchItemTxResult{Item: arg.Item}, nil
+		})
+	store.EXPECT().UpdateTransferBatchItem(gomock.Any(), gomock.Any()).Times(1).
+		DoAndReturn(func(_ context.Context, arg db.UpdateTransferBatchItemParams) (db.TransferBatchItem, error) {
+			require.Equal(t, items[1].ID, arg.ID)
+			require.Equal(t, domain.ErrInsufficientFunds.Message, arg.Error)
+			return db.TransferBatchItem{}, nil
+		})
+	store.EXPECT().UpdateTransferBatchProgress(gomock.Any(), gomock.Eq(batch.ID)).Times(4).Return(batch, nil)
+	store.EXPECT().FinishTransferBatch(gomock.Any(), gomock.Any()).Times(1).
+		DoAndReturn(func(_ context.Context, arg db.FinishTransferBatchParams) (db.TransferBatch, error) {
+			require.Equal(t, string(transferbatch.StatusCompleted), arg.Status)
+			batch.Status, batch.SucceededRows, batch.FailedRows = arg.Status, 2, 1
+			return batch, nil
+		})
+
+	require.NoError(t, service.Process(context.Background(), batch))
+	require.Len(t, notifier.Notifications, 1)
+	require.Equal(t, transferbatch.KindCompleted, notifier.Notifications[0].Kind)
+	require.Contains(t, notifier.Notifications[0].Message, "2 of 3")
+}
+
+func TestProcessBestEffortHeld(t *testing.T) {
+	batch := db.TransferBatch{ID: 8, Owner: "payroll", FromAccountID: 1, Currency: utils.USD, Mode: string(transferbatch.ModeBestEffort), Status: string(transferbatch.StatusProcessing), TotalRows: 3, ClientIp: "203.0.113.7"}
+	items := pendingItems(batch.ID, 3)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	notifier := &paymenttest.Notifier{}
+	service := newService(t, store, notifier, 0, screening.NewLists())
+	paymenttest.StubNoKYCLimits(store)
+	paymenttest.StubNoFees(store)
+	expectRowAccounts(store)
+
+	// row 2 pays an account the owner never paid
+	store.EXPECT().HasTransferredTo(gomock.Any(), gomock.Any()).Times(3).
+		DoAndReturn(func(_ context.Context, arg db.HasTransferredToParams) (bool, error) {
+			require.Equal(t, batch.Owner, arg.Owner)
+			return arg.ToAccountID != items[1].ToAccountID, nil
+		})
+	store.EXPECT().CreateFraudDecision(gomock.Any(), gomock.Any()).Times(3).
+		DoAndReturn(func(_ context.Context, arg db.CreateFraudDecisionParams) (db.FraudDecision, error) {
+			require.Equal(t, db.HeldTransferBatchItem, arg.Kind)
+			require.Equal(t, batch.ClientIp, arg.ClientIp)
+			return db.FraudDecision{ID: int64(arg.ToAccountID), Username: arg.Username, Kind: arg.Kind, Payload: arg.Payload, Status: arg.Status}, nil
+		})
+	store.EXPECT().SetFraudDecisionTransfer(gomock.Any(), gomock.Any()).Times(2).Return(nil)
+
+	store.EXPECT().ListPendingTransferBatchItems(gomock.Any(), gomock.Eq(batch.ID)).Times(1).Return(items, nil)
+	store.EXPECT().PostTransferBatchItemTx(gomock.Any(), gomock.Any()).Times(2).
+		DoAndReturn(func(_ context.Context, arg db.PostTransferBatchItemTxParams) (db.PostTransferBatchItemTxResult, error) {
+			require.NotEqual(t, items[1].ID, arg.Item.ID)
+			return db.PostTransferBatchItemTxResult{Item: arg.Item}, nil
+		})
+	store.EXPECT().UpdateTransferBatchItem(gomock.Any(), gomock.Any()).Times(1).
+		DoAndReturn(func(_ context.Context, arg db.UpdateTransferBatchItemParams) (db.TransferBatchItem, error) {
+			require.Equal(t, items[1].ID, arg.ID)
+			require.Equal(t, transferbatch.ItemHeld, arg.Status)
+			return db.TransferBatchItem{}, nil
+		})
+	store.EXPECT().UpdateTransferBatchProgress(gomock.Any(), gomock.Eq(batch.ID)).Times(4).Return(batch, nil)
+	store.EXPECT().FinishTransferBatch(gomock.Any(), gomock.Any()).Times(1).
+		DoAndReturn(func(_ context.Context, arg db.FinishTransferBatchParams) (db.TransferBatch, error) {
+			require.Equal(t, string(transferbatch.StatusCompleted), arg.Status)
+			batch.Status, batch.SucceededRows, batch.HeldRows = arg.Status, 2, 1
+			return batch, nil
+		})
+
+	require.NoError(t, service.Process(context.Background(), batch))
+
+	// the owner is told the row is held, then that the batch completed
+	require.Len(t, notifier.Notifications, 2)
+	require.Equal(t, fraud.KindHeld, notifier.Notifications[0].Kind)
+	require.Equal(t, transferbatch.KindCompleted, notifier.Notifications[1].Kind)
+	require.Contains(t, notifier.Notifications[1].Message, "2 of 3")
+	require.Contains(t, notifier.Notifications[1].Message, "1 held for review")
+}
+
+func TestProcessAllOrNothingBlocksHeldRow(t *testing.T) {
+	batch := db.TransferBatch{ID: 7, Owner: "payroll", FromAccountID: 1, Mode: string(transferbatch.ModeAllOrNothing), Status: string(transferbatch.StatusProcessing), TotalRows: 3}
+	items := pendingItems(batch.ID, 3)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	notifier := &paymenttest.Notifier{}
+	service := newService(t, store, notifier, 0, screening.NewLists())
+	paymenttest.StubNoKYCLimits(store)
+	paymenttest.StubNoFees(store)
+	expectFraudChecks(store, items[1].ToAccountID)
+
+	store.EXPECT().ListPendingTransferBatchItems(gomock.Any(), gomock.Eq(batch.ID)).Times(1).Return(items, nil)
+	store.EXPECT().PostTransferBatchTx(gomock.Any(), gomock.Any()).Times(0)
+	store.EXPECT().UpdateTransferBatchItem(gomock.Any(), gomock.Any()).Times(1).
+		DoAndReturn(func(_ context.Context, arg db.UpdateTransferBatchItemParams) (db.TransferBatchItem, error) {
+			require.Equal(t, items[1].ID, arg.ID)
+			require.Equal(t, transferbatch.ItemFailed, arg.Status)
+			require.Contains(t, arg.Error, domain.ErrTransferBlocked.Message)
+			return db.TransferBatchItem{}, nil
+		})
+	store.EXPECT().FailPendingTransferBatchItems(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+	store.EXPECT().UpdateTransferBatchProgress(gomock.Any(), gomock.Eq(batch.ID)).Times(1).Return(batch, nil)
+	store.EXPECT().FinishTransferBatch(gomock.Any(), gomock.Any()).Times(1).
+		DoAndReturn(func(_ context.Context, arg db.FinishTransferBatchParams) (db.TransferBatch, error) {
+			require.Equal(t, string(transferbatch.StatusFailed), arg.Status)
+			require.Contains(t, arg.Error, "row 2")
+			batch.Status = arg.Status
+			return batch, nil
+		})
+
+	require.NoError(t, service.Process(context.Background(), batch))
+	require.Len(t, notifier.Notifications, 1)
+	require.Equal(t, transferbatch.KindFailed, notifier.Notifications[0].Kind)
+}
+
+func TestProcessBestEffortSanctionsMatch(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sdn.csv")
+	row := `2674,"BIN LADIN, Usama","individual","SDGT",-0- ,-0- ,-0- ,-0- ,-0- ,-0- ,-0- ,-0-` + "\n"
+	require.NoError(t, os.WriteFile(path, []byte(row), 0o600))
+
+	lists := screening.NewLists()
+	require.NoError(t, lists.Load([]string{path}))
+
+	batch := db.TransferBatch{ID: 8, Owner: "payroll", FromAccountID: 1, Currency: utils.USD, Mode: string(transferbatch.ModeBestEffort), Status: string(transferbatch.StatusProcessing), TotalRows: 2}
+	items := pendingItems(batch.ID, 2)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	notifier := &paymenttest.Notifier{}
+	service := newService(t, store, notifier, 0, lists)
+	paymenttest.StubNoKYCLimits(store)
+	paymenttest.StubNoFees(store)
+	expectFraudChecks(store)
+
+	// row 2 pays a sanctioned name
+	gomock.InOrder(
+		store.EXPECT().GetUser(gomock.Any(), gomock.Eq("employee")).Times(1).
+			Return(db.User{Username: "employee", FullName: "Jane Roe"}, nil),
+		store.EXPECT().GetUser(gomock.Any(), gomock.Eq("employee")).Times(1).
+			Return(db.User{Username: "employee", FullName: "Usama Bin Ladin"}, nil),
+	)
+	store.EXPECT().ListWhitelistedEntryIDs(gomock.Any(), gomock.Any()).AnyTimes().Return(nil, nil)
+	store.EXPECT().CreateScreeningMatch(gomock.Any(), gomock.Any()).AnyTimes().Return(db.ScreeningMatch{ID: 1}, nil)
+
+	store.EXPECT().ListPendingTransferBatchItems(gomock.Any(), gomock.Eq(batch.ID)).Times(1).Return(items, nil)
+	store.EXPECT().PostTransferBatchItemTx(gomock.Any(), gomock.Any()).Times(1).
+		DoAndReturn(func(_ context.Context, arg db.PostTransferBatchItemTxParams) (db.PostTransferBatchItemTxResult, error) {
+			require.Equal(t, items[0].ID, arg.Item.ID)
+			return db.PostTransferBatchItemTxResult{Item: arg.Item}, nil
+		})
+	store.EXPECT().UpdateTransferBatchItem(gomock.Any(), gomock.Any()).Times(1).
+		DoAndReturn(func(_ context.Context, arg db.UpdateTransferBatchItemParams) (db.TransferBatchItem, error) {
+			require.Equal(t, items[1].ID, arg.ID)
+			require.Equal(t, transferbatch.ItemFailed, arg.Status)
+			require.Contains(t, arg.Error, domain.ErrSanctionsMatch.Message)
+			return db.TransferBatchItem{}, nil
+		})
+	store.EXPECT().UpdateTransferBatchProgress(gomock.Any(), gomock.Eq(batch.ID)).Times(3).Return(batch, nil)
+	store.EXPECT().FinishTransferBatch(gomock.Any(), gomock.Any()).Times(1).Return(batch, nil)
+
+	require.NoError(t, service.Process(context.Background(), batch))
+}
+
+func TestProcessBestEffortKYCLimitExceeded(t *testing.T) {
+	batch := db.TransferBatch{ID: 8, Owner: "payroll", FromAccountID: 1, Currency: utils.USD, Mode: string(transferbatch.ModeBestEffort), Status: string(transferbatch.StatusProcessing), TotalRows: 2}
+	items := pendingItems(batch.ID, 2)
+	items[1].Amount = 10001
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	notifier := &paymenttest.Notifier{}
+	service := newService(t, store, notifier, 0, screening.NewLists())
+	expectFraudChecks(store)
+	paymenttest.StubNoFees(store)
+
+	// row 2 is above the limit per transfer of the owner's level
+	store.EXPECT().
+		GetUserKycTransferLimits(gomock.Any(), gomock.Eq(db.GetUserKycTransferLimitsParams{Username: batch.Owner, Currency: utils.USD})).
+		Times(2).
+		Return(db.GetUserKycTransferLimitsRow{
+			Level:             kyc.LevelUnverified,
+			Name:              "Unverified",
+			MaxTransferAmount: sql.NullInt64{Int64: 10000, Valid: true},
+		}, nil)
+
+	store.EXPECT().ListPendingTransferBatchItems(gomock.Any(), gomock.Eq(batch.ID)).Times(1).Return(items, nil)
+	store.EXPECT().PostTransferBatchItemTx(gomock.Any(), gomock.Any()).Times(1).
+		DoAndReturn(func(_ context.Context, arg db.PostTransferBatchItemTxParams) (db.PostTransferBatchItemTxResult, error) {
+			require.Equal(t, items[0].ID, arg.Item.ID)
+			return db.PostTransferBatchItemTxResult{Item: arg.Item}, nil
+		})
+	store.EXPECT().UpdateTransferBatchItem(gomock.Any(), gomock.Any()).Times(1).
+		DoAndReturn(func(_ context.Context, arg db.UpdateTransferBatchItemParams) (db.TransferBatchItem, error) {
+			require.Equal(t, items[1].ID, arg.ID)
+			require.Equal(t, transferbatch.ItemFailed, arg.Status)
+			require.Contains(t, arg.Error, domain.ErrKYCLimitExceeded.Message)
+			return db.TransferBatchItem{}, nil
+		})
+	store.EXPECT().UpdateTransferBatchProgress(gomock.Any(), gomock.Eq(batch.ID)).Times(3).Return(batch, nil)
+	store.EXPECT().FinishTransferBatch(gomock.Any(), gomock.Any()).Times(1).Return(batch, nil)
+
+	require.NoError(t, service.Process(context.Background(), batch))
+}
+
+func TestProcessNextStopsOnTransientError(t *testing.T) {
+	batch := db.TransferBatch{ID: 9, FromAccountID: 1, Mode: string(transferbatch.ModeBestEffort), Status: string(transferbatch.StatusProcessing)}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	service := newService(t, store, &paymenttest.Notifier{}, 0, screening.NewLists())
+	paymenttest.StubNoKYCLimits(store)
+	paymenttest.StubNoFees(store)
+	expectFraudChecks(store)
+
+	store.EXPECT().ClaimTransferBatch(gomock.Any(), gomock.Any()).Times(1).Return(batch, nil)
+	store.EXPECT().ListPendingTransferBatchItems(gomock.Any(), gomock.Eq(batch.ID)).Times(1).Return(pendingItems(batch.ID, 2), nil)
+	store.EXPECT().PostTransferBatchItemTx(gomock.Any(), gomock.Any()).Times(1).Return(db.PostTransferBatchItemTxResult{}, sql.ErrConnDone)
+	store.EXPECT().UpdateTransferBatchItem(gomock.Any(), gomock.Any()).Times(0)
+	store.EXPECT().FinishTransferBatch(gomock.Any(), gomock.Any()).Times(0)
+
+	processed, err := service.ProcessNext(context.Background())
+	require.True(t, processed)
+	require.ErrorIs(t, err, sql.ErrConnDone)
+
+	store.EXPECT().ClaimTransferBatch(gomock.Any(), gomock.Any()).Times(1).Return(db.TransferBatch{}, domain.ErrTransferBatchNotFound)
+	processed, err = service.ProcessNext(context.Background())
+	require.False(t, processed)
+	require.NoError(t, err)
+}
+
+func TestWriteResults(t *testing.T) {
+	items := pendingItems(1, 2)
+	items[0].Status, items[0].TransferID = transferbatch.ItemSucceeded, sql.NullInt64{Int64: 31, Valid: true}
+	items[1].Status, items[1].Error = transferbatch.ItemFailed, "insufficient funds"
+
+	var buf bytes.Buffer
+	require.NoError(t, transferbatch.WriteResults(&buf, items))
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 3)
+	require.Equal(t, []string{"1", "10", "50", "", "succeeded", "31", ""}, records[1])
+	require.Equal(t, []string{"2", "11", "50", "", "failed", "", "insufficient funds"}, records[2])
+}
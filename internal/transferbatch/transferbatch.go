@@ -0,0 +1,290 @@
+package transferbatch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/NhutHuyDev/sgbank/internal/accountno"
+	"github.com/NhutHuyDev/sgbank/internal/domain"
+	"github.com/NhutHuyDev/sgbank/internal/infra/db"
+	"github.com/NhutHuyDev/sgbank/internal/memo"
+	"github.com/NhutHuyDev/sgbank/internal/money"
+	"github.com/NhutHuyDev/sgbank/internal/notify"
+	"github.com/NhutHuyDev/sgbank/internal/payment"
+	"github.com/NhutHuyDev/sgbank/pkg/utils"
+)
+
+type Mode string
+
+const (
+	// ModeAllOrNothing posts every row in one transaction: either all
+	// transfers are made or none is.
+	ModeAllOrNothing Mode = "all_or_nothing"
+	// ModeBestEffort posts each row in its own transaction and reports the
+	// rows that failed.
+	ModeBestEffort Mode = "best_effort"
+)
+
+type Status string
+
+const (
+	StatusPending    Status = "pending"
+	StatusProcessing Status = "processing"
+	StatusCompleted  Status = "completed"
+	StatusFailed     Status = "failed"
+)
+
+const (
+	ItemPending = db.TransferBatchItemPending
+	// ItemHeld is a row of a best-effort batch whose transfer waits for a
+	// fraud review; the review posts or fails it.
+	ItemHeld      = db.TransferBatchItemHeld
+	ItemSucceeded = db.TransferBatchItemSucceeded
+	ItemFailed    = db.TransferBatchItemFailed
+)
+
+const (
+	DefaultMaxRows = 1000
+	// Lease is how long a processing batch may go without progress before
+	// another worker takes it over, e.g. after a crash.
+	Lease = 5 * time.Minute
+)
+
+// Notification kinds emitted when a batch finishes.
+const (
+	KindCompleted = "transfer_batch.completed"
+	KindFailed    = "transfer_batch.failed"
+)
+
+// Row is one requested transfer of a batch. Exactly one of ToAccountID and
+// ToAccountNumber is set. Reference becomes the description of the transfer.
+type Row struct {
+	ToAccountID     int64  `json:"to_account_id"`
+	ToAccountNumber string `json:"to_account_number"`
+	Amount          int64  `json:"amount"`
+	Reference       string `json:"reference"`
+}
+
+// Service accepts batches of transfers from one account, validates every
+// row up front and posts them in the background through the same checks
+// and transaction code as single transfers.
+type Service struct {
+	store    db.Store
+	notifier notify.Notifier
+	payments *payment.Service
+	maxRows  int
+}
+
+func NewService(store db.Store, notifier notify.Notifier, payments *payment.Service, maxRows int) *Service {
+	if maxRows <= 0 {
+		maxRows = DefaultMaxRows
+	}
+
+	return &Service{store: store, notifier: notifier, payments: payments, maxRows: maxRows}
+}
+
+// FromConfig builds the service from TRANSFER_BATCH_MAX_ROWS, using the
+// default when it is not set.
+func FromConfig(store db.Store, notifier notify.Notifier, payments *payment.Service, config utils.Config) *Service {
+	return NewService(store, notifier, payments, config.TransferBatchMaxRows)
+}
+
+type CreateParams struct {
+	Owner         string
+	FromAccountID int64
+	Currency      string
+	Mode          Mode
+	Rows          []Row
+	// ClientIP is the address the batch was submitted from, which the
+	// fraud rules see for every row.
+	ClientIP string
+}
+
+// Create validates every row and queues the batch for the worker. Invalid
+// rows are all reported at once, as violations of rows[n] where n counts
+// rows from 1; nothing is queued unless every row is valid.
+func (service *Service) Create(ctx context.Context, arg CreateParams) (db.TransferBatch, error) {
+	if arg.Mode != ModeAllOrNothing && arg.Mode != ModeBestEffort {
+		return db.TransferBatch{}, domain.NewValidationError(domain.FieldViolation{
+			Field:       "mode",
+			Description: fmt.Sprintf("must be one of %s %s", ModeAllOrNothing, ModeBestEffort),
+		})
+	}
+
+	if len(arg.Rows) == 0 || len(arg.Rows) > service.maxRows {
+		return db.TransferBatch{}, domain.NewValidationError(domain.FieldViolation{
+			Field:       "rows",
+			Description: fmt.Sprintf("must contain between 1 and %d rows", service.maxRows),
+		})
+	}
+
+	fromAccount, err := service.store.GetAccount(ctx, arg.FromAccountID)
+	if err != nil {
+		return db.TransferBatch{}, err
+	}
+
+	if fromAccount.Owner != arg.Owner {
+		return db.TransferBatch{}, fmt.Errorf("%w: from account doesn't belong to the authenticated user", domain.ErrForbidden)
+	}
+
+	if fromAccount.Currency != arg.Currency {
+		return db.TransferBatch{}, fmt.Errorf("%w: from account [%d]: %s vs %s", domain.ErrCurrencyMismatch, fromAccount.ID, fromAccount.Currency, arg.Currency)
+	}
+
+	items, total, err := service.validateRows(ctx, fromAccount, arg.Rows)
+	if err != nil {
+		return db.TransferBatch{}, err
+	}
+
+	if arg.Mode == ModeAllOrNothing && total > fromAccount.Balance+fromAccount.OverdraftLimit {
+		return db.TransferBatch{}, fmt.Errorf("%w: the batch totals %d %s", domain.ErrInsufficientFunds, total, arg.Currency)
+	}
+
+	result, err := service.store.CreateTransferBatchTx(ctx, db.CreateTransferBatchTxParams{
+		CreateTransferBatchParams: db.CreateTransferBatchParams{
+			Owner:         arg.Owner,
+			FromAccountID: arg.FromAccountID,
+			Currency:      arg.Currency,
+			Mode:          string(arg.Mode),
+			TotalRows:     int32(len(items)),
+			TotalAmount:   total,
+			ClientIp:      arg.ClientIP,
+		},
+		Items: items,
+	})
+
+	return result.Batch, err
+}
+
+func (service *Service) validateRows(ctx context.Context, fromAccount db.Account, rows []Row) ([]db.CreateTransferBatchItemParams, int64, error) {
+	var violations []domain.FieldViolation
+	var total int64
+	items := make([]db.CreateTransferBatchItemParams, 0, len(rows))
+	accounts := make(map[string]db.Account)
+
+	for i, row := range rows {
+		rowNumber := i + 1
+		violation := func(field string, description string) {
+			violations = append(violations, domain.FieldViolation{
+				Field:       fmt.Sprintf("rows[%d].%s", rowNumber, field),
+				Description: description,
+			})
+		}
+		before := len(violations)
+
+		if row.Amount <= 0 {
+			violation("amount", "must be greater than 0")
+		} else if sum, err := money.Add(total, row.Amount); err != nil {
+			violation("amount", "the batch total is too large")
+		} else {
+			total = sum
+		}
+
+		if description := memo.ValidateDescription(row.Reference); description != "" {
+			violation("reference", description)
+		}
+
+		field, account, err := service.rowAccount(ctx, row, accounts)
+		switch {
+		case errors.Is(err, errNoDestination):
+			violation("to_account_id", err.Error())
+		case errors.Is(err, accountno.ErrInvalidFormat), errors.Is(err, accountno.ErrInvalidCheckDigits):
+			violation(field, err.Error())
+		case errors.Is(err, domain.ErrAccountNotFound):
+			violation(field, "account not found")
+		case err != nil:
+			return nil, 0, err
+		case account.Currency != fromAccount.Currency:
+			violation(field, fmt.Sprintf("account currency is %s, not %s", account.Currency, fromAccount.Currency))
+		case account.ID == fromAccount.ID:
+			violation(field, "cannot transfer to the from account")
+		}
+
+		if len(violations) == before {
+			items = append(items, db.CreateTransferBatchItemParams{
+				RowNumber:   int32(rowNumber),
+				ToAccountID: account.ID,
+				Amount:      row.Amount,
+				Reference:   row.Reference,
+			})
+		}
+	}
+
+	if violations != nil {
+		return nil, 0, domain.NewValidationError(violations...)
+	}
+
+	return items, total, nil
+}
+
+var errNoDestination = errors.New("exactly one of to_account_id and to_account_number is required")
+
+// rowAccount resolves the destination of row, caching accounts by reference
+// since payroll files often pay the same account more than once. It returns
+// the name of the field the destination came from.
+func (service *Service) rowAccount(ctx context.Context, row Row, accounts map[string]db.Account) (string, db.Account, error) {
+	if (row.ToAccountID == 0) == (row.ToAccountNumber == "") {
+		return "", db.Account{}, errNoDestination
+	}
+
+	field, ref := "to_account_id", fmt.Sprint(row.ToAccountID)
+	if row.ToAccountNumber != "" {
+		field, ref = "to_account_number", accountno.Normalize(row.ToAccountNumber)
+		if err := accountno.Validate(ref); err != nil {
+			return field, db.Account{}, err
+		}
+	}
+
+	if account, ok := accounts[ref]; ok {
+		return field, account, nil
+	}
+
+	var account db.Account
+	var err error
+	if row.ToAccountNumber != "" {
+		account, err = service.store.GetAccountByNumber(ctx, ref)
+	} else {
+		account, err = service.store.GetAccount(ctx, row.ToAccountID)
+	}
+	if err != nil {
+		return field, account, err
+	}
+
+	accounts[ref] = account
+	return field, account, nil
+}
+
+// Get returns the batch if owner created it.
+func (service *Service) Get(ctx context.Context, owner string, id int64) (db.TransferBatch, error) {
+	batch, err := service.store.GetTransferBatch(ctx, id)
+	if err != nil {
+		return batch, err
+	}
+
+	if batch.Owner != owner {
+		return db.TransferBatch{}, fmt.Errorf("%w: transfer batch doesn't belong to the authenticated user", domain.ErrForbidden)
+	}
+
+	return batch, nil
+}
+
+// Results returns the outcome of every row of a finished batch.
+func (service *Service) Results(ctx context.Context, owner string, id int64) (db.TransferBatch, []db.TransferBatchItem, error) {
+	batch, err := service.Get(ctx, owner, id)
+	if err != nil {
+		return batch, nil, err
+	}
+
+	if !IsFinished(batch) {
+		return batch, nil, fmt.Errorf("%w: %d of %d rows processed", domain.ErrBatchInProgress, batch.ProcessedRows, batch.TotalRows)
+	}
+
+	items, err := service.store.ListTransferBatchItems(ctx, batch.ID)
+	return batch, items, err
+}
+
+func IsFinished(batch db.TransferBatch) bool {
+	return batch.Status == string(StatusCompleted) || batch.Status == string(StatusFailed)
+}
@@ -0,0 +1,305 @@
+package transferbatch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/NhutHuyDev/sgbank/internal/domain"
+	"github.com/NhutHuyDev/sgbank/internal/infra/db"
+	"github.com/NhutHuyDev/sgbank/internal/notify"
+	"github.com/NhutHuyDev/sgbank/internal/payment"
+	"github.com/rs/zerolog/log"
+)
+
+const DefaultPollInterval = 2 * time.Second
+
+// Worker processes queued batches. Several workers, in one process or many,
+// may run at once: each batch is claimed by one of them, and a batch whose
+// worker stopped making progress is taken over after Lease.
+type Worker struct {
+	service  *Service
+	interval time.Duration
+}
+
+func NewWorker(service *Service, interval time.Duration) *Worker {
+	if interval <= 0 {
+		interval = DefaultPollInterval
+	}
+
+	return &Worker{service: service, interval: interval}
+}
+
+func (worker *Worker) Run(ctx context.Context) error {
+	ticker := time.NewTicker(worker.interval)
+	defer ticker.Stop()
+
+	for {
+		for {
+			processed, err := worker.service.ProcessNext(ctx)
+			if err != nil {
+				log.Error().Err(err).Msg("cannot process transfer batch")
+				break
+			}
+			if !processed {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// ProcessNext claims one queued batch and processes it. It reports false
+// when there was nothing to do.
+func (service *Service) ProcessNext(ctx context.Context) (bool, error) {
+	batch, err := service.store.ClaimTransferBatch(ctx, time.Now().Add(-Lease))
+	if errors.Is(err, domain.ErrTransferBatchNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return true, service.Process(ctx, batch)
+}
+
+// Process posts the pending rows of a claimed batch and finishes it. When it
+// returns a transient error, such as a lost connection, the batch stays
+// processing and is resumed from its pending rows once its lease expires.
+func (service *Service) Process(ctx context.Context, batch db.TransferBatch) error {
+	items, err := service.store.ListPendingTransferBatchItems(ctx, batch.ID)
+	if err != nil {
+		return err
+	}
+
+	if Mode(batch.Mode) == ModeAllOrNothing {
+		return service.processAll(ctx, batch, items)
+	}
+
+	return service.processEach(ctx, batch, items)
+}
+
+// processAll clears every row before posting any of them. A row cannot be
+// held for review without holding up the whole batch, so the fraud rules
+// block it instead, which rolls the batch back.
+func (service *Service) processAll(ctx context.Context, batch db.TransferBatch, items []db.TransferBatchItem) error {
+	if len(items) == 0 {
+		return service.finish(ctx, batch, StatusCompleted, "")
+	}
+
+	clearances := make(map[int64]payment.Clearance, len(items))
+	accounts := make(map[int64]db.Account)
+	arg := db.PostTransferBatchTxParams{
+		FromAccountID: batch.FromAccountID,
+		Items:         items,
+		Fees:          make(map[int64]int64, len(items)),
+	}
+	for _, item := range items {
+		clearance, err := service.clear(ctx, batch, item, accounts, true)
+		switch {
+		case err == nil:
+			clearances[item.ID] = clearance
+			if clearance.Params.Fee > 0 {
+				arg.Fees[item.ID], arg.FeeAccountID = clearance.Params.Fee, clearance.Params.FeeAccountID
+			}
+		case isTransient(err):
+			return err
+		default:
+			return service.rollBack(ctx, batch, items, item.RowNumber, err)
+		}
+	}
+
+	result, err := service.store.PostTransferBatchTx(ctx, arg)
+	switch {
+	case err == nil:
+		for _, item := range result.Items {
+			service.payments.Posted(ctx, clearances[item.ID], item.TransferID.Int64)
+		}
+	case errors.Is(err, domain.ErrInvalidTransition):
+		// posted by a worker that held the batch before
+	case isTransient(err):
+		return err
+	default:
+		return service.rollBack(ctx, batch, items, result.FailedRow, err)
+	}
+
+	return service.finish(ctx, batch, StatusCompleted, "")
+}
+
+// rollBack records why an all-or-nothing batch was not posted: the failing
+// row gets the error and every other row is marked failed because of it.
+func (service *Service) rollBack(ctx context.Context, batch db.TransferBatch, items []db.TransferBatchItem, failedRow int32, cause error) error {
+	reason := fmt.Sprintf("row %d: %s", failedRow, cause)
+
+	for _, item := range items {
+		if item.RowNumber != failedRow {
+			continue
+		}
+
+		_, err := service.store.UpdateTransferBatchItem(ctx, db.UpdateTransferBatchItemParams{
+			ID:     item.ID,
+			Status: ItemFailed,
+			Error:  cause.Error(),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	err := service.store.FailPendingTransferBatchItems(ctx, db.FailPendingTransferBatchItemsParams{
+		BatchID: batch.ID,
+		Error:   fmt.Sprintf("not posted: row %d failed", failedRow),
+	})
+	if err != nil {
+		return err
+	}
+
+	return service.finish(ctx, batch, StatusFailed, reason)
+}
+
+func (service *Service) processEach(ctx context.Context, batch db.TransferBatch, items []db.TransferBatchItem) error {
+	accounts := make(map[int64]db.Account)
+	for _, item := range items {
+		err := service.processItem(ctx, batch, item, accounts)
+
+		switch {
+		case err == nil, errors.Is(err, domain.ErrInvalidTransition):
+			// posted now, or by a worker that held the batch before
+		case isTransient(err):
+			return err
+		default:
+			_, err = service.store.UpdateTransferBatchItem(ctx, db.UpdateTransferBatchItemParams{
+				ID:     item.ID,
+				Status: ItemFailed,
+				Error:  err.Error(),
+			})
+			if err != nil && !errors.Is(err, domain.ErrTransferBatchNotFound) {
+				return err
+			}
+		}
+
+		// Reporting progress also renews the lease on the batch.
+		if _, err := service.store.UpdateTransferBatchProgress(ctx, batch.ID); err != nil {
+			return err
+		}
+	}
+
+	return service.finish(ctx, batch, StatusCompleted, "")
+}
+
+// processItem clears one row of a best-effort batch and posts it, or marks
+// it held when the fraud rules hold it for review.
+func (service *Service) processItem(ctx context.Context, batch db.TransferBatch, item db.TransferBatchItem, accounts map[int64]db.Account) error {
+	clearance, err := service.clear(ctx, batch, item, accounts, false)
+	if err != nil {
+		return err
+	}
+
+	if clearance.Held() {
+		_, err = service.store.UpdateTransferBatchItem(ctx, db.UpdateTransferBatchItemParams{
+			ID:     item.ID,
+			Status: ItemHeld,
+		})
+		if errors.Is(err, domain.ErrTransferBatchNotFound) {
+			// settled by a worker that held the batch before
+			return nil
+		}
+		return err
+	}
+
+	result, err := service.store.PostTransferBatchItemTx(ctx, db.PostTransferBatchItemTxParams{
+		FromAccountID: batch.FromAccountID,
+		Item:          item,
+		Fee:           clearance.Params.Fee,
+		FeeAccountID:  clearance.Params.FeeAccountID,
+	})
+	if err != nil {
+		return err
+	}
+
+	service.payments.Posted(ctx, clearance, result.Transfer.ID)
+	return nil
+}
+
+// clear runs the checks on the payment a row makes, caching the accounts
+// it is sent to by ID since payroll batches often pay the same account
+// more than once. Once a held row is approved it is posted by
+// PostTransferBatchItemTx.
+func (service *Service) clear(ctx context.Context, batch db.TransferBatch, item db.TransferBatchItem, accounts map[int64]db.Account, immediate bool) (payment.Clearance, error) {
+	toAccount, ok := accounts[item.ToAccountID]
+	if !ok {
+		var err error
+		toAccount, err = service.store.GetAccount(ctx, item.ToAccountID)
+		if err != nil {
+			return payment.Clearance{}, err
+		}
+		accounts[item.ToAccountID] = toAccount
+	}
+
+	return service.payments.Clear(ctx, payment.Payment{
+		Username: batch.Owner,
+		ClientIP: batch.ClientIp,
+		Currency: batch.Currency,
+		Params: db.TransferTxParams{
+			FromAccountID: batch.FromAccountID,
+			ToAccountID:   item.ToAccountID,
+			Amount:        item.Amount,
+			Description:   item.Reference,
+		},
+		ToAccount: toAccount,
+		Kind:      db.HeldTransferBatchItem,
+		Payload: db.PostTransferBatchItemTxParams{
+			FromAccountID: batch.FromAccountID,
+			Item:          item,
+		},
+		Immediate: immediate,
+	})
+}
+
+func (service *Service) finish(ctx context.Context, batch db.TransferBatch, status Status, reason string) error {
+	if _, err := service.store.UpdateTransferBatchProgress(ctx, batch.ID); err != nil {
+		return err
+	}
+
+	batch, err := service.store.FinishTransferBatch(ctx, db.FinishTransferBatchParams{
+		ID:     batch.ID,
+		Status: string(status),
+		Error:  reason,
+	})
+	if errors.Is(err, domain.ErrTransferBatchNotFound) {
+		// another worker took the batch over and finished it
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	notification := notify.Notification{
+		Username: batch.Owner,
+		Kind:     KindCompleted,
+		Message:  fmt.Sprintf("transfer batch %d completed: %d of %d transfers succeeded", batch.ID, batch.SucceededRows, batch.TotalRows),
+	}
+	if batch.HeldRows > 0 {
+		notification.Message += fmt.Sprintf(", %d held for review", batch.HeldRows)
+	}
+	if status == StatusFailed {
+		notification.Kind = KindFailed
+		notification.Message = fmt.Sprintf("transfer batch %d failed: %s", batch.ID, reason)
+	}
+	service.notifier.Notify(ctx, notification)
+
+	return nil
+}
+
+// isTransient reports whether err says nothing about the row itself, so the
+// row must be retried rather than reported as failed.
+func isTransient(err error) bool {
+	kind := domain.Lookup(err)
+	return kind == domain.ErrInternal || kind == domain.ErrTxConflict
+}
@@ -25,34 +25,49 @@ var File_service_sgbank_proto protoreflect.FileDescriptor
 
 const file_service_sgbank_proto_rawDesc = "" +
 	"\n" +
-	"\x14service_sgbank.proto\x12\x02pb\x1a\x1cgoogle/api/annotations.proto\x1a\x15rpc_create_user.proto\x1a\x15rpc_update_user.proto\x1a\x14rpc_login_user.proto2\x8f\x02\n" +
+	"\x14service_sgbank.proto\x12\x02pb\x1a\x1cgoogle/api/annotations.proto\x1a\x15rpc_create_user.proto\x1a\x15rpc_update_user.proto\x1a\x14rpc_login_user.proto\x1a\x16rpc_list_entries.proto\x1a\x18rpc_list_transfers.proto\x1a\x1drpc_get_account_balance.proto2\xd4\x04\n" +
 	"\x06Sgbank\x12W\n" +
 	"\n" +
 	"CreateUser\x12\x15.pb.CreateUserRequest\x1a\x16.pb.CreateUserResponse\"\x1a\x82\xd3\xe4\x93\x02\x14:\x01*\"\x0f/v1/create_user\x12W\n" +
 	"\n" +
 	"UpdateUser\x12\x15.pb.UpdateUserRequest\x1a\x16.pb.UpdateUserResponse\"\x1a\x82\xd3\xe4\x93\x02\x14:\x01*\"\x0f/v1/update_user\x12S\n" +
-	"\tLoginUser\x12\x14.pb.LoginUserRequest\x1a\x15.pb.LoginUserResponse\"\x19\x82\xd3\xe4\x93\x02\x13:\x01*\"\x0e/v1/login_userB!Z\x1fgithub.com/NhutHuyDev/sgbank/pbb\x06proto3"
+	"\tLoginUser\x12\x14.pb.LoginUserRequest\x1a\x15.pb.LoginUserResponse\"\x19\x82\xd3\xe4\x93\x02\x13:\x01*\"\x0e/v1/login_user\x12i\n" +
+	"\vListEntries\x12\x16.pb.ListEntriesRequest\x1a\x17.pb.ListEntriesResponse\")\x82\xd3\xe4\x93\x02#\x12!/v1/accounts/{account_id}/entries\x12{\n" +
+	"\x11GetAccountBalance\x12\x1c.pb.GetAccountBalanceRequest\x1a\x1d.pb.GetAccountBalanceResponse\")\x82\xd3\xe4\x93\x02#\x12!/v1/accounts/{account_id}/balance\x12[\n" +
+	"\rListTransfers\x12\x18.pb.ListTransfersRequest\x1a\x19.pb.ListTransfersResponse\"\x15\x82\xd3\xe4\x93\x02\x0f\x12\r/v1/transfersB!Z\x1fgithub.com/NhutHuyDev/sgbank/pbb\x06proto3"
 
 var file_service_sgbank_proto_goTypes = []any{
-	(*CreateUserRequest)(nil),  // 0: pb.CreateUserRequest
-	(*UpdateUserRequest)(nil),  // 1: pb.UpdateUserRequest
-	(*LoginUserRequest)(nil),   // 2: pb.LoginUserRequest
-	(*CreateUserResponse)(nil), // 3: pb.CreateUserResponse
-	(*UpdateUserResponse)(nil), // 4: pb.UpdateUserResponse
-	(*LoginUserResponse)(nil),  // 5: pb.LoginUserResponse
+	(*CreateUserRequest)(nil),         // 0: pb.CreateUserRequest
+	(*UpdateUserRequest)(nil),         // 1: pb.UpdateUserRequest
+	(*LoginUserRequest)(nil),          // 2: pb.LoginUserRequest
+	(*ListEntriesRequest)(nil),        // 3: pb.ListEntriesRequest
+	(*GetAccountBalanceRequest)(nil),  // 4: pb.GetAccountBalanceRequest
+	(*ListTransfersRequest)(nil),      // 5: pb.ListTransfersRequest
+	(*CreateUserResponse)(nil),        // 6: pb.CreateUserResponse
+	(*UpdateUserResponse)(nil),        // 7: pb.UpdateUserResponse
+	(*LoginUserResponse)(nil),         // 8: pb.LoginUserResponse
+	(*ListEntriesResponse)(nil),       // 9: pb.ListEntriesResponse
+	(*GetAccountBalanceResponse)(nil), // 10: pb.GetAccountBalanceResponse
+	(*ListTransfersResponse)(nil),     // 11: pb.ListTransfersResponse
 }
 var file_service_sgbank_proto_depIdxs = []int32{
-	0, // 0: pb.Sgbank.CreateUser:input_type -> pb.CreateUserRequest
-	1, // 1: pb.Sgbank.UpdateUser:input_type -> pb.UpdateUserRequest
-	2, // 2: pb.Sgbank.LoginUser:input_type -> pb.LoginUserRequest
-	3, // 3: pb.Sgbank.CreateUser:output_type -> pb.CreateUserResponse
-	4, // 4: pb.Sgbank.UpdateUser:output_type -> pb.UpdateUserResponse
-	5, // 5: pb.Sgbank.LoginUser:output_type -> pb.LoginUserResponse
-	3, // [3:6] is the sub-list for method output_type
-	0, // [0:3] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	0,  // 0: pb.Sgbank.CreateUser:input_type -> pb.CreateUserRequest
+	1,  // 1: pb.Sgbank.UpdateUser:input_type -> pb.UpdateUserRequest
+	2,  // 2: pb.Sgbank.LoginUser:input_type -> pb.LoginUserRequest
+	3,  // 3: pb.Sgbank.ListEntries:input_type -> pb.ListEntriesRequest
+	4,  // 4: pb.Sgbank.GetAccountBalance:input_type -> pb.GetAccountBalanceRequest
+	5,  // 5: pb.Sgbank.ListTransfers:input_type -> pb.ListTransfersRequest
+	6,  // 6: pb.Sgbank.CreateUser:output_type -> pb.CreateUserResponse
+	7,  // 7: pb.Sgbank.UpdateUser:output_type -> pb.UpdateUserResponse
+	8,  // 8: pb.Sgbank.LoginUser:output_type -> pb.LoginUserResponse
+	9,  // 9: pb.Sgbank.ListEntries:output_type -> pb.ListEntriesResponse
+	10, // 10: pb.Sgbank.GetAccountBalance:output_type -> pb.GetAccountBalanceResponse
+	11, // 11: pb.Sgbank.ListTransfers:output_type -> pb.ListTransfersResponse
+	6,  // [6:12] is the sub-list for method output_type
+	0,  // [0:6] is the sub-list for method input_type
+	0,  // [0:0] is the sub-list for extension type_name
+	0,  // [0:0] is the sub-list for extension extendee
+	0,  // [0:0] is the sub-list for field type_name
 }
 
 func init() { file_service_sgbank_proto_init() }
@@ -63,6 +78,9 @@ func file_service_sgbank_proto_init() {
 	file_rpc_create_user_proto_init()
 	file_rpc_update_user_proto_init()
 	file_rpc_login_user_proto_init()
+	file_rpc_list_entries_proto_init()
+	file_rpc_list_transfers_proto_init()
+	file_rpc_get_account_balance_proto_init()
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
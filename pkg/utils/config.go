@@ -6,16 +6,61 @@ import (
 	"github.com/spf13/viper"
 )
 
+const (
+	// HTTPServerModeGin serves the Gin routes on the HTTP address.
+	HTTPServerModeGin = "gin"
+	// HTTPServerModeGateway serves the grpc-gateway REST mapping on the HTTP address.
+	HTTPServerModeGateway = "gateway"
+)
+
 type Config struct {
-	DBDriver             string        `mapstructure:"DB_DRIVER"`
-	DBSource             string        `mapstructure:"DB_SOURCE"`
-	MigrationUrl         string        `mapstructure:"MIGRATION_URL"`
-	HTTPServerAddress    string        `mapstructure:"HTTP_SERVER_ADDRESS"`
-	GRPCServerAddress    string        `mapstructure:"GRPC_SERVER_ADDRESS"`
-	ServerAddress        string        `mapstructure:"SERVER_ADDRESS"`
-	TokenSymmetricKey    string        `mapstructure:"TOKEN_SYMMETRIC_KEY"`
-	AccessTokenDuration  time.Duration `mapstructure:"ACCESS_TOKEN_DURATION"`
-	RefreshTokenDuration time.Duration `mapstructure:"REFRESH_TOKEN_DURATION"`
+	DBDriver                      string        `mapstructure:"DB_DRIVER"`
+	DBSource                      string        `mapstructure:"DB_SOURCE"`
+	MigrationUrl                  string        `mapstructure:"MIGRATION_URL"`
+	HTTPServerAddress             string        `mapstructure:"HTTP_SERVER_ADDRESS"`
+	GRPCServerAddress             string        `mapstructure:"GRPC_SERVER_ADDRESS"`
+	ServerAddress                 string        `mapstructure:"SERVER_ADDRESS"`
+	TokenSymmetricKey             string        `mapstructure:"TOKEN_SYMMETRIC_KEY"`
+	AccessTokenDuration           time.Duration `mapstructure:"ACCESS_TOKEN_DURATION"`
+	RefreshTokenDuration          time.Duration `mapstructure:"REFRESH_TOKEN_DURATION"`
+	HTTPServerMode                string        `mapstructure:"HTTP_SERVER_MODE"`
+	ShutdownTimeout               time.Duration `mapstructure:"SHUTDOWN_TIMEOUT"`
+	TracingExporter               string        `mapstructure:"TRACING_EXPORTER"`
+	OTLPEndpoint                  string        `mapstructure:"OTLP_ENDPOINT"`
+	OTLPInsecure                  bool          `mapstructure:"OTLP_INSECURE"`
+	CursorSecretKey               string        `mapstructure:"CURSOR_SECRET_KEY"`
+	RecipientLookupLimit          int           `mapstructure:"RECIPIENT_LOOKUP_LIMIT"`
+	AccountBankCode               string        `mapstructure:"ACCOUNT_BANK_CODE"`
+	AccountBranchCode             string        `mapstructure:"ACCOUNT_BRANCH_CODE"`
+	BeneficiaryCoolingOff         time.Duration `mapstructure:"BENEFICIARY_COOLING_OFF"`
+	BeneficiaryCoolingOffLimit    int64         `mapstructure:"BENEFICIARY_COOLING_OFF_LIMIT"`
+	TransferBatchMaxRows          int           `mapstructure:"TRANSFER_BATCH_MAX_ROWS"`
+	TransferBatchPollInterval     time.Duration `mapstructure:"TRANSFER_BATCH_POLL_INTERVAL"`
+	EODRunInterval                time.Duration `mapstructure:"EOD_RUN_INTERVAL"`
+	OverdraftNotifyInterval       time.Duration `mapstructure:"OVERDRAFT_NOTIFY_INTERVAL"`
+	CurrencyRefreshInterval       time.Duration `mapstructure:"CURRENCY_REFRESH_INTERVAL"`
+	FraudHoldScore                int           `mapstructure:"FRAUD_HOLD_SCORE"`
+	FraudBlockScore               int           `mapstructure:"FRAUD_BLOCK_SCORE"`
+	FraudRuleScores               string        `mapstructure:"FRAUD_RULE_SCORES"`
+	FraudVelocityWindow           time.Duration `mapstructure:"FRAUD_VELOCITY_WINDOW"`
+	FraudVelocityLimit            int64         `mapstructure:"FRAUD_VELOCITY_LIMIT"`
+	FraudLargeAmount              int64         `mapstructure:"FRAUD_LARGE_AMOUNT"`
+	FraudRoundAmount              int64         `mapstructure:"FRAUD_ROUND_AMOUNT"`
+	SanctionsListPaths            string        `mapstructure:"SANCTIONS_LIST_PATHS"`
+	ScreeningFlagThreshold        float64       `mapstructure:"SCREENING_FLAG_THRESHOLD"`
+	ScreeningBlockThreshold       float64       `mapstructure:"SCREENING_BLOCK_THRESHOLD"`
+	ScreeningReloadInterval       time.Duration `mapstructure:"SCREENING_RELOAD_INTERVAL"`
+	KYCStorageDir                 string        `mapstructure:"KYC_STORAGE_DIR"`
+	KYCMaxDocumentSize            int64         `mapstructure:"KYC_MAX_DOCUMENT_SIZE"`
+	DisputeWindow                 time.Duration `mapstructure:"DISPUTE_WINDOW"`
+	DisputeSLA                    time.Duration `mapstructure:"DISPUTE_SLA"`
+	DisputeSLAWarning             time.Duration `mapstructure:"DISPUTE_SLA_WARNING"`
+	DisputeProvisionalCreditLimit int64         `mapstructure:"DISPUTE_PROVISIONAL_CREDIT_LIMIT"`
+	DisputeCheckInterval          time.Duration `mapstructure:"DISPUTE_CHECK_INTERVAL"`
+	DisputeStorageDir             string        `mapstructure:"DISPUTE_STORAGE_DIR"`
+	EscrowDefaultReleaseAfter     time.Duration `mapstructure:"ESCROW_DEFAULT_RELEASE_AFTER"`
+	EscrowMaxReleaseAfter         time.Duration `mapstructure:"ESCROW_MAX_RELEASE_AFTER"`
+	EscrowReleaseInterval         time.Duration `mapstructure:"ESCROW_RELEASE_INTERVAL"`
 }
 
 func LoadConfig(path string, name string) (config Config, err error) {
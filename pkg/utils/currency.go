@@ -5,12 +5,3 @@ const (
 	EUR = "EUR"
 	CAD = "CAD"
 )
-
-func IsSupportedCurrency(currency string) bool {
-	switch currency {
-	case USD, EUR, CAD:
-		return true
-	}
-
-	return false
-}